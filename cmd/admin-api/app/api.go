@@ -70,6 +70,36 @@ func NewAPICmd(cli *KunkkaCli) *cobra.Command {
 	cmd.PersistentFlags().BoolVar(&opt.IsMeta, "is-meta", opt.IsMeta, "Whether it is a meta cluster")
 	cmd.PersistentFlags().BoolVar(&opt.GinLogEnabled, "enable-ginlog", opt.GinLogEnabled, "Enabled will open gin run log.")
 	cmd.PersistentFlags().BoolVar(&opt.PprofEnabled, "enable-pprof", opt.PprofEnabled, "Enabled will open endpoint for go pprof.")
+
+	cmd.PersistentFlags().StringVar(&opt.Auth.Namespace, "auth-namespace", opt.Auth.Namespace, "the namespace of the users and the jwt keys secrets")
+	cmd.PersistentFlags().StringVar(&opt.Auth.UsersSecret, "users-secret", opt.Auth.UsersSecret, "the secret of the local users with bcrypt password hashes")
+	cmd.PersistentFlags().StringVar(&opt.Auth.JWTKeysSecret, "jwt-keys-secret", opt.Auth.JWTKeysSecret, "the secret of the rotated jwt signing keys")
+	cmd.PersistentFlags().StringVar(&opt.Auth.JWTSecretFile, "jwt-secret-file", opt.Auth.JWTSecretFile, "the file of a static jwt signing key, disables the keys secret")
+	cmd.PersistentFlags().DurationVar(&opt.Auth.JWTKeyRotation, "jwt-key-rotation", opt.Auth.JWTKeyRotation, "the rotation period of the jwt signing keys, 0 disables the rotation")
+	cmd.PersistentFlags().DurationVar(&opt.Auth.AccessTokenTTL, "access-token-ttl", opt.Auth.AccessTokenTTL, "the lifetime of the access tokens")
+	cmd.PersistentFlags().DurationVar(&opt.Auth.RefreshTokenTTL, "refresh-token-ttl", opt.Auth.RefreshTokenTTL, "the lifetime of the refresh tokens")
+	cmd.PersistentFlags().DurationVar(&opt.Auth.SessionTTL, "session-ttl", opt.Auth.SessionTTL, "the lifetime of a login, the tokens are not refreshed after it")
+	cmd.PersistentFlags().StringVar(&opt.Auth.LDAP.URL, "ldap-url", opt.Auth.LDAP.URL, "the ldap:// or ldaps:// url of the ldap server, empty disables ldap")
+	cmd.PersistentFlags().BoolVar(&opt.Auth.LDAP.StartTLS, "ldap-start-tls", opt.Auth.LDAP.StartTLS, "upgrade the ldap:// connection with StartTLS")
+	cmd.PersistentFlags().BoolVar(&opt.Auth.LDAP.InsecureSkipVerify, "ldap-insecure-skip-verify", opt.Auth.LDAP.InsecureSkipVerify, "skip the tls verify of the ldap server")
+	cmd.PersistentFlags().StringVar(&opt.Auth.LDAP.BindDN, "ldap-bind-dn", opt.Auth.LDAP.BindDN, "the dn of the ldap search account")
+	cmd.PersistentFlags().StringVar(&opt.Auth.LDAPBindPasswordFile, "ldap-bind-password-file", opt.Auth.LDAPBindPasswordFile, "the file of the ldap search account password")
+	cmd.PersistentFlags().StringVar(&opt.Auth.LDAP.BaseDN, "ldap-base-dn", opt.Auth.LDAP.BaseDN, "the base dn of the ldap user search")
+	cmd.PersistentFlags().StringVar(&opt.Auth.LDAP.UserAttribute, "ldap-user-attribute", opt.Auth.LDAP.UserAttribute, "the ldap attribute of the username, defaults to uid")
+	cmd.PersistentFlags().StringVar(&opt.Auth.LDAP.ObjectClass, "ldap-object-class", opt.Auth.LDAP.ObjectClass, "the ldap object class of the users")
+	cmd.PersistentFlags().StringVar(&opt.Auth.OIDC.IssuerURL, "oidc-issuer-url", opt.Auth.OIDC.IssuerURL, "the issuer url of the oidc provider, empty disables oidc")
+	cmd.PersistentFlags().StringVar(&opt.Auth.OIDC.ClientID, "oidc-client-id", opt.Auth.OIDC.ClientID, "the oidc client id")
+	cmd.PersistentFlags().StringVar(&opt.Auth.OIDCClientSecretFile, "oidc-client-secret-file", opt.Auth.OIDCClientSecretFile, "the file of the oidc client secret")
+	cmd.PersistentFlags().StringVar(&opt.Auth.OIDC.RedirectURL, "oidc-redirect-url", opt.Auth.OIDC.RedirectURL, "the external url of /oauth/oidc/callback")
+	cmd.PersistentFlags().StringSliceVar(&opt.Auth.OIDC.Scopes, "oidc-scopes", opt.Auth.OIDC.Scopes, "the oidc scopes besides openid")
+	cmd.PersistentFlags().StringVar(&opt.Auth.OIDC.UsernameClaim, "oidc-username-claim", opt.Auth.OIDC.UsernameClaim, "the id token claim of the username")
+	cmd.PersistentFlags().StringVar(&opt.Auth.OIDC.GroupsClaim, "oidc-groups-claim", opt.Auth.OIDC.GroupsClaim, "the id token claim of the groups")
+	cmd.PersistentFlags().BoolVar(&opt.Auth.OIDC.InsecureSkipVerify, "oidc-insecure-skip-verify", opt.Auth.OIDC.InsecureSkipVerify, "skip the tls verify of the oidc provider")
+	cmd.PersistentFlags().StringSliceVar(&opt.Auth.AdminGroups, "admin-groups", opt.Auth.AdminGroups, "the groups allowed every api without a global role binding")
+	cmd.PersistentFlags().StringVar(&opt.Auth.ConsoleURL, "console-url", opt.Auth.ConsoleURL, "the console url the oidc login redirects to")
+	cmd.PersistentFlags().StringSliceVar(&opt.Auth.AllowedOrigins, "allowed-origins", opt.Auth.AllowedOrigins, "the origins allowed to open the websockets besides the api and the console url, e.g. https://console.example.com")
+	opt.CredentialKMS.AddFlags(cmd.PersistentFlags())
+	opt.SSHCredentials.AddFlags(cmd.PersistentFlags())
 	return cmd
 }
 
@@ -2,19 +2,26 @@ package apimanager
 
 import (
 	"context"
+	"io/ioutil"
+	"strings"
+	"time"
+
 	"github.com/gostship/kunkka/pkg/apimanager/healthcheck"
 	"github.com/gostship/kunkka/pkg/apimanager/router"
 	apiv1 "github.com/gostship/kunkka/pkg/apimanager/v1"
 	"github.com/gostship/kunkka/pkg/controllers/apictl"
+	"github.com/gostship/kunkka/pkg/controllers/common"
 	"github.com/gostship/kunkka/pkg/controllers/k8smanager"
 	"github.com/gostship/kunkka/pkg/gmanager"
+	"github.com/gostship/kunkka/pkg/option"
 	"github.com/gostship/kunkka/pkg/provider/monitoring/prometheus"
+	"github.com/gostship/kunkka/pkg/util/authutil"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
-	"time"
 )
 
 // Option ...
@@ -30,6 +37,41 @@ type Option struct {
 	GinLogEnabled  bool
 	GinLogSkipPath []string
 	PprofEnabled   bool
+
+	Auth AuthOption
+	// CredentialKMS opens and seals the key material of the ClusterCredentials, it must be
+	// the same as the one of the controller.
+	CredentialKMS *option.CredentialKMSOption
+	// SSHCredentials are the ssh credentials and the pinned host keys of the machines, they
+	// must be the same as the ones of the controller.
+	SSHCredentials *option.SSHCredentialsOption
+}
+
+// AuthOption is the authentication config of the api.
+type AuthOption struct {
+	// Namespace holds the users and the jwt keys secrets.
+	Namespace     string
+	UsersSecret   string
+	JWTKeysSecret string
+	// JWTSecretFile is a file of a static signing key, the keys secret is not used if set.
+	JWTSecretFile   string
+	JWTKeyRotation  time.Duration
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	// SessionTTL is the lifetime of a login, the tokens are not refreshed after it.
+	SessionTTL time.Duration
+
+	LDAP                 authutil.LDAPConfig
+	LDAPBindPasswordFile string
+
+	OIDC                 authutil.OIDCConfig
+	OIDCClientSecretFile string
+	// ConsoleURL is where the oidc login redirects to.
+	ConsoleURL string
+	// AllowedOrigins may open the websockets besides the api and the console.
+	AllowedOrigins []string
+	// AdminGroups are allowed every route without a GlobalRoleBinding.
+	AdminGroups []string
 }
 
 // APIManager ...
@@ -49,6 +91,19 @@ func DefaultOption() *Option {
 		GinLogSkipPath:     []string{"/ready", "/live"},
 		GinLogEnabled:      true,
 		PprofEnabled:       true,
+		Auth: AuthOption{
+			Namespace:       "kunkka-system",
+			UsersSecret:     "kunkka-users",
+			JWTKeysSecret:   "kunkka-jwt-keys",
+			JWTKeyRotation:  7 * 24 * time.Hour,
+			AccessTokenTTL:  authutil.DefaultAccessTokenTTL,
+			RefreshTokenTTL: authutil.DefaultRefreshTokenTTL,
+			SessionTTL:      authutil.DefaultSessionTTL,
+			ConsoleURL:      "/",
+			AdminGroups:     []string{"platform-admin"},
+		},
+		CredentialKMS:  option.DefaultCredentialKMSOption(),
+		SSHCredentials: option.DefaultSSHCredentialsOption(),
 	}
 }
 
@@ -63,9 +118,25 @@ func NewAPIManager(mgr manager.Manager, cli k8smanager.MasterClient, opt *Option
 		HealthHandler: healthHandler,
 	}
 
-	v1 := apiv1.Manager{}
-
 	klog.Info("start init kunkka api manager... ")
+	auth, err := newAuth(mgr, cli, &opt.Auth)
+	if err != nil {
+		return nil, err
+	}
+	v1 := &apiv1.Manager{Auth: auth}
+
+	credentialEnvelope, err := opt.CredentialKMS.Envelope()
+	if err != nil {
+		return nil, err
+	}
+	common.SetCredentialEnvelope(credentialEnvelope)
+
+	kubeCli, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return nil, err
+	}
+	common.SetupSSHCredentials(kubeCli, opt.SSHCredentials)
+
 	k8sMgr, err := k8smanager.NewManager(cli)
 	if err != nil {
 		klog.Fatalf("unable to new k8s manager err: %v", err)
@@ -82,6 +153,8 @@ func NewAPIManager(mgr manager.Manager, cli k8smanager.MasterClient, opt *Option
 	}
 	rt := router.NewRouter(routerOptions)
 
+	// every api except the oauth endpoints needs a access token
+	rt.UsePrefix("/apis/", v1.Authenticate)
 	rt.AddRoutes("kapi", v1.Routes())
 	apiMgr.Router = rt
 
@@ -104,6 +177,88 @@ func NewAPIManager(mgr manager.Manager, cli k8smanager.MasterClient, opt *Option
 	return apiMgr, nil
 }
 
+func newAuth(mgr manager.Manager, cli k8smanager.MasterClient, opt *AuthOption) (*apiv1.Auth, error) {
+	var keys *authutil.KeySet
+	if opt.JWTSecretFile != "" {
+		secret, err := readSecretFile(opt.JWTSecretFile)
+		if err != nil {
+			return nil, err
+		}
+		keys, err = authutil.NewStaticKeySet(secret)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// the old keys are kept for the lifetime of the refresh tokens
+		keys = authutil.NewSecretKeySet(cli.KubeCli, opt.Namespace, opt.JWTKeysSecret, opt.JWTKeyRotation, opt.RefreshTokenTTL)
+		if err := keys.Sync(context.Background()); err != nil {
+			return nil, errors.Wrap(err, "init jwt keys")
+		}
+		if err := mgr.Add(keys); err != nil {
+			return nil, err
+		}
+	}
+
+	users := authutil.NewSecretUserStore(cli.KubeCli, opt.Namespace, opt.UsersSecret)
+	chain := authutil.Chain{users}
+	if opt.LDAP.URL != "" {
+		cfg := opt.LDAP
+		if opt.LDAPBindPasswordFile != "" {
+			password, err := readSecretFile(opt.LDAPBindPasswordFile)
+			if err != nil {
+				return nil, err
+			}
+			cfg.BindPassword = password
+		}
+		ldap, err := authutil.NewLDAPAuthenticator(cfg)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, ldap)
+		klog.Infof("ldap authentication is enabled, url: %s", cfg.URL)
+	}
+
+	issuer := authutil.NewTokenIssuer(keys, opt.AccessTokenTTL, opt.RefreshTokenTTL)
+	issuer.SessionTTL = opt.SessionTTL
+	issuer.Users = chain
+
+	auth := &apiv1.Auth{
+		Authenticator:  chain,
+		Users:          users,
+		ConsoleURL:     opt.ConsoleURL,
+		AllowedOrigins: opt.AllowedOrigins,
+		Issuer:         issuer,
+		Authorizer:     authutil.NewRoleAuthorizer(mgr.GetClient(), opt.AdminGroups),
+	}
+
+	if opt.OIDC.IssuerURL != "" {
+		cfg := opt.OIDC
+		if opt.OIDCClientSecretFile != "" {
+			secret, err := readSecretFile(opt.OIDCClientSecretFile)
+			if err != nil {
+				return nil, err
+			}
+			cfg.ClientSecret = secret
+		}
+		provider, err := authutil.NewOIDCProvider(cfg)
+		if err != nil {
+			return nil, err
+		}
+		auth.OIDC = provider
+		klog.Infof("oidc login is enabled, issuer: %s", cfg.IssuerURL)
+	}
+
+	return auth, nil
+}
+
+func readSecretFile(file string) (string, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", errors.Wrapf(err, "read secret file %s", file)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
 func GetClusterLs() map[string]string {
 	return map[string]string{
 		"ClusterOwner": "kunkka-api",
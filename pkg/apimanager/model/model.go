@@ -11,6 +11,7 @@ type Rack struct {
 	ID           string           `json:"id"`
 	RackCidr     string           `json:"rackCidr"`
 	RackCidrGw   string           `json:"rackCidrGw"`
+	RackAsn      uint32           `json:"rackAsn,omitempty"` //机柜交换机的 BGP AS 号, calico 节点与其建立 BGP 连接
 	ProviderCidr string           `json:"providerCidr"`
 	ServiceRoute string           `json:"serviceRoute"`
 	RackTag      string           `json:"rackTag"`
@@ -67,11 +68,16 @@ type AddCluster struct {
 	Password       string   `json:"passWord"`
 	ClusterVersion string   `json:"clusterVersion"`
 	DockerVersion  string   `json:"dockerVersion"`
-	CustomScript   string   `json:"customScript,omitempty"`
-	CustomConfig   string   `json:"customConfig,omitempty"`
-	Description    string   `json:"description"`
-	ClusterGroup   string   `json:"clusterGroup"`
-	PodPool        []string `json:"podPool"`
+	// 容器运行时 docker 或 containerd, 默认 docker
+	ContainerRuntime string `json:"containerRuntime,omitempty"`
+	// 网络类型, calico 时 pod 地址通过机柜交换机的 BGP 路由, 默认使用 cni 插件
+	NetworkType  string   `json:"networkType,omitempty"`
+	CustomScript string   `json:"customScript,omitempty"`
+	CustomConfig string   `json:"customConfig,omitempty"`
+	Description  string   `json:"description"`
+	ClusterGroup string   `json:"clusterGroup"`
+	PodPool      []string `json:"podPool"`
+	DryRun       bool     `json:"dryRun,omitempty"` //为true时只生成创建计划, 审批后才开始创建
 }
 
 type CniOption struct {
@@ -87,11 +93,13 @@ type ClusterNode struct {
 	ClusterName   string   `json:"clusterName"`
 	CustomScript  string   `json:"customScript"`
 	DockerVersion string   `json:"dockerVersion"`
-	NodeRack      []string `json:"nodeRack"`
-	NodeVersion   string   `json:"nodeVersion"`
-	Password      string   `json:"password"`
-	PodPool       []string `json:"podPool"`
-	UserName      string   `json:"userName"`
+	// 容器运行时, 为空时使用集群的运行时
+	ContainerRuntime string   `json:"containerRuntime,omitempty"`
+	NodeRack         []string `json:"nodeRack"`
+	NodeVersion      string   `json:"nodeVersion"`
+	Password         string   `json:"password"`
+	PodPool          []string `json:"podPool"`
+	UserName         string   `json:"userName"`
 }
 
 // cluster condition
@@ -8,6 +8,7 @@ import (
 	"github.com/gostship/kunkka/pkg/apimanager/metrics"
 	"github.com/gostship/kunkka/pkg/version"
 	"net/http"
+	"strings"
 	"text/template"
 	"time"
 
@@ -50,6 +51,12 @@ type Router struct {
 	httpServer          *http.Server
 	ProfileDescriptions []*Profile
 	Opt                 *Options
+	prefixMiddlewares   []prefixMiddleware
+}
+
+type prefixMiddleware struct {
+	prefix   string
+	handlers []gin.HandlerFunc
 }
 
 // Profile ...
@@ -65,6 +72,14 @@ type Route struct {
 	Path    string
 	Handler gin.HandlerFunc
 	Desc    string
+
+	// Verb and Resource are checked by the authorization, the verb defaults to the one of the method.
+	Verb     string
+	Resource string
+	// ClusterParam is the path or query parameter of the cluster the route is scoped to.
+	ClusterParam string
+	// Middlewares run before the handler, after the prefix middlewares.
+	Middlewares []gin.HandlerFunc
 }
 
 // NewRouter creates a new Router instance
@@ -206,19 +221,36 @@ func (r *Router) AddProfile(method, href, desc string) {
 	})
 }
 
+// UsePrefix adds the middlewares to the routes with the path prefix,
+// it must be called before the routes are added.
+func (r *Router) UsePrefix(prefix string, handlers ...gin.HandlerFunc) {
+	r.prefixMiddlewares = append(r.prefixMiddlewares, prefixMiddleware{prefix: prefix, handlers: handlers})
+}
+
+func (r *Router) handlers(route *Route) []gin.HandlerFunc {
+	var handlers []gin.HandlerFunc
+	for _, m := range r.prefixMiddlewares {
+		if strings.HasPrefix(route.Path, m.prefix) {
+			handlers = append(handlers, m.handlers...)
+		}
+	}
+	handlers = append(handlers, route.Middlewares...)
+	return append(handlers, route.Handler)
+}
+
 // AddRoutes applies list of routes
 func (r *Router) AddRoutes(apiGroup string, routes []*Route) {
 	klog.V(3).Infof("load apiGroup:%s", apiGroup)
 	for _, route := range routes {
 		switch route.Method {
 		case "GET":
-			r.GET(route.Path, route.Handler)
+			r.GET(route.Path, r.handlers(route)...)
 		case "POST":
-			r.POST(route.Path, route.Handler)
+			r.POST(route.Path, r.handlers(route)...)
 		case "DELETE":
-			r.DELETE(route.Path, route.Handler)
+			r.DELETE(route.Path, r.handlers(route)...)
 		case "Any":
-			r.Any(route.Path, route.Handler)
+			r.Any(route.Path, r.handlers(route)...)
 		default:
 			klog.Warningf("no method:%s apiGroup:%s", route.Method, apiGroup)
 		}
@@ -286,8 +318,8 @@ func (r *Router) DefaultRoutes() []*Route {
 	var routes []*Route
 
 	appRoutes := []*Route{
-		{"GET", "/", r.IndexHandler, ""},
-		{"GET", VersionPath, VersionHandler, ""},
+		{Method: "GET", Path: "/", Handler: r.IndexHandler},
+		{Method: "GET", Path: VersionPath, Handler: VersionHandler},
 	}
 
 	routes = append(routes, appRoutes...)
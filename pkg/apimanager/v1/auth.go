@@ -0,0 +1,189 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/gostship/kunkka/pkg/apimanager/router"
+	"github.com/gostship/kunkka/pkg/util/authutil"
+	"k8s.io/klog"
+)
+
+const (
+	// ContextUserKey is the key of the authenticated user in the gin context
+	ContextUserKey = "kunkka.user"
+	// TokenCookieName is the cookie of the access token set by the console,
+	// the websocket requests of browsers can't carry the Authorization header.
+	// It is only accepted on the websocket upgrades, whose origin is checked.
+	TokenCookieName = "token"
+)
+
+// Auth holds the authenticators and the token issuer of the api.
+type Auth struct {
+	// Authenticator checks the username and password, usually a chain of the local users and ldap.
+	Authenticator authutil.Authenticator
+	// Users lists the local users, optional.
+	Users *authutil.SecretUserStore
+	// OIDC is the optional OpenID Connect login.
+	OIDC *authutil.OIDCProvider
+	// ConsoleURL is where the oidc callback redirects to with the tokens.
+	ConsoleURL string
+	// AllowedOrigins are the origins allowed to open the websockets besides
+	// the api itself and the console, e.g. https://console.example.com
+	AllowedOrigins []string
+	Issuer         *authutil.TokenIssuer
+	// Authorizer checks the verb and resource of every route.
+	Authorizer authutil.Authorizer
+}
+
+// Authenticate is the middleware of the api routes, it rejects the requests
+// without a valid access token.
+func (m *Manager) Authenticate(c *gin.Context) {
+	token := bearerToken(c.Request)
+	if token == "" {
+		unauthorized(c, "access token is required")
+		return
+	}
+
+	clm, err := m.Auth.Issuer.Verify(token, authutil.TokenTypeAccess)
+	if err != nil {
+		unauthorized(c, "access token is invalid or expired")
+		return
+	}
+
+	c.Set(ContextUserKey, clm.User())
+	c.Next()
+}
+
+// Authorize returns the middleware checking the route with the authorizer,
+// it runs after the Authenticate middleware.
+func (m *Manager) Authorize(route *router.Route) gin.HandlerFunc {
+	verb := route.Verb
+	if verb == "" {
+		verb = methodVerbs[route.Method]
+	}
+
+	return func(c *gin.Context) {
+		attrs := &authutil.Attributes{
+			User:     UserFromContext(c),
+			Verb:     verb,
+			Resource: route.Resource,
+		}
+		if route.ClusterParam != "" {
+			attrs.Cluster = c.Param(route.ClusterParam)
+			if attrs.Cluster == "" {
+				attrs.Cluster = c.Query(route.ClusterParam)
+			}
+		} else {
+			attrs.Cluster = c.Param("name")
+		}
+
+		if attrs.Verb == "" || attrs.Resource == "" {
+			forbidden(c, fmt.Sprintf("route %s %s has no verb or resource", route.Method, route.Path))
+			return
+		}
+
+		allowed, reason, err := m.Auth.Authorizer.Authorize(c.Request.Context(), attrs)
+		if err != nil {
+			klog.Errorf("authorize %s err: %v", attrs, err)
+			forbidden(c, "authorization failed")
+			return
+		}
+		if !allowed {
+			klog.V(4).Infof("forbidden %s %s: %s", c.Request.Method, c.Request.URL.Path, reason)
+			forbidden(c, reason)
+			return
+		}
+		c.Next()
+	}
+}
+
+var methodVerbs = map[string]string{
+	http.MethodGet:    authutil.VerbGet,
+	http.MethodPost:   authutil.VerbCreate,
+	http.MethodPut:    authutil.VerbUpdate,
+	http.MethodPatch:  authutil.VerbUpdate,
+	http.MethodDelete: authutil.VerbDelete,
+}
+
+// UserFromContext returns the user set by the Authenticate middleware.
+func UserFromContext(c *gin.Context) *authutil.User {
+	v, ok := c.Get(ContextUserKey)
+	if !ok {
+		return nil
+	}
+	u, _ := v.(*authutil.User)
+	return u
+}
+
+func bearerToken(req *http.Request) string {
+	h := req.Header.Get("Authorization")
+	if len(h) > 7 && strings.EqualFold(h[:7], "Bearer ") {
+		return strings.TrimSpace(h[7:])
+	}
+
+	// browsers attach the cookie to the cross site requests too, so it is
+	// only used by the websockets which check the origin on upgrade
+	if !websocket.IsWebSocketUpgrade(req) {
+		return ""
+	}
+	if cookie, err := req.Cookie(TokenCookieName); err == nil {
+		return cookie.Value
+	}
+	return ""
+}
+
+// upgrader returns the websocket upgrader of the api, which rejects the
+// cross site upgrades carrying the token cookie of the console.
+func (m *Manager) upgrader() *websocket.Upgrader {
+	return &websocket.Upgrader{CheckOrigin: m.checkOrigin}
+}
+
+// checkOrigin allows the upgrades with no origin (not from a browser), from
+// the api itself, from the console or from the allowed origins.
+func (m *Manager) checkOrigin(req *http.Request) bool {
+	origin := req.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	if strings.EqualFold(u.Host, req.Host) {
+		return true
+	}
+
+	allowed := m.Auth.AllowedOrigins
+	if console, err := url.Parse(m.Auth.ConsoleURL); err == nil && console.Host != "" {
+		allowed = append([]string{console.Scheme + "://" + console.Host}, allowed...)
+	}
+	for _, o := range allowed {
+		if strings.EqualFold(strings.TrimSuffix(o, "/"), u.Scheme+"://"+u.Host) {
+			return true
+		}
+	}
+	klog.Warningf("reject websocket %s from origin %s", req.URL.Path, origin)
+	return false
+}
+
+func forbidden(c *gin.Context, reason string) {
+	c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+		"success": false,
+		"message": reason,
+		"data":    nil,
+	})
+}
+
+func unauthorized(c *gin.Context, msg string) {
+	c.Header("WWW-Authenticate", `Bearer realm="kunkka"`)
+	c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+		"success": false,
+		"message": msg,
+		"data":    nil,
+	})
+}
@@ -0,0 +1,249 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/gostship/kunkka/pkg/apimanager/model/auth"
+	"github.com/gostship/kunkka/pkg/apimanager/router"
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/util/authutil"
+	"golang.org/x/crypto/bcrypt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newTestManager returns a manager of the local users alice and bob of the group dev, the
+// group dev gets the clusters of dev-cluster only.
+func newTestManager(t *testing.T) (*Manager, kubernetes.Interface) {
+	gin.SetMode(gin.TestMode)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, _ := json.Marshal(&authutil.LocalUser{PasswordHash: string(hash), Groups: []string{"dev"}})
+	kubeCli := kubefake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "kunkka-users", Namespace: "kunkka-system"},
+		Data:       map[string][]byte{"alice": user, "bob": user},
+	})
+	users := authutil.NewSecretUserStore(kubeCli, "kunkka-system", "kunkka-users")
+
+	scheme := runtime.NewScheme()
+	if err := devopsv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	cli := fake.NewFakeClientWithScheme(scheme,
+		&devopsv1.GlobalRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "viewer"},
+			Spec: devopsv1.GlobalRoleSpec{Rules: []devopsv1.PolicyRule{
+				{Verbs: []string{"get"}, Resources: []string{"clusters"}},
+			}},
+		},
+		&devopsv1.GlobalRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "dev-viewer"},
+			Spec: devopsv1.GlobalRoleBindingSpec{
+				RoleRef:  "viewer",
+				Subjects: []devopsv1.Subject{{Kind: devopsv1.SubjectKindGroup, Name: "dev"}},
+				Clusters: []string{"dev-cluster"},
+			},
+		},
+	)
+
+	keys, err := authutil.NewStaticKeySet("0123456789abcdef")
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer := authutil.NewTokenIssuer(keys, time.Hour, 2*time.Hour)
+	issuer.Users = authutil.Chain{users}
+
+	return &Manager{
+		Auth: &Auth{
+			Authenticator: authutil.Chain{users},
+			Users:         users,
+			Issuer:        issuer,
+			Authorizer:    authutil.NewRoleAuthorizer(cli, []string{"platform-admin"}),
+		},
+	}, kubeCli
+}
+
+// serve serves the request by the route behind the authentication and the authorization.
+func serve(m *Manager, route *router.Route, req *http.Request) *httptest.ResponseRecorder {
+	engine := gin.New()
+	engine.Handle(route.Method, route.Path, m.Authenticate, m.Authorize(route), func(c *gin.Context) {
+		c.String(http.StatusOK, UserFromContext(c).Name)
+	})
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	return w
+}
+
+// signToken signs the access token of alice expiring at exp with the key of the kid.
+func signToken(t *testing.T, kid string, key []byte, exp time.Time) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &authutil.Claims{
+		Username:  "alice",
+		Groups:    []string{"dev"},
+		TokenType: authutil.TokenTypeAccess,
+		StandardClaims: jwt.StandardClaims{
+			Issuer:    authutil.DefaultIssuerName,
+			IssuedAt:  exp.Add(-time.Hour).Unix(),
+			ExpiresAt: exp.Unix(),
+		},
+	})
+	token.Header["kid"] = kid
+	s, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestAuthenticate(t *testing.T) {
+	m, _ := newTestManager(t)
+	token, err := m.Auth.Issuer.IssueTo(&authutil.User{Name: "alice", Groups: []string{"dev"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	kid, key, _ := m.Auth.Issuer.Keys.SigningKey()
+	route := &router.Route{Method: "GET", Path: "/apis/cluster/getClusterDetail", Resource: "clusters", ClusterParam: "name"}
+
+	header := func(token string) func(*http.Request) {
+		return func(req *http.Request) { req.Header.Set("Authorization", "Bearer "+token) }
+	}
+	cookie := func(websocket bool) func(*http.Request) {
+		return func(req *http.Request) {
+			req.AddCookie(&http.Cookie{Name: TokenCookieName, Value: token.AccessToken})
+			if websocket {
+				req.Header.Set("Connection", "Upgrade")
+				req.Header.Set("Upgrade", "websocket")
+			}
+		}
+	}
+
+	tests := []struct {
+		name  string
+		setup func(*http.Request)
+		want  int
+	}{
+		{name: "header", setup: header(token.AccessToken), want: http.StatusOK},
+		{name: "missing token", setup: func(*http.Request) {}, want: http.StatusUnauthorized},
+		{name: "signed token", setup: header(signToken(t, kid, key, time.Now().Add(time.Hour))), want: http.StatusOK},
+		{name: "expired token", setup: header(signToken(t, kid, key, time.Now().Add(-time.Minute))), want: http.StatusUnauthorized},
+		{name: "wrong kid", setup: header(signToken(t, "rotated", key, time.Now().Add(time.Hour))), want: http.StatusUnauthorized},
+		{name: "refresh token", setup: header(token.RefreshToken), want: http.StatusUnauthorized},
+		{name: "cookie of websocket", setup: cookie(true), want: http.StatusOK},
+		{name: "cookie of plain request", setup: cookie(false), want: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/apis/cluster/getClusterDetail?name=dev-cluster", nil)
+			tt.setup(req)
+			w := serve(m, route, req)
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.want, w.Body.String())
+			}
+			if w.Code == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") == "" {
+				t.Errorf("401 without the WWW-Authenticate header")
+			}
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	m, _ := newTestManager(t)
+	token, err := m.Auth.Issuer.IssueTo(&authutil.User{Name: "alice", Groups: []string{"dev"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	admin, err := m.Auth.Issuer.IssueTo(&authutil.User{Name: "root", Groups: []string{"platform-admin"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	detail := &router.Route{Method: "GET", Path: "/apis/cluster/getClusterDetail", Resource: "clusters", ClusterParam: "name"}
+	list := &router.Route{Method: "GET", Path: "/apis/cluster/getMetaList", Resource: "clusters"}
+	tests := []struct {
+		name  string
+		route *router.Route
+		url   string
+		token string
+		want  int
+	}{
+		{name: "bound cluster", route: detail, url: "/apis/cluster/getClusterDetail?name=dev-cluster", token: token.AccessToken, want: http.StatusOK},
+		{name: "other cluster", route: detail, url: "/apis/cluster/getClusterDetail?name=prod", token: token.AccessToken, want: http.StatusForbidden},
+		{name: "global route of a cluster binding", route: list, url: "/apis/cluster/getMetaList", token: token.AccessToken, want: http.StatusForbidden},
+		{name: "verb not bound", route: &router.Route{Method: "POST", Path: "/apis/cluster/addCluster", Resource: "clusters", ClusterParam: "name"},
+			url: "/apis/cluster/addCluster?name=dev-cluster", token: token.AccessToken, want: http.StatusForbidden},
+		{name: "route without resource", route: &router.Route{Method: "GET", Path: "/apis/cluster/Test"},
+			url: "/apis/cluster/Test", token: admin.AccessToken, want: http.StatusForbidden},
+		{name: "admin group", route: list, url: "/apis/cluster/getMetaList", token: admin.AccessToken, want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.route.Method, tt.url, nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			w := serve(m, tt.route, req)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.want, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestTokenHandler(t *testing.T) {
+	m, kubeCli := newTestManager(t)
+	engine := gin.New()
+	engine.POST("/oauth/token", m.tokenHandler)
+	grant := func(form url.Values) (*httptest.ResponseRecorder, *auth.Token) {
+		req := httptest.NewRequest(http.MethodPost, "/oauth/token", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+		token := &auth.Token{}
+		_ = json.Unmarshal(w.Body.Bytes(), token)
+		return w, token
+	}
+
+	w, token := grant(url.Values{"grant_type": {"password"}, "username": {"bob"}, "password": {"secret"}})
+	if w.Code != http.StatusOK || token.AccessToken == "" || token.RefreshToken == "" {
+		t.Fatalf("password grant = %d %s", w.Code, w.Body.String())
+	}
+	if w, _ := grant(url.Values{"grant_type": {"password"}, "username": {"bob"}, "password": {"wrong"}}); w.Code != http.StatusUnauthorized {
+		t.Errorf("password grant with a wrong password = %d, want 401", w.Code)
+	}
+	if w, _ := grant(url.Values{"grant_type": {"client_credentials"}}); w.Code != http.StatusBadRequest {
+		t.Errorf("unsupported grant = %d, want 400", w.Code)
+	}
+	if w, _ := grant(url.Values{"grant_type": {"refresh_token"}, "refresh_token": {token.AccessToken}}); w.Code != http.StatusUnauthorized {
+		t.Errorf("refresh with a access token = %d, want 401", w.Code)
+	}
+	if w, refreshed := grant(url.Values{"grant_type": {"refresh_token"}, "refresh_token": {token.RefreshToken}}); w.Code != http.StatusOK || refreshed.AccessToken == "" {
+		t.Fatalf("refresh grant = %d %s", w.Code, w.Body.String())
+	}
+
+	// the deleted user can't refresh its tokens any more
+	secrets := kubeCli.CoreV1().Secrets("kunkka-system")
+	secret, err := secrets.Get(context.TODO(), "kunkka-users", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	delete(secret.Data, "bob")
+	if _, err := secrets.Update(context.TODO(), secret, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if w, _ := grant(url.Values{"grant_type": {"refresh_token"}, "refresh_token": {token.RefreshToken}}); w.Code != http.StatusUnauthorized {
+		t.Errorf("refresh of a deleted user = %d, want 401: %s", w.Code, w.Body.String())
+	}
+}
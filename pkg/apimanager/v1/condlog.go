@@ -0,0 +1,153 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/provider/condlog"
+	"github.com/gostship/kunkka/pkg/util/responseutil"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// get the remote command output of the cluster condition, or of the machine condition with
+// the machine query, the websocket request tails the output until the condition is done
+func (m *Manager) getConditionLogs(c *gin.Context) {
+	resp := responseutil.Gin{Ctx: c}
+	name := c.Param("name")
+	conditionType := c.Param("type")
+	machine := c.Query("machine")
+
+	cli := m.Cluster.GetClient()
+	ctx := context.Background()
+
+	if !websocket.IsWebSocketUpgrade(c.Request) {
+		owner := name
+		if machine != "" {
+			owner = machine
+		}
+		data, err := condlog.Load(ctx, cli, name, owner)
+		if err != nil {
+			klog.Errorf("get %s/%s logs error: %v", name, owner, err)
+			resp.RespError(err.Error())
+			return
+		}
+		logs, ok := data[conditionType]
+		if !ok {
+			resp.RespError(fmt.Sprintf("%s logs of %s are not found.", conditionType, owner))
+			return
+		}
+		resp.RespSuccess(true, "success", logs, 1)
+		return
+	}
+
+	ws, err := m.upgrader().Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		klog.Error("update websocket error", err)
+		resp.RespError("update websocket error.")
+		return
+	}
+	defer ws.Close()
+
+	// the client only closes the connection
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := ws.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	err = tailConditionLogs(ctx, cli, ws, closed, name, machine, conditionType)
+	if err != nil {
+		klog.Errorf("tail %s %s logs error: %v", name, conditionType, err)
+		ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseInternalServerErr, err.Error()))
+		return
+	}
+	ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+}
+
+// tailConditionLogs sends the logs appended every condlog.FlushPeriod, until the condition
+// is done or the client leaves.
+func tailConditionLogs(ctx context.Context, cli client.Client, ws *websocket.Conn, closed <-chan struct{},
+	name, machine, conditionType string) error {
+	owner := name
+	if machine != "" {
+		owner = machine
+	}
+
+	last := ""
+	for {
+		// the logs are saved before the condition changes, so the last ones are read
+		// after the condition is done
+		running, err := isConditionRunning(ctx, cli, name, machine, conditionType)
+		if err != nil {
+			return err
+		}
+
+		data, err := condlog.Load(ctx, cli, name, owner)
+		if err != nil {
+			return err
+		}
+		logs := data[conditionType]
+		if appended := condlog.Appended(last, logs); appended != "" {
+			ws.SetWriteDeadline(time.Now().Add(10 * time.Second))
+			if err := ws.WriteMessage(websocket.TextMessage, []byte(appended)); err != nil {
+				return err
+			}
+		}
+		last = logs
+
+		if !running {
+			return nil
+		}
+		select {
+		case <-closed:
+			return nil
+		case <-time.After(condlog.FlushPeriod):
+		}
+	}
+}
+
+// isConditionRunning means the handler of the condition has not succeeded, and it would
+// run again.
+func isConditionRunning(ctx context.Context, cli client.Client, name, machine, conditionType string) (bool, error) {
+	if machine != "" {
+		obj := &devopsv1.Machine{}
+		err := cli.Get(ctx, types.NamespacedName{Namespace: name, Name: machine}, obj)
+		if err != nil {
+			return false, err
+		}
+		if obj.Status.Phase == devopsv1.MachineFailed {
+			return false, nil
+		}
+		for _, condition := range obj.Status.Conditions {
+			if condition.Type == conditionType {
+				return condition.Status != devopsv1.ConditionTrue, nil
+			}
+		}
+		return false, nil
+	}
+
+	obj := &devopsv1.Cluster{}
+	err := cli.Get(ctx, types.NamespacedName{Namespace: name, Name: name}, obj)
+	if err != nil {
+		return false, err
+	}
+	if obj.Status.Phase == devopsv1.ClusterFailed {
+		return false, nil
+	}
+	for _, condition := range obj.Status.Conditions {
+		if condition.Type == conditionType {
+			return condition.Status != devopsv1.ConditionTrue, nil
+		}
+	}
+	return false, nil
+}
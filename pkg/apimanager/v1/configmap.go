@@ -3,19 +3,22 @@ package v1
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/ghodss/yaml"
 	"github.com/gin-gonic/gin"
 	"github.com/gostship/kunkka/pkg/apimanager/model"
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/provider/ipam"
 	"github.com/gostship/kunkka/pkg/util/cidrutil"
 	"github.com/gostship/kunkka/pkg/util/responseutil"
-	"github.com/gostship/kunkka/pkg/util/uidutil"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"strconv"
 )
 
@@ -23,7 +26,7 @@ var (
 	ConfigMapName = "kunkka-api"
 )
 
-// Add ConfigMap data
+// Add rack and its host/pod pools
 func (m *Manager) AddRackCidr(c *gin.Context) {
 	newRack := &model.Rack{}
 	resp := responseutil.Gin{Ctx: c}
@@ -31,156 +34,74 @@ func (m *Manager) AddRackCidr(c *gin.Context) {
 	// 获取创建Rack结构体
 	r, err := resp.Bind(newRack)
 	if err != nil {
-		klog.Error("Http Bind ConfigMap error %v: ", err)
-		resp.RespError("http Bind ConfigMap error")
+		klog.Errorf("http bind rack error: %v", err)
+		resp.RespError("http bind rack error")
+		return
+	}
+	rack := r.(*model.Rack)
+	if rack.RackTag == "" || rack.PodNum <= 0 {
+		resp.RespError("rackTag and podNum are required")
 		return
 	}
-
-	// 赋值UUID
-	uid := uidutil.GenerateId()
-	r.(*model.Rack).ID = uid
-
-	// generate pod or host address
-	rackNetAddr := r.(*model.Rack).RackCidr //10.28.0.0/22
-	podList, hostList := cidrutil.GenerateCidr(rackNetAddr, r.(*model.Rack).RackCidrGw, r.(*model.Rack).PodNum, r.(*model.Rack).ServiceRoute, r.(*model.Rack).RackTag)
-	r.(*model.Rack).HostAddr = hostList
-	r.(*model.Rack).PodCidr = podList
 
 	cli := m.Cluster.GetClient()
 	ctx := context.Background()
-	listMap := []*model.Rack{}
-	cm := &corev1.ConfigMap{}
 
-	// 获取configMap
-	err = cli.Get(ctx, types.NamespacedName{Namespace: ConfigMapName, Name: ConfigMapName}, cm)
+	racks, err := m.listRacks(ctx)
 	if err != nil {
-		if apierrors.IsNotFound(err) {
-			metaCm := &corev1.ConfigMap{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      ConfigMapName,
-					Namespace: ConfigMapName,
-				},
-				Data: map[string]string{"List": ""},
-			}
-
-			// 创建 comfilMap
-			err := cli.Create(ctx, metaCm)
-			if err != nil {
-				klog.Errorf("failed to create rack configMaps, %s", err)
-				resp.RespError("failed to create rack configMaps.")
-				return
-			}
-			cm = metaCm
+		klog.Errorf("failed to list racks: %v", err)
+		resp.RespError("failed to list racks.")
+		return
+	}
+	for _, item := range racks {
+		if item.RackCidr == rack.RackCidr {
+			klog.Errorf("cidr %s is already", rack.RackCidr)
+			resp.RespError(fmt.Sprintf("cidr %s is already", rack.RackCidr))
+			return
 		}
 	}
 
-	klog.Info("re create ConfigMap Name: ", ConfigMapName)
-
-	// 获取confiMap的数据
-	data, ok := cm.Data["List"]
-	if !ok {
-		klog.Info("no ConfigMap list!")
-		resp.RespError("no configMap list!")
-		return
+	obj := &devopsv1.Rack{
+		ObjectMeta: metav1.ObjectMeta{Name: rack.RackTag},
 	}
-	// 将yaml转换为json
-	yamlToRack, err := yaml.YAMLToJSON([]byte(data))
+	setRackSpec(obj, rack)
+	err = cli.Create(ctx, obj)
 	if err != nil {
-		klog.Errorf("yamlToJson error", err)
-		resp.RespError("yaml to struct error!")
+		klog.Errorf("failed to create rack %s: %v", rack.RackTag, err)
+		resp.RespError(fmt.Sprintf("failed to create rack %s.", rack.RackTag))
 		return
 	}
-	// 转换为结构体
-	err = json.Unmarshal(yamlToRack, &listMap)
+
+	err = m.createRackPools(ctx, obj)
 	if err != nil {
-		klog.Errorf("Unmarshal json err", err)
-		resp.RespError("Unmarshal list json error.")
-		return
-	}
-	for _, rack := range listMap {
-		if rack.RackCidr == r.(*model.Rack).RackCidr {
-			// cidr already
-			klog.Error("cidr %s is already:", r.(*model.Rack).RackCidr)
-			resp.RespError(fmt.Sprintf("cidr %s is already", r.(*model.Rack).RackCidr))
-			return
+		klog.Errorf("failed to create pools of rack %s: %v", rack.RackTag, err)
+		// the created pools are deleted with the rack
+		if derr := cli.Delete(ctx, obj); derr != nil {
+			klog.Errorf("failed to delete rack %s: %v", rack.RackTag, derr)
 		}
-	}
-	// 将新数据添加到Map
-	listMap = append(listMap, r.(*model.Rack))
-
-	// 反解析json字符串
-	strRackList, _ := json.MarshalIndent(listMap, "", "  ")
-
-	// 写入configMap
-	cm.Data["List"] = string(strRackList)
-
-	// 更新configMap
-	uerr := cli.Update(ctx, cm)
-	if uerr != nil {
-		klog.Errorf("failed to update rack configMap.")
-		resp.RespError("failed to update  rack configMap.")
+		resp.RespError("failed to create rack pools.")
 		return
 	}
 
 	resp.RespSuccess(true, nil, "OK", 0)
 }
 
-// Get ConfigMap data
+// Get racks
 func (m *Manager) GetRackMap(c *gin.Context) {
 	cidrName := c.DefaultQuery("rackCidr", "all")
 	page := c.Query("page")
 	limit := c.Query("limit")
 	resp := responseutil.Gin{Ctx: c}
 
-	cli := m.Cluster.GetClient()
-	ctx := context.Background()
-	cms := []model.Rack{}
-
-	cmList := &corev1.ConfigMap{}
-	err := cli.Get(ctx, types.NamespacedName{
-		Namespace: ConfigMapName,
-		Name:      ConfigMapName,
-	}, cmList)
-
-	if err != nil {
-		if apierrors.IsNotFound(err) {
-			metaCm := &corev1.ConfigMap{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      ConfigMapName,
-					Namespace: ConfigMapName,
-				},
-				Data: map[string]string{"List": ""},
-			}
-
-			// 创建 comfilMap
-			err := cli.Create(ctx, metaCm)
-			if err != nil {
-				klog.Errorf("failed to create rack configMaps, %s", err)
-				resp.RespError("failed to create rack configMaps.")
-				return
-			}
-			cmList = metaCm
-		}
-	}
-
-	data := cmList.Data["List"]
-
-	// 将yaml转换为json
-	yamlToRack, err := yaml.YAMLToJSON([]byte(data))
+	cms, err := m.listRacks(context.Background())
 	if err != nil {
-		klog.Errorf("yamlToJson error", err)
-		resp.RespError("yamlToJson error")
+		klog.Errorf("failed to list racks: %v", err)
+		resp.RespError("failed to list racks.")
 		return
 	}
 
-	rerr := json.Unmarshal(yamlToRack, &cms)
-	if rerr != nil {
-		klog.Errorf("failed to Unmarshal err: %v", rerr)
-		resp.RespError("failed to Unmarshal error.")
-		return
-	}
-	rackList := []model.Rack{}
-	resultList := []model.Rack{}
+	rackList := []*model.Rack{}
+	resultList := []*model.Rack{}
 	if cidrName == "all" {
 		rackList = cms
 	} else {
@@ -205,81 +126,46 @@ func (m *Manager) GetRackMap(c *gin.Context) {
 	resp.RespSuccess(true, nil, resultList, len(rackList))
 }
 
-//Update configMap data
+// Update rack, the cidr and pod number can't be changed once the pools are created
 func (m *Manager) UptConfigMap(c *gin.Context) {
 	newRack := &model.Rack{}
 	resp := responseutil.Gin{Ctx: c}
 	// 获取创建Rack结构体
 	r, err := resp.Bind(newRack)
 	if err != nil {
-		klog.Error("http Bind update ConfigMap error %v: ", err)
+		klog.Errorf("http bind update rack error: %v", err)
 		resp.RespError("Update httpParams error.")
 		return
 	}
+	rack := r.(*model.Rack)
 
 	cli := m.Cluster.GetClient()
 	ctx := context.Background()
-	listMap := []*model.Rack{}
-	cm := &corev1.ConfigMap{}
 
-	// 获取configMap
-	err = cli.Get(ctx, types.NamespacedName{Namespace: ConfigMapName, Name: ConfigMapName}, cm)
+	obj := &devopsv1.Rack{}
+	err = cli.Get(ctx, types.NamespacedName{Name: rack.ID}, obj)
 	if err != nil {
-		if apierrors.IsNotFound(err) {
-			klog.Error("get ConfigMap %s error %v: ", ConfigMapName, err)
-			resp.RespError("get configMap error.")
-			return
-		}
-	}
-
-	// 获取confiMap的数据
-	data, ok := cm.Data["List"]
-	if !ok {
-		klog.Info("no ConfigMap list!")
-		resp.RespError("no ConfigMap list!")
+		klog.Errorf("get rack %s error: %v", rack.ID, err)
+		resp.RespError("get rack error.")
 		return
 	}
-
-	// 将yaml转换为json
-	yamlToRack, err := yaml.YAMLToJSON([]byte(data))
-	if err != nil {
-		klog.Errorf("yamlToJson error", err)
-		resp.RespError("yamlToJson error.")
-		return
-	}
-	// 转换为结构体
-	err = json.Unmarshal(yamlToRack, &listMap)
-	if err != nil {
-		klog.Errorf("Unmarshal json err", err)
-		resp.RespError("Unmarshal json err")
+	if obj.Spec.CIDR != rack.RackCidr || obj.Spec.PodNum != rack.PodNum {
+		resp.RespError("rack cidr and podNum can't be changed.")
 		return
 	}
 
-	// 查找修改数据
-	for i := 0; i < len(listMap); i++ {
-		if listMap[i].ID == r.(*model.Rack).ID {
-			listMap[i] = r.(*model.Rack) // update
-		}
-	}
-
-	// 反解析json字符串
-	makeList, _ := json.MarshalIndent(listMap, "", "  ")
-
-	// 写入configMap
-	cm.Data["List"] = string(makeList)
-
-	// 更新configMap
-	uerr := cli.Update(ctx, cm)
-	if uerr != nil {
-		klog.Errorf("failed to update Rack configMap.")
-		resp.RespError("failed to update Rack configMap.")
+	setRackSpec(obj, rack)
+	err = cli.Update(ctx, obj)
+	if err != nil {
+		klog.Errorf("failed to update rack %s: %v", rack.ID, err)
+		resp.RespError("failed to update rack.")
 		return
 	}
 
 	resp.RespSuccess(true, nil, "OK", 0)
 }
 
-// Delete ConfigMap data
+// Delete rack, a rack with allocated addresses can't be deleted
 func (m *Manager) DelConfigMap(c *gin.Context) {
 	newRack := &model.Rack{}
 	resp := responseutil.Gin{Ctx: c}
@@ -287,66 +173,46 @@ func (m *Manager) DelConfigMap(c *gin.Context) {
 	// 获取创建Rack结构体
 	r, err := resp.Bind(newRack)
 	if err != nil {
-		klog.Error("bind delete ConfigMap error %v: ", err)
+		klog.Errorf("bind delete rack error: %v", err)
 		resp.RespError("bind delete Params error")
 		return
 	}
+	rack := r.(*model.Rack)
 
 	cli := m.Cluster.GetClient()
 	ctx := context.Background()
-	listMap := []*model.Rack{}
-	cm := &corev1.ConfigMap{}
 
-	// 获取configMap
-	err = cli.Get(ctx, types.NamespacedName{Namespace: ConfigMapName, Name: ConfigMapName}, cm)
+	obj := &devopsv1.Rack{}
+	err = cli.Get(ctx, types.NamespacedName{Name: rack.ID}, obj)
 	if err != nil {
-		if apierrors.IsNotFound(err) {
-			klog.Error("get ConfigMap %s error %v: ", ConfigMapName, err)
-			resp.RespError("get configMap error")
-			return
-		}
-	}
-
-	// 获取confiMap的数据
-	data, ok := cm.Data["List"]
-	if !ok {
-		klog.Info("no ConfigMap list!")
-		resp.RespError("no ConfigMap list!")
+		klog.Errorf("get rack %s error: %v", rack.ID, err)
+		resp.RespError("get rack error")
 		return
 	}
-	// 将yaml转换为json
-	yamlToRack, err := yaml.YAMLToJSON([]byte(data))
-	if err != nil {
-		klog.Errorf("yamlToJson error", err)
-		resp.RespError("yamlToJson error.")
+	if obj.Spec.CIDR != rack.RackCidr {
+		resp.RespError(fmt.Sprintf("rack %s cidr is not %s", rack.ID, rack.RackCidr))
 		return
 	}
-	// 转换为结构体
-	err = json.Unmarshal(yamlToRack, &listMap)
+
+	pools := &devopsv1.IPPoolList{}
+	err = cli.List(ctx, pools, client.MatchingLabels{devopsv1.LabelRack: obj.Name})
 	if err != nil {
-		klog.Errorf("Unmarshal json err", err)
-		resp.RespError("Unmarshal json err.")
+		klog.Errorf("list pools of rack %s error: %v", obj.Name, err)
+		resp.RespError("list rack pools error")
 		return
 	}
-
-	// 查找修改数据
-	for i := 0; i < len(listMap); i++ {
-		if listMap[i].ID == r.(*model.Rack).ID && listMap[i].RackCidr == r.(*model.Rack).RackCidr {
-			listMap = append(listMap[:i], listMap[i+1:]...) //delete
+	for _, pool := range pools.Items {
+		if pool.Status.Used > 0 {
+			resp.RespError(fmt.Sprintf("rack %s is in use, pool %s has %d allocated.", obj.Name, pool.Name, pool.Status.Used))
+			return
 		}
 	}
 
-	// 反解析json字符串
-	strList, _ := json.MarshalIndent(listMap, "", "  ")
-
-	// 写入configMap
-	cm.Data["List"] = string(strList)
-
-	// 更新configMap
-	uerr := cli.Update(ctx, cm)
-	if uerr != nil {
-		klog.Errorf("failed to update Rack configMap.")
-		resp.RespError("failed to update Rack configMap.")
+	// the pools are deleted with the rack
+	err = cli.Delete(ctx, obj)
+	if err != nil {
+		klog.Errorf("failed to delete rack %s: %v", obj.Name, err)
+		resp.RespError("failed to delete rack.")
 		return
 	}
 
@@ -357,176 +223,385 @@ func (m *Manager) DelConfigMap(c *gin.Context) {
 func (m *Manager) getMasterRack(c *gin.Context) {
 	resp := responseutil.Gin{Ctx: c}
 
-	cli := m.Cluster.GetClient()
-	ctx := context.Background()
-	cms := []model.Rack{}
-
-	cmList := &corev1.ConfigMap{}
-	err := cli.Get(ctx, types.NamespacedName{
-		Namespace: ConfigMapName,
-		Name:      ConfigMapName,
-	}, cmList)
-
+	cms, err := m.listRacks(context.Background())
 	if err != nil {
-		klog.Error("Get ConfigMap error %v: ", err)
+		klog.Errorf("failed to list racks: %v", err)
 		resp.RespError("can't found rackcidr, please create!")
 		return
 	}
 
-	data := cmList.Data["List"]
-
-	// 将yaml转换为json
-	yamlToRack, err := yaml.YAMLToJSON([]byte(data))
-	if err != nil {
-		klog.Errorf("yamlToJson error", err)
-		resp.RespError("yamlToJson error")
-		return
+	rackList := []*model.Rack{}
+	for _, rack := range cms {
+		if rack.IsMaster == 1 {
+			rackList = append(rackList, rack)
+		}
 	}
+	resp.RespSuccess(true, "scuccess", rackList, len(rackList))
+}
 
-	rerr := json.Unmarshal(yamlToRack, &cms)
-	if rerr != nil {
-		klog.Errorf("failed to Unmarshal err: %v", rerr)
-		resp.RespError("failed to Unmarshal error.")
-		return
+// getHostRack returns the rack of the host address for the baremetal cluster,
+// or the rack named typeName for the hosted cluster.
+func (m *Manager) getHostRack(ctx context.Context, typeName string, clstype string) (*model.Rack, error) {
+	cms, err := m.listRacks(ctx)
+	if err != nil {
+		return nil, err
 	}
-	rackList := []model.Rack{}
 
 	for _, rack := range cms {
-		if rack.IsMaster == 1 {
-			rackList = append(rackList, rack)
+		if clstype == "Baremetal" {
+			for _, hosts := range rack.HostAddr {
+				if hosts.IPADDR == typeName {
+					return rack, nil
+				}
+			}
+		} else { //全托管集群返回机柜信息
+			if typeName == rack.RackTag {
+				return rack, nil
+			}
 		}
 	}
-	resp.RespSuccess(true, "scuccess", rackList, len(rackList))
+	return nil, fmt.Errorf("can't found rack of %s", typeName)
 }
 
-func (m *Manager) getHostRack(typeName string, c *gin.Context, clstype string) *model.Rack {
-	resp := responseutil.Gin{Ctx: c}
+// listRacks returns the racks with the state of their addresses, the racks in the
+// ConfigMap of the old versions are imported at the first time.
+func (m *Manager) listRacks(ctx context.Context) ([]*model.Rack, error) {
 	cli := m.Cluster.GetClient()
-	ctx := context.Background()
-	cms := []model.Rack{}
 
-	cmList := &corev1.ConfigMap{}
-	err := cli.Get(ctx, types.NamespacedName{
-		Namespace: ConfigMapName,
-		Name:      ConfigMapName,
-	}, cmList)
+	racks := &devopsv1.RackList{}
+	err := cli.List(ctx, racks)
+	if err != nil {
+		return nil, err
+	}
+	if len(racks.Items) == 0 {
+		imported, err := m.importRacks(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "import racks")
+		}
+		racks.Items = imported
+	}
 
+	pools := &devopsv1.IPPoolList{}
+	err = cli.List(ctx, pools)
 	if err != nil {
-		if apierrors.IsNotFound(err) {
-			ns := &corev1.Namespace{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: ConfigMapName,
-				},
+		return nil, err
+	}
+	poolMap := make(map[string]*devopsv1.IPPool, len(pools.Items))
+	for i := range pools.Items {
+		poolMap[pools.Items[i].Name] = &pools.Items[i]
+	}
+
+	result := make([]*model.Rack, 0, len(racks.Items))
+	for i := range racks.Items {
+		rack, err := buildRack(&racks.Items[i], poolMap)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, rack)
+	}
+	return result, nil
+}
+
+// buildRack returns the rack model of the rack and its pools, the ids of the
+// addresses are the claim names.
+func buildRack(obj *devopsv1.Rack, pools map[string]*devopsv1.IPPool) (*model.Rack, error) {
+	rack := &model.Rack{
+		ID:           obj.Name,
+		RackCidr:     obj.Spec.CIDR,
+		RackCidrGw:   obj.Spec.Gateway,
+		RackAsn:      obj.Spec.ASNumber,
+		ProviderCidr: obj.Spec.ClusterCIDR,
+		ServiceRoute: obj.Spec.ServiceCIDR,
+		RackTag:      obj.Name,
+		PodNum:       obj.Spec.PodNum,
+		HostAddr:     []*model.HostAddr{},
+		PodCidr:      []*devopsv1.ClusterCni{},
+	}
+	if obj.Spec.Master {
+		rack.IsMaster = 1
+	}
+	metaHosts := make(map[string]bool, len(obj.Spec.MetaHosts))
+	for _, host := range obj.Spec.MetaHosts {
+		metaHosts[host] = true
+	}
+
+	if p, ok := pools[ipam.HostPoolName(obj.Name)]; ok {
+		err := forEachBlock(p, func(id string, block devopsv1.IPRange, used int) {
+			host := &model.HostAddr{
+				ID:       id,
+				IPADDR:   block.Start,
+				NetMask:  p.Spec.NetMask,
+				GateWay:  p.Spec.Gateway,
+				UseState: used,
 			}
-			err := cli.Create(ctx, ns)
-			if err != nil {
-				klog.Errorf("get namespace:%s , error: %s", ConfigMapName, err)
-				resp.RespError("get namespace error.")
+			if metaHosts[block.Start] {
+				host.IsMeta = 1
 			}
+			rack.HostAddr = append(rack.HostAddr, host)
+		})
+		if err != nil {
+			return nil, err
 		}
-
-		klog.Error("get configMap error %v: ", err)
-		resp.RespError("can't found rackcidr, please create.")
 	}
 
-	data := cmList.Data["List"]
+	if p, ok := pools[ipam.PodPoolName(obj.Name)]; ok {
+		err := forEachBlock(p, func(id string, block devopsv1.IPRange, used int) {
+			rack.PodCidr = append(rack.PodCidr, &devopsv1.ClusterCni{
+				ID:           id,
+				Subnet:       p.Spec.Subnet,
+				RangeStart:   block.Start,
+				RangeEnd:     block.End,
+				DefaultRoute: p.Spec.DefaultRoute,
+				UseState:     used,
+				RackTag:      obj.Name,
+				GW:           p.Spec.Gateway,
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return rack, nil
+}
 
-	// 将yaml转换为json
-	yamlToRack, err := yaml.YAMLToJSON([]byte(data))
+func forEachBlock(p *devopsv1.IPPool, fn func(id string, block devopsv1.IPRange, used int)) error {
+	pool, err := ipam.NewPool(p)
 	if err != nil {
-		klog.Errorf("yamlToJson error", err)
-		resp.RespError("yamlToJson error.")
+		return err
 	}
+	bitmap, err := pool.Bitmap()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < pool.Size(); i++ {
+		block, _ := pool.Block(i)
+		used := 0
+		if bitmap.Has(i) {
+			used = 1
+		}
+		fn(ipam.ClaimName(p.Name, i), block, used)
+	}
+	return nil
+}
 
-	rerr := json.Unmarshal(yamlToRack, &cms)
-	if rerr != nil {
-		klog.Errorf("failed to Unmarshal err: %v", rerr)
-		resp.RespError("failed to Unmarshal err.")
+func setRackSpec(obj *devopsv1.Rack, rack *model.Rack) {
+	obj.Spec.CIDR = rack.RackCidr
+	obj.Spec.Gateway = rack.RackCidrGw
+	obj.Spec.ASNumber = rack.RackAsn
+	obj.Spec.ClusterCIDR = rack.ProviderCidr
+	obj.Spec.ServiceCIDR = rack.ServiceRoute
+	obj.Spec.PodNum = rack.PodNum
+	obj.Spec.Master = rack.IsMaster == 1
+	obj.Spec.MetaHosts = nil
+	for _, host := range rack.HostAddr {
+		if host.IsMeta == 1 {
+			obj.Spec.MetaHosts = append(obj.Spec.MetaHosts, host.IPADDR)
+		}
 	}
+}
 
-	result := &model.Rack{}
-	for _, rack := range cms {
-		if clstype == "Baremetal" {
-			for _, hosts := range rack.HostAddr {
-				if hosts.IPADDR == typeName {
-					result = &rack
-					return result
-				}
-			}
-		} else { //全托管集群返回机柜信息
-			if typeName == rack.RackTag {
-				result = &rack
-				return result
-			}
+// createRackPools creates the host and pod pools of the rack, the pools are owned by the rack.
+func (m *Manager) createRackPools(ctx context.Context, rack *devopsv1.Rack) error {
+	cli := m.Cluster.GetClient()
+	podList, hostList := cidrutil.GenerateCidr(rack.Spec.CIDR, rack.Spec.Gateway, rack.Spec.PodNum, rack.Spec.ServiceCIDR, rack.Name)
+	if len(hostList) == 0 || len(podList) == 0 {
+		return fmt.Errorf("rack %s cidr %s has no address", rack.Name, rack.Spec.CIDR)
+	}
+
+	hostRanges := make([]devopsv1.IPRange, 0, len(hostList))
+	for _, host := range hostList {
+		hostRanges = append(hostRanges, devopsv1.IPRange{Start: host.IPADDR, End: host.IPADDR})
+	}
+	podRanges := make([]devopsv1.IPRange, 0, len(podList))
+	for _, pod := range podList {
+		podRanges = append(podRanges, devopsv1.IPRange{Start: pod.RangeStart, End: pod.RangeEnd})
+	}
+
+	pools := []*devopsv1.IPPool{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: ipam.HostPoolName(rack.Name)},
+			Spec: devopsv1.IPPoolSpec{
+				Type:      devopsv1.IPPoolHost,
+				Ranges:    ipam.MergeRanges(hostRanges),
+				BlockSize: 1,
+				NetMask:   hostList[0].NetMask,
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: ipam.PodPoolName(rack.Name)},
+			Spec: devopsv1.IPPoolSpec{
+				Type:         devopsv1.IPPoolPod,
+				Ranges:       ipam.MergeRanges(podRanges),
+				BlockSize:    rack.Spec.PodNum,
+				DefaultRoute: rack.Spec.ServiceCIDR,
+			},
+		},
+	}
+	for _, pool := range pools {
+		pool.Labels = map[string]string{devopsv1.LabelRack: rack.Name}
+		pool.Spec.Rack = rack.Name
+		pool.Spec.Subnet = rack.Spec.CIDR
+		pool.Spec.Gateway = rack.Spec.Gateway
+		if err := controllerutil.SetControllerReference(rack, pool, m.Cluster.GetScheme()); err != nil {
+			return err
+		}
+		if err := cli.Create(ctx, pool); err != nil {
+			return errors.Wrapf(err, "create ippool %s", pool.Name)
 		}
 	}
-	return result
+	return nil
 }
 
-// 更新机柜使用状态信息
-func (m *Manager) UptRackStatePhase(rack string, machines string, podID string, state int) error {
-	// 获取创建Rack结构体
+// allocateCni reserves the machine addresses and pod blocks of the options, nothing
+// is reserved if any of them is in use.
+func allocateCni(ctx context.Context, cli client.Client, cniOpts []*model.CniOption) ([]*devopsv1.IPClaim, error) {
+	claims := []*devopsv1.IPClaim{}
+	for _, opt := range cniOpts {
+		if opt.Cni == nil || opt.Machine == "" {
+			ipam.ReleaseAll(ctx, cli, claims)
+			return nil, fmt.Errorf("can't found the address or pod block of %s", opt.Machine)
+		}
+
+		claim, err := ipam.Allocate(ctx, cli, ipam.HostPoolName(opt.Cni.RackTag), opt.Machine)
+		if err != nil {
+			ipam.ReleaseAll(ctx, cli, claims)
+			return nil, err
+		}
+		claims = append(claims, claim)
+
+		pool, _, err := ipam.ParseClaimName(opt.Cni.ID)
+		if err != nil {
+			ipam.ReleaseAll(ctx, cli, claims)
+			return nil, err
+		}
+		claim, err = ipam.Allocate(ctx, cli, pool, opt.Cni.RangeStart)
+		if err != nil {
+			ipam.ReleaseAll(ctx, cli, claims)
+			return nil, err
+		}
+		claims = append(claims, claim)
+	}
+	return claims, nil
+}
+
+// importRacks imports the racks saved in the ConfigMap by the old versions, and
+// claims the addresses of the existing clusters and machines.
+func (m *Manager) importRacks(ctx context.Context) ([]devopsv1.Rack, error) {
 	cli := m.Cluster.GetClient()
-	ctx := context.Background()
-	listMap := []*model.Rack{}
-	cm := &corev1.ConfigMap{}
 
-	// 获取configMap
+	cm := &corev1.ConfigMap{}
 	err := cli.Get(ctx, types.NamespacedName{Namespace: ConfigMapName, Name: ConfigMapName}, cm)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			klog.Error("get ConfigMap %s error %v: ", ConfigMapName, err)
-			return err
+			return nil, nil
 		}
+		return nil, err
 	}
-
-	// 获取confiMap的数据
-	data, ok := cm.Data["List"]
-	if !ok {
-		klog.Info("no ConfigMap list!")
-		return errors.New("not found rack configMap Data!")
+	data := cm.Data["List"]
+	if data == "" {
+		return nil, nil
 	}
 
-	// 将yaml转换为json
+	listMap := []*model.Rack{}
 	yamlToRack, err := yaml.YAMLToJSON([]byte(data))
 	if err != nil {
-		klog.Errorf("yamlToJson error", err)
-		return err
+		return nil, err
 	}
-	// 转换为结构体
 	err = json.Unmarshal(yamlToRack, &listMap)
 	if err != nil {
-		klog.Errorf("Unmarshal json err", err)
-		return err
+		return nil, err
 	}
 
-	// 查找修改数据
-	for i := 0; i < len(listMap); i++ {
-		if listMap[i].RackTag == rack {
-			for j := 0; i < len(listMap[i].HostAddr); j++ {
-				if listMap[i].HostAddr[j].IPADDR == machines { //获取已经选择的machine节点
-					listMap[i].HostAddr[j].UseState = state // 如果为0表示释放改CIDR资源;为1则正在使用该资源
-				}
-			}
-			for k := 0; k < len(listMap[i].PodCidr); k++ {
-				if listMap[i].PodCidr[k].ID == podID {
-					listMap[i].PodCidr[k].UseState = state // 如果为0表示释放改CIDR资源;为1则正在使用该资源
-				}
-			}
+	racks := []devopsv1.Rack{}
+	for _, item := range listMap {
+		if item.RackTag == "" {
+			continue
+		}
+		obj := &devopsv1.Rack{
+			ObjectMeta: metav1.ObjectMeta{Name: item.RackTag},
+		}
+		setRackSpec(obj, item)
+		err = cli.Create(ctx, obj)
+		if apierrors.IsAlreadyExists(err) {
+			continue
 		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "create rack %s", item.RackTag)
+		}
+		err = m.createRackPools(ctx, obj)
+		if err != nil {
+			return nil, err
+		}
+		klog.Infof("imported rack %s from configMap %s/%s", obj.Name, cm.Namespace, cm.Name)
+		racks = append(racks, *obj)
+	}
+	if len(racks) == 0 {
+		return racks, nil
 	}
 
-	// 反解析json字符串
-	makeList, _ := json.MarshalIndent(listMap, "", "  ")
+	clusters := &devopsv1.ClusterList{}
+	err = cli.List(ctx, clusters)
+	if err != nil {
+		return nil, err
+	}
+	for i := range clusters.Items {
+		cls := &clusters.Items[i]
+		if cls.Spec.Type != "Baremetal" {
+			continue
+		}
+		for _, machine := range cls.Spec.Machines {
+			m.claimImported(ctx, cls, machine)
+		}
+	}
 
-	// 写入configMap
-	cm.Data["List"] = string(makeList)
+	machines := &devopsv1.MachineList{}
+	err = cli.List(ctx, machines)
+	if err != nil {
+		return nil, err
+	}
+	for i := range machines.Items {
+		m.claimImported(ctx, &machines.Items[i], machines.Items[i].Spec.Machine)
+	}
+	return racks, nil
+}
 
-	// 更新configMap
-	uerr := cli.Update(ctx, cm)
-	if uerr != nil {
-		klog.Errorf("failed to update Rack configMap.")
-		return err
+// claimImported claims the address and pod block used by the machine of the owner.
+func (m *Manager) claimImported(ctx context.Context, owner metav1.Object, machine *devopsv1.ClusterMachine) {
+	if machine == nil {
+		return
+	}
+	cli := m.Cluster.GetClient()
+
+	pools := &devopsv1.IPPoolList{}
+	if err := cli.List(ctx, pools); err != nil {
+		klog.Errorf("list ippools error: %v", err)
+		return
+	}
+
+	addresses := []string{machine.IP}
+	if machine.HostCni != nil {
+		addresses = append(addresses, machine.HostCni.RangeStart)
+	}
+	for _, address := range addresses {
+		for i := range pools.Items {
+			pool, err := ipam.NewPool(&pools.Items[i])
+			if err != nil {
+				continue
+			}
+			if _, err := pool.Offset(address); err != nil {
+				continue
+			}
+
+			claim, err := ipam.Allocate(ctx, cli, pool.Name, address)
+			if err != nil {
+				klog.Errorf("allocate %s of %s/%s error: %v", address, owner.GetNamespace(), owner.GetName(), err)
+				break
+			}
+			err = ipam.Claim(ctx, cli, m.Cluster.GetScheme(), owner, []*devopsv1.IPClaim{claim})
+			if err != nil {
+				klog.Errorf("claim %s of %s/%s error: %v", address, owner.GetNamespace(), owner.GetName(), err)
+			}
+			break
+		}
 	}
-	return nil
 }
@@ -7,6 +7,7 @@ import (
 
 type Manager struct {
 	Cluster *k8smanager.ClusterManager
+	Auth    *Auth
 	//Monitor map[string]*prometheus.Prometheus
 	sync.RWMutex
 }
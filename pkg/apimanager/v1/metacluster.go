@@ -4,10 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/ghodss/yaml"
 	"github.com/gin-gonic/gin"
 	"github.com/gostship/kunkka/pkg/apimanager/model"
 	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/provider/ipam"
 	"github.com/gostship/kunkka/pkg/util/crdutil"
 	"github.com/gostship/kunkka/pkg/util/k8sutil"
 
@@ -40,7 +42,7 @@ func (m *Manager) GetClusterVersion(c *gin.Context) {
 	}, cmList)
 
 	if err != nil {
-		klog.Error("Get ConfigMap error %v: ", err)
+		klog.Errorf("Get ConfigMap error: %v", err)
 		resp.RespError("can't found clusterVersion configMap, please create!")
 		return
 	}
@@ -50,7 +52,7 @@ func (m *Manager) GetClusterVersion(c *gin.Context) {
 	// 将yaml转换为json
 	yamlToRack, err := yaml.YAMLToJSON([]byte(data))
 	if err != nil {
-		klog.Errorf("yamlToJson error", err)
+		klog.Errorf("yamlToJson error: %v", err)
 		resp.RespError("yamlToJson error")
 		return
 	}
@@ -88,7 +90,7 @@ func (m *Manager) getClusterList(c *gin.Context) {
 		return
 	}
 	// append meta cluster
-	metaObj, err := metautil.BuildMetaObj()
+	metaObj, err := metautil.BuildMetaObj(cli)
 	if err != nil {
 		klog.Error("build meta cluster error")
 		resp.RespError("build meta cluster error!")
@@ -132,13 +134,26 @@ func (m *Manager) AddCluster(c *gin.Context) {
 		resp.RespError("add cluster faild params.")
 		return
 	}
+	ctx := context.Background()
 	if cluster.(*model.AddCluster).ClusterType == "Baremetal" {
 		for _, host := range cluster.(*model.AddCluster).ClusterIP {
-			listRack = append(listRack, m.getHostRack(host, c, cluster.(*model.AddCluster).ClusterType))
+			rack, err := m.getHostRack(ctx, host, cluster.(*model.AddCluster).ClusterType)
+			if err != nil {
+				klog.Errorf("get rack of host %s error: %v", host, err)
+				resp.RespError(err.Error())
+				return
+			}
+			listRack = append(listRack, rack)
 		}
-	} else {
-		for _, rack := range cluster.(*model.AddCluster).ClusterRack {
-			listRack = append(listRack, m.getHostRack(rack, c, cluster.(*model.AddCluster).ClusterType))
+	} else if cluster.(*model.AddCluster).ClusterType != "Include" {
+		for _, name := range cluster.(*model.AddCluster).ClusterRack {
+			rack, err := m.getHostRack(ctx, name, cluster.(*model.AddCluster).ClusterType)
+			if err != nil {
+				klog.Errorf("get rack %s error: %v", name, err)
+				resp.RespError(err.Error())
+				return
+			}
+			listRack = append(listRack, rack)
 		}
 	}
 
@@ -151,7 +166,7 @@ func (m *Manager) AddCluster(c *gin.Context) {
 	// 导入外部集群
 	if cluster.(*model.AddCluster).ClusterType == "Include" {
 		// 将配置持久化存储到meta集群
-		klog.Info("cluster %s is extend.", cluster.(*model.AddCluster).ClusterName)
+		klog.Infof("cluster %s is extend.", cluster.(*model.AddCluster).ClusterName)
 
 		// 生成CRD对象
 		err := crdutil.BuildExtendCrd(cluster.(*model.AddCluster), cli)
@@ -194,26 +209,51 @@ func (m *Manager) AddCluster(c *gin.Context) {
 				}
 			}
 			cniOptList = append(cniOptList, cniOpt)
-			//err = m.UptRackStatePhase(cniOpt.Racks, cniOpt.Machine, cniOpt.Cni.ID, 1) //更新机器/CNI状态为使用状态。
-			//klog.Info("update rack state: %s", err)
+		}
+	}
+
+	// 托管集群的master运行在meta集群节点上, 只有裸金属集群需要分配地址
+	claims := []*devopsv1.IPClaim{}
+	if cluster.(*model.AddCluster).ClusterType == "Baremetal" {
+		claims, err = allocateCni(ctx, cli, cniOptList)
+		if err != nil {
+			klog.Errorf("allocate cluster address error: %v", err)
+			resp.RespError(fmt.Sprintf("allocate cluster address error: %v", err))
+			return
 		}
 	}
 
 	cls, err := crdutil.BuildBremetalCrd(cluster.(*model.AddCluster), cniOptList)
 	if err != nil {
 		klog.Error("Build Object Bremetal err.", err)
+		ipam.ReleaseAll(ctx, cli, claims)
 		resp.RespError("Build Object Bremetal err.")
 		return
 	}
 
 	logger := ctrl.Log.WithValues("cluster", cluster.(*model.AddCluster).ClusterName)
 	logger.Info("create cluster reconcile ...")
+	var owner *devopsv1.Cluster
 	for _, obj := range cls {
 		err := k8sutil.Reconcile(logger, cli, obj, k8sutil.DesiredStatePresent)
 		if err != nil {
+			ipam.ReleaseAll(ctx, cli, claims)
 			resp.RespError("create cluster reconcile error")
 			return
 		}
+		if o, ok := obj.(*devopsv1.Cluster); ok {
+			owner = o
+		}
+	}
+
+	// 地址由集群持有, 删除集群时释放
+	if owner != nil && len(claims) > 0 {
+		err = ipam.Claim(ctx, cli, m.Cluster.GetScheme(), owner, claims)
+		if err != nil {
+			logger.Error(err, "claim cluster address error")
+			resp.RespError("claim cluster address error")
+			return
+		}
 	}
 	resp.RespSuccess(true, "success", "OK", 0)
 }
@@ -241,7 +281,7 @@ func (m *Manager) GetClusterDetail(c *gin.Context) {
 		return
 	}
 	// append meta cluster
-	metaObj, err := metautil.BuildMetaObj()
+	metaObj, err := metautil.BuildMetaObj(cli)
 	if err != nil {
 		klog.Error("build meta cluster error")
 		resp.RespError("build meta cluster error!")
@@ -2,11 +2,11 @@ package v1
 
 import (
 	"context"
-	"encoding/json"
-	"github.com/ghodss/yaml"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/gostship/kunkka/pkg/apimanager/model"
 	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/provider/ipam"
 	"github.com/gostship/kunkka/pkg/util/crdutil"
 	"github.com/gostship/kunkka/pkg/util/k8sutil"
 	"github.com/gostship/kunkka/pkg/util/metautil"
@@ -20,10 +20,12 @@ import (
 	v13 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"strings"
 )
 
@@ -33,7 +35,6 @@ func (m *Manager) addClusterNode(c *gin.Context) {
 	cli := m.Cluster.GetClient()
 	ctx := context.Background()
 	nodeParm := &model.ClusterNode{}
-	listMap := []*model.Rack{}
 
 	//cni := &devopsv1.ClusterCni{}
 
@@ -43,44 +44,16 @@ func (m *Manager) addClusterNode(c *gin.Context) {
 		resp.RespError("bind http params error")
 		return
 	}
-	cms := &corev1.ConfigMap{}
-
-	err = cli.Get(ctx, types.NamespacedName{
-		Name:      ConfigMapName,
-		Namespace: ConfigMapName,
-	}, cms)
-
-	if err != nil {
-		resp.RespError("not found rack cfg.")
-		return
-	}
-
-	// 获取confiMap的数据
-	data, ok := cms.Data["List"]
-	if !ok {
-		klog.Info("no ConfigMap list!")
-		resp.RespError("no configMap list!")
-		return
-	}
-	// 将yaml转换为json
-	yamlToRack, err := yaml.YAMLToJSON([]byte(data))
-	if err != nil {
-		klog.Errorf("yamlToJson error", err)
-		resp.RespError("yaml to struct error!")
-		return
-	}
-	// 转换为结构体
-	err = json.Unmarshal(yamlToRack, &listMap)
-	if err != nil {
-		klog.Errorf("Unmarshal json err", err)
-		resp.RespError("Unmarshal list json error.")
-		return
-	}
-
 	listRack := []*model.Rack{}
 
 	for _, host := range node.(*model.ClusterNode).AddressList {
-		listRack = append(listRack, m.getHostRack(host, c, "Baremetal"))
+		rack, err := m.getHostRack(ctx, host, "Baremetal")
+		if err != nil {
+			klog.Errorf("get rack of host %s error: %v", host, err)
+			resp.RespError(err.Error())
+			return
+		}
+		listRack = append(listRack, rack)
 	}
 
 	if len(listRack) != len(node.(*model.ClusterNode).AddressList) {
@@ -108,26 +81,69 @@ func (m *Manager) addClusterNode(c *gin.Context) {
 			}
 		}
 		cniOptList = append(cniOptList, cniOpt)
-		//err = m.UptRackStatePhase(cniOpt.Racks, cniOpt.Machine, cniOpt.Cni.ID, 1) //更新机器/CNI状态为使用状态。
-		//klog.Info("update rack state: %s", err)
+	}
+
+	claims, err := allocateCni(ctx, cli, cniOptList)
+	if err != nil {
+		klog.Errorf("allocate node address error: %v", err)
+		resp.RespError(fmt.Sprintf("allocate node address error: %v", err))
+		return
 	}
 
 	nodeObj, err := crdutil.BuildNodeCrd(node.(*model.ClusterNode), cniOptList)
 	if err != nil {
 		klog.Error("build node crd cfg error: ", err)
+		ipam.ReleaseAll(ctx, cli, claims)
 		resp.RespError("build node crd cfg error")
 		return
 	}
 
 	logger := ctrl.Log.WithValues("cluster", node.(*model.ClusterNode).ClusterName)
 	logger.Info("create node reconcile ...")
+	machines := []*devopsv1.Machine{}
 	for _, obj := range nodeObj {
 		err := k8sutil.Reconcile(logger, cli, obj, k8sutil.DesiredStatePresent)
 		if err != nil {
+			ipam.ReleaseAll(ctx, cli, claims)
 			resp.RespError("create node reconcile error")
 			return
 		}
+		if machine, ok := obj.(*devopsv1.Machine); ok {
+			machines = append(machines, machine)
+		}
+	}
+
+	// 每个节点持有自己的主机地址和pod地址段, 删除节点时释放
+	// allocateCni 为每个节点依次分配主机地址和pod地址段
+	nodeClaims := make(map[string][]*devopsv1.IPClaim, len(cniOptList))
+	for i, opt := range cniOptList {
+		if 2*i+1 < len(claims) {
+			nodeClaims[opt.Machine] = claims[2*i : 2*i+2]
+		}
+	}
+	for _, machine := range machines {
+		if machine.Spec.Machine == nil {
+			continue
+		}
+		// 节点的ssh凭据Secret随Machine删除
+		if err := ownCredentials(ctx, cli, m.Cluster.GetScheme(), machine); err != nil {
+			logger.Error(err, "set owner of node credentials error", "machine", machine.Name)
+		}
+		ip := machine.Spec.Machine.IP
+		mclaims, ok := nodeClaims[ip]
+		if !ok {
+			continue
+		}
+		delete(nodeClaims, ip)
+		err = ipam.Claim(ctx, cli, m.Cluster.GetScheme(), machine, mclaims)
+		if err != nil {
+			logger.Error(err, "claim node address error", "machine", machine.Name)
+			releaseUnclaimed(ctx, cli, mclaims, nodeClaims)
+			resp.RespError("claim node address error")
+			return
+		}
 	}
+	releaseUnclaimed(ctx, cli, nil, nodeClaims)
 	resp.RespSuccess(true, "success", "OK", 0)
 }
 
@@ -193,7 +209,7 @@ func (m *Manager) getNodePodDetail(c *gin.Context) {
 
 	cli, err := m.getClient(clsName)
 	if err != nil {
-		klog.Error("get clienet error:%s", err)
+		klog.Errorf("get clienet error:%s", err)
 		resp.RespError("get client error")
 		return
 	}
@@ -727,3 +743,35 @@ func (m *Manager) getStorageClassesDetail(c *gin.Context) {
 	}
 	resp.RespJson(cus)
 }
+
+// releaseUnclaimed frees the blocks of the claims not created, the created ones are
+// owned by their machines and released with them.
+func releaseUnclaimed(ctx context.Context, cli client.Client, claims []*devopsv1.IPClaim, rest map[string][]*devopsv1.IPClaim) {
+	untaken := []*devopsv1.IPClaim{}
+	for _, claim := range claims {
+		if claim.ResourceVersion == "" {
+			untaken = append(untaken, claim)
+		}
+	}
+	for _, claims := range rest {
+		untaken = append(untaken, claims...)
+	}
+	ipam.ReleaseAll(ctx, cli, untaken)
+}
+
+// ownCredentials sets the machine as the owner of its ssh credentials secret, so the
+// secret is deleted with the machine.
+func ownCredentials(ctx context.Context, cli client.Client, scheme *runtime.Scheme, machine *devopsv1.Machine) error {
+	ref := machine.Spec.Machine.CredentialsRef
+	if ref == nil || (ref.Namespace != "" && ref.Namespace != machine.Namespace) {
+		return nil
+	}
+	secret := &corev1.Secret{}
+	if err := cli.Get(ctx, types.NamespacedName{Namespace: machine.Namespace, Name: ref.Name}, secret); err != nil {
+		return err
+	}
+	if err := controllerutil.SetOwnerReference(machine, secret, scheme); err != nil {
+		return err
+	}
+	return cli.Update(ctx, secret)
+}
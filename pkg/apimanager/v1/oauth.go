@@ -4,15 +4,24 @@ import (
 	"encoding/base64"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/gostship/kunkka/pkg/apimanager/model/auth"
 	"github.com/gostship/kunkka/pkg/util/authutil"
 	"github.com/gostship/kunkka/pkg/util/responseutil"
+	"github.com/gostship/kunkka/pkg/util/uidutil"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/klog"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 )
 
+const (
+	oidcStateCookie = "kunkka_oidc_state"
+	oidcStateMaxAge = 10 * time.Minute
+)
+
 func (m *Manager) AuthorizeHandler(c *gin.Context) {
 	resp := responseutil.Gin{Ctx: c}
 	//clientId := c.Query("client_id")
@@ -25,50 +34,166 @@ func (m *Manager) AuthorizeHandler(c *gin.Context) {
 		return
 	}
 
-	authorization := c.GetHeader("Authorization")
+	authorization := strings.TrimPrefix(c.GetHeader("Authorization"), "Basic ")
 	decodeUser, _ := base64.StdEncoding.DecodeString(authorization)
-	userInfo := strings.Split(string(decodeUser), ":")
+	userInfo := strings.SplitN(string(decodeUser), ":", 2)
+	if len(userInfo) != 2 {
+		unauthorized(c, "username and password are required")
+		return
+	}
 
 	username := userInfo[0]
 	password := userInfo[1]
 
-	userState := authutil.Authenticate(password)
-	if !userState {
-		klog.Error("user: %s ,password error.", username)
+	user, err := m.Auth.Authenticator.Authenticate(c.Request.Context(), username, password)
+	if err != nil {
+		klog.Warningf("user: %s authenticate failed: %v", username, err)
+		unauthorized(c, authutil.ErrInvalidCredentials.Error())
 		return
 	}
 
 	redirectURL := "*"
-	Token, err := authutil.IssueTo(username)
+	Token, err := m.Auth.Issuer.IssueTo(user)
 	if err != nil {
 		klog.Error("generate access token error.")
+		resp.RespError("generate access token error.")
 		return
 	}
-	redirectURL = fmt.Sprintf("%s#access_token=%s&token_type=Bearer", redirectURL, Token.AccessToken)
+	redirectURL = fmt.Sprintf("%s#%s", redirectURL, tokenFragment(Token))
 
-	if Token.ExpiresIn > 0 {
-		redirectURL = fmt.Sprintf("%s&expires_in=%v", redirectURL, Token.ExpiresIn)
-	}
 	c.Request.Header.Set("Content-Type", "text/plain")
 	c.Redirect(http.StatusFound, redirectURL)
 }
 
+// tokenHandler is the token endpoint of the password and refresh_token grants.
+func (m *Manager) tokenHandler(c *gin.Context) {
+	var token *auth.Token
+	var err error
+	switch grantType := c.PostForm("grant_type"); grantType {
+	case "password":
+		var user *authutil.User
+		user, err = m.Auth.Authenticator.Authenticate(c.Request.Context(), c.PostForm("username"), c.PostForm("password"))
+		if err == nil {
+			token, err = m.Auth.Issuer.IssueTo(user)
+		}
+	case "refresh_token":
+		token, err = m.Auth.Issuer.Refresh(c.Request.Context(), c.PostForm("refresh_token"))
+	default:
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error":             "unsupported_grant_type",
+			"error_description": fmt.Sprintf("grant type %q is not supported", grantType),
+		})
+		return
+	}
+
+	if err != nil {
+		klog.V(4).Infof("token grant failed: %v", err)
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"error":             "invalid_grant",
+			"error_description": err.Error(),
+		})
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, token)
+}
+
+// oidcLogin redirects to the login page of the oidc provider.
+func (m *Manager) oidcLogin(c *gin.Context) {
+	if m.Auth.OIDC == nil {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"success": false, "message": "oidc is not enabled"})
+		return
+	}
+
+	resp := responseutil.Gin{Ctx: c}
+	state := uidutil.GenerateId()
+	url, err := m.Auth.OIDC.AuthCodeURL(c.Request.Context(), state)
+	if err != nil {
+		klog.Errorf("oidc login err: %v", err)
+		resp.RespError(err.Error())
+		return
+	}
+
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    state,
+		Path:     "/oauth/oidc",
+		MaxAge:   int(oidcStateMaxAge / time.Second),
+		HttpOnly: true,
+		Secure:   c.Request.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+	c.Redirect(http.StatusFound, url)
+}
+
+// oidcCallback exchanges the code and redirects to the console with the tokens.
+func (m *Manager) oidcCallback(c *gin.Context) {
+	if m.Auth.OIDC == nil {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"success": false, "message": "oidc is not enabled"})
+		return
+	}
+
+	cookie, err := c.Request.Cookie(oidcStateCookie)
+	if err != nil || cookie.Value == "" || cookie.Value != c.Query("state") {
+		unauthorized(c, "oidc state mismatch")
+		return
+	}
+	if e := c.Query("error"); e != "" {
+		unauthorized(c, fmt.Sprintf("oidc login failed: %s %s", e, c.Query("error_description")))
+		return
+	}
+
+	user, err := m.Auth.OIDC.Exchange(c.Request.Context(), c.Query("code"), cookie.Value)
+	if err != nil {
+		klog.Warningf("oidc callback err: %v", err)
+		unauthorized(c, "oidc login failed")
+		return
+	}
+
+	resp := responseutil.Gin{Ctx: c}
+	token, err := m.Auth.Issuer.IssueTo(user)
+	if err != nil {
+		resp.RespError("generate access token error.")
+		return
+	}
+
+	http.SetCookie(c.Writer, &http.Cookie{Name: oidcStateCookie, Path: "/oauth/oidc", MaxAge: -1})
+	c.Redirect(http.StatusFound, fmt.Sprintf("%s#%s", m.Auth.ConsoleURL, tokenFragment(token)))
+}
+
+func tokenFragment(token *auth.Token) string {
+	v := url.Values{}
+	v.Set("access_token", token.AccessToken)
+	v.Set("token_type", token.TokenType)
+	if token.RefreshToken != "" {
+		v.Set("refresh_token", token.RefreshToken)
+	}
+	if token.ExpiresIn > 0 {
+		v.Set("expires_in", strconv.Itoa(token.ExpiresIn))
+	}
+	return v.Encode()
+}
+
 func (m *Manager) getAuthConfig(c *gin.Context) {
 	resp := responseutil.Gin{Ctx: c}
 	res := map[string]time.Duration{
-		"accessTokenMaxAge":            6 * time.Hour,
-		"accessTokenInactivityTimeout": 6 * time.Hour,
+		"accessTokenMaxAge":            m.Auth.Issuer.AccessTokenTTL,
+		"accessTokenInactivityTimeout": m.Auth.Issuer.AccessTokenTTL,
 	}
 	resp.RespJson(res)
 }
 
 func (m *Manager) getUserDetail(c *gin.Context) {
 	resp := responseutil.Gin{Ctx: c}
-	//userName := c.Param("username")
+	user := UserFromContext(c)
+	if user == nil || user.Name != c.Param("username") {
+		resp.RespError("only the current user can be queried")
+		return
+	}
 	result := map[string]string{
-		"email":      "admin@gostship.io",
+		"email":      user.Email,
 		"lang":       "zh",
-		"username":   "admin",
+		"username":   user.Name,
 		"globalrole": "true",
 	}
 	resp.RespJson(result)
@@ -112,7 +237,22 @@ func (m *Manager) getClusterConfig(c *gin.Context) {
 func (m *Manager) getClusterUser(c *gin.Context) {
 	resp := responseutil.Gin{Ctx: c}
 
-	res, err := authutil.BuildUserMap()
+	var users []authutil.User
+	if m.Auth.Users != nil {
+		var err error
+		users, err = m.Auth.Users.List(c.Request.Context())
+		if err != nil {
+			klog.Errorf("list users err: %v", err)
+			resp.RespError("list users error.")
+			return
+		}
+	}
+	// the ldap and oidc users can't be listed, the current user is always shown
+	if current := UserFromContext(c); current != nil && !containsUser(users, current.Name) {
+		users = append(users, *current)
+	}
+
+	res, err := authutil.BuildUserMap(users)
 	if err != nil {
 		klog.Error("build user map error.")
 		resp.RespError("build user map error.")
@@ -132,3 +272,12 @@ func (m *Manager) getGlobalRole(c *gin.Context) {
 	}
 	resp.RespJson(res)
 }
+
+func containsUser(users []authutil.User, name string) bool {
+	for _, u := range users {
+		if u.Name == name {
+			return true
+		}
+	}
+	return false
+}
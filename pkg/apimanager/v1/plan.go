@@ -0,0 +1,139 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/constants"
+	"github.com/gostship/kunkka/pkg/provider/plan"
+	"github.com/gostship/kunkka/pkg/util/responseutil"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// clusterPlan is the plan of the cluster with its rendered artifacts.
+type clusterPlan struct {
+	*plan.Plan
+	Artifacts map[string]string `json:"artifacts"`
+}
+
+// get the creation plan of the cluster
+func (m *Manager) getClusterPlan(c *gin.Context) {
+	resp := responseutil.Gin{Ctx: c}
+	name := c.Param("name")
+
+	cli := m.Cluster.GetClient()
+	ctx := context.Background()
+
+	p, err := plan.Load(ctx, cli, name, name)
+	if err != nil {
+		klog.Errorf("get cluster %s plan error: %v", name, err)
+		resp.RespError(err.Error())
+		return
+	}
+	if p == nil {
+		resp.RespError("cluster plan is not found.")
+		return
+	}
+	resp.RespSuccess(true, "success", &clusterPlan{Plan: p, Artifacts: p.Artifacts}, 1)
+}
+
+// request a new creation plan, the cluster stops before creation until the plan is approved
+func (m *Manager) planCluster(c *gin.Context) {
+	resp := responseutil.Gin{Ctx: c}
+	name := c.Param("name")
+
+	cli := m.Cluster.GetClient()
+	ctx := context.Background()
+
+	cluster, err := getPlanCluster(ctx, cli, name)
+	if err != nil {
+		resp.RespError(err.Error())
+		return
+	}
+	if cluster.Status.Phase == devopsv1.ClusterRunning {
+		resp.RespError(fmt.Sprintf("cluster %s is already running.", name))
+		return
+	}
+
+	cm := &corev1.ConfigMap{}
+	cm.Namespace = name
+	cm.Name = plan.ConfigMapName(name)
+	err = cli.Delete(ctx, cm)
+	if err != nil && !apierrors.IsNotFound(err) {
+		klog.Errorf("delete cluster %s plan error: %v", name, err)
+		resp.RespError(err.Error())
+		return
+	}
+
+	if cluster.Annotations == nil {
+		cluster.Annotations = map[string]string{}
+	}
+	cluster.Annotations[constants.ClusterAnnotationDryRun] = "true"
+	err = cli.Update(ctx, cluster)
+	if err != nil {
+		klog.Errorf("update cluster %s error: %v", name, err)
+		resp.RespError(err.Error())
+		return
+	}
+	resp.RespSuccess(true, "success", "OK", 0)
+}
+
+// approve the plan, the cluster starts creation
+func (m *Manager) approveClusterPlan(c *gin.Context) {
+	resp := responseutil.Gin{Ctx: c}
+	name := c.Param("name")
+
+	cli := m.Cluster.GetClient()
+	ctx := context.Background()
+
+	cluster, err := getPlanCluster(ctx, cli, name)
+	if err != nil {
+		resp.RespError(err.Error())
+		return
+	}
+	if _, ok := cluster.Annotations[constants.ClusterAnnotationDryRun]; !ok {
+		resp.RespError(fmt.Sprintf("cluster %s has no plan to approve.", name))
+		return
+	}
+
+	p, err := plan.Load(ctx, cli, name, name)
+	if err != nil {
+		resp.RespError(err.Error())
+		return
+	}
+	// the plan must be of the current spec
+	if p == nil || p.Generation != cluster.Generation {
+		resp.RespError(fmt.Sprintf("cluster %s plan is not ready.", name))
+		return
+	}
+
+	delete(cluster.Annotations, constants.ClusterAnnotationDryRun)
+	err = cli.Update(ctx, cluster)
+	if err != nil {
+		klog.Errorf("update cluster %s error: %v", name, err)
+		resp.RespError(err.Error())
+		return
+	}
+	klog.Infof("cluster %s plan of generation %d is approved", name, p.Generation)
+	resp.RespSuccess(true, "success", "OK", 0)
+}
+
+func getPlanCluster(ctx context.Context, cli client.Client, name string) (*devopsv1.Cluster, error) {
+	cluster := &devopsv1.Cluster{}
+	err := cli.Get(ctx, types.NamespacedName{Namespace: name, Name: name}, cluster)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, errors.New("cluster is not found.")
+		}
+		klog.Errorf("get cluster %s error: %v", name, err)
+		return nil, err
+	}
+	return cluster, nil
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"github.com/gin-gonic/gin"
-	"github.com/gorilla/websocket"
 	"github.com/gostship/kunkka/pkg/apimanager/model"
 	"github.com/gostship/kunkka/pkg/util/responseutil"
 	websocket2 "github.com/gostship/kunkka/pkg/util/websocket"
@@ -15,7 +14,6 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/klog"
-	"net/http"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"strconv"
 )
@@ -25,12 +23,6 @@ const (
 	deployNameFormat = "kubectl-%s"
 )
 
-var upGrader = websocket.Upgrader{
-	CheckOrigin: func(r *http.Request) bool {
-		return true
-	},
-}
-
 func (m *Manager) getKubectlPod(c *gin.Context) {
 	resp := responseutil.Gin{Ctx: c}
 
@@ -104,7 +96,7 @@ func (m *Manager) getTerminalSession(c *gin.Context) {
 
 	handle := websocket2.NewTerminaler(cli, &cfg)
 
-	ws, err := upGrader.Upgrade(c.Writer, c.Request, nil)
+	ws, err := m.upgrader().Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
 		klog.Error("update websocker error", err)
 		resp.RespError("update websocket error.")
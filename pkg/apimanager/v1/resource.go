@@ -86,7 +86,7 @@ func (m *Manager) getClusterNameSpacePods(c *gin.Context) {
 	ctx := context.Background()
 	cli, err := m.getClient(clsName)
 	if err != nil {
-		klog.Error("get clienet error:%s", err)
+		klog.Errorf("get clienet error:%s", err)
 		resp.RespError("get client error")
 		return
 	}
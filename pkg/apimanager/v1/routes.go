@@ -1,6 +1,10 @@
 package v1
 
-import "github.com/gostship/kunkka/pkg/apimanager/router"
+import (
+	"strings"
+
+	"github.com/gostship/kunkka/pkg/apimanager/router"
+)
 
 // Routes ...
 func (m *Manager) Routes() []*router.Route {
@@ -12,354 +16,478 @@ func (m *Manager) Routes() []*router.Route {
 			Handler: m.AuthorizeHandler,
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/users",
-			Handler: m.getClusterUser,
+			Method:  "POST",
+			Path:    "/oauth/token",
+			Handler: m.tokenHandler,
 		},
 		{
 			Method:  "GET",
-			Path:    "/apis/cluster/globalroles",
-			Handler: m.getGlobalRole,
+			Path:    "/oauth/oidc/login",
+			Handler: m.oidcLogin,
 		},
 		{
 			Method:  "GET",
-			Path:    "/apis/cluster/configs/oauth",
-			Handler: m.getAuthConfig,
+			Path:    "/oauth/oidc/callback",
+			Handler: m.oidcCallback,
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/users/:username",
-			Handler: m.getUserDetail,
+			Method:   "GET",
+			Path:     "/apis/cluster/users",
+			Handler:  m.getClusterUser,
+			Resource: "users",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/workspaces",
-			Handler: m.getWorkSpace,
+			Method:   "GET",
+			Path:     "/apis/cluster/globalroles",
+			Handler:  m.getGlobalRole,
+			Resource: "profile",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/configs/configz",
-			Handler: m.getClusterConfig,
+			Method:   "GET",
+			Path:     "/apis/cluster/configs/oauth",
+			Handler:  m.getAuthConfig,
+			Resource: "profile",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/getRackCidr",
-			Handler: m.GetRackMap,
+			Method:   "GET",
+			Path:     "/apis/cluster/users/:username",
+			Handler:  m.getUserDetail,
+			Resource: "profile",
 		},
 		{
-			Method:  "POST",
-			Path:    "/apis/cluster/addRackCidr",
-			Handler: m.AddRackCidr,
+			Method:   "GET",
+			Path:     "/apis/cluster/workspaces",
+			Handler:  m.getWorkSpace,
+			Resource: "profile",
 		},
 		{
-			Method:  "POST",
-			Path:    "/apis/cluster/updateRackCidr",
-			Handler: m.UptConfigMap,
+			Method:   "GET",
+			Path:     "/apis/cluster/configs/configz",
+			Handler:  m.getClusterConfig,
+			Resource: "profile",
 		},
 		{
-			Method:  "DELETE",
-			Path:    "/apis/cluster/delRackCidr",
-			Handler: m.DelConfigMap,
+			Method:   "GET",
+			Path:     "/apis/cluster/getRackCidr",
+			Handler:  m.GetRackMap,
+			Resource: "racks",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/getPodCidr",
-			Handler: m.GetPodCidr,
+			Method:   "POST",
+			Path:     "/apis/cluster/addRackCidr",
+			Handler:  m.AddRackCidr,
+			Resource: "racks",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/getClusterVersion",
-			Handler: m.GetClusterVersion,
+			Method:   "POST",
+			Path:     "/apis/cluster/updateRackCidr",
+			Handler:  m.UptConfigMap,
+			Verb:     "update",
+			Resource: "racks",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/getMetaList",
-			Handler: m.getClusterList,
+			Method:   "DELETE",
+			Path:     "/apis/cluster/delRackCidr",
+			Handler:  m.DelConfigMap,
+			Resource: "racks",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/getMemberList",
-			Handler: m.getClusterList,
+			Method:   "GET",
+			Path:     "/apis/cluster/getPodCidr",
+			Handler:  m.GetPodCidr,
+			Resource: "racks",
 		},
 		{
-			Method:  "POST",
-			Path:    "/apis/cluster/addCluster",
-			Handler: m.AddCluster,
+			Method:   "GET",
+			Path:     "/apis/cluster/getClusterVersion",
+			Handler:  m.GetClusterVersion,
+			Resource: "clusters",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/getClusterDetail",
-			Handler: m.GetClusterDetail,
+			Method:   "GET",
+			Path:     "/apis/cluster/getMetaList",
+			Handler:  m.getClusterList,
+			Resource: "clusters",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/getMasterRack",
-			Handler: m.getMasterRack,
+			Method:   "GET",
+			Path:     "/apis/cluster/getMemberList",
+			Handler:  m.getClusterList,
+			Resource: "clusters",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/getClusterCondition",
-			Handler: m.GetClusterCondition,
+			Method:   "POST",
+			Path:     "/apis/cluster/addCluster",
+			Handler:  m.AddCluster,
+			Resource: "clusters",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/getNodeCondition",
-			Handler: m.getNodeCondition,
+			Method:       "GET",
+			Path:         "/apis/cluster/getClusterDetail",
+			Handler:      m.GetClusterDetail,
+			Resource:     "clusters",
+			ClusterParam: "name",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/getMemberMeta",
-			Handler: m.GetMemberMetaData,
+			Method:   "GET",
+			Path:     "/apis/cluster/getMasterRack",
+			Handler:  m.getMasterRack,
+			Resource: "racks",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/getClusterCounts",
-			Handler: m.GetClusterCounts,
+			Method:       "GET",
+			Path:         "/apis/cluster/getClusterCondition",
+			Handler:      m.GetClusterCondition,
+			Resource:     "clusters",
+			ClusterParam: "clusterName",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/getClusterRole",
-			Handler: m.GetClusterRole,
+			Method:       "GET",
+			Path:         "/apis/cluster/getNodeCondition",
+			Handler:      m.getNodeCondition,
+			Resource:     "nodes",
+			ClusterParam: "clusterName",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/getNodeCount",
-			Handler: m.GetNodeCount,
+			Method:       "GET",
+			Path:         "/apis/cluster/getMemberMeta",
+			Handler:      m.GetMemberMetaData,
+			Resource:     "clusters",
+			ClusterParam: "clusterName",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/Test",
-			Handler: m.TestGet,
+			Method:       "GET",
+			Path:         "/apis/cluster/getClusterCounts",
+			Handler:      m.GetClusterCounts,
+			Resource:     "clusters",
+			ClusterParam: "clusterName",
 		},
 		{
-			Method:  "POST",
-			Path:    "/apis/cluster/addClusterNode",
-			Handler: m.addClusterNode,
+			Method:   "GET",
+			Path:     "/apis/cluster/getClusterRole",
+			Handler:  m.GetClusterRole,
+			Resource: "profile",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/getNoreadyNode",
-			Handler: m.getNoreadyNode,
+			Method:       "GET",
+			Path:         "/apis/cluster/getNodeCount",
+			Handler:      m.GetNodeCount,
+			Resource:     "nodes",
+			ClusterParam: "clusterName",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/Monitoring/:name/nodes",
-			Handler: m.getNodeMonitor,
+			Method:       "GET",
+			Path:         "/apis/cluster/Test",
+			Handler:      m.TestGet,
+			Resource:     "clusters",
+			ClusterParam: "clusterName",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/Monitoring/:name/cluster",
-			Handler: m.getClusterMonitor,
+			Method:   "POST",
+			Path:     "/apis/cluster/addClusterNode",
+			Handler:  m.addClusterNode,
+			Resource: "nodes",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/Monitoring/:name/namespaces/:namespace",
-			Handler: m.getClusterNsMonitor,
+			Method:       "GET",
+			Path:         "/apis/cluster/getNoreadyNode",
+			Handler:      m.getNoreadyNode,
+			Resource:     "nodes",
+			ClusterParam: "clusterName",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/Monitoring/:name/namespaces/:namespace/pods",
-			Handler: m.getClusterNsPodsMonitor,
+			Method:   "GET",
+			Path:     "/apis/cluster/Monitoring/:name/nodes",
+			Handler:  m.getNodeMonitor,
+			Resource: "monitoring",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/Monitoring/:name/namespaces/:namespace/workloads/:kind/:workload/pods",
-			Handler: m.getClusterNsPodsMonitor,
+			Method:   "GET",
+			Path:     "/apis/cluster/Monitoring/:name/cluster",
+			Handler:  m.getClusterMonitor,
+			Resource: "monitoring",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/Monitoring/:name/namespaces/:namespace/pods/:pod",
-			Handler: m.getClusterNsPodsMonitor,
+			Method:   "GET",
+			Path:     "/apis/cluster/Monitoring/:name/namespaces/:namespace",
+			Handler:  m.getClusterNsMonitor,
+			Resource: "monitoring",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/Monitoring/:name/namespaces",
-			Handler: m.getClusterNsMonitor,
+			Method:   "GET",
+			Path:     "/apis/cluster/Monitoring/:name/namespaces/:namespace/pods",
+			Handler:  m.getClusterNsPodsMonitor,
+			Resource: "monitoring",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/Monitoring/:name/components/:component",
-			Handler: m.getApiserverMonitor,
+			Method:   "GET",
+			Path:     "/apis/cluster/Monitoring/:name/namespaces/:namespace/workloads/:kind/:workload/pods",
+			Handler:  m.getClusterNsPodsMonitor,
+			Resource: "monitoring",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/nodes/:node",
-			Handler: m.getNodeDetail,
+			Method:   "GET",
+			Path:     "/apis/cluster/Monitoring/:name/namespaces/:namespace/pods/:pod",
+			Handler:  m.getClusterNsPodsMonitor,
+			Resource: "monitoring",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/components",
-			Handler: m.getClusterComponents,
+			Method:   "GET",
+			Path:     "/apis/cluster/Monitoring/:name/namespaces",
+			Handler:  m.getClusterNsMonitor,
+			Resource: "monitoring",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/components/:component",
-			Handler: m.getComponentsDetail,
+			Method:   "GET",
+			Path:     "/apis/cluster/Monitoring/:name/components/:component",
+			Handler:  m.getApiserverMonitor,
+			Resource: "monitoring",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/namespaces/:namespace/services/:service",
-			Handler: m.getServiceDetail,
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/nodes/:node",
+			Handler:  m.getNodeDetail,
+			Resource: "nodes",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/Monitoring/:name/nodes/:node/pods",
-			Handler: m.getNodePods,
+			Method:       "GET",
+			Path:         "/apis/cluster/klusters/:name/plan",
+			Handler:      m.getClusterPlan,
+			Resource:     "clusters",
+			ClusterParam: "name",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/pods",
-			Handler: m.getNodePodDetail,
+			Method:       "POST",
+			Path:         "/apis/cluster/klusters/:name/plan",
+			Handler:      m.planCluster,
+			Verb:         "update",
+			Resource:     "clusters",
+			ClusterParam: "name",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/services",
-			Handler: m.getServiceList,
+			Method:       "POST",
+			Path:         "/apis/cluster/klusters/:name/plan/approve",
+			Handler:      m.approveClusterPlan,
+			Verb:         "update",
+			Resource:     "clusters",
+			ClusterParam: "name",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/ingresses",
-			Handler: m.getIngressesDetail,
+			Method:       "GET",
+			Path:         "/apis/cluster/klusters/:name/conditions/:type/logs",
+			Handler:      m.getConditionLogs,
+			Resource:     "clusters",
+			ClusterParam: "name",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/secrets",
-			Handler: m.getSecretsDetail,
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/components",
+			Handler:  m.getClusterComponents,
+			Resource: "components",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/configmaps",
-			Handler: m.getConfigmapsDetail,
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/components/:component",
+			Handler:  m.getComponentsDetail,
+			Resource: "components",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/persistentvolumeclaims",
-			Handler: m.getPvcDetail,
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/namespaces/:namespace/services/:service",
+			Handler:  m.getServiceDetail,
+			Resource: "services",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/storageclasses",
-			Handler: m.getStorageClassesDetail,
+			Method:   "GET",
+			Path:     "/apis/cluster/Monitoring/:name/nodes/:node/pods",
+			Handler:  m.getNodePods,
+			Resource: "monitoring",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/deployments",
-			Handler: m.getDeploymentList,
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/pods",
+			Handler:  m.getNodePodDetail,
+			Resource: "pods",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/namespaces/:namespace/events",
-			Handler: m.getNsPodEvents,
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/services",
+			Handler:  m.getServiceList,
+			Resource: "services",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/resource/klusters/:name/namespaces",
-			Handler: m.getClusterAllNameSpace,
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/ingresses",
+			Handler:  m.getIngressesDetail,
+			Resource: "ingresses",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/namespaces/:namespace",
-			Handler: m.getClusterNameSpace,
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/secrets",
+			Handler:  m.getSecretsDetail,
+			Resource: "secrets",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/namespaces/:namespace/pods",
-			Handler: m.getClusterNameSpacePods,
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/configmaps",
+			Handler:  m.getConfigmapsDetail,
+			Resource: "configmaps",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/namespaces/:namespace/statefulsets/:workload",
-			Handler: m.getStatefulsetsWorkLoad,
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/persistentvolumeclaims",
+			Handler:  m.getPvcDetail,
+			Resource: "persistentvolumeclaims",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/namespaces/:namespace/deployments/:workload",
-			Handler: m.getDeploymentDetail,
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/storageclasses",
+			Handler:  m.getStorageClassesDetail,
+			Resource: "storageclasses",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/namespaces/:namespace/daemonsets/:workload",
-			Handler: m.getDaemonsetsDetail,
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/deployments",
+			Handler:  m.getDeploymentList,
+			Resource: "deployments",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/statefulsets",
-			Handler: m.getStatefulsetsDetail,
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/namespaces/:namespace/events",
+			Handler:  m.getNsPodEvents,
+			Resource: "events",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/daemonsets",
-			Handler: m.getDaemonsetsList,
+			Method:   "GET",
+			Path:     "/apis/cluster/resource/klusters/:name/namespaces",
+			Handler:  m.getClusterAllNameSpace,
+			Resource: "namespaces",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/jobs",
-			Handler: m.getJobsDetail,
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/namespaces/:namespace",
+			Handler:  m.getClusterNameSpace,
+			Resource: "namespaces",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/cronjobs",
-			Handler: m.getCronJobDetail,
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/namespaces/:namespace/pods",
+			Handler:  m.getClusterNameSpacePods,
+			Resource: "pods",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/events",
-			Handler: m.getNodeEvents,
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/namespaces/:namespace/statefulsets/:workload",
+			Handler:  m.getStatefulsetsWorkLoad,
+			Resource: "statefulsets",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/componenthealth",
-			Handler: m.getComponentHealth,
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/namespaces/:namespace/deployments/:workload",
+			Handler:  m.getDeploymentDetail,
+			Resource: "deployments",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/users/:user/kubectl",
-			Handler: m.getKubectlPod,
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/namespaces/:namespace/daemonsets/:workload",
+			Handler:  m.getDaemonsetsDetail,
+			Resource: "daemonsets",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/clusters/:name/namespaces/:namespace/pods/:pod",
-			Handler: m.getTerminalSession,
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/statefulsets",
+			Handler:  m.getStatefulsetsDetail,
+			Resource: "statefulsets",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/users/:user/kubeconfig",
-			Handler: m.getKubeConfig,
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/daemonsets",
+			Handler:  m.getDaemonsetsList,
+			Resource: "daemonsets",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/namespaces/:namespace/pods/:pod",
-			Handler: m.getPodDetail,
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/jobs",
+			Handler:  m.getJobsDetail,
+			Resource: "jobs",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/namespaces/:namespace/pods/:pod/log",
-			Handler: m.getPodLogs,
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/cronjobs",
+			Handler:  m.getCronJobDetail,
+			Resource: "cronjobs",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/namespaces/:namespace/replicasets",
-			Handler: m.getDeploymentReplicaset,
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/events",
+			Handler:  m.getNodeEvents,
+			Resource: "events",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/namespaces/:namespace/controllerrevisions",
-			Handler: m.getControllerRevisions,
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/componenthealth",
+			Handler:  m.getComponentHealth,
+			Resource: "components",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/namespaces/:namespace/endpoints/:service",
-			Handler: m.getServicePods,
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/users/:user/kubectl",
+			Handler:  m.getKubectlPod,
+			Resource: "pods/exec",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/namespaces/:namespace/deployments",
-			Handler: m.getServiceDep,
+			Method:   "GET",
+			Path:     "/apis/clusters/:name/namespaces/:namespace/pods/:pod",
+			Handler:  m.getTerminalSession,
+			Resource: "pods/exec",
 		},
 		{
-			Method:  "GET",
-			Path:    "/apis/cluster/klusters/:name/namespaces/:namespace/statefulsets",
-			Handler: m.getServiceDae,
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/users/:user/kubeconfig",
+			Handler:  m.getKubeConfig,
+			Resource: "kubeconfig",
+		},
+		{
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/namespaces/:namespace/pods/:pod",
+			Handler:  m.getPodDetail,
+			Resource: "pods",
+		},
+		{
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/namespaces/:namespace/pods/:pod/log",
+			Handler:  m.getPodLogs,
+			Resource: "pods/log",
+		},
+		{
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/namespaces/:namespace/replicasets",
+			Handler:  m.getDeploymentReplicaset,
+			Resource: "replicasets",
+		},
+		{
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/namespaces/:namespace/controllerrevisions",
+			Handler:  m.getControllerRevisions,
+			Resource: "controllerrevisions",
+		},
+		{
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/namespaces/:namespace/endpoints/:service",
+			Handler:  m.getServicePods,
+			Resource: "endpoints",
+		},
+		{
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/namespaces/:namespace/deployments",
+			Handler:  m.getServiceDep,
+			Resource: "deployments",
+		},
+		{
+			Method:   "GET",
+			Path:     "/apis/cluster/klusters/:name/namespaces/:namespace/statefulsets",
+			Handler:  m.getServiceDae,
+			Resource: "statefulsets",
 		},
 		//
 		//{
@@ -456,5 +584,10 @@ func (m *Manager) Routes() []*router.Route {
 	}
 
 	routes = append(routes, apiRoutes...)
+	for _, r := range routes {
+		if strings.HasPrefix(r.Path, "/apis/") {
+			r.Middlewares = append(r.Middlewares, m.Authorize(r))
+		}
+	}
 	return routes
 }
@@ -0,0 +1,89 @@
+/*
+Copyright 2020 dke.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ArtifactSource is where the nodes of an architecture download the file.
+type ArtifactSource struct {
+	// Arch is the architecture of the nodes, e.g. amd64 or arm64.
+	Arch string `json:"arch"`
+	// URL is a http(s) url, or a path relative to the artifact dir of the operator,
+	// which is served by the operator.
+	URL string `json:"url"`
+	// SHA256 is the hex sha256 sum of the file.
+	SHA256 string `json:"sha256"`
+}
+
+// ArtifactFile is a file installed on the nodes.
+type ArtifactFile struct {
+	// Name of the file, e.g. kubelet.
+	Name string `json:"name"`
+	// Dst is the path of the file on the nodes.
+	Dst string `json:"dst"`
+	// Mode is the octal file mode, e.g. 0755 for the binaries.
+	// +optional
+	Mode string `json:"mode,omitempty"`
+	// ExtractDir means the file is a tgz extracted into the dir, e.g. the cni plugins.
+	// +optional
+	ExtractDir string `json:"extractDir,omitempty"`
+	// ContainerRuntime means the file is only installed on the nodes of the runtime.
+	// +optional
+	ContainerRuntime ContainerRuntimeType `json:"containerRuntime,omitempty"`
+	Sources          []ArtifactSource     `json:"sources"`
+}
+
+// KubernetesArtifactSpec is the release manifest of a kubernetes version.
+type KubernetesArtifactSpec struct {
+	// Version is the kubernetes version of the clusters using the artifact, e.g. 1.18.6.
+	Version string         `json:"version"`
+	Files   []ArtifactFile `json:"files"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// +kubebuilder:object:root=true
+
+// KubernetesArtifact is the Schema for the KubernetesArtifact API, the nodes of the
+// clusters of the version download the files and verify their sha256 sums.
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:scope=Cluster,shortName=ka
+// +kubebuilder:printcolumn:name="VERSION",type="string",JSONPath=".spec.version",description="The kubernetes version."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp",description="CreationTimestamp is a timestamp representing the server time when this object was created. "
+type KubernetesArtifact struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec KubernetesArtifactSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// KubernetesArtifactList contains a list of KubernetesArtifact
+type KubernetesArtifactList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KubernetesArtifact `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KubernetesArtifact{}, &KubernetesArtifactList{})
+}
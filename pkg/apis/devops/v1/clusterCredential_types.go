@@ -2,6 +2,10 @@ package v1
 
 import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+// CredentialInfo is the certificates and the keys of a cluster. The key material, the private
+// keys, the tokens, ExtData, KubeData and CertsBinaryData, is kept in the secret
+// <name>-credential owned by the ClusterCredential, sealed by the kms provider of the
+// controller, the fields are only set in memory. The keys of CARotation are kept the same way.
 type CredentialInfo struct {
 	TenantID    string `json:"tenantID"`
 	ClusterName string `json:"clusterName"`
@@ -41,6 +45,36 @@ type CredentialInfo struct {
 	KubeData        map[string]string `json:"kubeData,omitempty"`
 	ManifestsData   map[string]string `json:"manifestsData,omitempty"`
 	CertsBinaryData map[string][]byte `json:"certsBinaryData,omitempty"`
+
+	// CARotation is the progress of the rotation of the certificate authorities, it is nil
+	// once no rotation runs.
+	// +optional
+	CARotation *CARotation `json:"caRotation,omitempty"`
+}
+
+// CARotationPhase is the stage of the rotation of the certificate authorities which the
+// credential is prepared for.
+type CARotationPhase string
+
+const (
+	// CARotationTrustBoth trusts the old and the new certificate authorities, the
+	// certificates are still issued by the old ones.
+	CARotationTrustBoth CARotationPhase = "TrustBoth"
+	// CARotationReissue trusts both the certificate authorities, the certificates are issued
+	// by the new ones.
+	CARotationReissue CARotationPhase = "Reissue"
+	// CARotationDropOld trusts the new certificate authorities only.
+	CARotationDropOld CARotationPhase = "DropOld"
+)
+
+// CARotation is the progress of the rotation of the certificate authorities, each stage is
+// rolled out to the whole cluster before the credential is prepared for the next one.
+type CARotation struct {
+	Phase CARotationPhase `json:"phase"`
+	// Keys are the private keys of the new certificate authorities by path until they issue
+	// the certificates, they are kept in the credential secret as the other key material.
+	// +optional
+	Keys map[string][]byte `json:"keys,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -36,6 +36,23 @@ const (
 // NetworkType defines the network type of cluster.
 type NetworkType string
 
+const (
+	// NetworkTypeCalico installs calico, the pod addresses are routed by the BGP
+	// peering of the nodes and the top of rack switches.
+	NetworkTypeCalico NetworkType = "calico"
+)
+
+// ContainerRuntimeType defines the container runtime of the nodes.
+// +kubebuilder:validation:Enum=docker;containerd
+type ContainerRuntimeType string
+
+const (
+	// ContainerRuntimeDocker runs the containers with docker by the dockershim of kubelet.
+	ContainerRuntimeDocker ContainerRuntimeType = "docker"
+	// ContainerRuntimeContainerd runs the containers with containerd by its cri plugin.
+	ContainerRuntimeContainerd ContainerRuntimeType = "containerd"
+)
+
 // ResourceList is a set of (resource name, quantity) pairs.
 type ResourceList map[string]resource.Quantity
 
@@ -89,6 +106,8 @@ const (
 	ClusterTerminating ClusterPhase = "Terminating"
 	// ClusterNotSupport is the not support phase.
 	ClusterNotSupport ClusterPhase = "NotSupport"
+	// ClusterUpgrading means the cluster is upgrading to the spec version.
+	ClusterUpgrading ClusterPhase = "Upgrading"
 )
 
 // ClusterCondition contains details for the current condition of this cluster.
@@ -110,6 +129,9 @@ type ClusterCondition struct {
 	// Human-readable message indicating details about last transition.
 	// +optional
 	Message string `json:"message,omitempty"`
+	// Retries is the count of the failed runs of the handler since it last succeeded.
+	// +optional
+	Retries int32 `json:"retries,omitempty"`
 }
 
 type HookType string
@@ -167,8 +189,19 @@ type HA struct {
 	ThirdPartyHA *ThirdPartyHA `json:"thirdParty,omitempty"`
 }
 
+// DKEHA is the vip of the masters held by keepalived, the apiservers are load balanced by
+// haproxy on every master.
 type DKEHA struct {
 	VIP string `json:"vip"`
+	// VPort is the port of haproxy, 8443 by default as the apiservers listen on 6443.
+	// +optional
+	VPort int32 `json:"vport,omitempty"`
+	// VRID is the virtual router id of keepalived, it must be unique in the network of the
+	// masters. It is the last byte of the vip by default.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=255
+	VRID int32 `json:"vrid,omitempty"`
 }
 
 type ThirdPartyHA struct {
@@ -199,6 +232,59 @@ type ClusterFeature struct {
 	Files []File `json:"files,omitempty"`
 	// +optional
 	Hooks map[HookType]string `json:"hooks,omitempty"`
+	// Parallelism is the max count of machines a handler runs on at the same time,
+	// 1 runs them one by one. Defaults to 10.
+	// +optional
+	Parallelism int32 `json:"parallelism,omitempty"`
+	// LoadBalancer is the metallb config of the services of type LoadBalancer, metallb is
+	// installed if it is set and InternalLB or PublicLB is enabled.
+	// +optional
+	LoadBalancer *LoadBalancer `json:"loadBalancer,omitempty"`
+}
+
+// LoadBalancerMode is how metallb announces the addresses of the services.
+// +kubebuilder:validation:Enum=layer2;bgp
+type LoadBalancerMode string
+
+const (
+	// LoadBalancerLayer2 answers the arp requests of the addresses from a node.
+	LoadBalancerLayer2 LoadBalancerMode = "layer2"
+	// LoadBalancerBGP announces the addresses to the bgp peers from all the nodes.
+	LoadBalancerBGP LoadBalancerMode = "bgp"
+)
+
+// LoadBalancer is the address pools and the announcement of the LoadBalancer services.
+type LoadBalancer struct {
+	// Mode defaults to layer2.
+	// +optional
+	Mode LoadBalancerMode `json:"mode,omitempty"`
+	// Addresses are the ranges of the internal pool, e.g. 10.28.0.200-10.28.0.220 or
+	// 10.28.1.0/28.
+	// +optional
+	Addresses []string `json:"addresses,omitempty"`
+	// Rack allocates the internal pool from the host pool of the rack if Addresses is
+	// empty, the addresses are claimed by the cluster.
+	// +optional
+	Rack string `json:"rack,omitempty"`
+	// Count is the count of the addresses allocated from the rack, defaults to 8.
+	// +optional
+	Count int `json:"count,omitempty"`
+	// PublicAddresses are the ranges of the public pool used if PublicLB is enabled, the
+	// services get them with the metallb.universe.tf/address-pool: public annotation.
+	// +optional
+	PublicAddresses []string `json:"publicAddresses,omitempty"`
+	// ASNumber is the AS number of the nodes in bgp mode, defaults to 64512.
+	// +optional
+	ASNumber uint32 `json:"asNumber,omitempty"`
+	// Peers are the bgp routers the nodes peer with in bgp mode.
+	// +optional
+	Peers []LoadBalancerPeer `json:"peers,omitempty"`
+}
+
+// LoadBalancerPeer is a bgp router the nodes announce the addresses to.
+type LoadBalancerPeer struct {
+	Address  string `json:"address"`
+	ASNumber uint32 `json:"asNumber"`
 }
 
 // HelmChartSpec records the attribute application of  cluster.
@@ -213,6 +299,54 @@ type HelmChartSpec struct {
 	RawValueSet   map[string]string `json:"rawValueSet,omitempty"`
 }
 
+// AppPhase defines the phase of a cluster application release.
+type AppPhase string
+
+const (
+	// AppDeployed means the release is applied to the cluster.
+	AppDeployed AppPhase = "Deployed"
+	// AppFailed means the last install or upgrade failed.
+	AppFailed AppPhase = "Failed"
+)
+
+// AppStatus records the release status of an application of the cluster.
+type AppStatus struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	// +optional
+	ChartName string `json:"chartName,omitempty"`
+	// Deployed chart version.
+	// +optional
+	ChartVersion string `json:"chartVersion,omitempty"`
+	// +optional
+	Revision int32 `json:"revision,omitempty"`
+	// +optional
+	Phase AppPhase `json:"phase,omitempty"`
+	// Error message of the last failed install or upgrade.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// +optional
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// CertificateSourceCredential is the source of the certificates kept in the ClusterCredential.
+const CertificateSourceCredential = "credential"
+
+// CertificateStatus records the expiration of a certificate of the cluster.
+type CertificateStatus struct {
+	// Name is the path of the certificate, or of the kubeconfig with the client certificate.
+	Name string `json:"name"`
+	// Source is credential for the certificates of the ClusterCredential, or the ip of
+	// the master the certificate is read from.
+	Source string `json:"source"`
+	// +optional
+	CommonName string `json:"commonName,omitempty"`
+	// IsCA is true for the certificate authorities, they are rotated by EnsureRotateCA only.
+	// +optional
+	IsCA     bool        `json:"isCA,omitempty"`
+	NotAfter metav1.Time `json:"notAfter"`
+}
+
 // ClusterProperty records the attribute information of the cluster.
 type ClusterProperty struct {
 	// +optional
@@ -283,8 +417,18 @@ type ClusterSpec struct {
 	Properties ClusterProperty `json:"properties,omitempty"`
 	// +optional
 	Machines []*ClusterMachine `json:"machines,omitempty"`
+	// HostSelector claims Count hosts of the inventory and adds them to Machines as the masters
+	// while the cluster is initializing.
+	// +optional
+	HostSelector *HostSelector `json:"hostSelector,omitempty"`
+	// ContainerRuntime is the container runtime of the nodes, defaults to docker.
+	// +optional
+	ContainerRuntime ContainerRuntimeType `json:"containerRuntime,omitempty"`
 	// +optional
 	DockerExtraArgs map[string]string `json:"dockerExtraArgs,omitempty"`
+	// ContainerdExtraArgs holds the version and the comma separated insecure-registries of containerd.
+	// +optional
+	ContainerdExtraArgs map[string]string `json:"containerdExtraArgs,omitempty"`
 	// +optional
 	KubeletExtraArgs map[string]string `json:"kubeletExtraArgs,omitempty"`
 	// +optional
@@ -340,6 +484,20 @@ type ClusterStatus struct {
 	// +optional
 	RegistryIPs []string `json:"registryIPs,omitempty"`
 	NodeCount   int      `json:"nodeCount,omitempty"`
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge
+	Apps []AppStatus `json:"apps,omitempty"`
+	// Certificates of the cluster sorted by the expiration.
+	// +optional
+	Certificates []CertificateStatus `json:"certificates,omitempty"`
+	// CertificatesCheckTime is the last time the certificates are inspected.
+	// +optional
+	CertificatesCheckTime *metav1.Time `json:"certificatesCheckTime,omitempty"`
+	// HAMasters are the masters keepalived and haproxy of DKEHA are installed on, the ones
+	// removed from the spec are cleaned by the update.
+	// +optional
+	HAMasters []*ClusterMachine `json:"haMasters,omitempty"`
 }
 
 // MonitoringStatus defines the monit statu of  cluster
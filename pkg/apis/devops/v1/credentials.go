@@ -0,0 +1,122 @@
+package v1
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/gostship/kunkka/pkg/util/ssh"
+)
+
+// The keys of the secret of a ssh credential.
+const (
+	CredentialsUsernameKey   = "username"
+	CredentialsPasswordKey   = "password"
+	CredentialsPrivateKeyKey = "privateKey"
+	CredentialsPassPhraseKey = "passPhrase"
+)
+
+// SecretGetter returns the data of the secret.
+// +kubebuilder:object:generate=false
+type SecretGetter func(namespace, name string) (map[string][]byte, error)
+
+var (
+	secretGetterLock sync.RWMutex
+	secretGetter     SecretGetter
+)
+
+// SetSecretGetter sets how the secrets of the credentialsRef of the machines are read, every
+// binary connecting to the machines sets it on start by common.SetupSSHCredentials.
+func SetSecretGetter(getter SecretGetter) {
+	secretGetterLock.Lock()
+	defer secretGetterLock.Unlock()
+
+	secretGetter = getter
+}
+
+// resolveCredentials sets the values of the secret of the reference on the config.
+func resolveCredentials(ref *CredentialsRef, c *ssh.Config) error {
+	if ref == nil {
+		return nil
+	}
+	secretGetterLock.RLock()
+	getter := secretGetter
+	secretGetterLock.RUnlock()
+	if getter == nil {
+		return fmt.Errorf("no secret getter is set to read the credential %s/%s", ref.Namespace, ref.Name)
+	}
+
+	data, err := getter(ref.Namespace, ref.Name)
+	if err != nil {
+		return fmt.Errorf("read the credential %s/%s of %s err: %v", ref.Namespace, ref.Name, c.Host, err)
+	}
+	if v, ok := data[CredentialsUsernameKey]; ok {
+		c.User = string(v)
+	}
+	if v, ok := data[CredentialsPasswordKey]; ok {
+		c.Password = string(v)
+	}
+	if v, ok := data[CredentialsPrivateKeyKey]; ok {
+		c.PrivateKey = v
+	}
+	if v, ok := data[CredentialsPassPhraseKey]; ok {
+		c.PassPhrase = v
+	}
+	return nil
+}
+
+// CredentialsRefs returns the credentialsRef of the machine and of its jump hosts.
+func (in *ClusterMachine) CredentialsRefs() []*CredentialsRef {
+	var refs []*CredentialsRef
+	if in.CredentialsRef != nil {
+		refs = append(refs, in.CredentialsRef)
+	}
+	for i := range in.JumpHosts {
+		if in.JumpHosts[i].CredentialsRef != nil {
+			refs = append(refs, in.JumpHosts[i].CredentialsRef)
+		}
+	}
+	return refs
+}
+
+// HasInlineCredentials reports whether the password or the private key of the machine or its
+// jump hosts is kept in the spec.
+func (in *ClusterMachine) HasInlineCredentials() bool {
+	if in.Password != "" || len(in.PrivateKey) > 0 || len(in.PassPhrase) > 0 {
+		return true
+	}
+	for _, host := range in.JumpHosts {
+		if host.Password != "" || len(host.PrivateKey) > 0 || len(host.PassPhrase) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactCredentials clears the inline password and private key of the machine and its jump
+// hosts, the machine can not connect afterwards unless it has a credentialsRef.
+func (in *ClusterMachine) RedactCredentials() {
+	in.Password = ""
+	in.PrivateKey = nil
+	in.PassPhrase = nil
+	for i := range in.JumpHosts {
+		in.JumpHosts[i].Password = ""
+		in.JumpHosts[i].PrivateKey = nil
+		in.JumpHosts[i].PassPhrase = nil
+	}
+}
+
+// RedactCredentials clears the inline credentials of the machines of the cluster.
+func (in *Cluster) RedactCredentials() {
+	for _, m := range in.Spec.Machines {
+		if m != nil {
+			m.RedactCredentials()
+		}
+	}
+}
+
+// RedactCredentials clears the inline credentials of the machine.
+func (in *Machine) RedactCredentials() {
+	if in.Spec.Machine != nil {
+		in.Spec.Machine.RedactCredentials()
+	}
+}
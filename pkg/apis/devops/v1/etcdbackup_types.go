@@ -0,0 +1,155 @@
+/*
+Copyright 2020 dke.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EtcdBackupPhase defines the phase of etcd backup.
+type EtcdBackupPhase string
+
+const (
+	// EtcdBackupActive means the last scheduled backup succeeded.
+	EtcdBackupActive EtcdBackupPhase = "Active"
+	// EtcdBackupSuspended means the schedule is suspended.
+	EtcdBackupSuspended EtcdBackupPhase = "Suspended"
+	// EtcdBackupFailed means the last scheduled backup failed.
+	EtcdBackupFailed EtcdBackupPhase = "Failed"
+)
+
+// LocalBackupStorage stores the snapshots in a directory of the controller,
+// the directory is usually a mounted hostPath or persistent volume.
+type LocalBackupStorage struct {
+	// Path is the root directory of the snapshots.
+	Path string `json:"path"`
+}
+
+// S3BackupStorage stores the snapshots in a S3 compatible object storage.
+type S3BackupStorage struct {
+	// Endpoint is the host[:port] of the object storage, e.g. minio.minio:9000.
+	Endpoint string `json:"endpoint"`
+	Bucket   string `json:"bucket"`
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+	// +optional
+	Region string `json:"region,omitempty"`
+	// Insecure uses http instead of https.
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+	// CredentialsSecret is the name of the secret in the same namespace,
+	// which holds the accessKeyID and secretAccessKey keys.
+	CredentialsSecret string `json:"credentialsSecret"`
+}
+
+// EtcdBackupStorage is the store of snapshots, only one of them can be set.
+type EtcdBackupStorage struct {
+	// +optional
+	Local *LocalBackupStorage `json:"local,omitempty"`
+	// +optional
+	S3 *S3BackupStorage `json:"s3,omitempty"`
+}
+
+// EtcdBackupRetention controls how many snapshots are kept in the store.
+type EtcdBackupRetention struct {
+	// MaxBackups is the max number of snapshots, zero means no limit.
+	// +optional
+	MaxBackups int32 `json:"maxBackups,omitempty"`
+	// MaxAge removes the snapshots older than it.
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+}
+
+// EtcdBackupSpec is a description of etcd backup.
+type EtcdBackupSpec struct {
+	// ClusterName is the cluster in the same namespace.
+	ClusterName string `json:"clusterName"`
+	// Schedule is a cron expression or @every <duration>,
+	// the backup runs only once when it is empty.
+	// +optional
+	Schedule string            `json:"schedule,omitempty"`
+	Storage  EtcdBackupStorage `json:"storage"`
+	// +optional
+	Retention EtcdBackupRetention `json:"retention,omitempty"`
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// EtcdSnapshot records a snapshot in the store.
+type EtcdSnapshot struct {
+	Name string `json:"name"`
+	// +optional
+	Size int64 `json:"size,omitempty"`
+	// +optional
+	SHA256 string `json:"sha256,omitempty"`
+	// +optional
+	CreationTime metav1.Time `json:"creationTime,omitempty"`
+}
+
+// EtcdBackupStatus represents information about the status of an etcd backup.
+type EtcdBackupStatus struct {
+	// +optional
+	Phase EtcdBackupPhase `json:"phase,omitempty"`
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// +optional
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+	// Snapshots are the snapshots in the store, the newest is the last.
+	// +optional
+	Snapshots []EtcdSnapshot `json:"snapshots,omitempty"`
+	// A human readable message indicating details about why the backup is in this condition.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// A brief CamelCase message indicating details about why the backup is in this state.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// +kubebuilder:object:root=true
+
+// EtcdBackup is the Schema for the EtcdBackup API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=eb
+// +kubebuilder:printcolumn:name="CLUSTER",type="string",JSONPath=".spec.clusterName",description="The cluster name."
+// +kubebuilder:printcolumn:name="SCHEDULE",type="string",JSONPath=".spec.schedule",description="The backup schedule."
+// +kubebuilder:printcolumn:name="PHASE",type="string",JSONPath=".status.phase",description="The backup phase."
+// +kubebuilder:printcolumn:name="LAST",type="date",JSONPath=".status.lastSuccessfulTime",description="The last successful backup time."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp",description="CreationTimestamp is a timestamp representing the server time when this object was created. "
+type EtcdBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EtcdBackupSpec   `json:"spec,omitempty"`
+	Status EtcdBackupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// EtcdBackupList contains a list of EtcdBackup
+type EtcdBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EtcdBackup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EtcdBackup{}, &EtcdBackupList{})
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2020 dke.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EtcdRestorePhase defines the phase of etcd restore.
+type EtcdRestorePhase string
+
+const (
+	// EtcdRestoreRunning means the members are being restored.
+	EtcdRestoreRunning EtcdRestorePhase = "Running"
+	// EtcdRestoreCompleted means all members are restored from the snapshot.
+	EtcdRestoreCompleted EtcdRestorePhase = "Completed"
+	// EtcdRestoreFailed means the restore failed, it is not retried.
+	EtcdRestoreFailed EtcdRestorePhase = "Failed"
+)
+
+// EtcdRestoreSpec is a description of etcd restore.
+type EtcdRestoreSpec struct {
+	// ClusterName is the cluster in the same namespace.
+	ClusterName string `json:"clusterName"`
+	// BackupName is the EtcdBackup in the same namespace, its storage is used to load the snapshot.
+	BackupName string `json:"backupName"`
+	// Snapshot is the snapshot name in the store, defaults to the newest one.
+	// +optional
+	Snapshot string `json:"snapshot,omitempty"`
+}
+
+// EtcdRestoreStatus represents information about the status of an etcd restore.
+type EtcdRestoreStatus struct {
+	// +optional
+	Phase EtcdRestorePhase `json:"phase,omitempty"`
+	// Snapshot is the snapshot used to restore.
+	// +optional
+	Snapshot string `json:"snapshot,omitempty"`
+	// Members are the etcd members rebuilt from the snapshot.
+	// +optional
+	Members []string `json:"members,omitempty"`
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// A human readable message indicating details about why the restore is in this condition.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// A brief CamelCase message indicating details about why the restore is in this state.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// +kubebuilder:object:root=true
+
+// EtcdRestore is the Schema for the EtcdRestore API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=er
+// +kubebuilder:printcolumn:name="CLUSTER",type="string",JSONPath=".spec.clusterName",description="The cluster name."
+// +kubebuilder:printcolumn:name="SNAPSHOT",type="string",JSONPath=".status.snapshot",description="The snapshot used to restore."
+// +kubebuilder:printcolumn:name="PHASE",type="string",JSONPath=".status.phase",description="The restore phase."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp",description="CreationTimestamp is a timestamp representing the server time when this object was created. "
+type EtcdRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EtcdRestoreSpec   `json:"spec,omitempty"`
+	Status EtcdRestoreStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// EtcdRestoreList contains a list of EtcdRestore
+type EtcdRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EtcdRestore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EtcdRestore{}, &EtcdRestoreList{})
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2020 dke.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// SubjectKindUser binds a role to a user.
+	SubjectKindUser = "User"
+	// SubjectKindGroup binds a role to the users of a group.
+	SubjectKindGroup = "Group"
+
+	// SubjectProviderLocal is the provider of the users of the local user store.
+	SubjectProviderLocal = "local"
+)
+
+// PolicyRule is the verbs allowed on the resources of the apimanager.
+type PolicyRule struct {
+	// Verbs are get, list, create, update, delete or * for all.
+	Verbs []string `json:"verbs"`
+	// Resources are the resources of the apimanager routes, e.g. clusters, nodes,
+	// pods, pods/log, pods/exec, kubeconfig, or * for all.
+	Resources []string `json:"resources"`
+}
+
+// GlobalRoleSpec defines the rules of the role.
+type GlobalRoleSpec struct {
+	Rules []PolicyRule `json:"rules"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// +kubebuilder:object:root=true
+
+// GlobalRole is the Schema for the GlobalRole API
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:scope=Cluster,shortName=gr
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp",description="CreationTimestamp is a timestamp representing the server time when this object was created. "
+type GlobalRole struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec GlobalRoleSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GlobalRoleList contains a list of GlobalRole
+type GlobalRoleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GlobalRole `json:"items"`
+}
+
+// Subject is a user or a group of the apimanager authentication.
+type Subject struct {
+	// Kind is User or Group.
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// Provider is the authenticator of the subject, e.g. local, ldap or oidc.
+	// A User subject with no provider is a local user, a Group subject with
+	// no provider matches the group of every provider.
+	// +optional
+	Provider string `json:"provider,omitempty"`
+}
+
+// GlobalRoleBindingSpec binds a role to the subjects.
+type GlobalRoleBindingSpec struct {
+	// RoleRef is the name of the GlobalRole.
+	RoleRef  string    `json:"roleRef"`
+	Subjects []Subject `json:"subjects"`
+	// Clusters limits the binding to the routes of these clusters, e.g. /klusters/:name/...
+	// The binding with no clusters applies to all the routes.
+	// +optional
+	Clusters []string `json:"clusters,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// +kubebuilder:object:root=true
+
+// GlobalRoleBinding is the Schema for the GlobalRoleBinding API
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:scope=Cluster,shortName=grb
+// +kubebuilder:printcolumn:name="ROLE",type="string",JSONPath=".spec.roleRef",description="The bound role."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp",description="CreationTimestamp is a timestamp representing the server time when this object was created. "
+type GlobalRoleBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec GlobalRoleBindingSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GlobalRoleBindingList contains a list of GlobalRoleBinding
+type GlobalRoleBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GlobalRoleBinding `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GlobalRole{}, &GlobalRoleList{}, &GlobalRoleBinding{}, &GlobalRoleBindingList{})
+}
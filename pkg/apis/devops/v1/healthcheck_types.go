@@ -0,0 +1,155 @@
+/*
+Copyright 2020 dke.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// RemediationType is an action taken on an unhealthy machine.
+type RemediationType string
+
+const (
+	// RemediationRestartKubelet restarts the kubelet of the machine over ssh.
+	RemediationRestartKubelet RemediationType = "RestartKubelet"
+	// RemediationReboot reboots the machine over ssh.
+	RemediationReboot RemediationType = "Reboot"
+	// RemediationReprovision cleans the machine and runs the create handlers of the machine again.
+	RemediationReprovision RemediationType = "Reprovision"
+)
+
+// UnhealthyCondition is a node condition making the machine unhealthy once the condition
+// stays in the status for Timeout.
+type UnhealthyCondition struct {
+	Type    corev1.NodeConditionType `json:"type"`
+	Status  corev1.ConditionStatus   `json:"status"`
+	Timeout metav1.Duration          `json:"timeout"`
+}
+
+// MachineHealthCheckSpec defines the machines checked and how they are remediated.
+type MachineHealthCheckSpec struct {
+	// ClusterName is the cluster of the machines.
+	ClusterName string `json:"clusterName"`
+	// Selector selects the machines of the cluster by label, all of them if empty.
+	// +optional
+	Selector metav1.LabelSelector `json:"selector,omitempty"`
+	// UnhealthyConditions are the node conditions making the machine unhealthy, Ready not
+	// True and DiskPressure True for 5m if not set.
+	// +optional
+	UnhealthyConditions []UnhealthyCondition `json:"unhealthyConditions,omitempty"`
+	// HeartbeatTimeout is the time the machine is unhealthy after the last heartbeat of the
+	// kubelet or once its node is gone, 5m if not set.
+	// +optional
+	HeartbeatTimeout *metav1.Duration `json:"heartbeatTimeout,omitempty"`
+	// Remediations are the actions taken in turn on an unhealthy machine, RestartKubelet,
+	// Reboot and Reprovision if not set.
+	// +optional
+	Remediations []RemediationType `json:"remediations,omitempty"`
+	// RemediationTimeout is the time the machine is given to turn healthy after a remediation
+	// before the next one is taken, 10m if not set.
+	// +optional
+	RemediationTimeout *metav1.Duration `json:"remediationTimeout,omitempty"`
+	// MaxUnhealthy is the number or percentage of unhealthy machines, with the ones still
+	// recovering from a remediation, above which no machine is remediated, so a network
+	// partition does not take down all the machines, 40% if not set.
+	// +optional
+	MaxUnhealthy *intstr.IntOrString `json:"maxUnhealthy,omitempty"`
+}
+
+// MachineHealthTarget is a machine failing a check, or recovering from a remediation, and its
+// remediations.
+type MachineHealthTarget struct {
+	Machine string `json:"machine"`
+	// Healthy means the machine is healthy again and is given RemediationTimeout after
+	// the last remediation before it is dropped.
+	// +optional
+	Healthy bool `json:"healthy,omitempty"`
+	// Reason is why the machine is unhealthy.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// +optional
+	Message string `json:"message,omitempty"`
+	// UnhealthySince is the time the machine is seen unhealthy.
+	// +optional
+	UnhealthySince *metav1.Time `json:"unhealthySince,omitempty"`
+	// Remediations is the count of the remediations taken.
+	// +optional
+	Remediations int32 `json:"remediations,omitempty"`
+	// +optional
+	LastRemediation RemediationType `json:"lastRemediation,omitempty"`
+	// +optional
+	LastRemediationTime *metav1.Time `json:"lastRemediationTime,omitempty"`
+}
+
+// MachineHealthCheckStatus is the health of the machines checked.
+type MachineHealthCheckStatus struct {
+	// ExpectedMachines is the count of the running machines selected and the ones being
+	// provisioned again by a remediation.
+	// +optional
+	ExpectedMachines int32 `json:"expectedMachines,omitempty"`
+	// +optional
+	CurrentHealthy int32 `json:"currentHealthy,omitempty"`
+	// RemediationsAllowed is the count of the unhealthy machines allowed by MaxUnhealthy.
+	// +optional
+	RemediationsAllowed int32 `json:"remediationsAllowed,omitempty"`
+	// Targets are the machines failing a check or recovering from a remediation.
+	// +optional
+	Targets []MachineHealthTarget `json:"targets,omitempty"`
+	// Reason is TooManyUnhealthy once the remediations are stopped by MaxUnhealthy.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// +optional
+	Message string `json:"message,omitempty"`
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// +kubebuilder:object:root=true
+
+// MachineHealthCheck is the Schema for the MachineHealthCheck API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=mhc
+// +kubebuilder:printcolumn:name="CLUSTER",type="string",JSONPath=".spec.clusterName",description="The cluster of the machines."
+// +kubebuilder:printcolumn:name="EXPECTED",type="integer",JSONPath=".status.expectedMachines",description="The count of machines checked."
+// +kubebuilder:printcolumn:name="HEALTHY",type="integer",JSONPath=".status.currentHealthy",description="The count of healthy machines."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp",description="CreationTimestamp is a timestamp representing the server time when this object was created. "
+type MachineHealthCheck struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MachineHealthCheckSpec   `json:"spec,omitempty"`
+	Status MachineHealthCheckStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MachineHealthCheckList contains a list of MachineHealthCheck
+type MachineHealthCheckList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MachineHealthCheck `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MachineHealthCheck{}, &MachineHealthCheckList{})
+}
@@ -42,6 +42,39 @@ type ClusterMachine struct {
 	// +optional
 	Taints  []corev1.Taint `json:"taints,omitempty"`
 	HostCni *ClusterCni    `json:"hostCni"`
+	// JumpHosts is the chain of the bastions the machine is reached through, the first
+	// one is connected directly.
+	// +optional
+	JumpHosts []JumpHost `json:"jumpHosts,omitempty"`
+	// CredentialsRef is the secret of the ssh credential of the machine, its values take
+	// precedence over the inline ones.
+	// +optional
+	CredentialsRef *CredentialsRef `json:"credentialsRef,omitempty"`
+}
+
+// CredentialsRef points at a secret of a ssh credential, with the keys username, password,
+// privateKey and passPhrase, all optional. The secret may be shared, e.g. by the machines
+// of a rack. It is in the namespace of the owner or the shared credentials namespace of the
+// controller, the others are rejected.
+type CredentialsRef struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// JumpHost is a bastion on the ssh path to a machine
+type JumpHost struct {
+	IP   string `json:"ip"`
+	Port int32  `json:"port"`
+	// +optional
+	Username string `json:"username,omitempty"`
+	// +optional
+	Password string `json:"password,omitempty"`
+	// +optional
+	PrivateKey []byte `json:"privateKey,omitempty"`
+	// +optional
+	PassPhrase []byte `json:"passPhrase,omitempty"`
+	// +optional
+	CredentialsRef *CredentialsRef `json:"credentialsRef,omitempty"`
 }
 
 // ClusterCni configuration for cluster or machine cni
@@ -96,9 +129,41 @@ func (in *ClusterMachine) SSH() (*ssh.SSH, error) {
 		DialTimeOut: time.Second,
 		Retry:       0,
 	}
+	if err := resolveCredentials(in.CredentialsRef, sshConfig); err != nil {
+		return nil, err
+	}
+	for _, host := range in.JumpHosts {
+		config := ssh.Config{
+			User:        host.Username,
+			Host:        host.IP,
+			Port:        int(host.Port),
+			Password:    host.Password,
+			PrivateKey:  host.PrivateKey,
+			PassPhrase:  host.PassPhrase,
+			DialTimeOut: 5 * time.Second,
+		}
+		if err := resolveCredentials(host.CredentialsRef, &config); err != nil {
+			return nil, err
+		}
+		// the jump hosts without a username use the one of the machine
+		if config.User == "" {
+			config.User = sshConfig.User
+		}
+		sshConfig.JumpHosts = append(sshConfig.JumpHosts, config)
+	}
 	return ssh.New(sshConfig)
 }
 
+// Rekey drops the pinned host key of the machine behind its jump hosts, so the reinstalled
+// machine is trusted on the next connection.
+func (in *ClusterMachine) Rekey() error {
+	sshConfig := &ssh.Config{Host: in.IP, Port: int(in.Port)}
+	for _, host := range in.JumpHosts {
+		sshConfig.JumpHosts = append(sshConfig.JumpHosts, ssh.Config{Host: host.IP, Port: int(host.Port)})
+	}
+	return ssh.Rekey(sshConfig)
+}
+
 func (in *Cluster) Address(addrType AddressType) *ClusterAddress {
 	for _, one := range in.Status.Addresses {
 		if one.Type == addrType {
@@ -188,15 +253,21 @@ func (in *Machine) SetCondition(newCondition MachineCondition) {
 }
 
 func (in *MachineSpec) SSH() (*ssh.SSH, error) {
-	sshConfig := &ssh.Config{
-		User:        in.Machine.Username,
-		Host:        in.Machine.IP,
-		Port:        int(in.Machine.Port),
-		Password:    in.Machine.Password,
-		PrivateKey:  in.Machine.PrivateKey,
-		PassPhrase:  in.Machine.PassPhrase,
-		DialTimeOut: time.Second,
-		Retry:       0,
+	return in.Machine.SSH()
+}
+
+// GetContainerRuntime returns the container runtime of the cluster, docker if not set.
+func (in *ClusterSpec) GetContainerRuntime() ContainerRuntimeType {
+	if in.ContainerRuntime == "" {
+		return ContainerRuntimeDocker
 	}
-	return ssh.New(sshConfig)
+	return in.ContainerRuntime
+}
+
+// GetContainerRuntime returns the container runtime of the machine, the cluster one if not set.
+func (in *MachineSpec) GetContainerRuntime(cluster *ClusterSpec) ContainerRuntimeType {
+	if in.ContainerRuntime == "" {
+		return cluster.GetContainerRuntime()
+	}
+	return in.ContainerRuntime
 }
@@ -0,0 +1,166 @@
+/*
+Copyright 2020 dke.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HostPhase is the state of a host in the inventory.
+type HostPhase string
+
+const (
+	// HostDiscovering means the facts of the host are being discovered.
+	HostDiscovering HostPhase = "Discovering"
+	// HostAvailable means the host may be claimed.
+	HostAvailable HostPhase = "Available"
+	// HostClaimed means the host is claimed by a running cluster or machine.
+	HostClaimed HostPhase = "Claimed"
+	// HostProvisioning means the cluster or machine claiming the host is being created on it.
+	HostProvisioning HostPhase = "Provisioning"
+	// HostFailed means the discovery of the host or its provisioning failed.
+	HostFailed HostPhase = "Failed"
+	// HostMaintenance means the host is taken out of the inventory.
+	HostMaintenance HostPhase = "Maintenance"
+)
+
+// LabelHost is the label of the machines created on a host of the inventory.
+const LabelHost = "devops.gostship.io/host"
+
+// HostSpec defines the ssh endpoint of a server in the inventory.
+type HostSpec struct {
+	IP   string `json:"ip"`
+	Port int32  `json:"port"`
+	// +optional
+	Username string `json:"username,omitempty"`
+	// CredentialsRef is the secret of the ssh credential of the host, it must be in the
+	// shared credentials namespace of the controller.
+	CredentialsRef *CredentialsRef `json:"credentialsRef"`
+	// +optional
+	JumpHosts []JumpHost `json:"jumpHosts,omitempty"`
+	// Rack is the rack the host is in.
+	// +optional
+	Rack string `json:"rack,omitempty"`
+	// Maintenance takes the host out of the inventory once it is not claimed.
+	// +optional
+	Maintenance bool `json:"maintenance,omitempty"`
+}
+
+// HostDisk is a block device of the host.
+type HostDisk struct {
+	Name string            `json:"name"`
+	Size resource.Quantity `json:"size"`
+	// Rotational means a spinning disk.
+	// +optional
+	Rotational bool `json:"rotational,omitempty"`
+	// +optional
+	Model string `json:"model,omitempty"`
+}
+
+// HostNIC is a physical network interface of the host.
+type HostNIC struct {
+	Name string `json:"name"`
+	MAC  string `json:"mac"`
+	// +optional
+	IPs []string `json:"ips,omitempty"`
+}
+
+// HostStatus is the facts discovered on the host and its claim.
+type HostStatus struct {
+	// +optional
+	Phase HostPhase `json:"phase,omitempty"`
+	// ClaimRef is the cluster or machine claiming the host.
+	// +optional
+	ClaimRef *corev1.ObjectReference `json:"claimRef,omitempty"`
+	// +optional
+	CPU int32 `json:"cpu,omitempty"`
+	// +optional
+	Memory *resource.Quantity `json:"memory,omitempty"`
+	// RootDiskAvail is the space available on the root filesystem.
+	// +optional
+	RootDiskAvail *resource.Quantity `json:"rootDiskAvail,omitempty"`
+	// +optional
+	Disks []HostDisk `json:"disks,omitempty"`
+	// +optional
+	NICs []HostNIC `json:"nics,omitempty"`
+	// +optional
+	SystemInfo MachineSystemInfo `json:"systemInfo,omitempty"`
+	// +optional
+	LastDiscoveryTime *metav1.Time `json:"lastDiscoveryTime,omitempty"`
+	// A brief CamelCase message indicating details about why the host is in this state.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// A human readable message indicating details about why the host is in this state.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// +kubebuilder:object:root=true
+
+// Host is the Schema for the Host API, a server of the inventory claimed by the clusters and
+// machines by selector.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="IP",type="string",JSONPath=".spec.ip",description="The ssh address of the host."
+// +kubebuilder:printcolumn:name="RACK",type="string",JSONPath=".spec.rack",description="The rack of the host."
+// +kubebuilder:printcolumn:name="PHASE",type="string",JSONPath=".status.phase",description="The host phase."
+// +kubebuilder:printcolumn:name="CPU",type="integer",JSONPath=".status.cpu",description="The count of logical cpus."
+// +kubebuilder:printcolumn:name="MEMORY",type="string",JSONPath=".status.memory",description="The memory of the host."
+// +kubebuilder:printcolumn:name="CLAIM",type="string",JSONPath=".status.claimRef.name",description="The cluster or machine claiming the host."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp",description="CreationTimestamp is a timestamp representing the server time when this object was created. "
+type Host struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HostSpec   `json:"spec,omitempty"`
+	Status HostStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// HostList contains a list of Host
+type HostList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Host `json:"items"`
+}
+
+// HostSelector selects the available hosts of the inventory claimed instead of the machines
+// given by ip.
+type HostSelector struct {
+	// Selector selects the hosts by label, all of them if empty.
+	// +optional
+	Selector metav1.LabelSelector `json:"selector,omitempty"`
+	// Rack selects the hosts of the rack.
+	// +optional
+	Rack string `json:"rack,omitempty"`
+	// Count is the count of the hosts claimed by a cluster as its masters, a machine claims
+	// one host.
+	// +optional
+	Count int `json:"count,omitempty"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Host{}, &HostList{})
+}
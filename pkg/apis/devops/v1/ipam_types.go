@@ -0,0 +1,207 @@
+/*
+Copyright 2020 dke.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IPPoolType is the usage of the pool addresses.
+type IPPoolType string
+
+const (
+	// IPPoolHost is the pool of the machine addresses.
+	IPPoolHost IPPoolType = "Host"
+	// IPPoolPod is the pool of the pod address blocks of the machines.
+	IPPoolPod IPPoolType = "Pod"
+)
+
+const (
+	// LabelRack is the rack label of the pools.
+	LabelRack = "devops.gostship.io/rack"
+	// LabelIPPool is the pool label of the claims.
+	LabelIPPool = "devops.gostship.io/ippool"
+	// LabelLoadBalancer marks the claims of the LoadBalancer addresses of the cluster.
+	LabelLoadBalancer = "devops.gostship.io/loadbalancer"
+	// LabelMachineAddress is the machine address label of the claims of a claimed host.
+	LabelMachineAddress = "devops.gostship.io/machine-address"
+)
+
+// RackSpec defines the network of a rack, the host and pod pools of the rack
+// are named <rack>-host and <rack>-pod.
+type RackSpec struct {
+	// CIDR is the network of the rack, e.g. 10.28.0.0/22.
+	CIDR    string `json:"cidr"`
+	Gateway string `json:"gateway"`
+	// ASNumber is the AS number of the top of rack switch at the gateway, the calico
+	// nodes of the rack peer with it, 64512 if not set.
+	// +optional
+	ASNumber uint32 `json:"asNumber,omitempty"`
+	// ClusterCIDR is the cluster cidr of the clusters in the rack.
+	// +optional
+	ClusterCIDR string `json:"clusterCIDR,omitempty"`
+	// ServiceCIDR is the service cidr, and the default route of the pod blocks.
+	// +optional
+	ServiceCIDR string `json:"serviceCIDR,omitempty"`
+	// PodNum is the size of a pod address block.
+	PodNum int `json:"podNum"`
+	// Master means the rack holds the master machines.
+	// +optional
+	Master bool `json:"master,omitempty"`
+	// MetaHosts are the addresses of the meta cluster nodes in the rack,
+	// the hosted clusters run their masters on them.
+	// +optional
+	MetaHosts []string `json:"metaHosts,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// +kubebuilder:object:root=true
+
+// Rack is the Schema for the Rack API
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="CIDR",type="string",JSONPath=".spec.cidr",description="The rack cidr."
+// +kubebuilder:printcolumn:name="GATEWAY",type="string",JSONPath=".spec.gateway",description="The rack gateway."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp",description="CreationTimestamp is a timestamp representing the server time when this object was created. "
+type Rack struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec RackSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RackList contains a list of Rack
+type RackList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Rack `json:"items"`
+}
+
+// IPRange is a range of addresses, both ends included.
+type IPRange struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// IPPoolSpec defines the addresses of the pool. The ranges are split into blocks
+// of BlockSize addresses, and a block is allocated at a time.
+type IPPoolSpec struct {
+	Rack string     `json:"rack"`
+	Type IPPoolType `json:"type"`
+	// Subnet is the cidr of the addresses.
+	Subnet string    `json:"subnet"`
+	Ranges []IPRange `json:"ranges"`
+	// +optional
+	BlockSize int    `json:"blockSize,omitempty"`
+	Gateway   string `json:"gateway"`
+	// +optional
+	NetMask string `json:"netMask,omitempty"`
+	// +optional
+	DefaultRoute string `json:"defaultRoute,omitempty"`
+}
+
+// IPPoolStatus is the allocation state of the pool.
+type IPPoolStatus struct {
+	// Allocated is the bitmap of the allocated blocks.
+	// +optional
+	Allocated []byte `json:"allocated,omitempty"`
+	// Size is the count of blocks.
+	// +optional
+	Size int `json:"size,omitempty"`
+	// Used is the count of allocated blocks.
+	// +optional
+	Used int `json:"used,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// +kubebuilder:object:root=true
+
+// IPPool is the Schema for the IPPool API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=ipp
+// +kubebuilder:printcolumn:name="RACK",type="string",JSONPath=".spec.rack",description="The rack of the pool."
+// +kubebuilder:printcolumn:name="TYPE",type="string",JSONPath=".spec.type",description="The pool type."
+// +kubebuilder:printcolumn:name="SIZE",type="integer",JSONPath=".status.size",description="The count of blocks."
+// +kubebuilder:printcolumn:name="USED",type="integer",JSONPath=".status.used",description="The count of allocated blocks."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp",description="CreationTimestamp is a timestamp representing the server time when this object was created. "
+type IPPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IPPoolSpec   `json:"spec,omitempty"`
+	Status IPPoolStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// IPPoolList contains a list of IPPool
+type IPPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IPPool `json:"items"`
+}
+
+// IPClaimSpec is a block allocated in a pool.
+type IPClaimSpec struct {
+	Pool string `json:"pool"`
+	// Offset is the index of the block in the pool.
+	Offset     int    `json:"offset"`
+	RangeStart string `json:"rangeStart"`
+	RangeEnd   string `json:"rangeEnd"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// +kubebuilder:object:root=true
+
+// IPClaim is the Schema for the IPClaim API, the claim is owned by the Machine or
+// Cluster using the addresses, and the addresses are released when it is deleted.
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:shortName=ipc
+// +kubebuilder:printcolumn:name="POOL",type="string",JSONPath=".spec.pool",description="The pool of the claim."
+// +kubebuilder:printcolumn:name="START",type="string",JSONPath=".spec.rangeStart",description="The first address."
+// +kubebuilder:printcolumn:name="END",type="string",JSONPath=".spec.rangeEnd",description="The last address."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp",description="CreationTimestamp is a timestamp representing the server time when this object was created. "
+type IPClaim struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec IPClaimSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// IPClaimList contains a list of IPClaim
+type IPClaimList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IPClaim `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Rack{}, &RackList{}, &IPPool{}, &IPPoolList{}, &IPClaim{}, &IPClaimList{})
+}
@@ -70,6 +70,10 @@ type MachineSystemInfo struct {
 	KernelVersion string `json:"kernelVersion,omitempty"`
 	// OS Image reported by the node.
 	OSImage string `json:"osImage,omitempty"`
+	// OSID is the distro id of /etc/os-release, e.g. centos or ubuntu.
+	OSID string `json:"osID,omitempty"`
+	// OSVersion is the distro version id of /etc/os-release, e.g. 7 or 20.04.
+	OSVersion string `json:"osVersion,omitempty"`
 	// ContainerRuntime Version reported by the node.
 	ContainerRuntimeVersion string `json:"containerRuntimeVersion,omitempty"`
 	// Kubelet Version reported by the node.
@@ -101,6 +105,9 @@ type MachineCondition struct {
 	// Human-readable message indicating details about last transition.
 	// +optional
 	Message string `json:"message,omitempty"`
+	// Retries is the count of the failed runs of the handler since it last succeeded.
+	// +optional
+	Retries int32 `json:"retries,omitempty"`
 }
 
 type MachineFeature struct {
@@ -123,6 +130,12 @@ type MachineSpec struct {
 	Machine     *ClusterMachine `json:"machine,omitempty"`
 	Feature     *MachineFeature `json:"feature,omitempty"`
 	Pause       bool            `json:"pause,omitempty"`
+	// ContainerRuntime is the container runtime of the machine, defaults to the cluster one.
+	// +optional
+	ContainerRuntime ContainerRuntimeType `json:"containerRuntime,omitempty"`
+	// HostSelector claims a host of the inventory as the machine once it is not set.
+	// +optional
+	HostSelector *HostSelector `json:"hostSelector,omitempty"`
 	//HostCni     *ClusterCni     `json:"hostCni"`
 }
 
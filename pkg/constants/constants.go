@@ -20,6 +20,11 @@ const (
 	KubeControllerManagerPodManifestFile = KubeletPodManifestDir + "kube-controller-manager.yaml"
 	KubeSchedulerPodManifestFile         = KubeletPodManifestDir + "kube-scheduler.yaml"
 	KeepavlivedManifestFile              = KubeletPodManifestDir + "keepalived.yaml"
+	HAProxyManifestFile                  = KubeletPodManifestDir + "haproxy.yaml"
+
+	KeepalivedConfigFile      = "/etc/keepalived/keepalived.conf"
+	KeepalivedCheckScriptFile = "/etc/keepalived/check_apiserver.sh"
+	HAProxyConfigFile         = "/etc/haproxy/haproxy.cfg"
 
 	DstTmpDir  = "/tmp/k8s/"
 	DstBinDir  = "/usr/local/bin/"
@@ -80,18 +85,25 @@ const (
 	// RenewCertsTimeThreshold control how long time left to renew certs
 	RenewCertsTimeThreshold = 30 * 24 * time.Hour
 
-	FlannelDirFile    = KubernetesDir + "flannel.yaml"
-	CustomDir         = "/opt/k8s/"
-	SystemInitFile    = CustomDir + "init.sh"
-	SystemInitCniFile = CustomDir + "initCni.sh"
-	CniHostLocalFile  = CNIConfDIr + "/net.d/10-host-local.conf"
-	CniLoopBack       = CNIConfDIr + "/net.d/99-loopback.conf"
+	// DefaultMachineParallelism is the default count of machines a handler runs on at the same time
+	DefaultMachineParallelism = 10
+
+	FlannelDirFile     = KubernetesDir + "flannel.yaml"
+	CustomDir          = "/opt/k8s/"
+	SystemInitFile     = CustomDir + "init.sh"
+	SystemInitCniFile  = CustomDir + "initCni.sh"
+	ArtifactScriptFile = CustomDir + "artifacts.sh"
+	CniHostLocalFile   = CNIConfDIr + "/net.d/10-host-local.conf"
+	CniLoopBack        = CNIConfDIr + "/net.d/99-loopback.conf"
 )
 
 const (
 	// DefaultDockerCRISocket defines the default Docker CRI socket
 	DefaultDockerCRISocket = "/var/run/dockershim.sock"
 
+	// ContainerdCRISocket defines the CRI socket of containerd
+	ContainerdCRISocket = "/run/containerd/containerd.sock"
+
 	// PauseVersion indicates the default pause image version for kubeadm
 	PauseVersion = "3.2"
 
@@ -3,6 +3,7 @@ package constants
 const (
 	FinalizersCluster = "finalizers.k8s.io/cluster"
 	FinalizersMachine = "finalizers.k8s.io/machine"
+	FinalizersIPClaim = "finalizers.k8s.io/ipclaim"
 )
 
 func ContainsString(slice []string, s string) bool {
@@ -20,6 +20,19 @@ const (
 	ClusterApiSvcType        = "k8s.io/apiSvcType"
 	ClusterApiSvcVip         = "k8s.io/apiSvcVip"
 	ClusterAnnoLocalDebugDir = "k8s.io/localDebugDir"
+	// ClusterAnnotationDryRun makes the Cluster or Machine only save the plan of its creation,
+	// the creation starts once the annotation is removed.
+	ClusterAnnotationDryRun = "k8s.io/dryRun"
+	// ClusterAnnotationRetry resets the retries of the failed handlers of the Cluster or Machine,
+	// and a Failed one starts again.
+	ClusterAnnotationRetry = "k8s.io/retry"
+	// ClusterAnnotationRekeyHosts drops the pinned host keys of the masters and worker Machines
+	// of the Cluster, or of the Machine, the keys presented on the next connections are pinned,
+	// and the retries are reset.
+	ClusterAnnotationRekeyHosts = "k8s.io/rekeyHosts"
+	// MachineAnnotationForceDrain deletes the pods of the deleted Machine left after the drain
+	// timeout, even if they are blocked by the disruption budgets.
+	MachineAnnotationForceDrain = "k8s.io/forceDrain"
 )
 
 var KubeApiServerLabels = map[string]string{
@@ -38,4 +38,9 @@ const (
 	// KubeSchedulerPort is the default port for the scheduler status server.
 	// May be overridden by a flag at startup.
 	KubeSchedulerPort = 10259
+
+	// KubeAPIServerPort is the port of the apiservers on the masters.
+	KubeAPIServerPort = 6443
+	// DKEHAPort is the default port of haproxy in front of the apiservers on the masters.
+	DKEHAPort = 8443
 )
@@ -1,13 +1,16 @@
 package constants
 
 import (
+	"fmt"
+
 	"github.com/thoas/go-funk"
+	"k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/klog"
 )
 
 var (
 	OSs              = []string{"linux"}
-	K8sVersions      = []string{"v1.16.14", "v1.18.5"}
+	K8sVersions      = []string{"v1.16.14", "v1.17.11", "v1.18.5"}
 	K8sVersionsWithV = funk.Map(K8sVersions, func(s string) string {
 		return "v" + s
 	}).([]string)
@@ -25,3 +28,32 @@ func IsK8sSupport(version string) bool {
 	klog.Errorf("k8s version only support: %#v", K8sVersions)
 	return false
 }
+
+// CheckUpgradeSkew checks the cluster can be upgraded from the current version to the target version,
+// kubeadm only supports upgrading one minor version at a time and downgrade is not allowed.
+func CheckUpgradeSkew(from, to string) error {
+	if !IsK8sSupport(to) {
+		return fmt.Errorf("target version %s is not supported, only support: %v", to, K8sVersions)
+	}
+
+	fromVer, err := version.ParseSemantic(from)
+	if err != nil {
+		return fmt.Errorf("invalid current version %q: %v", from, err)
+	}
+	toVer, err := version.ParseSemantic(to)
+	if err != nil {
+		return fmt.Errorf("invalid target version %q: %v", to, err)
+	}
+
+	if toVer.LessThan(fromVer) {
+		return fmt.Errorf("downgrade from %s to %s is not supported", from, to)
+	}
+	if toVer.Major() != fromVer.Major() {
+		return fmt.Errorf("upgrade from %s to %s across major versions is not supported", from, to)
+	}
+	if toVer.Minor()-fromVer.Minor() > 1 {
+		return fmt.Errorf("upgrade from %s to %s skips a minor version, upgrade one minor version at a time", from, to)
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package constants
+
+import "testing"
+
+func TestCheckUpgradeSkew(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		wantErr bool
+	}{
+		{name: "one minor", from: "v1.16.14", to: "v1.17.11"},
+		{name: "patch", from: "v1.17.1", to: "v1.17.11"},
+		{name: "skip minor", from: "v1.16.14", to: "v1.18.5", wantErr: true},
+		{name: "downgrade", from: "v1.18.5", to: "v1.17.11", wantErr: true},
+		{name: "not support", from: "v1.18.5", to: "v1.19.0", wantErr: true},
+		{name: "invalid current", from: "1.x", to: "v1.17.11", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckUpgradeSkew(tt.from, tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckUpgradeSkew() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
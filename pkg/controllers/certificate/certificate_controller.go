@@ -0,0 +1,187 @@
+/*
+Copyright 2020 dke.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/constants"
+	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/gmanager"
+	"github.com/gostship/kunkka/pkg/provider/phases/certs"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// renewHandler is the update handler issuing the expiring certificates again
+	renewHandler = "EnsureRenewCerts"
+	// rotateHandler is the update handler rotating the expiring certificate authorities
+	rotateHandler = "EnsureRotateCA"
+)
+
+// certificateReconciler checks the certificates of the running clusters
+type certificateReconciler struct {
+	client.Client
+	*gmanager.GManager
+	Log     logr.Logger
+	Mgr     manager.Manager
+	Metrics *expirationMetrics
+}
+
+func Add(mgr manager.Manager, pMgr *gmanager.GManager) error {
+	reconciler := &certificateReconciler{
+		Client:   mgr.GetClient(),
+		Mgr:      mgr,
+		Log:      ctrl.Log.WithName("controllers").WithName("certificate"),
+		GManager: pMgr,
+		Metrics:  defaultMetrics,
+	}
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		Named("certificate").
+		For(&devopsv1.Cluster{}).
+		Complete(reconciler)
+	if err != nil {
+		return errors.Wrapf(err, "unable to create certificate controller")
+	}
+
+	return nil
+}
+
+// +kubebuilder:rbac:groups=devops.gostship.io,resources=clusters,verbs=get;list;watch;update
+// +kubebuilder:rbac:groups=devops.gostship.io,resources=clusters/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=devops.gostship.io,resources=clustercredentials,verbs=get;list;watch
+
+func (r *certificateReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	logger := r.Log.WithValues("cluster", req.NamespacedName.String())
+
+	c := &devopsv1.Cluster{}
+	err := r.Client.Get(ctx, req.NamespacedName, c)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			r.Metrics.Delete(req.NamespacedName)
+			return reconcile.Result{}, nil
+		}
+
+		logger.Error(err, "failed to get cluster")
+		return reconcile.Result{}, err
+	}
+
+	if !c.DeletionTimestamp.IsZero() {
+		r.Metrics.Delete(req.NamespacedName)
+		return reconcile.Result{}, nil
+	}
+	// the cluster is checked again once it turns running
+	if c.Status.Phase != devopsv1.ClusterRunning || c.Spec.Pause {
+		return reconcile.Result{}, nil
+	}
+
+	policy := r.ProviderManager.Cfg.Certs
+	now := time.Now()
+	if c.Status.CertificatesCheckTime != nil {
+		if next := c.Status.CertificatesCheckTime.Add(policy.CheckInterval); next.After(now) {
+			return reconcile.Result{RequeueAfter: next.Sub(now)}, nil
+		}
+	}
+
+	p, err := r.CpManager.GetProvider(c.Spec.Type)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	clusterWrapper, err := common.GetCluster(ctx, r.Client, c, r.ClusterManager)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	list, err := p.Certificates(ctx, clusterWrapper)
+	if err != nil {
+		logger.Error(err, "failed to check certificates")
+		return reconcile.Result{}, err
+	}
+	r.Metrics.Set(req.NamespacedName, list)
+
+	checkTime := metav1.NewTime(now)
+	c.Status.Certificates = list
+	c.Status.CertificatesCheckTime = &checkTime
+	err = r.Client.Status().Update(ctx, c)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	leaf, ca := certs.Expiring(list, policy.RenewBefore, now)
+	for _, cert := range ca {
+		logger.Info("certificate authority expires soon, add "+rotateHandler+" to the action annotation to rotate it",
+			"name", cert.Name, "source", cert.Source, "notAfter", cert.NotAfter)
+	}
+	if len(leaf) > 0 {
+		logger.Info("certificates expire soon, start renew", "count", len(leaf), "name", leaf[0].Name, "notAfter", leaf[0].NotAfter)
+	}
+	annotations, changed := setAction(c.Annotations, renewHandler, len(leaf) > 0)
+	// each run of the rotation takes one stage, the handler is kept until it is done
+	if rotation := clusterWrapper.ClusterCredential.CARotation; rotation != nil {
+		logger.Info("certificate authorities are rotating, keep "+rotateHandler+" in the action annotation", "phase", rotation.Phase)
+		var rotating bool
+		annotations, rotating = setAction(annotations, rotateHandler, true)
+		changed = changed || rotating
+	}
+	if changed {
+		c.Annotations = annotations
+		err = r.Client.Update(ctx, c)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	return reconcile.Result{RequeueAfter: policy.CheckInterval}, nil
+}
+
+// setAction adds the handler to the action annotation or removes it from the annotation,
+// and reports whether the annotations are changed.
+func setAction(annotations map[string]string, handler string, enabled bool) (map[string]string, bool) {
+	var handlers []string
+	if action := constants.GetAnnotationKey(annotations, constants.ClusterAnnotationAction); action != "" {
+		handlers = strings.Split(action, ",")
+	}
+	if constants.ContainsString(handlers, handler) == enabled {
+		return annotations, false
+	}
+
+	if enabled {
+		handlers = append(handlers, handler)
+	} else {
+		handlers = constants.RemoveString(handlers, handler)
+	}
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	if len(handlers) == 0 {
+		delete(annotations, constants.ClusterAnnotationAction)
+	} else {
+		annotations[constants.ClusterAnnotationAction] = strings.Join(handlers, ",")
+	}
+	return annotations, true
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2020 dke.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"strconv"
+	"sync"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var defaultMetrics = newExpirationMetrics()
+
+func init() {
+	metrics.Registry.MustRegister(defaultMetrics.gauge)
+}
+
+// expirationMetrics is the expiration gauge of the cluster certificates, the series of
+// a cluster are replaced by the ones of its last check.
+type expirationMetrics struct {
+	sync.Mutex
+	gauge  *prometheus.GaugeVec
+	series map[types.NamespacedName][]prometheus.Labels
+}
+
+func newExpirationMetrics() *expirationMetrics {
+	return &expirationMetrics{
+		gauge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "kunkka_cluster_certificate_expiration_timestamp_seconds",
+			Help: "The expiration of the certificates of the cluster in unix seconds.",
+		}, []string{"namespace", "cluster", "name", "source", "ca"}),
+		series: make(map[types.NamespacedName][]prometheus.Labels),
+	}
+}
+
+// Set replaces the series of the cluster with the certificates.
+func (m *expirationMetrics) Set(key types.NamespacedName, list []devopsv1.CertificateStatus) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.deleteLocked(key)
+	series := make([]prometheus.Labels, 0, len(list))
+	for _, cert := range list {
+		labels := prometheus.Labels{
+			"namespace": key.Namespace,
+			"cluster":   key.Name,
+			"name":      cert.Name,
+			"source":    cert.Source,
+			"ca":        strconv.FormatBool(cert.IsCA),
+		}
+		m.gauge.With(labels).Set(float64(cert.NotAfter.Unix()))
+		series = append(series, labels)
+	}
+	m.series[key] = series
+}
+
+// Delete removes the series of the cluster.
+func (m *expirationMetrics) Delete(key types.NamespacedName) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.deleteLocked(key)
+}
+
+func (m *expirationMetrics) deleteLocked(key types.NamespacedName) {
+	for _, labels := range m.series[key] {
+		m.gauge.Delete(labels)
+	}
+	delete(m.series, key)
+}
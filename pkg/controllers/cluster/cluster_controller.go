@@ -30,6 +30,7 @@ import (
 	"github.com/gostship/kunkka/pkg/constants"
 	"github.com/gostship/kunkka/pkg/controllers/common"
 	"github.com/gostship/kunkka/pkg/gmanager"
+	"github.com/gostship/kunkka/pkg/provider/ipam"
 	"github.com/gostship/kunkka/pkg/provider/phases/clean"
 	"github.com/gostship/kunkka/pkg/util/pkiutil"
 	"github.com/pkg/errors"
@@ -85,6 +86,10 @@ func (r *clusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 // +kubebuilder:rbac:groups=devops.gostship.io,resources=clusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=devops.gostship.io,resources=clusters/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=devops.gostship.io,resources=hosts,verbs=get;list;watch
+// +kubebuilder:rbac:groups=devops.gostship.io,resources=hosts/status,verbs=get;update;patch
 
 func (r *clusterReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	ctx := context.Background()
@@ -116,6 +121,13 @@ func (r *clusterReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		return reconcile.Result{}, err
 	}
 
+	// the machines are never connected with the secrets of the other namespaces,
+	// the cluster waits for the references to be fixed
+	if err := common.CheckCredentialsRefs(c.Namespace, c.Spec.Machines...); err != nil {
+		logger.Error(err, "invalid machine credentials")
+		return reconcile.Result{}, nil
+	}
+
 	rc := &clusterContext{
 		Key:     req.NamespacedName,
 		Logger:  logger,
@@ -146,12 +158,51 @@ func (r *clusterReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		return reconcile.Result{}, nil
 	}
 
+	migrated, err := common.MigrateCredentials(ctx, r.Client, r.Scheme, c, c.Spec.Machines...)
+	if err != nil {
+		logger.Error(err, "failed to migrate machine credentials")
+		return reconcile.Result{}, err
+	}
+	if migrated {
+		err := r.Client.Update(ctx, c)
+		if err != nil {
+			logger.Error(err, "failed to update migrated machine credentials")
+			return reconcile.Result{}, err
+		}
+
+		return reconcile.Result{}, nil
+	}
+
+	err = common.MigrateClusterCredential(ctx, r.Client, req.NamespacedName)
+	if err != nil {
+		logger.Error(err, "failed to migrate cluster credential")
+		return reconcile.Result{}, err
+	}
+
 	if c.Spec.Pause == true {
 		logger.V(4).Info("cluster is Pause")
 		return reconcile.Result{}, nil
 	}
 
-	if !constants.IsK8sSupport(c.Spec.Version) {
+	if c.Spec.HostSelector != nil && (c.Status.Phase == "" || c.Status.Phase == devopsv1.ClusterInitializing) {
+		claimed, err := r.claimHosts(ctx, c)
+		if err != nil {
+			logger.Error(err, "failed to claim hosts")
+			return reconcile.Result{}, err
+		}
+		if claimed {
+			err := r.Client.Update(ctx, c)
+			if err != nil {
+				logger.Error(err, "failed to update claimed hosts")
+				return reconcile.Result{}, err
+			}
+
+			return reconcile.Result{}, nil
+		}
+	}
+
+	// the version of running cluster is checked by the upgrade
+	if len(c.Status.Version) == 0 && !constants.IsK8sSupport(c.Spec.Version) {
 		if c.Status.Phase != devopsv1.ClusterNotSupport {
 			logger.V(4).Info("not support", "version", c.Spec.Version)
 			c.Status.Phase = devopsv1.ClusterNotSupport
@@ -175,6 +226,10 @@ func (r *clusterReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	}
 
 	r.reconcile(ctx, rc)
+	if after := r.retryAfter(rc); after > 0 {
+		logger.V(4).Info("wait retry", "after", after)
+		return ctrl.Result{RequeueAfter: after}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -228,6 +283,18 @@ func (r *clusterReconciler) reconcile(ctx context.Context, rc *clusterContext) e
 		return nil
 	}
 
+	if len(constants.GetAnnotationKey(rc.Cluster.Annotations, constants.ClusterAnnotationRekeyHosts)) > 0 {
+		return r.rekeyHosts(ctx, rc)
+	}
+	if len(constants.GetAnnotationKey(rc.Cluster.Annotations, constants.ClusterAnnotationRetry)) > 0 {
+		return r.resetRetries(ctx, rc)
+	}
+
+	if rc.Cluster.Status.Phase == devopsv1.ClusterFailed {
+		rc.Logger.V(4).Info("cluster is failed, waiting retry")
+		return nil
+	}
+
 	p, err := r.CpManager.GetProvider(rc.Cluster.Spec.Type)
 	if err != nil {
 		return err
@@ -238,14 +305,29 @@ func (r *clusterReconciler) reconcile(ctx context.Context, rc *clusterContext) e
 		return err
 	}
 
+	if rc.Cluster.Status.Phase == devopsv1.ClusterInitializing &&
+		len(constants.GetAnnotationKey(rc.Cluster.Annotations, constants.ClusterAnnotationDryRun)) > 0 {
+		rc.Logger.Info("onPlan")
+		return r.onPlan(ctx, rc, p, clusterWrapper)
+	}
+
 	switch rc.Cluster.Status.Phase {
 	case devopsv1.ClusterInitializing:
 		rc.Logger.Info("onCreate")
 		r.onCreate(ctx, rc, p, clusterWrapper)
 	case devopsv1.ClusterRunning:
-		rc.Logger.Info("onUpdate")
 		r.addClusterCheck(ctx, clusterWrapper)
+		if len(rc.Cluster.Status.Version) > 0 && rc.Cluster.Spec.Version != rc.Cluster.Status.Version {
+			rc.Logger.Info("onUpgrade", "from", rc.Cluster.Status.Version, "to", rc.Cluster.Spec.Version)
+			r.onUpgrade(ctx, rc, p, clusterWrapper)
+			break
+		}
+		rc.Logger.Info("onUpdate")
 		r.onUpdate(ctx, rc, p, clusterWrapper)
+	case devopsv1.ClusterUpgrading:
+		rc.Logger.Info("onUpgrade")
+		r.addClusterCheck(ctx, clusterWrapper)
+		r.onUpgrade(ctx, rc, p, clusterWrapper)
 	default:
 		return fmt.Errorf("no handler for %q", rc.Cluster.Status.Phase)
 	}
@@ -315,14 +397,52 @@ func (r *clusterReconciler) cleanClusterResources(ctx context.Context, rc *clust
 			return err
 		}
 
-		err = clean.CleanNode(ssh)
+		err = clean.CleanNode(ssh, rc.Cluster.Spec.GetContainerRuntime())
 		if err != nil {
 			rc.Logger.Error(err, "failed clean machine node", "node", m.IP)
 			return err
 		}
 	}
 
+	err = common.ReleaseHosts(ctx, r.Client, rc.Cluster)
+	if err != nil {
+		rc.Logger.Error(err, "failed release hosts")
+		return err
+	}
+
 	rc.Logger.Info("clean all manchine success, start clean cluster finalizers")
 	rc.Cluster.ObjectMeta.Finalizers = constants.RemoveString(rc.Cluster.ObjectMeta.Finalizers, constants.FinalizersCluster)
 	return r.Client.Update(ctx, rc.Cluster)
 }
+
+// claimHosts claims the hosts selected by the cluster and adds them to its machines, it reports
+// whether the machines are changed.
+func (r *clusterReconciler) claimHosts(ctx context.Context, c *devopsv1.Cluster) (bool, error) {
+	hosts, err := common.ClaimHosts(ctx, r.Client, r.Scheme, c, c.Spec.HostSelector, c.Spec.HostSelector.Count)
+	if err != nil {
+		return false, err
+	}
+
+	changed := false
+	for _, host := range hosts {
+		exist := false
+		for _, m := range c.Spec.Machines {
+			if m.IP == host.Spec.IP {
+				exist = true
+				break
+			}
+		}
+		if !exist {
+			m := common.HostMachine(host)
+			if host.Spec.Rack != "" {
+				m.HostCni, err = ipam.AllocateMachine(ctx, r.Client, r.Scheme, c, host.Spec.Rack, host.Spec.IP)
+				if err != nil {
+					return false, err
+				}
+			}
+			c.Spec.Machines = append(c.Spec.Machines, m)
+			changed = true
+		}
+	}
+	return changed, nil
+}
@@ -21,24 +21,29 @@ import (
 	"time"
 
 	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/constants"
 	"github.com/gostship/kunkka/pkg/controllers/common"
 	"github.com/gostship/kunkka/pkg/provider/cluster"
+	"github.com/gostship/kunkka/pkg/provider/condlog"
+	"github.com/gostship/kunkka/pkg/provider/plan"
+	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
 	clusterClientRetryCount    = 5
 	clusterClientRetryInterval = 5 * time.Second
 
-	reasonFailedInit   = "FailedInit"
-	reasonFailedUpdate = "FailedUpdate"
+	reasonFailedInit    = "FailedInit"
+	reasonFailedUpdate  = "FailedUpdate"
+	reasonFailedUpgrade = "FailedUpgrade"
 )
 
 func (r *clusterReconciler) applyStatus(ctx context.Context, rc *clusterContext, cluster *common.Cluster) error {
-	credential := &devopsv1.ClusterCredential{}
-	err := r.Client.Get(ctx, rc.Key, credential)
+	credential, err := common.GetClusterCredential(ctx, r.Client, rc.Key)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			rc.Logger.Error(err, "not find cluster credential")
@@ -57,7 +62,7 @@ func (r *clusterReconciler) applyStatus(ctx context.Context, rc *clusterContext,
 			return err
 		}
 		metaAccessor.SetResourceVersion(cluster.ClusterCredential, currentResourceVersion)
-		err = r.Client.Update(ctx, cluster.ClusterCredential)
+		err = common.UpdateClusterCredential(ctx, r.Client, cluster.ClusterCredential)
 		if err != nil {
 			rc.Logger.Error(err, "failed to update cluster credential")
 			return err
@@ -99,6 +104,7 @@ func (r *clusterReconciler) applyStatus(ctx context.Context, rc *clusterContext,
 }
 
 func (r *clusterReconciler) onCreate(ctx context.Context, rc *clusterContext, p cluster.Provider, clusterWrapper *common.Cluster) error {
+	ctx = condlog.WithRecorder(ctx, condlog.NewRecorder(r.Client, r.Scheme, rc.Cluster))
 	err := p.OnCreate(ctx, clusterWrapper)
 	if err != nil {
 		clusterWrapper.Cluster.Status.Message = err.Error()
@@ -117,7 +123,92 @@ func (r *clusterReconciler) onCreate(ctx context.Context, rc *clusterContext, p
 	return nil
 }
 
+// resetRetries resets the retries of the failed handlers, a Failed cluster goes back to
+// the phase it failed in.
+func (r *clusterReconciler) resetRetries(ctx context.Context, rc *clusterContext) error {
+	rc.Logger.Info("reset retries")
+	for i := range rc.Cluster.Status.Conditions {
+		condition := &rc.Cluster.Status.Conditions[i]
+		if condition.Status == devopsv1.ConditionFalse {
+			condition.Retries = 0
+		}
+	}
+	if rc.Cluster.Status.Phase == devopsv1.ClusterFailed {
+		// the status version is only behind the spec version during the upgrade
+		if len(rc.Cluster.Status.Version) > 0 && rc.Cluster.Status.Version != rc.Cluster.Spec.Version {
+			rc.Cluster.Status.Phase = devopsv1.ClusterUpgrading
+		} else {
+			rc.Cluster.Status.Phase = devopsv1.ClusterInitializing
+		}
+	}
+	err := r.Client.Status().Update(ctx, rc.Cluster)
+	if err != nil {
+		return err
+	}
+
+	obj := &devopsv1.Cluster{}
+	err = r.Client.Get(ctx, rc.Key, obj)
+	if err != nil {
+		return err
+	}
+	delete(obj.Annotations, constants.ClusterAnnotationRetry)
+	delete(obj.Annotations, constants.ClusterAnnotationRekeyHosts)
+	return r.Client.Update(ctx, obj)
+}
+
+// rekeyHosts drops the pinned host keys of the masters and the worker Machines of the
+// cluster, so the reinstalled ones are trusted on the next connection, then resets the
+// retries.
+func (r *clusterReconciler) rekeyHosts(ctx context.Context, rc *clusterContext) error {
+	hosts := append([]*devopsv1.ClusterMachine(nil), rc.Cluster.Spec.Machines...)
+	ms := &devopsv1.MachineList{}
+	if err := r.Client.List(ctx, ms, &client.ListOptions{Namespace: rc.Key.Namespace}); err != nil {
+		return errors.Wrapf(err, "list machines of cluster %s", rc.Key)
+	}
+	for i := range ms.Items {
+		m := &ms.Items[i]
+		if m.Spec.ClusterName == rc.Cluster.Name && m.Spec.Machine != nil {
+			hosts = append(hosts, m.Spec.Machine)
+		}
+	}
+
+	for _, m := range hosts {
+		rc.Logger.Info("rekey host", "node", m.IP)
+		if err := m.Rekey(); err != nil {
+			return errors.Wrapf(err, "rekey host %s", m.IP)
+		}
+	}
+	return r.resetRetries(ctx, rc)
+}
+
+// retryAfter returns when the failed handler of the cluster should run again.
+func (r *clusterReconciler) retryAfter(rc *clusterContext) time.Duration {
+	switch rc.Cluster.Status.Phase {
+	case devopsv1.ClusterInitializing, devopsv1.ClusterRunning, devopsv1.ClusterUpgrading:
+		return cluster.RetryAfter(rc.Cluster, &r.Cfg.Retry)
+	}
+	return 0
+}
+
+func (r *clusterReconciler) onPlan(ctx context.Context, rc *clusterContext, p cluster.Provider, clusterWrapper *common.Cluster) error {
+	old, err := plan.Load(ctx, r.Client, rc.Cluster.Namespace, rc.Cluster.Name)
+	if err != nil {
+		return err
+	}
+	if old != nil && old.Generation == rc.Cluster.Generation {
+		return nil
+	}
+
+	result, err := p.Plan(ctx, clusterWrapper)
+	if err != nil {
+		return errors.Wrapf(err, "plan cluster %s", rc.Cluster.Name)
+	}
+	rc.Logger.Info("save plan", "steps", len(result.Steps), "errors", len(result.Errors))
+	return plan.Save(ctx, r.Client, r.Scheme, rc.Cluster, result)
+}
+
 func (r *clusterReconciler) onUpdate(ctx context.Context, rc *clusterContext, p cluster.Provider, clusterWrapper *common.Cluster) error {
+	ctx = condlog.WithRecorder(ctx, condlog.NewRecorder(r.Client, r.Scheme, rc.Cluster))
 	err := p.OnUpdate(ctx, clusterWrapper)
 	if err != nil {
 		clusterWrapper.Cluster.Status.Message = err.Error()
@@ -129,3 +220,17 @@ func (r *clusterReconciler) onUpdate(ctx context.Context, rc *clusterContext, p
 
 	return nil
 }
+
+func (r *clusterReconciler) onUpgrade(ctx context.Context, rc *clusterContext, p cluster.Provider, clusterWrapper *common.Cluster) error {
+	// the failed upgrade step records its message and reason itself
+	clusterWrapper.Cluster.Status.Message = ""
+	clusterWrapper.Cluster.Status.Reason = ""
+	ctx = condlog.WithRecorder(ctx, condlog.NewRecorder(r.Client, r.Scheme, rc.Cluster))
+	err := p.OnUpgrade(ctx, clusterWrapper)
+	if err != nil {
+		clusterWrapper.Cluster.Status.Message = err.Error()
+		clusterWrapper.Cluster.Status.Reason = reasonFailedUpgrade
+	}
+
+	return nil
+}
@@ -0,0 +1,325 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/util/envelope"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// credentialKeysLabel marks the secrets of the key material of the cluster credentials
+	credentialKeysLabel = "devops.gostship.io/credential-keys"
+	// credentialKMSAnnotation is the kms provider sealing the key material
+	credentialKMSAnnotation = "devops.gostship.io/kms-provider"
+	// credentialDataKey is the sealed key material, credentialDEKKey is its encrypted data
+	// encryption key
+	credentialDataKey = "credential"
+	credentialDEKKey  = "dek"
+	// credentialBoundAnnotation marks the key material sealed with the namespace/name of the
+	// credential as additional data, so it is not opened once it is copied to another secret.
+	// The key material sealed before is opened without it and sealed again.
+	credentialBoundAnnotation = "devops.gostship.io/credential-bound"
+)
+
+var (
+	credentialEnvelopeLock sync.RWMutex
+	credentialEnvelope     *envelope.Envelope
+)
+
+// SetCredentialEnvelope sets the envelope sealing the key material of the cluster credentials,
+// it is kept unencrypted in the secrets without one.
+func SetCredentialEnvelope(e *envelope.Envelope) {
+	credentialEnvelopeLock.Lock()
+	defer credentialEnvelopeLock.Unlock()
+
+	credentialEnvelope = e
+}
+
+func getCredentialEnvelope() *envelope.Envelope {
+	credentialEnvelopeLock.RLock()
+	defer credentialEnvelopeLock.RUnlock()
+
+	return credentialEnvelope
+}
+
+// credentialKeys is the key material of a ClusterCredential, it is kept in a secret owned by
+// the credential instead of the credential itself.
+type credentialKeys struct {
+	ETCDCAKey        []byte            `json:"etcdCAKey,omitempty"`
+	ETCDAPIClientKey []byte            `json:"etcdAPIClientKey,omitempty"`
+	CAKey            []byte            `json:"caKey,omitempty"`
+	ClientKey        []byte            `json:"clientKey,omitempty"`
+	Token            *string           `json:"token,omitempty"`
+	BootstrapToken   *string           `json:"bootstrapToken,omitempty"`
+	CertificateKey   *string           `json:"certificateKey,omitempty"`
+	ExtData          map[string]string `json:"extData,omitempty"`
+	KubeData         map[string]string `json:"kubeData,omitempty"`
+	CertsBinaryData  map[string][]byte `json:"certsBinaryData,omitempty"`
+	CARotationKeys   map[string][]byte `json:"caRotationKeys,omitempty"`
+}
+
+// extractKeys moves the key material out of the credential info.
+func extractKeys(info *devopsv1.CredentialInfo) *credentialKeys {
+	keys := &credentialKeys{
+		ETCDCAKey:        info.ETCDCAKey,
+		ETCDAPIClientKey: info.ETCDAPIClientKey,
+		CAKey:            info.CAKey,
+		ClientKey:        info.ClientKey,
+		Token:            info.Token,
+		BootstrapToken:   info.BootstrapToken,
+		CertificateKey:   info.CertificateKey,
+		ExtData:          info.ExtData,
+		KubeData:         info.KubeData,
+		CertsBinaryData:  info.CertsBinaryData,
+	}
+	if info.CARotation != nil {
+		keys.CARotationKeys = info.CARotation.Keys
+		info.CARotation.Keys = nil
+	}
+	info.ETCDCAKey = nil
+	info.ETCDAPIClientKey = nil
+	info.CAKey = nil
+	info.ClientKey = nil
+	info.Token = nil
+	info.BootstrapToken = nil
+	info.CertificateKey = nil
+	info.ExtData = nil
+	info.KubeData = nil
+	info.CertsBinaryData = nil
+	return keys
+}
+
+// mergeKeys sets the key material on the credential info, the values kept in the info take
+// precedence, they are written by the ones not aware of the secret.
+func mergeKeys(info *devopsv1.CredentialInfo, keys *credentialKeys) {
+	if info.ETCDCAKey == nil {
+		info.ETCDCAKey = keys.ETCDCAKey
+	}
+	if info.ETCDAPIClientKey == nil {
+		info.ETCDAPIClientKey = keys.ETCDAPIClientKey
+	}
+	if info.CAKey == nil {
+		info.CAKey = keys.CAKey
+	}
+	if info.ClientKey == nil {
+		info.ClientKey = keys.ClientKey
+	}
+	if info.Token == nil {
+		info.Token = keys.Token
+	}
+	if info.BootstrapToken == nil {
+		info.BootstrapToken = keys.BootstrapToken
+	}
+	if info.CertificateKey == nil {
+		info.CertificateKey = keys.CertificateKey
+	}
+	if info.ExtData == nil {
+		info.ExtData = keys.ExtData
+	}
+	if info.KubeData == nil {
+		info.KubeData = keys.KubeData
+	}
+	if info.CertsBinaryData == nil {
+		info.CertsBinaryData = keys.CertsBinaryData
+	}
+	if info.CARotation != nil && info.CARotation.Keys == nil {
+		info.CARotation.Keys = keys.CARotationKeys
+	}
+}
+
+// CredentialSecretName returns the name of the secret of the key material of the credential.
+func CredentialSecretName(name string) string {
+	return fmt.Sprintf("%s-credential", name)
+}
+
+// GetClusterCredential returns the ClusterCredential with its key material read from its
+// secret, the key material still kept in the credential takes precedence. It never writes,
+// the key material is moved and sealed again by MigrateClusterCredential.
+func GetClusterCredential(ctx context.Context, cli client.Client, key types.NamespacedName) (*devopsv1.ClusterCredential, error) {
+	credential, _, _, err := getClusterCredential(ctx, cli, key)
+	return credential, err
+}
+
+// MigrateClusterCredential moves the key material still kept in the credential to its secret
+// and seals it again once the kms provider is changed or it is not bound to the credential
+// name yet, it is called by the cluster controller only, so it does not race with the readers.
+func MigrateClusterCredential(ctx context.Context, cli client.Client, key types.NamespacedName) error {
+	credential, secret, inline, err := getClusterCredential(ctx, cli, key)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	resealed := secret != nil && (sealedBy(secret) != getCredentialEnvelope().Provider() || !bound(secret))
+	if !inline && !resealed {
+		return nil
+	}
+
+	stripped := credential.DeepCopy()
+	keys := extractKeys(&stripped.CredentialInfo)
+
+	// the secret is written first, the credential keeps its key material until it is saved
+	klog.Infof("cluster credential: %s seals its key material to secret %s with %s", key.String(), CredentialSecretName(key.Name), getCredentialEnvelope().Provider())
+	if err := saveKeys(ctx, cli, credential, keys); err != nil {
+		return err
+	}
+	if inline {
+		return cli.Update(ctx, stripped)
+	}
+	return nil
+}
+
+// UpdateClusterCredential updates the credential without its key material and saves the key
+// material to its secret, the credential keeps the key material in memory. The credential is
+// written first, so a conflict leaves the secret untouched.
+func UpdateClusterCredential(ctx context.Context, cli client.Client, credential *devopsv1.ClusterCredential) error {
+	stripped := credential.DeepCopy()
+	keys := extractKeys(&stripped.CredentialInfo)
+	if err := cli.Update(ctx, stripped); err != nil {
+		return err
+	}
+	credential.ResourceVersion = stripped.ResourceVersion
+
+	return saveKeys(ctx, cli, credential, keys)
+}
+
+// getClusterCredential returns the credential with its key material, the secret it is read
+// from, nil if it is not created yet, and whether the credential still keeps key material.
+func getClusterCredential(ctx context.Context, cli client.Client, key types.NamespacedName) (*devopsv1.ClusterCredential, *corev1.Secret, bool, error) {
+	credential := &devopsv1.ClusterCredential{}
+	err := cli.Get(ctx, key, credential)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	inline := !reflect.DeepEqual(*extractKeys(credential.CredentialInfo.DeepCopy()), credentialKeys{})
+	secret := &corev1.Secret{}
+	err = cli.Get(ctx, types.NamespacedName{Namespace: key.Namespace, Name: CredentialSecretName(key.Name)}, secret)
+	if apierrors.IsNotFound(err) {
+		return credential, nil, inline, nil
+	}
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("get credential secret %s/%s err: %v", key.Namespace, CredentialSecretName(key.Name), err)
+	}
+
+	keys, err := openKeys(ctx, secret, key)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("open credential secret %s/%s err: %v", secret.Namespace, secret.Name, err)
+	}
+	mergeKeys(&credential.CredentialInfo, keys)
+	return credential, secret, inline, nil
+}
+
+func saveKeys(ctx context.Context, cli client.Client, credential *devopsv1.ClusterCredential, keys *credentialKeys) error {
+	data, err := json.Marshal(keys)
+	if err != nil {
+		return err
+	}
+	key := types.NamespacedName{Namespace: credential.Namespace, Name: credential.Name}
+	sealed, err := getCredentialEnvelope().Seal(ctx, data, credentialAAD(key))
+	if err != nil {
+		return err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        CredentialSecretName(credential.Name),
+			Namespace:   credential.Namespace,
+			Labels:      map[string]string{credentialKeysLabel: "true"},
+			Annotations: map[string]string{credentialKMSAnnotation: sealed.Provider, credentialBoundAnnotation: "true"},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(credential, devopsv1.GroupVersion.WithKind("ClusterCredential")),
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{credentialDataKey: sealed.Ciphertext},
+	}
+	if len(sealed.DEK) > 0 {
+		secret.Data[credentialDEKKey] = sealed.DEK
+	}
+
+	current := &corev1.Secret{}
+	err = cli.Get(ctx, types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name}, current)
+	if apierrors.IsNotFound(err) {
+		err = cli.Create(ctx, secret)
+		if err != nil {
+			return fmt.Errorf("create credential secret %s/%s err: %v", secret.Namespace, secret.Name, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get credential secret %s/%s err: %v", secret.Namespace, secret.Name, err)
+	}
+
+	// the key material is sealed again only once it is changed, so the secret is not
+	// rewritten by each reconcile
+	if current.Annotations[credentialKMSAnnotation] == sealed.Provider && bound(current) {
+		if old, err := openKeys(ctx, current, key); err == nil {
+			if oldData, err := json.Marshal(old); err == nil && bytes.Equal(oldData, data) {
+				return nil
+			}
+		}
+	}
+	current.Labels = secret.Labels
+	current.Annotations = secret.Annotations
+	current.OwnerReferences = secret.OwnerReferences
+	current.Data = secret.Data
+	if err := cli.Update(ctx, current); err != nil {
+		return fmt.Errorf("update credential secret %s/%s err: %v", secret.Namespace, secret.Name, err)
+	}
+	return nil
+}
+
+// sealedBy returns the provider sealing the key material of the secret.
+func sealedBy(secret *corev1.Secret) string {
+	provider := secret.Annotations[credentialKMSAnnotation]
+	if provider == "" {
+		return envelope.IdentityProvider
+	}
+	return provider
+}
+
+// bound reports whether the key material of the secret is sealed with the credential name.
+func bound(secret *corev1.Secret) bool {
+	return secret.Annotations[credentialBoundAnnotation] == "true"
+}
+
+// credentialAAD is the additional data the key material of the credential is sealed with.
+func credentialAAD(key types.NamespacedName) []byte {
+	return []byte(key.String())
+}
+
+// openKeys opens the key material of the secret of the credential of the key.
+func openKeys(ctx context.Context, secret *corev1.Secret, key types.NamespacedName) (*credentialKeys, error) {
+	var aad []byte
+	if bound(secret) {
+		aad = credentialAAD(key)
+	}
+	data, err := getCredentialEnvelope().Open(ctx, &envelope.Sealed{
+		Provider:   sealedBy(secret),
+		DEK:        secret.Data[credentialDEKKey],
+		Ciphertext: secret.Data[credentialDataKey],
+	}, aad)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := &credentialKeys{}
+	if err := json.Unmarshal(data, keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
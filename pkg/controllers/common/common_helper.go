@@ -11,6 +11,7 @@ import (
 	"github.com/gostship/kunkka/pkg/constants"
 	"github.com/gostship/kunkka/pkg/controllers/k8smanager"
 	"github.com/gostship/kunkka/pkg/util/k8sutil"
+	"github.com/gostship/kunkka/pkg/util/pkiutil"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
@@ -33,11 +34,14 @@ type Cluster struct {
 }
 
 func GetCluster(ctx context.Context, cli client.Client, cluster *devopsv1.Cluster, mgr *k8smanager.ClusterManager) (*Cluster, error) {
+	if err := CheckCredentialsRefs(cluster.Namespace, cluster.Spec.Machines...); err != nil {
+		return nil, err
+	}
+
 	result := new(Cluster)
 	result.Cluster = cluster
 
-	clusterCredential := &devopsv1.ClusterCredential{}
-	err := cli.Get(ctx, types.NamespacedName{Name: cluster.Name, Namespace: cluster.Namespace}, clusterCredential)
+	clusterCredential, err := GetClusterCredential(ctx, cli, types.NamespacedName{Name: cluster.Name, Namespace: cluster.Namespace})
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			klog.V(3).Infof("cluster: %s not find credential, start create ...", cluster.Name)
@@ -178,3 +182,16 @@ func (c *Cluster) IPs() []string {
 	}
 	return ips
 }
+
+// ReloadClient replaces the cached client of the cluster with the external kubeconfig of
+// the credential, the cached one keeps the old certificates once they are issued again.
+func (c *Cluster) ReloadClient() error {
+	kubeconfig, ok := c.ClusterCredential.ExtData[pkiutil.ExternalAdminKubeConfigFileName]
+	if !ok || c.ClusterManager == nil {
+		return nil
+	}
+
+	c.ClusterManager.Delete(c.Name)
+	_, err := c.ClusterManager.AddNewClusters(c.Name, kubeconfig)
+	return err
+}
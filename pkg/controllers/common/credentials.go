@@ -0,0 +1,160 @@
+package common
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/option"
+	"github.com/gostship/kunkka/pkg/util/ssh"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// credentialsLabel marks the secrets the inline credentials of the machines are moved to
+const credentialsLabel = "devops.gostship.io/ssh-credentials"
+
+var (
+	sharedCredentialsLock      sync.RWMutex
+	sharedCredentialsNamespace = "kunkka-system"
+)
+
+// SetSharedCredentialsNamespace sets the namespace of the credentials shared by the owners of
+// all the namespaces, e.g. the ones of the hosts of the inventory.
+func SetSharedCredentialsNamespace(namespace string) {
+	sharedCredentialsLock.Lock()
+	defer sharedCredentialsLock.Unlock()
+
+	sharedCredentialsNamespace = namespace
+}
+
+// CredentialsNamespaceAllowed reports whether the owner of the namespace may refer the secrets
+// of the ref namespace, which is its own namespace or the shared credentials namespace. The
+// cluster scoped owners, e.g. the hosts, only refer the shared credentials.
+func CredentialsNamespaceAllowed(namespace, refNamespace string) bool {
+	sharedCredentialsLock.RLock()
+	defer sharedCredentialsLock.RUnlock()
+
+	return refNamespace != "" && (refNamespace == namespace || refNamespace == sharedCredentialsNamespace)
+}
+
+// CheckCredentialsRefs returns the error of the first credentialsRef of the machines referring
+// a secret the owner of the namespace may not refer, so nobody can have the operator read the
+// secrets of the other namespaces and send them to a host of their own.
+func CheckCredentialsRefs(namespace string, machines ...*devopsv1.ClusterMachine) error {
+	for _, m := range machines {
+		if m == nil {
+			continue
+		}
+		for _, ref := range m.CredentialsRefs() {
+			if !CredentialsNamespaceAllowed(namespace, ref.Namespace) {
+				return fmt.Errorf("the credentialsRef %s/%s of %s is out of the namespace %q and the shared credentials namespace",
+					ref.Namespace, ref.Name, m.IP, namespace)
+			}
+		}
+	}
+	return nil
+}
+
+// OwnerObject is the Cluster or Machine owning the migrated credentials.
+type OwnerObject interface {
+	metav1.Object
+	runtime.Object
+}
+
+// SetupSSHCredentials sets where the ssh credentials and the pinned host keys of the machines
+// are read, every binary connecting to the machines calls it on start.
+func SetupSSHCredentials(kubeCli kubernetes.Interface, opt *option.SSHCredentialsOption) {
+	ssh.SetHostKeyStore(ssh.NewSecretHostKeyStore(kubeCli, opt.KnownHostsNamespace))
+	devopsv1.SetSecretGetter(NewSecretGetter(kubeCli))
+	SetSharedCredentialsNamespace(opt.CredentialsNamespace)
+}
+
+// NewSecretGetter returns the getter reading the secrets of the machine credentials.
+func NewSecretGetter(kubeCli kubernetes.Interface) devopsv1.SecretGetter {
+	return func(namespace, name string) (map[string][]byte, error) {
+		secret, err := kubeCli.CoreV1().Secrets(namespace).Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return secret.Data, nil
+	}
+}
+
+// MigrateCredentials moves the inline passwords and private keys of the machines and their
+// jump hosts into secrets owned by the owner, the machines point at them by credentialsRef
+// afterwards. The machines sharing a credential share the secret. It reports whether the
+// machines are changed, the caller should update the owner then.
+func MigrateCredentials(ctx context.Context, cli client.Client, scheme *runtime.Scheme, owner OwnerObject, machines ...*devopsv1.ClusterMachine) (bool, error) {
+	changed := false
+	for _, m := range machines {
+		if m == nil || !m.HasInlineCredentials() {
+			continue
+		}
+
+		if m.CredentialsRef == nil && (m.Password != "" || len(m.PrivateKey) > 0) {
+			ref, err := ensureCredentialsSecret(ctx, cli, scheme, owner, m.Password, m.PrivateKey, m.PassPhrase)
+			if err != nil {
+				return changed, errors.Wrapf(err, "migrate the credential of %s", m.IP)
+			}
+			m.CredentialsRef = ref
+		}
+		for i := range m.JumpHosts {
+			host := &m.JumpHosts[i]
+			if host.CredentialsRef != nil || (host.Password == "" && len(host.PrivateKey) == 0) {
+				continue
+			}
+			ref, err := ensureCredentialsSecret(ctx, cli, scheme, owner, host.Password, host.PrivateKey, host.PassPhrase)
+			if err != nil {
+				return changed, errors.Wrapf(err, "migrate the credential of the jump host %s", host.IP)
+			}
+			host.CredentialsRef = ref
+		}
+		m.RedactCredentials()
+		klog.Infof("owner: %s/%s moved the inline credential of %s to secrets", owner.GetNamespace(), owner.GetName(), m.IP)
+		changed = true
+	}
+	return changed, nil
+}
+
+func ensureCredentialsSecret(ctx context.Context, cli client.Client, scheme *runtime.Scheme, owner OwnerObject,
+	password string, privateKey, passPhrase []byte) (*devopsv1.CredentialsRef, error) {
+	data := map[string][]byte{}
+	if password != "" {
+		data[devopsv1.CredentialsPasswordKey] = []byte(password)
+	}
+	if len(privateKey) > 0 {
+		data[devopsv1.CredentialsPrivateKeyKey] = privateKey
+	}
+	if len(passPhrase) > 0 {
+		data[devopsv1.CredentialsPassPhraseKey] = passPhrase
+	}
+	sum := sha256.Sum256([]byte(password + "\x00" + string(privateKey) + "\x00" + string(passPhrase)))
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-ssh-%s", owner.GetName(), hex.EncodeToString(sum[:4])),
+			Namespace: owner.GetNamespace(),
+			Labels:    map[string]string{credentialsLabel: "true"},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: data,
+	}
+	if err := controllerutil.SetOwnerReference(owner, secret, scheme); err != nil {
+		return nil, err
+	}
+	if err := cli.Create(ctx, secret); err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, err
+	}
+
+	return &devopsv1.CredentialsRef{Name: secret.Name, Namespace: secret.Namespace}, nil
+}
@@ -0,0 +1,105 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestMigrateCredentials(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := devopsv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	cluster := &devopsv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "c1", Name: "c1", UID: "uid"},
+		Spec: devopsv1.ClusterSpec{
+			Machines: []*devopsv1.ClusterMachine{
+				{IP: "10.0.0.1", Username: "root", Password: "secret",
+					JumpHosts: []devopsv1.JumpHost{{IP: "10.0.1.1", PrivateKey: []byte("key")}}},
+				{IP: "10.0.0.2", Username: "root", Password: "secret"},
+				{IP: "10.0.0.3", Username: "root", CredentialsRef: &devopsv1.CredentialsRef{Name: "rack1", Namespace: "c1"}},
+			},
+		},
+	}
+	cli := fake.NewFakeClientWithScheme(scheme, cluster)
+	ctx := context.TODO()
+
+	changed, err := MigrateCredentials(ctx, cli, scheme, cluster, cluster.Spec.Machines...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Fatal("MigrateCredentials() of the inline credentials changes nothing")
+	}
+	m1, m2, m3 := cluster.Spec.Machines[0], cluster.Spec.Machines[1], cluster.Spec.Machines[2]
+	if m1.HasInlineCredentials() || m2.HasInlineCredentials() {
+		t.Errorf("the credentials are still inline: %+v, %+v", m1, m2)
+	}
+	if m1.CredentialsRef == nil || m2.CredentialsRef == nil || *m1.CredentialsRef != *m2.CredentialsRef {
+		t.Errorf("the machines with the same password refer %v and %v, want the same secret", m1.CredentialsRef, m2.CredentialsRef)
+	}
+	if m3.CredentialsRef.Name != "rack1" {
+		t.Errorf("the existing credentialsRef is changed to %v", m3.CredentialsRef)
+	}
+
+	secret := &corev1.Secret{}
+	if err := cli.Get(ctx, types.NamespacedName{Namespace: "c1", Name: m1.CredentialsRef.Name}, secret); err != nil {
+		t.Fatal(err)
+	}
+	if string(secret.Data[devopsv1.CredentialsPasswordKey]) != "secret" || len(secret.OwnerReferences) != 1 {
+		t.Errorf("secret = %+v", secret)
+	}
+	jump := m1.JumpHosts[0].CredentialsRef
+	if jump == nil || jump.Name == m1.CredentialsRef.Name {
+		t.Errorf("the jump host refers %v, want its own secret", jump)
+	}
+
+	if changed, err := MigrateCredentials(ctx, cli, scheme, cluster, cluster.Spec.Machines...); err != nil || changed {
+		t.Errorf("MigrateCredentials() of the migrated machines = %v, %v", changed, err)
+	}
+}
+
+func TestCheckCredentialsRefs(t *testing.T) {
+	ref := func(namespace string) *devopsv1.CredentialsRef {
+		return &devopsv1.CredentialsRef{Namespace: namespace, Name: "ssh"}
+	}
+	tests := []struct {
+		name      string
+		namespace string
+		machine   *devopsv1.ClusterMachine
+		wantErr   bool
+	}{
+		{name: "no ref", namespace: "c1", machine: &devopsv1.ClusterMachine{IP: "10.0.0.1", Password: "secret"}},
+		{name: "own namespace", namespace: "c1", machine: &devopsv1.ClusterMachine{IP: "10.0.0.1", CredentialsRef: ref("c1")}},
+		{name: "shared namespace", namespace: "c1", machine: &devopsv1.ClusterMachine{IP: "10.0.0.1", CredentialsRef: ref("kunkka-system")}},
+		{name: "other namespace", namespace: "c1", machine: &devopsv1.ClusterMachine{IP: "10.0.0.1", CredentialsRef: ref("c2")}, wantErr: true},
+		{name: "empty namespace", namespace: "c1", machine: &devopsv1.ClusterMachine{IP: "10.0.0.1", CredentialsRef: ref("")}, wantErr: true},
+		{
+			name:      "jump host of other namespace",
+			namespace: "c1",
+			machine: &devopsv1.ClusterMachine{IP: "10.0.0.1", CredentialsRef: ref("c1"),
+				JumpHosts: []devopsv1.JumpHost{{IP: "10.0.1.1", CredentialsRef: ref("kube-system")}}},
+			wantErr: true,
+		},
+		{name: "host of shared namespace", machine: &devopsv1.ClusterMachine{IP: "10.0.0.1", CredentialsRef: ref("kunkka-system")}},
+		{name: "host of other namespace", machine: &devopsv1.ClusterMachine{IP: "10.0.0.1", CredentialsRef: ref("c1")}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckCredentialsRefs(tt.namespace, tt.machine)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckCredentialsRefs() err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
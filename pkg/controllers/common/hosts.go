@@ -0,0 +1,116 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/reference"
+	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ClaimHosts claims count available hosts selected by the selector for the owner, the hosts
+// claimed by the owner already are counted first. The hosts are claimed one by one through the
+// update of their status, so a host taken by another owner at the same time is skipped. It
+// returns the hosts claimed with an error once there are not enough available hosts.
+func ClaimHosts(ctx context.Context, cli client.Client, scheme *runtime.Scheme, owner OwnerObject, selector *devopsv1.HostSelector, count int) ([]*devopsv1.Host, error) {
+	sel, err := metav1.LabelSelectorAsSelector(&selector.Selector)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid host selector")
+	}
+	ref, err := reference.GetReference(scheme, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &devopsv1.HostList{}
+	err = cli.List(ctx, list, client.MatchingLabelsSelector{Selector: sel})
+	if err != nil {
+		return nil, errors.Wrapf(err, "list hosts")
+	}
+	sort.Slice(list.Items, func(i, j int) bool { return list.Items[i].Name < list.Items[j].Name })
+
+	var claimed, available []*devopsv1.Host
+	for i := range list.Items {
+		host := &list.Items[i]
+		switch {
+		case host.Status.ClaimRef != nil && host.Status.ClaimRef.UID == owner.GetUID():
+			claimed = append(claimed, host)
+		case host.Status.ClaimRef == nil && host.Status.Phase == devopsv1.HostAvailable && !host.Spec.Maintenance &&
+			(selector.Rack == "" || host.Spec.Rack == selector.Rack):
+			available = append(available, host)
+		}
+	}
+
+	for _, host := range available {
+		if len(claimed) >= count {
+			break
+		}
+		host.Status.ClaimRef = ref
+		host.Status.Phase = devopsv1.HostProvisioning
+		host.Status.Reason = ""
+		host.Status.Message = ""
+		err := cli.Status().Update(ctx, host)
+		if err != nil {
+			if apierrors.IsConflict(err) {
+				continue
+			}
+			return claimed, errors.Wrapf(err, "claim host %s", host.Name)
+		}
+		klog.Infof("owner: %s/%s claims host %s (%s)", owner.GetNamespace(), owner.GetName(), host.Name, host.Spec.IP)
+		claimed = append(claimed, host)
+	}
+
+	if len(claimed) < count {
+		return claimed, fmt.Errorf("%d hosts are claimed, %d available hosts are required", len(claimed), count)
+	}
+	return claimed[:count], nil
+}
+
+// ReleaseHosts releases the hosts claimed by the owner, their facts are discovered again before
+// they are available.
+func ReleaseHosts(ctx context.Context, cli client.Client, owner metav1.Object) error {
+	list := &devopsv1.HostList{}
+	err := cli.List(ctx, list)
+	if err != nil {
+		return errors.Wrapf(err, "list hosts")
+	}
+
+	for i := range list.Items {
+		host := &list.Items[i]
+		if host.Status.ClaimRef == nil || host.Status.ClaimRef.UID != owner.GetUID() {
+			continue
+		}
+		host.Status.ClaimRef = nil
+		host.Status.Phase = devopsv1.HostDiscovering
+		host.Status.Reason = ""
+		host.Status.Message = ""
+		err := cli.Status().Update(ctx, host)
+		if err != nil {
+			return errors.Wrapf(err, "release host %s", host.Name)
+		}
+		klog.Infof("owner: %s/%s releases host %s (%s)", owner.GetNamespace(), owner.GetName(), host.Name, host.Spec.IP)
+	}
+	return nil
+}
+
+// HostMachine returns the machine of the host.
+func HostMachine(host *devopsv1.Host) *devopsv1.ClusterMachine {
+	m := &devopsv1.ClusterMachine{
+		IP:        host.Spec.IP,
+		Port:      host.Spec.Port,
+		Username:  host.Spec.Username,
+		JumpHosts: append([]devopsv1.JumpHost(nil), host.Spec.JumpHosts...),
+	}
+	if host.Spec.CredentialsRef != nil {
+		ref := *host.Spec.CredentialsRef
+		m.CredentialsRef = &ref
+	}
+	return m
+}
@@ -0,0 +1,87 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newHost(name, ip, rack string, phase devopsv1.HostPhase, labels map[string]string) *devopsv1.Host {
+	return &devopsv1.Host{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Spec: devopsv1.HostSpec{
+			IP:             ip,
+			Port:           22,
+			Rack:           rack,
+			CredentialsRef: &devopsv1.CredentialsRef{Namespace: "kunkka-system", Name: rack},
+		},
+		Status: devopsv1.HostStatus{Phase: phase},
+	}
+}
+
+func TestClaimHosts(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := devopsv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	gpu := map[string]string{"gpu": "true"}
+	m1 := &devopsv1.Machine{ObjectMeta: metav1.ObjectMeta{Namespace: "c1", Name: "m1", UID: "m1"}}
+	m2 := &devopsv1.Machine{ObjectMeta: metav1.ObjectMeta{Namespace: "c1", Name: "m2", UID: "m2"}}
+	cli := fake.NewFakeClientWithScheme(scheme,
+		newHost("h1", "10.0.0.1", "r1", devopsv1.HostAvailable, gpu),
+		newHost("h2", "10.0.0.2", "r2", devopsv1.HostAvailable, gpu),
+		newHost("h3", "10.0.0.3", "r1", devopsv1.HostFailed, gpu),
+		newHost("h4", "10.0.0.4", "r1", devopsv1.HostAvailable, nil),
+	)
+	ctx := context.TODO()
+	selector := &devopsv1.HostSelector{
+		Selector: metav1.LabelSelector{MatchLabels: gpu},
+		Rack:     "r1",
+	}
+
+	hosts, err := ClaimHosts(ctx, cli, scheme, m1, selector, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hosts) != 1 || hosts[0].Name != "h1" {
+		t.Fatalf("ClaimHosts() = %v, want h1", hosts)
+	}
+	host := &devopsv1.Host{}
+	if err := cli.Get(ctx, types.NamespacedName{Name: "h1"}, host); err != nil {
+		t.Fatal(err)
+	}
+	if host.Status.Phase != devopsv1.HostProvisioning || host.Status.ClaimRef == nil ||
+		host.Status.ClaimRef.Kind != "Machine" || host.Status.ClaimRef.UID != m1.UID {
+		t.Errorf("claimed host status = %+v", host.Status)
+	}
+	if m := HostMachine(host); m.IP != "10.0.0.1" || m.Port != 22 || m.CredentialsRef == nil || m.CredentialsRef.Name != "r1" {
+		t.Errorf("HostMachine() = %+v", m)
+	}
+
+	// the claim is kept by the next reconcile
+	hosts, err = ClaimHosts(ctx, cli, scheme, m1, selector, 1)
+	if err != nil || len(hosts) != 1 || hosts[0].Name != "h1" {
+		t.Errorf("ClaimHosts() again = %v, %v, want h1", hosts, err)
+	}
+
+	// h2 is in another rack, h3 failed and h4 is not selected
+	if _, err := ClaimHosts(ctx, cli, scheme, m2, selector, 1); err == nil {
+		t.Errorf("ClaimHosts() without available hosts returns no error")
+	}
+
+	if err := ReleaseHosts(ctx, cli, m1); err != nil {
+		t.Fatal(err)
+	}
+	host = &devopsv1.Host{}
+	if err := cli.Get(ctx, types.NamespacedName{Name: "h1"}, host); err != nil {
+		t.Fatal(err)
+	}
+	if host.Status.ClaimRef != nil || host.Status.Phase != devopsv1.HostDiscovering {
+		t.Errorf("released host status = %+v", host.Status)
+	}
+}
@@ -17,12 +17,26 @@ limitations under the License.
 package controllers
 
 import (
+	"net/http"
+	"time"
+
+	"github.com/gostship/kunkka/pkg/controllers/certificate"
 	"github.com/gostship/kunkka/pkg/controllers/cluster"
+	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/controllers/etcdbackup"
+	"github.com/gostship/kunkka/pkg/controllers/healthcheck"
+	"github.com/gostship/kunkka/pkg/controllers/host"
+	"github.com/gostship/kunkka/pkg/controllers/ipam"
 	"github.com/gostship/kunkka/pkg/controllers/k8smanager"
 	"github.com/gostship/kunkka/pkg/controllers/machine"
 	"github.com/gostship/kunkka/pkg/gmanager"
 	"github.com/gostship/kunkka/pkg/option"
 	"github.com/gostship/kunkka/pkg/provider"
+	"github.com/gostship/kunkka/pkg/provider/addons/helm"
+	"github.com/gostship/kunkka/pkg/provider/artifact"
+	"github.com/gostship/kunkka/pkg/provider/config"
+	"github.com/gostship/kunkka/pkg/util/ssh"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
@@ -42,11 +56,70 @@ func AddToManager(m manager.Manager, opt *option.ControllersManagerOption) error
 		AddToManagerWithProviderFuncs = append(AddToManagerWithProviderFuncs, machine.Add)
 	}
 
+	if opt.EnableEtcdBackup {
+		AddToManagerWithProviderFuncs = append(AddToManagerWithProviderFuncs, etcdbackup.Add)
+	}
+
+	if opt.EnableIPAM {
+		AddToManagerWithProviderFuncs = append(AddToManagerWithProviderFuncs, ipam.Add)
+	}
+
+	if opt.EnableCertificate {
+		AddToManagerWithProviderFuncs = append(AddToManagerWithProviderFuncs, certificate.Add)
+	}
+
+	if opt.EnableHealthCheck {
+		AddToManagerWithProviderFuncs = append(AddToManagerWithProviderFuncs, healthcheck.Add)
+	}
+
+	if opt.EnableHost {
+		AddToManagerWithProviderFuncs = append(AddToManagerWithProviderFuncs, host.Add)
+	}
+
 	pMgr, err := provider.NewProvider()
 	if err != nil {
 		klog.Errorf("NewProvider err: %v", err)
 		return err
 	}
+	// the providers share the config, so the policy applies to all of them
+	pMgr.Cfg.Retry = config.Retry{
+		Limit:     opt.RetryLimit,
+		BaseDelay: opt.RetryBaseDelay,
+		MaxDelay:  opt.RetryMaxDelay,
+	}
+	pMgr.Cfg.Artifact = config.Artifact{
+		URL: opt.ArtifactURL,
+	}
+	pMgr.Cfg.Certs = config.Certs{
+		CheckInterval: opt.CertCheckInterval,
+		RenewBefore:   opt.CertRenewBefore,
+	}
+	pMgr.Cfg.Drain = config.Drain{
+		Timeout:         opt.DrainTimeout,
+		Force:           opt.DrainForce,
+		DeleteLocalData: opt.DrainDeleteLocalData,
+	}
+	kubeCli, err := kubernetes.NewForConfig(m.GetConfig())
+	if err != nil {
+		return err
+	}
+	common.SetupSSHCredentials(kubeCli, opt.SSHCredentials)
+	credentialEnvelope, err := opt.CredentialKMS.Envelope()
+	if err != nil {
+		return err
+	}
+	common.SetCredentialEnvelope(credentialEnvelope)
+	ssh.ConfigurePool(ssh.PoolConfig{
+		KeepAlive:   opt.SSHKeepAlive,
+		IdleTimeout: opt.SSHIdleTimeout,
+		MaxSessions: opt.SSHMaxSessions,
+	})
+	helm.SetDefaultGetter(helm.NewGetter(&http.Client{Timeout: 60 * time.Second}, opt.ChartCacheDir, opt.ChartRoot))
+	if opt.ArtifactBindAddress != "" {
+		if err := m.Add(artifact.NewServer(opt.ArtifactDir, opt.ArtifactBindAddress)); err != nil {
+			return err
+		}
+	}
 
 	k8sMgr, _ := k8smanager.NewManager(k8smanager.MasterClient{
 		Manager: m,
@@ -0,0 +1,260 @@
+/*
+Copyright 2020 dke.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdbackup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/gmanager"
+	"github.com/gostship/kunkka/pkg/provider/etcdbackup"
+	"github.com/pkg/errors"
+	"github.com/robfig/cron/v3"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	reasonInvalidSchedule = "InvalidSchedule"
+	reasonInvalidStorage  = "InvalidStorage"
+	reasonSnapshotFailed  = "SnapshotFailed"
+	reasonPruneFailed     = "PruneFailed"
+
+	// waitClusterInterval is the interval to recheck a cluster not running
+	waitClusterInterval = 30 * time.Second
+	// oneShotRetryInterval is the interval to retry a failed backup without schedule
+	oneShotRetryInterval = 5 * time.Minute
+	// minScheduleInterval is the shortest interval of a @every schedule
+	minScheduleInterval = time.Minute
+)
+
+// backupReconciler reconciles a EtcdBackup object
+type backupReconciler struct {
+	client.Client
+	*gmanager.GManager
+	Log logr.Logger
+	Mgr manager.Manager
+}
+
+func addBackup(mgr manager.Manager, pMgr *gmanager.GManager) error {
+	reconciler := &backupReconciler{
+		Client:   mgr.GetClient(),
+		Mgr:      mgr,
+		Log:      ctrl.Log.WithName("controllers").WithName("etcdbackup"),
+		GManager: pMgr,
+	}
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&devopsv1.EtcdBackup{}).
+		Complete(reconciler)
+	if err != nil {
+		return errors.Wrapf(err, "unable to create etcdbackup controller")
+	}
+
+	return nil
+}
+
+// +kubebuilder:rbac:groups=devops.gostship.io,resources=etcdbackups,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=devops.gostship.io,resources=etcdbackups/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods/exec,verbs=create
+
+func (r *backupReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	logger := r.Log.WithValues("etcdbackup", req.NamespacedName.String())
+
+	b := &devopsv1.EtcdBackup{}
+	err := r.Client.Get(ctx, req.NamespacedName, b)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.V(4).Info("not find etcdbackup")
+			return reconcile.Result{}, nil
+		}
+
+		logger.Error(err, "failed to get etcdbackup")
+		return reconcile.Result{}, err
+	}
+
+	if !b.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	if b.Spec.Suspend {
+		if b.Status.Phase == devopsv1.EtcdBackupSuspended {
+			return reconcile.Result{}, nil
+		}
+		b.Status.Phase = devopsv1.EtcdBackupSuspended
+		return reconcile.Result{}, r.Client.Status().Update(ctx, b)
+	}
+
+	var schedule cron.Schedule
+	if b.Spec.Schedule != "" {
+		schedule, err = parseSchedule(b.Spec.Schedule)
+		if err != nil {
+			return reconcile.Result{}, r.failed(ctx, b, reasonInvalidSchedule, err)
+		}
+	}
+
+	now := time.Now()
+	if schedule == nil && b.Status.LastSuccessfulTime != nil {
+		// the backup without schedule is done
+		return reconcile.Result{}, nil
+	}
+	if schedule != nil {
+		last := b.CreationTimestamp.Time
+		if b.Status.LastScheduleTime != nil {
+			last = b.Status.LastScheduleTime.Time
+		}
+		if next := schedule.Next(last); next.After(now) {
+			return reconcile.Result{RequeueAfter: next.Sub(now)}, nil
+		}
+	}
+
+	cluster := &devopsv1.Cluster{}
+	err = r.Client.Get(ctx, types.NamespacedName{Namespace: b.Namespace, Name: b.Spec.ClusterName}, cluster)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("not find cluster", "cluster", b.Spec.ClusterName)
+			return reconcile.Result{RequeueAfter: waitClusterInterval}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	if cluster.Status.Phase != devopsv1.ClusterRunning {
+		logger.V(4).Info("cluster is not running", "phase", cluster.Status.Phase)
+		return reconcile.Result{RequeueAfter: waitClusterInterval}, nil
+	}
+
+	store, err := etcdbackup.NewStore(ctx, r.Client, b)
+	if err != nil {
+		return r.requeue(schedule, now), r.failed(ctx, b, reasonInvalidStorage, err)
+	}
+
+	b.Status.LastScheduleTime = &metav1.Time{Time: now}
+	snap, err := r.snapshot(ctx, cluster, store, etcdbackup.SnapshotName(cluster.Name, now))
+	if err != nil {
+		logger.Error(err, "failed to snapshot etcd")
+		return r.requeue(schedule, now), r.failed(ctx, b, reasonSnapshotFailed, err)
+	}
+	logger.Info("etcd snapshot is saved", "snapshot", snap.Name, "size", snap.Size)
+
+	b.Status.LastSuccessfulTime = &metav1.Time{Time: now}
+	b.Status.Phase = devopsv1.EtcdBackupActive
+	b.Status.Reason = ""
+	b.Status.Message = ""
+
+	deleted, err := etcdbackup.Prune(ctx, store, &b.Spec.Retention, now)
+	if err != nil {
+		logger.Error(err, "failed to prune snapshots")
+		b.Status.Reason = reasonPruneFailed
+		b.Status.Message = err.Error()
+	} else if len(deleted) > 0 {
+		logger.Info("pruned snapshots", "snapshots", deleted)
+	}
+
+	err = r.syncSnapshots(ctx, b, store, snap)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return r.requeue(schedule, now), r.Client.Status().Update(ctx, b)
+}
+
+func (r *backupReconciler) snapshot(ctx context.Context, cluster *devopsv1.Cluster, store etcdbackup.Store, name string) (*devopsv1.EtcdSnapshot, error) {
+	c, err := common.GetCluster(ctx, r.Client, cluster, r.ClusterManager)
+	if err != nil {
+		return nil, err
+	}
+
+	members, _, err := etcdbackup.Members(r.Mgr.GetConfig(), c)
+	if err != nil {
+		return nil, err
+	}
+
+	return etcdbackup.Snapshot(ctx, members, store, name)
+}
+
+// syncSnapshots lists the snapshots in the store, the checksums are kept from the status.
+func (r *backupReconciler) syncSnapshots(ctx context.Context, b *devopsv1.EtcdBackup, store etcdbackup.Store, snap *devopsv1.EtcdSnapshot) error {
+	known := make(map[string]devopsv1.EtcdSnapshot)
+	for _, s := range b.Status.Snapshots {
+		known[s.Name] = s
+	}
+	known[snap.Name] = *snap
+
+	objs, err := store.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	snapshots := make([]devopsv1.EtcdSnapshot, 0, len(objs))
+	for _, obj := range objs {
+		s, ok := known[obj.Name]
+		if !ok {
+			s = devopsv1.EtcdSnapshot{
+				Name:         obj.Name,
+				CreationTime: metav1.Time{Time: obj.ModTime},
+			}
+		}
+		s.Size = obj.Size
+		snapshots = append(snapshots, s)
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].CreationTime.Before(&snapshots[j].CreationTime)
+	})
+
+	b.Status.Snapshots = snapshots
+	return nil
+}
+
+func (r *backupReconciler) failed(ctx context.Context, b *devopsv1.EtcdBackup, reason string, err error) error {
+	b.Status.Phase = devopsv1.EtcdBackupFailed
+	b.Status.Reason = reason
+	b.Status.Message = err.Error()
+	return r.Client.Status().Update(ctx, b)
+}
+
+// requeue returns the result to run the next scheduled backup.
+func (r *backupReconciler) requeue(schedule cron.Schedule, now time.Time) reconcile.Result {
+	if schedule == nil {
+		return reconcile.Result{RequeueAfter: oneShotRetryInterval}
+	}
+
+	return reconcile.Result{RequeueAfter: schedule.Next(now).Sub(now)}
+}
+
+// parseSchedule parses a standard 5 fields cron expression (minute hour dom month dow),
+// the descriptors like @daily and @every <duration> are also supported.
+func parseSchedule(spec string) (cron.Schedule, error) {
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %v", spec, err)
+	}
+	if every, ok := schedule.(cron.ConstantDelaySchedule); ok && every.Delay < minScheduleInterval {
+		return nil, fmt.Errorf("invalid schedule %q: interval must be at least %v", spec, minScheduleInterval)
+	}
+	return schedule, nil
+}
@@ -0,0 +1,37 @@
+package etcdbackup
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSchedule(t *testing.T) {
+	base := time.Date(2020, 8, 14, 10, 30, 15, 0, time.UTC) // Friday
+	tests := []struct {
+		name    string
+		spec    string
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "daily", spec: "@daily", want: time.Date(2020, 8, 15, 0, 0, 0, 0, time.UTC)},
+		{name: "step hours", spec: "0 */6 * * *", want: time.Date(2020, 8, 14, 12, 0, 0, 0, time.UTC)},
+		{name: "weekday", spec: "0 2 * * 1", want: time.Date(2020, 8, 17, 2, 0, 0, 0, time.UTC)},
+		{name: "every duration", spec: "@every 1h", want: time.Date(2020, 8, 14, 11, 30, 15, 0, time.UTC)},
+		{name: "too short interval", spec: "@every 10s", wantErr: true},
+		{name: "bad fields", spec: "* * *", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := parseSchedule(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSchedule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := schedule.Next(base); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
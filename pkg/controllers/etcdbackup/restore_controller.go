@@ -0,0 +1,249 @@
+/*
+Copyright 2020 dke.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdbackup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/gmanager"
+	"github.com/gostship/kunkka/pkg/provider/etcdbackup"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	reasonInterrupted   = "Interrupted"
+	reasonInvalidSource = "InvalidSource"
+	reasonRestoreFailed = "RestoreFailed"
+)
+
+// restoreReconciler reconciles a EtcdRestore object
+type restoreReconciler struct {
+	client.Client
+	*gmanager.GManager
+	Log logr.Logger
+	Mgr manager.Manager
+}
+
+func addRestore(mgr manager.Manager, pMgr *gmanager.GManager) error {
+	reconciler := &restoreReconciler{
+		Client:   mgr.GetClient(),
+		Mgr:      mgr,
+		Log:      ctrl.Log.WithName("controllers").WithName("etcdrestore"),
+		GManager: pMgr,
+	}
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&devopsv1.EtcdRestore{}).
+		Complete(reconciler)
+	if err != nil {
+		return errors.Wrapf(err, "unable to create etcdrestore controller")
+	}
+
+	return nil
+}
+
+// +kubebuilder:rbac:groups=devops.gostship.io,resources=etcdrestores,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=devops.gostship.io,resources=etcdrestores/status,verbs=get;update;patch
+
+// Reconcile restores the etcd of the cluster once, a restore interrupted by a
+// controller restart is failed instead of retried, since the members may be
+// left in any state and need a manual check.
+func (r *restoreReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	logger := r.Log.WithValues("etcdrestore", req.NamespacedName.String())
+
+	er := &devopsv1.EtcdRestore{}
+	err := r.Client.Get(ctx, req.NamespacedName, er)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.V(4).Info("not find etcdrestore")
+			return reconcile.Result{}, nil
+		}
+
+		logger.Error(err, "failed to get etcdrestore")
+		return reconcile.Result{}, err
+	}
+
+	switch er.Status.Phase {
+	case devopsv1.EtcdRestoreCompleted, devopsv1.EtcdRestoreFailed:
+		return reconcile.Result{}, nil
+	case devopsv1.EtcdRestoreRunning:
+		return reconcile.Result{}, r.finish(ctx, er, reasonInterrupted, fmt.Errorf("restore is interrupted, check the etcd members manually"))
+	}
+
+	b := &devopsv1.EtcdBackup{}
+	err = r.Client.Get(ctx, types.NamespacedName{Namespace: er.Namespace, Name: er.Spec.BackupName}, b)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, r.finish(ctx, er, reasonInvalidSource, err)
+		}
+		return reconcile.Result{}, err
+	}
+
+	cluster := &devopsv1.Cluster{}
+	err = r.Client.Get(ctx, types.NamespacedName{Namespace: er.Namespace, Name: er.Spec.ClusterName}, cluster)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, r.finish(ctx, er, reasonInvalidSource, err)
+		}
+		return reconcile.Result{}, err
+	}
+	if cluster.Status.Phase != devopsv1.ClusterRunning {
+		logger.V(4).Info("cluster is not running", "phase", cluster.Status.Phase)
+		return reconcile.Result{RequeueAfter: waitClusterInterval}, nil
+	}
+
+	store, err := etcdbackup.NewStore(ctx, r.Client, b)
+	if err != nil {
+		return reconcile.Result{}, r.finish(ctx, er, reasonInvalidSource, err)
+	}
+	snapshot, checksum, err := findSnapshot(ctx, store, b, er.Spec.Snapshot)
+	if err != nil {
+		return reconcile.Result{}, r.finish(ctx, er, reasonInvalidSource, err)
+	}
+
+	er.Status.Phase = devopsv1.EtcdRestoreRunning
+	er.Status.Snapshot = snapshot
+	er.Status.StartTime = &metav1.Time{Time: time.Now()}
+	err = r.Client.Status().Update(ctx, er)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	logger.Info("start restore etcd", "cluster", cluster.Name, "snapshot", snapshot)
+	members, err := r.restore(ctx, cluster, store, snapshot, checksum)
+	er.Status.Members = members
+	if err != nil {
+		logger.Error(err, "failed to restore etcd")
+		return reconcile.Result{}, r.finish(ctx, er, reasonRestoreFailed, err)
+	}
+
+	logger.Info("etcd is restored", "cluster", cluster.Name, "snapshot", snapshot)
+	return reconcile.Result{}, r.finish(ctx, er, "", nil)
+}
+
+// restore pauses the cluster reconcile while the members are rebuilt.
+func (r *restoreReconciler) restore(ctx context.Context, cluster *devopsv1.Cluster, store etcdbackup.Store, snapshot, checksum string) ([]string, error) {
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}
+	pause := cluster.Spec.Pause
+	err := r.setPause(ctx, key, true)
+	if err != nil {
+		return nil, err
+	}
+	defer r.setPause(ctx, key, pause)
+
+	c, err := common.GetCluster(ctx, r.Client, cluster, r.ClusterManager)
+	if err != nil {
+		return nil, err
+	}
+
+	members, initialCluster, err := etcdbackup.Members(r.Mgr.GetConfig(), c)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, m := range members {
+		names = append(names, m.Name())
+	}
+
+	err = etcdbackup.Restore(ctx, members, initialCluster, store, snapshot, checksum)
+	if err != nil {
+		return names, err
+	}
+
+	return names, waitAPIServer(c)
+}
+
+func (r *restoreReconciler) setPause(ctx context.Context, key types.NamespacedName, pause bool) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cluster := &devopsv1.Cluster{}
+		err := r.Client.Get(ctx, key, cluster)
+		if err != nil {
+			return err
+		}
+		if cluster.Spec.Pause == pause {
+			return nil
+		}
+		cluster.Spec.Pause = pause
+		return r.Client.Update(ctx, cluster)
+	})
+}
+
+func (r *restoreReconciler) finish(ctx context.Context, er *devopsv1.EtcdRestore, reason string, err error) error {
+	er.Status.Phase = devopsv1.EtcdRestoreCompleted
+	er.Status.Reason = reason
+	er.Status.Message = ""
+	if err != nil {
+		er.Status.Phase = devopsv1.EtcdRestoreFailed
+		er.Status.Message = err.Error()
+	}
+	er.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+	return r.Client.Status().Update(ctx, er)
+}
+
+// findSnapshot returns the snapshot and its checksum, the newest one is used when the name is empty.
+func findSnapshot(ctx context.Context, store etcdbackup.Store, b *devopsv1.EtcdBackup, name string) (string, string, error) {
+	if name == "" {
+		objs, err := store.List(ctx)
+		if err != nil {
+			return "", "", err
+		}
+		if len(objs) == 0 {
+			return "", "", fmt.Errorf("no snapshot in backup %s", b.Name)
+		}
+		newest := objs[0]
+		for _, obj := range objs[1:] {
+			if obj.ModTime.After(newest.ModTime) {
+				newest = obj
+			}
+		}
+		name = newest.Name
+	}
+
+	for _, s := range b.Status.Snapshots {
+		if s.Name == name {
+			return name, s.SHA256, nil
+		}
+	}
+	return name, "", nil
+}
+
+func waitAPIServer(c *common.Cluster) error {
+	cli, err := c.Clientset()
+	if err != nil {
+		return err
+	}
+
+	return wait.PollImmediate(5*time.Second, 5*time.Minute, func() (bool, error) {
+		_, err := cli.Discovery().ServerVersion()
+		return err == nil, nil
+	})
+}
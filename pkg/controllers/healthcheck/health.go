@@ -0,0 +1,231 @@
+/*
+Copyright 2020 dke.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package healthcheck
+
+import (
+	"fmt"
+	"time"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	defaultConditionTimeout   = 5 * time.Minute
+	defaultHeartbeatTimeout   = 5 * time.Minute
+	defaultRemediationTimeout = 10 * time.Minute
+
+	reasonNodeNotFound       = "NodeNotFound"
+	reasonHeartbeatTimeout   = "HeartbeatTimeout"
+	reasonUnhealthyCondition = "UnhealthyCondition"
+	reasonProvisioning       = "Provisioning"
+)
+
+var (
+	defaultMaxUnhealthy = intstr.FromString("40%")
+
+	defaultRemediations = []devopsv1.RemediationType{
+		devopsv1.RemediationRestartKubelet,
+		devopsv1.RemediationReboot,
+		devopsv1.RemediationReprovision,
+	}
+)
+
+// healthPolicy is the spec of a MachineHealthCheck with the defaults set.
+type healthPolicy struct {
+	conditions         []devopsv1.UnhealthyCondition
+	heartbeatTimeout   time.Duration
+	remediations       []devopsv1.RemediationType
+	remediationTimeout time.Duration
+	maxUnhealthy       intstr.IntOrString
+}
+
+func newHealthPolicy(spec *devopsv1.MachineHealthCheckSpec) *healthPolicy {
+	p := &healthPolicy{
+		conditions:         spec.UnhealthyConditions,
+		heartbeatTimeout:   defaultHeartbeatTimeout,
+		remediations:       spec.Remediations,
+		remediationTimeout: defaultRemediationTimeout,
+		maxUnhealthy:       defaultMaxUnhealthy,
+	}
+	if len(p.conditions) == 0 {
+		timeout := metav1.Duration{Duration: defaultConditionTimeout}
+		p.conditions = []devopsv1.UnhealthyCondition{
+			{Type: corev1.NodeReady, Status: corev1.ConditionFalse, Timeout: timeout},
+			{Type: corev1.NodeReady, Status: corev1.ConditionUnknown, Timeout: timeout},
+			{Type: corev1.NodeDiskPressure, Status: corev1.ConditionTrue, Timeout: timeout},
+		}
+	}
+	if spec.HeartbeatTimeout != nil {
+		p.heartbeatTimeout = spec.HeartbeatTimeout.Duration
+	}
+	if len(p.remediations) == 0 {
+		p.remediations = defaultRemediations
+	}
+	if spec.RemediationTimeout != nil {
+		p.remediationTimeout = spec.RemediationTimeout.Duration
+	}
+	if spec.MaxUnhealthy != nil {
+		p.maxUnhealthy = *spec.MaxUnhealthy
+	}
+	return p
+}
+
+// failure is a failed check of a machine, the machine is unhealthy once it fails for timeout.
+type failure struct {
+	reason  string
+	message string
+	since   time.Time
+	timeout time.Duration
+}
+
+func (f *failure) expired(now time.Time) bool {
+	return !now.Before(f.since.Add(f.timeout))
+}
+
+// check checks the node of the machine and its lease, it returns the failure expiring first,
+// nil if the machine is healthy.
+func (p *healthPolicy) check(node *corev1.Node, lease *coordinationv1.Lease, prev *devopsv1.MachineHealthTarget, now time.Time) *failure {
+	if node == nil {
+		// the node has no time it is gone, the first check seeing it gone is kept instead
+		since := now
+		if prev != nil && prev.Reason == reasonNodeNotFound && prev.UnhealthySince != nil {
+			since = prev.UnhealthySince.Time
+		}
+		return &failure{
+			reason:  reasonNodeNotFound,
+			message: "node is not found",
+			since:   since,
+			timeout: p.heartbeatTimeout,
+		}
+	}
+
+	var first *failure
+	add := func(f *failure) {
+		if first == nil || f.since.Add(f.timeout).Before(first.since.Add(first.timeout)) {
+			first = f
+		}
+	}
+
+	if heartbeat := lastHeartbeat(node, lease); !heartbeat.IsZero() && now.Sub(heartbeat) > p.heartbeatTimeout {
+		add(&failure{
+			reason:  reasonHeartbeatTimeout,
+			message: fmt.Sprintf("kubelet heartbeat is missed since %s", heartbeat.Format(time.RFC3339)),
+			since:   heartbeat,
+			timeout: p.heartbeatTimeout,
+		})
+	}
+	for _, uc := range p.conditions {
+		for _, c := range node.Status.Conditions {
+			if c.Type != uc.Type || c.Status != uc.Status {
+				continue
+			}
+			add(&failure{
+				reason:  reasonUnhealthyCondition,
+				message: fmt.Sprintf("node condition %s is %s: %s", c.Type, c.Status, c.Message),
+				since:   c.LastTransitionTime.Time,
+				timeout: uc.Timeout.Duration,
+			})
+		}
+	}
+	return first
+}
+
+// lastHeartbeat returns the last heartbeat of the kubelet, the renew of its lease or the update of
+// its Ready condition.
+func lastHeartbeat(node *corev1.Node, lease *coordinationv1.Lease) time.Time {
+	var last time.Time
+	if lease != nil && lease.Spec.RenewTime != nil {
+		last = lease.Spec.RenewTime.Time
+	}
+	for _, c := range node.Status.Conditions {
+		if c.Type == corev1.NodeReady && c.LastHeartbeatTime.Time.After(last) {
+			last = c.LastHeartbeatTime.Time
+		}
+	}
+	return last
+}
+
+// target returns the target of the machine after the check, nil if the machine is healthy and
+// is not recovering from a remediation.
+func (p *healthPolicy) target(name string, f *failure, prev *devopsv1.MachineHealthTarget, now time.Time) *devopsv1.MachineHealthTarget {
+	t := &devopsv1.MachineHealthTarget{Machine: name}
+	if prev != nil {
+		t.Remediations = prev.Remediations
+		t.LastRemediation = prev.LastRemediation
+		t.LastRemediationTime = prev.LastRemediationTime
+	}
+
+	if f == nil {
+		if t.LastRemediationTime == nil || !now.Before(t.LastRemediationTime.Add(p.remediationTimeout)) {
+			return nil
+		}
+		t.Healthy = true
+		t.Reason = "Recovering"
+		t.Message = fmt.Sprintf("machine is healthy after %s", t.LastRemediation)
+		return t
+	}
+
+	since := metav1.NewTime(f.since)
+	t.Reason = f.reason
+	t.Message = f.message
+	t.UnhealthySince = &since
+	return t
+}
+
+// provisioning returns the target of a machine not running after a remediation, it is kept
+// until the machine runs again, nil if the machine is not remediated.
+func (p *healthPolicy) provisioning(name string, phase devopsv1.MachinePhase, prev *devopsv1.MachineHealthTarget) *devopsv1.MachineHealthTarget {
+	if prev == nil || prev.LastRemediationTime == nil {
+		return nil
+	}
+	t := prev.DeepCopy()
+	t.Machine = name
+	t.Healthy = false
+	t.Reason = reasonProvisioning
+	t.Message = fmt.Sprintf("machine is %s after %s", phase, t.LastRemediation)
+	return t
+}
+
+// remediating reports whether the target is not given time to recover from its last
+// remediation, it counts against MaxUnhealthy as the unhealthy ones.
+func (p *healthPolicy) remediating(t *devopsv1.MachineHealthTarget, now time.Time) bool {
+	if t == nil || t.LastRemediationTime == nil {
+		return false
+	}
+	return t.Reason == reasonProvisioning || now.Before(t.LastRemediationTime.Add(p.remediationTimeout))
+}
+
+// nextRemediation returns the remediation due for the unhealthy target, false if the last one is
+// given time to take effect or all of them are taken.
+func (p *healthPolicy) nextRemediation(t *devopsv1.MachineHealthTarget, now time.Time) (devopsv1.RemediationType, bool) {
+	if t.LastRemediationTime != nil && now.Before(t.LastRemediationTime.Add(p.remediationTimeout)) {
+		return "", false
+	}
+	if int(t.Remediations) >= len(p.remediations) {
+		return "", false
+	}
+	return p.remediations[t.Remediations], true
+}
+
+// allowed returns the count of the unhealthy machines allowed by MaxUnhealthy.
+func (p *healthPolicy) allowed(total int) (int, error) {
+	return intstr.GetValueFromIntOrPercent(&p.maxUnhealthy, total, true)
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2020 dke.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package healthcheck
+
+import (
+	"testing"
+	"time"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func newNode(now time.Time, conditions ...corev1.NodeCondition) *corev1.Node {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+	for _, c := range conditions {
+		if c.LastHeartbeatTime.IsZero() {
+			c.LastHeartbeatTime = metav1.NewTime(now)
+		}
+		node.Status.Conditions = append(node.Status.Conditions, c)
+	}
+	return node
+}
+
+func condition(t corev1.NodeConditionType, status corev1.ConditionStatus, since time.Time) corev1.NodeCondition {
+	return corev1.NodeCondition{Type: t, Status: status, LastTransitionTime: metav1.NewTime(since)}
+}
+
+func TestCheck(t *testing.T) {
+	now := time.Now()
+	p := newHealthPolicy(&devopsv1.MachineHealthCheckSpec{})
+	lost := metav1.NewTime(now.Add(-time.Hour))
+	seen := metav1.NewTime(now.Add(-10 * time.Minute))
+
+	tests := []struct {
+		name       string
+		node       *corev1.Node
+		lease      *coordinationv1.Lease
+		prev       *devopsv1.MachineHealthTarget
+		wantReason string
+		expired    bool
+	}{
+		{
+			name: "healthy",
+			node: newNode(now, condition(corev1.NodeReady, corev1.ConditionTrue, now.Add(-time.Hour))),
+		},
+		{
+			name:       "not ready for a minute",
+			node:       newNode(now, condition(corev1.NodeReady, corev1.ConditionFalse, now.Add(-time.Minute))),
+			wantReason: reasonUnhealthyCondition,
+		},
+		{
+			name:       "disk pressure for 10 minutes",
+			node:       newNode(now, condition(corev1.NodeReady, corev1.ConditionTrue, now.Add(-time.Hour)), condition(corev1.NodeDiskPressure, corev1.ConditionTrue, now.Add(-10*time.Minute))),
+			wantReason: reasonUnhealthyCondition,
+			expired:    true,
+		},
+		{
+			name: "heartbeat missed",
+			node: newNode(now, corev1.NodeCondition{
+				Type: corev1.NodeReady, Status: corev1.ConditionTrue,
+				LastHeartbeatTime: lost, LastTransitionTime: lost,
+			}),
+			wantReason: reasonHeartbeatTimeout,
+			expired:    true,
+		},
+		{
+			name: "heartbeat renewed by lease",
+			node: newNode(now, corev1.NodeCondition{
+				Type: corev1.NodeReady, Status: corev1.ConditionTrue,
+				LastHeartbeatTime: lost, LastTransitionTime: lost,
+			}),
+			lease: &coordinationv1.Lease{Spec: coordinationv1.LeaseSpec{RenewTime: &metav1.MicroTime{Time: now}}},
+		},
+		{
+			name:       "node gone",
+			wantReason: reasonNodeNotFound,
+		},
+		{
+			name:       "node gone for 10 minutes",
+			prev:       &devopsv1.MachineHealthTarget{Reason: reasonNodeNotFound, UnhealthySince: &seen},
+			wantReason: reasonNodeNotFound,
+			expired:    true,
+		},
+	}
+	for _, tt := range tests {
+		f := p.check(tt.node, tt.lease, tt.prev, now)
+		if tt.wantReason == "" {
+			if f != nil {
+				t.Errorf("%s: check() = %s, want healthy", tt.name, f.reason)
+			}
+			continue
+		}
+		if f == nil {
+			t.Errorf("%s: check() is healthy, want %s", tt.name, tt.wantReason)
+			continue
+		}
+		if f.reason != tt.wantReason || f.expired(now) != tt.expired {
+			t.Errorf("%s: check() = %s expired %v, want %s expired %v", tt.name, f.reason, f.expired(now), tt.wantReason, tt.expired)
+		}
+	}
+}
+
+func TestRemediations(t *testing.T) {
+	now := time.Now()
+	p := newHealthPolicy(&devopsv1.MachineHealthCheckSpec{})
+	f := &failure{reason: reasonUnhealthyCondition, since: now.Add(-time.Hour), timeout: time.Minute}
+
+	target := p.target("m1", f, nil, now)
+	for i, want := range defaultRemediations {
+		got, ok := p.nextRemediation(target, now)
+		if !ok || got != want {
+			t.Fatalf("remediation %d = %q %v, want %q", i, got, ok, want)
+		}
+		last := metav1.NewTime(now)
+		target.Remediations++
+		target.LastRemediation = got
+		target.LastRemediationTime = &last
+
+		if _, ok := p.nextRemediation(target, now.Add(time.Minute)); ok {
+			t.Fatalf("remediation %d is taken again before the remediation timeout", i)
+		}
+		now = now.Add(p.remediationTimeout)
+		target = p.target("m1", f, target, now)
+	}
+	if got, ok := p.nextRemediation(target, now); ok {
+		t.Errorf("remediation = %q after all of them are taken", got)
+	}
+
+	last := metav1.NewTime(now)
+	target.LastRemediationTime = &last
+	if got := p.target("m1", nil, target, now.Add(time.Minute)); got == nil || !got.Healthy {
+		t.Errorf("target() = %v, want recovering", got)
+	}
+	if got := p.target("m1", nil, target, now.Add(p.remediationTimeout)); got != nil {
+		t.Errorf("target() = %v, want dropped", got)
+	}
+	if !p.remediating(target, now.Add(time.Minute)) || p.remediating(target, now.Add(p.remediationTimeout)) {
+		t.Errorf("remediating() is not limited to the remediation timeout")
+	}
+
+	// a reprovisioned machine keeps its target until it runs again
+	got := p.provisioning("m1", devopsv1.MachineInitializing, target)
+	if got == nil || got.Healthy || got.Reason != reasonProvisioning || !p.remediating(got, now.Add(time.Hour)) {
+		t.Errorf("provisioning() = %v, want the target kept", got)
+	}
+	if got := p.provisioning("m2", devopsv1.MachineInitializing, nil); got != nil {
+		t.Errorf("provisioning() of a machine not remediated = %v", got)
+	}
+}
+
+func TestAllowed(t *testing.T) {
+	tests := []struct {
+		maxUnhealthy *intstr.IntOrString
+		total        int
+		want         int
+	}{
+		{total: 10, want: 4},
+		{total: 1, want: 1},
+		{maxUnhealthy: &intstr.IntOrString{Type: intstr.String, StrVal: "100%"}, total: 5, want: 5},
+		{maxUnhealthy: &intstr.IntOrString{Type: intstr.Int, IntVal: 2}, total: 20, want: 2},
+	}
+	for _, tt := range tests {
+		p := newHealthPolicy(&devopsv1.MachineHealthCheckSpec{MaxUnhealthy: tt.maxUnhealthy})
+		got, err := p.allowed(tt.total)
+		if err != nil {
+			t.Fatalf("allowed(%d) error = %v", tt.total, err)
+		}
+		if got != tt.want {
+			t.Errorf("allowed(%d) with %s = %d, want %d", tt.total, p.maxUnhealthy.String(), got, tt.want)
+		}
+	}
+}
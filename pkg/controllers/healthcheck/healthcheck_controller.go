@@ -0,0 +1,330 @@
+/*
+Copyright 2020 dke.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/constants"
+	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/controllers/k8smanager"
+	"github.com/gostship/kunkka/pkg/gmanager"
+	"github.com/gostship/kunkka/pkg/provider/condlog"
+	"github.com/gostship/kunkka/pkg/provider/config"
+	"github.com/gostship/kunkka/pkg/provider/phases/clean"
+	"github.com/gostship/kunkka/pkg/provider/phases/component"
+	"github.com/gostship/kunkka/pkg/provider/phases/drain"
+	sshutil "github.com/gostship/kunkka/pkg/util/ssh"
+	"github.com/pkg/errors"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// checkInterval is the interval the machines of a MachineHealthCheck are checked
+	checkInterval = 30 * time.Second
+
+	reasonTooManyUnhealthy  = "TooManyUnhealthy"
+	reasonClusterNotFound   = "ClusterNotFound"
+	reasonRemediationFailed = "RemediationFailed"
+)
+
+// healthCheckReconciler checks the nodes of the running machines and remediates the unhealthy ones
+type healthCheckReconciler struct {
+	client.Client
+	*gmanager.GManager
+	Log logr.Logger
+	Mgr manager.Manager
+}
+
+func Add(mgr manager.Manager, pMgr *gmanager.GManager) error {
+	reconciler := &healthCheckReconciler{
+		Client:   mgr.GetClient(),
+		Mgr:      mgr,
+		Log:      ctrl.Log.WithName("controllers").WithName("machinehealthcheck"),
+		GManager: pMgr,
+	}
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&devopsv1.MachineHealthCheck{}).
+		Complete(reconciler)
+	if err != nil {
+		return errors.Wrapf(err, "unable to create machinehealthcheck controller")
+	}
+
+	return nil
+}
+
+// +kubebuilder:rbac:groups=devops.gostship.io,resources=machinehealthchecks,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=devops.gostship.io,resources=machinehealthchecks/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=devops.gostship.io,resources=machines,verbs=get;list;watch
+// +kubebuilder:rbac:groups=devops.gostship.io,resources=machines/status,verbs=get;update;patch
+
+func (r *healthCheckReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	logger := r.Log.WithValues("machinehealthcheck", req.NamespacedName.String())
+
+	mhc := &devopsv1.MachineHealthCheck{}
+	err := r.Client.Get(ctx, req.NamespacedName, mhc)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+
+		logger.Error(err, "failed to get machinehealthcheck")
+		return reconcile.Result{}, err
+	}
+	if !mhc.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	now := time.Now()
+	checkTime := metav1.NewTime(now)
+	mhc.Status.LastCheckTime = &checkTime
+	mhc.Status.Reason = ""
+	mhc.Status.Message = ""
+
+	// the nodes are read from the cache of the cluster, a cluster not reachable is not
+	// checked, so its machines are not taken as unhealthy
+	clusterCtx, err := r.ClusterManager.Get(mhc.Spec.ClusterName)
+	if err != nil {
+		mhc.Status.Reason = reasonClusterNotFound
+		mhc.Status.Message = err.Error()
+		return reconcile.Result{RequeueAfter: checkInterval}, r.Client.Status().Update(ctx, mhc)
+	}
+
+	machines, err := r.selectMachines(ctx, mhc)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	policy := newHealthPolicy(&mhc.Spec)
+	prevs := make(map[string]*devopsv1.MachineHealthTarget, len(mhc.Status.Targets))
+	for i := range mhc.Status.Targets {
+		prevs[mhc.Status.Targets[i].Machine] = &mhc.Status.Targets[i]
+	}
+
+	var targets []devopsv1.MachineHealthTarget
+	var unhealthy []*devopsv1.Machine
+	// remediating are the machines not unhealthy now but still recovering from a remediation,
+	// provisioning are the ones not running after it, both count against MaxUnhealthy
+	total, remediating, provisioning := 0, 0, 0
+	for i := range machines {
+		m := &machines[i]
+		prev := prevs[m.Name]
+		// the machines being created are checked once they are running
+		if m.Status.Phase != devopsv1.MachineRunning {
+			if t := policy.provisioning(m.Name, m.Status.Phase, prev); t != nil {
+				targets = append(targets, *t)
+				total++
+				provisioning++
+			}
+			continue
+		}
+		total++
+
+		node, lease, err := getNode(ctx, clusterCtx, m.Name)
+		if err != nil {
+			logger.Error(err, "failed to get node", "machine", m.Name)
+			return reconcile.Result{}, err
+		}
+
+		f := policy.check(node, lease, prev, now)
+		t := policy.target(m.Name, f, prev, now)
+		if t != nil {
+			targets = append(targets, *t)
+		}
+		if f != nil && f.expired(now) {
+			unhealthy = append(unhealthy, m)
+		} else if policy.remediating(t, now) {
+			remediating++
+		}
+	}
+
+	allowed, err := policy.allowed(total)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "invalid maxUnhealthy %s", policy.maxUnhealthy.String())
+	}
+	pending := len(unhealthy) + remediating + provisioning
+	mhc.Status.ExpectedMachines = int32(total)
+	mhc.Status.CurrentHealthy = int32(total - len(unhealthy) - provisioning)
+	mhc.Status.RemediationsAllowed = int32(allowed - pending)
+	if pending > allowed {
+		mhc.Status.RemediationsAllowed = 0
+		mhc.Status.Reason = reasonTooManyUnhealthy
+		mhc.Status.Message = fmt.Sprintf("%d of %d machines are unhealthy or being remediated, above the max unhealthy %s, no machine is remediated",
+			pending, total, policy.maxUnhealthy.String())
+		logger.Info("too many unhealthy machines, stop remediation", "unhealthy", len(unhealthy), "remediating", remediating+provisioning, "total", total)
+	} else {
+		for _, m := range unhealthy {
+			t := findTarget(targets, m.Name)
+			remediation, ok := policy.nextRemediation(t, now)
+			if !ok {
+				if int(t.Remediations) >= len(policy.remediations) {
+					t.Message = fmt.Sprintf("%s, all the remediations are taken", t.Message)
+				}
+				continue
+			}
+
+			logger.Info("remediate unhealthy machine", "machine", m.Name, "remediation", remediation, "reason", t.Reason, "message", t.Message)
+			remediationTime := metav1.NewTime(now)
+			t.Remediations++
+			t.LastRemediation = remediation
+			t.LastRemediationTime = &remediationTime
+			if err := r.remediate(ctx, clusterCtx, m, remediation); err != nil {
+				logger.Error(err, "failed to remediate machine", "machine", m.Name, "remediation", remediation)
+				t.Reason = reasonRemediationFailed
+				t.Message = err.Error()
+			}
+		}
+	}
+
+	mhc.Status.Targets = targets
+	err = r.Client.Status().Update(ctx, mhc)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: checkInterval}, nil
+}
+
+// selectMachines returns the machines of the cluster selected by the health check.
+func (r *healthCheckReconciler) selectMachines(ctx context.Context, mhc *devopsv1.MachineHealthCheck) ([]devopsv1.Machine, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&mhc.Spec.Selector)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid selector")
+	}
+
+	list := &devopsv1.MachineList{}
+	err = r.Client.List(ctx, list, client.InNamespace(mhc.Namespace), client.MatchingLabelsSelector{Selector: selector})
+	if err != nil {
+		return nil, errors.Wrapf(err, "list machines")
+	}
+
+	machines := make([]devopsv1.Machine, 0, len(list.Items))
+	for _, m := range list.Items {
+		if m.Spec.ClusterName != mhc.Spec.ClusterName || m.Spec.Pause || !m.DeletionTimestamp.IsZero() {
+			continue
+		}
+		machines = append(machines, m)
+	}
+	return machines, nil
+}
+
+// getNode returns the node of the machine and its lease from the cache of the cluster, nil if
+// they are not found.
+func getNode(ctx context.Context, clusterCtx *k8smanager.Cluster, name string) (*corev1.Node, *coordinationv1.Lease, error) {
+	node := &corev1.Node{}
+	err := clusterCtx.Client.Get(ctx, types.NamespacedName{Name: name}, node)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	lease := &coordinationv1.Lease{}
+	err = clusterCtx.Client.Get(ctx, types.NamespacedName{Namespace: corev1.NamespaceNodeLease, Name: name}, lease)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return node, nil, nil
+		}
+		return nil, nil, err
+	}
+	return node, lease, nil
+}
+
+func (r *healthCheckReconciler) remediate(ctx context.Context, clusterCtx *k8smanager.Cluster, m *devopsv1.Machine, remediation devopsv1.RemediationType) error {
+	if err := common.CheckCredentialsRefs(m.Namespace, m.Spec.Machine); err != nil {
+		return err
+	}
+	ssh, err := condlog.SSH(ctx, m.Spec.Machine)
+	if err != nil {
+		return err
+	}
+
+	switch remediation {
+	case devopsv1.RemediationRestartKubelet:
+		return component.RestartKubelet(ssh)
+	case devopsv1.RemediationReboot:
+		return component.Reboot(ssh)
+	case devopsv1.RemediationReprovision:
+		return r.reprovision(ctx, clusterCtx, m, ssh)
+	default:
+		return fmt.Errorf("unknown remediation %q", remediation)
+	}
+}
+
+// reprovision drains the node of the machine like the deletion of the machine does, cleans the
+// machine and deletes its node, then resets the machine to run its create handlers again. The
+// machine is not cleaned while its pods are blocked by the disruption budgets past the drain
+// timeout, unless the drain is forced.
+func (r *healthCheckReconciler) reprovision(ctx context.Context, clusterCtx *k8smanager.Cluster, m *devopsv1.Machine, ssh sshutil.Interface) error {
+	cluster := &devopsv1.Cluster{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: m.Spec.ClusterName, Namespace: m.Namespace}, cluster)
+	if err != nil {
+		return err
+	}
+
+	opts := drain.Options{
+		Timeout:         r.Cfg.Drain.Timeout,
+		Force:           r.Cfg.Drain.Force || m.Annotations[constants.MachineAnnotationForceDrain] == "true",
+		DeleteLocalData: r.Cfg.Drain.DeleteLocalData,
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = config.DefaultDrain.Timeout
+	}
+	err = drain.CordonAndDrain(ctx, clusterCtx.KubeCli, m.Name, opts)
+	if err != nil {
+		return err
+	}
+
+	err = clean.CleanNode(ssh, m.Spec.GetContainerRuntime(&cluster.Spec))
+	if err != nil {
+		return err
+	}
+
+	err = clusterCtx.KubeCli.CoreV1().Nodes().Delete(ctx, m.Name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "delete node %s", m.Name)
+	}
+
+	m.Status.Phase = devopsv1.MachineInitializing
+	m.Status.Conditions = nil
+	m.Status.Reason = ""
+	m.Status.Message = ""
+	return r.Client.Status().Update(ctx, m)
+}
+
+func findTarget(targets []devopsv1.MachineHealthTarget, name string) *devopsv1.MachineHealthTarget {
+	for i := range targets {
+		if targets[i].Machine == name {
+			return &targets[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2020 dke.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package host
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/gmanager"
+	"github.com/gostship/kunkka/pkg/provider/phases/hostos"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// rediscoverInterval is the interval the facts of the available hosts are discovered again
+	rediscoverInterval = time.Hour
+	// retryInterval is the interval the discovery of a failed host is tried again
+	retryInterval = 5 * time.Minute
+	// claimSyncInterval is the interval the phase of a claimed host follows its owner
+	claimSyncInterval = time.Minute
+
+	reasonDiscoveryFailed = "DiscoveryFailed"
+)
+
+// hostReconciler discovers the facts of the hosts of the inventory and keeps their phases
+type hostReconciler struct {
+	client.Client
+	*gmanager.GManager
+	Log logr.Logger
+	Mgr manager.Manager
+}
+
+func Add(mgr manager.Manager, pMgr *gmanager.GManager) error {
+	reconciler := &hostReconciler{
+		Client:   mgr.GetClient(),
+		Mgr:      mgr,
+		Log:      ctrl.Log.WithName("controllers").WithName("host"),
+		GManager: pMgr,
+	}
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&devopsv1.Host{}).
+		Complete(reconciler)
+	if err != nil {
+		return errors.Wrapf(err, "unable to create host controller")
+	}
+
+	return nil
+}
+
+// +kubebuilder:rbac:groups=devops.gostship.io,resources=hosts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=devops.gostship.io,resources=hosts/status,verbs=get;update;patch
+
+func (r *hostReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	logger := r.Log.WithValues("host", req.Name)
+
+	host := &devopsv1.Host{}
+	err := r.Client.Get(ctx, req.NamespacedName, host)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+
+		logger.Error(err, "failed to get host")
+		return reconcile.Result{}, err
+	}
+	if !host.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	if host.Status.ClaimRef != nil {
+		return r.syncClaim(ctx, logger, host)
+	}
+
+	switch {
+	case host.Spec.Maintenance:
+		if host.Status.Phase == devopsv1.HostMaintenance {
+			return reconcile.Result{}, nil
+		}
+		logger.Info("host enters maintenance")
+		return reconcile.Result{}, r.setPhase(ctx, host, devopsv1.HostMaintenance)
+	case host.Status.Phase == "" || host.Status.Phase == devopsv1.HostMaintenance:
+		return reconcile.Result{}, r.setPhase(ctx, host, devopsv1.HostDiscovering)
+	}
+
+	now := time.Now()
+	if host.Status.LastDiscoveryTime != nil {
+		var next time.Time
+		switch host.Status.Phase {
+		case devopsv1.HostAvailable:
+			next = host.Status.LastDiscoveryTime.Add(rediscoverInterval)
+		case devopsv1.HostFailed:
+			next = host.Status.LastDiscoveryTime.Add(retryInterval)
+		}
+		if next.After(now) {
+			return reconcile.Result{RequeueAfter: next.Sub(now)}, nil
+		}
+	}
+
+	logger.Info("start discover host", "ip", host.Spec.IP)
+	discoveryTime := metav1.NewTime(now)
+	host.Status.LastDiscoveryTime = &discoveryTime
+	err = r.discover(host)
+	if err != nil {
+		logger.Error(err, "failed to discover host")
+		host.Status.Phase = devopsv1.HostFailed
+		host.Status.Reason = reasonDiscoveryFailed
+		host.Status.Message = err.Error()
+	} else {
+		host.Status.Phase = devopsv1.HostAvailable
+		host.Status.Reason = ""
+		host.Status.Message = ""
+	}
+	err = r.Client.Status().Update(ctx, host)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if host.Status.Phase == devopsv1.HostFailed {
+		return reconcile.Result{RequeueAfter: retryInterval}, nil
+	}
+	return reconcile.Result{RequeueAfter: rediscoverInterval}, nil
+}
+
+func (r *hostReconciler) discover(host *devopsv1.Host) error {
+	m := common.HostMachine(host)
+	// the hosts are cluster scoped and only refer the shared credentials
+	if err := common.CheckCredentialsRefs("", m); err != nil {
+		return err
+	}
+	ssh, err := m.SSH()
+	if err != nil {
+		return err
+	}
+	return hostos.Discover(ssh, &host.Status)
+}
+
+// syncClaim keeps the phase of the claimed host with the phase of the cluster or machine
+// claiming it, the host is released once its owner is gone.
+func (r *hostReconciler) syncClaim(ctx context.Context, logger logr.Logger, host *devopsv1.Host) (ctrl.Result, error) {
+	ref := host.Status.ClaimRef
+	key := types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}
+
+	var owner metav1.Object
+	var phase devopsv1.HostPhase
+	switch ref.Kind {
+	case "Machine":
+		m := &devopsv1.Machine{}
+		owner = m
+		err := r.Client.Get(ctx, key, m)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return reconcile.Result{}, err
+		}
+		phase = hostPhase(m.Status.Phase == devopsv1.MachineRunning, m.Status.Phase == devopsv1.MachineFailed)
+	case "Cluster":
+		c := &devopsv1.Cluster{}
+		owner = c
+		err := r.Client.Get(ctx, key, c)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return reconcile.Result{}, err
+		}
+		phase = hostPhase(c.Status.Phase == devopsv1.ClusterRunning, c.Status.Phase == devopsv1.ClusterFailed)
+	default:
+		logger.Info("unknown claim kind, release host", "kind", ref.Kind)
+	}
+
+	if owner == nil || owner.GetUID() != ref.UID {
+		logger.Info("owner is gone, release host", "kind", ref.Kind, "owner", key.String())
+		host.Status.ClaimRef = nil
+		return reconcile.Result{}, r.setPhase(ctx, host, devopsv1.HostDiscovering)
+	}
+	if host.Status.Phase != phase {
+		err := r.setPhase(ctx, host, phase)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{RequeueAfter: claimSyncInterval}, nil
+}
+
+func hostPhase(running, failed bool) devopsv1.HostPhase {
+	switch {
+	case running:
+		return devopsv1.HostClaimed
+	case failed:
+		return devopsv1.HostFailed
+	default:
+		return devopsv1.HostProvisioning
+	}
+}
+
+func (r *hostReconciler) setPhase(ctx context.Context, host *devopsv1.Host, phase devopsv1.HostPhase) error {
+	host.Status.Phase = phase
+	host.Status.Reason = ""
+	host.Status.Message = ""
+	return r.Client.Status().Update(ctx, host)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2020 dke.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ipam
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/constants"
+	"github.com/gostship/kunkka/pkg/gmanager"
+	"github.com/gostship/kunkka/pkg/provider/ipam"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// claimReconciler releases the addresses of the deleted IPClaims
+type claimReconciler struct {
+	client.Client
+	*gmanager.GManager
+	Log logr.Logger
+	Mgr manager.Manager
+}
+
+func addClaim(mgr manager.Manager, pMgr *gmanager.GManager) error {
+	reconciler := &claimReconciler{
+		Client:   mgr.GetClient(),
+		Mgr:      mgr,
+		Log:      ctrl.Log.WithName("controllers").WithName("ipclaim"),
+		GManager: pMgr,
+	}
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&devopsv1.IPClaim{}).
+		Complete(reconciler)
+	if err != nil {
+		return errors.Wrapf(err, "unable to create ipclaim controller")
+	}
+
+	return nil
+}
+
+// +kubebuilder:rbac:groups=devops.gostship.io,resources=ipclaims,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=devops.gostship.io,resources=ippools,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=devops.gostship.io,resources=ippools/status,verbs=get;update;patch
+
+func (r *claimReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	logger := r.Log.WithValues("ipclaim", req.NamespacedName.String())
+
+	claim := &devopsv1.IPClaim{}
+	err := r.Client.Get(ctx, req.NamespacedName, claim)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.V(4).Info("not find ipclaim")
+			return reconcile.Result{}, nil
+		}
+
+		logger.Error(err, "failed to get ipclaim")
+		return reconcile.Result{}, err
+	}
+
+	if claim.DeletionTimestamp.IsZero() {
+		if constants.ContainsString(claim.Finalizers, constants.FinalizersIPClaim) {
+			return reconcile.Result{}, nil
+		}
+		logger.V(4).Info("start set", "finalizers", constants.FinalizersIPClaim)
+		claim.Finalizers = append(claim.Finalizers, constants.FinalizersIPClaim)
+		return reconcile.Result{}, r.Client.Update(ctx, claim)
+	}
+
+	if !constants.ContainsString(claim.Finalizers, constants.FinalizersIPClaim) {
+		return reconcile.Result{}, nil
+	}
+
+	err = ipam.Release(ctx, r.Client, claim.Spec.Pool, claim.Spec.Offset)
+	if err != nil {
+		logger.Error(err, "failed to release", "pool", claim.Spec.Pool, "offset", claim.Spec.Offset)
+		return reconcile.Result{}, err
+	}
+	logger.Info("released", "pool", claim.Spec.Pool, "rangeStart", claim.Spec.RangeStart, "rangeEnd", claim.Spec.RangeEnd)
+
+	claim.Finalizers = constants.RemoveString(claim.Finalizers, constants.FinalizersIPClaim)
+	return reconcile.Result{}, r.Client.Update(ctx, claim)
+}
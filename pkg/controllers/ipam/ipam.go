@@ -0,0 +1,32 @@
+/*
+Copyright 2020 dke.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ipam
+
+import (
+	"github.com/gostship/kunkka/pkg/gmanager"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// Add adds the IPClaim and IPPool controllers to the manager.
+func Add(mgr manager.Manager, pMgr *gmanager.GManager) error {
+	err := addClaim(mgr, pMgr)
+	if err != nil {
+		return err
+	}
+
+	return addPool(mgr, pMgr)
+}
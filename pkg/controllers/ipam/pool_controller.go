@@ -0,0 +1,121 @@
+/*
+Copyright 2020 dke.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ipam
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/gmanager"
+	"github.com/gostship/kunkka/pkg/provider/ipam"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// repairInterval is the interval to repair the pools, a block allocated without claim
+// is released once it has been seen unclaimed for longer, it must be longer than the
+// creation of the claim.
+const repairInterval = 5 * time.Minute
+
+// poolReconciler repairs the bitmap of the IPPools with their claims
+type poolReconciler struct {
+	client.Client
+	*gmanager.GManager
+	Log logr.Logger
+	Mgr manager.Manager
+
+	mu sync.Mutex
+	// suspects are the allocated blocks without claim of each pool, with the time they were first seen
+	suspects map[string]map[int]time.Time
+}
+
+func addPool(mgr manager.Manager, pMgr *gmanager.GManager) error {
+	reconciler := &poolReconciler{
+		Client:   mgr.GetClient(),
+		Mgr:      mgr,
+		Log:      ctrl.Log.WithName("controllers").WithName("ippool"),
+		GManager: pMgr,
+		suspects: make(map[string]map[int]time.Time),
+	}
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&devopsv1.IPPool{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(reconciler)
+	if err != nil {
+		return errors.Wrapf(err, "unable to create ippool controller")
+	}
+
+	return nil
+}
+
+func (r *poolReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	logger := r.Log.WithValues("ippool", req.Name)
+
+	pool := &devopsv1.IPPool{}
+	err := r.Client.Get(ctx, req.NamespacedName, pool)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.V(4).Info("not find ippool")
+			r.setSuspects(req.Name, nil)
+			return reconcile.Result{}, nil
+		}
+
+		logger.Error(err, "failed to get ippool")
+		return reconcile.Result{}, err
+	}
+
+	if !pool.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	r.mu.Lock()
+	suspects := r.suspects[pool.Name]
+	r.mu.Unlock()
+
+	suspects, err = ipam.Repair(ctx, r.Client, pool, suspects, repairInterval)
+	if err != nil {
+		if apierrors.IsConflict(err) {
+			return reconcile.Result{Requeue: true}, nil
+		}
+		logger.Error(err, "failed to repair ippool")
+		return reconcile.Result{}, err
+	}
+	r.setSuspects(pool.Name, suspects)
+
+	return reconcile.Result{RequeueAfter: repairInterval}, nil
+}
+
+func (r *poolReconciler) setSuspects(pool string, suspects map[int]time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(suspects) == 0 {
+		delete(r.suspects, pool)
+		return
+	}
+	r.suspects[pool] = suspects
+}
@@ -28,12 +28,18 @@ import (
 
 	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
 	"github.com/gostship/kunkka/pkg/constants"
+	"github.com/gostship/kunkka/pkg/controllers/common"
 	"github.com/gostship/kunkka/pkg/gmanager"
+	"github.com/gostship/kunkka/pkg/provider/addons/calico"
+	"github.com/gostship/kunkka/pkg/provider/ipam"
+	machineprovider "github.com/gostship/kunkka/pkg/provider/machine"
 	"github.com/gostship/kunkka/pkg/provider/phases/clean"
+	"github.com/gostship/kunkka/pkg/provider/phases/drain"
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -42,6 +48,19 @@ import (
 
 const (
 	machineMaxReconciles = 2
+
+	conditionTypeCordon = "Cordon"
+	conditionTypeDrain  = "Drain"
+
+	reasonDraining     = "Draining"
+	reasonDrainTimeout = "DrainTimeout"
+	reasonForceDrained = "ForceDrained"
+
+	drainPollInterval    = 5 * time.Second
+	drainTimeoutInterval = 30 * time.Second
+	// drainPassTimeout bounds a drain pass, so the reconciles of the other machines are not
+	// blocked by the disruption budgets.
+	drainPassTimeout = 30 * time.Second
 )
 
 // machineReconciler reconciles a machine object
@@ -87,6 +106,9 @@ func (r *machineReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 // +kubebuilder:rbac:groups=devops.gostship.io,resources=machines,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=devops.gostship.io,resources=machines/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=devops.gostship.io,resources=hosts,verbs=get;list;watch
+// +kubebuilder:rbac:groups=devops.gostship.io,resources=hosts/status,verbs=get;update;patch
 
 func (r *machineReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	ctx := context.Background()
@@ -118,13 +140,20 @@ func (r *machineReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		return reconcile.Result{}, err
 	}
 
+	// the machine is never connected with the secrets of the other namespaces,
+	// it waits for the references to be fixed
+	if err := common.CheckCredentialsRefs(m.Namespace, m.Spec.Machine); err != nil {
+		logger.Error(err, "invalid machine credentials")
+		return reconcile.Result{}, nil
+	}
+
 	if !m.ObjectMeta.DeletionTimestamp.IsZero() {
-		err := r.cleanMachinesResources(ctx, logger, m)
+		result, err := r.cleanMachinesResources(ctx, logger, m)
 		if err != nil {
 			logger.Error(err, "failed to clean machine resources")
 			return reconcile.Result{}, err
 		}
-		return reconcile.Result{}, nil
+		return result, nil
 	}
 
 	if !constants.ContainsString(m.ObjectMeta.Finalizers, constants.FinalizersMachine) {
@@ -142,6 +171,49 @@ func (r *machineReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		return reconcile.Result{}, nil
 	}
 
+	migrated, err := common.MigrateCredentials(ctx, r.Client, r.Scheme, m, m.Spec.Machine)
+	if err != nil {
+		logger.Error(err, "failed to migrate machine credentials")
+		return reconcile.Result{}, err
+	}
+	if migrated {
+		err := r.Client.Update(ctx, m)
+		if err != nil {
+			logger.Error(err, "failed to update migrated machine credentials")
+			return reconcile.Result{}, err
+		}
+
+		return reconcile.Result{}, nil
+	}
+
+	if m.Spec.Machine == nil && m.Spec.HostSelector != nil {
+		hosts, err := common.ClaimHosts(ctx, r.Client, r.Scheme, m, m.Spec.HostSelector, 1)
+		if err != nil {
+			logger.Error(err, "failed to claim host")
+			return reconcile.Result{}, err
+		}
+		m.Spec.Machine = common.HostMachine(hosts[0])
+		// the host takes its address and a pod block of its rack as the machines added by the api
+		if rack := hosts[0].Spec.Rack; rack != "" {
+			m.Spec.Machine.HostCni, err = ipam.AllocateMachine(ctx, r.Client, r.Scheme, m, rack, hosts[0].Spec.IP)
+			if err != nil {
+				logger.Error(err, "failed to allocate host address", "rack", rack)
+				return reconcile.Result{}, err
+			}
+		}
+		if m.Labels == nil {
+			m.Labels = map[string]string{}
+		}
+		m.Labels[devopsv1.LabelHost] = hosts[0].Name
+		err = r.Client.Update(ctx, m)
+		if err != nil {
+			logger.Error(err, "failed to update claimed host")
+			return reconcile.Result{}, err
+		}
+
+		return reconcile.Result{}, nil
+	}
+
 	if m.Spec.Pause == true {
 		logger.Info("machine is Pause")
 		return reconcile.Result{}, nil
@@ -175,8 +247,7 @@ func (r *machineReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		}, nil
 	}
 
-	credential := &devopsv1.ClusterCredential{}
-	err = r.Client.Get(ctx, types.NamespacedName{Name: m.Spec.ClusterName, Namespace: m.Namespace}, credential)
+	credential, err := common.GetClusterCredential(ctx, r.Client, types.NamespacedName{Name: m.Spec.ClusterName, Namespace: m.Namespace})
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.Info("not find ClusterCredential")
@@ -189,44 +260,179 @@ func (r *machineReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 
 	klog.Infof("name: %s", cluster.Name)
 
-	r.reconcile(ctx, &manchineContext{
+	rc := &manchineContext{
 		Key:               req.NamespacedName,
 		Logger:            logger,
 		Machine:           m,
 		Cluster:           cluster,
 		ClusterCredential: credential,
-	})
+	}
+	r.reconcile(ctx, rc)
+	if m.Status.Phase == devopsv1.MachineInitializing {
+		if after := machineprovider.RetryAfter(m, &r.Cfg.Retry); after > 0 {
+			logger.V(4).Info("wait retry", "after", after)
+			return ctrl.Result{RequeueAfter: after}, nil
+		}
+	}
 	return ctrl.Result{}, nil
 }
 
-func (r *machineReconciler) cleanMachinesResources(ctx context.Context, logger logr.Logger, m *devopsv1.Machine) error {
-	clusterCtx, err := r.ClusterManager.Get(m.Name)
+func (r *machineReconciler) cleanMachinesResources(ctx context.Context, logger logr.Logger, m *devopsv1.Machine) (ctrl.Result, error) {
+	clusterCtx, err := r.ClusterManager.Get(m.Spec.ClusterName)
 	if err == nil {
+		result, err := r.drainNode(ctx, logger, m, clusterCtx.KubeCli)
+		if err != nil || result.RequeueAfter > 0 {
+			return result, err
+		}
+
 		logger.Info("start delete node")
-		clusterCtx.KubeCli.CoreV1().Nodes().Delete(ctx, m.Name, metav1.DeleteOptions{})
-	}
+		err = clusterCtx.KubeCli.CoreV1().Nodes().Delete(ctx, m.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, errors.Wrapf(err, "delete node %s", m.Name)
+		}
 
-	ssh, err := m.Spec.Machine.SSH()
-	if err != nil {
-		logger.Error(err, "failed new ssh")
-		return err
+		err = calico.DeleteNodeObjs(ctx, clusterCtx.Client, m.Spec.Machine)
+		if err != nil {
+			logger.Error(err, "failed delete calico objects of the node")
+			return ctrl.Result{}, err
+		}
 	}
 
-	logger.Info("start clean node")
+	// the machine waiting for a host has nothing to clean
+	if m.Spec.Machine != nil {
+		ssh, err := m.Spec.Machine.SSH()
+		if err != nil {
+			logger.Error(err, "failed new ssh")
+			return ctrl.Result{}, err
+		}
 
-	err = clean.DleNode(ssh, m.Name)
+		logger.Info("start clean node")
+
+		err = clean.DleNode(ssh, m.Name)
+		if err != nil {
+			logger.Error(err, "failed delete machine node!")
+			return ctrl.Result{}, err
+		}
+
+		// the cluster may be deleted before the machine
+		cluster := &devopsv1.Cluster{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: m.Spec.ClusterName, Namespace: m.Namespace}, cluster); err != nil && !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		err = clean.CleanNode(ssh, m.Spec.GetContainerRuntime(&cluster.Spec))
+		if err != nil {
+			logger.Error(err, "failed clean machine node")
+			return ctrl.Result{}, err
+		}
+	}
+
+	err = ipam.ReleaseOwned(ctx, r.Client, m)
 	if err != nil {
-		logger.Error(err, "failed delete machine node!")
-		return err
+		logger.Error(err, "failed release machine addresses")
+		return ctrl.Result{}, err
 	}
 
-	err = clean.CleanNode(ssh)
+	err = common.ReleaseHosts(ctx, r.Client, m)
 	if err != nil {
-		logger.Error(err, "failed clean machine node")
-		return err
+		logger.Error(err, "failed release machine host")
+		return ctrl.Result{}, err
 	}
 
 	logger.Info("start clean machine finalizers")
 	m.ObjectMeta.Finalizers = constants.RemoveString(m.ObjectMeta.Finalizers, constants.FinalizersMachine)
-	return r.Client.Update(ctx, m)
+	return ctrl.Result{}, r.Client.Update(ctx, m)
+}
+
+// drainNode cordons the node of the machine and drains its pods, one drain pass bounded by
+// drainPassTimeout is run by each reconcile and the progress is kept in the conditions of the
+// machine. It returns a result to requeue until the node is drained.
+func (r *machineReconciler) drainNode(ctx context.Context, logger logr.Logger, m *devopsv1.Machine, cli kubernetes.Interface) (ctrl.Result, error) {
+	if isMachineConditionTrue(m, conditionTypeDrain) {
+		return ctrl.Result{}, nil
+	}
+
+	m.Status.Phase = devopsv1.MachineTerminating
+	if !isMachineConditionTrue(m, conditionTypeCordon) {
+		err := drain.Cordon(ctx, cli, m.Name)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		m.SetCondition(devopsv1.MachineCondition{
+			Type:   conditionTypeCordon,
+			Status: devopsv1.ConditionTrue,
+		})
+	}
+
+	opts := drain.Options{
+		Timeout:         drainPassTimeout,
+		Force:           r.Cfg.Drain.Force || m.Annotations[constants.MachineAnnotationForceDrain] == "true",
+		DeleteLocalData: r.Cfg.Drain.DeleteLocalData,
+	}
+	drainErr := drain.Drain(ctx, cli, m.Name, opts)
+
+	var requeue ctrl.Result
+	switch {
+	case drainErr == nil:
+		logger.Info("node is drained")
+		m.SetCondition(devopsv1.MachineCondition{
+			Type:               conditionTypeDrain,
+			Status:             devopsv1.ConditionTrue,
+			LastTransitionTime: metav1.Now(),
+		})
+	case time.Since(drainStartTime(m)) < r.Cfg.Drain.Timeout:
+		m.SetCondition(devopsv1.MachineCondition{
+			Type:    conditionTypeDrain,
+			Status:  devopsv1.ConditionFalse,
+			Reason:  reasonDraining,
+			Message: drainErr.Error(),
+		})
+		requeue = ctrl.Result{RequeueAfter: drainPollInterval}
+	case opts.Force:
+		logger.Info("drain timed out, force delete the pods left", "err", drainErr.Error())
+		err := drain.Force(ctx, cli, m.Name, opts)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		m.SetCondition(devopsv1.MachineCondition{
+			Type:               conditionTypeDrain,
+			Status:             devopsv1.ConditionTrue,
+			LastTransitionTime: metav1.Now(),
+			Reason:             reasonForceDrained,
+			Message:            drainErr.Error(),
+		})
+	default:
+		logger.Info("drain timed out, wait the pods left", "err", drainErr.Error())
+		m.SetCondition(devopsv1.MachineCondition{
+			Type:    conditionTypeDrain,
+			Status:  devopsv1.ConditionFalse,
+			Reason:  reasonDrainTimeout,
+			Message: drainErr.Error(),
+		})
+		requeue = ctrl.Result{RequeueAfter: drainTimeoutInterval}
+	}
+
+	err := r.Client.Status().Update(ctx, m)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	return requeue, nil
+}
+
+// drainStartTime returns when the drain of the machine starts, now if it is not started yet.
+func drainStartTime(m *devopsv1.Machine) time.Time {
+	for _, condition := range m.Status.Conditions {
+		if condition.Type == conditionTypeDrain && !condition.LastTransitionTime.IsZero() {
+			return condition.LastTransitionTime.Time
+		}
+	}
+	return time.Now()
+}
+
+func isMachineConditionTrue(m *devopsv1.Machine, conditionType string) bool {
+	for _, condition := range m.Status.Conditions {
+		if condition.Type == conditionType {
+			return condition.Status == devopsv1.ConditionTrue
+		}
+	}
+	return false
 }
@@ -23,7 +23,11 @@ import (
 	"time"
 
 	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/constants"
 	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/provider/condlog"
+	"github.com/gostship/kunkka/pkg/provider/plan"
+	"github.com/pkg/errors"
 )
 
 const (
@@ -46,6 +50,7 @@ func (r *machineReconciler) onCreate(ctx context.Context, rc *manchineContext) e
 		Client:            r.Client,
 		ClusterManager:    r.ClusterManager,
 	}
+	ctx = condlog.WithRecorder(ctx, condlog.NewRecorder(r.Client, r.Scheme, rc.Machine))
 	err = p.OnCreate(ctx, rc.Machine, clusterWrapper)
 	if err != nil {
 		rc.Machine.Status.Message = err.Error()
@@ -72,6 +77,34 @@ func (r *machineReconciler) onCreate(ctx context.Context, rc *manchineContext) e
 	return nil
 }
 
+func (r *machineReconciler) onPlan(ctx context.Context, rc *manchineContext) error {
+	old, err := plan.Load(ctx, r.Client, rc.Machine.Namespace, rc.Machine.Name)
+	if err != nil {
+		return err
+	}
+	if old != nil && old.Generation == rc.Machine.Generation {
+		return nil
+	}
+
+	p, err := r.MpManager.GetProvider(rc.Cluster.Spec.Type)
+	if err != nil {
+		return err
+	}
+
+	clusterWrapper := &common.Cluster{
+		Cluster:           rc.Cluster,
+		ClusterCredential: rc.ClusterCredential,
+		Client:            r.Client,
+		ClusterManager:    r.ClusterManager,
+	}
+	result, err := p.Plan(ctx, rc.Machine, clusterWrapper)
+	if err != nil {
+		return errors.Wrapf(err, "plan machine %s", rc.Machine.Name)
+	}
+	rc.Logger.Info("save plan", "steps", len(result.Steps), "errors", len(result.Errors))
+	return plan.Save(ctx, r.Client, r.Scheme, rc.Machine, result)
+}
+
 func (r *machineReconciler) onUpdate(ctx context.Context, rc *manchineContext) error {
 	p, err := r.MpManager.GetProvider(rc.Cluster.Spec.Type)
 	if err != nil {
@@ -85,6 +118,7 @@ func (r *machineReconciler) onUpdate(ctx context.Context, rc *manchineContext) e
 		ClusterManager:    r.ClusterManager,
 	}
 
+	ctx = condlog.WithRecorder(ctx, condlog.NewRecorder(r.Client, r.Scheme, rc.Machine))
 	err = p.OnUpdate(ctx, rc.Machine, clusterWrapper)
 	if err != nil {
 		clusterWrapper.Cluster.Status.Message = err.Error()
@@ -94,20 +128,73 @@ func (r *machineReconciler) onUpdate(ctx context.Context, rc *manchineContext) e
 	}
 	clusterWrapper.Cluster.Status.Message = ""
 	clusterWrapper.Cluster.Status.Reason = ""
-	r.Client.Status().Update(ctx, clusterWrapper.ClusterCredential)
+	common.UpdateClusterCredential(ctx, r.Client, clusterWrapper.ClusterCredential)
 	r.Client.Status().Update(ctx, clusterWrapper.Cluster)
 	return nil
 }
 
+// resetRetries resets the retries of the failed handlers, a Failed machine starts again.
+func (r *machineReconciler) resetRetries(ctx context.Context, rc *manchineContext) error {
+	rc.Logger.Info("reset retries")
+	for i := range rc.Machine.Status.Conditions {
+		condition := &rc.Machine.Status.Conditions[i]
+		if condition.Status == devopsv1.ConditionFalse {
+			condition.Retries = 0
+		}
+	}
+	if rc.Machine.Status.Phase == devopsv1.MachineFailed {
+		rc.Machine.Status.Phase = devopsv1.MachineInitializing
+	}
+	err := r.Client.Status().Update(ctx, rc.Machine)
+	if err != nil {
+		return err
+	}
+
+	obj := &devopsv1.Machine{}
+	err = r.Client.Get(ctx, rc.Key, obj)
+	if err != nil {
+		return err
+	}
+	delete(obj.Annotations, constants.ClusterAnnotationRetry)
+	delete(obj.Annotations, constants.ClusterAnnotationRekeyHosts)
+	return r.Client.Update(ctx, obj)
+}
+
+// rekeyHosts drops the pinned host key of the machine, so the reinstalled one is trusted on
+// the next connection, then resets the retries.
+func (r *machineReconciler) rekeyHosts(ctx context.Context, rc *manchineContext) error {
+	if m := rc.Machine.Spec.Machine; m != nil {
+		rc.Logger.Info("rekey host", "node", m.IP)
+		if err := m.Rekey(); err != nil {
+			return errors.Wrapf(err, "rekey host %s", m.IP)
+		}
+	}
+	return r.resetRetries(ctx, rc)
+}
+
 func (r *machineReconciler) reconcile(ctx context.Context, rc *manchineContext) error {
+	if len(constants.GetAnnotationKey(rc.Machine.Annotations, constants.ClusterAnnotationRekeyHosts)) > 0 {
+		return r.rekeyHosts(ctx, rc)
+	}
+	if len(constants.GetAnnotationKey(rc.Machine.Annotations, constants.ClusterAnnotationRetry)) > 0 {
+		return r.resetRetries(ctx, rc)
+	}
+
 	var err error
 	switch rc.Machine.Status.Phase {
 	case devopsv1.MachineInitializing:
+		if len(constants.GetAnnotationKey(rc.Machine.Annotations, constants.ClusterAnnotationDryRun)) > 0 {
+			rc.Logger.Info("onPlan")
+			err = r.onPlan(ctx, rc)
+			break
+		}
 		rc.Logger.Info("onCreate")
 		err = r.onCreate(ctx, rc)
 	case devopsv1.MachineRunning:
 		rc.Logger.Info("onUpdate")
 		err = r.onUpdate(ctx, rc)
+	case devopsv1.MachineFailed:
+		rc.Logger.V(4).Info("machine is failed, waiting retry")
 	default:
 		err = fmt.Errorf("no handler for %q", rc.Cluster.Status.Phase)
 	}
@@ -17,25 +17,104 @@ limitations under the License.
 package option
 
 import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gostship/kunkka/pkg/constants"
+	"github.com/gostship/kunkka/pkg/provider/config"
+	"github.com/gostship/kunkka/pkg/util/ssh"
 	"github.com/spf13/pflag"
 )
 
 type ControllersManagerOption struct {
 	EnableCluster     bool
 	EnableMachine     bool
+	EnableEtcdBackup  bool
+	EnableIPAM        bool
+	EnableCertificate bool
+	EnableHealthCheck bool
+	EnableHost        bool
 	EnableManagerCrds bool
+
+	RetryLimit     int32
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+
+	ArtifactDir         string
+	ArtifactBindAddress string
+	ArtifactURL         string
+
+	ChartCacheDir string
+	ChartRoot     string
+
+	CertCheckInterval time.Duration
+	CertRenewBefore   time.Duration
+
+	SSHKeepAlive   time.Duration
+	SSHIdleTimeout time.Duration
+	SSHMaxSessions int
+
+	SSHCredentials *SSHCredentialsOption
+
+	CredentialKMS *CredentialKMSOption
+
+	DrainTimeout         time.Duration
+	DrainForce           bool
+	DrainDeleteLocalData bool
 }
 
 func DefaultControllersManagerOption() *ControllersManagerOption {
 	return &ControllersManagerOption{
 		EnableCluster:     true,
 		EnableMachine:     true,
+		EnableEtcdBackup:  true,
+		EnableIPAM:        true,
+		EnableCertificate: true,
+		EnableHealthCheck: true,
+		EnableHost:        true,
 		EnableManagerCrds: false,
+		RetryLimit:        8,
+		RetryBaseDelay:    10 * time.Second,
+		RetryMaxDelay:     10 * time.Minute,
+		ArtifactDir:       "/k8s-artifacts",
+		ChartCacheDir:     filepath.Join(os.TempDir(), "kunkka-charts"),
+		CertCheckInterval: time.Hour,
+		CertRenewBefore:   constants.RenewCertsTimeThreshold,
+		SSHKeepAlive:      ssh.DefaultPoolConfig.KeepAlive,
+		SSHIdleTimeout:    ssh.DefaultPoolConfig.IdleTimeout,
+		SSHMaxSessions:    ssh.DefaultPoolConfig.MaxSessions,
+		SSHCredentials:    DefaultSSHCredentialsOption(),
+		CredentialKMS:     DefaultCredentialKMSOption(),
+		DrainTimeout:      config.DefaultDrain.Timeout,
 	}
 }
 
 func (o *ControllersManagerOption) AddFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&o.EnableCluster, "enable-cluster", o.EnableCluster, "Enables the Cluster controller manager")
 	fs.BoolVar(&o.EnableMachine, "enable-machine", o.EnableMachine, "Enables the Machine controller manager")
+	fs.BoolVar(&o.EnableEtcdBackup, "enable-etcd-backup", o.EnableEtcdBackup, "Enables the EtcdBackup and EtcdRestore controller manager")
+	fs.BoolVar(&o.EnableIPAM, "enable-ipam", o.EnableIPAM, "Enables the IPPool and IPClaim controller manager")
+	fs.BoolVar(&o.EnableCertificate, "enable-certificate", o.EnableCertificate, "Enables the certificate controller checking the expiration of the cluster certificates")
+	fs.BoolVar(&o.EnableHealthCheck, "enable-health-check", o.EnableHealthCheck, "Enables the MachineHealthCheck controller remediating the unhealthy machines")
+	fs.BoolVar(&o.EnableHost, "enable-host", o.EnableHost, "Enables the Host controller discovering the facts of the hosts of the inventory")
 	fs.BoolVar(&o.EnableManagerCrds, "enable-manager-crds", o.EnableManagerCrds, "Enables to manager the associated crds")
+	fs.Int32Var(&o.RetryLimit, "retry-limit", o.RetryLimit, "The failed runs of a handler before the Cluster or Machine turns Failed, 0 means no limit")
+	fs.DurationVar(&o.RetryBaseDelay, "retry-base-delay", o.RetryBaseDelay, "The delay before a failed handler runs again, doubled after each failure")
+	fs.DurationVar(&o.RetryMaxDelay, "retry-max-delay", o.RetryMaxDelay, "The max delay before a failed handler runs again")
+	fs.StringVar(&o.ArtifactDir, "artifact-dir", o.ArtifactDir, "The dir of the files of the KubernetesArtifacts served to the nodes")
+	fs.StringVar(&o.ArtifactBindAddress, "artifact-bind-address", o.ArtifactBindAddress, "The address the artifact server listens on, e.g. :8091, empty means the artifacts are not served")
+	fs.StringVar(&o.ArtifactURL, "artifact-url", o.ArtifactURL, "The url the nodes download the artifacts from, e.g. http://10.0.0.10:8091")
+	fs.StringVar(&o.ChartCacheDir, "chart-cache-dir", o.ChartCacheDir, "The dir the indexes and the chart archives of the chart repositories of the cluster apps are kept in")
+	fs.StringVar(&o.ChartRoot, "chart-root", o.ChartRoot, "The dir the local charts of the cluster apps are loaded from, empty means the local charts are refused")
+	fs.DurationVar(&o.CertCheckInterval, "cert-check-interval", o.CertCheckInterval, "The interval the certificates of a cluster are checked")
+	fs.DurationVar(&o.CertRenewBefore, "cert-renew-before", o.CertRenewBefore, "The certificates of a cluster are issued again once they expire within the duration")
+	fs.DurationVar(&o.SSHKeepAlive, "ssh-keepalive", o.SSHKeepAlive, "The interval the pooled ssh connections to the machines are probed")
+	fs.DurationVar(&o.SSHIdleTimeout, "ssh-idle-timeout", o.SSHIdleTimeout, "The time an unused ssh connection is kept open, 0 means no limit")
+	fs.IntVar(&o.SSHMaxSessions, "ssh-max-sessions", o.SSHMaxSessions, "The max sessions opened on a ssh connection at the same time, must be below the MaxSessions of sshd, 0 means no limit")
+	o.SSHCredentials.AddFlags(fs)
+	o.CredentialKMS.AddFlags(fs)
+	fs.DurationVar(&o.DrainTimeout, "drain-timeout", o.DrainTimeout, "The time the pods of a deleted machine are evicted before they are deleted by --drain-force")
+	fs.BoolVar(&o.DrainForce, "drain-force", o.DrainForce, "Deletes the pods of a deleted machine still blocked by the disruption budgets after --drain-timeout and drains the pods not managed by a controller, otherwise the deletion waits for them")
+	fs.BoolVar(&o.DrainDeleteLocalData, "drain-delete-local-data", o.DrainDeleteLocalData, "Drains the pods with emptyDir volumes of a deleted machine, their data is lost, otherwise the deletion waits for them")
 }
@@ -0,0 +1,77 @@
+/*
+Copyright 2020 dke.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gostship/kunkka/pkg/util/envelope"
+	"github.com/spf13/pflag"
+	"k8s.io/klog"
+)
+
+// CredentialKMSOption is the key sealing the key material of the cluster credentials, it
+// is shared by every binary reading or writing the ClusterCredentials.
+type CredentialKMSOption struct {
+	KeyFile string
+	KeyName string
+	// DecryptKeys are the replaced keys as name=file, they only open the key material
+	// sealed before, which is sealed by the key of KeyFile once it is saved again.
+	DecryptKeys []string
+}
+
+func DefaultCredentialKMSOption() *CredentialKMSOption {
+	return &CredentialKMSOption{
+		KeyName: "key1",
+	}
+}
+
+func (o *CredentialKMSOption) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.KeyFile, "credential-kms-key-file", o.KeyFile, "The file of the base64 AES-256 key encrypting the key material of the cluster credentials, empty means it is kept unencrypted in the secrets")
+	fs.StringVar(&o.KeyName, "credential-kms-key-name", o.KeyName, "The name of the key of --credential-kms-key-file, a new name is required once the key is replaced and the old key is moved to --credential-kms-decrypt-keys")
+	fs.StringSliceVar(&o.DecryptKeys, "credential-kms-decrypt-keys", o.DecryptKeys, "The replaced keys as name=file, they only decrypt the key material sealed before, which is sealed again with --credential-kms-key-file once the credential is read or saved")
+}
+
+// Envelope returns the envelope of the keys, it is nil without any key.
+func (o *CredentialKMSOption) Envelope() (*envelope.Envelope, error) {
+	decrypters := make([]envelope.Provider, 0, len(o.DecryptKeys))
+	for _, key := range o.DecryptKeys {
+		parts := strings.SplitN(key, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid --credential-kms-decrypt-keys %q, want name=file", key)
+		}
+		provider, err := envelope.NewLocalProvider(parts[0], parts[1])
+		if err != nil {
+			return nil, err
+		}
+		decrypters = append(decrypters, provider)
+	}
+
+	if o.KeyFile == "" {
+		klog.Warning("no --credential-kms-key-file, the key material of the cluster credentials is kept unencrypted in the secrets")
+		if len(decrypters) == 0 {
+			return nil, nil
+		}
+		return envelope.New(nil, decrypters...), nil
+	}
+	provider, err := envelope.NewLocalProvider(o.KeyName, o.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	return envelope.New(provider, decrypters...), nil
+}
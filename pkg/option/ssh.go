@@ -0,0 +1,40 @@
+/*
+Copyright 2020 dke.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"github.com/spf13/pflag"
+)
+
+// SSHCredentialsOption is where the ssh credentials and the pinned host keys of the machines
+// are kept, it is shared by every binary connecting to the machines.
+type SSHCredentialsOption struct {
+	KnownHostsNamespace  string
+	CredentialsNamespace string
+}
+
+func DefaultSSHCredentialsOption() *SSHCredentialsOption {
+	return &SSHCredentialsOption{
+		KnownHostsNamespace:  "kunkka-system",
+		CredentialsNamespace: "kunkka-system",
+	}
+}
+
+func (o *SSHCredentialsOption) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.KnownHostsNamespace, "known-hosts-namespace", o.KnownHostsNamespace, "The namespace of the secrets of the host keys pinned on the first ssh connection to the machines")
+	fs.StringVar(&o.CredentialsNamespace, "credentials-namespace", o.CredentialsNamespace, "The namespace of the ssh credentials secrets shared by the machines of every namespace and the hosts, the others only refer the secrets of their own namespace")
+}
@@ -0,0 +1,473 @@
+package calico
+
+import (
+	"bytes"
+
+	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/provider/config"
+	"github.com/gostship/kunkka/pkg/util/k8sutil"
+	"github.com/gostship/kunkka/pkg/util/template"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog"
+)
+
+const (
+	// Version is the calico version installed.
+	Version = "v3.14.2"
+
+	calicoTemplate = `
+{{- range .CRDs }}
+---
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: {{ .Plural }}.crd.projectcalico.org
+spec:
+  scope: {{ .Scope }}
+  group: crd.projectcalico.org
+  version: v1
+  names:
+    kind: {{ .Kind }}
+    plural: {{ .Plural }}
+    singular: {{ lower .Kind }}
+{{- end }}
+---
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: calico-config
+  namespace: kube-system
+data:
+  typha_service_name: "none"
+  calico_backend: "bird"
+  veth_mtu: "{{ .MTU }}"
+  cni_network_config: |-
+    {
+      "name": "k8s-pod-network",
+      "cniVersion": "0.3.1",
+      "plugins": [
+        {
+          "type": "calico",
+          "log_level": "info",
+          "datastore_type": "kubernetes",
+          "nodename": "__KUBERNETES_NODE_NAME__",
+          "mtu": __CNI_MTU__,
+          "ipam": {
+              "type": "calico-ipam"
+          },
+          "policy": {
+              "type": "k8s"
+          },
+          "kubernetes": {
+              "kubeconfig": "__KUBECONFIG_FILEPATH__"
+          }
+        },
+        {
+          "type": "portmap",
+          "snat": true,
+          "capabilities": {"portMappings": true}
+        },
+        {
+          "type": "bandwidth",
+          "capabilities": {"bandwidth": true}
+        }
+      ]
+    }
+---
+kind: ClusterRole
+apiVersion: rbac.authorization.k8s.io/v1
+metadata:
+  name: calico-kube-controllers
+rules:
+  - apiGroups: [""]
+    resources: ["nodes"]
+    verbs: ["watch", "list", "get"]
+  - apiGroups: [""]
+    resources: ["pods"]
+    verbs: ["get"]
+  - apiGroups: ["crd.projectcalico.org"]
+    resources: ["ipamblocks", "ipamhandles", "blockaffinities", "ipamconfigs"]
+    verbs: ["get", "list", "create", "update", "delete"]
+  - apiGroups: ["crd.projectcalico.org"]
+    resources: ["clusterinformations"]
+    verbs: ["get", "create", "update"]
+  - apiGroups: ["crd.projectcalico.org"]
+    resources: ["kubecontrollersconfigurations"]
+    verbs: ["get", "create", "update", "watch"]
+---
+kind: ClusterRoleBinding
+apiVersion: rbac.authorization.k8s.io/v1
+metadata:
+  name: calico-kube-controllers
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: calico-kube-controllers
+subjects:
+- kind: ServiceAccount
+  name: calico-kube-controllers
+  namespace: kube-system
+---
+kind: ClusterRole
+apiVersion: rbac.authorization.k8s.io/v1
+metadata:
+  name: calico-node
+rules:
+  - apiGroups: [""]
+    resources: ["pods", "nodes", "namespaces", "configmaps"]
+    verbs: ["get"]
+  - apiGroups: [""]
+    resources: ["endpoints", "services"]
+    verbs: ["watch", "list", "get"]
+  - apiGroups: [""]
+    resources: ["nodes/status"]
+    verbs: ["patch", "update"]
+  - apiGroups: ["networking.k8s.io"]
+    resources: ["networkpolicies"]
+    verbs: ["watch", "list"]
+  - apiGroups: [""]
+    resources: ["pods", "namespaces", "serviceaccounts", "nodes"]
+    verbs: ["list", "watch"]
+  - apiGroups: [""]
+    resources: ["pods/status"]
+    verbs: ["patch"]
+  - apiGroups: ["crd.projectcalico.org"]
+    resources:
+      - globalfelixconfigs
+      - felixconfigurations
+      - bgppeers
+      - globalbgpconfigs
+      - bgpconfigurations
+      - ippools
+      - ipamblocks
+      - globalnetworkpolicies
+      - globalnetworksets
+      - networkpolicies
+      - networksets
+      - clusterinformations
+      - hostendpoints
+      - blockaffinities
+    verbs: ["get", "list", "watch"]
+  - apiGroups: ["crd.projectcalico.org"]
+    resources: ["ippools", "felixconfigurations", "clusterinformations", "bgpconfigurations", "bgppeers"]
+    verbs: ["create", "update"]
+  - apiGroups: ["crd.projectcalico.org"]
+    resources: ["blockaffinities", "ipamblocks", "ipamhandles"]
+    verbs: ["get", "list", "create", "update", "delete"]
+  - apiGroups: ["crd.projectcalico.org"]
+    resources: ["ipamconfigs"]
+    verbs: ["get"]
+  - apiGroups: ["crd.projectcalico.org"]
+    resources: ["blockaffinities"]
+    verbs: ["watch"]
+  - apiGroups: ["apps"]
+    resources: ["daemonsets"]
+    verbs: ["get"]
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  name: calico-node
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: calico-node
+subjects:
+- kind: ServiceAccount
+  name: calico-node
+  namespace: kube-system
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: calico-node
+  namespace: kube-system
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: calico-kube-controllers
+  namespace: kube-system
+---
+kind: DaemonSet
+apiVersion: apps/v1
+metadata:
+  name: calico-node
+  namespace: kube-system
+  labels:
+    k8s-app: calico-node
+spec:
+  selector:
+    matchLabels:
+      k8s-app: calico-node
+  updateStrategy:
+    type: RollingUpdate
+    rollingUpdate:
+      maxUnavailable: 1
+  template:
+    metadata:
+      labels:
+        k8s-app: calico-node
+    spec:
+      nodeSelector:
+        kubernetes.io/os: linux
+      hostNetwork: true
+      tolerations:
+        - effect: NoSchedule
+          operator: Exists
+        - key: CriticalAddonsOnly
+          operator: Exists
+        - effect: NoExecute
+          operator: Exists
+      serviceAccountName: calico-node
+      terminationGracePeriodSeconds: 0
+      priorityClassName: system-node-critical
+      initContainers:
+        - name: install-cni
+          image: {{ .CNIImage }}
+          command: ["/install-cni.sh"]
+          env:
+            - name: CNI_CONF_NAME
+              value: "10-calico.conflist"
+            - name: CNI_NETWORK_CONFIG
+              valueFrom:
+                configMapKeyRef:
+                  name: calico-config
+                  key: cni_network_config
+            - name: KUBERNETES_NODE_NAME
+              valueFrom:
+                fieldRef:
+                  fieldPath: spec.nodeName
+            - name: CNI_MTU
+              valueFrom:
+                configMapKeyRef:
+                  name: calico-config
+                  key: veth_mtu
+            - name: SLEEP
+              value: "false"
+          volumeMounts:
+            - mountPath: /host/opt/cni/bin
+              name: cni-bin-dir
+            - mountPath: /host/etc/cni/net.d
+              name: cni-net-dir
+          securityContext:
+            privileged: true
+      containers:
+        - name: calico-node
+          image: {{ .NodeImage }}
+          env:
+            - name: DATASTORE_TYPE
+              value: "kubernetes"
+            - name: WAIT_FOR_DATASTORE
+              value: "true"
+            - name: NODENAME
+              valueFrom:
+                fieldRef:
+                  fieldPath: spec.nodeName
+            - name: CALICO_NETWORKING_BACKEND
+              valueFrom:
+                configMapKeyRef:
+                  name: calico-config
+                  key: calico_backend
+            - name: CLUSTER_TYPE
+              value: "k8s,bgp"
+            - name: IP
+              value: "autodetect"
+            - name: IP_AUTODETECTION_METHOD
+              value: "{{ .IPAutodetectionMethod }}"
+            # the pools are managed by kunkka, from the cluster cidr or the rack pod ranges
+            - name: NO_DEFAULT_POOLS
+              value: "true"
+            - name: FELIX_IPINIPMTU
+              valueFrom:
+                configMapKeyRef:
+                  name: calico-config
+                  key: veth_mtu
+            - name: CALICO_DISABLE_FILE_LOGGING
+              value: "true"
+            - name: FELIX_DEFAULTENDPOINTTOHOSTACTION
+              value: "ACCEPT"
+            - name: FELIX_IPV6SUPPORT
+              value: "false"
+            - name: FELIX_LOGSEVERITYSCREEN
+              value: "info"
+            - name: FELIX_HEALTHENABLED
+              value: "true"
+          securityContext:
+            privileged: true
+          resources:
+            requests:
+              cpu: 250m
+          livenessProbe:
+            exec:
+              command:
+              - /bin/calico-node
+              - -felix-live
+              - -bird-live
+            periodSeconds: 10
+            initialDelaySeconds: 10
+            failureThreshold: 6
+          readinessProbe:
+            exec:
+              command:
+              - /bin/calico-node
+              - -felix-ready
+              - -bird-ready
+            periodSeconds: 10
+          volumeMounts:
+            - mountPath: /lib/modules
+              name: lib-modules
+              readOnly: true
+            - mountPath: /run/xtables.lock
+              name: xtables-lock
+              readOnly: false
+            - mountPath: /var/run/calico
+              name: var-run-calico
+              readOnly: false
+            - mountPath: /var/lib/calico
+              name: var-lib-calico
+              readOnly: false
+      volumes:
+        - name: lib-modules
+          hostPath:
+            path: /lib/modules
+        - name: var-run-calico
+          hostPath:
+            path: /var/run/calico
+        - name: var-lib-calico
+          hostPath:
+            path: /var/lib/calico
+        - name: xtables-lock
+          hostPath:
+            path: /run/xtables.lock
+            type: FileOrCreate
+        - name: cni-bin-dir
+          hostPath:
+            path: /opt/cni/bin
+        - name: cni-net-dir
+          hostPath:
+            path: /etc/cni/net.d
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: calico-kube-controllers
+  namespace: kube-system
+  labels:
+    k8s-app: calico-kube-controllers
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      k8s-app: calico-kube-controllers
+  strategy:
+    type: Recreate
+  template:
+    metadata:
+      name: calico-kube-controllers
+      namespace: kube-system
+      labels:
+        k8s-app: calico-kube-controllers
+    spec:
+      nodeSelector:
+        kubernetes.io/os: linux
+      tolerations:
+        - key: CriticalAddonsOnly
+          operator: Exists
+        - key: node-role.kubernetes.io/master
+          effect: NoSchedule
+      serviceAccountName: calico-kube-controllers
+      priorityClassName: system-cluster-critical
+      containers:
+        - name: calico-kube-controllers
+          image: {{ .ControllersImage }}
+          env:
+            - name: ENABLED_CONTROLLERS
+              value: node
+            - name: DATASTORE_TYPE
+              value: kubernetes
+          readinessProbe:
+            exec:
+              command:
+              - /usr/bin/check-status
+              - -r
+---
+apiVersion: crd.projectcalico.org/v1
+kind: BGPConfiguration
+metadata:
+  name: default
+spec:
+  logSeverityScreen: Info
+  nodeToNodeMeshEnabled: {{ not .Peering }}
+  asNumber: {{ .ASNumber }}
+`
+)
+
+// crd is a calico resource stored as a crd of the cluster.
+type crd struct {
+	Kind   string
+	Plural string
+	Scope  string
+}
+
+var crds = []crd{
+	{"BGPConfiguration", "bgpconfigurations", "Cluster"},
+	{"BGPPeer", "bgppeers", "Cluster"},
+	{"BlockAffinity", "blockaffinities", "Cluster"},
+	{"ClusterInformation", "clusterinformations", "Cluster"},
+	{"FelixConfiguration", "felixconfigurations", "Cluster"},
+	{"GlobalNetworkPolicy", "globalnetworkpolicies", "Cluster"},
+	{"GlobalNetworkSet", "globalnetworksets", "Cluster"},
+	{"HostEndpoint", "hostendpoints", "Cluster"},
+	{"IPAMBlock", "ipamblocks", "Cluster"},
+	{"IPAMConfig", "ipamconfigs", "Cluster"},
+	{"IPAMHandle", "ipamhandles", "Cluster"},
+	{"IPPool", "ippools", "Cluster"},
+	{"KubeControllersConfiguration", "kubecontrollersconfigurations", "Cluster"},
+	{"NetworkPolicy", "networkpolicies", "Namespaced"},
+	{"NetworkSet", "networksets", "Namespaced"},
+}
+
+type Option struct {
+	CRDs []crd
+	// Peering means the nodes peer with the top of rack switches instead of each other,
+	// the pod addresses are routed without encapsulation.
+	Peering               bool
+	ASNumber              uint32
+	MTU                   int
+	IPAutodetectionMethod string
+	NodeImage             string
+	CNIImage              string
+	ControllersImage      string
+}
+
+// BuildCalicoAddon renders the calico manifests of the cluster, the ip pools and the
+// bgp peers of the nodes are built by BuildNodeObjs.
+func BuildCalicoAddon(cfg *config.Config, c *common.Cluster, peering bool) ([]runtime.Object, error) {
+	opt := &Option{
+		CRDs:                  crds,
+		Peering:               peering,
+		ASNumber:              DefaultASNumber,
+		MTU:                   1440,
+		IPAutodetectionMethod: "first-found",
+		NodeImage:             cfg.ImageFullName("calico-node", Version),
+		CNIImage:              cfg.ImageFullName("calico-cni", Version),
+		ControllersImage:      cfg.ImageFullName("calico-kube-controllers", Version),
+	}
+	if peering {
+		// no ipip header
+		opt.MTU = 1500
+	}
+	data, err := template.ParseString(calicoTemplate, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	objs, err := k8sutil.LoadObjs(bytes.NewReader(data))
+	if err != nil {
+		klog.Errorf("calico load objs err: %v", err)
+		return nil, err
+	}
+
+	return objs, nil
+}
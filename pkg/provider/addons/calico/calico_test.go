@@ -0,0 +1,178 @@
+package calico
+
+import (
+	"context"
+	"testing"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/provider/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRangeCIDRs(t *testing.T) {
+	tests := []struct {
+		start, end string
+		want       []string
+		wantErr    bool
+	}{
+		{start: "10.28.4.0", end: "10.28.4.63", want: []string{"10.28.4.0/26"}},
+		{start: "10.28.4.64", end: "10.28.4.191", want: []string{"10.28.4.64/26", "10.28.4.128/26"}},
+		{start: "10.28.4.2", end: "10.28.4.9", want: []string{"10.28.4.2/31", "10.28.4.4/30", "10.28.4.8/31"}},
+		{start: "10.28.4.5", end: "10.28.4.5", want: []string{"10.28.4.5/32"}},
+		{start: "0.0.0.0", end: "255.255.255.255", want: []string{"0.0.0.0/0"}},
+		{start: "10.28.4.9", end: "10.28.4.2", wantErr: true},
+		{start: "10.28.4.0", end: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		cidrs, err := RangeCIDRs(tt.start, tt.end)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("RangeCIDRs(%s, %s) error = %v, wantErr %v", tt.start, tt.end, err, tt.wantErr)
+		}
+		var got []string
+		for _, cidr := range cidrs {
+			got = append(got, cidr.String())
+		}
+		if len(got) != len(tt.want) {
+			t.Fatalf("RangeCIDRs(%s, %s) = %v, want %v", tt.start, tt.end, got, tt.want)
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("RangeCIDRs(%s, %s) = %v, want %v", tt.start, tt.end, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestPeering(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := devopsv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	rack := &devopsv1.ClusterCni{RackTag: "rack-a", RangeStart: "10.28.4.64", RangeEnd: "10.28.4.95"}
+	master := &devopsv1.ClusterMachine{IP: "10.28.0.10", HostCni: rack}
+	worker := func(name string, hostCni *devopsv1.ClusterCni) *devopsv1.Machine {
+		return &devopsv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "c1", Name: name},
+			Spec: devopsv1.MachineSpec{
+				ClusterName: "c1",
+				Machine:     &devopsv1.ClusterMachine{IP: name, HostCni: hostCni},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		masters  []*devopsv1.ClusterMachine
+		machines []runtime.Object
+		want     bool
+	}{
+		{name: "no machines"},
+		{name: "all racks", masters: []*devopsv1.ClusterMachine{master}, machines: []runtime.Object{worker("10.28.0.11", rack)}, want: true},
+		{name: "master without rack", masters: []*devopsv1.ClusterMachine{master, {IP: "10.28.0.12"}}},
+		{name: "worker without rack", masters: []*devopsv1.ClusterMachine{master}, machines: []runtime.Object{worker("10.28.0.11", nil)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &common.Cluster{
+				Cluster: &devopsv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "c1", Name: "c1"},
+					Spec:       devopsv1.ClusterSpec{Machines: tt.masters},
+				},
+				Client: fake.NewFakeClientWithScheme(scheme, tt.machines...),
+			}
+			got, err := Peering(context.TODO(), c)
+			if err != nil || got != tt.want {
+				t.Errorf("Peering() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildNodeObjs(t *testing.T) {
+	hostCni := &devopsv1.ClusterCni{
+		RangeStart: "10.28.4.64",
+		RangeEnd:   "10.28.4.95",
+		RackTag:    "rack-a",
+		GW:         "10.28.0.1",
+	}
+	rack := &devopsv1.Rack{
+		ObjectMeta: metav1.ObjectMeta{Name: "rack-a"},
+		Spec:       devopsv1.RackSpec{Gateway: "10.28.0.254", ASNumber: 65001},
+	}
+
+	tests := []struct {
+		name    string
+		rack    *devopsv1.Rack
+		peerIP  string
+		asn     int64
+		wantErr bool
+	}{
+		{name: "rack", rack: rack, peerIP: "10.28.0.254", asn: 65001},
+		{name: "no rack", peerIP: "10.28.0.1", asn: int64(DefaultASNumber)},
+		{name: "bad gateway", rack: &devopsv1.Rack{Spec: devopsv1.RackSpec{Gateway: "gw"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objs, err := BuildNodeObjs("10.28.0.10", hostCni, tt.rack)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BuildNodeObjs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(objs) != 2 {
+				t.Fatalf("BuildNodeObjs() = %d objs, want 2", len(objs))
+			}
+
+			pool := objs[0].(*unstructured.Unstructured)
+			cidr, _, _ := unstructured.NestedString(pool.Object, "spec", "cidr")
+			blockSize, _, _ := unstructured.NestedInt64(pool.Object, "spec", "blockSize")
+			selector, _, _ := unstructured.NestedString(pool.Object, "spec", "nodeSelector")
+			if pool.GetName() != "node-10-28-0-10-0" || cidr != "10.28.4.64/27" || blockSize != 27 ||
+				selector != "kubernetes.io/hostname == '10.28.0.10'" {
+				t.Errorf("pool = %v", pool.Object)
+			}
+
+			peer := objs[1].(*unstructured.Unstructured)
+			peerIP, _, _ := unstructured.NestedString(peer.Object, "spec", "peerIP")
+			asn, _, _ := unstructured.NestedInt64(peer.Object, "spec", "asNumber")
+			node, _, _ := unstructured.NestedString(peer.Object, "spec", "node")
+			if peer.GetKind() != "BGPPeer" || peerIP != tt.peerIP || asn != tt.asn || node != "10.28.0.10" {
+				t.Errorf("peer = %v", peer.Object)
+			}
+		})
+	}
+}
+
+func TestBuildCalicoAddon(t *testing.T) {
+	c := &common.Cluster{Cluster: &devopsv1.Cluster{}}
+	for _, peering := range []bool{false, true} {
+		objs, err := BuildCalicoAddon(&config.Config{}, c, peering)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// the objects failing to decode are dropped by LoadObjs
+		if want := len(crds) + 10; len(objs) != want {
+			t.Fatalf("BuildCalicoAddon() = %d objs, want %d", len(objs), want)
+		}
+
+		var bgp *unstructured.Unstructured
+		for _, obj := range objs {
+			if u, ok := obj.(*unstructured.Unstructured); ok && u.GetKind() == "BGPConfiguration" {
+				bgp = u
+			}
+		}
+		if bgp == nil {
+			t.Fatalf("BuildCalicoAddon() has no BGPConfiguration in %d objs", len(objs))
+		}
+		mesh, _, _ := unstructured.NestedBool(bgp.Object, "spec", "nodeToNodeMeshEnabled")
+		if mesh == peering {
+			t.Errorf("BuildCalicoAddon(peering %v) nodeToNodeMeshEnabled = %v", peering, mesh)
+		}
+	}
+}
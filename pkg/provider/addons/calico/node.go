@@ -0,0 +1,258 @@
+package calico
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/provider/config"
+	"github.com/gostship/kunkka/pkg/util/k8sutil"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultASNumber is the AS number of the nodes, and of the top of rack switches
+	// without one set in the rack.
+	DefaultASNumber uint32 = 64512
+
+	// DefaultPoolName is the pool of the cluster cidr, used when the nodes have no rack.
+	DefaultPoolName = "default-ipv4-ippool"
+
+	apiVersion       = "crd.projectcalico.org/v1"
+	defaultBlockSize = 26
+)
+
+// Peering reports whether the nodes of the cluster peer with their top of rack switches
+// instead of each other, it is true once every machine of the cluster has the pod range of
+// a rack, as the nodes without one are only reachable through the node to node mesh.
+func Peering(ctx context.Context, c *common.Cluster) (bool, error) {
+	if len(c.Spec.Machines) == 0 {
+		return false, nil
+	}
+	for _, m := range c.Spec.Machines {
+		if !hasRack(m) {
+			return false, nil
+		}
+	}
+
+	machines := &devopsv1.MachineList{}
+	if err := c.Client.List(ctx, machines, client.InNamespace(c.Namespace)); err != nil {
+		return false, errors.Wrapf(err, "list machines of cluster %s", c.Name)
+	}
+	for i := range machines.Items {
+		m := &machines.Items[i]
+		if m.Spec.ClusterName != c.Name || !m.ObjectMeta.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if !hasRack(m.Spec.Machine) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func hasRack(m *devopsv1.ClusterMachine) bool {
+	return m != nil && m.HostCni != nil && m.HostCni.RackTag != "" && m.HostCni.RangeStart != ""
+}
+
+// DefaultPool returns the pool of the cluster cidr, pods get the addresses of it through
+// ipip across the subnets.
+func DefaultPool(cidr string) *unstructured.Unstructured {
+	if cidr == "" {
+		cidr = "10.244.0.0/16"
+	}
+	return newObj("IPPool", DefaultPoolName, map[string]interface{}{
+		"cidr":         cidr,
+		"blockSize":    int64(defaultBlockSize),
+		"ipipMode":     "CrossSubnet",
+		"vxlanMode":    "Never",
+		"natOutgoing":  true,
+		"nodeSelector": "all()",
+	})
+}
+
+// BuildNodeObjs returns the ip pools of the pod range of the node, and the bgp peer of the
+// node with the top of rack switch at the rack gateway. The pod addresses are announced
+// to the switch, so they are routable without encapsulation.
+func BuildNodeObjs(node string, hostCni *devopsv1.ClusterCni, rack *devopsv1.Rack) ([]runtime.Object, error) {
+	cidrs, err := RangeCIDRs(hostCni.RangeStart, hostCni.RangeEnd)
+	if err != nil {
+		return nil, errors.Wrapf(err, "node: %s pod range", node)
+	}
+
+	objs := make([]runtime.Object, 0, len(cidrs)+1)
+	for i, cidr := range cidrs {
+		ones, _ := cidr.Mask.Size()
+		blockSize := defaultBlockSize
+		if ones > blockSize {
+			blockSize = ones
+		}
+		objs = append(objs, newObj("IPPool", poolName(node, i), map[string]interface{}{
+			"cidr":         cidr.String(),
+			"blockSize":    int64(blockSize),
+			"ipipMode":     "Never",
+			"vxlanMode":    "Never",
+			"natOutgoing":  false,
+			"nodeSelector": fmt.Sprintf("kubernetes.io/hostname == '%s'", node),
+		}))
+	}
+
+	peerIP := hostCni.GW
+	asNumber := DefaultASNumber
+	if rack != nil {
+		peerIP = rack.Spec.Gateway
+		if rack.Spec.ASNumber != 0 {
+			asNumber = rack.Spec.ASNumber
+		}
+	}
+	if net.ParseIP(peerIP) == nil {
+		return nil, fmt.Errorf("node: %s rack %s has invalid gateway %q", node, hostCni.RackTag, peerIP)
+	}
+	objs = append(objs, newObj("BGPPeer", peerName(node), map[string]interface{}{
+		"node":     node,
+		"peerIP":   peerIP,
+		"asNumber": int64(asNumber),
+	}))
+	return objs, nil
+}
+
+// DeleteNodeObjs deletes the ip pools and the bgp peer of the machine with a rack pod range,
+// once its node is removed from the cluster. The cluster without calico has none of them.
+func DeleteNodeObjs(ctx context.Context, cli client.Client, m *devopsv1.ClusterMachine) error {
+	if !hasRack(m) {
+		return nil
+	}
+	cidrs, err := RangeCIDRs(m.HostCni.RangeStart, m.HostCni.RangeEnd)
+	if err != nil {
+		return errors.Wrapf(err, "node: %s pod range", m.IP)
+	}
+
+	objs := []*unstructured.Unstructured{newObj("BGPPeer", peerName(m.IP), nil)}
+	for i := range cidrs {
+		objs = append(objs, newObj("IPPool", poolName(m.IP, i), nil))
+	}
+	for _, obj := range objs {
+		err := cli.Delete(ctx, obj)
+		if err != nil && !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			return errors.Wrapf(err, "delete %s %s", obj.GetKind(), obj.GetName())
+		}
+	}
+	return nil
+}
+
+func poolName(node string, i int) string {
+	return fmt.Sprintf("node-%s-%d", strings.ReplaceAll(node, ".", "-"), i)
+}
+
+func peerName(node string) string {
+	return fmt.Sprintf("node-%s-tor", strings.ReplaceAll(node, ".", "-"))
+}
+
+// RangeCIDRs returns the smallest list of cidrs covering the ipv4 range.
+func RangeCIDRs(start, end string) ([]*net.IPNet, error) {
+	s, e := net.ParseIP(start).To4(), net.ParseIP(end).To4()
+	if s == nil || e == nil {
+		return nil, fmt.Errorf("invalid range %s-%s", start, end)
+	}
+	lo, hi := uint64(binary.BigEndian.Uint32(s)), uint64(binary.BigEndian.Uint32(e))
+	if lo > hi {
+		return nil, fmt.Errorf("invalid range %s-%s", start, end)
+	}
+
+	var cidrs []*net.IPNet
+	for lo <= hi {
+		size := uint(0)
+		// the largest block aligned at lo and within the range
+		for size < 32 && lo&(1<<(size+1)-1) == 0 && lo+1<<(size+1)-1 <= hi {
+			size++
+		}
+		ip := make(net.IP, 4)
+		binary.BigEndian.PutUint32(ip, uint32(lo))
+		cidrs = append(cidrs, &net.IPNet{IP: ip, Mask: net.CIDRMask(32-int(size), 32)})
+		lo += 1 << size
+	}
+	return cidrs, nil
+}
+
+// Apply reconciles calico in the cluster, and the ip pools and the bgp peers of the
+// machines once all of them peer with their racks. The default pool of the cluster cidr is
+// removed then, so the pods never get an address of it, and it is kept for the clusters
+// with a machine without a rack.
+func Apply(ctx context.Context, cfg *config.Config, c *common.Cluster, machines []*devopsv1.ClusterMachine) error {
+	clusterCtx, err := c.ClusterManager.Get(c.Name)
+	if err != nil {
+		return nil
+	}
+
+	peering, err := Peering(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	objs, err := BuildCalicoAddon(cfg, c, peering)
+	if err != nil {
+		return errors.Wrapf(err, "build calico err: %v", err)
+	}
+	for _, m := range machines {
+		if !peering || !hasRack(m) {
+			continue
+		}
+
+		rack := &devopsv1.Rack{}
+		err := c.Client.Get(ctx, types.NamespacedName{Name: m.HostCni.RackTag}, rack)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return errors.Wrapf(err, "get rack %s", m.HostCni.RackTag)
+			}
+			rack = nil
+		}
+		nodeObjs, err := BuildNodeObjs(m.IP, m.HostCni, rack)
+		if err != nil {
+			return err
+		}
+		objs = append(objs, nodeObjs...)
+	}
+
+	logger := ctrl.Log.WithValues("cluster", c.Name, "component", "calico")
+	logger.Info("start reconcile ...")
+	for _, obj := range objs {
+		err = k8sutil.Reconcile(logger, clusterCtx.Client, obj, k8sutil.DesiredStatePresent)
+		if err != nil {
+			return errors.Wrapf(err, "Reconcile  err: %v", err)
+		}
+	}
+
+	state := k8sutil.DesiredStatePresent
+	if peering {
+		state = k8sutil.DesiredStateAbsent
+	}
+	err = k8sutil.Reconcile(logger, clusterCtx.Client, DefaultPool(c.Spec.ClusterCIDR), state)
+	if err != nil {
+		return errors.Wrapf(err, "Reconcile  err: %v", err)
+	}
+	return nil
+}
+
+func newObj(kind, name string, spec map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": apiVersion,
+			"kind":       kind,
+			"metadata": map[string]interface{}{
+				"name": name,
+			},
+			"spec": spec,
+		},
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/gostship/kunkka/pkg/constants"
 	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/provider/phases/hostos"
 	"github.com/gostship/kunkka/pkg/util/ssh"
 	"github.com/gostship/kunkka/pkg/util/template"
 	"github.com/pkg/errors"
@@ -49,6 +50,55 @@ DEVICE=eth1
 ONBOOT=yes
 BRIDGE=cni0
 EOF
+`
+
+	// networkdInitShell moves the address of eth1 to the cni0 bridge by systemd-networkd, the
+	// files sort before the ones netplan renders, so they also take effect on the netplan hosts.
+	networkdInitShell = `
+#!/usr/bin/env bash
+
+set -xeuo pipefail
+
+ADDR=$(ip -4 -o addr show dev eth1 | awk '{print $4}' | head -1)
+GATEWAY=$(ip -4 route show default dev eth1 | awk '{print $3}' | head -1)
+if [ -z "${ADDR}" ]; then
+  echo "eth1 has no ipv4 address"
+  exit 1
+fi
+
+mkdir -p /etc/systemd/network
+
+#cni0
+cat << EOF | tee /etc/systemd/network/01-cni0.netdev
+[NetDev]
+Name=cni0
+Kind=bridge
+
+[Bridge]
+STP=yes
+EOF
+
+cat << EOF | tee /etc/systemd/network/01-cni0.network
+[Match]
+Name=cni0
+
+[Network]
+Address=${ADDR}
+EOF
+if [ -n "${GATEWAY}" ]; then
+  echo "Gateway=${GATEWAY}" | tee -a /etc/systemd/network/01-cni0.network
+fi
+
+#eth1
+cat << EOF | tee /etc/systemd/network/01-cni0-eth1.network
+[Match]
+Name=eth1
+
+[Network]
+Bridge=cni0
+EOF
+
+systemctl enable systemd-networkd
 `
 
 	hostLocalTemplate = `
@@ -99,6 +149,8 @@ const (
 	CniHostLocalConfig = "cni-host-local-config"
 	Eth1CfgPath        = "/etc/sysconfig/network-scripts/ifcfg-eth1"
 	Cni0CfgPath        = "/etc/sysconfig/network-scripts/ifcfg-cni0"
+	Cni0NetdevPath     = "/etc/systemd/network/01-cni0.netdev"
+	Eth1SysPath        = "/sys/class/net/eth1"
 )
 
 type Option struct {
@@ -111,8 +163,28 @@ type Option struct {
 	Gw         string `json:"gw,omitempty"`
 }
 
+// Type returns the cni plugin of the cluster, calico if it is the network type of the
+// cluster, otherwise the cniInstall hook.
+func Type(c *common.Cluster) (string, bool) {
+	if c.Spec.NetworkType == devopsv1.NetworkTypeCalico {
+		return string(devopsv1.NetworkTypeCalico), true
+	}
+	cniType, ok := c.Cluster.Spec.Features.Hooks[devopsv1.HookCniInstall]
+	return cniType, ok
+}
+
+// ApplyEth moves the address of eth1 to the cni0 bridge, by network-scripts on the rhel
+// hosts and systemd-networkd on the debian hosts.
 func ApplyEth(s ssh.Interface, c *common.Cluster) error {
-	err := s.WriteFile(strings.NewReader(cniInitShell), constants.SystemInitCniFile)
+	o, err := hostos.Detect(s)
+	if err != nil {
+		return err
+	}
+	if o.Family == hostos.Debian {
+		return applyNetworkdEth(s)
+	}
+
+	err = s.WriteFile(strings.NewReader(cniInitShell), constants.SystemInitCniFile)
 	if err != nil {
 		return err
 	}
@@ -140,9 +212,38 @@ func ApplyEth(s ssh.Interface, c *common.Cluster) error {
 	return nil
 }
 
-func ApplyClusterCni(s ssh.Interface, c *common.Cluster, machine *devopsv1.ClusterMachine) error {
+func applyNetworkdEth(s ssh.Interface) error {
+	err := s.WriteFile(strings.NewReader(networkdInitShell), constants.SystemInitCniFile)
+	if err != nil {
+		return err
+	}
 
-	opt := &Option{
+	if exist, _ := s.Exist(Cni0NetdevPath); exist {
+		klog.Warningf("node: %s file: %s always exist", s.HostIP(), Cni0NetdevPath)
+		return nil
+	}
+
+	if exist, _ := s.Exist(Eth1SysPath); !exist {
+		klog.Warningf("node: %s eth1 not exist", s.HostIP())
+		return nil
+	}
+
+	klog.Infof("node: %s start exec init eth ... ", s.HostIP())
+	cmd := fmt.Sprintf("chmod a+x %s && %s", constants.SystemInitCniFile, constants.SystemInitCniFile)
+	exit, err := s.ExecStream(cmd, os.Stdout, os.Stderr)
+	if err != nil {
+		klog.Errorf("%q %+v", exit, err)
+		return errors.Wrapf(err, "node: %s exec cmd: %s", s.HostIP(), cmd)
+	}
+
+	// the connection may be on eth1, so the network restarts in the background
+	klog.Infof("node: %s restart systemd-networkd", s.HostIP())
+	_, _ = s.CombinedOutput("nohup sh -c 'ip addr flush dev eth1; systemctl restart systemd-networkd' > /dev/null 2>&1 &")
+	return nil
+}
+
+func clusterCniOption(machine *devopsv1.ClusterMachine) *Option {
+	return &Option{
 		Subnet:     machine.HostCni.Subnet,
 		RangeEnd:   machine.HostCni.RangeEnd,
 		RangeStart: machine.HostCni.RangeStart,
@@ -150,7 +251,18 @@ func ApplyClusterCni(s ssh.Interface, c *common.Cluster, machine *devopsv1.Clust
 		Dst:        machine.HostCni.DefaultRoute,
 		Gw:         machine.IP,
 	}
+}
 
+// BuildHostLocalConfig renders the host-local cni config of the machine.
+func BuildHostLocalConfig(machine *devopsv1.ClusterMachine) ([]byte, error) {
+	if machine.HostCni == nil {
+		return nil, fmt.Errorf("machine %s has no hostCni", machine.IP)
+	}
+	return template.ParseString(hostLocalTemplate, clusterCniOption(machine))
+}
+
+func ApplyClusterCni(s ssh.Interface, c *common.Cluster, machine *devopsv1.ClusterMachine) error {
+	opt := clusterCniOption(machine)
 	localByte, err := template.ParseString(hostLocalTemplate, opt)
 	if err != nil {
 		return err
@@ -176,9 +288,8 @@ func ApplyClusterCni(s ssh.Interface, c *common.Cluster, machine *devopsv1.Clust
 	return nil
 }
 
-func ApplyNodeCni(s ssh.Interface, c *common.Cluster, machine *devopsv1.Machine) error {
-
-	opt := &Option{
+func nodeCniOption(machine *devopsv1.Machine) *Option {
+	return &Option{
 		Subnet:     machine.Spec.Machine.HostCni.Subnet,
 		RangeEnd:   machine.Spec.Machine.HostCni.RangeEnd,
 		RangeStart: machine.Spec.Machine.HostCni.RangeStart,
@@ -186,7 +297,18 @@ func ApplyNodeCni(s ssh.Interface, c *common.Cluster, machine *devopsv1.Machine)
 		Dst:        machine.Spec.Machine.HostCni.DefaultRoute,
 		Gw:         machine.Name,
 	}
+}
 
+// BuildNodeHostLocalConfig renders the host-local cni config of the node.
+func BuildNodeHostLocalConfig(machine *devopsv1.Machine) ([]byte, error) {
+	if machine.Spec.Machine == nil || machine.Spec.Machine.HostCni == nil {
+		return nil, fmt.Errorf("machine %s has no hostCni", machine.Name)
+	}
+	return template.ParseString(hostLocalTemplate, nodeCniOption(machine))
+}
+
+func ApplyNodeCni(s ssh.Interface, c *common.Cluster, machine *devopsv1.Machine) error {
+	opt := nodeCniOption(machine)
 	localByte, err := template.ParseString(hostLocalTemplate, opt)
 	if err != nil {
 		return err
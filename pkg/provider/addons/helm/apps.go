@@ -0,0 +1,127 @@
+package helm
+
+import (
+	"context"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/controllers/k8smanager"
+	"github.com/gostship/kunkka/pkg/util/pkiutil"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// ReconcileApps installs or upgrades every app of the cluster spec and
+// uninstalls the apps removed from the spec, the result of each release
+// is recorded in the cluster status.
+func ReconcileApps(ctx context.Context, c *common.Cluster) error {
+	if len(c.Spec.Apps) == 0 && len(c.Cluster.Status.Apps) == 0 {
+		return nil
+	}
+
+	clusterCtx, err := clusterClient(c)
+	if err != nil {
+		return err
+	}
+
+	logger := ctrl.Log.WithValues("cluster", c.Name, "component", "helm")
+	h, err := NewClient(clusterCtx, logger)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	desired := map[string]bool{}
+	for _, app := range c.Spec.Apps {
+		if app == nil {
+			continue
+		}
+		app = app.DeepCopy()
+		if app.Namespace == "" {
+			app.Namespace = metav1.NamespaceDefault
+		}
+		desired[app.Namespace+"/"+app.Name] = true
+
+		status := devopsv1.AppStatus{
+			Name:           app.Name,
+			Namespace:      app.Namespace,
+			ChartName:      app.ChartName,
+			LastUpdateTime: metav1.Now(),
+		}
+		if old := findAppStatus(c.Cluster, app.Name, app.Namespace); old != nil {
+			status.ChartVersion = old.ChartVersion
+			status.Revision = old.Revision
+		}
+
+		rel, err := h.Apply(app)
+		if err != nil {
+			klog.Errorf("cluster: %s apply app %s/%s err: %v", c.Name, app.Namespace, app.Name, err)
+			status.Phase = devopsv1.AppFailed
+			status.Message = err.Error()
+			errs = append(errs, errors.Wrapf(err, "app %s/%s", app.Namespace, app.Name))
+		} else {
+			klog.Infof("cluster: %s app %s/%s deployed chart %s-%s revision %d", c.Name, app.Namespace, app.Name, rel.Chart.Name(), rel.Chart.Metadata.Version, rel.Version)
+			status.Phase = devopsv1.AppDeployed
+			status.ChartVersion = rel.Chart.Metadata.Version
+			status.Revision = int32(rel.Version)
+		}
+		setAppStatus(c.Cluster, status)
+	}
+
+	apps := make([]devopsv1.AppStatus, 0, len(c.Cluster.Status.Apps))
+	for _, status := range c.Cluster.Status.Apps {
+		if desired[status.Namespace+"/"+status.Name] {
+			apps = append(apps, status)
+			continue
+		}
+
+		err := h.Uninstall(status.Name, status.Namespace)
+		if err != nil {
+			klog.Errorf("cluster: %s uninstall app %s/%s err: %v", c.Name, status.Namespace, status.Name, err)
+			status.Phase = devopsv1.AppFailed
+			status.Message = err.Error()
+			status.LastUpdateTime = metav1.Now()
+			apps = append(apps, status)
+			errs = append(errs, errors.Wrapf(err, "uninstall app %s/%s", status.Namespace, status.Name))
+			continue
+		}
+		klog.Infof("cluster: %s app %s/%s uninstalled", c.Name, status.Namespace, status.Name)
+	}
+	c.Cluster.Status.Apps = apps
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// clusterClient returns the workload cluster client, the cluster is added
+// to the manager when the external kubeconfig is already generated.
+func clusterClient(c *common.Cluster) (*k8smanager.Cluster, error) {
+	if clusterCtx, err := c.ClusterManager.Get(c.Name); err == nil {
+		return clusterCtx, nil
+	}
+
+	extKubeconfig, ok := c.ClusterCredential.ExtData[pkiutil.ExternalAdminKubeConfigFileName]
+	if !ok {
+		return nil, errors.Errorf("cluster: %s kubeconfig not ready", c.Name)
+	}
+	return c.ClusterManager.AddNewClusters(c.Name, extKubeconfig)
+}
+
+func findAppStatus(cls *devopsv1.Cluster, name, namespace string) *devopsv1.AppStatus {
+	for i := range cls.Status.Apps {
+		if cls.Status.Apps[i].Name == name && cls.Status.Apps[i].Namespace == namespace {
+			return &cls.Status.Apps[i]
+		}
+	}
+	return nil
+}
+
+func setAppStatus(cls *devopsv1.Cluster, status devopsv1.AppStatus) {
+	if old := findAppStatus(cls, status.Name, status.Namespace); old != nil {
+		*old = status
+		return
+	}
+	cls.Status.Apps = append(cls.Status.Apps, status)
+}
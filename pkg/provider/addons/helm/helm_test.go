@@ -0,0 +1,299 @@
+package helm
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+)
+
+func TestMergeValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		app     *devopsv1.HelmChartSpec
+		want    Values
+		wantErr bool
+	}{
+		{
+			name: "override value",
+			app: &devopsv1.HelmChartSpec{
+				OverrideValue: "image:\n  tag: v1\n",
+			},
+			want: Values{"image": map[string]interface{}{"tag": "v1"}},
+		},
+		{
+			name: "typed set values",
+			app: &devopsv1.HelmChartSpec{
+				OverrideValue: "image:\n  tag: v1\n",
+				Values: map[string]string{
+					"image.tag":                         "v2",
+					"replicas":                          "3",
+					"enabled":                           "true",
+					"hosts":                             "{a,b}",
+					"nodeSelector\\.kubernetes\\.io/os": "linux",
+					"list[1].name":                      "x",
+				},
+			},
+			want: Values{
+				"image":                         map[string]interface{}{"tag": "v2"},
+				"replicas":                      int64(3),
+				"enabled":                       true,
+				"hosts":                         []interface{}{"a", "b"},
+				"nodeSelector.kubernetes.io/os": "linux",
+				"list":                          []interface{}{nil, map[string]interface{}{"name": "x"}},
+			},
+		},
+		{
+			name: "raw value set wins",
+			app: &devopsv1.HelmChartSpec{
+				Values:      map[string]string{"replicas": "3"},
+				RawValueSet: map[string]string{"replicas": "03"},
+			},
+			want: Values{"replicas": "03"},
+		},
+		{
+			name: "invalid key",
+			app: &devopsv1.HelmChartSpec{
+				Values: map[string]string{"a..b": "1"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MergeValues(tt.app)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MergeValues() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MergeValues() got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+var testChart = map[string]string{
+	"demo/Chart.yaml":             "apiVersion: v2\nname: demo\nversion: 0.1.0\ndependencies:\n- name: sub\n  condition: sub.enabled\n",
+	"demo/values.yaml":            "replicas: 1\nglobal:\n  registry: docker.io\nsub:\n  enabled: true\n",
+	"demo/templates/_helpers.tpl": `{{- define "demo.name" -}}{{ .Release.Name }}-{{ .Chart.Name }}{{- end -}}`,
+	"demo/templates/deploy.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ include "demo.name" . }}
+spec:
+  replicas: {{ .Values.replicas }}
+  template:
+    spec:
+      containers:
+      - image: {{ .Values.global.registry }}/demo
+`,
+	"demo/templates/NOTES.txt":           "installed",
+	"demo/charts/sub/Chart.yaml":         "apiVersion: v2\nname: sub\nversion: 0.0.1\n",
+	"demo/charts/sub/values.yaml":        "port: 80\n",
+	"demo/charts/sub/templates/svc.yaml": "apiVersion: v1\nkind: Service\nmetadata:\n  name: sub\nspec:\n  ports:\n  - port: {{ .Values.port }}\n# {{ .Values.global.registry }}\n",
+}
+
+func packChart(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, data := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tw.Close()
+	gz.Close()
+	return buf.Bytes()
+}
+
+// render renders the chart of the app like helm install --dry-run does.
+func render(t *testing.T, c *chart.Chart, app *devopsv1.HelmChartSpec) string {
+	vals, err := MergeValues(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	install := action.NewInstall(&action.Configuration{})
+	install.DryRun = true
+	install.ClientOnly = true
+	install.ReleaseName = app.Name
+	install.Namespace = "default"
+	rel, err := install.Run(c, vals)
+	if err != nil {
+		t.Fatalf("install.Run() error = %v", err)
+	}
+	return rel.Manifest
+}
+
+func TestFetchAndRender(t *testing.T) {
+	archive := packChart(t, testChart)
+	downloads := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/index.yaml":
+			fmt.Fprint(w, "apiVersion: v1\nentries:\n  demo:\n  - name: demo\n    version: 0.1.0\n    urls: [charts/demo-0.1.0.tgz]\n  - name: demo\n    version: 0.0.9\n    urls: [charts/demo-0.0.9.tgz]\n")
+		case "/charts/demo-0.1.0.tgz":
+			downloads++
+			w.Write(archive)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	// a registry serving the chart as an oci artifact, demo:0.1.0 only, localhost registries
+	// are pulled by plain http
+	manifest := ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		Config:    ocispec.Descriptor{MediaType: "application/vnd.cncf.helm.config.v1+json", Digest: digest.FromString("{}"), Size: 2},
+		Layers: []ocispec.Descriptor{
+			{MediaType: chartLayerMediaTypes[0], Digest: digest.FromBytes(archive), Size: int64(len(archive))},
+		},
+	}
+	manifestData, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/charts/demo/manifests/0.1.0", "/v2/charts/demo/manifests/" + digest.FromBytes(manifestData).String():
+			w.Header().Set("Content-Type", ocispec.MediaTypeImageManifest)
+			w.Header().Set("Docker-Content-Digest", digest.FromBytes(manifestData).String())
+			w.Header().Set("Content-Length", strconv.Itoa(len(manifestData)))
+			w.Write(manifestData)
+		case "/v2/charts/demo/blobs/" + digest.FromBytes(archive).String():
+			w.Write(archive)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer registry.Close()
+	cacheDir, err := ioutil.TempDir("", "charts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(cacheDir)
+	getter := NewGetter(&http.Client{Timeout: 10 * time.Second}, cacheDir, "")
+	ociRepo := "oci://" + strings.TrimPrefix(registry.URL, "http://") + "/charts"
+
+	tests := []struct {
+		name      string
+		app       *devopsv1.HelmChartSpec
+		contains  []string
+		templates int
+		wantErr   bool
+	}{
+		{
+			name:      "latest version",
+			app:       &devopsv1.HelmChartSpec{Name: "r1", Repo: srv.URL, ChartName: "demo"},
+			contains:  []string{"name: r1-demo", "replicas: 1", "docker.io/demo", "port: 80", "# docker.io"},
+			templates: 2,
+		},
+		{
+			name: "values and disabled subchart",
+			app: &devopsv1.HelmChartSpec{Name: "r2", Repo: srv.URL, ChartName: "demo", ChartVersion: "0.1.0",
+				Values: map[string]string{"replicas": "2", "sub.enabled": "false", "global.registry": "local"}},
+			contains:  []string{"replicas: 2", "local/demo"},
+			templates: 1,
+		},
+		{
+			name:    "missing version",
+			app:     &devopsv1.HelmChartSpec{Name: "r3", Repo: srv.URL, ChartName: "demo", ChartVersion: "1.0.0"},
+			wantErr: true,
+		},
+		{
+			name:      "oci registry",
+			app:       &devopsv1.HelmChartSpec{Name: "r4", Repo: ociRepo, ChartName: "demo", ChartVersion: "0.1.0"},
+			contains:  []string{"name: r4-demo", "port: 80"},
+			templates: 2,
+		},
+		{
+			name:    "oci missing tag",
+			app:     &devopsv1.HelmChartSpec{Name: "r5", Repo: ociRepo, ChartName: "demo", ChartVersion: "0.2.0"},
+			wantErr: true,
+		},
+		{
+			name:    "oci without version",
+			app:     &devopsv1.HelmChartSpec{Name: "r6", Repo: ociRepo, ChartName: "demo"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := getter.Fetch(tt.app)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Fetch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			manifest := render(t, c, tt.app)
+			if n := strings.Count(manifest, "# Source:"); n != tt.templates {
+				t.Errorf("render() got %d templates, want %d", n, tt.templates)
+			}
+			for _, s := range tt.contains {
+				if !strings.Contains(manifest, s) {
+					t.Errorf("manifest missing %q:\n%s", s, manifest)
+				}
+			}
+		})
+	}
+	if downloads != 1 {
+		t.Errorf("chart archive downloaded %d times, want it cached", downloads)
+	}
+}
+
+func TestFetchLocal(t *testing.T) {
+	getter := NewGetter(http.DefaultClient, "", "../../../../charts")
+
+	tests := []struct {
+		name    string
+		repo    string
+		wantErr bool
+	}{
+		{name: "chart root", repo: "file://"},
+		{name: "relative dir", repo: "file://kunkka-api"},
+		{name: "out of the root", repo: "file://../pkg", wantErr: true},
+		{name: "absolute out of the root", repo: "/etc", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &devopsv1.HelmChartSpec{Name: "api", Repo: tt.repo, ChartName: "kunkka-api"}
+			c, err := getter.Fetch(app)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Fetch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if manifest := render(t, c, app); !strings.Contains(manifest, "kind: Deployment") {
+				t.Errorf("render() got no deployment:\n%s", manifest)
+			}
+		})
+	}
+
+	if _, err := NewGetter(http.DefaultClient, "", "").Fetch(&devopsv1.HelmChartSpec{Repo: "file://../../../../charts", ChartName: "kunkka-api"}); err == nil {
+		t.Errorf("Fetch() loads a local chart without a chart root")
+	}
+}
@@ -0,0 +1,183 @@
+package helm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/controllers/k8smanager"
+	"github.com/pkg/errors"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/kube"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	// releaseTimeout bounds the hooks of a release.
+	releaseTimeout = 5 * time.Minute
+	// releaseMaxHistory is the number of revisions kept of a release.
+	releaseMaxHistory = 10
+)
+
+// Client installs, upgrades and uninstalls the releases of the apps on a
+// workload cluster with the helm sdk, the releases are stored in secrets of
+// the app namespace like the helm cli does.
+type Client struct {
+	Name string
+	Log  logr.Logger
+
+	config     *rest.Config
+	kubeconfig []byte
+	kubeCli    kubernetes.Interface
+	discovery  discovery.CachedDiscoveryInterface
+	mapper     meta.RESTMapper
+}
+
+// NewClient builds a release client for the cluster.
+func NewClient(cls *k8smanager.Cluster, log logr.Logger) (*Client, error) {
+	dc := memory.NewMemCacheClient(cls.KubeCli.Discovery())
+	if _, err := dc.ServerVersion(); err != nil {
+		return nil, errors.Wrapf(err, "discover cluster %s version", cls.Name)
+	}
+
+	return &Client{
+		Name:       cls.Name,
+		Log:        log,
+		config:     cls.RestConfig,
+		kubeconfig: cls.RawKubeconfig,
+		kubeCli:    cls.KubeCli,
+		discovery:  dc,
+		mapper:     restmapper.NewDeferredDiscoveryRESTMapper(dc),
+	}, nil
+}
+
+// actionConfig returns the helm action configuration of the releases in the namespace.
+func (h *Client) actionConfig(namespace string) *action.Configuration {
+	getter := &restClientGetter{client: h, namespace: namespace}
+	kc := kube.New(getter)
+	kc.Namespace = namespace
+	kc.Log = h.debugf
+
+	d := driver.NewSecrets(h.kubeCli.CoreV1().Secrets(namespace))
+	d.Log = h.debugf
+	releases := storage.Init(d)
+	releases.Log = h.debugf
+
+	return &action.Configuration{
+		RESTClientGetter: getter,
+		Releases:         releases,
+		KubeClient:       kc,
+		Log:              h.debugf,
+	}
+}
+
+// Apply installs the app, or upgrades it when the rendered manifest or the
+// chart version differs from the deployed release.
+func (h *Client) Apply(app *devopsv1.HelmChartSpec) (*release.Release, error) {
+	c, err := Fetch(app)
+	if err != nil {
+		return nil, err
+	}
+	vals, err := MergeValues(app)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := h.actionConfig(app.Namespace)
+	last, err := cfg.Releases.Last(app.Name)
+	if err != nil && !errors.Is(err, driver.ErrReleaseNotFound) {
+		return nil, errors.Wrapf(err, "get release %s/%s", app.Namespace, app.Name)
+	}
+
+	if last == nil {
+		install := action.NewInstall(cfg)
+		install.ReleaseName = app.Name
+		install.Namespace = app.Namespace
+		install.CreateNamespace = true
+		install.Timeout = releaseTimeout
+		rel, err := install.Run(c, vals)
+		if err != nil {
+			return nil, errors.Wrapf(err, "install release %s/%s", app.Namespace, app.Name)
+		}
+		return rel, nil
+	}
+
+	upgrade := action.NewUpgrade(cfg)
+	upgrade.Namespace = app.Namespace
+	upgrade.Timeout = releaseTimeout
+	upgrade.MaxHistory = releaseMaxHistory
+	if last.Info.Status == release.StatusDeployed {
+		upgrade.DryRun = true
+		rel, err := upgrade.Run(app.Name, c, vals)
+		if err != nil {
+			return nil, errors.Wrapf(err, "upgrade release %s/%s", app.Namespace, app.Name)
+		}
+		if rel.Manifest == last.Manifest && rel.Chart.Metadata.Version == last.Chart.Metadata.Version {
+			return last, nil
+		}
+		upgrade.DryRun = false
+	}
+
+	rel, err := upgrade.Run(app.Name, c, vals)
+	if err != nil {
+		return nil, errors.Wrapf(err, "upgrade release %s/%s", app.Namespace, app.Name)
+	}
+	return rel, nil
+}
+
+// Uninstall deletes the release resources and the release history.
+func (h *Client) Uninstall(name, namespace string) error {
+	uninstall := action.NewUninstall(h.actionConfig(namespace))
+	uninstall.Timeout = releaseTimeout
+	_, err := uninstall.Run(name)
+	if err != nil && !errors.Is(err, driver.ErrReleaseNotFound) {
+		return errors.Wrapf(err, "uninstall release %s/%s", namespace, name)
+	}
+	return nil
+}
+
+func (h *Client) debugf(format string, v ...interface{}) {
+	h.Log.V(1).Info(fmt.Sprintf(format, v...))
+}
+
+// restClientGetter hands the workload cluster clients to the helm sdk.
+type restClientGetter struct {
+	client    *Client
+	namespace string
+}
+
+var _ genericclioptions.RESTClientGetter = &restClientGetter{}
+
+func (g *restClientGetter) ToRESTConfig() (*rest.Config, error) {
+	return rest.CopyConfig(g.client.config), nil
+}
+
+func (g *restClientGetter) ToDiscoveryClient() (discovery.CachedDiscoveryInterface, error) {
+	return g.client.discovery, nil
+}
+
+func (g *restClientGetter) ToRESTMapper() (meta.RESTMapper, error) {
+	return g.client.mapper, nil
+}
+
+func (g *restClientGetter) ToRawKubeConfigLoader() clientcmd.ClientConfig {
+	cfg, err := clientcmd.Load(g.client.kubeconfig)
+	if err != nil {
+		cfg = clientcmdapi.NewConfig()
+	}
+	return clientcmd.NewDefaultClientConfig(*cfg, &clientcmd.ConfigOverrides{
+		Context: clientcmdapi.Context{Namespace: g.namespace},
+	})
+}
@@ -0,0 +1,391 @@
+package helm
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/containerd/containerd/remotes/docker"
+	orascontent "github.com/deislabs/oras/pkg/content"
+	"github.com/deislabs/oras/pkg/oras"
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/pkg/errors"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/downloader"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/helmpath"
+	"helm.sh/helm/v3/pkg/provenance"
+	"helm.sh/helm/v3/pkg/repo"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/klog"
+)
+
+const (
+	// chartCacheSize is the number of pulled oci chart archives kept in memory.
+	chartCacheSize = 32
+	chartCacheTTL  = time.Hour
+	// indexTTL is how long the downloaded index of a chart repository is used before it is
+	// downloaded again, a chart or version missing from it downloads it at once.
+	indexTTL = 10 * time.Minute
+)
+
+// chartLayerMediaTypes are the media types of the chart archive layer of an oci artifact,
+// pushed by helm 3.7+ and by the experimental registry support of older helm releases.
+var chartLayerMediaTypes = []string{
+	"application/vnd.cncf.helm.chart.content.v1.tar+gzip",
+	"application/tar+gzip",
+}
+
+// Getter downloads charts from chart repositories and oci registries, and loads the local
+// charts under its chart root.
+type Getter struct {
+	Client *http.Client
+	// CacheDir keeps the indexes and the chart archives of the chart repositories.
+	CacheDir string
+	// ChartRoot is the dir the local charts are loaded from, they are refused if it is empty.
+	ChartRoot string
+
+	// mu guards the repositories file and the indexes in the cache dir.
+	mu    sync.Mutex
+	cache *cache.LRUExpireCache
+}
+
+// NewGetter returns a getter which keeps the indexes and the chart archives of the chart
+// repositories in cacheDir, and loads the local charts under chartRoot only.
+func NewGetter(cli *http.Client, cacheDir, chartRoot string) *Getter {
+	return &Getter{
+		Client:    cli,
+		CacheDir:  cacheDir,
+		ChartRoot: chartRoot,
+		cache:     cache.NewLRUExpireCache(chartCacheSize),
+	}
+}
+
+var (
+	defaultGetterLock sync.RWMutex
+	defaultGetter     = NewGetter(&http.Client{Timeout: 60 * time.Second}, filepath.Join(os.TempDir(), "kunkka-charts"), "")
+)
+
+// SetDefaultGetter sets the getter used by Fetch, the controllers set it on start.
+func SetDefaultGetter(g *Getter) {
+	defaultGetterLock.Lock()
+	defer defaultGetterLock.Unlock()
+
+	defaultGetter = g
+}
+
+// Fetch resolves the chart described by the app spec and loads it.
+// Repo may be a directory under the chart root (optionally prefixed with file://) holding
+// the chart directory or a packaged chart, a http(s) chart repository or
+// an oci:// registry path the chart is pushed to by tag ChartVersion.
+func Fetch(app *devopsv1.HelmChartSpec) (*chart.Chart, error) {
+	defaultGetterLock.RLock()
+	g := defaultGetter
+	defaultGetterLock.RUnlock()
+
+	return g.Fetch(app)
+}
+
+// Fetch resolves the chart described by the app spec and loads it.
+func (g *Getter) Fetch(app *devopsv1.HelmChartSpec) (*chart.Chart, error) {
+	repo := strings.TrimSpace(app.Repo)
+	switch {
+	case strings.HasPrefix(repo, "oci://"):
+		return g.fetchOCI(repo, app.ChartName, app.ChartVersion)
+	case strings.HasPrefix(repo, "http://"), strings.HasPrefix(repo, "https://"):
+		return g.fetchRemote(repo, app.ChartName, app.ChartVersion)
+	default:
+		return g.fetchLocal(strings.TrimPrefix(repo, "file://"), app.ChartName, app.ChartVersion)
+	}
+}
+
+func (g *Getter) fetchLocal(repo, name, version string) (*chart.Chart, error) {
+	dir, err := g.localPath(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := []string{
+		filepath.Join(dir, name),
+		filepath.Join(dir, fmt.Sprintf("%s-%s.tgz", name, version)),
+		dir,
+	}
+	for _, p := range candidates {
+		if _, err := os.Stat(p); err != nil {
+			continue
+		}
+		if p, err = g.localPath(p); err != nil {
+			return nil, err
+		}
+		c, err := loader.Load(p)
+		if err != nil {
+			return nil, errors.Wrapf(err, "load %s", p)
+		}
+		if c.Name() != name {
+			continue
+		}
+		if err := checkVersion(c, version); err != nil {
+			return nil, errors.Wrapf(err, "repo %s", repo)
+		}
+		return c, nil
+	}
+
+	return nil, errors.Errorf("chart %s not found in %s", name, repo)
+}
+
+// localPath resolves the path of a local chart under the chart root, relative paths are
+// relative to the root. The paths out of the root, symlinks included, are refused.
+func (g *Getter) localPath(p string) (string, error) {
+	if g.ChartRoot == "" {
+		return "", errors.Errorf("local chart %s is refused, no chart root is set", p)
+	}
+	root, err := filepath.Abs(g.ChartRoot)
+	if err != nil {
+		return "", errors.Wrapf(err, "chart root %s", g.ChartRoot)
+	}
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(root, p)
+	}
+	p = filepath.Clean(p)
+	if !withinDir(root, p) {
+		return "", errors.Errorf("local chart %s is out of the chart root %s", p, root)
+	}
+
+	// the symlinks under the root may point out of it
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return "", errors.Wrapf(err, "chart root %s", root)
+	}
+	realPath, err := filepath.EvalSymlinks(p)
+	if os.IsNotExist(err) {
+		return p, nil
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "local chart %s", p)
+	}
+	if !withinDir(realRoot, realPath) {
+		return "", errors.Errorf("local chart %s is out of the chart root %s", p, root)
+	}
+	return p, nil
+}
+
+func withinDir(dir, p string) bool {
+	rel, err := filepath.Rel(dir, p)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// fetchRemote resolves the chart in the index of the chart repository and downloads it by the
+// helm chart downloader, the index and the archive are kept in the cache dir.
+func (g *Getter) fetchRemote(repoURL, name, version string) (*chart.Chart, error) {
+	entry := &repo.Entry{
+		Name: fmt.Sprintf("repo-%x", sha256.Sum256([]byte(repoURL)))[:21],
+		URL:  strings.TrimSuffix(repoURL, "/"),
+	}
+	cv, err := g.chartVersion(entry, name, version)
+	if err != nil {
+		return nil, err
+	}
+	if len(cv.URLs) == 0 {
+		return nil, errors.Errorf("chart %s-%s has no download url", cv.Name, cv.Version)
+	}
+	chartURL, err := repo.ResolveReferenceURL(entry.URL, cv.URLs[0])
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(g.CacheDir, "charts", entry.Name)
+	archive := filepath.Join(dir, path.Base(chartURL))
+	if !g.cachedArchive(archive, cv.Digest) {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, errors.Wrapf(err, "create chart cache %s", dir)
+		}
+		dl := &downloader.ChartDownloader{
+			Out:              ioutil.Discard,
+			Verify:           downloader.VerifyNever,
+			Getters:          getters,
+			RepositoryConfig: g.repositoryConfig(),
+			RepositoryCache:  g.CacheDir,
+		}
+		archive, _, err = dl.DownloadTo(entry.Name+"/"+name, cv.Version, dir)
+		if err != nil {
+			return nil, errors.Wrapf(err, "download chart %s-%s from %s", name, cv.Version, repoURL)
+		}
+		klog.V(4).Infof("download %s to %s", chartURL, archive)
+		if !g.cachedArchive(archive, cv.Digest) {
+			os.Remove(archive)
+			return nil, errors.Errorf("chart %s digest mismatch, expect %s", chartURL, cv.Digest)
+		}
+	}
+
+	c, err := loader.Load(archive)
+	if err != nil {
+		return nil, errors.Wrapf(err, "load %s", chartURL)
+	}
+	return c, nil
+}
+
+// chartVersion returns the version of the chart in the index of the repository, the index is
+// downloaded again once it is older than indexTTL or misses the chart.
+func (g *Getter) chartVersion(entry *repo.Entry, name, version string) (*repo.ChartVersion, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	indexFile := filepath.Join(g.CacheDir, helmpath.CacheIndexFile(entry.Name))
+	if fi, err := os.Stat(indexFile); err == nil && time.Since(fi.ModTime()) < indexTTL {
+		index, err := repo.LoadIndexFile(indexFile)
+		if err == nil {
+			if cv, err := index.Get(name, version); err == nil {
+				return cv, nil
+			}
+		}
+	}
+
+	r, err := repo.NewChartRepository(entry, getters)
+	if err != nil {
+		return nil, err
+	}
+	r.CachePath = g.CacheDir
+	indexFile, err = r.DownloadIndexFile()
+	if err != nil {
+		return nil, errors.Wrapf(err, "download the index of %s", entry.URL)
+	}
+	if err := g.updateRepositoryConfig(entry); err != nil {
+		return nil, err
+	}
+
+	index, err := repo.LoadIndexFile(indexFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "load the index of %s", entry.URL)
+	}
+	cv, err := index.Get(name, version)
+	if err != nil {
+		return nil, errors.Wrapf(err, "repo %s chart %s", entry.URL, name)
+	}
+	return cv, nil
+}
+
+// repositoryConfig is the repositories file of the chart downloader in the cache dir.
+func (g *Getter) repositoryConfig() string {
+	return filepath.Join(g.CacheDir, "repositories.yaml")
+}
+
+// updateRepositoryConfig adds the repository to the repositories file, the chart downloader
+// resolves the charts of the repositories in it.
+func (g *Getter) updateRepositoryConfig(entry *repo.Entry) error {
+	f, err := repo.LoadFile(g.repositoryConfig())
+	if err != nil {
+		if _, statErr := os.Stat(g.repositoryConfig()); !os.IsNotExist(statErr) {
+			return err
+		}
+		f = repo.NewFile()
+	}
+	if f.Has(entry.Name) {
+		return nil
+	}
+	f.Update(entry)
+	return f.WriteFile(g.repositoryConfig(), 0644)
+}
+
+// cachedArchive reports whether the archive is in the cache dir with the digest of the index.
+func (g *Getter) cachedArchive(archive, digest string) bool {
+	if _, err := os.Stat(archive); err != nil {
+		return false
+	}
+	if digest == "" {
+		return true
+	}
+	sum, err := provenance.DigestFile(archive)
+	return err == nil && sum == digest
+}
+
+// getters are the protocols of the chart repositories.
+var getters = getter.Providers{{
+	Schemes: []string{"http", "https"},
+	New:     getter.NewHTTPGetter,
+}}
+
+func (g *Getter) fetchOCI(repo, name, version string) (*chart.Chart, error) {
+	if version == "" {
+		return nil, errors.Errorf("chart %s in %s has no version, oci charts are pulled by tag", name, repo)
+	}
+	ref := fmt.Sprintf("%s/%s:%s", strings.TrimSuffix(strings.TrimPrefix(repo, "oci://"), "/"), name,
+		strings.Replace(version, "+", "_", -1))
+
+	data, err := g.cached(ref, func() ([]byte, error) {
+		return g.pull(ref)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := loader.LoadArchive(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrapf(err, "load %s", ref)
+	}
+	if c.Name() != name {
+		return nil, errors.Errorf("%s is chart %s, want %s", ref, c.Name(), name)
+	}
+	if err := checkVersion(c, version); err != nil {
+		return nil, errors.Wrapf(err, "pull %s", ref)
+	}
+	return c, nil
+}
+
+// pull downloads the chart archive layer of the oci artifact.
+func (g *Getter) pull(ref string) ([]byte, error) {
+	ctx := context.Background()
+	if g.Client.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, g.Client.Timeout)
+		defer cancel()
+	}
+
+	resolver := docker.NewResolver(docker.ResolverOptions{Client: g.Client})
+	store := orascontent.NewMemoryStore()
+	_, layers, err := oras.Pull(ctx, resolver, ref, store,
+		oras.WithPullEmptyNameAllowed(),
+		oras.WithAllowedMediaTypes(chartLayerMediaTypes))
+	if err != nil {
+		return nil, errors.Wrapf(err, "pull %s", ref)
+	}
+
+	for _, layer := range layers {
+		_, data, ok := store.Get(layer)
+		if ok {
+			klog.V(4).Infof("pull %s (%d bytes)", ref, len(data))
+			return data, nil
+		}
+	}
+	return nil, errors.Errorf("%s has no chart layer", ref)
+}
+
+// cached returns the data cached with key, or the data of get which is cached on success.
+func (g *Getter) cached(key string, get func() ([]byte, error)) ([]byte, error) {
+	if data, ok := g.cache.Get(key); ok {
+		return data.([]byte), nil
+	}
+
+	data, err := get()
+	if err != nil {
+		return nil, err
+	}
+	g.cache.Add(key, data, chartCacheTTL)
+	return data, nil
+}
+
+func checkVersion(c *chart.Chart, version string) error {
+	if version != "" && c.Metadata.Version != version {
+		return errors.Errorf("chart %s version %s not found, got %s", c.Name(), version, c.Metadata.Version)
+	}
+	return nil
+}
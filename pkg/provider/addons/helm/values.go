@@ -0,0 +1,50 @@
+package helm
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/pkg/errors"
+	"helm.sh/helm/v3/pkg/strvals"
+)
+
+// Values is the values tree passed to the chart.
+type Values = map[string]interface{}
+
+// MergeValues builds the user supplied values of an app, the priority is
+// OverrideValue < Values (like --set) < RawValueSet (like --set-string).
+func MergeValues(app *devopsv1.HelmChartSpec) (Values, error) {
+	vals := Values{}
+	if strings.TrimSpace(app.OverrideValue) != "" {
+		if err := yaml.Unmarshal([]byte(app.OverrideValue), &vals); err != nil {
+			return nil, errors.Wrap(err, "parse overrideValue")
+		}
+		if vals == nil {
+			vals = Values{}
+		}
+	}
+
+	for _, key := range sortedKeys(app.Values) {
+		if err := strvals.ParseInto(key+"="+app.Values[key], vals); err != nil {
+			return nil, errors.Wrapf(err, "parse value %s", key)
+		}
+	}
+	for _, key := range sortedKeys(app.RawValueSet) {
+		if err := strvals.ParseIntoString(key+"="+app.RawValueSet[key], vals); err != nil {
+			return nil, errors.Wrapf(err, "parse raw value %s", key)
+		}
+	}
+
+	return vals, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
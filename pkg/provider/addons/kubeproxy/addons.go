@@ -8,6 +8,7 @@ import (
 	kubeproxyv1alpha1 "github.com/gostship/kunkka/pkg/apis/kubeproxy/config/v1alpha1"
 	"github.com/gostship/kunkka/pkg/constants"
 	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/provider/addons/metallb"
 	"github.com/gostship/kunkka/pkg/provider/config"
 	"github.com/gostship/kunkka/pkg/provider/phases/certs"
 	"github.com/gostship/kunkka/pkg/provider/phases/kubemisc"
@@ -129,6 +130,9 @@ const (
 
 	// KubeProxyServiceAccountName describes the name of the ServiceAccount for the kube-proxy addon
 	KubeProxyServiceAccountName = "kube-proxy"
+
+	// KubeProxyDaemonSetName is the name of the kube-proxy DaemonSet in kube-system
+	KubeProxyDaemonSetName = "kube-proxy"
 )
 
 // GetProxyEnvVars builds a list of environment variables in order to use the right proxy
@@ -162,6 +166,10 @@ func getKubeProxyConfiguration(c *common.Cluster) *kubeproxyv1alpha1.KubeProxyCo
 		ClientConnection: componentbaseconfigv1alpha1.ClientConnectionConfiguration{
 			Kubeconfig: "/var/lib/kube-proxy/kubeconfig.conf",
 		},
+		// metallb layer2 needs the addresses of kube-ipvs0 not answering arp
+		IPVS: kubeproxyv1alpha1.KubeProxyIPVSConfiguration{
+			StrictARP: kubeProxyMode == "ipvs" && metallb.Enabled(c),
+		},
 	}
 }
 
@@ -226,6 +234,11 @@ func BuildKubeproxyAddon(cfg *config.Config, c *common.Cluster) ([]runtime.Objec
 		return nil, errors.Wrap(err, "unable to decode kube-proxy daemonset")
 	}
 
+	// the pods are rolled when the configuration changes, e.g. the strictARP of metallb
+	if kubeproxyDaemonSet.Spec.Template.Annotations == nil {
+		kubeproxyDaemonSet.Spec.Template.Annotations = map[string]string{}
+	}
+	kubeproxyDaemonSet.Spec.Template.Annotations[configHashAnnotation] = configHash(string(kubeproxyBytes))
 	kubeproxyDaemonSet.Spec.Template.Spec.HostAliases = []corev1.HostAlias{
 		{
 			IP:        c.Cluster.Spec.Features.HA.ThirdPartyHA.VIP,
@@ -0,0 +1,106 @@
+package kubeproxy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/ghodss/yaml"
+	"github.com/gostship/kunkka/pkg/constants"
+	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/provider/addons/metallb"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+)
+
+// configHashAnnotation is the hash of the kube-proxy configuration the pods of the DaemonSet
+// run with, the pods are rolled once it changes.
+const configHashAnnotation = "devops.gostship.io/config-hash"
+
+// ApplyStrictARP reconciles strictARP of the ipvs kube-proxy with metallb, the addresses of
+// kube-ipvs0 must not answer arp for the layer2 mode. The kube-proxy ConfigMap is updated in
+// place, the other fields are kept, and the kube-proxy pods are rolled to take it.
+func ApplyStrictARP(ctx context.Context, c *common.Cluster) error {
+	clusterCtx, err := c.ClusterManager.Get(c.Name)
+	if err != nil {
+		return nil
+	}
+	cli := clusterCtx.Client
+
+	cm := &corev1.ConfigMap{}
+	key := types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: constants.KubeProxyConfigMap}
+	if err := cli.Get(ctx, key, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "get configmap %s", key)
+	}
+	data, err := setStrictARP(cm.Data[constants.KubeProxyConfigMapKey], metallb.Enabled(c))
+	if err != nil {
+		return errors.Wrapf(err, "configmap %s", key)
+	}
+	if data != cm.Data[constants.KubeProxyConfigMapKey] {
+		cm.Data[constants.KubeProxyConfigMapKey] = data
+		if err := cli.Update(ctx, cm); err != nil {
+			return errors.Wrapf(err, "update configmap %s", key)
+		}
+		klog.Infof("cluster: %s kube-proxy strictARP is %v", c.Name, metallb.Enabled(c))
+	}
+
+	ds := &appsv1.DaemonSet{}
+	key.Name = KubeProxyDaemonSetName
+	if err := cli.Get(ctx, key, ds); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "get daemonset %s", key)
+	}
+	hash := configHash(data)
+	if ds.Spec.Template.Annotations[configHashAnnotation] == hash {
+		return nil
+	}
+	if ds.Spec.Template.Annotations == nil {
+		ds.Spec.Template.Annotations = map[string]string{}
+	}
+	ds.Spec.Template.Annotations[configHashAnnotation] = hash
+	if err := cli.Update(ctx, ds); err != nil {
+		return errors.Wrapf(err, "update daemonset %s", key)
+	}
+	klog.Infof("cluster: %s roll kube-proxy with the configuration %s", c.Name, hash)
+	return nil
+}
+
+// setStrictARP sets ipvs.strictARP of the kube-proxy configuration if it runs in the ipvs
+// mode, the configuration is returned as is if it has the value already.
+func setStrictARP(data string, enabled bool) (string, error) {
+	cfg := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(data), &cfg); err != nil {
+		return "", errors.Wrap(err, "unmarshal kube-proxy configuration")
+	}
+	strict := enabled && cfg["mode"] == "ipvs"
+	ipvs, _ := cfg["ipvs"].(map[string]interface{})
+	if current, _ := ipvs["strictARP"].(bool); current == strict {
+		return data, nil
+	}
+	if ipvs == nil {
+		ipvs = map[string]interface{}{}
+		cfg["ipvs"] = ipvs
+	}
+	ipvs["strictARP"] = strict
+
+	out, err := yaml.Marshal(cfg)
+	if err != nil {
+		return "", errors.Wrap(err, "marshal kube-proxy configuration")
+	}
+	return string(out), nil
+}
+
+func configHash(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:8])
+}
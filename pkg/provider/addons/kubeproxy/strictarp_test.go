@@ -0,0 +1,37 @@
+package kubeproxy
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetStrictARP(t *testing.T) {
+	ipvs := "apiVersion: kubeproxy.config.k8s.io/v1alpha1\nkind: KubeProxyConfiguration\nmode: ipvs\nclusterCIDR: 10.244.0.0/16\n"
+	tests := []struct {
+		name    string
+		data    string
+		enabled bool
+		want    string
+		changed bool
+	}{
+		{name: "ipvs enabled", data: ipvs, enabled: true, want: "strictARP: true", changed: true},
+		{name: "ipvs disabled", data: ipvs, enabled: false},
+		{name: "ipvs set", data: ipvs + "ipvs:\n  strictARP: true\n", enabled: true},
+		{name: "ipvs unset", data: ipvs + "ipvs:\n  strictARP: true\n", enabled: false, want: "strictARP: false", changed: true},
+		{name: "iptables", data: "mode: iptables\n", enabled: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := setStrictARP(tt.data, tt.enabled)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (got != tt.data) != tt.changed || !strings.Contains(got, tt.want) {
+				t.Errorf("setStrictARP() = %q", got)
+			}
+			if tt.changed && !strings.Contains(got, "clusterCIDR: 10.244.0.0/16") {
+				t.Errorf("setStrictARP() drops the other fields: %q", got)
+			}
+		})
+	}
+}
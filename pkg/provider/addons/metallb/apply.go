@@ -0,0 +1,214 @@
+package metallb
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"sort"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/k8sclient"
+	"github.com/gostship/kunkka/pkg/provider/config"
+	"github.com/gostship/kunkka/pkg/provider/ipam"
+	"github.com/gostship/kunkka/pkg/util/k8sutil"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultRackCount is the count of the addresses allocated from the rack.
+	DefaultRackCount = 8
+
+	memberlistSecret = "memberlist"
+)
+
+// Addresses returns the internal addresses of the cluster, the addresses of the spec or
+// the addresses claimed from the host pool of the rack. The claims are reconciled with
+// the rack and the count, so a smaller count releases the last addresses, and the
+// addresses of the spec release all of them.
+func Addresses(ctx context.Context, c *common.Cluster) ([]string, error) {
+	lb := c.Spec.Features.LoadBalancer
+	fromRack := len(lb.Addresses) == 0 && lb.Rack != ""
+
+	count := 0
+	if fromRack {
+		count = lb.Count
+		if count <= 0 {
+			count = DefaultRackCount
+		}
+	}
+	pool := ipam.HostPoolName(lb.Rack)
+
+	claims, err := listClaims(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	var kept []*devopsv1.IPClaim
+	for i := range claims {
+		claim := &claims[i]
+		if claim.Spec.Pool == pool && len(kept) < count {
+			kept = append(kept, claim)
+			continue
+		}
+		klog.Infof("cluster: %s release loadbalancer address %s of pool %s", c.Name, claim.Spec.RangeStart, claim.Spec.Pool)
+		if err := c.Client.Delete(ctx, claim); err != nil && !apierrors.IsNotFound(err) {
+			return nil, errors.Wrapf(err, "delete ipclaim %s", claim.Name)
+		}
+	}
+
+	for len(kept) < count {
+		claim, err := ipam.Allocate(ctx, c.Client, pool, "")
+		if err != nil {
+			return nil, errors.Wrapf(err, "allocate loadbalancer address of cluster %s", c.Name)
+		}
+		claim.Labels[devopsv1.LabelLoadBalancer] = c.Name
+		if err := ipam.Claim(ctx, c.Client, k8sclient.GetScheme(), c.Cluster, []*devopsv1.IPClaim{claim}); err != nil {
+			ipam.ReleaseAll(ctx, c.Client, []*devopsv1.IPClaim{claim})
+			return nil, err
+		}
+		klog.Infof("cluster: %s claim loadbalancer address %s of pool %s", c.Name, claim.Spec.RangeStart, pool)
+		kept = append(kept, claim)
+	}
+
+	if !fromRack {
+		return lb.Addresses, nil
+	}
+	addresses := make([]string, 0, len(kept))
+	for _, claim := range kept {
+		addresses = append(addresses, claim.Spec.RangeStart+"-"+claim.Spec.RangeEnd)
+	}
+	return addresses, nil
+}
+
+// listClaims returns the loadbalancer claims of the cluster sorted by pool and offset.
+func listClaims(ctx context.Context, c *common.Cluster) ([]devopsv1.IPClaim, error) {
+	list := &devopsv1.IPClaimList{}
+	err := c.Client.List(ctx, list, client.InNamespace(c.Namespace), client.MatchingLabels{devopsv1.LabelLoadBalancer: c.Name})
+	if err != nil {
+		return nil, errors.Wrapf(err, "list loadbalancer ipclaims of cluster %s", c.Name)
+	}
+	sort.Slice(list.Items, func(i, j int) bool {
+		a, b := list.Items[i].Spec, list.Items[j].Spec
+		if a.Pool != b.Pool {
+			return a.Pool < b.Pool
+		}
+		return a.Offset < b.Offset
+	})
+	return list.Items, nil
+}
+
+// Apply installs or upgrades metallb in the cluster with the addresses of the cluster,
+// metallb and the claimed rack addresses are removed once it is disabled.
+func Apply(ctx context.Context, cfg *config.Config, c *common.Cluster) error {
+	clusterCtx, err := c.ClusterManager.Get(c.Name)
+	if err != nil {
+		return nil
+	}
+	logger := ctrl.Log.WithValues("cluster", c.Name, "component", "metallb")
+
+	if !Enabled(c) {
+		return remove(ctx, cfg, c, clusterCtx.Client)
+	}
+
+	addresses, err := Addresses(ctx, c)
+	if err != nil {
+		return err
+	}
+	lbConfig, err := BuildConfig(c, addresses)
+	if err != nil {
+		return err
+	}
+	objs, err := BuildMetallbAddon(cfg, lbConfig)
+	if err != nil {
+		return errors.Wrapf(err, "build metallb err: %v", err)
+	}
+
+	logger.Info("start reconcile ...")
+	for i, obj := range objs {
+		err = k8sutil.Reconcile(logger, clusterCtx.Client, obj, k8sutil.DesiredStatePresent)
+		if err != nil {
+			return errors.Wrapf(err, "Reconcile  err: %v", err)
+		}
+		// the speakers need the secret of the namespace
+		if i == 0 {
+			if err := ensureSecret(ctx, clusterCtx.Client); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ensureSecret creates the memberlist key of the speakers once, it is never rotated by
+// the reconcile.
+func ensureSecret(ctx context.Context, cli client.Client) error {
+	secret := &corev1.Secret{}
+	err := cli.Get(ctx, types.NamespacedName{Namespace: Namespace, Name: memberlistSecret}, secret)
+	if err == nil {
+		return nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "get secret %s/%s", Namespace, memberlistSecret)
+	}
+
+	key := make([]byte, 128)
+	if _, err := rand.Read(key); err != nil {
+		return err
+	}
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: Namespace,
+			Name:      memberlistSecret,
+		},
+		StringData: map[string]string{
+			"secretkey": base64.StdEncoding.EncodeToString(key),
+		},
+	}
+	if err := cli.Create(ctx, secret); err != nil && !apierrors.IsAlreadyExists(err) {
+		return errors.Wrapf(err, "create secret %s/%s", Namespace, memberlistSecret)
+	}
+	return nil
+}
+
+// remove deletes metallb from the cluster and releases the rack addresses.
+func remove(ctx context.Context, cfg *config.Config, c *common.Cluster, cli client.Client) error {
+	claims, err := listClaims(ctx, c)
+	if err != nil {
+		return err
+	}
+	for i := range claims {
+		if err := c.Client.Delete(ctx, &claims[i]); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "delete ipclaim %s", claims[i].Name)
+		}
+	}
+
+	ns := &corev1.Namespace{}
+	err = cli.Get(ctx, types.NamespacedName{Name: Namespace}, ns)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "get namespace %s", Namespace)
+	}
+
+	objs, err := BuildMetallbAddon(cfg, "")
+	if err != nil {
+		return err
+	}
+	logger := ctrl.Log.WithValues("cluster", c.Name, "component", "metallb")
+	// the namespace is deleted last with the namespaced objects left
+	for i := len(objs) - 1; i >= 0; i-- {
+		err = k8sutil.Reconcile(logger, cli, objs[i], k8sutil.DesiredStateAbsent)
+		if err != nil {
+			return errors.Wrapf(err, "Reconcile  err: %v", err)
+		}
+	}
+	return nil
+}
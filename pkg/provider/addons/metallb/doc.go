@@ -1 +1,3 @@
+// Package metallb installs metallb in the clusters, the LoadBalancer services get the
+// addresses of the cluster pools, or of the rack host pool.
 package metallb
@@ -0,0 +1,427 @@
+package metallb
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ghodss/yaml"
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/provider/config"
+	"github.com/gostship/kunkka/pkg/util/k8sutil"
+	"github.com/gostship/kunkka/pkg/util/template"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog"
+)
+
+const (
+	// Version is the metallb version installed.
+	Version = "v0.9.3"
+	// Namespace is the namespace of metallb.
+	Namespace = "metallb-system"
+
+	// InternalPool is the address pool of the internal addresses, the services get them
+	// by default.
+	InternalPool = "internal"
+	// PublicPool is the address pool of the public addresses, the services get them with
+	// the metallb.universe.tf/address-pool annotation.
+	PublicPool = "public"
+
+	// DefaultASNumber is the AS number of the nodes in bgp mode.
+	DefaultASNumber uint32 = 64512
+
+	metallbTemplate = `---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: metallb-system
+  labels:
+    app: metallb
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: controller
+  namespace: metallb-system
+  labels:
+    app: metallb
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: speaker
+  namespace: metallb-system
+  labels:
+    app: metallb
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: metallb-system:controller
+  labels:
+    app: metallb
+rules:
+- apiGroups: [""]
+  resources: ["services"]
+  verbs: ["get", "list", "watch", "update"]
+- apiGroups: [""]
+  resources: ["services/status"]
+  verbs: ["update"]
+- apiGroups: [""]
+  resources: ["events"]
+  verbs: ["create", "patch"]
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: metallb-system:speaker
+  labels:
+    app: metallb
+rules:
+- apiGroups: [""]
+  resources: ["services", "endpoints", "nodes"]
+  verbs: ["get", "list", "watch"]
+- apiGroups: [""]
+  resources: ["events"]
+  verbs: ["create", "patch"]
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  name: config-watcher
+  namespace: metallb-system
+  labels:
+    app: metallb
+rules:
+- apiGroups: [""]
+  resources: ["configmaps"]
+  verbs: ["get", "list", "watch"]
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  name: pod-lister
+  namespace: metallb-system
+  labels:
+    app: metallb
+rules:
+- apiGroups: [""]
+  resources: ["pods"]
+  verbs: ["list"]
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  name: metallb-system:controller
+  labels:
+    app: metallb
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: metallb-system:controller
+subjects:
+- kind: ServiceAccount
+  name: controller
+  namespace: metallb-system
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  name: metallb-system:speaker
+  labels:
+    app: metallb
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: metallb-system:speaker
+subjects:
+- kind: ServiceAccount
+  name: speaker
+  namespace: metallb-system
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata:
+  name: config-watcher
+  namespace: metallb-system
+  labels:
+    app: metallb
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: Role
+  name: config-watcher
+subjects:
+- kind: ServiceAccount
+  name: controller
+  namespace: metallb-system
+- kind: ServiceAccount
+  name: speaker
+  namespace: metallb-system
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata:
+  name: pod-lister
+  namespace: metallb-system
+  labels:
+    app: metallb
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: Role
+  name: pod-lister
+subjects:
+- kind: ServiceAccount
+  name: speaker
+  namespace: metallb-system
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: metallb-system
+  labels:
+    app: metallb
+data:
+  config: |
+{{ .Config | indent 4 }}
+---
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: speaker
+  namespace: metallb-system
+  labels:
+    app: metallb
+    component: speaker
+spec:
+  selector:
+    matchLabels:
+      app: metallb
+      component: speaker
+  template:
+    metadata:
+      annotations:
+        prometheus.io/port: "7472"
+        prometheus.io/scrape: "true"
+      labels:
+        app: metallb
+        component: speaker
+    spec:
+      containers:
+      - name: speaker
+        image: {{ .SpeakerImage }}
+        args:
+        - --port=7472
+        - --config=config
+        env:
+        - name: METALLB_NODE_NAME
+          valueFrom:
+            fieldRef:
+              fieldPath: spec.nodeName
+        - name: METALLB_HOST
+          valueFrom:
+            fieldRef:
+              fieldPath: status.hostIP
+        - name: METALLB_ML_BIND_ADDR
+          valueFrom:
+            fieldRef:
+              fieldPath: status.podIP
+        - name: METALLB_ML_LABELS
+          value: "app=metallb,component=speaker"
+        - name: METALLB_ML_NAMESPACE
+          valueFrom:
+            fieldRef:
+              fieldPath: metadata.namespace
+        - name: METALLB_ML_SECRET_KEY
+          valueFrom:
+            secretKeyRef:
+              name: memberlist
+              key: secretkey
+        ports:
+        - name: monitoring
+          containerPort: 7472
+        resources:
+          limits:
+            cpu: 100m
+            memory: 100Mi
+        securityContext:
+          allowPrivilegeEscalation: false
+          capabilities:
+            add:
+            - NET_ADMIN
+            - NET_RAW
+            - SYS_ADMIN
+            drop:
+            - ALL
+          readOnlyRootFilesystem: true
+      hostNetwork: true
+      nodeSelector:
+        kubernetes.io/os: linux
+      serviceAccountName: speaker
+      terminationGracePeriodSeconds: 2
+      tolerations:
+      - effect: NoSchedule
+        key: node-role.kubernetes.io/master
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: controller
+  namespace: metallb-system
+  labels:
+    app: metallb
+    component: controller
+spec:
+  revisionHistoryLimit: 3
+  selector:
+    matchLabels:
+      app: metallb
+      component: controller
+  template:
+    metadata:
+      annotations:
+        prometheus.io/port: "7472"
+        prometheus.io/scrape: "true"
+      labels:
+        app: metallb
+        component: controller
+    spec:
+      containers:
+      - name: controller
+        image: {{ .ControllerImage }}
+        args:
+        - --port=7472
+        - --config=config
+        ports:
+        - name: monitoring
+          containerPort: 7472
+        resources:
+          limits:
+            cpu: 100m
+            memory: 100Mi
+        securityContext:
+          allowPrivilegeEscalation: false
+          capabilities:
+            drop:
+            - all
+          readOnlyRootFilesystem: true
+      nodeSelector:
+        kubernetes.io/os: linux
+      securityContext:
+        runAsNonRoot: true
+        runAsUser: 65534
+      serviceAccountName: controller
+      terminationGracePeriodSeconds: 0
+`
+)
+
+// configFile is the config of metallb in the config ConfigMap.
+type configFile struct {
+	Peers        []peer        `json:"peers,omitempty"`
+	AddressPools []addressPool `json:"address-pools"`
+}
+
+type peer struct {
+	PeerAddress string `json:"peer-address"`
+	PeerASN     uint32 `json:"peer-asn"`
+	MyASN       uint32 `json:"my-asn"`
+}
+
+type addressPool struct {
+	Name       string   `json:"name"`
+	Protocol   string   `json:"protocol"`
+	Addresses  []string `json:"addresses"`
+	AutoAssign *bool    `json:"auto-assign,omitempty"`
+}
+
+type Option struct {
+	Config          string
+	SpeakerImage    string
+	ControllerImage string
+}
+
+// Enabled reports whether metallb is installed in the cluster.
+func Enabled(c *common.Cluster) bool {
+	f := &c.Spec.Features
+	return f.LoadBalancer != nil && (isTrue(f.InternalLB) || isTrue(f.PublicLB))
+}
+
+// BuildConfig returns the metallb config of the cluster, the internal pool has the
+// addresses, the public pool is only auto assigned if there is no internal pool.
+func BuildConfig(c *common.Cluster, addresses []string) (string, error) {
+	lb := c.Spec.Features.LoadBalancer
+	protocol := string(devopsv1.LoadBalancerLayer2)
+	if lb.Mode != "" {
+		protocol = string(lb.Mode)
+	}
+
+	cfg := &configFile{}
+	if protocol == string(devopsv1.LoadBalancerBGP) {
+		asn := lb.ASNumber
+		if asn == 0 {
+			asn = DefaultASNumber
+		}
+		for _, p := range lb.Peers {
+			cfg.Peers = append(cfg.Peers, peer{PeerAddress: p.Address, PeerASN: p.ASNumber, MyASN: asn})
+		}
+	}
+
+	internal := isTrue(c.Spec.Features.InternalLB)
+	if internal {
+		if len(addresses) == 0 {
+			return "", fmt.Errorf("cluster %s has no internal loadbalancer addresses", c.Name)
+		}
+		cfg.AddressPools = append(cfg.AddressPools, addressPool{
+			Name:      InternalPool,
+			Protocol:  protocol,
+			Addresses: addresses,
+		})
+	}
+	if isTrue(c.Spec.Features.PublicLB) {
+		if len(lb.PublicAddresses) == 0 {
+			return "", fmt.Errorf("cluster %s has no public loadbalancer addresses", c.Name)
+		}
+		pool := addressPool{
+			Name:      PublicPool,
+			Protocol:  protocol,
+			Addresses: lb.PublicAddresses,
+		}
+		if internal {
+			autoAssign := false
+			pool.AutoAssign = &autoAssign
+		}
+		cfg.AddressPools = append(cfg.AddressPools, pool)
+	}
+
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// BuildMetallbAddon renders the metallb manifests with the config, the memberlist secret
+// of the speakers is created once by Apply, it is not in the manifests.
+func BuildMetallbAddon(cfg *config.Config, lbConfig string) ([]runtime.Object, error) {
+	opt := &Option{
+		Config:          lbConfig,
+		SpeakerImage:    cfg.ImageFullName("metallb-speaker", Version),
+		ControllerImage: cfg.ImageFullName("metallb-controller", Version),
+	}
+	data, err := template.ParseString(metallbTemplate, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	objs, err := k8sutil.LoadObjs(bytes.NewReader(data))
+	if err != nil {
+		klog.Errorf("metallb load objs err: %v", err)
+		return nil, err
+	}
+
+	return objs, nil
+}
+
+func isTrue(b *bool) bool {
+	return b != nil && *b
+}
@@ -0,0 +1,153 @@
+package metallb
+
+import (
+	"strings"
+	"testing"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/provider/config"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newCluster(internal, public bool, lb *devopsv1.LoadBalancer) *common.Cluster {
+	return &common.Cluster{
+		Cluster: &devopsv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "demo"},
+			Spec: devopsv1.ClusterSpec{
+				Features: devopsv1.ClusterFeature{
+					InternalLB:   &internal,
+					PublicLB:     &public,
+					LoadBalancer: lb,
+				},
+			},
+		},
+	}
+}
+
+func TestBuildConfig(t *testing.T) {
+	addresses := []string{"10.28.0.200-10.28.0.220"}
+	tests := []struct {
+		name      string
+		cluster   *common.Cluster
+		addresses []string
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:      "layer2",
+			cluster:   newCluster(true, false, &devopsv1.LoadBalancer{}),
+			addresses: addresses,
+			want: `address-pools:
+- addresses:
+  - 10.28.0.200-10.28.0.220
+  name: internal
+  protocol: layer2
+`,
+		},
+		{
+			name:      "public",
+			cluster:   newCluster(true, true, &devopsv1.LoadBalancer{PublicAddresses: []string{"1.2.3.0/28"}}),
+			addresses: addresses,
+			want: `address-pools:
+- addresses:
+  - 10.28.0.200-10.28.0.220
+  name: internal
+  protocol: layer2
+- addresses:
+  - 1.2.3.0/28
+  auto-assign: false
+  name: public
+  protocol: layer2
+`,
+		},
+		{
+			name:    "public only",
+			cluster: newCluster(false, true, &devopsv1.LoadBalancer{PublicAddresses: []string{"1.2.3.0/28"}}),
+			want: `address-pools:
+- addresses:
+  - 1.2.3.0/28
+  name: public
+  protocol: layer2
+`,
+		},
+		{
+			name: "bgp",
+			cluster: newCluster(true, false, &devopsv1.LoadBalancer{
+				Mode:  devopsv1.LoadBalancerBGP,
+				Peers: []devopsv1.LoadBalancerPeer{{Address: "10.28.0.1", ASNumber: 65001}},
+			}),
+			addresses: addresses,
+			want: `address-pools:
+- addresses:
+  - 10.28.0.200-10.28.0.220
+  name: internal
+  protocol: bgp
+peers:
+- my-asn: 64512
+  peer-address: 10.28.0.1
+  peer-asn: 65001
+`,
+		},
+		{
+			name:    "no internal addresses",
+			cluster: newCluster(true, false, &devopsv1.LoadBalancer{}),
+			wantErr: true,
+		},
+		{
+			name:      "no public addresses",
+			cluster:   newCluster(true, true, &devopsv1.LoadBalancer{}),
+			addresses: addresses,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildConfig(tt.cluster, tt.addresses)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BuildConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("BuildConfig() = \n%s, want \n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	if Enabled(newCluster(true, false, nil)) {
+		t.Error("Enabled() without loadBalancer = true")
+	}
+	if Enabled(newCluster(false, false, &devopsv1.LoadBalancer{})) {
+		t.Error("Enabled() without internalLB and publicLB = true")
+	}
+	if !Enabled(newCluster(true, false, &devopsv1.LoadBalancer{})) {
+		t.Error("Enabled() with internalLB = false")
+	}
+}
+
+func TestBuildMetallbAddon(t *testing.T) {
+	lbConfig, err := BuildConfig(newCluster(true, false, &devopsv1.LoadBalancer{}), []string{"10.28.1.0/28"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	objs, err := BuildMetallbAddon(&config.Config{}, lbConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the objects failing to decode are dropped by LoadObjs
+	if len(objs) != 14 {
+		t.Fatalf("BuildMetallbAddon() = %d objs, want 14", len(objs))
+	}
+
+	var cm *corev1.ConfigMap
+	for _, obj := range objs {
+		if c, ok := obj.(*corev1.ConfigMap); ok {
+			cm = c
+		}
+	}
+	if cm == nil || !strings.Contains(cm.Data["config"], "- 10.28.1.0/28\n") {
+		t.Errorf("BuildMetallbAddon() config = %v", cm)
+	}
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2020 dke.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifact
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/constants"
+	"github.com/gostship/kunkka/pkg/util/ssh"
+	"github.com/gostship/kunkka/pkg/util/template"
+	"github.com/pkg/errors"
+	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// PathPrefix is the url path the artifact dir is served at.
+	PathPrefix = "/artifacts/"
+
+	// installScript downloads the files which are missing or have another sum, a file
+	// is only replaced after its sum is verified, so a broken download never overwrites
+	// a working binary.
+	installScript = `#!/bin/bash
+set -o pipefail
+
+function Fetch() {
+  local dst=$1 url=$2 sum=$3
+  if [ -f "$dst" ] && echo "$sum  $dst" | sha256sum -c --status; then
+    echo "$dst is up to date"
+    return 0
+  fi
+  mkdir -p "$(dirname "$dst")" || return 1
+  echo "download $url"
+  curl -fsSL --retry 3 --connect-timeout 10 -o "$dst.download" "$url" || { rm -f "$dst.download"; return 1; }
+  if ! echo "$sum  $dst.download" | sha256sum -c --status; then
+    echo "$url sha256 mismatch, want $sum got $(sha256sum "$dst.download" | cut -d' ' -f1)"
+    rm -f "$dst.download"
+    return 1
+  fi
+  mv -f "$dst.download" "$dst"
+}
+
+{{- range .Files }}
+
+Fetch {{ .Dst }} {{ .URL }} {{ .SHA256 }} || exit 1
+{{- if .Mode }}
+chmod {{ .Mode }} {{ .Dst }} || exit 1
+{{- end }}
+{{- if .ExtractDir }}
+mkdir -p {{ .ExtractDir }} && tar -C {{ .ExtractDir }} -xzf {{ .Dst }} || exit 1
+{{- end }}
+{{- end }}
+`
+)
+
+var (
+	sha256Regexp = regexp.MustCompile(`^[0-9a-f]{64}$`)
+	modeRegexp   = regexp.MustCompile(`^0?[0-7]{3}$`)
+)
+
+// File is a file of the artifact resolved for a node.
+type File struct {
+	Name       string
+	Dst        string
+	Mode       string
+	ExtractDir string
+	URL        string
+	SHA256     string
+}
+
+// Find returns the artifact of the kubernetes version, nil if there is none.
+func Find(ctx context.Context, cli client.Client, version string) (*devopsv1.KubernetesArtifact, error) {
+	list := &devopsv1.KubernetesArtifactList{}
+	if err := cli.List(ctx, list); err != nil {
+		return nil, errors.Wrap(err, "list kubernetes artifacts")
+	}
+
+	version = strings.TrimPrefix(version, "v")
+	for i := range list.Items {
+		if strings.TrimPrefix(list.Items[i].Spec.Version, "v") == version {
+			return &list.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// Arch returns the architecture of the host in the GOARCH names.
+func Arch(s ssh.Interface) (string, error) {
+	out, err := s.CombinedOutput("uname -m")
+	if err != nil {
+		return "", errors.Wrapf(err, "node: %s get arch", s.HostIP())
+	}
+
+	arch := strings.TrimSpace(string(out))
+	switch arch {
+	case "x86_64":
+		return "amd64", nil
+	case "aarch64":
+		return "arm64", nil
+	}
+	return arch, nil
+}
+
+// Resolve returns the files of the names installed on the nodes of the arch and runtime,
+// all the files are returned if names is empty. The relative urls are resolved against
+// baseURL, the address of the operator artifact server.
+func Resolve(a *devopsv1.KubernetesArtifact, names []string, arch string, runtime devopsv1.ContainerRuntimeType, baseURL string) ([]File, error) {
+	var files []File
+	for _, f := range a.Spec.Files {
+		if len(names) > 0 && !contains(names, f.Name) {
+			continue
+		}
+		if f.ContainerRuntime != "" && f.ContainerRuntime != runtime {
+			continue
+		}
+
+		var source *devopsv1.ArtifactSource
+		for i := range f.Sources {
+			if f.Sources[i].Arch == arch {
+				source = &f.Sources[i]
+				break
+			}
+		}
+		if source == nil {
+			return nil, fmt.Errorf("artifact %s has no %s source of %s", a.Name, arch, f.Name)
+		}
+
+		sum := strings.ToLower(source.SHA256)
+		if !sha256Regexp.MatchString(sum) {
+			return nil, fmt.Errorf("artifact %s %s has invalid sha256 %q", a.Name, f.Name, source.SHA256)
+		}
+		if f.Mode != "" && !modeRegexp.MatchString(f.Mode) {
+			return nil, fmt.Errorf("artifact %s %s has invalid mode %q", a.Name, f.Name, f.Mode)
+		}
+		if !strings.HasPrefix(f.Dst, "/") || strings.ContainsAny(f.Dst+f.ExtractDir, " '\"$`;&|") {
+			return nil, fmt.Errorf("artifact %s %s has invalid dst %q", a.Name, f.Name, f.Dst)
+		}
+
+		url, err := sourceURL(source.URL, baseURL)
+		if err != nil {
+			return nil, errors.Wrapf(err, "artifact %s %s", a.Name, f.Name)
+		}
+		files = append(files, File{
+			Name:       f.Name,
+			Dst:        f.Dst,
+			Mode:       f.Mode,
+			ExtractDir: f.ExtractDir,
+			URL:        url,
+			SHA256:     sum,
+		})
+	}
+
+	for _, name := range names {
+		if !hasFile(files, name) {
+			return nil, fmt.Errorf("artifact %s has no %s", a.Name, name)
+		}
+	}
+	return files, nil
+}
+
+// sourceURL quotes the url for the install script.
+func sourceURL(url, baseURL string) (string, error) {
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		if baseURL == "" {
+			return "", fmt.Errorf("the artifact server url is not set for the relative url %s", url)
+		}
+		url = strings.TrimSuffix(baseURL, "/") + PathPrefix + strings.TrimPrefix(url, "/")
+	}
+	return "'" + strings.ReplaceAll(url, "'", `'\''`) + "'", nil
+}
+
+// BuildScript renders the script installing the files on a node.
+func BuildScript(files []File) ([]byte, error) {
+	return template.ParseString(installScript, map[string]interface{}{
+		"Files": files,
+	})
+}
+
+// Install downloads the files on the node from the artifact server or their urls in
+// parallel with the other nodes, the files already present with the same sum are skipped.
+func Install(s ssh.Interface, files []File) error {
+	data, err := BuildScript(files)
+	if err != nil {
+		return err
+	}
+
+	err = s.WriteFile(bytes.NewReader(data), constants.ArtifactScriptFile)
+	if err != nil {
+		return err
+	}
+
+	klog.Infof("node: %s start install %d artifacts ... ", s.HostIP(), len(files))
+	cmd := fmt.Sprintf("chmod a+x %s && %s", constants.ArtifactScriptFile, constants.ArtifactScriptFile)
+	exit, err := s.ExecStream(cmd, os.Stdout, os.Stderr)
+	if err != nil || exit != 0 {
+		return errors.Errorf("node: %s install artifacts exit %d: %v", s.HostIP(), exit, err)
+	}
+
+	klog.Infof("node: %s install artifacts success", s.HostIP())
+	return nil
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasFile(files []File, name string) bool {
+	for _, f := range files {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
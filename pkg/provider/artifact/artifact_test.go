@@ -0,0 +1,201 @@
+package artifact
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const sum = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func newArtifact(files ...devopsv1.ArtifactFile) *devopsv1.KubernetesArtifact {
+	return &devopsv1.KubernetesArtifact{
+		ObjectMeta: metav1.ObjectMeta{Name: "v1.18.6"},
+		Spec: devopsv1.KubernetesArtifactSpec{
+			Version: "1.18.6",
+			Files:   files,
+		},
+	}
+}
+
+func TestResolve(t *testing.T) {
+	kubelet := devopsv1.ArtifactFile{
+		Name: "kubelet",
+		Dst:  "/usr/bin/kubelet",
+		Mode: "0755",
+		Sources: []devopsv1.ArtifactSource{
+			{Arch: "amd64", URL: "k8s-1.18.6/amd64/kubelet", SHA256: sum},
+			{Arch: "arm64", URL: "https://mirror.local/arm64/kubelet", SHA256: strings.ToUpper(sum)},
+		},
+	}
+	crictl := devopsv1.ArtifactFile{
+		Name:             "crictl",
+		Dst:              "/usr/local/bin/crictl",
+		ContainerRuntime: devopsv1.ContainerRuntimeContainerd,
+		Sources:          []devopsv1.ArtifactSource{{Arch: "amd64", URL: "crictl", SHA256: sum}},
+	}
+	badSum := kubelet
+	badSum.Sources = []devopsv1.ArtifactSource{{Arch: "amd64", URL: "kubelet", SHA256: "abc"}}
+	badDst := kubelet
+	badDst.Dst = "/usr/bin/kubelet;reboot"
+
+	tests := []struct {
+		name     string
+		artifact *devopsv1.KubernetesArtifact
+		names    []string
+		arch     string
+		runtime  devopsv1.ContainerRuntimeType
+		baseURL  string
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:     "relative",
+			artifact: newArtifact(kubelet, crictl),
+			arch:     "amd64",
+			runtime:  devopsv1.ContainerRuntimeDocker,
+			baseURL:  "http://10.0.0.10:8091/",
+			want:     []string{"'http://10.0.0.10:8091/artifacts/k8s-1.18.6/amd64/kubelet'"},
+		},
+		{
+			name:     "absolute",
+			artifact: newArtifact(kubelet),
+			arch:     "arm64",
+			want:     []string{"'https://mirror.local/arm64/kubelet'"},
+		},
+		{
+			name:     "runtime",
+			artifact: newArtifact(kubelet, crictl),
+			arch:     "amd64",
+			runtime:  devopsv1.ContainerRuntimeContainerd,
+			baseURL:  "http://10.0.0.10:8091",
+			want:     []string{"'http://10.0.0.10:8091/artifacts/k8s-1.18.6/amd64/kubelet'", "'http://10.0.0.10:8091/artifacts/crictl'"},
+		},
+		{
+			name:     "names",
+			artifact: newArtifact(kubelet, crictl),
+			names:    []string{"crictl"},
+			arch:     "amd64",
+			runtime:  devopsv1.ContainerRuntimeContainerd,
+			baseURL:  "http://10.0.0.10:8091",
+			want:     []string{"'http://10.0.0.10:8091/artifacts/crictl'"},
+		},
+		{
+			name:     "missing name",
+			artifact: newArtifact(kubelet),
+			names:    []string{"kubeadm"},
+			arch:     "amd64",
+			baseURL:  "http://10.0.0.10:8091",
+			wantErr:  true,
+		},
+		{
+			name:     "missing arch",
+			artifact: newArtifact(kubelet),
+			arch:     "ppc64le",
+			wantErr:  true,
+		},
+		{
+			name:     "no server url",
+			artifact: newArtifact(kubelet),
+			arch:     "amd64",
+			wantErr:  true,
+		},
+		{
+			name:     "invalid sha256",
+			artifact: newArtifact(badSum),
+			arch:     "amd64",
+			baseURL:  "http://10.0.0.10:8091",
+			wantErr:  true,
+		},
+		{
+			name:     "invalid dst",
+			artifact: newArtifact(badDst),
+			arch:     "amd64",
+			baseURL:  "http://10.0.0.10:8091",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := Resolve(tt.artifact, tt.names, tt.arch, tt.runtime, tt.baseURL)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Resolve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(files) != len(tt.want) {
+				t.Fatalf("Resolve() = %+v, want %v", files, tt.want)
+			}
+			for i, f := range files {
+				if f.URL != tt.want[i] || f.SHA256 != sum {
+					t.Errorf("Resolve() file %d = %+v, want url %s", i, f, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBuildScript(t *testing.T) {
+	data, err := BuildScript([]File{
+		{Name: "kubelet", Dst: "/usr/bin/kubelet", Mode: "0755", URL: "'http://a/kubelet'", SHA256: sum},
+		{Name: "cni", Dst: "/opt/cni.tgz", ExtractDir: "/opt/cni/bin", URL: "'http://a/cni.tgz'", SHA256: sum},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	script := string(data)
+	for _, want := range []string{
+		"Fetch /usr/bin/kubelet 'http://a/kubelet' " + sum + " || exit 1\nchmod 0755 /usr/bin/kubelet || exit 1\n",
+		"Fetch /opt/cni.tgz 'http://a/cni.tgz' " + sum + " || exit 1\nmkdir -p /opt/cni/bin && tar -C /opt/cni/bin -xzf /opt/cni.tgz || exit 1\n",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("BuildScript() = %s, want %q", script, want)
+		}
+	}
+}
+
+func TestServer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "artifacts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := os.MkdirAll(filepath.Join(dir, "k8s-1.18.6"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "k8s-1.18.6", "kubelet"), []byte("kubelet"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(NewServer(dir, "").Handler())
+	defer srv.Close()
+
+	tests := []struct {
+		path string
+		code int
+	}{
+		{"/artifacts/k8s-1.18.6/kubelet", http.StatusOK},
+		{"/artifacts/k8s-1.18.6/", http.StatusNotFound},
+		{"/artifacts/k8s-1.18.6/kubeadm", http.StatusNotFound},
+		{"/artifacts/../etc/passwd", http.StatusNotFound},
+		{"/k8s-1.18.6/kubelet", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		resp, err := http.Get(srv.URL + tt.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.code {
+			t.Errorf("GET %s = %d, want %d", tt.path, resp.StatusCode, tt.code)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2020 dke.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifact
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"time"
+
+	"k8s.io/klog"
+)
+
+// Server serves the files of the artifact dir to the nodes, so the nodes download
+// the artifacts in parallel instead of the operator pushing them one by one.
+type Server struct {
+	Dir  string
+	Addr string
+}
+
+// NewServer returns the artifact server of the dir listening on addr.
+func NewServer(dir, addr string) *Server {
+	return &Server{
+		Dir:  dir,
+		Addr: addr,
+	}
+}
+
+// Handler serves the regular files of the dir under PathPrefix, the dirs are not listed.
+func (s *Server) Handler() http.Handler {
+	files := http.StripPrefix(PathPrefix, http.FileServer(http.Dir(s.Dir)))
+	mux := http.NewServeMux()
+	mux.HandleFunc(PathPrefix, func(w http.ResponseWriter, r *http.Request) {
+		f, err := http.Dir(s.Dir).Open(r.URL.Path[len(PathPrefix)-1:])
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		info, err := f.Stat()
+		f.Close()
+		if err != nil || info.IsDir() {
+			http.NotFound(w, r)
+			return
+		}
+		files.ServeHTTP(w, r)
+	})
+	return mux
+}
+
+// Start implements manager.Runnable, the server stops with the manager.
+func (s *Server) Start(stop <-chan struct{}) error {
+	if _, err := os.Stat(s.Dir); err != nil {
+		klog.Warningf("artifact dir %s is not available: %v", s.Dir, err)
+	}
+
+	srv := &http.Server{
+		Addr:    s.Addr,
+		Handler: s.Handler(),
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		klog.Infof("start serving artifacts of %s on %s", s.Dir, s.Addr)
+		errCh <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-stop:
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return srv.Shutdown(ctx)
+	}
+}
+
+// NeedLeaderElection is false, every replica serves the artifacts.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
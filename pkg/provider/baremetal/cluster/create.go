@@ -31,14 +31,19 @@ import (
 	"bytes"
 
 	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/provider/addons/calico"
 	"github.com/gostship/kunkka/pkg/provider/addons/cni"
 	"github.com/gostship/kunkka/pkg/provider/addons/flannel"
+	"github.com/gostship/kunkka/pkg/provider/addons/helm"
+	"github.com/gostship/kunkka/pkg/provider/addons/kubeproxy"
+	"github.com/gostship/kunkka/pkg/provider/addons/metallb"
 	"github.com/gostship/kunkka/pkg/provider/addons/metricsserver"
+	"github.com/gostship/kunkka/pkg/provider/condlog"
 	"github.com/gostship/kunkka/pkg/provider/phases/certs"
 
-	"sync"
-
 	"github.com/gostship/kunkka/pkg/provider/phases/component"
+	"github.com/gostship/kunkka/pkg/provider/phases/ha"
+	"github.com/gostship/kunkka/pkg/provider/phases/hostos"
 	"github.com/gostship/kunkka/pkg/util/k8sutil"
 	"github.com/gostship/kunkka/pkg/util/pkiutil"
 	"github.com/gostship/kunkka/pkg/util/ssh"
@@ -49,26 +54,29 @@ import (
 )
 
 func (p *Provider) EnsureCopyFiles(ctx context.Context, c *common.Cluster) error {
-	for _, file := range c.Spec.Features.Files {
-		for _, machine := range c.Spec.Machines {
-			machineSSH, err := machine.SSH()
-			if err != nil {
-				return err
-			}
+	if len(c.Spec.Features.Files) == 0 {
+		return nil
+	}
+
+	return forEachMachine(ctx, c, c.Spec.Machines, func(ctx context.Context, machine *devopsv1.ClusterMachine) error {
+		machineSSH, err := condlog.SSH(ctx, machine)
+		if err != nil {
+			return err
+		}
 
-			err = system.CopyFile(machineSSH, &file)
+		for i := range c.Spec.Features.Files {
+			err = system.CopyFile(machineSSH, &c.Spec.Features.Files[i])
 			if err != nil {
 				return err
 			}
 		}
-	}
-
-	return nil
+		return nil
+	})
 }
 
 func (p *Provider) EnsurePreflight(ctx context.Context, c *common.Cluster) error {
-	for _, machine := range c.Spec.Machines {
-		machineSSH, err := machine.SSH()
+	return forEachMachine(ctx, c, c.Spec.Machines, func(ctx context.Context, machine *devopsv1.ClusterMachine) error {
+		machineSSH, err := condlog.SSH(ctx, machine)
 		if err != nil {
 			return err
 		}
@@ -77,11 +85,10 @@ func (p *Provider) EnsurePreflight(ctx context.Context, c *common.Cluster) error
 		err = preflight.RunMasterChecks(machineSSH, c)
 		if err != nil {
 			klog.Errorf("node:%s check err: %+v", machine.IP, err)
-			return errors.Wrap(err, machine.IP)
+			return err
 		}
-	}
-
-	return nil
+		return nil
+	})
 }
 
 func (p *Provider) EnsureClusterComplete(ctx context.Context, c *common.Cluster) error {
@@ -148,8 +155,8 @@ func completeAddresses(cluster *common.Cluster) error {
 	}
 
 	if cluster.Spec.Features.HA != nil {
-		if cluster.Spec.Features.HA.DKEHA != nil {
-			cluster.AddAddress(devopsv1.AddressAdvertise, cluster.Spec.Features.HA.DKEHA.VIP, 6443)
+		if ha.Enabled(cluster.Cluster) {
+			cluster.AddAddress(devopsv1.AddressAdvertise, cluster.Spec.Features.HA.DKEHA.VIP, ha.Port(cluster.Cluster))
 		}
 		if cluster.Spec.Features.HA.ThirdPartyHA != nil {
 			cluster.AddAddress(devopsv1.AddressAdvertise, cluster.Spec.Features.HA.ThirdPartyHA.VIP, cluster.Spec.Features.HA.ThirdPartyHA.VPort)
@@ -180,23 +187,18 @@ func completeCredential(cluster *common.Cluster) error {
 }
 
 func (p *Provider) EnsureKubeconfig(ctx context.Context, c *common.Cluster) error {
-	for _, machine := range c.Spec.Machines {
-		machineSSH, err := machine.SSH()
+	return forEachMachine(ctx, c, c.Spec.Machines, func(ctx context.Context, machine *devopsv1.ClusterMachine) error {
+		machineSSH, err := condlog.SSH(ctx, machine)
 		if err != nil {
 			return err
 		}
 
-		err = kubemisc.Install(machineSSH, c)
-		if err != nil {
-			return errors.Wrap(err, machine.IP)
-		}
-	}
-
-	return nil
+		return kubemisc.Install(machineSSH, c)
+	})
 }
 
 func (p *Provider) EnsureKubeadmInitKubeletStartPhase(ctx context.Context, c *common.Cluster) error {
-	machineSSH, err := c.Spec.Machines[0].SSH()
+	machineSSH, err := condlog.SSH(ctx, c.Spec.Machines[0])
 	if err != nil {
 		return err
 	}
@@ -211,8 +213,8 @@ func (p *Provider) EnsureCerts(ctx context.Context, c *common.Cluster) error {
 		return err
 	}
 
-	for _, machine := range c.Spec.Machines {
-		sh, err := machine.SSH()
+	return forEachMachine(ctx, c, c.Spec.Machines, func(ctx context.Context, machine *devopsv1.ClusterMachine) error {
+		sh, err := condlog.SSH(ctx, machine)
 		if err != nil {
 			return err
 		}
@@ -225,13 +227,12 @@ func (p *Provider) EnsureCerts(ctx context.Context, c *common.Cluster) error {
 				return err
 			}
 		}
-	}
-
-	return nil
+		return nil
+	})
 }
 
 func (p *Provider) EnsureKubeMiscPhase(ctx context.Context, c *common.Cluster) error {
-	sh, err := c.Spec.Machines[0].SSH()
+	sh, err := condlog.SSH(ctx, c.Spec.Machines[0])
 	if err != nil {
 		return err
 	}
@@ -265,7 +266,7 @@ func (p *Provider) EnsureKubeMiscPhase(ctx context.Context, c *common.Cluster) e
 }
 
 func (p *Provider) EnsureKubeadmInitControlPlanePhase(ctx context.Context, c *common.Cluster) error {
-	machineSSH, err := c.Spec.Machines[0].SSH()
+	machineSSH, err := condlog.SSH(ctx, c.Spec.Machines[0])
 	if err != nil {
 		return err
 	}
@@ -274,7 +275,7 @@ func (p *Provider) EnsureKubeadmInitControlPlanePhase(ctx context.Context, c *co
 }
 
 func (p *Provider) EnsureKubeadmInitEtcdPhase(ctx context.Context, c *common.Cluster) error {
-	machineSSH, err := c.Spec.Machines[0].SSH()
+	machineSSH, err := condlog.SSH(ctx, c.Spec.Machines[0])
 	if err != nil {
 		return err
 	}
@@ -282,7 +283,7 @@ func (p *Provider) EnsureKubeadmInitEtcdPhase(ctx context.Context, c *common.Clu
 }
 
 func (p *Provider) EnsureKubeadmInitUploadConfigPhase(ctx context.Context, c *common.Cluster) error {
-	machineSSH, err := c.Spec.Machines[0].SSH()
+	machineSSH, err := condlog.SSH(ctx, c.Spec.Machines[0])
 	if err != nil {
 		return err
 	}
@@ -290,7 +291,7 @@ func (p *Provider) EnsureKubeadmInitUploadConfigPhase(ctx context.Context, c *co
 }
 
 func (p *Provider) EnsureKubeadmInitUploadCertsPhase(ctx context.Context, c *common.Cluster) error {
-	machineSSH, err := c.Spec.Machines[0].SSH()
+	machineSSH, err := condlog.SSH(ctx, c.Spec.Machines[0])
 	if err != nil {
 		return err
 	}
@@ -298,7 +299,7 @@ func (p *Provider) EnsureKubeadmInitUploadCertsPhase(ctx context.Context, c *com
 }
 
 func (p *Provider) EnsureKubeadmInitBootstrapTokenPhase(ctx context.Context, c *common.Cluster) error {
-	machineSSH, err := c.Spec.Machines[0].SSH()
+	machineSSH, err := condlog.SSH(ctx, c.Spec.Machines[0])
 	if err != nil {
 		return err
 	}
@@ -306,7 +307,7 @@ func (p *Provider) EnsureKubeadmInitBootstrapTokenPhase(ctx context.Context, c *
 }
 
 func (p *Provider) EnsureKubeadmInitAddonPhase(ctx context.Context, c *common.Cluster) error {
-	machineSSH, err := c.Spec.Machines[0].SSH()
+	machineSSH, err := condlog.SSH(ctx, c.Spec.Machines[0])
 	if err != nil {
 		return err
 	}
@@ -315,7 +316,7 @@ func (p *Provider) EnsureKubeadmInitAddonPhase(ctx context.Context, c *common.Cl
 
 func (p *Provider) EnsureJoinControlePlane(ctx context.Context, c *common.Cluster) error {
 	for _, machine := range c.Spec.Machines[1:] {
-		sh, err := machine.SSH()
+		sh, err := condlog.SSH(ctx, machine)
 		if err != nil {
 			return err
 		}
@@ -354,53 +355,30 @@ func (p *Provider) EnsureJoinControlePlane(ctx context.Context, c *common.Cluste
 }
 
 func (p *Provider) EnsureComponent(ctx context.Context, c *common.Cluster) error {
-	for _, machine := range c.Spec.Machines {
-		machineSSH, err := machine.SSH()
+	return forEachMachine(ctx, c, c.Spec.Machines, func(ctx context.Context, machine *devopsv1.ClusterMachine) error {
+		machineSSH, err := condlog.SSH(ctx, machine)
 		if err != nil {
 			return err
 		}
 
-		err = component.Install(machineSSH, c)
-		if err != nil {
-			return errors.Wrap(err, machine.IP)
-		}
-	}
-
-	return nil
+		return component.Install(machineSSH, c, p.Cfg, c.Spec.GetContainerRuntime())
+	})
 }
 
 func (p *Provider) EnsureSystem(ctx context.Context, c *common.Cluster) error {
-	wg := sync.WaitGroup{}
-	quitErrors := make(chan error)
-	wgDone := make(chan struct{})
-	for _, mach := range c.Spec.Machines {
-		sh, err := mach.SSH()
+	err := forEachMachine(ctx, c, c.Spec.Machines, func(ctx context.Context, machine *devopsv1.ClusterMachine) error {
+		sh, err := condlog.SSH(ctx, machine)
 		if err != nil {
 			return err
 		}
 
-		wg.Add(1)
-
-		go func(s ssh.Interface) {
-			defer wg.Done()
-			err = system.Install(s, c)
-			if err != nil {
-				quitErrors <- errors.Wrap(err, mach.IP)
-			}
-		}(sh)
-	}
-
-	go func() {
-		wg.Wait()
-		close(wgDone)
-	}()
-
-	// Wait until either WaitGroup is done or an error is received through the channel
-	select {
-	case <-wgDone:
-		break
-	case err := <-quitErrors:
-		close(quitErrors)
+		o, err := hostos.Detect(sh)
+		if err != nil {
+			return err
+		}
+		return system.Install(sh, c, p.Cfg, c.Spec.GetContainerRuntime(), o)
+	})
+	if err != nil {
 		klog.Errorf("err: %+v", err)
 		return err
 	}
@@ -410,7 +388,7 @@ func (p *Provider) EnsureSystem(ctx context.Context, c *common.Cluster) error {
 }
 
 func (p *Provider) EnsureKubeadmInitWaitControlPlanePhase(ctx context.Context, c *common.Cluster) error {
-	sh, err := c.Spec.Machines[0].SSH()
+	sh, err := condlog.SSH(ctx, c.Spec.Machines[0])
 	if err != nil {
 		return err
 	}
@@ -481,8 +459,8 @@ func (p *Provider) EnsureRegistryHosts(ctx context.Context, c *common.Cluster) e
 		p.Cfg.Registry.Domain,
 		c.Spec.TenantID + "." + p.Cfg.Registry.Domain,
 	}
-	for _, machine := range c.Spec.Machines {
-		machineSSH, err := machine.SSH()
+	return forEachMachine(ctx, c, c.Spec.Machines, func(ctx context.Context, machine *devopsv1.ClusterMachine) error {
+		machineSSH, err := condlog.SSH(ctx, machine)
 		if err != nil {
 			return err
 		}
@@ -491,12 +469,11 @@ func (p *Provider) EnsureRegistryHosts(ctx context.Context, c *common.Cluster) e
 			remoteHosts := &hosts.RemoteHosts{Host: one, SSH: machineSSH}
 			err := remoteHosts.Set(p.Cfg.Registry.IP)
 			if err != nil {
-				return errors.Wrap(err, machine.IP)
+				return err
 			}
 		}
-	}
-
-	return nil
+		return nil
+	})
 }
 
 func (p *Provider) EnsurePreInstallHook(ctx context.Context, c *common.Cluster) error {
@@ -510,8 +487,8 @@ func (p *Provider) EnsurePreInstallHook(ctx context.Context, c *common.Cluster)
 	}
 	cmd := strings.Split(hook, " ")[0]
 
-	for _, machine := range c.Spec.Machines {
-		machineSSH, err := machine.SSH()
+	return forEachMachine(ctx, c, c.Spec.Machines, func(ctx context.Context, machine *devopsv1.ClusterMachine) error {
+		machineSSH, err := condlog.SSH(ctx, machine)
 		if err != nil {
 			return err
 		}
@@ -521,8 +498,8 @@ func (p *Provider) EnsurePreInstallHook(ctx context.Context, c *common.Cluster)
 		if err != nil || exit != 0 {
 			return fmt.Errorf("exec %q failed:exit %d:stderr %s:error %s", hook, exit, stderr, err)
 		}
-	}
-	return nil
+		return nil
+	})
 }
 
 func (p *Provider) EnsurePostInstallHook(ctx context.Context, c *common.Cluster) error {
@@ -536,8 +513,8 @@ func (p *Provider) EnsurePostInstallHook(ctx context.Context, c *common.Cluster)
 	}
 	cmd := strings.Split(hook, " ")[0]
 
-	for _, machine := range c.Spec.Machines {
-		machineSSH, err := machine.SSH()
+	return forEachMachine(ctx, c, c.Spec.Machines, func(ctx context.Context, machine *devopsv1.ClusterMachine) error {
+		machineSSH, err := condlog.SSH(ctx, machine)
 		if err != nil {
 			return err
 		}
@@ -547,8 +524,8 @@ func (p *Provider) EnsurePostInstallHook(ctx context.Context, c *common.Cluster)
 		if err != nil || exit != 0 {
 			return fmt.Errorf("exec %q failed:exit %d:stderr %s:error %s", hook, exit, stderr, err)
 		}
-	}
-	return nil
+		return nil
+	})
 }
 
 func (p *Provider) EnsureApplyEtcd(ctx context.Context, c *common.Cluster) error {
@@ -560,7 +537,7 @@ func (p *Provider) EnsureApplyEtcd(ctx context.Context, c *common.Cluster) error
 	}
 
 	for _, machine := range c.Spec.Machines {
-		sh, err := machine.SSH()
+		sh, err := condlog.SSH(ctx, machine)
 		if err != nil {
 			return err
 		}
@@ -646,7 +623,7 @@ func (p *Provider) EnsureApplyEtcd(ctx context.Context, c *common.Cluster) error
 
 func (p *Provider) EnsureApplyControlPlane(ctx context.Context, c *common.Cluster) error {
 	for _, machine := range c.Spec.Machines[1:] {
-		sh, err := machine.SSH()
+		sh, err := condlog.SSH(ctx, machine)
 		if err != nil {
 			return err
 		}
@@ -659,15 +636,15 @@ func (p *Provider) EnsureApplyControlPlane(ctx context.Context, c *common.Cluste
 		if err != nil {
 			return err
 		}
-		// err = kubeadm.RestartContainerByFilter(sh, kubeadm.DockerFilterForControlPlane("kube-apiserver"))
+		// err = kubeadm.RestartContainer(sh, c.Spec.GetContainerRuntime(), "kube-apiserver")
 		// if err != nil {
 		// 	return err
 		// }
-		// err = kubeadm.RestartContainerByFilter(sh, kubeadm.DockerFilterForControlPlane("kube-controller-manager"))
+		// err = kubeadm.RestartContainer(sh, c.Spec.GetContainerRuntime(), "kube-controller-manager")
 		// if err != nil {
 		// 	return err
 		// }
-		// err = kubeadm.RestartContainerByFilter(sh, kubeadm.DockerFilterForControlPlane("kube-scheduler"))
+		// err = kubeadm.RestartContainer(sh, c.Spec.GetContainerRuntime(), "kube-scheduler")
 		// if err != nil {
 		// 	return err
 		// }
@@ -704,6 +681,68 @@ func (p *Provider) EnsureExtKubeconfig(ctx context.Context, c *common.Cluster) e
 	return nil
 }
 
+// EnsureDKEHA installs keepalived and haproxy on the masters holding the vip of DKEHA, the
+// files of every master are updated once a master is added or removed, and they are removed
+// from the masters taken out of the spec, or from all of them once DKEHA is disabled.
+func (p *Provider) EnsureDKEHA(ctx context.Context, c *common.Cluster) error {
+	if !ha.Enabled(c.Cluster) {
+		return p.uninstallHA(ctx, c, nil)
+	}
+
+	// the vip is advertised at the port of haproxy
+	vip, port := c.Spec.Features.HA.DKEHA.VIP, ha.Port(c.Cluster)
+	addrs := c.Cluster.Status.Addresses[:0]
+	for _, one := range c.Cluster.Status.Addresses {
+		if one.Type == devopsv1.AddressAdvertise && one.Host == vip && one.Port != port {
+			continue
+		}
+		addrs = append(addrs, one)
+	}
+	c.Cluster.Status.Addresses = addrs
+	c.AddAddress(devopsv1.AddressAdvertise, vip, port)
+
+	err := forEachMachine(ctx, c, c.Spec.Machines, func(ctx context.Context, machine *devopsv1.ClusterMachine) error {
+		sh, err := condlog.SSH(ctx, machine)
+		if err != nil {
+			return err
+		}
+
+		return ha.Install(sh, p.Cfg, c.Cluster)
+	})
+	if err != nil {
+		return err
+	}
+	return p.uninstallHA(ctx, c, c.Spec.Machines)
+}
+
+// uninstallHA removes keepalived and haproxy from the masters of the status not in the
+// masters, and records the masters in the status. The master failing the removal is kept
+// in the status, so it is tried again by the next update.
+func (p *Provider) uninstallHA(ctx context.Context, c *common.Cluster, masters []*devopsv1.ClusterMachine) error {
+	kept := make(map[string]bool, len(masters))
+	installed := make([]*devopsv1.ClusterMachine, 0, len(masters))
+	for _, m := range masters {
+		kept[m.IP] = true
+		installed = append(installed, m.DeepCopy())
+	}
+
+	for _, m := range c.Cluster.Status.HAMasters {
+		if kept[m.IP] {
+			continue
+		}
+		sh, err := condlog.SSH(ctx, m)
+		if err == nil {
+			err = ha.Uninstall(sh)
+		}
+		if err != nil {
+			klog.Warningf("cluster: %s failed remove ha from master %s: %v", c.Name, m.IP, err)
+			installed = append(installed, m)
+		}
+	}
+	c.Cluster.Status.HAMasters = installed
+	return nil
+}
+
 func (p *Provider) EnsureMetricsServer(ctx context.Context, c *common.Cluster) error {
 	clusterCtx, err := c.ClusterManager.Get(c.Name)
 	if err != nil {
@@ -726,11 +765,24 @@ func (p *Provider) EnsureMetricsServer(ctx context.Context, c *common.Cluster) e
 	return nil
 }
 
+func (p *Provider) EnsureMetalLB(ctx context.Context, c *common.Cluster) error {
+	// the ipvs kube-proxy needs strictARP for metallb, and back without it
+	err := kubeproxy.ApplyStrictARP(ctx, c)
+	if err != nil {
+		return err
+	}
+	return metallb.Apply(ctx, p.Cfg, c)
+}
+
+func (p *Provider) EnsureApps(ctx context.Context, c *common.Cluster) error {
+	return helm.ReconcileApps(ctx, c)
+}
+
 func (p *Provider) EnsureEth(ctx context.Context, c *common.Cluster) error {
 	var cniType string
 	var ok bool
 
-	if cniType, ok = c.Cluster.Spec.Features.Hooks[devopsv1.HookCniInstall]; !ok {
+	if cniType, ok = cni.Type(c); !ok {
 		return nil
 	}
 
@@ -738,8 +790,8 @@ func (p *Provider) EnsureEth(ctx context.Context, c *common.Cluster) error {
 		return nil
 	}
 
-	for _, machine := range c.Spec.Machines {
-		sh, err := machine.SSH()
+	return forEachMachine(ctx, c, c.Spec.Machines, func(ctx context.Context, machine *devopsv1.ClusterMachine) error {
+		sh, err := condlog.SSH(ctx, machine)
 		if err != nil {
 			return err
 		}
@@ -749,23 +801,22 @@ func (p *Provider) EnsureEth(ctx context.Context, c *common.Cluster) error {
 			klog.Errorf("node: %s apply eth err: %v", sh.HostIP(), err)
 			return err
 		}
-	}
-
-	return nil
+		return nil
+	})
 }
 
 func (p *Provider) EnsureCni(ctx context.Context, c *common.Cluster) error {
 	var cniType string
 	var ok bool
 
-	if cniType, ok = c.Cluster.Spec.Features.Hooks[devopsv1.HookCniInstall]; !ok {
+	if cniType, ok = cni.Type(c); !ok {
 		return nil
 	}
 
 	switch cniType {
 	case "dke-cni":
-		for _, machine := range c.Spec.Machines {
-			sh, err := machine.SSH()
+		return forEachMachine(ctx, c, c.Spec.Machines, func(ctx context.Context, machine *devopsv1.ClusterMachine) error {
+			sh, err := condlog.SSH(ctx, machine)
 			if err != nil {
 				return err
 			}
@@ -774,7 +825,8 @@ func (p *Provider) EnsureCni(ctx context.Context, c *common.Cluster) error {
 				klog.Errorf("node: %s apply cni cfg err: %v", sh.HostIP(), err)
 				return err
 			}
-		}
+			return nil
+		})
 	case "flannel":
 		clusterCtx, err := c.ClusterManager.Get(c.Name)
 		if err != nil {
@@ -793,6 +845,8 @@ func (p *Provider) EnsureCni(ctx context.Context, c *common.Cluster) error {
 				return errors.Wrapf(err, "Reconcile  err: %v", err)
 			}
 		}
+	case string(devopsv1.NetworkTypeCalico):
+		return calico.Apply(ctx, p.Cfg, c, c.Spec.Machines)
 	default:
 		return fmt.Errorf("unknown cni type: %s", cniType)
 	}
@@ -837,7 +891,7 @@ func (p *Provider) EnsureMasterNode(ctx context.Context, c *common.Cluster) erro
 	}
 
 	klog.Infof("start reconcile node: %s", noReadNode.IP)
-	sh, err := noReadNode.SSH()
+	sh, err := condlog.SSH(ctx, noReadNode)
 	if err != nil {
 		return err
 	}
@@ -849,9 +903,18 @@ func (p *Provider) EnsureMasterNode(ctx context.Context, c *common.Cluster) erro
 		}
 	}
 
+	runtime := c.Spec.GetContainerRuntime()
 	phases := []func(s ssh.Interface, c *common.Cluster) error{
-		system.Install,
-		component.Install,
+		func(s ssh.Interface, c *common.Cluster) error {
+			o, err := hostos.Detect(s)
+			if err != nil {
+				return err
+			}
+			return system.Install(s, c, p.Cfg, runtime, o)
+		},
+		func(s ssh.Interface, c *common.Cluster) error {
+			return component.Install(s, c, p.Cfg, runtime)
+		},
 		preflight.RunMasterChecks,
 		kubemisc.Install,
 		kubeadm.JoinControlPlane,
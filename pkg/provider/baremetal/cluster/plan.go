@@ -0,0 +1,153 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ghodss/yaml"
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	kubeadmv1beta2 "github.com/gostship/kunkka/pkg/apis/kubeadm/v1beta2"
+	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/provider/addons/cni"
+	"github.com/gostship/kunkka/pkg/provider/phases/hostos"
+	"github.com/gostship/kunkka/pkg/provider/phases/kubeadm"
+	"github.com/gostship/kunkka/pkg/provider/phases/system"
+	"github.com/gostship/kunkka/pkg/provider/plan"
+	"github.com/gostship/kunkka/pkg/provider/preflight"
+	"github.com/gostship/kunkka/pkg/util/pkiutil"
+	"github.com/pkg/errors"
+	certutil "k8s.io/client-go/util/cert"
+)
+
+const (
+	redactedToken          = "xxxxxx.xxxxxxxxxxxxxxxx"
+	redactedCertificateKey = "redacted"
+)
+
+// plan validates the cluster, runs the preflight checks which only read the hosts, and
+// renders the files the create handlers would write.
+func (p *Provider) plan(ctx context.Context, c *common.Cluster, result *plan.Plan) error {
+	c, err := p.planCluster(c)
+	if err != nil {
+		result.Errors = append(result.Errors, err.Error())
+		return nil
+	}
+	for _, err := range p.Validate(c) {
+		result.Errors = append(result.Errors, err.Error())
+	}
+
+	if len(c.Spec.Machines) > 0 {
+		cfg := kubeadm.GetKubeadmConfigByMaster0(c, p.Cfg)
+		data, err := cfg.Marshal()
+		if err != nil {
+			return errors.Wrap(err, "marshal kubeadm config")
+		}
+		result.AddArtifact("kubeadm.yaml", data)
+
+		sans, err := certSANs(c, cfg)
+		if err != nil {
+			result.Errors = append(result.Errors, err.Error())
+		} else {
+			result.AddArtifact("cert-sans.yaml", sans)
+		}
+	}
+
+	for _, machine := range c.Spec.Machines {
+		o, err := detectOS(machine)
+		if err != nil {
+			result.Errors = append(result.Errors, err.Error())
+		} else {
+			data, err := system.BuildInitScript(c, p.Cfg, machine.IP, c.Spec.GetContainerRuntime(), o)
+			if err != nil {
+				return errors.Wrapf(err, "render init script of %s", machine.IP)
+			}
+			result.AddArtifact(fmt.Sprintf("init-%s.sh", machine.IP), data)
+		}
+
+		if cniType := c.Spec.Features.Hooks[devopsv1.HookCniInstall]; cniType == "dke-cni" && machine.HostCni != nil {
+			data, err := cni.BuildHostLocalConfig(machine)
+			if err != nil {
+				return errors.Wrapf(err, "render cni of %s", machine.IP)
+			}
+			result.AddArtifact(fmt.Sprintf("cni-%s.json", machine.IP), data)
+		}
+
+		result.Preflight[machine.IP] = plan.PreflightResult(func() error {
+			machineSSH, err := machine.SSH()
+			if err != nil {
+				return err
+			}
+			return preflight.RunMasterChecks(machineSSH, c)
+		}())
+	}
+
+	return nil
+}
+
+// planCluster returns a copy of the cluster completed like EnsureClusterComplete,
+// the credentials are redacted as the plan is saved in a ConfigMap.
+func (p *Provider) planCluster(c *common.Cluster) (*common.Cluster, error) {
+	cp := &common.Cluster{
+		Cluster:           c.Cluster.DeepCopy(),
+		ClusterCredential: c.ClusterCredential.DeepCopy(),
+		Client:            c.Client,
+		ClusterManager:    c.ClusterManager,
+	}
+	if cp.ClusterCredential == nil {
+		cp.ClusterCredential = &devopsv1.ClusterCredential{}
+	}
+	token, key := redactedToken, redactedCertificateKey
+	cp.ClusterCredential.BootstrapToken = &token
+	cp.ClusterCredential.CertificateKey = &key
+
+	if err := p.PreCreate(cp); err != nil {
+		return nil, err
+	}
+	funcs := []func(cluster *common.Cluster) error{
+		completeK8sVersion,
+		completeNetworking,
+		completeDNS,
+		completeAddresses,
+	}
+	for _, f := range funcs {
+		if err := f(cp); err != nil {
+			return nil, err
+		}
+	}
+	return cp, nil
+}
+
+func certSANs(c *common.Cluster, cfg *kubeadm.Config) ([]byte, error) {
+	warp := &kubeadmv1beta2.WarpperConfiguration{
+		InitConfiguration:    cfg.InitConfiguration,
+		ClusterConfiguration: cfg.ClusterConfiguration,
+		IPs:                  c.IPs(),
+	}
+
+	sans := make(map[string][]string)
+	for name, f := range map[string]func(*kubeadmv1beta2.WarpperConfiguration) (*certutil.AltNames, error){
+		pkiutil.APIServerCertName:  pkiutil.GetAPIServerAltNames,
+		pkiutil.EtcdServerCertName: pkiutil.GetEtcdAltNames,
+		pkiutil.EtcdPeerCertName:   pkiutil.GetEtcdPeerAltNames,
+	} {
+		altNames, err := f(warp)
+		if err != nil {
+			return nil, errors.Wrapf(err, "get %s sans", name)
+		}
+		names := append([]string{}, altNames.DNSNames...)
+		for _, ip := range altNames.IPs {
+			names = append(names, ip.String())
+		}
+		sans[name] = names
+	}
+	return yaml.Marshal(sans)
+}
+
+// detectOS reads the os of the host, the init script is rendered for it.
+func detectOS(machine *devopsv1.ClusterMachine) (*hostos.OS, error) {
+	machineSSH, err := machine.SSH()
+	if err != nil {
+		return nil, err
+	}
+	return hostos.Detect(machineSSH)
+}
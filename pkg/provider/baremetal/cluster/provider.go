@@ -1,6 +1,7 @@
 package cluster
 
 import (
+	"context"
 	"path"
 	"strings"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/gostship/kunkka/pkg/controllers/common"
 	"github.com/gostship/kunkka/pkg/provider/baremetal/validation"
 	"github.com/gostship/kunkka/pkg/provider/config"
+	"github.com/gostship/kunkka/pkg/util/parallel"
 	"github.com/gostship/kunkka/pkg/util/pointer"
 	"k8s.io/klog"
 )
@@ -44,6 +46,7 @@ func NewProvider(mgr *clusterprovider.CpManager, cfg *config.Config) (*Provider,
 
 	p.DelegateProvider = &clusterprovider.DelegateProvider{
 		ProviderName: "Baremetal",
+		Retry:        &cfg.Retry,
 		CreateHandlers: []clusterprovider.Handler{
 			p.EnsureCopyFiles,
 			p.EnsurePreInstallHook,
@@ -67,22 +70,34 @@ func NewProvider(mgr *clusterprovider.CpManager, cfg *config.Config) (*Provider,
 			p.EnsureJoinControlePlane,
 			p.EnsureMarkControlPlane,
 			p.EnsureApplyEtcd,
+			p.EnsureDKEHA,
 
 			p.EnsureCni,
 			p.EnsureApplyControlPlane,
 			p.EnsureExtKubeconfig,
+			p.EnsureApps,
 			//p.EnsurePostInstallHook,
 		},
 		UpdateHandlers: []clusterprovider.Handler{
 			p.EnsureExtKubeconfig,
 			p.EnsureMasterNode,
+			p.EnsureDKEHA,
 			p.EnsureCni,
 			p.EnsureApplyEtcd,
 			p.EnsureApplyControlPlane,
 			p.EnsureRenewCerts,
+			p.EnsureRotateCA,
 			p.EnsureAPIServerCert,
 			p.EnsureMetricsServer,
+			p.EnsureMetalLB,
+			p.EnsureApps,
 		},
+		UpgradeHandlers: []clusterprovider.Handler{
+			p.EnsureUpgradeControlPlane,
+			p.EnsureUpgradeMachines,
+		},
+		PlanFunc:         p.plan,
+		CertificatesFunc: p.certificates,
 	}
 
 	return p, nil
@@ -129,3 +144,22 @@ func (p *Provider) PreCreate(cluster *common.Cluster) error {
 
 	return nil
 }
+
+// forEachMachine runs f on the machines with the parallelism of the cluster, and returns the
+// errors of the hosts together. The handlers which must run the machines in order, like
+// EnsureJoinControlePlane, loop the machines themselves.
+func forEachMachine(ctx context.Context, c *common.Cluster, machines []*devopsv1.ClusterMachine,
+	f func(ctx context.Context, machine *devopsv1.ClusterMachine) error) error {
+	workers := int(c.Spec.Features.Parallelism)
+	if workers <= 0 {
+		workers = constants.DefaultMachineParallelism
+	}
+
+	names := make([]string, 0, len(machines))
+	for _, machine := range machines {
+		names = append(names, machine.IP)
+	}
+	return parallel.ForEach(ctx, workers, names, func(ctx context.Context, i int) error {
+		return f(ctx, machines[i])
+	})
+}
@@ -1,14 +1,19 @@
 package cluster
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"reflect"
 	"time"
 
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
 	"github.com/gostship/kunkka/pkg/constants"
 	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/provider/condlog"
+	"github.com/gostship/kunkka/pkg/provider/phases/carotation"
 	"github.com/gostship/kunkka/pkg/provider/phases/certs"
+	"github.com/gostship/kunkka/pkg/provider/phases/joinnode"
 	"github.com/gostship/kunkka/pkg/provider/phases/kubeadm"
 	"github.com/gostship/kunkka/pkg/provider/phases/kubemisc"
 	"github.com/gostship/kunkka/pkg/util/k8sutil"
@@ -16,39 +21,141 @@ import (
 	"github.com/prometheus/common/log"
 	"github.com/thoas/go-funk"
 	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/klog"
 )
 
+// EnsureRenewCerts issues the certificates of the cluster again with the certificate
+// authorities of the credential once some expire within the renew duration of the config.
 func (p *Provider) EnsureRenewCerts(ctx context.Context, c *common.Cluster) error {
-	for _, machine := range c.Spec.Machines {
-		s, err := machine.SSH()
+	list, err := p.Certificates(ctx, c)
+	if err != nil {
+		return err
+	}
+	leaf, _ := certs.Expiring(list, p.Cfg.Certs.RenewBefore, time.Now())
+	if len(leaf) == 0 {
+		klog.Infof("cluster: %s skip EnsureRenewCerts because no certificate expires within %s", c.Name, p.Cfg.Certs.RenewBefore)
+		return nil
+	}
+
+	klog.Infof("cluster: %s EnsureRenewCerts, %s of %s expires at %s", c.Name, leaf[0].Name, leaf[0].Source, leaf[0].NotAfter)
+	return p.reissueCerts(ctx, c)
+}
+
+// EnsureRotateCA rotates the certificate authorities of the cluster once one expires within
+// the renew duration of the config, one stage of the rotation runs each time, see
+// carotation.Rotate, and the certificate controller keeps the handler in the action annotation
+// until the rotation is done. The masters are restarted one by one in each stage. The service
+// account keys are kept, so the tokens are still valid.
+func (p *Provider) EnsureRotateCA(ctx context.Context, c *common.Cluster) error {
+	if c.ClusterCredential.CARotation == nil {
+		list, err := p.Certificates(ctx, c)
 		if err != nil {
 			return err
 		}
+		_, ca := certs.Expiring(list, p.Cfg.Certs.RenewBefore, time.Now())
+		if len(ca) == 0 {
+			klog.Infof("cluster: %s skip EnsureRotateCA because no certificate authority expires within %s", c.Name, p.Cfg.Certs.RenewBefore)
+			return nil
+		}
+		klog.Infof("cluster: %s EnsureRotateCA, %s of %s expires at %s", c.Name, ca[0].Name, ca[0].Source, ca[0].NotAfter)
+	}
 
-		data, err := s.ReadFile(constants.APIServerCertName)
+	return carotation.Rotate(ctx, c, kubeadm.GetKubeadmConfigByMaster0(c, p.Cfg), false, p.applyCerts)
+}
+
+// reissueCerts issues the certificates and the kubeconfigs of the credential again, and
+// writes them to the masters and the workers.
+func (p *Provider) reissueCerts(ctx context.Context, c *common.Cluster) error {
+	err := kubeadm.ReissueCerts(kubeadm.GetKubeadmConfigByMaster0(c, p.Cfg), c, false)
+	if err != nil {
+		return err
+	}
+	err = p.applyCerts(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	err = joinnode.RenewMachines(ctx, c)
+	if err != nil {
+		return err
+	}
+	return c.ReloadClient()
+}
+
+// applyCerts builds the kubeconfigs of the credential, and writes them and the certificates
+// to the masters. The masters are restarted one by one, so the cluster keeps serving.
+func (p *Provider) applyCerts(ctx context.Context, c *common.Cluster) error {
+	err := kubemisc.ApplyMasterMisc(c, certs.BuildApiserverEndpoint(c.Spec.Machines[0].IP, 6443))
+	if err != nil {
+		return err
+	}
+	err = p.EnsureExtKubeconfig(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	for _, machine := range c.Spec.Machines {
+		sh, err := condlog.SSH(ctx, machine)
 		if err != nil {
 			return err
 		}
-		cts, err := certutil.ParseCertsPEM(data)
+
+		for pathFile, va := range c.ClusterCredential.CertsBinaryData {
+			klog.Infof("node: %s start write BinaryData [%s] ...", sh.HostIP(), pathFile)
+			err = sh.WriteFile(bytes.NewReader(va), pathFile)
+			if err != nil {
+				return errors.Wrapf(err, "node: %s write %s", sh.HostIP(), pathFile)
+			}
+		}
+		err = kubemisc.CovertMasterKubeConfig(sh, c)
 		if err != nil {
 			return err
 		}
-		expirationDuration := time.Until(cts[0].NotAfter)
-		if expirationDuration > constants.RenewCertsTimeThreshold {
-			log.Infof("skip EnsureRenewCerts because expiration duration(%s) > threshold(%s)", expirationDuration, constants.RenewCertsTimeThreshold)
-			return nil
+		err = kubemisc.Install(sh, c)
+		if err != nil {
+			return err
 		}
 
-		log.Infof("EnsureRenewCerts for %s", s.Host)
-		err = kubeadm.RenewCerts(s)
+		_, err = sh.CombinedOutput("systemctl restart kubelet")
 		if err != nil {
-			return errors.Wrap(err, machine.IP)
+			return errors.Wrapf(err, "node: %s restart kubelet", sh.HostIP())
+		}
+		// etcd reads the certificate authorities on start only
+		if c.ClusterCredential.CARotation != nil && c.Spec.Etcd != nil && c.Spec.Etcd.Local != nil {
+			err = kubeadm.RestartContainer(sh, c.Spec.GetContainerRuntime(), "etcd")
+			if err != nil {
+				return err
+			}
+		}
+		err = kubeadm.RestartControlPlane(sh, c.Spec.GetContainerRuntime())
+		if err != nil {
+			return err
+		}
+		err = kubeadm.WaitAPIServer(sh, 6443)
+		if err != nil {
+			return err
 		}
 	}
-
 	return nil
 }
 
+// certificates returns the certificates on the masters.
+func (p *Provider) certificates(ctx context.Context, c *common.Cluster) ([]devopsv1.CertificateStatus, error) {
+	var result []devopsv1.CertificateStatus
+	for _, machine := range c.Spec.Machines {
+		sh, err := machine.SSH()
+		if err != nil {
+			return nil, err
+		}
+		files, err := certs.NodeFiles(sh)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, certs.Inventory(machine.IP, files)...)
+	}
+	return result, nil
+}
+
 func (p *Provider) EnsureAPIServerCert(ctx context.Context, c *common.Cluster) error {
 	apiserver := certs.BuildApiserverEndpoint(c.Cluster.Spec.PublicAlternativeNames[0], kubemisc.GetBindPort(c.Cluster))
 
@@ -57,7 +164,7 @@ func (p *Provider) EnsureAPIServerCert(ctx context.Context, c *common.Cluster) e
 
 	needUpload := false
 	for _, machine := range c.Spec.Machines {
-		s, err := machine.SSH()
+		s, err := condlog.SSH(ctx, machine)
 		if err != nil {
 			return err
 		}
@@ -78,7 +185,7 @@ func (p *Provider) EnsureAPIServerCert(ctx context.Context, c *common.Cluster) e
 			return nil
 		}
 
-		log.Infof("EnsureAPIServerCert for %s", s.Host)
+		log.Infof("EnsureAPIServerCert for %s", s.HostIP())
 		for _, file := range []string{constants.APIServerCertName, constants.APIServerKeyName} {
 			s.CombinedOutput(fmt.Sprintf("rm -f %s", file))
 		}
@@ -87,7 +194,7 @@ func (p *Provider) EnsureAPIServerCert(ctx context.Context, c *common.Cluster) e
 		if err != nil {
 			return errors.Wrap(err, machine.IP)
 		}
-		err = kubeadm.RestartContainerByFilter(s, kubeadm.DockerFilterForControlPlane("kube-apiserver"))
+		err = kubeadm.RestartContainer(s, c.Spec.GetContainerRuntime(), "kube-apiserver")
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,100 @@
+package cluster
+
+import (
+	"context"
+	"time"
+
+	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/provider/condlog"
+	"github.com/gostship/kunkka/pkg/provider/phases/component"
+	"github.com/gostship/kunkka/pkg/provider/phases/kubeadm"
+	"github.com/gostship/kunkka/pkg/provider/phases/upgrade"
+	"github.com/gostship/kunkka/pkg/util/apiclient"
+	"github.com/gostship/kunkka/pkg/util/ssh"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog"
+)
+
+// EnsureUpgradeControlPlane upgrades the masters one by one with kubeadm,
+// the kubelet of each master is upgraded after its control plane is healthy.
+func (p *Provider) EnsureUpgradeControlPlane(ctx context.Context, c *common.Cluster) error {
+	clientset, err := c.ClientsetForBootstrap()
+	if err != nil {
+		return err
+	}
+
+	for i, machine := range c.Spec.Machines {
+		ok, err := apiclient.CheckNodeVersion(ctx, clientset, machine.IP, c.Spec.Version)
+		if err != nil {
+			return errors.Wrap(err, machine.IP)
+		}
+		if ok {
+			klog.Infof("node: %s already upgraded to %s", machine.IP, c.Spec.Version)
+			continue
+		}
+
+		sh, err := condlog.SSH(ctx, machine)
+		if err != nil {
+			return err
+		}
+
+		err = component.UpgradeBinaries(sh, c, p.Cfg)
+		if err != nil {
+			return errors.Wrap(err, machine.IP)
+		}
+
+		if i == 0 {
+			// kubeadm upgrade reads the cluster config with the new version from kube-system
+			err = kubeadm.Init(sh, kubeadm.GetKubeadmConfigByMaster0(c, p.Cfg), "upload-config all ")
+			if err != nil {
+				return errors.Wrap(err, machine.IP)
+			}
+			err = kubeadm.UpgradeApply(sh, c.Spec.Version)
+		} else {
+			err = kubeadm.UpgradeNode(sh)
+		}
+		if err != nil {
+			return errors.Wrap(err, machine.IP)
+		}
+
+		if p.Cfg.CustomeImages {
+			err = kubeadm.ApplyCustomMaster(sh, c, p.Cfg)
+			if err != nil {
+				return errors.Wrap(err, machine.IP)
+			}
+		}
+
+		err = waitLocalAPIServer(sh)
+		if err != nil {
+			return errors.Wrap(err, machine.IP)
+		}
+
+		// kubeadm upgraded the configuration of the master above
+		err = upgrade.Kubelet(ctx, sh, c, p.Cfg, clientset, nil)
+		if err != nil {
+			return errors.Wrap(err, machine.IP)
+		}
+		klog.Infof("node: %s upgrade control plane to %s success", machine.IP, c.Spec.Version)
+	}
+
+	return nil
+}
+
+// EnsureUpgradeMachines upgrades the kubelet of the worker machines one by one, their kubelet
+// configuration is upgraded by kubeadm upgrade node.
+func (p *Provider) EnsureUpgradeMachines(ctx context.Context, c *common.Cluster) error {
+	return upgrade.Machines(ctx, c, p.Cfg, upgrade.KubeadmNode)
+}
+
+func waitLocalAPIServer(s ssh.Interface) error {
+	cmd := "curl -sk --max-time 3 https://127.0.0.1:6443/healthz"
+	return wait.PollImmediate(5*time.Second, 5*time.Minute, func() (bool, error) {
+		out, err := s.CombinedOutput(cmd)
+		if err != nil || string(out) != "ok" {
+			klog.Warningf("node: %s apiserver not healthy: %s", s.HostIP(), string(out))
+			return false, nil
+		}
+		return true, nil
+	})
+}
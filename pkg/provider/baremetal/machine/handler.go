@@ -14,9 +14,13 @@ import (
 	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
 	"github.com/gostship/kunkka/pkg/constants"
 	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/provider/addons/calico"
 	"github.com/gostship/kunkka/pkg/provider/addons/cni"
+	"github.com/gostship/kunkka/pkg/provider/condlog"
 	"github.com/gostship/kunkka/pkg/provider/phases/certs"
 	"github.com/gostship/kunkka/pkg/provider/phases/component"
+	"github.com/gostship/kunkka/pkg/provider/phases/ha"
+	"github.com/gostship/kunkka/pkg/provider/phases/hostos"
 	"github.com/gostship/kunkka/pkg/provider/phases/joinnode"
 	"github.com/gostship/kunkka/pkg/provider/phases/kubemisc"
 	"github.com/gostship/kunkka/pkg/provider/phases/system"
@@ -28,7 +32,7 @@ import (
 )
 
 func (p *Provider) EnsureCopyFiles(ctx context.Context, machine *devopsv1.Machine, c *common.Cluster) error {
-	machineSSH, err := machine.Spec.SSH()
+	machineSSH, err := condlog.SSH(ctx, &machine.Spec)
 	if err != nil {
 		return err
 	}
@@ -49,7 +53,7 @@ func (p *Provider) EnsurePreInstallHook(ctx context.Context, machine *devopsv1.M
 		return nil
 	}
 
-	machineSSH, err := machine.Spec.SSH()
+	machineSSH, err := condlog.SSH(ctx, &machine.Spec)
 	if err != nil {
 		return err
 	}
@@ -70,7 +74,7 @@ func (p *Provider) EnsurePostInstallHook(ctx context.Context, machine *devopsv1.
 		return nil
 	}
 
-	machineSSH, err := machine.Spec.SSH()
+	machineSSH, err := condlog.SSH(ctx, &machine.Spec)
 	if err != nil {
 		return err
 	}
@@ -87,7 +91,7 @@ func (p *Provider) EnsurePostInstallHook(ctx context.Context, machine *devopsv1.
 }
 
 func (p *Provider) EnsureClean(ctx context.Context, machine *devopsv1.Machine, c *common.Cluster) error {
-	machineSSH, err := machine.Spec.SSH()
+	machineSSH, err := condlog.SSH(ctx, &machine.Spec)
 	if err != nil {
 		return err
 	}
@@ -106,7 +110,7 @@ func (p *Provider) EnsureClean(ctx context.Context, machine *devopsv1.Machine, c
 }
 
 func (p *Provider) EnsurePreflight(ctx context.Context, machine *devopsv1.Machine, c *common.Cluster) error {
-	machineSSH, err := machine.Spec.SSH()
+	machineSSH, err := condlog.SSH(ctx, &machine.Spec)
 	if err != nil {
 		return err
 	}
@@ -137,7 +141,7 @@ func (p *Provider) EnsureRegistryHosts(ctx context.Context, machine *devopsv1.Ma
 		return nil
 	}
 
-	sh, err := machine.Spec.SSH()
+	sh, err := condlog.SSH(ctx, &machine.Spec)
 	if err != nil {
 		return err
 	}
@@ -168,12 +172,18 @@ func (p *Provider) EnsureRegistryHosts(ctx context.Context, machine *devopsv1.Ma
 }
 
 func (p *Provider) EnsureSystem(ctx context.Context, machine *devopsv1.Machine, c *common.Cluster) error {
-	sh, err := machine.Spec.SSH()
+	sh, err := condlog.SSH(ctx, &machine.Spec)
 	if err != nil {
 		return err
 	}
 
-	err = system.Install(sh, c)
+	o, err := hostos.Detect(sh)
+	if err != nil {
+		return err
+	}
+	hostos.Record(&machine.Status.MachineInfo, o)
+
+	err = system.Install(sh, c, p.Cfg, machine.Spec.GetContainerRuntime(&c.Spec), o)
 	if err != nil {
 		return errors.Wrap(err, sh.HostIP())
 	}
@@ -182,12 +192,12 @@ func (p *Provider) EnsureSystem(ctx context.Context, machine *devopsv1.Machine,
 }
 
 func (p *Provider) EnsureK8sComponent(ctx context.Context, machine *devopsv1.Machine, c *common.Cluster) error {
-	sh, err := machine.Spec.SSH()
+	sh, err := condlog.SSH(ctx, &machine.Spec)
 	if err != nil {
 		return err
 	}
 
-	err = component.Install(sh, c)
+	err = component.Install(sh, c, p.Cfg, machine.Spec.GetContainerRuntime(&c.Spec))
 	if err != nil {
 		return errors.Wrap(err, sh.HostIP())
 	}
@@ -195,11 +205,20 @@ func (p *Provider) EnsureK8sComponent(ctx context.Context, machine *devopsv1.Mac
 	return nil
 }
 
+// apiserverEndpoint returns the apiserver the nodes join, the vip of the masters if they
+// hold one.
+func apiserverEndpoint(c *common.Cluster) string {
+	if ha.Enabled(c.Cluster) {
+		return certs.BuildApiserverEndpoint(c.Spec.Features.HA.DKEHA.VIP, int(ha.Port(c.Cluster)))
+	}
+	return certs.BuildApiserverEndpoint(c.Cluster.Spec.PublicAlternativeNames[0], kubemisc.GetBindPort(c.Cluster))
+}
+
 func (p *Provider) EnsureKubeconfig(ctx context.Context, machine *devopsv1.Machine, c *common.Cluster) error {
-	apiserver := certs.BuildApiserverEndpoint(c.Cluster.Spec.PublicAlternativeNames[0], kubemisc.GetBindPort(c.Cluster))
+	apiserver := apiserverEndpoint(c)
 	klog.Infof("join apiserver: %s", apiserver)
 
-	machineSSH, err := machine.Spec.SSH()
+	machineSSH, err := condlog.SSH(ctx, &machine.Spec)
 	if err != nil {
 		return err
 	}
@@ -219,15 +238,15 @@ func (p *Provider) EnsureKubeconfig(ctx context.Context, machine *devopsv1.Machi
 }
 
 func (p *Provider) EnsureJoinNode(ctx context.Context, machine *devopsv1.Machine, c *common.Cluster) error {
-	sh, err := machine.Spec.SSH()
+	sh, err := condlog.SSH(ctx, &machine.Spec)
 	if err != nil {
 		return err
 	}
 
-	apiserver := certs.BuildApiserverEndpoint(c.Cluster.Spec.PublicAlternativeNames[0], kubemisc.GetBindPort(c.Cluster))
+	apiserver := apiserverEndpoint(c)
 	klog.Infof("join apiserver: %s", apiserver)
 
-	err = joinnode.JoinNodePhase(sh, p.Cfg, c, apiserver, false)
+	err = joinnode.JoinNodePhase(sh, p.Cfg, c, apiserver, false, machine.Spec.GetContainerRuntime(&c.Spec))
 	if err != nil {
 		return err
 	}
@@ -251,7 +270,7 @@ func (p *Provider) EnsureEth(ctx context.Context, machine *devopsv1.Machine, c *
 	var cniType string
 	var ok bool
 
-	if cniType, ok = c.Cluster.Spec.Features.Hooks[devopsv1.HookCniInstall]; !ok {
+	if cniType, ok = cni.Type(c); !ok {
 		return nil
 	}
 
@@ -259,7 +278,7 @@ func (p *Provider) EnsureEth(ctx context.Context, machine *devopsv1.Machine, c *
 		return nil
 	}
 
-	sh, err := machine.Spec.SSH()
+	sh, err := condlog.SSH(ctx, &machine.Spec)
 	if err != nil {
 		return err
 	}
@@ -277,15 +296,19 @@ func (p *Provider) EnsureCni(ctx context.Context, machine *devopsv1.Machine, c *
 	var cniType string
 	var ok bool
 
-	if cniType, ok = c.Cluster.Spec.Features.Hooks[devopsv1.HookCniInstall]; !ok {
+	if cniType, ok = cni.Type(c); !ok {
 		return nil
 	}
 
+	if cniType == string(devopsv1.NetworkTypeCalico) {
+		return calico.Apply(ctx, p.Cfg, c, []*devopsv1.ClusterMachine{machine.Spec.Machine})
+	}
+
 	if cniType != "dke-cni" {
 		return nil
 	}
 
-	sh, err := machine.Spec.SSH()
+	sh, err := condlog.SSH(ctx, &machine.Spec)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,64 @@
+package machine
+
+import (
+	"context"
+	"fmt"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/provider/addons/cni"
+	"github.com/gostship/kunkka/pkg/provider/phases/hostos"
+	"github.com/gostship/kunkka/pkg/provider/phases/system"
+	"github.com/gostship/kunkka/pkg/provider/plan"
+	"github.com/gostship/kunkka/pkg/provider/preflight"
+	"github.com/pkg/errors"
+)
+
+// plan validates the machine, runs the preflight checks which only read the host, and
+// renders the files the create handlers would write.
+func (p *Provider) plan(ctx context.Context, machine *devopsv1.Machine, c *common.Cluster, result *plan.Plan) error {
+	for _, err := range p.Validate(machine) {
+		result.Errors = append(result.Errors, err.Error())
+	}
+	if machine.Spec.Machine == nil {
+		return nil
+	}
+
+	ip := machine.Spec.Machine.IP
+	o, err := detectOS(machine)
+	if err != nil {
+		result.Errors = append(result.Errors, err.Error())
+	} else {
+		data, err := system.BuildInitScript(c, p.Cfg, ip, machine.Spec.GetContainerRuntime(&c.Spec), o)
+		if err != nil {
+			return errors.Wrapf(err, "render init script of %s", ip)
+		}
+		result.AddArtifact(fmt.Sprintf("init-%s.sh", ip), data)
+	}
+
+	if cniType := c.Cluster.Spec.Features.Hooks[devopsv1.HookCniInstall]; cniType == "dke-cni" && machine.Spec.Machine.HostCni != nil {
+		data, err := cni.BuildNodeHostLocalConfig(machine)
+		if err != nil {
+			return errors.Wrapf(err, "render cni of %s", ip)
+		}
+		result.AddArtifact(fmt.Sprintf("cni-%s.json", ip), data)
+	}
+
+	result.Preflight[ip] = plan.PreflightResult(func() error {
+		machineSSH, err := machine.Spec.SSH()
+		if err != nil {
+			return err
+		}
+		return preflight.RunNodeChecks(machineSSH)
+	}())
+	return nil
+}
+
+// detectOS reads the os of the host, the init script is rendered for it.
+func detectOS(machine *devopsv1.Machine) (*hostos.OS, error) {
+	machineSSH, err := machine.Spec.SSH()
+	if err != nil {
+		return nil, err
+	}
+	return hostos.Detect(machineSSH)
+}
@@ -35,6 +35,7 @@ func NewProvider(mgr *machineprovider.MpManager, cfg *config.Config) (*Provider,
 
 	p.DelegateProvider = &machineprovider.DelegateProvider{
 		ProviderName: "Baremetal",
+		Retry:        &cfg.Retry,
 		CreateHandlers: []machineprovider.Handler{
 			p.EnsureCopyFiles,
 			p.EnsurePreInstallHook,
@@ -59,6 +60,7 @@ func NewProvider(mgr *machineprovider.MpManager, cfg *config.Config) (*Provider,
 			p.EnsurePostInstallHook,
 			p.EnsureRegistryHosts,
 		},
+		PlanFunc: p.plan,
 	}
 
 	return p, nil
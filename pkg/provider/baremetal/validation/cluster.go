@@ -1,9 +1,12 @@
 package validation
 
 import (
+	"bytes"
 	"fmt"
 	"net"
+	"strings"
 
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
@@ -22,6 +25,9 @@ var (
 // ValidateCluster validates a given Cluster.
 func ValidateCluster(obj *common.Cluster) field.ErrorList {
 	allErrs := ValidatClusterSpec(&obj.Spec, field.NewPath("spec"), obj.Cluster.Status.Phase)
+	for i, m := range obj.Spec.Machines {
+		allErrs = append(allErrs, ValidateCredentialsRefs(obj.Namespace, m, field.NewPath("spec", "machines").Index(i))...)
+	}
 
 	return allErrs
 }
@@ -33,6 +39,12 @@ func ValidatClusterSpec(spec *devopsv1.ClusterSpec, fldPath *field.Path, phase d
 	allErrs = append(allErrs, ValidateClusterSpecVersion(spec.Version, fldPath.Child("version"), phase)...)
 	allErrs = append(allErrs, ValidateCIDRs(spec, fldPath)...)
 	allErrs = append(allErrs, ValidateClusterProperty(spec, fldPath.Child("properties"))...)
+	allErrs = append(allErrs, ValidateClusterApps(spec.Apps, fldPath.Child("apps"))...)
+	allErrs = append(allErrs, ValidateLoadBalancer(spec, fldPath.Child("features", "loadBalancer"))...)
+	allErrs = append(allErrs, ValidateDKEHA(spec, fldPath.Child("features", "ha", "dke"))...)
+	for i, m := range spec.Machines {
+		allErrs = append(allErrs, ValidateJumpHosts(m.JumpHosts, fldPath.Child("machines").Index(i).Child("jumpHosts"))...)
+	}
 	// allErrs = append(allErrs, ValidateClusterMachines(spec.Machines, fldPath.Child("machines"))...)
 	// allErrs = append(allErrs, ValidateClusterFeature(&spec.Features, fldPath.Child("features"))...)
 
@@ -117,3 +129,130 @@ func ValidateClusterProperty(spec *devopsv1.ClusterSpec, propPath *field.Path) f
 
 	return allErrs
 }
+
+// ValidateClusterApps validates the helm apps of the cluster.
+func ValidateClusterApps(apps []*devopsv1.HelmChartSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	names := map[string]bool{}
+	for i, app := range apps {
+		idxPath := fldPath.Index(i)
+		if app == nil {
+			allErrs = append(allErrs, field.Required(idxPath, ""))
+			continue
+		}
+		if len(app.Name) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), ""))
+		} else {
+			for _, msg := range k8svalidation.IsDNS1123Label(app.Name) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), app.Name, msg))
+			}
+		}
+		if len(app.ChartName) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("chartName"), ""))
+		}
+		if strings.HasPrefix(app.Repo, "oci://") {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("repo"), app.Repo, []string{"file://", "http://", "https://"}))
+		}
+
+		key := app.Namespace + "/" + app.Name
+		if names[key] {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), app.Name))
+		}
+		names[key] = true
+	}
+
+	return allErrs
+}
+
+// ValidateLoadBalancer validates the metallb config of the cluster.
+func ValidateLoadBalancer(spec *devopsv1.ClusterSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	lb := spec.Features.LoadBalancer
+	if lb == nil {
+		return allErrs
+	}
+
+	if len(lb.Addresses) == 0 && lb.Rack == "" && spec.Features.InternalLB != nil && *spec.Features.InternalLB {
+		allErrs = append(allErrs, field.Required(fldPath.Child("addresses"), "addresses or rack is required by internalLB"))
+	}
+	if len(lb.PublicAddresses) == 0 && spec.Features.PublicLB != nil && *spec.Features.PublicLB {
+		allErrs = append(allErrs, field.Required(fldPath.Child("publicAddresses"), "publicAddresses is required by publicLB"))
+	}
+	for i, address := range lb.Addresses {
+		if err := validateAddressRange(address); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("addresses").Index(i), address, err.Error()))
+		}
+	}
+	for i, address := range lb.PublicAddresses {
+		if err := validateAddressRange(address); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("publicAddresses").Index(i), address, err.Error()))
+		}
+	}
+
+	if lb.Mode == devopsv1.LoadBalancerBGP {
+		if spec.NetworkType == devopsv1.NetworkTypeCalico {
+			// the calico nodes hold the bgp port
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("mode"), "bgp mode can't be used together with calico"))
+		}
+		if len(lb.Peers) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("peers"), "peers is required by bgp mode"))
+		}
+		for i, peer := range lb.Peers {
+			if net.ParseIP(peer.Address) == nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("peers").Index(i).Child("address"), peer.Address, "must be an ip address"))
+			}
+			if peer.ASNumber == 0 {
+				allErrs = append(allErrs, field.Required(fldPath.Child("peers").Index(i).Child("asNumber"), ""))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateDKEHA validates the vip held by keepalived on the masters.
+func ValidateDKEHA(spec *devopsv1.ClusterSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.Features.HA == nil || spec.Features.HA.DKEHA == nil {
+		return allErrs
+	}
+
+	dke := spec.Features.HA.DKEHA
+	if net.ParseIP(dke.VIP).To4() == nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("vip"), dke.VIP, "must be an ipv4 address"))
+	}
+	for _, m := range spec.Machines {
+		if m.IP == dke.VIP {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("vip"), dke.VIP, "must not be the ip of a master"))
+		}
+	}
+	if dke.VPort != 0 {
+		for _, msg := range k8svalidation.IsValidPortNum(int(dke.VPort)) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("vport"), dke.VPort, msg))
+		}
+		if dke.VPort == constants.KubeAPIServerPort {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("vport"), dke.VPort, "the apiservers listen on it"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateAddressRange validates a cidr or a range of the start and end addresses.
+func validateAddressRange(address string) error {
+	if !strings.Contains(address, "-") {
+		_, _, err := net.ParseCIDR(address)
+		return err
+	}
+
+	ips := strings.SplitN(address, "-", 2)
+	start, end := net.ParseIP(strings.TrimSpace(ips[0])), net.ParseIP(strings.TrimSpace(ips[1]))
+	if start == nil || end == nil {
+		return fmt.Errorf("invalid address range %s", address)
+	}
+	if bytes.Compare(start.To16(), end.To16()) > 0 {
+		return fmt.Errorf("the start address is after the end address")
+	}
+	return nil
+}
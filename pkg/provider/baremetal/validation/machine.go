@@ -1,7 +1,11 @@
 package validation
 
 import (
+	"net"
+
 	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/controllers/common"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -10,6 +14,7 @@ func ValidateMachine(machine *devopsv1.Machine) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	allErrs = append(allErrs, ValidateMachineSpec(&machine.Spec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, ValidateCredentialsRefs(machine.Namespace, machine.Spec.Machine, field.NewPath("spec", "machine"))...)
 
 	return allErrs
 }
@@ -18,6 +23,10 @@ func ValidateMachine(machine *devopsv1.Machine) field.ErrorList {
 func ValidateMachineSpec(spec *devopsv1.MachineSpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
+	if spec.Machine != nil {
+		allErrs = append(allErrs, ValidateJumpHosts(spec.Machine.JumpHosts, fldPath.Child("machine", "jumpHosts"))...)
+	}
+
 	// s, err := spec.SSH()
 	// if err == nil {
 	// 	if gpu.IsEnable(spec.Labels) {
@@ -29,3 +38,49 @@ func ValidateMachineSpec(spec *devopsv1.MachineSpec, fldPath *field.Path) field.
 
 	return allErrs
 }
+
+// ValidateCredentialsRefs validates the credentialsRef of the machine of the namespace and of its
+// jump hosts refer the secrets of the namespace or the shared credentials namespace.
+func ValidateCredentialsRefs(namespace string, m *devopsv1.ClusterMachine, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if m == nil {
+		return allErrs
+	}
+
+	msg := "must be the namespace of the owner or the shared credentials namespace"
+	if m.CredentialsRef != nil && !common.CredentialsNamespaceAllowed(namespace, m.CredentialsRef.Namespace) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("credentialsRef", "namespace"), msg))
+	}
+	for i, host := range m.JumpHosts {
+		if host.CredentialsRef != nil && !common.CredentialsNamespaceAllowed(namespace, host.CredentialsRef.Namespace) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("jumpHosts").Index(i).Child("credentialsRef", "namespace"), msg))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateJumpHosts validates the chain of the jump hosts of a machine.
+func ValidateJumpHosts(hosts []devopsv1.JumpHost, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, host := range hosts {
+		idxPath := fldPath.Index(i)
+		if net.ParseIP(host.IP) == nil {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("ip"), host.IP, "must be an ip address"))
+		}
+		if host.Port != 0 {
+			for _, msg := range k8svalidation.IsValidPortNum(int(host.Port)) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("port"), host.Port, msg))
+			}
+		}
+		if host.Password == "" && len(host.PrivateKey) == 0 && host.CredentialsRef == nil {
+			allErrs = append(allErrs, field.Required(idxPath, "password, privateKey or credentialsRef at least one"))
+		}
+		if host.CredentialsRef != nil && host.CredentialsRef.Name == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("credentialsRef", "name"), ""))
+		}
+	}
+
+	return allErrs
+}
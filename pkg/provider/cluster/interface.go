@@ -13,8 +13,13 @@ import (
 	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
 	"github.com/gostship/kunkka/pkg/constants"
 	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/provider/condlog"
+	"github.com/gostship/kunkka/pkg/provider/config"
+	"github.com/gostship/kunkka/pkg/provider/phases/certs"
+	"github.com/gostship/kunkka/pkg/provider/plan"
 	"github.com/thoas/go-funk"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apiserver/pkg/server/mux"
 	"k8s.io/klog"
@@ -25,8 +30,13 @@ const (
 	ReasonWaitingProcess    = "WaitingProcess"
 	ReasonSuccessfulProcess = "SuccessfulProcess"
 	ReasonSkipProcess       = "SkipProcess"
+	// ReasonUnsupportedUpgrade means the spec version can't be upgraded to, it is not tried
+	// again until the spec version changes.
+	ReasonUnsupportedUpgrade = "UnsupportedUpgrade"
 
 	ConditionTypeDone = "EnsureDone"
+	// ConditionTypeUpgradeSkew records the version skew check of the upgrade.
+	ConditionTypeUpgradeSkew = "UpgradeSkew"
 )
 
 // Provider defines a set of response interfaces for specific cluster
@@ -44,6 +54,12 @@ type Provider interface {
 	OnCreate(ctx context.Context, cluster *common.Cluster) error
 	OnUpdate(ctx context.Context, cluster *common.Cluster) error
 	OnDelete(ctx context.Context, cluster *common.Cluster) error
+	OnUpgrade(ctx context.Context, cluster *common.Cluster) error
+
+	Plan(ctx context.Context, cluster *common.Cluster) (*plan.Plan, error)
+
+	// Certificates returns the certificates of the cluster credential and the cluster hosts.
+	Certificates(ctx context.Context, cluster *common.Cluster) ([]devopsv1.CertificateStatus, error)
 }
 
 var _ Provider = &DelegateProvider{}
@@ -60,6 +76,19 @@ type DelegateProvider struct {
 	CreateHandlers []Handler
 	DeleteHandlers []Handler
 	UpdateHandlers []Handler
+	// UpgradeHandlers run in order when the spec version differs from the status version.
+	UpgradeHandlers []Handler
+
+	// PlanFunc validates the cluster and renders the artifacts of the create handlers
+	// without touching the hosts.
+	PlanFunc func(ctx context.Context, cluster *common.Cluster, p *plan.Plan) error
+
+	// CertificatesFunc returns the certificates on the hosts of the cluster, only the
+	// certificates of the credential are returned if nil.
+	CertificatesFunc func(ctx context.Context, cluster *common.Cluster) ([]devopsv1.CertificateStatus, error)
+
+	// Retry is the retry policy of the failed handlers, config.DefaultRetry if nil.
+	Retry *config.Retry
 }
 
 func (p *DelegateProvider) Name() string {
@@ -101,6 +130,9 @@ func (p *DelegateProvider) OnCreate(ctx context.Context, cluster *common.Cluster
 	if err != nil {
 		return err
 	}
+	if p.waitRetry(condition) {
+		return nil
+	}
 
 	now := metav1.Now()
 	if cluster.Spec.Features.SkipConditions != nil &&
@@ -120,18 +152,14 @@ func (p *DelegateProvider) OnCreate(ctx context.Context, cluster *common.Cluster
 
 		handlerName := f.Name()
 		klog.Infof("clusterName: %s OnCreate handler: %s", cluster.Name, handlerName)
-		err = f(ctx, cluster)
+		hctx, end := condlog.Begin(ctx, condition.Type)
+		err = f(hctx, cluster)
+		end(err)
 		if err != nil {
 			klog.Errorf("cluster: %s OnCreate handler: %s err: %+v", cluster.Name, handlerName, err)
-			cluster.SetCondition(devopsv1.ClusterCondition{
-				Type:          condition.Type,
-				Status:        devopsv1.ConditionFalse,
-				LastProbeTime: now,
-				Message:       err.Error(),
-				Reason:        ReasonFailedProcess,
-			})
-			cluster.Cluster.Status.Reason = ReasonFailedProcess
-			cluster.Cluster.Status.Message = err.Error()
+			if p.setFailed(cluster, condition.Type, err) {
+				cluster.Cluster.Status.Phase = devopsv1.ClusterFailed
+			}
 			return nil
 		}
 
@@ -205,20 +233,19 @@ func (p *DelegateProvider) OnUpdate(ctx context.Context, cluster *common.Cluster
 			continue
 		}
 
+		// the exhausted handler waits the retry annotation, the others still run
+		if p.waitRetry(getCondition(cluster, handlerName)) {
+			continue
+		}
+
 		klog.Infof("clusterName: %s OnUpdate handler: %s", cluster.Name, handlerName)
 		now := metav1.Now()
-		err := f(ctx, cluster)
+		hctx, end := condlog.Begin(ctx, handlerName)
+		err := f(hctx, cluster)
+		end(err)
 		if err != nil {
 			klog.Errorf("cluster: %s OnUpdate handler: %s err: %+v", cluster.Name, handlerName, err)
-			cluster.SetCondition(devopsv1.ClusterCondition{
-				Type:          handlerName,
-				Status:        devopsv1.ConditionFalse,
-				LastProbeTime: now,
-				Message:       err.Error(),
-				Reason:        ReasonFailedProcess,
-			})
-			cluster.Cluster.Status.Reason = ReasonFailedProcess
-			cluster.Cluster.Status.Message = err.Error()
+			p.setFailed(cluster, handlerName, err)
 			return nil
 		}
 
@@ -234,18 +261,227 @@ func (p *DelegateProvider) OnUpdate(ctx context.Context, cluster *common.Cluster
 	return nil
 }
 
+// OnDelete runs all the delete handlers even if some fail, so a unreachable host does not
+// keep the others from being cleaned, the errors are returned together.
 func (p *DelegateProvider) OnDelete(ctx context.Context, cluster *common.Cluster) error {
+	var errs []error
 	for _, f := range p.DeleteHandlers {
 		klog.Infof("clusterName: %s OnDelete handler: %s", cluster.Name, f.Name())
 		err := f(ctx, cluster)
 		if err != nil {
-			return err
+			klog.Errorf("cluster: %s OnDelete handler: %s err: %+v", cluster.Name, f.Name(), err)
+			errs = append(errs, fmt.Errorf("%s: %v", f.Name(), err))
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// OnUpgrade upgrades the cluster to the spec version, one upgrade handler runs in each reconcile
+// and the progress is recorded in the conditions of the upgrade handlers.
+func (p *DelegateProvider) OnUpgrade(ctx context.Context, cluster *common.Cluster) error {
+	if len(p.UpgradeHandlers) == 0 {
+		return fmt.Errorf("provider %s not support upgrade", p.Name())
+	}
+
+	now := metav1.Now()
+	if cluster.Cluster.Status.Phase == devopsv1.ClusterRunning {
+		err := constants.CheckUpgradeSkew(cluster.Cluster.Status.Version, cluster.Spec.Version)
+		if err != nil {
+			// the message names both versions, so the check is recorded once per spec version
+			cluster.Cluster.Status.Reason = ReasonUnsupportedUpgrade
+			cluster.Cluster.Status.Message = err.Error()
+			if condition := getCondition(cluster, ConditionTypeUpgradeSkew); condition != nil &&
+				condition.Status == devopsv1.ConditionFalse && condition.Message == err.Error() {
+				return nil
+			}
+			klog.Errorf("cluster: %s can't upgrade: %v", cluster.Name, err)
+			cluster.SetCondition(devopsv1.ClusterCondition{
+				Type:               ConditionTypeUpgradeSkew,
+				Status:             devopsv1.ConditionFalse,
+				LastProbeTime:      now,
+				LastTransitionTime: now,
+				Message:            err.Error(),
+				Reason:             ReasonUnsupportedUpgrade,
+			})
+			return nil
+		}
+
+		// clean the conditions of last upgrade
+		conditions := make([]devopsv1.ClusterCondition, 0, len(cluster.Cluster.Status.Conditions))
+		for _, condition := range cluster.Cluster.Status.Conditions {
+			if p.getUpgradeHandler(condition.Type) == nil && condition.Type != ConditionTypeUpgradeSkew {
+				conditions = append(conditions, condition)
+			}
+		}
+		cluster.Cluster.Status.Conditions = conditions
+		cluster.SetCondition(devopsv1.ClusterCondition{
+			Type:               p.UpgradeHandlers[0].Name(),
+			Status:             devopsv1.ConditionUnknown,
+			LastProbeTime:      now,
+			LastTransitionTime: now,
+			Message:            fmt.Sprintf("waiting upgrade from %s to %s", cluster.Cluster.Status.Version, cluster.Spec.Version),
+			Reason:             ReasonWaitingProcess,
+		})
+		klog.Infof("cluster: %s start upgrade from %s to %s", cluster.Name, cluster.Cluster.Status.Version, cluster.Spec.Version)
+		cluster.Cluster.Status.Phase = devopsv1.ClusterUpgrading
+		return nil
+	}
+
+	for i, f := range p.UpgradeHandlers {
+		handlerName := f.Name()
+		condition := getCondition(cluster, handlerName)
+		if condition != nil && condition.Status == devopsv1.ConditionTrue {
+			continue
+		}
+		if p.waitRetry(condition) {
+			return nil
+		}
+
+		klog.Infof("clusterName: %s OnUpgrade handler: %s", cluster.Name, handlerName)
+		hctx, end := condlog.Begin(ctx, handlerName)
+		err := f(hctx, cluster)
+		end(err)
+		if err != nil {
+			klog.Errorf("cluster: %s OnUpgrade handler: %s err: %+v", cluster.Name, handlerName, err)
+			if p.setFailed(cluster, handlerName, err) {
+				cluster.Cluster.Status.Phase = devopsv1.ClusterFailed
+			}
+			return nil
+		}
+
+		cluster.SetCondition(devopsv1.ClusterCondition{
+			Type:               handlerName,
+			Status:             devopsv1.ConditionTrue,
+			LastProbeTime:      now,
+			LastTransitionTime: now,
+			Reason:             ReasonSuccessfulProcess,
+		})
+		if i < len(p.UpgradeHandlers)-1 {
+			cluster.SetCondition(devopsv1.ClusterCondition{
+				Type:               p.UpgradeHandlers[i+1].Name(),
+				Status:             devopsv1.ConditionUnknown,
+				LastProbeTime:      now,
+				LastTransitionTime: now,
+				Message:            "waiting process",
+				Reason:             ReasonWaitingProcess,
+			})
+			return nil
 		}
+		break
 	}
 
+	klog.Infof("cluster: %s upgrade to %s success", cluster.Name, cluster.Spec.Version)
+	cluster.Cluster.Status.Version = cluster.Spec.Version
+	cluster.Cluster.Status.Phase = devopsv1.ClusterRunning
 	return nil
 }
 
+// Plan walks the create handlers like OnCreate without running them, and records
+// the handlers would run or be skipped.
+func (p *DelegateProvider) Plan(ctx context.Context, cluster *common.Cluster) (*plan.Plan, error) {
+	result := plan.New("Cluster", cluster.Cluster)
+	for _, f := range p.CreateHandlers {
+		name := f.Name()
+		switch {
+		case isConditionTrue(cluster, name):
+			result.AddStep(name, plan.ActionDone)
+		case funk.ContainsString(cluster.Spec.Features.SkipConditions, name):
+			result.AddStep(name, plan.ActionSkip)
+		default:
+			result.AddStep(name, plan.ActionRun)
+		}
+	}
+
+	if p.PlanFunc != nil {
+		if err := p.PlanFunc(ctx, cluster, result); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// Certificates returns the certificates of the credential and the hosts sorted by the expiration.
+func (p *DelegateProvider) Certificates(ctx context.Context, cluster *common.Cluster) ([]devopsv1.CertificateStatus, error) {
+	result := certs.Inventory(devopsv1.CertificateSourceCredential, certs.CredentialFiles(cluster.ClusterCredential))
+	if p.CertificatesFunc != nil {
+		hosts, err := p.CertificatesFunc(ctx, cluster)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, hosts...)
+	}
+
+	certs.SortCertificates(result)
+	return result, nil
+}
+
+func (p *DelegateProvider) retry() *config.Retry {
+	if p.Retry != nil {
+		return p.Retry
+	}
+	return &config.DefaultRetry
+}
+
+// waitRetry means the failed handler of the condition is in its backoff, or its
+// retries are exhausted.
+func (p *DelegateProvider) waitRetry(condition *devopsv1.ClusterCondition) bool {
+	if condition == nil || condition.Status != devopsv1.ConditionFalse || condition.Reason != ReasonFailedProcess {
+		return false
+	}
+	retry := p.retry()
+	return retry.Exhausted(condition.Retries) || retry.Wait(condition.Retries, condition.LastProbeTime.Time) > 0
+}
+
+// setFailed records the failure of the handler and counts its retries, it returns
+// true if the retries are exhausted.
+func (p *DelegateProvider) setFailed(cluster *common.Cluster, conditionType string, err error) bool {
+	retries := int32(1)
+	if condition := getCondition(cluster, conditionType); condition != nil &&
+		condition.Status == devopsv1.ConditionFalse && condition.Reason == ReasonFailedProcess {
+		retries = condition.Retries + 1
+	}
+
+	message := err.Error()
+	exhausted := p.retry().Exhausted(retries)
+	if exhausted {
+		message = fmt.Sprintf("failed %d times, stop retrying: %s", retries, message)
+	}
+	cluster.SetCondition(devopsv1.ClusterCondition{
+		Type:          conditionType,
+		Status:        devopsv1.ConditionFalse,
+		LastProbeTime: metav1.Now(),
+		Message:       message,
+		Reason:        ReasonFailedProcess,
+		Retries:       retries,
+	})
+	cluster.Cluster.Status.Reason = ReasonFailedProcess
+	cluster.Cluster.Status.Message = message
+	return exhausted
+}
+
+// RetryAfter returns the shortest wait of the failed handlers in their backoff,
+// or 0 if there is none.
+func RetryAfter(cluster *devopsv1.Cluster, retry *config.Retry) time.Duration {
+	var after time.Duration
+	for _, condition := range cluster.Status.Conditions {
+		if condition.Status != devopsv1.ConditionFalse || condition.Reason != ReasonFailedProcess ||
+			retry.Exhausted(condition.Retries) {
+			continue
+		}
+		wait := retry.Wait(condition.Retries, condition.LastProbeTime.Time)
+		if wait > 0 && (after == 0 || wait < after) {
+			after = wait
+		}
+	}
+	return after
+}
+
+func isConditionTrue(c *common.Cluster, conditionType string) bool {
+	condition := getCondition(c, conditionType)
+	return condition != nil && condition.Status == devopsv1.ConditionTrue
+}
+
 func (h Handler) Name() string {
 	name := runtime.FuncForPC(reflect.ValueOf(h).Pointer()).Name()
 	i := strings.Index(name, "Ensure")
@@ -284,6 +520,26 @@ func (p *DelegateProvider) getCreateHandler(conditionType string) Handler {
 	return nil
 }
 
+func (p *DelegateProvider) getUpgradeHandler(conditionType string) Handler {
+	for _, f := range p.UpgradeHandlers {
+		if conditionType == f.Name() {
+			return f
+		}
+	}
+
+	return nil
+}
+
+func getCondition(c *common.Cluster, conditionType string) *devopsv1.ClusterCondition {
+	for i := range c.Cluster.Status.Conditions {
+		if c.Cluster.Status.Conditions[i].Type == conditionType {
+			return &c.Cluster.Status.Conditions[i]
+		}
+	}
+
+	return nil
+}
+
 func (p *DelegateProvider) getCreateCurrentCondition(c *common.Cluster) (*devopsv1.ClusterCondition, error) {
 	if c.Cluster.Status.Phase == devopsv1.ClusterRunning {
 		return nil, errors.New("cluster phase is running now")
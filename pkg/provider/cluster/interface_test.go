@@ -0,0 +1,179 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/provider/config"
+	"github.com/gostship/kunkka/pkg/provider/plan"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func EnsureCopyFiles(ctx context.Context, c *common.Cluster) error { return nil }
+func EnsureSystem(ctx context.Context, c *common.Cluster) error    { return nil }
+func EnsureCni(ctx context.Context, c *common.Cluster) error       { return nil }
+
+var failedRuns int
+
+func EnsureFailed(ctx context.Context, c *common.Cluster) error {
+	failedRuns++
+	return errors.New("ssh: connection refused")
+}
+
+func TestPlan(t *testing.T) {
+	p := &DelegateProvider{
+		CreateHandlers: []Handler{EnsureCopyFiles, EnsureSystem, EnsureCni},
+		PlanFunc: func(ctx context.Context, c *common.Cluster, p *plan.Plan) error {
+			p.AddArtifact("kubeadm.yaml", []byte("kind: InitConfiguration"))
+			return nil
+		},
+	}
+	c := &common.Cluster{
+		Cluster: &devopsv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "c1", Generation: 2},
+			Spec: devopsv1.ClusterSpec{
+				Features: devopsv1.ClusterFeature{SkipConditions: []string{"EnsureCni"}},
+			},
+			Status: devopsv1.ClusterStatus{
+				Phase: devopsv1.ClusterInitializing,
+				Conditions: []devopsv1.ClusterCondition{
+					{Type: "EnsureCopyFiles", Status: devopsv1.ConditionTrue},
+					{Type: "EnsureSystem", Status: devopsv1.ConditionFalse},
+				},
+			},
+		},
+	}
+
+	result, err := p.Plan(context.TODO(), c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []plan.Step{
+		{Condition: "EnsureCopyFiles", Action: plan.ActionDone},
+		{Condition: "EnsureSystem", Action: plan.ActionRun},
+		{Condition: "EnsureCni", Action: plan.ActionSkip},
+	}
+	if !reflect.DeepEqual(result.Steps, want) {
+		t.Errorf("Plan() steps = %v, want %v", result.Steps, want)
+	}
+	if result.Generation != 2 {
+		t.Errorf("Plan() generation = %d, want 2", result.Generation)
+	}
+
+	cm, err := result.ToConfigMap("c1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cm.Name != "c1-plan" {
+		t.Errorf("ToConfigMap() name = %s, want c1-plan", cm.Name)
+	}
+	loaded, err := plan.FromConfigMap(cm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded.Steps, want) || loaded.Artifacts["kubeadm.yaml"] != "kind: InitConfiguration" {
+		t.Errorf("FromConfigMap() = %+v", loaded)
+	}
+}
+
+func TestOnCreateRetry(t *testing.T) {
+	failedRuns = 0
+	p := &DelegateProvider{
+		CreateHandlers: []Handler{EnsureFailed},
+		Retry:          &config.Retry{Limit: 2, BaseDelay: time.Hour, MaxDelay: time.Hour},
+	}
+	c := &common.Cluster{
+		Cluster: &devopsv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "c1"},
+			Status:     devopsv1.ClusterStatus{Phase: devopsv1.ClusterInitializing},
+		},
+	}
+	name := "EnsureFailed"
+
+	if err := p.OnCreate(context.TODO(), c); err != nil {
+		t.Fatal(err)
+	}
+	condition := getCondition(c, name)
+	if failedRuns != 1 || condition.Retries != 1 || c.Cluster.Status.Phase != devopsv1.ClusterInitializing {
+		t.Fatalf("first run: runs = %d, retries = %d, phase = %s", failedRuns, condition.Retries, c.Cluster.Status.Phase)
+	}
+	if after := RetryAfter(c.Cluster, p.Retry); after <= 0 || after > time.Hour {
+		t.Errorf("RetryAfter() = %v, want in (0, 1h]", after)
+	}
+
+	// the handler waits its backoff
+	if err := p.OnCreate(context.TODO(), c); err != nil {
+		t.Fatal(err)
+	}
+	if failedRuns != 1 {
+		t.Errorf("handler runs in backoff, runs = %d", failedRuns)
+	}
+
+	condition.LastProbeTime = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	if err := p.OnCreate(context.TODO(), c); err != nil {
+		t.Fatal(err)
+	}
+	condition = getCondition(c, name)
+	if failedRuns != 2 || condition.Retries != 2 || c.Cluster.Status.Phase != devopsv1.ClusterFailed {
+		t.Errorf("second run: runs = %d, retries = %d, phase = %s", failedRuns, condition.Retries, c.Cluster.Status.Phase)
+	}
+	if after := RetryAfter(c.Cluster, p.Retry); after != 0 {
+		t.Errorf("RetryAfter() of exhausted = %v, want 0", after)
+	}
+}
+
+func TestOnDelete(t *testing.T) {
+	runs := 0
+	fail := func(ctx context.Context, c *common.Cluster) error {
+		runs++
+		return errors.New("unreachable")
+	}
+	p := &DelegateProvider{DeleteHandlers: []Handler{fail, fail}}
+	c := &common.Cluster{Cluster: &devopsv1.Cluster{}}
+
+	if err := p.OnDelete(context.TODO(), c); err == nil || runs != 2 {
+		t.Errorf("OnDelete() err = %v, runs = %d, want error and 2 runs", err, runs)
+	}
+}
+
+func TestOnUpgradeSkew(t *testing.T) {
+	p := &DelegateProvider{UpgradeHandlers: []Handler{EnsureCopyFiles}}
+	c := &common.Cluster{
+		Cluster: &devopsv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "c1"},
+			Spec:       devopsv1.ClusterSpec{Version: "v1.18.5"},
+			Status:     devopsv1.ClusterStatus{Phase: devopsv1.ClusterRunning, Version: "v1.16.14"},
+		},
+	}
+
+	if err := p.OnUpgrade(context.TODO(), c); err != nil {
+		t.Fatal(err)
+	}
+	condition := getCondition(c, ConditionTypeUpgradeSkew)
+	if condition == nil || condition.Status != devopsv1.ConditionFalse || c.Cluster.Status.Phase != devopsv1.ClusterRunning {
+		t.Fatalf("OnUpgrade() skipping a minor version: condition = %+v, phase = %s", condition, c.Cluster.Status.Phase)
+	}
+
+	// the check is not recorded again until the spec version changes
+	probed := metav1.NewTime(time.Now().Add(-time.Hour))
+	condition.LastProbeTime = probed
+	if err := p.OnUpgrade(context.TODO(), c); err != nil {
+		t.Fatal(err)
+	}
+	if condition := getCondition(c, ConditionTypeUpgradeSkew); !condition.LastProbeTime.Equal(&probed) {
+		t.Errorf("OnUpgrade() of the same spec version probes again at %v", condition.LastProbeTime)
+	}
+
+	c.Cluster.Spec.Version = "v1.17.11"
+	if err := p.OnUpgrade(context.TODO(), c); err != nil {
+		t.Fatal(err)
+	}
+	if condition := getCondition(c, ConditionTypeUpgradeSkew); condition != nil || c.Cluster.Status.Phase != devopsv1.ClusterUpgrading {
+		t.Errorf("OnUpgrade() of a supported version: condition = %+v, phase = %s", condition, c.Cluster.Status.Phase)
+	}
+}
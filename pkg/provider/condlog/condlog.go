@@ -0,0 +1,244 @@
+/*
+Copyright 2020 dke.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package condlog records the remote command output of the condition handlers,
+// the output of each condition is kept in a ConfigMap owned by the Cluster or Machine.
+package condlog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// MaxSize is the max bytes kept of a condition, the oldest lines are dropped first.
+	MaxSize = 32 * 1024
+
+	// FlushPeriod is how often the output of a running handler is saved, it is also
+	// the delay of tailing the logs.
+	FlushPeriod = 5 * time.Second
+)
+
+// ConfigMapName returns the name of the logs ConfigMap of the Cluster or Machine.
+func ConfigMapName(name string) string {
+	return name + "-logs"
+}
+
+// Load returns the logs of the conditions of the Cluster or Machine, nil if there is none.
+func Load(ctx context.Context, cli client.Client, namespace, name string) (map[string]string, error) {
+	cm := &corev1.ConfigMap{}
+	err := cli.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ConfigMapName(name)}, cm)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return cm.Data, nil
+}
+
+// Trim keeps the last max bytes of the logs, cut at a line start.
+func Trim(logs string, max int) string {
+	if len(logs) <= max {
+		return logs
+	}
+	logs = logs[len(logs)-max:]
+	if i := strings.IndexByte(logs, '\n'); i >= 0 {
+		logs = logs[i+1:]
+	}
+	return logs
+}
+
+// Recorder saves the logs of the conditions of the owner.
+type Recorder struct {
+	cli    client.Client
+	scheme *runtime.Scheme
+	owner  metav1.Object
+}
+
+// NewRecorder returns a Recorder saving the logs in the namespace of the owner.
+func NewRecorder(cli client.Client, scheme *runtime.Scheme, owner metav1.Object) *Recorder {
+	return &Recorder{cli: cli, scheme: scheme, owner: owner}
+}
+
+type recorderKey struct{}
+type runKey struct{}
+
+// WithRecorder returns a context the handlers record their logs with.
+func WithRecorder(ctx context.Context, r *Recorder) context.Context {
+	return context.WithValue(ctx, recorderKey{}, r)
+}
+
+// run is the output of a handler run.
+type run struct {
+	recorder  *Recorder
+	condition string
+	base      string
+
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	changed bool
+
+	// saveMu keeps the periodic and the last save in order
+	saveMu sync.Mutex
+}
+
+func (r *run) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.buf.Write(p)
+	if r.buf.Len() > 2*MaxSize {
+		logs := Trim(r.buf.String(), MaxSize)
+		r.buf.Reset()
+		r.buf.WriteString(logs)
+	}
+	r.changed = true
+	return len(p), nil
+}
+
+func (r *run) logs() (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	changed := r.changed
+	r.changed = false
+	return Trim(r.base+r.buf.String(), MaxSize), changed
+}
+
+func (r *run) save(ctx context.Context) error {
+	r.saveMu.Lock()
+	defer r.saveMu.Unlock()
+
+	logs, changed := r.logs()
+	if !changed {
+		return nil
+	}
+
+	owner := r.recorder.owner
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		cm := &corev1.ConfigMap{}
+		err := r.recorder.cli.Get(ctx, types.NamespacedName{Namespace: owner.GetNamespace(), Name: ConfigMapName(owner.GetName())}, cm)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: owner.GetNamespace(),
+					Name:      ConfigMapName(owner.GetName()),
+				},
+				Data: map[string]string{r.condition: logs},
+			}
+			// not a controller reference, the controller of the owner watches its ConfigMaps
+			// and must not be requeued by every flush
+			if err := controllerutil.SetOwnerReference(owner, cm, r.recorder.scheme); err != nil {
+				return err
+			}
+			return r.recorder.cli.Create(ctx, cm)
+		}
+
+		// drops the controller reference of the ConfigMaps created by the old versions
+		if err := controllerutil.SetOwnerReference(owner, cm, r.recorder.scheme); err != nil {
+			return err
+		}
+
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[r.condition] = logs
+		return r.recorder.cli.Update(ctx, cm)
+	})
+}
+
+// Begin starts recording the output of the condition handler, the output is saved every
+// FlushPeriod until the returned func is called with the result of the handler.
+// Without a Recorder in the context, nothing is recorded.
+func Begin(ctx context.Context, condition string) (context.Context, func(err error)) {
+	recorder, ok := ctx.Value(recorderKey{}).(*Recorder)
+	if !ok {
+		return ctx, func(error) {}
+	}
+
+	owner := recorder.owner
+	data, err := Load(ctx, recorder.cli, owner.GetNamespace(), owner.GetName())
+	if err != nil {
+		klog.Errorf("load %s/%s logs err: %v", owner.GetNamespace(), owner.GetName(), err)
+	}
+	r := &run{recorder: recorder, condition: condition, base: data[condition]}
+	fmt.Fprintf(r, "==> %s %s\n", time.Now().Format(time.RFC3339), condition)
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(FlushPeriod)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				if err := r.save(ctx); err != nil {
+					klog.Errorf("save %s/%s %s logs err: %v", owner.GetNamespace(), owner.GetName(), condition, err)
+				}
+			}
+		}
+	}()
+
+	return context.WithValue(ctx, runKey{}, r), func(err error) {
+		close(stop)
+		<-done
+		if err != nil {
+			fmt.Fprintf(r, "<== failed: %v\n", err)
+		} else {
+			fmt.Fprintf(r, "<== done\n")
+		}
+		if err := r.save(ctx); err != nil {
+			klog.Errorf("save %s/%s %s logs err: %v", owner.GetNamespace(), owner.GetName(), condition, err)
+		}
+	}
+}
+
+// Appended returns the logs appended since the last read of them, the whole logs if the
+// last read ones are dropped.
+func Appended(last, logs string) string {
+	if strings.HasPrefix(logs, last) {
+		return logs[len(last):]
+	}
+
+	// the head of the last read is dropped, find where its tail is now
+	tail := last
+	if len(tail) > 256 {
+		tail = tail[len(tail)-256:]
+	}
+	if i := strings.Index(logs, tail); i >= 0 {
+		return logs[i+len(tail):]
+	}
+	return logs
+}
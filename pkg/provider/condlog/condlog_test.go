@@ -0,0 +1,103 @@
+package condlog
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/util/ssh"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type fakeSSH struct {
+	ssh.Interface
+	host   string
+	output string
+}
+
+func (s *fakeSSH) HostIP() string {
+	return s.host
+}
+
+func (s *fakeSSH) ExecStream(cmd string, stdout, stderr io.Writer) (int, error) {
+	io.WriteString(stdout, s.output)
+	return 0, nil
+}
+
+func TestBegin(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := devopsv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	cluster := &devopsv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "c1", Name: "c1"}}
+	cli := fake.NewFakeClientWithScheme(scheme, cluster)
+	ctx := WithRecorder(context.TODO(), NewRecorder(cli, scheme, cluster))
+
+	for i, result := range []error{errors.New("exit 1"), nil} {
+		hctx, end := Begin(ctx, "EnsureSystem")
+		s := Wrap(hctx, &fakeSSH{host: "10.0.0.1", output: "install docker\nstart"})
+		if _, err := s.ExecStream("init.sh", nil, nil); err != nil {
+			t.Fatal(err)
+		}
+		end(result)
+
+		data, err := Load(ctx, cli, "c1", "c1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		logs := data["EnsureSystem"]
+		if n := strings.Count(logs, "[10.0.0.1] install docker\n[10.0.0.1] start\n"); n != i+1 {
+			t.Errorf("run %d logs has %d outputs, want %d:\n%s", i, n, i+1, logs)
+		}
+		if i == 0 && !strings.HasSuffix(logs, "<== failed: exit 1\n") {
+			t.Errorf("run %d logs = %q, want the failure", i, logs)
+		}
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := cli.Get(ctx, types.NamespacedName{Namespace: "c1", Name: ConfigMapName("c1")}, cm); err != nil {
+		t.Fatal(err)
+	}
+	if refs := cm.OwnerReferences; len(refs) != 1 || refs[0].Name != "c1" || refs[0].Controller != nil {
+		t.Errorf("owner references = %+v, want a non-controller reference of the cluster", refs)
+	}
+
+	// out of a handler run the client is not wrapped
+	s := &fakeSSH{host: "10.0.0.1"}
+	if Wrap(ctx, s) != ssh.Interface(s) {
+		t.Errorf("Wrap() out of a run wraps the client")
+	}
+}
+
+func TestAppended(t *testing.T) {
+	long := strings.Repeat("a\n", 200)
+	tests := []struct {
+		name string
+		last string
+		logs string
+		want string
+	}{
+		{"first", "", "a\nb\n", "a\nb\n"},
+		{"append", "a\n", "a\nb\n", "b\n"},
+		{"same", "a\nb\n", "a\nb\n", ""},
+		{"trimmed", "x\n" + long + "b\n", Trim("x\n"+long+"b\nc\n", len(long)), "c\n"},
+		{"dropped", "a\n", "c\n", "c\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Appended(tt.last, tt.logs); got != tt.want {
+				t.Errorf("Appended() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2020 dke.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package condlog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/gostship/kunkka/pkg/util/ssh"
+)
+
+// Machine is the ClusterMachine or MachineSpec the handler connects to.
+type Machine interface {
+	SSH() (*ssh.SSH, error)
+}
+
+// SSH returns the ssh client of the machine, the combined output of its streamed commands
+// and the failed commands is recorded in the condition logs of the context.
+func SSH(ctx context.Context, m Machine) (ssh.Interface, error) {
+	s, err := m.SSH()
+	if err != nil {
+		return nil, err
+	}
+	return Wrap(ctx, s), nil
+}
+
+// Wrap records the output of the ssh client in the condition logs of the context,
+// the client is returned as is out of a handler run.
+func Wrap(ctx context.Context, s ssh.Interface) ssh.Interface {
+	r, ok := ctx.Value(runKey{}).(*run)
+	if !ok {
+		return s
+	}
+	return &recordSSH{
+		Interface: s,
+		out:       &lineWriter{w: r, prefix: []byte(fmt.Sprintf("[%s] ", s.HostIP()))},
+	}
+}
+
+type recordSSH struct {
+	ssh.Interface
+	out *lineWriter
+}
+
+// ExecStream writes the output to the condition logs instead of the given writers, which
+// are the stdout and stderr of the operator, so the output of the hosts is not mixed there.
+func (s *recordSSH) ExecStream(cmd string, stdout, stderr io.Writer) (int, error) {
+	defer s.out.Flush()
+	return s.Interface.ExecStream(cmd, s.out, s.out)
+}
+
+// Exec only records the failed commands, the output of the others is often parsed
+// and may hold secrets like the join token.
+func (s *recordSSH) Exec(cmd string) (string, string, int, error) {
+	stdout, stderr, exit, err := s.Interface.Exec(cmd)
+	if err != nil {
+		fmt.Fprintf(s.out, "%v\n", err)
+	} else if exit != 0 {
+		fmt.Fprintf(s.out, "exit %d: %s%s", exit, stdout, stderr)
+	}
+	s.out.Flush()
+	return stdout, stderr, exit, err
+}
+
+func (s *recordSSH) Execf(format string, a ...interface{}) (string, string, int, error) {
+	return s.Exec(fmt.Sprintf(format, a...))
+}
+
+func (s *recordSSH) CombinedOutput(cmd string) ([]byte, error) {
+	stdout, stderr, exit, err := s.Exec(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if exit != 0 {
+		return nil, fmt.Errorf("exit error %d:%s", exit, stderr)
+	}
+	return []byte(stdout), nil
+}
+
+// lineWriter writes the whole lines with the prefix, so the output of the hosts
+// handled in parallel is not mixed in a line.
+type lineWriter struct {
+	w      io.Writer
+	prefix []byte
+
+	mu   sync.Mutex
+	line []byte
+}
+
+func (l *lineWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.line = append(l.line, p...)
+	for {
+		i := bytes.IndexByte(l.line, '\n')
+		if i < 0 {
+			break
+		}
+		l.write(l.line[:i+1])
+		l.line = l.line[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush writes the last line without its line break.
+func (l *lineWriter) Flush() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.line) > 0 {
+		l.write(append(l.line, '\n'))
+		l.line = nil
+	}
+}
+
+func (l *lineWriter) write(line []byte) {
+	l.w.Write(append(append([]byte{}, l.prefix...), line...))
+}
@@ -6,12 +6,17 @@ import (
 	"fmt"
 	"path"
 	"strings"
+	"time"
 )
 
 type Config struct {
 	Registry       Registry
 	Audit          Audit
 	Feature        Feature
+	Retry          Retry
+	Artifact       Artifact
+	Certs          Certs
+	Drain          Drain
 	CustomRegistry string
 	CustomeCert    bool
 	CustomeImages  bool
@@ -28,10 +33,90 @@ type Audit struct {
 	Address string
 }
 
+// Artifact is the artifact server of the operator.
+type Artifact struct {
+	// URL is the address of the server the nodes download the artifacts from,
+	// e.g. http://10.0.0.10:8091.
+	URL string
+}
+
+// Certs is the policy of the certificate controller. The certificates of a cluster are
+// checked every CheckInterval, and issued again once they expire within RenewBefore.
+type Certs struct {
+	CheckInterval time.Duration
+	RenewBefore   time.Duration
+}
+
+// DefaultCerts is the certificate policy used when the provider has none.
+var DefaultCerts = Certs{
+	CheckInterval: time.Hour,
+	RenewBefore:   30 * 24 * time.Hour,
+}
+
+// Drain is the policy of the drain before a machine is deleted. The pods blocked by the
+// disruption budgets are evicted again until Timeout, then they are deleted if Force is set,
+// or the deletion waits for them. Force drains the pods not managed by a controller as well,
+// and DeleteLocalData the pods with emptyDir volumes.
+type Drain struct {
+	Timeout         time.Duration
+	Force           bool
+	DeleteLocalData bool
+}
+
+// DefaultDrain is the drain policy used when the provider has none.
+var DefaultDrain = Drain{
+	Timeout: 5 * time.Minute,
+}
+
 type Feature struct {
 	SkipConditions []string
 }
 
+// Retry is the retry policy of the failed handlers. A failed handler waits
+// BaseDelay*2^(retries-1) before it runs again, up to MaxDelay, and the
+// cluster or machine turns Failed after Limit failed runs of a handler.
+type Retry struct {
+	Limit     int32
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+// DefaultRetry is the retry policy used when the provider has none.
+var DefaultRetry = Retry{
+	Limit:     8,
+	BaseDelay: 10 * time.Second,
+	MaxDelay:  10 * time.Minute,
+}
+
+// Backoff returns the delay after the handler failed the retries time.
+func (r *Retry) Backoff(retries int32) time.Duration {
+	if retries <= 0 {
+		return 0
+	}
+	delay := r.BaseDelay
+	for i := int32(1); i < retries && delay < r.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > r.MaxDelay {
+		delay = r.MaxDelay
+	}
+	return delay
+}
+
+// Exhausted means the handler should not run again until the retries are reset.
+func (r *Retry) Exhausted(retries int32) bool {
+	return r.Limit > 0 && retries >= r.Limit
+}
+
+// Wait returns how long the handler failed at lastProbe still waits.
+func (r *Retry) Wait(retries int32, lastProbe time.Time) time.Duration {
+	wait := time.Until(lastProbe.Add(r.Backoff(retries)))
+	if wait < 0 {
+		return 0
+	}
+	return wait
+}
+
 func NewDefaultConfig() (*Config, error) {
 	config := &Config{
 		Registry: Registry{
@@ -39,6 +124,9 @@ func NewDefaultConfig() (*Config, error) {
 			// Prefix: "registry.aliyuncs.com/google_containers",
 		},
 		CustomRegistry: "symcn.tencentcloudcr.com/symcn",
+		Retry:          DefaultRetry,
+		Certs:          DefaultCerts,
+		Drain:          DefaultDrain,
 	}
 
 	s := strings.Split(config.Registry.Prefix, "/")
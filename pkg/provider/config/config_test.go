@@ -0,0 +1,33 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryBackoff(t *testing.T) {
+	r := &Retry{Limit: 5, BaseDelay: 10 * time.Second, MaxDelay: time.Minute}
+	tests := []struct {
+		retries int32
+		want    time.Duration
+	}{
+		{0, 0},
+		{1, 10 * time.Second},
+		{2, 20 * time.Second},
+		{3, 40 * time.Second},
+		{4, time.Minute},
+		{30, time.Minute},
+	}
+	for _, tt := range tests {
+		if got := r.Backoff(tt.retries); got != tt.want {
+			t.Errorf("Backoff(%d) = %v, want %v", tt.retries, got, tt.want)
+		}
+	}
+
+	if r.Exhausted(4) || !r.Exhausted(5) {
+		t.Errorf("Exhausted() of limit 5 is wrong")
+	}
+	if (&Retry{}).Exhausted(100) {
+		t.Errorf("Exhausted() of no limit = true")
+	}
+}
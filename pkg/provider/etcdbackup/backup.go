@@ -0,0 +1,225 @@
+package etcdbackup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"time"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog"
+)
+
+// Members returns the etcd members of the cluster and the initial cluster to restore them.
+func Members(cfg *rest.Config, c *common.Cluster) ([]Member, string, error) {
+	switch c.Spec.Type {
+	case "Baremetal":
+		return BaremetalMembers(c)
+	case "Hosted":
+		return HostedMembers(cfg, c)
+	default:
+		return nil, "", fmt.Errorf("cluster type %s does not support etcd backup", c.Spec.Type)
+	}
+}
+
+// SnapshotName returns the snapshot name of the cluster taken at the time.
+func SnapshotName(cluster string, t time.Time) string {
+	return fmt.Sprintf("%s-%s.db", cluster, t.UTC().Format("20060102-150405"))
+}
+
+// Snapshot takes a snapshot from the first healthy member and saves it to the store.
+func Snapshot(ctx context.Context, members []Member, store Store, name string) (*devopsv1.EtcdSnapshot, error) {
+	if len(members) == 0 {
+		return nil, fmt.Errorf("no etcd member")
+	}
+
+	tmp, err := ioutil.TempFile("", "etcd-snapshot-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		tmp.Close()
+		os.Remove(tmp.Name())
+	}()
+
+	var lastErr error
+	for _, m := range members {
+		err = snapshotToFile(ctx, m, tmp)
+		if err == nil {
+			lastErr = nil
+			break
+		}
+		klog.Warningf("snapshot etcd member %s err: %v", m.Name(), err)
+		lastErr = errors.Wrap(err, m.Name())
+	}
+	if lastErr != nil {
+		return nil, lastErr
+	}
+
+	info, err := tmp.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() == 0 {
+		return nil, fmt.Errorf("snapshot %s is empty", name)
+	}
+	checksum, err := fileSHA256(tmp)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tmp.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, err
+	}
+	err = store.Put(ctx, name, tmp, info.Size(), checksum)
+	if err != nil {
+		return nil, errors.Wrapf(err, "put snapshot %s", name)
+	}
+
+	return &devopsv1.EtcdSnapshot{
+		Name:         name,
+		Size:         info.Size(),
+		SHA256:       checksum,
+		CreationTime: metav1.Now(),
+	}, nil
+}
+
+func snapshotToFile(ctx context.Context, m Member, f *os.File) error {
+	_, err := f.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+	err = f.Truncate(0)
+	if err != nil {
+		return err
+	}
+
+	return m.Snapshot(ctx, f)
+}
+
+// Prune deletes the snapshots beyond the retention, the newest are kept.
+func Prune(ctx context.Context, store Store, retention *devopsv1.EtcdBackupRetention, now time.Time) ([]string, error) {
+	if retention == nil {
+		return nil, nil
+	}
+
+	objs, err := store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sortObjects(objs)
+
+	var deleted []string
+	for i, obj := range objs {
+		expired := retention.MaxAge != nil && retention.MaxAge.Duration > 0 && now.Sub(obj.ModTime) > retention.MaxAge.Duration
+		exceeded := retention.MaxBackups > 0 && i >= int(retention.MaxBackups)
+		if !expired && !exceeded {
+			continue
+		}
+
+		err = store.Delete(ctx, obj.Name)
+		if err != nil {
+			return deleted, errors.Wrapf(err, "delete snapshot %s", obj.Name)
+		}
+		deleted = append(deleted, obj.Name)
+	}
+
+	return deleted, nil
+}
+
+// sortObjects sorts the objects from the newest to the oldest.
+func sortObjects(objs []Object) {
+	sort.SliceStable(objs, func(i, j int) bool {
+		if !objs[i].ModTime.Equal(objs[j].ModTime) {
+			return objs[i].ModTime.After(objs[j].ModTime)
+		}
+		return objs[i].Name > objs[j].Name
+	})
+}
+
+// Restore downloads the snapshot and verifies the checksum, then stops all the members,
+// restores the data of each member and starts them again.
+func Restore(ctx context.Context, members []Member, initialCluster string, store Store, name string, checksum string) error {
+	if len(members) == 0 {
+		return fmt.Errorf("no etcd member")
+	}
+
+	tmp, err := ioutil.TempFile("", "etcd-restore-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		tmp.Close()
+		os.Remove(tmp.Name())
+	}()
+
+	r, err := store.Get(ctx, name)
+	if err != nil {
+		return errors.Wrapf(err, "get snapshot %s", name)
+	}
+	_, err = io.Copy(tmp, r)
+	r.Close()
+	if err != nil {
+		return errors.Wrapf(err, "download snapshot %s", name)
+	}
+
+	if checksum != "" {
+		actual, err := fileSHA256(tmp)
+		if err != nil {
+			return err
+		}
+		if actual != checksum {
+			return fmt.Errorf("snapshot %s checksum mismatch, expected %s, actual %s", name, checksum, actual)
+		}
+	}
+
+	for _, m := range members {
+		klog.Infof("stop etcd member %s", m.Name())
+		err = m.Stop(ctx)
+		if err != nil {
+			return errors.Wrapf(err, "stop %s", m.Name())
+		}
+	}
+
+	for _, m := range members {
+		klog.Infof("restore etcd member %s from %s", m.Name(), name)
+		err = m.Restore(ctx, tmp.Name(), initialCluster)
+		if err != nil {
+			return errors.Wrapf(err, "restore %s", m.Name())
+		}
+	}
+
+	for _, m := range members {
+		klog.Infof("start etcd member %s", m.Name())
+		err = m.Start(ctx)
+		if err != nil {
+			return errors.Wrapf(err, "start %s", m.Name())
+		}
+	}
+
+	return nil
+}
+
+func fileSHA256(f *os.File) (string, error) {
+	_, err := f.Seek(0, io.SeekStart)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,289 @@
+package etcdbackup
+
+import (
+	"bufio"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/minio/minio-go/v6"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type fakeMember struct {
+	name     string
+	data     string
+	err      error
+	restored string
+	calls    []string
+}
+
+func (m *fakeMember) Name() string { return m.name }
+
+func (m *fakeMember) Snapshot(ctx context.Context, w io.Writer) error {
+	if m.err != nil {
+		return m.err
+	}
+	_, err := io.WriteString(w, m.data)
+	return err
+}
+
+func (m *fakeMember) Stop(ctx context.Context) error {
+	m.calls = append(m.calls, "stop")
+	return nil
+}
+
+func (m *fakeMember) Restore(ctx context.Context, snapshot string, initialCluster string) error {
+	m.calls = append(m.calls, "restore")
+	data, err := ioutil.ReadFile(snapshot)
+	m.restored = string(data)
+	return err
+}
+
+func (m *fakeMember) Start(ctx context.Context) error {
+	m.calls = append(m.calls, "start")
+	return nil
+}
+
+func TestSnapshotAndRestore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "etcdbackup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ctx := context.Background()
+	store := NewLocalStore(dir)
+	broken := &fakeMember{name: "m0", err: fmt.Errorf("unhealthy")}
+	healthy := &fakeMember{name: "m1", data: "snapshot-data"}
+	members := []Member{broken, healthy}
+
+	snap, err := Snapshot(ctx, members, store, "c1.db")
+	if err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+	if snap.Size != int64(len(healthy.data)) || snap.SHA256 == "" {
+		t.Fatalf("Snapshot() = %+v", snap)
+	}
+
+	err = Restore(ctx, members, "", store, "c1.db", "bad")
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("Restore() with bad checksum error = %v", err)
+	}
+	if len(broken.calls) != 0 {
+		t.Fatalf("members are touched before checksum verified: %v", broken.calls)
+	}
+
+	err = Restore(ctx, members, "", store, "c1.db", snap.SHA256)
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	for _, m := range []*fakeMember{broken, healthy} {
+		if m.restored != healthy.data {
+			t.Errorf("member %s restored %q", m.name, m.restored)
+		}
+		if strings.Join(m.calls, ",") != "stop,restore,start" {
+			t.Errorf("member %s calls %v", m.name, m.calls)
+		}
+	}
+}
+
+func TestPrune(t *testing.T) {
+	now := time.Date(2020, 8, 14, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		retention *devopsv1.EtcdBackupRetention
+		want      []string
+	}{
+		{name: "no retention", retention: nil, want: nil},
+		{name: "max backups", retention: &devopsv1.EtcdBackupRetention{MaxBackups: 2}, want: []string{"c-1.db", "c-0.db"}},
+		{name: "max age", retention: &devopsv1.EtcdBackupRetention{MaxAge: &metav1.Duration{Duration: 36 * time.Hour}}, want: []string{"c-1.db", "c-0.db"}},
+		{name: "both", retention: &devopsv1.EtcdBackupRetention{MaxBackups: 1, MaxAge: &metav1.Duration{Duration: 72 * time.Hour}}, want: []string{"c-2.db", "c-1.db", "c-0.db"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "etcdbackup")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			store := NewLocalStore(dir)
+			for i := 0; i < 4; i++ {
+				name := fmt.Sprintf("c-%d.db", i)
+				if err := store.Put(context.Background(), name, strings.NewReader(name), 0, ""); err != nil {
+					t.Fatal(err)
+				}
+				// c-3 is 0 days old, c-0 is 3 days old
+				mtime := now.Add(-time.Duration(3-i) * 24 * time.Hour)
+				if err := os.Chtimes(dir+"/"+name, mtime, mtime); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := Prune(context.Background(), store, tt.retention, now)
+			if err != nil {
+				t.Fatalf("Prune() error = %v", err)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Prune() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// listBucketResult is the response of ListObjectsV2.
+type listBucketResult struct {
+	XMLName  xml.Name `xml:"ListBucketResult"`
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated bool `xml:"IsTruncated"`
+}
+
+// fakeS3 is an in-memory bucket with the path style API.
+type fakeS3 struct {
+	sync.Mutex
+	bucket  string
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=ak/") ||
+		r.Header.Get("X-Amz-Content-Sha256") == "" {
+		w.WriteHeader(http.StatusForbidden)
+		io.WriteString(w, "<Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>")
+		return
+	}
+
+	f.Lock()
+	defer f.Unlock()
+	key := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/"+f.bucket), "/")
+	switch {
+	case r.Method == http.MethodGet && key == "":
+		result := listBucketResult{}
+		for k, v := range f.objects {
+			if strings.HasPrefix(k, r.URL.Query().Get("prefix")) {
+				result.Contents = append(result.Contents, struct {
+					Key          string    `xml:"Key"`
+					Size         int64     `xml:"Size"`
+					LastModified time.Time `xml:"LastModified"`
+				}{Key: k, Size: int64(len(v)), LastModified: time.Now()})
+			}
+		}
+		xml.NewEncoder(w).Encode(result)
+	case r.Method == http.MethodPut:
+		data, err := readChunked(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.objects[key] = data
+	case r.Method == http.MethodGet, r.Method == http.MethodHead:
+		data, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, "<Error><Code>NoSuchKey</Code><Message>not found</Message></Error>")
+			return
+		}
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		if r.Method == http.MethodGet {
+			w.Write(data)
+		}
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// readChunked decodes the aws-chunked body of the streaming signature, the
+// chunk signatures are not verified.
+func readChunked(r io.Reader) ([]byte, error) {
+	br := bufio.NewReader(r)
+	var data []byte
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.ParseInt(strings.SplitN(strings.TrimSpace(line), ";", 2)[0], 16, 64)
+		if err != nil {
+			return nil, err
+		}
+		if size == 0 {
+			return data, nil
+		}
+		chunk := make([]byte, size+2)
+		if _, err := io.ReadFull(br, chunk); err != nil {
+			return nil, err
+		}
+		data = append(data, chunk[:size]...)
+	}
+}
+
+func TestS3Store(t *testing.T) {
+	fake := &fakeS3{bucket: "backup", objects: map[string][]byte{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	ctx := context.Background()
+	storage := &devopsv1.S3BackupStorage{
+		Endpoint: strings.TrimPrefix(server.URL, "http://"),
+		Bucket:   "backup",
+		Insecure: true,
+	}
+	store, err := NewS3Store(storage, "etcd/default/b1", "ak", "sk")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snap, err := Snapshot(ctx, []Member{&fakeMember{name: "m0", data: "s3-data"}}, store, "c1.db")
+	if err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+	if string(fake.objects["etcd/default/b1/c1.db"]) != "s3-data" {
+		t.Fatalf("object is not saved with the prefix: %v", fake.objects)
+	}
+
+	objs, err := store.List(ctx)
+	if err != nil || len(objs) != 1 || objs[0].Name != "c1.db" {
+		t.Fatalf("List() = %v, %v", objs, err)
+	}
+
+	m := &fakeMember{name: "m0"}
+	err = Restore(ctx, []Member{m}, "", store, "c1.db", snap.SHA256)
+	if err != nil || m.restored != "s3-data" {
+		t.Fatalf("Restore() restored %q, error = %v", m.restored, err)
+	}
+
+	if err := store.Delete(ctx, "c1.db"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	_, err = store.Get(ctx, "c1.db")
+	if code := minio.ToErrorResponse(err).Code; code != "NoSuchKey" {
+		t.Fatalf("Get() deleted object error = %v, code %q", err, code)
+	}
+
+	anonymous, err := NewS3Store(storage, "etcd/default/b1", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = anonymous.List(ctx)
+	if code := minio.ToErrorResponse(err).Code; code != "AccessDenied" {
+		t.Fatalf("List() without credentials error = %v, code %q", err, code)
+	}
+}
@@ -0,0 +1,178 @@
+package etcdbackup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gostship/kunkka/pkg/controllers/common"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/klog"
+)
+
+const (
+	// the hosted etcd is the statefulset of manifests/etcd-statefulset.yaml
+	hostedEtcdName      = "etcd"
+	hostedEtcdReplicas  = 3
+	hostedEtcdDataDir   = "/var/run/etcd"
+	hostedEtcdToken     = "etcd-cluster-1"
+	hostedEtcdContainer = "etcd"
+)
+
+// HostedMember is a member of the etcd statefulset in the cluster namespace,
+// the commands run in the etcd container.
+type HostedMember struct {
+	KubeCli   kubernetes.Interface
+	Config    *rest.Config
+	Namespace string
+	Pod       string
+}
+
+var _ Member = &HostedMember{}
+
+// HostedMembers returns the members of the etcd statefulset of the hosted cluster.
+func HostedMembers(cfg *rest.Config, c *common.Cluster) ([]Member, string, error) {
+	if c.Spec.Etcd != nil && c.Spec.Etcd.External != nil {
+		return nil, "", fmt.Errorf("external etcd of cluster %s is not managed", c.Name)
+	}
+
+	kubeCli, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var members []Member
+	var peers []string
+	for i := 0; i < hostedEtcdReplicas; i++ {
+		name := fmt.Sprintf("%s-%d", hostedEtcdName, i)
+		members = append(members, &HostedMember{
+			KubeCli:   kubeCli,
+			Config:    cfg,
+			Namespace: c.Namespace,
+			Pod:       name,
+		})
+		peers = append(peers, fmt.Sprintf("%s=http://%s.%s:2380", name, name, hostedEtcdName))
+	}
+
+	return members, strings.Join(peers, ","), nil
+}
+
+func (m *HostedMember) Name() string {
+	return m.Namespace + "/" + m.Pod
+}
+
+func (m *HostedMember) Snapshot(ctx context.Context, w io.Writer) error {
+	file := hostedEtcdDataDir + "/snapshot.db"
+	err := m.exec(fmt.Sprintf("ETCDCTL_API=3 etcdctl --endpoints http://127.0.0.1:2379 snapshot save %s", file), nil, nil)
+	if err != nil {
+		return err
+	}
+	defer m.exec("rm -f "+file, nil, nil)
+
+	return m.exec("cat "+file, nil, w)
+}
+
+// Stop is a no-op, the member keeps running until the data is swapped in Start.
+func (m *HostedMember) Stop(ctx context.Context) error {
+	return nil
+}
+
+func (m *HostedMember) Restore(ctx context.Context, snapshot string, initialCluster string) error {
+	f, err := os.Open(snapshot)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	file := hostedEtcdDataDir + "/restore.db"
+	err = m.exec("cat > "+file, f, nil)
+	if err != nil {
+		return err
+	}
+	defer m.exec("rm -f "+file, nil, nil)
+
+	cmd := fmt.Sprintf("rm -rf %s/restore.etcd && ETCDCTL_API=3 etcdctl snapshot restore %s --name %s --initial-cluster %s --initial-cluster-token %s --initial-advertise-peer-urls http://%s:2380 --data-dir %s/restore.etcd",
+		hostedEtcdDataDir, file, m.Pod, initialCluster, hostedEtcdToken, m.Pod, hostedEtcdDataDir)
+	return m.exec(cmd, nil, nil)
+}
+
+// Start swaps in the restored data and restarts the etcd container.
+func (m *HostedMember) Start(ctx context.Context) error {
+	backup := fmt.Sprintf("default.etcd.bak-%s", time.Now().Format("20060102150405"))
+	cmd := fmt.Sprintf("cd %s && mv default.etcd %s && mv restore.etcd default.etcd", hostedEtcdDataDir, backup)
+	err := m.exec(cmd, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	// the stream is closed by the exit of etcd
+	if err := m.exec("kill 1", nil, nil); err != nil {
+		klog.V(4).Infof("pod: %s kill etcd: %v", m.Name(), err)
+	}
+
+	err = wait.PollImmediate(5*time.Second, 3*time.Minute, func() (bool, error) {
+		pod, err := m.KubeCli.CoreV1().Pods(m.Namespace).Get(ctx, m.Pod, metav1.GetOptions{})
+		if err != nil {
+			return false, nil
+		}
+		return isPodReady(pod), nil
+	})
+	if err != nil {
+		return fmt.Errorf("pod: %s wait ready err: %v", m.Name(), err)
+	}
+	return nil
+}
+
+func (m *HostedMember) exec(cmd string, stdin io.Reader, stdout io.Writer) error {
+	klog.V(4).Infof("pod: %s, cmd: %s", m.Name(), cmd)
+	req := m.KubeCli.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(m.Namespace).
+		Name(m.Pod).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: hostedEtcdContainer,
+			Command:   []string{"/bin/sh", "-c", cmd},
+			Stdin:     stdin != nil,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(m.Config, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+
+	if stdout == nil {
+		stdout = new(bytes.Buffer)
+	}
+	stderr := new(bytes.Buffer)
+	err = executor.Stream(remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+	if err != nil {
+		return fmt.Errorf("pod: %s exec %q failed:stderr %s:error %v", m.Name(), cmd, stderr.String(), err)
+	}
+	return nil
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package etcdbackup
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// LocalStore saves the snapshots in a local directory.
+type LocalStore struct {
+	Dir string
+}
+
+var _ Store = &LocalStore{}
+
+// NewLocalStore returns a store of the directory.
+func NewLocalStore(dir string) *LocalStore {
+	return &LocalStore{Dir: dir}
+}
+
+func (s *LocalStore) Put(ctx context.Context, name string, r io.Reader, size int64, checksum string) error {
+	err := os.MkdirAll(s.Dir, 0700)
+	if err != nil {
+		return err
+	}
+
+	// write to a temp file first, so a partial snapshot is never listed
+	tmp, err := ioutil.TempFile(s.Dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = io.Copy(tmp, r)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), filepath.Join(s.Dir, name))
+}
+
+func (s *LocalStore) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	return os.Open(filepath.Join(s.Dir, name))
+}
+
+func (s *LocalStore) List(ctx context.Context) ([]Object, error) {
+	infos, err := ioutil.ReadDir(s.Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var objs []Object
+	for _, info := range infos {
+		if info.IsDir() || info.Name()[0] == '.' {
+			continue
+		}
+		objs = append(objs, Object{
+			Name:    info.Name(),
+			Size:    info.Size(),
+			ModTime: info.ModTime(),
+		})
+	}
+	return objs, nil
+}
+
+func (s *LocalStore) Delete(ctx context.Context, name string) error {
+	err := os.Remove(filepath.Join(s.Dir, name))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package etcdbackup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/constants"
+	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/provider/phases/cri"
+	"github.com/gostship/kunkka/pkg/provider/phases/kubeadm"
+	"github.com/gostship/kunkka/pkg/util/k8sutil"
+	"github.com/gostship/kunkka/pkg/util/ssh"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog"
+)
+
+const (
+	// workDir is the dir on the master to keep the etcd client certs and snapshots
+	workDir = "/var/lib/kunkka/etcd-backup"
+	// stashDir keeps the static pod manifests while etcd is stopped
+	stashDir = constants.KubernetesDir + "manifests-etcd-restore/"
+
+	etcdRestoreDataDir = constants.EtcdDataDir + "-restore"
+)
+
+// Member is an etcd member the snapshot is taken from or restored to.
+type Member interface {
+	Name() string
+	// Snapshot saves a snapshot of the member and writes it to w.
+	Snapshot(ctx context.Context, w io.Writer) error
+	// Stop stops the member and the components depending on it.
+	Stop(ctx context.Context) error
+	// Restore rebuilds the data of the member from the snapshot file with the initial member set.
+	Restore(ctx context.Context, snapshot string, initialCluster string) error
+	// Start starts the member with the restored data.
+	Start(ctx context.Context) error
+}
+
+// BaremetalMember is a stacked etcd member on a master, the commands run with
+// the etcd image over ssh and connect with the etcd client certs of the cluster.
+type BaremetalMember struct {
+	SSH        ssh.Interface
+	IP         string
+	Credential *devopsv1.ClusterCredential
+	Runtime    devopsv1.ContainerRuntimeType
+}
+
+var _ Member = &BaremetalMember{}
+
+// BaremetalMembers returns the etcd members on the masters of the cluster.
+func BaremetalMembers(c *common.Cluster) ([]Member, string, error) {
+	var members []Member
+	for _, machine := range c.Spec.Machines {
+		sh, err := machine.SSH()
+		if err != nil {
+			return nil, "", errors.Wrap(err, machine.IP)
+		}
+		members = append(members, &BaremetalMember{
+			SSH:        sh,
+			IP:         machine.IP,
+			Credential: c.ClusterCredential,
+			Runtime:    c.Spec.GetContainerRuntime(),
+		})
+	}
+
+	return members, kubeadm.BuildMasterEtcdPeerCluster(c), nil
+}
+
+func (m *BaremetalMember) Name() string {
+	return m.IP
+}
+
+func (m *BaremetalMember) Snapshot(ctx context.Context, w io.Writer) error {
+	image, err := m.image()
+	if err != nil {
+		return err
+	}
+
+	err = m.writeCerts()
+	if err != nil {
+		return err
+	}
+	defer m.SSH.Execf("rm -rf %s", path.Join(workDir, "pki"))
+
+	file := path.Join(workDir, "snapshot.db")
+	cmd := fmt.Sprintf("%s --endpoints https://%s:2379 --cacert %s/pki/ca.crt --cert %s/pki/client.crt --key %s/pki/client.key snapshot save %s",
+		m.etcdctl(image), m.IP, workDir, workDir, workDir, file)
+	err = m.exec(cmd)
+	if err != nil {
+		return err
+	}
+	defer m.SSH.Execf("rm -f %s", file)
+
+	stderr := new(bytes.Buffer)
+	exit, err := m.SSH.ExecStream("cat "+file, w, stderr)
+	if err != nil || exit != 0 {
+		return fmt.Errorf("node: %s read snapshot exit %d:stderr %s:error %v", m.IP, exit, stderr.String(), err)
+	}
+
+	return nil
+}
+
+func (m *BaremetalMember) Stop(ctx context.Context) error {
+	for _, manifest := range []string{constants.KubeAPIServerPodManifestFile, constants.EtcdPodManifestFile} {
+		cmd := fmt.Sprintf("mkdir -p %s && if [ -f %s ]; then mv -f %s %s; fi", stashDir, manifest, manifest, stashDir)
+		err := m.exec(cmd)
+		if err != nil {
+			return err
+		}
+	}
+
+	return m.waitContainer("etcd", false)
+}
+
+func (m *BaremetalMember) Restore(ctx context.Context, snapshot string, initialCluster string) error {
+	image, err := m.image()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(snapshot)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	file := path.Join(workDir, "restore.db")
+	err = m.SSH.WriteFile(f, file)
+	if err != nil {
+		return errors.Wrapf(err, "node: %s upload snapshot", m.IP)
+	}
+	defer m.SSH.Execf("rm -f %s", file)
+
+	cmd := fmt.Sprintf("rm -rf %s && %s snapshot restore %s --name %s --initial-cluster %s --initial-advertise-peer-urls https://%s:2380 --data-dir %s",
+		etcdRestoreDataDir, m.etcdctl(image), file, m.IP, initialCluster, m.IP, etcdRestoreDataDir)
+	err = m.exec(cmd)
+	if err != nil {
+		return err
+	}
+
+	backup := fmt.Sprintf("%s.bak-%s", constants.EtcdDataDir, time.Now().Format("20060102150405"))
+	return m.exec(fmt.Sprintf("if [ -d %s ]; then mv %s %s; fi && mv %s %s",
+		constants.EtcdDataDir, constants.EtcdDataDir, backup, etcdRestoreDataDir, constants.EtcdDataDir))
+}
+
+func (m *BaremetalMember) Start(ctx context.Context) error {
+	for _, manifest := range []string{constants.EtcdPodManifestFile, constants.KubeAPIServerPodManifestFile} {
+		stashed := stashDir + path.Base(manifest)
+		cmd := fmt.Sprintf("if [ -f %s ]; then mv -f %s %s; fi", stashed, stashed, manifest)
+		err := m.exec(cmd)
+		if err != nil {
+			return err
+		}
+	}
+
+	return m.waitContainer("etcd", true)
+}
+
+// image returns the image of the etcd static pod, the manifest may be stashed by Stop.
+func (m *BaremetalMember) image() (string, error) {
+	var lastErr error
+	for _, manifest := range []string{constants.EtcdPodManifestFile, stashDir + path.Base(constants.EtcdPodManifestFile)} {
+		data, err := m.SSH.ReadFile(manifest)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		obj, err := k8sutil.UnmarshalFromYaml(data, corev1.SchemeGroupVersion)
+		if err != nil {
+			return "", fmt.Errorf("node: %s unmarshal %s err: %v", m.IP, manifest, err)
+		}
+		pod, ok := obj.(*corev1.Pod)
+		if !ok || len(pod.Spec.Containers) == 0 {
+			return "", fmt.Errorf("node: %s %s is not a etcd pod", m.IP, manifest)
+		}
+		return pod.Spec.Containers[0].Image, nil
+	}
+
+	return "", fmt.Errorf("node: %s can't find etcd manifest: %v", m.IP, lastErr)
+}
+
+func (m *BaremetalMember) etcdctl(image string) string {
+	return cri.RunCmd(m.Runtime, image, []string{"ETCDCTL_API=3"}, []string{workDir, "/var/lib"}, "etcdctl")
+}
+
+func (m *BaremetalMember) writeCerts() error {
+	certs := map[string][]byte{
+		"ca.crt":     m.Credential.ETCDCACert,
+		"client.crt": m.Credential.ETCDAPIClientCert,
+		"client.key": m.Credential.ETCDAPIClientKey,
+	}
+	for name, data := range certs {
+		if len(data) == 0 {
+			return fmt.Errorf("cluster credential has no etcd %s", name)
+		}
+		err := m.SSH.WriteFile(bytes.NewReader(data), path.Join(workDir, "pki", name))
+		if err != nil {
+			return errors.Wrapf(err, "node: %s write etcd %s", m.IP, name)
+		}
+	}
+
+	return nil
+}
+
+func (m *BaremetalMember) exec(cmd string) error {
+	klog.V(4).Infof("node: %s, cmd: %s", m.IP, cmd)
+	_, stderr, exit, err := m.SSH.Exec(cmd)
+	if err != nil || exit != 0 {
+		return fmt.Errorf("node: %s exec %q failed:exit %d:stderr %s:error %v", m.IP, cmd, exit, stderr, err)
+	}
+	return nil
+}
+
+func (m *BaremetalMember) waitContainer(name string, running bool) error {
+	cmd := cri.PsCmd(m.Runtime, name)
+	err := wait.PollImmediate(5*time.Second, 3*time.Minute, func() (bool, error) {
+		out, err := m.SSH.CombinedOutput(cmd)
+		if err != nil {
+			return false, nil
+		}
+		return (len(strings.TrimSpace(string(out))) > 0) == running, nil
+	})
+	if err != nil {
+		return fmt.Errorf("node: %s wait container %s running=%t err: %v", m.IP, name, running, err)
+	}
+	return nil
+}
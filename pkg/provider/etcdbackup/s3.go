@@ -0,0 +1,100 @@
+package etcdbackup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/minio/minio-go/v6"
+	"github.com/minio/minio-go/v6/pkg/credentials"
+)
+
+const (
+	defaultS3Region = "us-east-1"
+	// checksumMetadata is the user metadata of the object holding the hex sha256 of the snapshot.
+	checksumMetadata = "Sha256"
+)
+
+// S3Store saves the snapshots in a S3 compatible object storage, which works
+// with both AWS S3 and MinIO.
+type S3Store struct {
+	Bucket string
+	Prefix string
+	Client *minio.Client
+}
+
+var _ Store = &S3Store{}
+
+// NewS3Store returns a store of the bucket, the objects are saved under the prefix.
+func NewS3Store(s3 *devopsv1.S3BackupStorage, prefix string, accessKey, secretKey string) (*S3Store, error) {
+	region := s3.Region
+	if region == "" {
+		region = defaultS3Region
+	}
+
+	cli, err := minio.NewWithOptions(s3.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure: !s3.Insecure,
+		Region: region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("new s3 client of %s err: %v", s3.Endpoint, err)
+	}
+
+	return &S3Store{
+		Bucket: s3.Bucket,
+		Prefix: strings.Trim(prefix, "/"),
+		Client: cli,
+	}, nil
+}
+
+func (s *S3Store) Put(ctx context.Context, name string, r io.Reader, size int64, checksum string) error {
+	opts := minio.PutObjectOptions{ContentType: "application/octet-stream"}
+	if checksum != "" {
+		opts.UserMetadata = map[string]string{checksumMetadata: checksum}
+	}
+	_, err := s.Client.PutObjectWithContext(ctx, s.Bucket, s.key(name), r, size, opts)
+	return err
+}
+
+func (s *S3Store) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	obj, err := s.Client.GetObjectWithContext(ctx, s.Bucket, s.key(name), minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	// the object is requested lazily, stat it to report a missing object here
+	if _, err := obj.Stat(); err != nil {
+		obj.Close()
+		return nil, err
+	}
+	return obj, nil
+}
+
+func (s *S3Store) List(ctx context.Context) ([]Object, error) {
+	prefix := s.Prefix + "/"
+	var objs []Object
+	for info := range s.Client.ListObjectsV2(s.Bucket, prefix, false, ctx.Done()) {
+		if info.Err != nil {
+			return nil, info.Err
+		}
+		name := strings.TrimPrefix(info.Key, prefix)
+		if name == "" || strings.Contains(name, "/") {
+			continue
+		}
+		objs = append(objs, Object{Name: name, Size: info.Size, ModTime: info.LastModified})
+	}
+	return objs, ctx.Err()
+}
+
+func (s *S3Store) Delete(ctx context.Context, name string) error {
+	return s.Client.RemoveObject(s.Bucket, s.key(name))
+}
+
+func (s *S3Store) key(name string) string {
+	if s.Prefix == "" {
+		return name
+	}
+	return s.Prefix + "/" + name
+}
@@ -0,0 +1,64 @@
+package etcdbackup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// the keys of EtcdBackup S3 credentials secret
+	accessKeyIDKey     = "accessKeyID"
+	secretAccessKeyKey = "secretAccessKey"
+)
+
+// Object is a snapshot in the store.
+type Object struct {
+	Name    string
+	Size    int64
+	ModTime time.Time
+}
+
+// Store saves the snapshots of a backup, the names are relative to the backup.
+type Store interface {
+	Put(ctx context.Context, name string, r io.Reader, size int64, checksum string) error
+	Get(ctx context.Context, name string) (io.ReadCloser, error)
+	List(ctx context.Context) ([]Object, error)
+	Delete(ctx context.Context, name string) error
+}
+
+// NewStore returns the store of the backup storage, the snapshots of each backup
+// are saved in the <namespace>/<backup name> dir of the storage.
+func NewStore(ctx context.Context, cli client.Client, backup *devopsv1.EtcdBackup) (Store, error) {
+	storage := backup.Spec.Storage
+	dir := path.Join(backup.Namespace, backup.Name)
+	switch {
+	case storage.Local != nil && storage.S3 != nil:
+		return nil, fmt.Errorf("only one of local and s3 storage can be set")
+	case storage.Local != nil:
+		if storage.Local.Path == "" {
+			return nil, fmt.Errorf("local storage path is empty")
+		}
+		return NewLocalStore(path.Join(storage.Local.Path, dir)), nil
+	case storage.S3 != nil:
+		secret := &corev1.Secret{}
+		err := cli.Get(ctx, types.NamespacedName{Namespace: backup.Namespace, Name: storage.S3.CredentialsSecret}, secret)
+		if err != nil {
+			return nil, fmt.Errorf("get s3 credentials secret %s err: %v", storage.S3.CredentialsSecret, err)
+		}
+		accessKey, secretKey := string(secret.Data[accessKeyIDKey]), string(secret.Data[secretAccessKeyKey])
+		if accessKey == "" || secretKey == "" {
+			return nil, fmt.Errorf("secret %s must have %s and %s", secret.Name, accessKeyIDKey, secretAccessKeyKey)
+		}
+		return NewS3Store(storage.S3, path.Join(storage.S3.Prefix, dir), accessKey, secretKey)
+	default:
+		return nil, fmt.Errorf("no storage is set")
+	}
+}
@@ -14,12 +14,18 @@ import (
 	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
 	"github.com/gostship/kunkka/pkg/constants"
 	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/provider/addons/calico"
 	"github.com/gostship/kunkka/pkg/provider/addons/cni"
 	"github.com/gostship/kunkka/pkg/provider/addons/coredns"
 	"github.com/gostship/kunkka/pkg/provider/addons/flannel"
+	"github.com/gostship/kunkka/pkg/provider/addons/helm"
 	"github.com/gostship/kunkka/pkg/provider/addons/kubeproxy"
+	"github.com/gostship/kunkka/pkg/provider/addons/metallb"
 	"github.com/gostship/kunkka/pkg/provider/addons/metricsserver"
+	"github.com/gostship/kunkka/pkg/provider/condlog"
+	"github.com/gostship/kunkka/pkg/provider/phases/carotation"
 	"github.com/gostship/kunkka/pkg/provider/phases/certs"
+	"github.com/gostship/kunkka/pkg/provider/phases/joinnode"
 	"github.com/gostship/kunkka/pkg/provider/phases/kubeadm"
 	"github.com/gostship/kunkka/pkg/provider/phases/kubemisc"
 	"github.com/gostship/kunkka/pkg/util/k8sutil"
@@ -27,6 +33,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/segmentio/ksuid"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	bootstraputil "k8s.io/cluster-bootstrap/token/util"
 	"k8s.io/klog"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -204,7 +211,7 @@ func (p *Provider) EnsureCerts(ctx context.Context, c *common.Cluster) error {
 		return err
 	}
 
-	return ApplyCertsConfigmap(c.Client, c, c.ClusterCredential.CertsBinaryData)
+	return ApplyCertsSecret(c.Client, c)
 }
 
 func (p *Provider) EnsureKubeMisc(ctx context.Context, c *common.Cluster) error {
@@ -213,7 +220,7 @@ func (p *Provider) EnsureKubeMisc(ctx context.Context, c *common.Cluster) error
 	if err != nil {
 		return err
 	}
-	return ApplyKubeMiscConfigmap(c.Client, c, c.ClusterCredential.KubeData)
+	return ApplyKubeMiscSecret(c.Client, c)
 }
 
 func (p *Provider) EnsureEtcd(ctx context.Context, c *common.Cluster) error {
@@ -269,6 +276,96 @@ func (p *Provider) EnsureExtKubeconfig(ctx context.Context, c *common.Cluster) e
 	return nil
 }
 
+// EnsureRenewCerts issues the certificates of the cluster again with the certificate
+// authorities of the credential once some expire within the renew duration of the config.
+func (p *Provider) EnsureRenewCerts(ctx context.Context, c *common.Cluster) error {
+	list, err := p.Certificates(ctx, c)
+	if err != nil {
+		return err
+	}
+	leaf, _ := certs.Expiring(list, p.Cfg.Certs.RenewBefore, time.Now())
+	if len(leaf) == 0 {
+		klog.Infof("cluster: %s skip EnsureRenewCerts because no certificate expires within %s", c.Name, p.Cfg.Certs.RenewBefore)
+		return nil
+	}
+
+	klog.Infof("cluster: %s EnsureRenewCerts, %s of %s expires at %s", c.Name, leaf[0].Name, leaf[0].Source, leaf[0].NotAfter)
+	return p.reissueCerts(ctx, c)
+}
+
+// EnsureRotateCA rotates the certificate authorities of the cluster once one expires within
+// the renew duration of the config, one stage of the rotation runs each time, see
+// carotation.Rotate, and the certificate controller keeps the handler in the action annotation
+// until the rotation is done. The master pods are rolled one by one by their Deployments in
+// each stage. The service account keys are kept, so the tokens are still valid.
+func (p *Provider) EnsureRotateCA(ctx context.Context, c *common.Cluster) error {
+	if c.ClusterCredential.CARotation == nil {
+		list, err := p.Certificates(ctx, c)
+		if err != nil {
+			return err
+		}
+		_, ca := certs.Expiring(list, p.Cfg.Certs.RenewBefore, time.Now())
+		if len(ca) == 0 {
+			klog.Infof("cluster: %s skip EnsureRotateCA because no certificate authority expires within %s", c.Name, p.Cfg.Certs.RenewBefore)
+			return nil
+		}
+		klog.Infof("cluster: %s EnsureRotateCA, %s of %s expires at %s", c.Name, ca[0].Name, ca[0].Source, ca[0].NotAfter)
+	}
+
+	apiserver := certs.BuildApiserverEndpoint(constants.KubeApiServer, 6443)
+	return carotation.Rotate(ctx, c, kubeadm.GetKubeadmConfig(c, p.Cfg, apiserver), true, p.applyCerts)
+}
+
+// reissueCerts issues the certificates and the kubeconfigs of the credential again, the
+// master pods are rolled with the hash of them.
+func (p *Provider) reissueCerts(ctx context.Context, c *common.Cluster) error {
+	apiserver := certs.BuildApiserverEndpoint(constants.KubeApiServer, 6443)
+	err := kubeadm.ReissueCerts(kubeadm.GetKubeadmConfig(c, p.Cfg, apiserver), c, true)
+	if err != nil {
+		return err
+	}
+	err = p.applyCerts(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	err = joinnode.RenewMachines(ctx, c)
+	if err != nil {
+		return err
+	}
+	return c.ReloadClient()
+}
+
+// applyCerts writes the certificates and the kubeconfigs of the credential to the secrets of
+// the masters, and waits the master Deployments to roll their pods one by one.
+func (p *Provider) applyCerts(ctx context.Context, c *common.Cluster) error {
+	err := ApplyCertsSecret(c.Client, c)
+	if err != nil {
+		return err
+	}
+	err = p.EnsureKubeMisc(ctx, c)
+	if err != nil {
+		return err
+	}
+	err = p.EnsureExtKubeconfig(ctx, c)
+	if err != nil {
+		return err
+	}
+	err = p.EnsureKubeMaster(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range []string{constants.KubeApiServer, constants.KubeControllerManager, constants.KubeKubeScheduler} {
+		key := types.NamespacedName{Namespace: c.Namespace, Name: name}
+		err = waitDeploymentRollout(ctx, c.Client, key)
+		if err != nil {
+			return errors.Wrapf(err, "wait deployment %s rollout", key)
+		}
+	}
+	return nil
+}
+
 func (p *Provider) EnsureAddons(ctx context.Context, c *common.Cluster) error {
 	clusterCtx, err := c.ClusterManager.Get(c.Name)
 	if err != nil {
@@ -306,14 +403,14 @@ func (p *Provider) EnsureCni(ctx context.Context, c *common.Cluster) error {
 	var cniType string
 	var ok bool
 
-	if cniType, ok = c.Cluster.Spec.Features.Hooks[devopsv1.HookCniInstall]; !ok {
+	if cniType, ok = cni.Type(c); !ok {
 		return nil
 	}
 
 	switch cniType {
 	case "dke-cni":
 		for _, machine := range c.Spec.Machines {
-			sh, err := machine.SSH()
+			sh, err := condlog.SSH(ctx, machine)
 			if err != nil {
 				return err
 			}
@@ -342,6 +439,8 @@ func (p *Provider) EnsureCni(ctx context.Context, c *common.Cluster) error {
 				return errors.Wrapf(err, "Reconcile  err: %v", err)
 			}
 		}
+	case string(devopsv1.NetworkTypeCalico):
+		return calico.Apply(ctx, p.Cfg, c, c.Spec.Machines)
 	default:
 		return fmt.Errorf("unknown cni type: %s", cniType)
 	}
@@ -372,3 +471,16 @@ func (p *Provider) EnsureMetricsServer(ctx context.Context, c *common.Cluster) e
 
 	return nil
 }
+
+func (p *Provider) EnsureMetalLB(ctx context.Context, c *common.Cluster) error {
+	// the ipvs kube-proxy needs strictARP for metallb, and back without it
+	err := kubeproxy.ApplyStrictARP(ctx, c)
+	if err != nil {
+		return err
+	}
+	return metallb.Apply(ctx, p.Cfg, c)
+}
+
+func (p *Provider) EnsureApps(ctx context.Context, c *common.Cluster) error {
+	return helm.ReconcileApps(ctx, c)
+}
@@ -2,6 +2,8 @@ package cluster
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"sort"
 	"strings"
@@ -13,6 +15,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalev2beta1 "k8s.io/api/autoscaling/v2beta1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -24,6 +27,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// certsHashAnnotation rolls the master pods once the certificates or the kubeconfigs of the
+// mounted configmaps are changed.
+const certsHashAnnotation = "devops.gostship.io/certs-hash"
+
 type Reconciler struct {
 	Obj     *common.Cluster
 	dynamic dynamic.Interface
@@ -72,46 +79,90 @@ func GetHPAReplicaCountOrDefault(client client.Client, name types.NamespacedName
 	return hpa.Status.DesiredReplicas
 }
 
-func ApplyCertsConfigmap(cli client.Client, obj *common.Cluster, pathCerts map[string][]byte) error {
-	noPathCerts := make(map[string]string, len(pathCerts))
-	for pathName, value := range pathCerts {
+// certsHash returns the hash of the certificates and the kubeconfigs of the credential.
+func certsHash(obj *common.Cluster) string {
+	h := sha256.New()
+	certNames := make([]string, 0, len(obj.ClusterCredential.CertsBinaryData))
+	for name := range obj.ClusterCredential.CertsBinaryData {
+		certNames = append(certNames, name)
+	}
+	sort.Strings(certNames)
+	for _, name := range certNames {
+		h.Write([]byte(name))
+		h.Write(obj.ClusterCredential.CertsBinaryData[name])
+	}
+
+	miscNames := make([]string, 0, len(obj.ClusterCredential.KubeData))
+	for name := range obj.ClusterCredential.KubeData {
+		miscNames = append(miscNames, name)
+	}
+	sort.Strings(miscNames)
+	for _, name := range miscNames {
+		h.Write([]byte(name))
+		h.Write([]byte(obj.ClusterCredential.KubeData[name]))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// ApplyCertsSecret applies the certificates and the keys of the credential to the secret
+// mounted by the master pods.
+func ApplyCertsSecret(cli client.Client, obj *common.Cluster) error {
+	noPathCerts := make(map[string][]byte, len(obj.ClusterCredential.CertsBinaryData))
+	for pathName, value := range obj.ClusterCredential.CertsBinaryData {
 		splits := strings.Split(pathName, "/")
 		noPathName := splits[len(splits)-1]
-		noPathCerts[noPathName] = string(value)
+		noPathCerts[noPathName] = value
 		klog.Infof("add noPathName: %s", noPathName)
 	}
 
-	cm := &corev1.ConfigMap{
+	secret := &corev1.Secret{
 		ObjectMeta: k8sutil.ObjectMeta(constants.KubeApiServerCerts, constants.CtrlLabels, obj.Cluster),
+		Type:       corev1.SecretTypeOpaque,
 		Data:       noPathCerts,
 	}
 
 	logger := ctrl.Log.WithValues("cluster", obj.Cluster.Name)
-	err := k8sutil.Reconcile(logger, cli, cm, k8sutil.DesiredStatePresent)
+	err := k8sutil.Reconcile(logger, cli, secret, k8sutil.DesiredStatePresent)
 	if err != nil {
-		return errors.Wrapf(err, "apply certs configmap err: %v", err)
+		return errors.Wrapf(err, "apply certs secret err: %v", err)
 	}
-	return nil
+	return deleteLegacyConfigmap(cli, obj, constants.KubeApiServerCerts)
 }
 
-func ApplyKubeMiscConfigmap(cli client.Client, obj *common.Cluster, pathKubeMisc map[string]string) error {
-	noPathKubeMisc := make(map[string]string, len(pathKubeMisc))
-	for pathName, value := range pathKubeMisc {
+// ApplyKubeMiscSecret applies the kubeconfigs of the credential to the secret mounted by the
+// master pods.
+func ApplyKubeMiscSecret(cli client.Client, obj *common.Cluster) error {
+	noPathKubeMisc := make(map[string][]byte, len(obj.ClusterCredential.KubeData))
+	for pathName, value := range obj.ClusterCredential.KubeData {
 		splits := strings.Split(pathName, "/")
 		noPathName := splits[len(splits)-1]
-		noPathKubeMisc[noPathName] = value
+		noPathKubeMisc[noPathName] = []byte(value)
 		klog.Infof("add noPathName: %s", noPathName)
 	}
 
-	cm := &corev1.ConfigMap{
+	secret := &corev1.Secret{
 		ObjectMeta: k8sutil.ObjectMeta(constants.KubeApiServerConfig, constants.CtrlLabels, obj.Cluster),
+		Type:       corev1.SecretTypeOpaque,
 		Data:       noPathKubeMisc,
 	}
 
 	logger := ctrl.Log.WithValues("cluster", obj.Cluster.Name)
-	err := k8sutil.Reconcile(logger, cli, cm, k8sutil.DesiredStatePresent)
+	err := k8sutil.Reconcile(logger, cli, secret, k8sutil.DesiredStatePresent)
 	if err != nil {
-		return errors.Wrapf(err, "apply kube misc configmap err: %v", err)
+		return errors.Wrapf(err, "apply kube misc secret err: %v", err)
+	}
+	return deleteLegacyConfigmap(cli, obj, constants.KubeApiServerConfig)
+}
+
+// deleteLegacyConfigmap deletes the configmap the certificates and the kubeconfigs were kept
+// in before they are moved to the secrets.
+func deleteLegacyConfigmap(cli client.Client, obj *common.Cluster, name string) error {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: obj.Cluster.Namespace},
+	}
+	err := cli.Delete(context.Background(), cm)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "delete legacy configmap %s err: %v", name, err)
 	}
 	return nil
 }
@@ -138,10 +189,8 @@ func (r *Reconciler) apiServerDeployment() runtime.Object {
 		{
 			Name: constants.KubeApiServerCerts,
 			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: constants.KubeApiServerCerts,
-					},
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  constants.KubeApiServerCerts,
 					DefaultMode: k8sutil.IntPointer(420),
 				},
 			},
@@ -149,10 +198,8 @@ func (r *Reconciler) apiServerDeployment() runtime.Object {
 		{
 			Name: constants.KubeApiServerConfig,
 			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: constants.KubeApiServerConfig,
-					},
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  constants.KubeApiServerConfig,
 					DefaultMode: k8sutil.IntPointer(420),
 				},
 			},
@@ -272,7 +319,8 @@ func (r *Reconciler) apiServerDeployment() runtime.Object {
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: constants.KubeApiServerLabels,
+					Labels:      constants.KubeApiServerLabels,
+					Annotations: map[string]string{certsHashAnnotation: certsHash(r.Obj)},
 				},
 				Spec: corev1.PodSpec{
 					Containers:  containers,
@@ -337,10 +385,8 @@ func (r *Reconciler) controllerManagerDeployment() runtime.Object {
 		{
 			Name: constants.KubeApiServerCerts,
 			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: constants.KubeApiServerCerts,
-					},
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  constants.KubeApiServerCerts,
 					DefaultMode: k8sutil.IntPointer(420),
 				},
 			},
@@ -348,10 +394,8 @@ func (r *Reconciler) controllerManagerDeployment() runtime.Object {
 		{
 			Name: constants.KubeApiServerConfig,
 			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: constants.KubeApiServerConfig,
-					},
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  constants.KubeApiServerConfig,
 					DefaultMode: k8sutil.IntPointer(420),
 				},
 			},
@@ -449,7 +493,8 @@ func (r *Reconciler) controllerManagerDeployment() runtime.Object {
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: constants.KubeControllerManagerLabels,
+					Labels:      constants.KubeControllerManagerLabels,
+					Annotations: map[string]string{certsHashAnnotation: certsHash(r.Obj)},
 				},
 				Spec: corev1.PodSpec{
 					Containers:  containers,
@@ -478,10 +523,8 @@ func (r *Reconciler) schedulerDeployment() runtime.Object {
 		{
 			Name: constants.KubeApiServerConfig,
 			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: constants.KubeApiServerConfig,
-					},
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  constants.KubeApiServerConfig,
 					DefaultMode: k8sutil.IntPointer(420),
 				},
 			},
@@ -555,7 +598,8 @@ func (r *Reconciler) schedulerDeployment() runtime.Object {
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: constants.KubeKubeSchedulerLabels,
+					Labels:      constants.KubeKubeSchedulerLabels,
+					Annotations: map[string]string{certsHashAnnotation: certsHash(r.Obj)},
 				},
 				Spec: corev1.PodSpec{
 					Containers:  containers,
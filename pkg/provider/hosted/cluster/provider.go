@@ -44,6 +44,7 @@ func NewProvider(mgr *clusterprovider.CpManager, cfg *config.Config) (*Provider,
 
 	p.DelegateProvider = &clusterprovider.DelegateProvider{
 		ProviderName: "Hosted",
+		Retry:        &cfg.Retry,
 		CreateHandlers: []clusterprovider.Handler{
 			p.EnsureCopyFiles,
 			p.EnsurePreInstallHook,
@@ -56,13 +57,23 @@ func NewProvider(mgr *clusterprovider.CpManager, cfg *config.Config) (*Provider,
 			p.EnsureExtKubeconfig,
 			p.EnsurePostInstallHook,
 			p.EnsureClusterReady, //健康检查cluster,如果未ready不能进入OnUpdate
+			p.EnsureApps,
 		},
 		UpdateHandlers: []clusterprovider.Handler{
 			p.EnsureExtKubeconfig,
 			p.EnsureKubeMaster,
+			p.EnsureRenewCerts,
+			p.EnsureRotateCA,
 			p.EnsureAddons,
 			p.EnsureCni,
 			p.EnsureMetricsServer,
+			p.EnsureMetalLB,
+			p.EnsureApps,
+		},
+		UpgradeHandlers: []clusterprovider.Handler{
+			p.EnsureUpgradeKubeMaster,
+			p.EnsureUpgradeAddons,
+			p.EnsureUpgradeMachines,
 		},
 	}
 
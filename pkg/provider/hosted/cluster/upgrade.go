@@ -0,0 +1,92 @@
+package cluster
+
+import (
+	"context"
+	"time"
+
+	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/provider/phases/joinnode"
+	"github.com/gostship/kunkka/pkg/provider/phases/upgrade"
+	"github.com/gostship/kunkka/pkg/util/k8sutil"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// EnsureUpgradeKubeMaster rolls the master deployments to the spec version one by one,
+// the next deployment is rolled after the previous one is available.
+func (p *Provider) EnsureUpgradeKubeMaster(ctx context.Context, c *common.Cluster) error {
+	r := &Reconciler{
+		Obj:      c,
+		Provider: p,
+	}
+
+	fs := []func() runtime.Object{
+		r.apiServerDeployment,
+		r.controllerManagerDeployment,
+		r.schedulerDeployment,
+	}
+
+	logger := ctrl.Log.WithValues("cluster", c.Name)
+	for _, f := range fs {
+		obj := f()
+		deploy, ok := obj.(*appsv1.Deployment)
+		if !ok {
+			return errors.Errorf("unexpected master object %T", obj)
+		}
+
+		err := k8sutil.Reconcile(logger, c.Client, obj, k8sutil.DesiredStatePresent)
+		if err != nil {
+			return errors.Wrapf(err, "apply object err: %v", err)
+		}
+
+		key := types.NamespacedName{Namespace: deploy.Namespace, Name: deploy.Name}
+		err = waitDeploymentRollout(ctx, c.Client, key)
+		if err != nil {
+			return errors.Wrapf(err, "wait deployment %s rollout", key)
+		}
+		klog.Infof("cluster: %s deployment: %s upgrade to %s success", c.Name, key, c.Spec.Version)
+	}
+
+	return nil
+}
+
+// EnsureUpgradeAddons applies the addons with the images of the spec version.
+func (p *Provider) EnsureUpgradeAddons(ctx context.Context, c *common.Cluster) error {
+	return p.EnsureAddons(ctx, c)
+}
+
+// EnsureUpgradeMachines upgrades the kubelet of the worker machines one by one, the hosted
+// control plane has no kubeadm configuration, so the kubelet configuration is written again.
+func (p *Provider) EnsureUpgradeMachines(ctx context.Context, c *common.Cluster) error {
+	return upgrade.Machines(ctx, c, p.Cfg, joinnode.UpgradeKubeletConfig)
+}
+
+func waitDeploymentRollout(ctx context.Context, cli client.Client, key types.NamespacedName) error {
+	return wait.PollImmediate(5*time.Second, 5*time.Minute, func() (bool, error) {
+		deploy := &appsv1.Deployment{}
+		err := cli.Get(ctx, key, deploy)
+		if err != nil {
+			klog.Warningf("get deployment: %s err: %v", key, err)
+			return false, nil
+		}
+
+		if deploy.Status.ObservedGeneration < deploy.Generation {
+			return false, nil
+		}
+
+		replicas := int32(1)
+		if deploy.Spec.Replicas != nil {
+			replicas = *deploy.Spec.Replicas
+		}
+
+		return deploy.Status.UpdatedReplicas == replicas &&
+			deploy.Status.Replicas == replicas &&
+			deploy.Status.AvailableReplicas == replicas, nil
+	})
+}
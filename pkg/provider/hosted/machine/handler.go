@@ -13,9 +13,12 @@ import (
 	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
 	"github.com/gostship/kunkka/pkg/constants"
 	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/provider/addons/calico"
 	"github.com/gostship/kunkka/pkg/provider/addons/cni"
+	"github.com/gostship/kunkka/pkg/provider/condlog"
 	"github.com/gostship/kunkka/pkg/provider/phases/certs"
 	"github.com/gostship/kunkka/pkg/provider/phases/component"
+	"github.com/gostship/kunkka/pkg/provider/phases/hostos"
 	"github.com/gostship/kunkka/pkg/provider/phases/joinnode"
 	"github.com/gostship/kunkka/pkg/provider/phases/kubemisc"
 	"github.com/gostship/kunkka/pkg/provider/phases/system"
@@ -27,7 +30,7 @@ import (
 )
 
 func (p *Provider) EnsureCopyFiles(ctx context.Context, machine *devopsv1.Machine, cluster *common.Cluster) error {
-	machineSSH, err := machine.Spec.SSH()
+	machineSSH, err := condlog.SSH(ctx, &machine.Spec)
 	if err != nil {
 		return err
 	}
@@ -69,7 +72,7 @@ func (p *Provider) EnsurePostInstallHook(ctx context.Context, machine *devopsv1.
 		return nil
 	}
 
-	machineSSH, err := machine.Spec.SSH()
+	machineSSH, err := condlog.SSH(ctx, &machine.Spec)
 	if err != nil {
 		return err
 	}
@@ -86,7 +89,7 @@ func (p *Provider) EnsurePostInstallHook(ctx context.Context, machine *devopsv1.
 }
 
 func (p *Provider) EnsureClean(ctx context.Context, machine *devopsv1.Machine, cluster *common.Cluster) error {
-	machineSSH, err := machine.Spec.SSH()
+	machineSSH, err := condlog.SSH(ctx, &machine.Spec)
 	if err != nil {
 		return err
 	}
@@ -100,7 +103,7 @@ func (p *Provider) EnsureClean(ctx context.Context, machine *devopsv1.Machine, c
 }
 
 func (p *Provider) EnsurePreflight(ctx context.Context, machine *devopsv1.Machine, cluster *common.Cluster) error {
-	machineSSH, err := machine.Spec.SSH()
+	machineSSH, err := condlog.SSH(ctx, &machine.Spec)
 	if err != nil {
 		return err
 	}
@@ -131,7 +134,7 @@ func (p *Provider) EnsureRegistryHosts(ctx context.Context, machine *devopsv1.Ma
 		return nil
 	}
 
-	sh, err := machine.Spec.SSH()
+	sh, err := condlog.SSH(ctx, &machine.Spec)
 	if err != nil {
 		return err
 	}
@@ -161,12 +164,18 @@ func (p *Provider) EnsureRegistryHosts(ctx context.Context, machine *devopsv1.Ma
 }
 
 func (p *Provider) EnsureSystem(ctx context.Context, machine *devopsv1.Machine, c *common.Cluster) error {
-	sh, err := machine.Spec.SSH()
+	sh, err := condlog.SSH(ctx, &machine.Spec)
 	if err != nil {
 		return err
 	}
 
-	err = system.Install(sh, c)
+	o, err := hostos.Detect(sh)
+	if err != nil {
+		return err
+	}
+	hostos.Record(&machine.Status.MachineInfo, o)
+
+	err = system.Install(sh, c, p.Cfg, machine.Spec.GetContainerRuntime(&c.Spec), o)
 	if err != nil {
 		return errors.Wrap(err, sh.HostIP())
 	}
@@ -175,12 +184,12 @@ func (p *Provider) EnsureSystem(ctx context.Context, machine *devopsv1.Machine,
 }
 
 func (p *Provider) EnsureK8sComponent(ctx context.Context, machine *devopsv1.Machine, c *common.Cluster) error {
-	sh, err := machine.Spec.SSH()
+	sh, err := condlog.SSH(ctx, &machine.Spec)
 	if err != nil {
 		return err
 	}
 
-	err = component.Install(sh, c)
+	err = component.Install(sh, c, p.Cfg, machine.Spec.GetContainerRuntime(&c.Spec))
 	if err != nil {
 		return errors.Wrap(err, sh.HostIP())
 	}
@@ -189,7 +198,7 @@ func (p *Provider) EnsureK8sComponent(ctx context.Context, machine *devopsv1.Mac
 }
 
 func (p *Provider) EnsureKubeconfig(ctx context.Context, machine *devopsv1.Machine, c *common.Cluster) error {
-	machineSSH, err := machine.Spec.SSH()
+	machineSSH, err := condlog.SSH(ctx, &machine.Spec)
 	if err != nil {
 		return err
 	}
@@ -212,14 +221,14 @@ func (p *Provider) EnsureKubeconfig(ctx context.Context, machine *devopsv1.Machi
 }
 
 func (p *Provider) EnsureJoinNode(ctx context.Context, machine *devopsv1.Machine, c *common.Cluster) error {
-	sh, err := machine.Spec.SSH()
+	sh, err := condlog.SSH(ctx, &machine.Spec)
 	if err != nil {
 		return err
 	}
 
 	apiserver := certs.BuildApiserverEndpoint(c.Cluster.Spec.PublicAlternativeNames[0], kubemisc.GetBindPort(c.Cluster))
 	klog.Infof("join apiserver: %s", apiserver)
-	err = joinnode.JoinNodePhase(sh, p.Cfg, c, apiserver, false)
+	err = joinnode.JoinNodePhase(sh, p.Cfg, c, apiserver, false, machine.Spec.GetContainerRuntime(&c.Spec))
 	if err != nil {
 		return err
 	}
@@ -292,7 +301,7 @@ func (p *Provider) EnsureEth(ctx context.Context, machine *devopsv1.Machine, c *
 	var cniType string
 	var ok bool
 
-	if cniType, ok = c.Cluster.Spec.Features.Hooks[devopsv1.HookCniInstall]; !ok {
+	if cniType, ok = cni.Type(c); !ok {
 		return nil
 	}
 
@@ -300,7 +309,7 @@ func (p *Provider) EnsureEth(ctx context.Context, machine *devopsv1.Machine, c *
 		return nil
 	}
 
-	sh, err := machine.Spec.SSH()
+	sh, err := condlog.SSH(ctx, &machine.Spec)
 	if err != nil {
 		return err
 	}
@@ -318,15 +327,19 @@ func (p *Provider) EnsureCni(ctx context.Context, machine *devopsv1.Machine, c *
 	var cniType string
 	var ok bool
 
-	if cniType, ok = c.Cluster.Spec.Features.Hooks[devopsv1.HookCniInstall]; !ok {
+	if cniType, ok = cni.Type(c); !ok {
 		return nil
 	}
 
+	if cniType == string(devopsv1.NetworkTypeCalico) {
+		return calico.Apply(ctx, p.Cfg, c, []*devopsv1.ClusterMachine{machine.Spec.Machine})
+	}
+
 	if cniType != "dke-cni" {
 		return nil
 	}
 
-	sh, err := machine.Spec.SSH()
+	sh, err := condlog.SSH(ctx, &machine.Spec)
 	if err != nil {
 		return err
 	}
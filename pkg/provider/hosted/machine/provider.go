@@ -35,6 +35,7 @@ func NewProvider(mgr *machineprovider.MpManager, cfg *config.Config) (*Provider,
 
 	p.DelegateProvider = &machineprovider.DelegateProvider{
 		ProviderName: "Hosted",
+		Retry:        &cfg.Retry,
 		CreateHandlers: []machineprovider.Handler{
 			p.EnsureCopyFiles,
 			p.EnsurePreInstallHook,
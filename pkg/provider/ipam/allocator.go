@@ -0,0 +1,223 @@
+/*
+Copyright 2020 dke.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ipam
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/constants"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var (
+	// ErrAllocated means the requested block is in use.
+	ErrAllocated = errors.New("address is already allocated")
+	// ErrFull means the pool has no free block.
+	ErrFull = errors.New("pool is full")
+)
+
+// HostPoolName returns the host pool name of the rack.
+func HostPoolName(rack string) string {
+	return rack + "-host"
+}
+
+// PodPoolName returns the pod pool name of the rack.
+func PodPoolName(rack string) string {
+	return rack + "-pod"
+}
+
+// ClaimName returns the name of the claim of the block, it is also the block id of the ui.
+func ClaimName(pool string, offset int) string {
+	return fmt.Sprintf("%s-%d", pool, offset)
+}
+
+// ParseClaimName returns the pool and offset of the claim name.
+func ParseClaimName(name string) (string, int, error) {
+	i := strings.LastIndex(name, "-")
+	if i <= 0 {
+		return "", 0, fmt.Errorf("invalid block id %q", name)
+	}
+	offset, err := strconv.Atoi(name[i+1:])
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid block id %q", name)
+	}
+	return name[:i], offset, nil
+}
+
+// Allocate reserves the block starting with the address in the pool, or the first free
+// block if the address is empty. The pool status is updated with its resourceVersion,
+// so the concurrent allocations never get the same block, a stale read only retries.
+//
+// The returned claim is not created, it should be created with Claim once the owner exists,
+// or the block released with Release.
+func Allocate(ctx context.Context, cli client.Client, poolName string, address string) (*devopsv1.IPClaim, error) {
+	var claim *devopsv1.IPClaim
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		p := &devopsv1.IPPool{}
+		if err := cli.Get(ctx, types.NamespacedName{Name: poolName}, p); err != nil {
+			return err
+		}
+		pool, err := NewPool(p)
+		if err != nil {
+			return err
+		}
+		bitmap, err := pool.Bitmap()
+		if err != nil {
+			return err
+		}
+
+		var offset int
+		if address != "" {
+			offset, err = pool.Offset(address)
+			if err != nil {
+				return err
+			}
+			ok, _ := bitmap.Allocate(offset)
+			if !ok {
+				return errors.Wrapf(ErrAllocated, "%s of pool %s", address, poolName)
+			}
+		} else {
+			var ok bool
+			offset, ok, _ = bitmap.AllocateNext()
+			if !ok {
+				return errors.Wrapf(ErrFull, "pool %s", poolName)
+			}
+		}
+
+		pool.SetBitmap(bitmap)
+		if err := cli.Status().Update(ctx, pool.IPPool); err != nil {
+			return err
+		}
+
+		block, _ := pool.Block(offset)
+		claim = &devopsv1.IPClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       ClaimName(poolName, offset),
+				Labels:     map[string]string{devopsv1.LabelIPPool: poolName},
+				Finalizers: []string{constants.FinalizersIPClaim},
+			},
+			Spec: devopsv1.IPClaimSpec{
+				Pool:       poolName,
+				Offset:     offset,
+				RangeStart: block.Start,
+				RangeEnd:   block.End,
+			},
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	klog.V(4).Infof("allocated %s-%s of pool %s", claim.Spec.RangeStart, claim.Spec.RangeEnd, poolName)
+	return claim, nil
+}
+
+// Release frees the block of the pool, releasing a free block is a no-op.
+func Release(ctx context.Context, cli client.Client, poolName string, offset int) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		p := &devopsv1.IPPool{}
+		if err := cli.Get(ctx, types.NamespacedName{Name: poolName}, p); err != nil {
+			// the pool is gone with its allocations
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		pool, err := NewPool(p)
+		if err != nil {
+			return err
+		}
+		bitmap, err := pool.Bitmap()
+		if err != nil {
+			return err
+		}
+		if !bitmap.Has(offset) {
+			return nil
+		}
+
+		bitmap.Release(offset)
+		pool.SetBitmap(bitmap)
+		return cli.Status().Update(ctx, pool.IPPool)
+	})
+}
+
+// Claim creates the claims owned by the object in its namespace, the blocks are released
+// by the garbage collector once the owner is deleted.
+func Claim(ctx context.Context, cli client.Client, scheme *runtime.Scheme, owner metav1.Object, claims []*devopsv1.IPClaim) error {
+	for _, claim := range claims {
+		claim.Namespace = owner.GetNamespace()
+		if err := controllerutil.SetOwnerReference(owner, claim, scheme); err != nil {
+			return err
+		}
+		if err := cli.Create(ctx, claim); err != nil {
+			return errors.Wrapf(err, "create ipclaim %s/%s", claim.Namespace, claim.Name)
+		}
+	}
+	return nil
+}
+
+// ReleaseAll frees the blocks of the claims not created, it is used to roll back a
+// failed creation of the owners.
+func ReleaseAll(ctx context.Context, cli client.Client, claims []*devopsv1.IPClaim) {
+	for _, claim := range claims {
+		if err := Release(ctx, cli, claim.Spec.Pool, claim.Spec.Offset); err != nil {
+			klog.Errorf("release %s-%s of pool %s err: %v", claim.Spec.RangeStart, claim.Spec.RangeEnd, claim.Spec.Pool, err)
+		}
+	}
+}
+
+// ReleaseOwned deletes the claims owned by the object, their blocks are released by the
+// finalizers of the claims.
+func ReleaseOwned(ctx context.Context, cli client.Client, owner metav1.Object) error {
+	claims := &devopsv1.IPClaimList{}
+	if err := cli.List(ctx, claims, client.InNamespace(owner.GetNamespace())); err != nil {
+		return errors.Wrapf(err, "list ipclaims of %s/%s", owner.GetNamespace(), owner.GetName())
+	}
+	for i := range claims.Items {
+		claim := &claims.Items[i]
+		if !isOwnedBy(claim, owner) {
+			continue
+		}
+		if err := cli.Delete(ctx, claim); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "delete ipclaim %s/%s", claim.Namespace, claim.Name)
+		}
+		klog.Infof("release %s-%s of pool %s owned by %s/%s", claim.Spec.RangeStart, claim.Spec.RangeEnd, claim.Spec.Pool, owner.GetNamespace(), owner.GetName())
+	}
+	return nil
+}
+
+func isOwnedBy(obj metav1.Object, owner metav1.Object) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == owner.GetUID() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,202 @@
+package ipam
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newTestClient(t *testing.T) client.Client {
+	scheme := runtime.NewScheme()
+	if err := devopsv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return fake.NewFakeClientWithScheme(scheme, &devopsv1.IPPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "rack1-pod"},
+		Spec: devopsv1.IPPoolSpec{
+			Rack:      "rack1",
+			Type:      devopsv1.IPPoolPod,
+			Subnet:    "10.28.0.0/22",
+			BlockSize: 16,
+			Ranges: MergeRanges([]devopsv1.IPRange{
+				{Start: "10.28.0.1", End: "10.28.0.16"},
+				{Start: "10.28.0.17", End: "10.28.0.32"},
+				{Start: "10.28.1.1", End: "10.28.1.16"},
+			}),
+		},
+	})
+}
+
+func getPool(t *testing.T, cli client.Client) *devopsv1.IPPool {
+	p := &devopsv1.IPPool{}
+	if err := cli.Get(context.TODO(), types.NamespacedName{Name: "rack1-pod"}, p); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestAllocate(t *testing.T) {
+	ctx := context.TODO()
+	cli := newTestClient(t)
+
+	claim, err := Allocate(ctx, cli, "rack1-pod", "10.28.0.17")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claim.Name != "rack1-pod-1" || claim.Spec.RangeEnd != "10.28.0.32" {
+		t.Errorf("Allocate() = %s %s-%s", claim.Name, claim.Spec.RangeStart, claim.Spec.RangeEnd)
+	}
+
+	_, err = Allocate(ctx, cli, "rack1-pod", "10.28.0.17")
+	if errors.Cause(err) != ErrAllocated {
+		t.Errorf("Allocate() of allocated block err = %v, want %v", err, ErrAllocated)
+	}
+	if _, err = Allocate(ctx, cli, "rack1-pod", "10.28.0.18"); err == nil {
+		t.Errorf("Allocate() of address not starting a block succeeded")
+	}
+
+	if p := getPool(t, cli); p.Status.Size != 3 || p.Status.Used != 1 {
+		t.Errorf("status size %d used %d, want 3 1", p.Status.Size, p.Status.Used)
+	}
+
+	if err := Release(ctx, cli, "rack1-pod", claim.Spec.Offset); err != nil {
+		t.Fatal(err)
+	}
+	if p := getPool(t, cli); p.Status.Used != 0 {
+		t.Errorf("status used %d after release, want 0", p.Status.Used)
+	}
+}
+
+func TestAllocateConcurrent(t *testing.T) {
+	ctx := context.TODO()
+	cli := newTestClient(t)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	allocated := 0
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := Allocate(ctx, cli, "rack1-pod", "10.28.1.1"); err == nil {
+				mu.Lock()
+				allocated++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if allocated != 1 {
+		t.Errorf("block allocated %d times, want 1", allocated)
+	}
+	if p := getPool(t, cli); p.Status.Used != 1 {
+		t.Errorf("status used %d, want 1", p.Status.Used)
+	}
+}
+
+func TestRepair(t *testing.T) {
+	ctx := context.TODO()
+	cli := newTestClient(t)
+
+	leaked, err := Allocate(ctx, cli, "rack1-pod", "10.28.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	claimed, err := Allocate(ctx, cli, "rack1-pod", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	claimed.Namespace = "default"
+	if err := cli.Create(ctx, claimed); err != nil {
+		t.Fatal(err)
+	}
+
+	// the unclaimed block is only remembered until it is older than the grace
+	var suspects map[int]time.Time
+	for i := 0; i < 2; i++ {
+		suspects, err = Repair(ctx, cli, getPool(t, cli), suspects, time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := suspects[leaked.Spec.Offset]; !ok || len(suspects) != 1 {
+			t.Errorf("suspects = %v, want offset %d", suspects, leaked.Spec.Offset)
+		}
+		if p := getPool(t, cli); p.Status.Used != 2 {
+			t.Errorf("status used %d after repair %d, want 2", p.Status.Used, i)
+		}
+	}
+
+	suspects[leaked.Spec.Offset] = suspects[leaked.Spec.Offset].Add(-2 * time.Minute)
+	suspects, err = Repair(ctx, cli, getPool(t, cli), suspects, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(suspects) != 0 {
+		t.Errorf("suspects = %v after grace, want none", suspects)
+	}
+	p := getPool(t, cli)
+	pool, _ := NewPool(p)
+	bitmap, _ := pool.Bitmap()
+	if bitmap.Has(leaked.Spec.Offset) || !bitmap.Has(claimed.Spec.Offset) || p.Status.Used != 1 {
+		t.Errorf("bitmap after repair: leaked %v claimed %v used %d", bitmap.Has(leaked.Spec.Offset), bitmap.Has(claimed.Spec.Offset), p.Status.Used)
+	}
+}
+
+func TestAllocateMachine(t *testing.T) {
+	ctx := context.TODO()
+	cli := newTestClient(t)
+	err := cli.Create(ctx, &devopsv1.IPPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "rack1-host"},
+		Spec: devopsv1.IPPoolSpec{
+			Rack:   "rack1",
+			Type:   devopsv1.IPPoolHost,
+			Subnet: "10.27.0.0/24",
+			Ranges: []devopsv1.IPRange{{Start: "10.27.0.10", End: "10.27.0.20"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner := &devopsv1.Machine{ObjectMeta: metav1.ObjectMeta{Namespace: "c1", Name: "m1", UID: "m1"}}
+	scheme := runtime.NewScheme()
+	if err := devopsv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	cni, err := AllocateMachine(ctx, cli, scheme, owner, "rack1", "10.27.0.12")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cni.ID != "rack1-pod-0" || cni.RangeStart != "10.28.0.1" || cni.RackTag != "rack1" {
+		t.Errorf("AllocateMachine() = %+v", cni)
+	}
+
+	// the claims of the address are taken again
+	again, err := AllocateMachine(ctx, cli, scheme, owner, "rack1", "10.27.0.12")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.ID != cni.ID {
+		t.Errorf("AllocateMachine() again = %s, want %s", again.ID, cni.ID)
+	}
+	claims := &devopsv1.IPClaimList{}
+	if err := cli.List(ctx, claims, client.InNamespace("c1")); err != nil {
+		t.Fatal(err)
+	}
+	if len(claims.Items) != 2 {
+		t.Errorf("%d claims are created, want 2", len(claims.Items))
+	}
+	if p := getPool(t, cli); p.Status.Used != 1 {
+		t.Errorf("pod pool used %d, want 1", p.Status.Used)
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2020 dke.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ipam
+
+import (
+	"context"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AllocateMachine allocates the address of the machine and a pod block in the pools of the
+// rack and claims them for the owner, the claims of the address owned already are taken again,
+// so a failed update of the owner is retried. It returns the cni of the pod block.
+func AllocateMachine(ctx context.Context, cli client.Client, scheme *runtime.Scheme, owner metav1.Object, rack, address string) (*devopsv1.ClusterCni, error) {
+	podPool := &devopsv1.IPPool{}
+	if err := cli.Get(ctx, types.NamespacedName{Name: PodPoolName(rack)}, podPool); err != nil {
+		return nil, errors.Wrapf(err, "get pod pool of rack %s", rack)
+	}
+
+	list := &devopsv1.IPClaimList{}
+	err := cli.List(ctx, list, client.InNamespace(owner.GetNamespace()), client.MatchingLabels{devopsv1.LabelMachineAddress: address})
+	if err != nil {
+		return nil, errors.Wrapf(err, "list ipclaims of %s", address)
+	}
+	var host, pod *devopsv1.IPClaim
+	for i := range list.Items {
+		claim := &list.Items[i]
+		if !isOwnedBy(claim, owner) {
+			continue
+		}
+		switch claim.Spec.Pool {
+		case HostPoolName(rack):
+			host = claim
+		case PodPoolName(rack):
+			pod = claim
+		}
+	}
+
+	var allocated []*devopsv1.IPClaim
+	if host == nil {
+		claim, err := Allocate(ctx, cli, HostPoolName(rack), address)
+		if err != nil {
+			return nil, err
+		}
+		allocated = append(allocated, claim)
+	}
+	if pod == nil {
+		claim, err := Allocate(ctx, cli, PodPoolName(rack), "")
+		if err != nil {
+			ReleaseAll(ctx, cli, allocated)
+			return nil, err
+		}
+		allocated = append(allocated, claim)
+		pod = claim
+	}
+	for _, claim := range allocated {
+		claim.Labels[devopsv1.LabelMachineAddress] = address
+	}
+	if err := Claim(ctx, cli, scheme, owner, allocated); err != nil {
+		var untaken []*devopsv1.IPClaim
+		for _, claim := range allocated {
+			if claim.ResourceVersion == "" {
+				untaken = append(untaken, claim)
+			}
+		}
+		ReleaseAll(ctx, cli, untaken)
+		return nil, err
+	}
+
+	return &devopsv1.ClusterCni{
+		ID:           pod.Name,
+		Subnet:       podPool.Spec.Subnet,
+		RangeStart:   pod.Spec.RangeStart,
+		RangeEnd:     pod.Spec.RangeEnd,
+		DefaultRoute: podPool.Spec.DefaultRoute,
+		UseState:     1,
+		RackTag:      rack,
+		GW:           podPool.Spec.Gateway,
+	}, nil
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2020 dke.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ipam
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/util/allocator"
+)
+
+// Pool is the blocks of a IPPool, the offset of a block is its index.
+type Pool struct {
+	*devopsv1.IPPool
+	blocks []devopsv1.IPRange
+}
+
+// NewPool splits the ranges of the pool into blocks.
+func NewPool(p *devopsv1.IPPool) (*Pool, error) {
+	size := p.Spec.BlockSize
+	if size <= 0 {
+		size = 1
+	}
+
+	pool := &Pool{IPPool: p}
+	for _, r := range p.Spec.Ranges {
+		start, err := ipToUint32(r.Start)
+		if err != nil {
+			return nil, err
+		}
+		end, err := ipToUint32(r.End)
+		if err != nil {
+			return nil, err
+		}
+		if end < start {
+			return nil, fmt.Errorf("pool %s range %s-%s is invalid", p.Name, r.Start, r.End)
+		}
+
+		for s := uint64(start); s+uint64(size)-1 <= uint64(end); s += uint64(size) {
+			pool.blocks = append(pool.blocks, devopsv1.IPRange{
+				Start: uint32ToIP(uint32(s)),
+				End:   uint32ToIP(uint32(s) + uint32(size) - 1),
+			})
+		}
+	}
+	return pool, nil
+}
+
+// Size returns the count of blocks.
+func (p *Pool) Size() int {
+	return len(p.blocks)
+}
+
+// Block returns the block of the offset.
+func (p *Pool) Block(offset int) (devopsv1.IPRange, error) {
+	if offset < 0 || offset >= len(p.blocks) {
+		return devopsv1.IPRange{}, fmt.Errorf("offset %d is out of pool %s", offset, p.Name)
+	}
+	return p.blocks[offset], nil
+}
+
+// Offset returns the offset of the block starting with the address.
+func (p *Pool) Offset(address string) (int, error) {
+	for i, b := range p.blocks {
+		if b.Start == address {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("address %s is not a block of pool %s", address, p.Name)
+}
+
+// Bitmap returns the allocation bitmap restored from the status.
+func (p *Pool) Bitmap() (*allocator.AllocationBitmap, error) {
+	spec := p.rangeSpec()
+	bitmap := allocator.NewContiguousAllocationMap(p.Size(), spec)
+	if err := bitmap.Restore(spec, p.Status.Allocated); err != nil {
+		return nil, err
+	}
+	return bitmap, nil
+}
+
+// SetBitmap saves the bitmap to the status.
+func (p *Pool) SetBitmap(bitmap *allocator.AllocationBitmap) {
+	_, data := bitmap.Snapshot()
+	p.Status.Allocated = data
+	p.Status.Size = p.Size()
+	p.Status.Used = p.Size() - bitmap.Free()
+}
+
+func (p *Pool) rangeSpec() string {
+	ranges := make([]string, 0, len(p.Spec.Ranges))
+	for _, r := range p.Spec.Ranges {
+		ranges = append(ranges, r.Start+"-"+r.End)
+	}
+	return fmt.Sprintf("%s/%d", strings.Join(ranges, ","), p.Spec.BlockSize)
+}
+
+// MergeRanges joins the adjacent ranges, the ranges must be sorted.
+func MergeRanges(ranges []devopsv1.IPRange) []devopsv1.IPRange {
+	var result []devopsv1.IPRange
+	for _, r := range ranges {
+		if n := len(result); n > 0 {
+			last, err1 := ipToUint32(result[n-1].End)
+			start, err2 := ipToUint32(r.Start)
+			if err1 == nil && err2 == nil && last+1 == start {
+				result[n-1].End = r.End
+				continue
+			}
+		}
+		result = append(result, r)
+	}
+	return result
+}
+
+func ipToUint32(s string) (uint32, error) {
+	ip := net.ParseIP(s).To4()
+	if ip == nil {
+		return 0, fmt.Errorf("%q is not a ipv4 address", s)
+	}
+	return binary.BigEndian.Uint32(ip), nil
+}
+
+func uint32ToIP(n uint32) string {
+	ip := make(net.IP, 4)
+	binary.BigEndian.PutUint32(ip, n)
+	return ip.String()
+}
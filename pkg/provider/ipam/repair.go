@@ -0,0 +1,94 @@
+/*
+Copyright 2020 dke.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ipam
+
+import (
+	"context"
+	"time"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Repair makes the bitmap of the pool match its claims. A allocated block without claim is
+// normal for a moment, between Allocate and Claim, so it is only released when it has been
+// unclaimed for longer than grace. The suspects map the unclaimed blocks to the time they were
+// first seen, they are returned to be passed to the next repair.
+//
+// The pool is updated with its resourceVersion read before the claims are listed,
+// so a concurrent allocation makes the update conflict instead of being lost.
+func Repair(ctx context.Context, cli client.Client, p *devopsv1.IPPool, suspects map[int]time.Time, grace time.Duration) (map[int]time.Time, error) {
+	pool, err := NewPool(p)
+	if err != nil {
+		return nil, err
+	}
+	bitmap, err := pool.Bitmap()
+	if err != nil {
+		return nil, err
+	}
+
+	claims := &devopsv1.IPClaimList{}
+	if err := cli.List(ctx, claims, client.MatchingLabels{devopsv1.LabelIPPool: p.Name}); err != nil {
+		return nil, err
+	}
+
+	claimed := make(map[int]bool, len(claims.Items))
+	changed := false
+	for i := range claims.Items {
+		claim := &claims.Items[i]
+		if claim.Spec.Pool != p.Name {
+			continue
+		}
+		claimed[claim.Spec.Offset] = true
+		if claim.DeletionTimestamp == nil && !bitmap.Has(claim.Spec.Offset) {
+			klog.Warningf("pool %s block %d of claim %s/%s is not allocated, fix it", p.Name, claim.Spec.Offset, claim.Namespace, claim.Name)
+			bitmap.Allocate(claim.Spec.Offset)
+			changed = true
+		}
+	}
+
+	now := time.Now()
+	leaked := make(map[int]time.Time)
+	bitmap.ForEach(func(offset int) {
+		if claimed[offset] {
+			return
+		}
+		if seen, ok := suspects[offset]; ok {
+			leaked[offset] = seen
+		} else {
+			leaked[offset] = now
+		}
+	})
+	for offset, seen := range leaked {
+		if now.Sub(seen) > grace {
+			klog.Warningf("pool %s block %d has no claim, release it", p.Name, offset)
+			bitmap.Release(offset)
+			delete(leaked, offset)
+			changed = true
+		}
+	}
+
+	if !changed && p.Status.Size == pool.Size() {
+		return leaked, nil
+	}
+	pool.SetBitmap(bitmap)
+	if err := cli.Status().Update(ctx, pool.IPPool); err != nil {
+		return suspects, err
+	}
+	return leaked, nil
+}
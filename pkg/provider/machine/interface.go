@@ -7,12 +7,17 @@ import (
 	"reflect"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/thoas/go-funk"
 
 	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
 	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/provider/condlog"
+	"github.com/gostship/kunkka/pkg/provider/config"
+	"github.com/gostship/kunkka/pkg/provider/plan"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/klog"
 )
@@ -40,6 +45,8 @@ type Provider interface {
 	OnCreate(ctx context.Context, machine *devopsv1.Machine, cluster *common.Cluster) error
 	OnUpdate(ctx context.Context, machine *devopsv1.Machine, cluster *common.Cluster) error
 	OnDelete(ctx context.Context, machine *devopsv1.Machine, cluster *common.Cluster) error
+
+	Plan(ctx context.Context, machine *devopsv1.Machine, cluster *common.Cluster) (*plan.Plan, error)
 }
 
 var _ Provider = &DelegateProvider{}
@@ -56,6 +63,13 @@ type DelegateProvider struct {
 	CreateHandlers []Handler
 	DeleteHandlers []Handler
 	UpdateHandlers []Handler
+
+	// PlanFunc validates the machine and renders the artifacts of the create handlers
+	// without touching the host.
+	PlanFunc func(ctx context.Context, machine *devopsv1.Machine, cluster *common.Cluster, p *plan.Plan) error
+
+	// Retry is the retry policy of the failed handlers, config.DefaultRetry if nil.
+	Retry *config.Retry
 }
 
 func (p *DelegateProvider) Name() string {
@@ -103,6 +117,9 @@ func (p *DelegateProvider) OnCreate(ctx context.Context, machine *devopsv1.Machi
 	if err != nil {
 		return err
 	}
+	if p.waitRetry(condition) {
+		return nil
+	}
 
 	now := metav1.Now()
 	if cluster.Spec.Features.SkipConditions != nil &&
@@ -122,16 +139,14 @@ func (p *DelegateProvider) OnCreate(ctx context.Context, machine *devopsv1.Machi
 		}
 		handlerName := f.Name()
 		klog.Infof("machineName: %s OnCreate handler: %s", machine.Name, handlerName)
-		err = f(ctx, machine, cluster)
+		hctx, end := condlog.Begin(ctx, condition.Type)
+		err = f(hctx, machine, cluster)
+		end(err)
 		if err != nil {
 			klog.Errorf("cluster: %s OnCreate handler: %s err: %+v", cluster.Name, handlerName, err)
-			machine.SetCondition(devopsv1.MachineCondition{
-				Type:          condition.Type,
-				Status:        devopsv1.ConditionFalse,
-				LastProbeTime: now,
-				Message:       err.Error(),
-				Reason:        ReasonFailedInit,
-			})
+			if p.setFailed(machine, condition.Type, err) {
+				machine.Status.Phase = devopsv1.MachineFailed
+			}
 
 			return err
 		}
@@ -163,7 +178,9 @@ func (p *DelegateProvider) OnCreate(ctx context.Context, machine *devopsv1.Machi
 func (p *DelegateProvider) OnUpdate(ctx context.Context, machine *devopsv1.Machine, cluster *common.Cluster) error {
 	for _, f := range p.UpdateHandlers {
 		klog.Infof("machineName: %s OnUpdate handler: %s", machine.Name, f.Name())
-		err := f(ctx, machine, cluster)
+		hctx, end := condlog.Begin(ctx, f.Name())
+		err := f(hctx, machine, cluster)
+		end(err)
 		if err != nil {
 			return err
 		}
@@ -174,16 +191,112 @@ func (p *DelegateProvider) OnUpdate(ctx context.Context, machine *devopsv1.Machi
 	return nil
 }
 
+// OnDelete runs all the delete handlers even if some fail, the errors are returned together.
 func (p *DelegateProvider) OnDelete(ctx context.Context, machine *devopsv1.Machine, cluster *common.Cluster) error {
+	var errs []error
 	for _, f := range p.DeleteHandlers {
 		klog.Infof("machineName: %s OnDelete handler: %s", machine.Name, f.Name())
 		err := f(ctx, machine, cluster)
 		if err != nil {
-			return err
+			klog.Errorf("machine: %s OnDelete handler: %s err: %+v", machine.Name, f.Name(), err)
+			errs = append(errs, fmt.Errorf("%s: %v", f.Name(), err))
 		}
 	}
 
-	return nil
+	return utilerrors.NewAggregate(errs)
+}
+
+// Plan walks the create handlers like OnCreate without running them, and records
+// the handlers would run or be skipped.
+func (p *DelegateProvider) Plan(ctx context.Context, machine *devopsv1.Machine, cluster *common.Cluster) (*plan.Plan, error) {
+	result := plan.New("Machine", machine)
+	for _, f := range p.CreateHandlers {
+		name := f.Name()
+		switch {
+		case isConditionTrue(machine, name):
+			result.AddStep(name, plan.ActionDone)
+		case funk.ContainsString(cluster.Spec.Features.SkipConditions, name):
+			result.AddStep(name, plan.ActionSkip)
+		default:
+			result.AddStep(name, plan.ActionRun)
+		}
+	}
+
+	if p.PlanFunc != nil {
+		if err := p.PlanFunc(ctx, machine, cluster, result); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (p *DelegateProvider) retry() *config.Retry {
+	if p.Retry != nil {
+		return p.Retry
+	}
+	return &config.DefaultRetry
+}
+
+// waitRetry means the failed handler of the condition is in its backoff, or its
+// retries are exhausted.
+func (p *DelegateProvider) waitRetry(condition *devopsv1.MachineCondition) bool {
+	if condition == nil || condition.Status != devopsv1.ConditionFalse || condition.Reason != ReasonFailedInit {
+		return false
+	}
+	retry := p.retry()
+	return retry.Exhausted(condition.Retries) || retry.Wait(condition.Retries, condition.LastProbeTime.Time) > 0
+}
+
+// setFailed records the failure of the handler and counts its retries, it returns
+// true if the retries are exhausted.
+func (p *DelegateProvider) setFailed(machine *devopsv1.Machine, conditionType string, err error) bool {
+	retries := int32(1)
+	for _, condition := range machine.Status.Conditions {
+		if condition.Type == conditionType && condition.Status == devopsv1.ConditionFalse && condition.Reason == ReasonFailedInit {
+			retries = condition.Retries + 1
+		}
+	}
+
+	message := err.Error()
+	exhausted := p.retry().Exhausted(retries)
+	if exhausted {
+		message = fmt.Sprintf("failed %d times, stop retrying: %s", retries, message)
+	}
+	machine.SetCondition(devopsv1.MachineCondition{
+		Type:          conditionType,
+		Status:        devopsv1.ConditionFalse,
+		LastProbeTime: metav1.Now(),
+		Message:       message,
+		Reason:        ReasonFailedInit,
+		Retries:       retries,
+	})
+	return exhausted
+}
+
+// RetryAfter returns the shortest wait of the failed handlers in their backoff,
+// or 0 if there is none.
+func RetryAfter(machine *devopsv1.Machine, retry *config.Retry) time.Duration {
+	var after time.Duration
+	for _, condition := range machine.Status.Conditions {
+		if condition.Status != devopsv1.ConditionFalse || condition.Reason != ReasonFailedInit ||
+			retry.Exhausted(condition.Retries) {
+			continue
+		}
+		wait := retry.Wait(condition.Retries, condition.LastProbeTime.Time)
+		if wait > 0 && (after == 0 || wait < after) {
+			after = wait
+		}
+	}
+	return after
+}
+
+func isConditionTrue(machine *devopsv1.Machine, conditionType string) bool {
+	for _, condition := range machine.Status.Conditions {
+		if condition.Type == conditionType {
+			return condition.Status == devopsv1.ConditionTrue
+		}
+	}
+	return false
 }
 
 func (p *DelegateProvider) getNextConditionType(conditionType string) string {
@@ -0,0 +1,319 @@
+package carotation
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/provider/phases/joinnode"
+	"github.com/gostship/kunkka/pkg/provider/phases/kubeadm"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// trustAnnotation is the hash of the certificate authorities the pods of the workload are
+// restarted with, so they mount the service account tokens of them.
+const trustAnnotation = "devops.gostship.io/ca-hash"
+
+var (
+	rolloutInterval = 5 * time.Second
+	rolloutTimeout  = 5 * time.Minute
+)
+
+// ApplyFunc writes the certificates and the kubeconfigs of the credential to the masters and
+// restarts them one by one, so the cluster keeps serving.
+type ApplyFunc func(ctx context.Context, c *common.Cluster) error
+
+// Rotate rolls out the stage of the rotation of the certificate authorities the credential is
+// prepared for, and prepares the credential for the next one, each run takes one stage:
+//  1. TrustBoth: the bundles of the old and the new certificate authorities are written to the
+//     masters and the workers, the ca.crt of the service account token secrets is updated
+//     with them and the workloads are rolled to load it.
+//  2. Reissue: once the workloads are rolled, the certificates issued by the new certificate
+//     authorities are written to the masters and the workers.
+//  3. DropOld: the old certificate authorities are removed from the bundles, the ca.crt of
+//     the service account token secrets is updated and the workloads are rolled again.
+//
+// The rotation is kept in the credential, so a failed stage runs again.
+func Rotate(ctx context.Context, c *common.Cluster, cfg *kubeadm.Config, isHosted bool, apply ApplyFunc) error {
+	if c.ClusterCredential.CARotation == nil {
+		err := kubeadm.TrustNewCAs(cfg, c, isHosted)
+		if err != nil {
+			return err
+		}
+	}
+	rotation := c.ClusterCredential.CARotation
+	klog.Infof("cluster: %s rotate certificate authorities, phase: %s", c.Name, rotation.Phase)
+
+	if rotation.Phase == devopsv1.CARotationReissue {
+		// the pods must trust the new certificate authorities before the apiservers serve
+		// with the certificates of them
+		cli, err := clusterClient(c)
+		if err != nil {
+			return err
+		}
+		hash, err := trustHash(c)
+		if err != nil {
+			return err
+		}
+		err = waitWorkloads(ctx, cli, hash)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := apply(ctx, c)
+	if err != nil {
+		return err
+	}
+	err = joinnode.RenewMachines(ctx, c)
+	if err != nil {
+		return err
+	}
+	err = c.ReloadClient()
+	if err != nil {
+		return err
+	}
+	if rotation.Phase != devopsv1.CARotationReissue {
+		cli, err := clusterClient(c)
+		if err != nil {
+			return err
+		}
+		hash, err := trustHash(c)
+		if err != nil {
+			return err
+		}
+		err = RefreshServiceAccountTokens(ctx, cli, c.ClusterCredential.CACert, hash)
+		if err != nil {
+			return err
+		}
+	}
+
+	switch rotation.Phase {
+	case devopsv1.CARotationTrustBoth:
+		return kubeadm.IssueWithNewCAs(cfg, c, isHosted)
+	case devopsv1.CARotationReissue:
+		return kubeadm.DropOldCAs(cfg, c, isHosted)
+	default:
+		klog.Infof("cluster: %s rotate certificate authorities done", c.Name)
+		c.ClusterCredential.CARotation = nil
+		return nil
+	}
+}
+
+// RefreshServiceAccountTokens writes the bundle as the ca.crt of the service account token
+// secrets in place, so the tokens used out of the cluster stay valid and the kubelets update
+// the mounted secrets of every pod, and rolls the Deployments, the StatefulSets and the
+// DaemonSets not restarted with the hash yet, so their pods load the new ca.crt.
+func RefreshServiceAccountTokens(ctx context.Context, cli client.Client, caBundle []byte, hash string) error {
+	secrets := &corev1.SecretList{}
+	err := cli.List(ctx, secrets)
+	if err != nil {
+		return errors.Wrap(err, "list secrets")
+	}
+	updated := 0
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if secret.Type != corev1.SecretTypeServiceAccountToken ||
+			bytes.Equal(secret.Data[corev1.ServiceAccountRootCAKey], caBundle) {
+			continue
+		}
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[corev1.ServiceAccountRootCAKey] = caBundle
+		err = cli.Update(ctx, secret)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "update secret %s/%s", secret.Namespace, secret.Name)
+		}
+		updated++
+	}
+	klog.Infof("update ca.crt of %d service account token secrets", updated)
+
+	deploys := &appsv1.DeploymentList{}
+	err = cli.List(ctx, deploys)
+	if err != nil {
+		return errors.Wrap(err, "list deployments")
+	}
+	for i := range deploys.Items {
+		deploy := &deploys.Items[i]
+		if !setTrustHash(&deploy.Spec.Template, hash) {
+			continue
+		}
+		err = cli.Update(ctx, deploy)
+		if err != nil {
+			return errors.Wrapf(err, "update deployment %s/%s", deploy.Namespace, deploy.Name)
+		}
+	}
+
+	stss := &appsv1.StatefulSetList{}
+	err = cli.List(ctx, stss)
+	if err != nil {
+		return errors.Wrap(err, "list statefulsets")
+	}
+	for i := range stss.Items {
+		sts := &stss.Items[i]
+		if !setTrustHash(&sts.Spec.Template, hash) {
+			continue
+		}
+		if sts.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+			klog.Warningf("statefulset %s/%s updates on delete, restart its pods to load the new ca.crt", sts.Namespace, sts.Name)
+		}
+		err = cli.Update(ctx, sts)
+		if err != nil {
+			return errors.Wrapf(err, "update statefulset %s/%s", sts.Namespace, sts.Name)
+		}
+	}
+
+	dss := &appsv1.DaemonSetList{}
+	err = cli.List(ctx, dss)
+	if err != nil {
+		return errors.Wrap(err, "list daemonsets")
+	}
+	for i := range dss.Items {
+		ds := &dss.Items[i]
+		if !setTrustHash(&ds.Spec.Template, hash) {
+			continue
+		}
+		if ds.Spec.UpdateStrategy.Type == appsv1.OnDeleteDaemonSetStrategyType {
+			klog.Warningf("daemonset %s/%s updates on delete, restart its pods to load the new ca.crt", ds.Namespace, ds.Name)
+		}
+		err = cli.Update(ctx, ds)
+		if err != nil {
+			return errors.Wrapf(err, "update daemonset %s/%s", ds.Namespace, ds.Name)
+		}
+	}
+	return nil
+}
+
+// waitWorkloads waits the workloads restarted with the hash to be rolled.
+func waitWorkloads(ctx context.Context, cli client.Client, hash string) error {
+	var pending []string
+	err := wait.PollImmediate(rolloutInterval, rolloutTimeout, func() (bool, error) {
+		var err error
+		pending, err = pendingWorkloads(ctx, cli, hash)
+		if err != nil {
+			klog.Warningf("list workloads err: %v", err)
+			return false, nil
+		}
+		return len(pending) == 0, nil
+	})
+	if err != nil {
+		return fmt.Errorf("workloads are not rolled with the new certificate authorities: %v", pending)
+	}
+	return nil
+}
+
+// pendingWorkloads returns the workloads restarted with the hash but not rolled yet, the
+// ones updating on delete are never rolled by their controller and not waited.
+func pendingWorkloads(ctx context.Context, cli client.Client, hash string) ([]string, error) {
+	var pending []string
+	deploys := &appsv1.DeploymentList{}
+	err := cli.List(ctx, deploys)
+	if err != nil {
+		return nil, err
+	}
+	for _, deploy := range deploys.Items {
+		if deploy.Spec.Template.Annotations[trustAnnotation] != hash {
+			continue
+		}
+		replicas := int32(1)
+		if deploy.Spec.Replicas != nil {
+			replicas = *deploy.Spec.Replicas
+		}
+		if deploy.Status.ObservedGeneration < deploy.Generation ||
+			deploy.Status.UpdatedReplicas != replicas || deploy.Status.Replicas != replicas {
+			pending = append(pending, "deployment "+deploy.Namespace+"/"+deploy.Name)
+		}
+	}
+
+	stss := &appsv1.StatefulSetList{}
+	err = cli.List(ctx, stss)
+	if err != nil {
+		return nil, err
+	}
+	for _, sts := range stss.Items {
+		if sts.Spec.Template.Annotations[trustAnnotation] != hash ||
+			sts.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+			continue
+		}
+		replicas := int32(1)
+		if sts.Spec.Replicas != nil {
+			replicas = *sts.Spec.Replicas
+		}
+		if sts.Status.ObservedGeneration < sts.Generation || sts.Status.UpdatedReplicas != replicas ||
+			sts.Status.CurrentRevision != sts.Status.UpdateRevision {
+			pending = append(pending, "statefulset "+sts.Namespace+"/"+sts.Name)
+		}
+	}
+
+	dss := &appsv1.DaemonSetList{}
+	err = cli.List(ctx, dss)
+	if err != nil {
+		return nil, err
+	}
+	for _, ds := range dss.Items {
+		if ds.Spec.Template.Annotations[trustAnnotation] != hash ||
+			ds.Spec.UpdateStrategy.Type == appsv1.OnDeleteDaemonSetStrategyType {
+			continue
+		}
+		if ds.Status.ObservedGeneration < ds.Generation ||
+			ds.Status.UpdatedNumberScheduled != ds.Status.DesiredNumberScheduled {
+			pending = append(pending, "daemonset "+ds.Namespace+"/"+ds.Name)
+		}
+	}
+	return pending, nil
+}
+
+// setTrustHash sets the hash on the pod template, and reports whether it is changed.
+func setTrustHash(template *corev1.PodTemplateSpec, hash string) bool {
+	if template.Annotations[trustAnnotation] == hash {
+		return false
+	}
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[trustAnnotation] = hash
+	return true
+}
+
+// trustHash returns the hash of the certificate authorities of the bundle of the credential,
+// their order is ignored, so the hash is kept while the new ones move to the front.
+func trustHash(c *common.Cluster) (string, error) {
+	list, err := certutil.ParseCertsPEM(c.ClusterCredential.CACert)
+	if err != nil {
+		return "", errors.Wrap(err, "parse certificate authority")
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return bytes.Compare(list[i].Raw, list[j].Raw) < 0
+	})
+
+	h := sha256.New()
+	for _, cert := range list {
+		h.Write(cert.Raw)
+	}
+	return hex.EncodeToString(h.Sum(nil)[:8]), nil
+}
+
+func clusterClient(c *common.Cluster) (client.Client, error) {
+	clusterCtx, err := c.ClusterManager.Get(c.Name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get cluster %s client", c.Name)
+	}
+	return clusterCtx.Client, nil
+}
@@ -0,0 +1,77 @@
+package carotation
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRefreshServiceAccountTokens(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := appsv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	objs := []runtime.Object{
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "coredns-token-abcde"},
+			Type:       corev1.SecretTypeServiceAccountToken,
+			Data: map[string][]byte{
+				corev1.ServiceAccountTokenKey:  []byte("token"),
+				corev1.ServiceAccountRootCAKey: []byte("old"),
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "tls"},
+			Type:       corev1.SecretTypeTLS,
+		},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "coredns", Generation: 1}},
+		&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "kube-proxy", Generation: 1}},
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Namespace: "monitoring", Name: "prometheus", Generation: 1}},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "manual", Generation: 1},
+			Spec: appsv1.StatefulSetSpec{
+				UpdateStrategy: appsv1.StatefulSetUpdateStrategy{Type: appsv1.OnDeleteStatefulSetStrategyType},
+			},
+		},
+	}
+	cli := fake.NewFakeClientWithScheme(scheme, objs...)
+	ctx := context.TODO()
+
+	if err := RefreshServiceAccountTokens(ctx, cli, []byte("bundle"), "hash"); err != nil {
+		t.Fatal(err)
+	}
+	token := &corev1.Secret{}
+	if err := cli.Get(ctx, types.NamespacedName{Namespace: "kube-system", Name: "coredns-token-abcde"}, token); err != nil {
+		t.Fatalf("the service account token secret is deleted, err: %v", err)
+	}
+	if string(token.Data[corev1.ServiceAccountRootCAKey]) != "bundle" || string(token.Data[corev1.ServiceAccountTokenKey]) != "token" {
+		t.Errorf("the service account token secret is not updated in place: %v", token.Data)
+	}
+	if err := cli.Get(ctx, types.NamespacedName{Namespace: "kube-system", Name: "tls"}, &corev1.Secret{}); err != nil {
+		t.Errorf("the other secret is deleted, err: %v", err)
+	}
+
+	pending, err := pendingWorkloads(ctx, cli, "hash")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 3 {
+		t.Errorf("pendingWorkloads() = %v, want the restarted deployment, statefulset and daemonset", pending)
+	}
+	pending, err = pendingWorkloads(ctx, cli, "other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("pendingWorkloads() of other hash = %v, want none", pending)
+	}
+}
@@ -0,0 +1,132 @@
+package certs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/constants"
+	"github.com/gostship/kunkka/pkg/util/pkiutil"
+	"github.com/gostship/kunkka/pkg/util/ssh"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/klog"
+)
+
+// Inventory returns the certificates of the files of the source, the first certificate of
+// the pem files and the client certificates of the kubeconfig files. The other files, like
+// the private keys, are skipped.
+func Inventory(source string, files map[string][]byte) []devopsv1.CertificateStatus {
+	var result []devopsv1.CertificateStatus
+	for name, data := range files {
+		switch {
+		case strings.HasSuffix(name, ".crt"):
+			certs, err := certutil.ParseCertsPEM(data)
+			if err != nil {
+				klog.Warningf("source: %s parse cert %s err: %v", source, name, err)
+				continue
+			}
+			result = append(result, devopsv1.CertificateStatus{
+				Name:       name,
+				Source:     source,
+				CommonName: certs[0].Subject.CommonName,
+				IsCA:       certs[0].IsCA,
+				NotAfter:   metav1.NewTime(certs[0].NotAfter),
+			})
+		case strings.HasSuffix(name, ".conf"):
+			kcfg := &clientcmdapi.Config{}
+			if err := DecodeKubeConfigByte(data, kcfg); err != nil {
+				klog.Warningf("source: %s decode kubeconfig %s err: %v", source, name, err)
+				continue
+			}
+			for _, auth := range kcfg.AuthInfos {
+				if len(auth.ClientCertificateData) == 0 {
+					continue
+				}
+				certs, err := certutil.ParseCertsPEM(auth.ClientCertificateData)
+				if err != nil {
+					klog.Warningf("source: %s parse client cert of %s err: %v", source, name, err)
+					continue
+				}
+				result = append(result, devopsv1.CertificateStatus{
+					Name:       name,
+					Source:     source,
+					CommonName: certs[0].Subject.CommonName,
+					NotAfter:   metav1.NewTime(certs[0].NotAfter),
+				})
+			}
+		}
+	}
+
+	SortCertificates(result)
+	return result
+}
+
+// CredentialFiles returns the certificates and the kubeconfigs kept in the credential.
+func CredentialFiles(credential *devopsv1.ClusterCredential) map[string][]byte {
+	files := make(map[string][]byte, len(credential.CertsBinaryData)+len(credential.KubeData)+1)
+	for name, data := range credential.CertsBinaryData {
+		files[name] = data
+	}
+	for name, data := range credential.KubeData {
+		files[name] = []byte(data)
+	}
+	if data, ok := credential.ExtData[pkiutil.ExternalAdminKubeConfigFileName]; ok {
+		files[pkiutil.ExternalAdminKubeConfigFileName] = []byte(data)
+	}
+	return files
+}
+
+// NodeFiles reads the certificates and the kubeconfigs of the kubernetes dir on the node.
+func NodeFiles(s ssh.Interface) (map[string][]byte, error) {
+	cmd := fmt.Sprintf("find %s -maxdepth 3 -type f \\( -name '*.crt' -o -name '*.conf' \\)", constants.KubernetesDir)
+	out, err := s.CombinedOutput(cmd)
+	if err != nil {
+		return nil, errors.Wrapf(err, "node: %s list certs", s.HostIP())
+	}
+
+	files := make(map[string][]byte)
+	for _, name := range strings.Fields(string(out)) {
+		data, err := s.ReadFile(name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "node: %s read %s", s.HostIP(), name)
+		}
+		files[name] = data
+	}
+	return files, nil
+}
+
+// Expiring returns the leaf certificates and the certificate authorities expiring within
+// the duration.
+func Expiring(certs []devopsv1.CertificateStatus, within time.Duration, now time.Time) (leaf, ca []devopsv1.CertificateStatus) {
+	deadline := now.Add(within)
+	for _, cert := range certs {
+		if cert.NotAfter.Time.After(deadline) {
+			continue
+		}
+		if cert.IsCA {
+			ca = append(ca, cert)
+		} else {
+			leaf = append(leaf, cert)
+		}
+	}
+	return leaf, ca
+}
+
+// SortCertificates sorts the certificates by the expiration, then the source and the name.
+func SortCertificates(certs []devopsv1.CertificateStatus) {
+	sort.Slice(certs, func(i, j int) bool {
+		a, b := certs[i], certs[j]
+		if !a.NotAfter.Equal(&b.NotAfter) {
+			return a.NotAfter.Before(&b.NotAfter)
+		}
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		return a.Name < b.Name
+	})
+}
@@ -0,0 +1,92 @@
+package certs
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"testing"
+	"time"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/util/pkiutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/keyutil"
+)
+
+func TestInventory(t *testing.T) {
+	caCert, caKey, err := pkiutil.NewCertificateAuthority(&pkiutil.CertConfig{
+		Config:             certutil.Config{CommonName: "kubernetes"},
+		PublicKeyAlgorithm: x509.RSA,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := keyutil.MarshalPrivateKeyToPEM(caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _, err := pkiutil.NewCertAndKey(caCert, caKey, &pkiutil.CertConfig{
+		Config: certutil.Config{
+			CommonName:   "system:node:10.28.0.10",
+			Organization: []string{"system:nodes"},
+			Usages:       []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		},
+		PublicKeyAlgorithm: x509.RSA,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	kubelet := []byte(fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: demo
+  cluster:
+    server: https://10.28.0.10:6443
+users:
+- name: kubelet
+  user:
+    client-certificate-data: %s
+`, base64.StdEncoding.EncodeToString(pkiutil.EncodeCertPEM(cert))))
+
+	list := Inventory("10.28.0.10", map[string][]byte{
+		"/etc/kubernetes/pki/ca.crt":   pkiutil.EncodeCertPEM(caCert),
+		"/etc/kubernetes/pki/ca.key":   key,
+		"/etc/kubernetes/kubelet.conf": kubelet,
+		"/etc/kubernetes/broken.crt":   []byte("broken"),
+	})
+	if len(list) != 2 {
+		t.Fatalf("Inventory() = %d certs, want 2: %v", len(list), list)
+	}
+	byName := make(map[string]devopsv1.CertificateStatus, len(list))
+	for _, cert := range list {
+		byName[cert.Name] = cert
+	}
+	if got := byName["/etc/kubernetes/kubelet.conf"]; got.IsCA || got.CommonName != "system:node:10.28.0.10" {
+		t.Errorf("Inventory() kubelet.conf = %+v", got)
+	}
+	if got := byName["/etc/kubernetes/pki/ca.crt"]; !got.IsCA || got.Source != "10.28.0.10" || !got.NotAfter.Equal(&metav1.Time{Time: caCert.NotAfter}) {
+		t.Errorf("Inventory() ca.crt = %+v", got)
+	}
+}
+
+func TestExpiring(t *testing.T) {
+	now := time.Now()
+	expire := func(name string, isCA bool, after time.Duration) devopsv1.CertificateStatus {
+		return devopsv1.CertificateStatus{Name: name, IsCA: isCA, NotAfter: metav1.NewTime(now.Add(after))}
+	}
+	list := []devopsv1.CertificateStatus{
+		expire("apiserver.crt", false, 24*time.Hour),
+		expire("admin.conf", false, 365*24*time.Hour),
+		expire("ca.crt", true, 10*24*time.Hour),
+		expire("etcd/ca.crt", true, 3650*24*time.Hour),
+	}
+
+	leaf, ca := Expiring(list, 30*24*time.Hour, now)
+	if len(leaf) != 1 || leaf[0].Name != "apiserver.crt" {
+		t.Errorf("Expiring() leaf = %v", leaf)
+	}
+	if len(ca) != 1 || ca[0].Name != "ca.crt" {
+		t.Errorf("Expiring() ca = %v", ca)
+	}
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/gostship/kunkka/pkg/constants"
 	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/provider/phases/ha"
 	kubeconfigutil "github.com/gostship/kunkka/pkg/util/kubeconfig"
 	"github.com/gostship/kunkka/pkg/util/pkiutil"
 	"github.com/pkg/errors"
@@ -40,7 +41,10 @@ type tokenAuth struct {
 
 // kubeConfigSpec struct holds info required to build a KubeConfig object
 type kubeConfigSpec struct {
-	CACert         *x509.Certificate
+	CACert *x509.Certificate
+	// CAData is the certificate authority bundle the kubeconfig trusts, CACert is its first
+	// certificate issuing the client certificate
+	CAData         []byte
 	APIServer      string
 	ClientName     string
 	TokenAuth      *tokenAuth
@@ -96,6 +100,7 @@ func getKubeConfigSpecs(CAKey, CACert []byte, apiserver string, kubeletNodeAddr
 	var kubeConfigSpec = map[string]*kubeConfigSpec{
 		pkiutil.AdminKubeConfigFileName: {
 			CACert:     caCert,
+			CAData:     CACert,
 			APIServer:  apiserver,
 			ClientName: "kubernetes-admin",
 			ClientCertAuth: &clientCertAuth{
@@ -105,6 +110,7 @@ func getKubeConfigSpecs(CAKey, CACert []byte, apiserver string, kubeletNodeAddr
 		},
 		pkiutil.KubeletKubeConfigFileName: {
 			CACert:     caCert,
+			CAData:     CACert,
 			APIServer:  apiserver,
 			ClientName: fmt.Sprintf("%s%s", pkiutil.NodesUserPrefix, kubeletNodeAddr),
 			ClientCertAuth: &clientCertAuth{
@@ -114,6 +120,7 @@ func getKubeConfigSpecs(CAKey, CACert []byte, apiserver string, kubeletNodeAddr
 		},
 		pkiutil.ControllerManagerKubeConfigFileName: {
 			CACert:     caCert,
+			CAData:     CACert,
 			APIServer:  apiserver,
 			ClientName: pkiutil.ControllerManagerUser,
 			ClientCertAuth: &clientCertAuth{
@@ -122,6 +129,7 @@ func getKubeConfigSpecs(CAKey, CACert []byte, apiserver string, kubeletNodeAddr
 		},
 		pkiutil.SchedulerKubeConfigFileName: {
 			CACert:     caCert,
+			CAData:     CACert,
 			APIServer:  apiserver,
 			ClientName: pkiutil.SchedulerUser,
 			ClientCertAuth: &clientCertAuth{
@@ -143,7 +151,7 @@ func buildKubeConfigFromSpec(spec *kubeConfigSpec, clustername string) (*clientc
 			spec.APIServer,
 			clustername,
 			spec.ClientName,
-			pkiutil.EncodeCertPEM(spec.CACert),
+			spec.CAData,
 			spec.TokenAuth.Token,
 		), nil
 	}
@@ -170,7 +178,7 @@ func buildKubeConfigFromSpec(spec *kubeConfigSpec, clustername string) (*clientc
 		spec.APIServer,
 		clustername,
 		spec.ClientName,
-		pkiutil.EncodeCertPEM(spec.CACert),
+		spec.CAData,
 		encodedClientKey,
 		pkiutil.EncodeCertPEM(clientCert),
 	), nil
@@ -229,6 +237,13 @@ func BuildExternalApiserverEndpoint(c *common.Cluster) string {
 		}
 	}
 
+	if ha.Enabled(c.Cluster) {
+		port = fmt.Sprintf("%d", ha.Port(c.Cluster))
+		if vip == "" {
+			vip = c.Cluster.Spec.Features.HA.DKEHA.VIP
+		}
+	}
+
 	if vip == "" && len(c.Cluster.Spec.Machines) > 0 {
 		vip = c.Cluster.Spec.Machines[0].IP
 	}
@@ -4,13 +4,16 @@ import (
 	"fmt"
 	"os"
 
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/provider/phases/cri"
 	"github.com/gostship/kunkka/pkg/util/ssh"
 	"github.com/pkg/errors"
 	"k8s.io/klog"
 )
 
-func CleanNode(s ssh.Interface) error {
-	cmd := "kubeadm reset -f && rm -rf /var/lib/etcd /var/lib/kubelet /var/lib/dockershim /var/run/kubernetes /var/lib/cni /etc/kubernetes /etc/cni /root/.kube && ipvsadm --clear"
+func CleanNode(s ssh.Interface, runtime devopsv1.ContainerRuntimeType) error {
+	cmd := fmt.Sprintf("kubeadm reset -f --cri-socket=%s && rm -rf /var/lib/etcd /var/lib/kubelet /var/lib/dockershim /var/run/kubernetes /var/lib/cni /etc/kubernetes /etc/cni /root/.kube && ipvsadm --clear",
+		cri.Socket(runtime))
 	exit, err := s.ExecStream(cmd, os.Stdout, os.Stderr)
 	if err != nil {
 		klog.Errorf("cmd: %s exit: %q err: %+v", cmd, exit, err)
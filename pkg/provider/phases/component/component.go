@@ -1,12 +1,15 @@
 package component
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
 	"github.com/gostship/kunkka/pkg/constants"
 	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/provider/artifact"
+	"github.com/gostship/kunkka/pkg/provider/config"
 	"github.com/gostship/kunkka/pkg/util/ssh"
 	"k8s.io/klog"
 )
@@ -16,7 +19,7 @@ const (
 [Unit]
 Description=kubelet: The Kubernetes Node Agent
 Documentation=https://kubernetes.io/docs/
-
+%s
 [Service]
 User=root
 ExecStart=/usr/bin/kubelet
@@ -39,17 +42,53 @@ ExecStart=/usr/bin/kubelet $KUBELET_KUBECONFIG_ARGS $KUBELET_CONFIG_ARGS $KUBELE
 `
 )
 
-func Install(s ssh.Interface, c *common.Cluster) error {
+// binDirs returns the local dirs of the kubernetes binaries and the other binaries.
+func binDirs(c *common.Cluster) (string, string) {
 	// dir := "k8s/linuxbin/" // local debug config dir
-	var k8sDir string
-	var otherDir string
 	if dir := constants.GetAnnotationKey(c.Cluster.Annotations, constants.ClusterAnnoLocalDebugDir); len(dir) > 0 {
-		k8sDir = dir
-		otherDir = dir
-	} else {
-		k8sDir = fmt.Sprintf("/k8s-%s/bin/", c.Cluster.Spec.Version)
-		otherDir = "/k8s/bin/"
+		return dir, dir
+	}
+
+	return fmt.Sprintf("/k8s-%s/bin/", c.Cluster.Spec.Version), "/k8s/bin/"
+}
+
+// kubeletUnit returns the kubelet unit, the kubelet of containerd starts after it.
+func kubeletUnit(runtime devopsv1.ContainerRuntimeType) string {
+	after := ""
+	if runtime == devopsv1.ContainerRuntimeContainerd {
+		after = "Wants=containerd.service\nAfter=containerd.service\n"
 	}
+	return fmt.Sprintf(kubeletService, after)
+}
+
+// findArtifact returns the KubernetesArtifact of the cluster version, the binaries of
+// the local debug dir are always copied.
+func findArtifact(c *common.Cluster) (*devopsv1.KubernetesArtifact, error) {
+	if dir := constants.GetAnnotationKey(c.Cluster.Annotations, constants.ClusterAnnoLocalDebugDir); len(dir) > 0 {
+		return nil, nil
+	}
+
+	return artifact.Find(context.TODO(), c.Client, c.Cluster.Spec.Version)
+}
+
+// installArtifact downloads the files of the names in the artifact on the node, all the
+// files if names is empty.
+func installArtifact(s ssh.Interface, a *devopsv1.KubernetesArtifact, names []string, cfg *config.Config, runtime devopsv1.ContainerRuntimeType) error {
+	arch, err := artifact.Arch(s)
+	if err != nil {
+		return err
+	}
+
+	files, err := artifact.Resolve(a, names, arch, runtime, cfg.Artifact.URL)
+	if err != nil {
+		return err
+	}
+	return artifact.Install(s, files)
+}
+
+// copyBinaries copies the binaries of the cluster version from the operator to the node.
+func copyBinaries(s ssh.Interface, c *common.Cluster, runtime devopsv1.ContainerRuntimeType) error {
+	k8sDir, otherDir := binDirs(c)
 
 	var CopyList = []devopsv1.File{
 		{
@@ -69,6 +108,13 @@ func Install(s ssh.Interface, c *common.Cluster) error {
 			Dst: "/opt/cni.tgz",
 		},
 	}
+	// crictl replaces the docker cli on the containerd nodes
+	if runtime == devopsv1.ContainerRuntimeContainerd {
+		CopyList = append(CopyList, devopsv1.File{
+			Src: otherDir + "crictl",
+			Dst: "/usr/local/bin/crictl",
+		})
+	}
 
 	for _, ls := range CopyList {
 		//if ok, err := s.Exist(ls.Dst); err == nil && ok {
@@ -99,8 +145,28 @@ func Install(s ssh.Interface, c *common.Cluster) error {
 		klog.Errorf("node: %s copy %s success", s.HostIP(), ls.Dst)
 	}
 
+	return nil
+}
+
+// Install installs the kubernetes binaries and starts the kubelet on the node. The binaries
+// are downloaded by the node when there is a KubernetesArtifact of the cluster version,
+// otherwise they are copied from the operator.
+func Install(s ssh.Interface, c *common.Cluster, cfg *config.Config, runtime devopsv1.ContainerRuntimeType) error {
+	a, err := findArtifact(c)
+	if err != nil {
+		return err
+	}
+	if a != nil {
+		err = installArtifact(s, a, nil, cfg, runtime)
+	} else {
+		err = copyBinaries(s, c, runtime)
+	}
+	if err != nil {
+		return err
+	}
+
 	klog.Infof("node: %s start write %s ... ", s.HostIP(), constants.KubeletSystemdUnitFilePath)
-	err := s.WriteFile(strings.NewReader(kubeletService), constants.KubeletSystemdUnitFilePath)
+	err = s.WriteFile(strings.NewReader(kubeletUnit(runtime)), constants.KubeletSystemdUnitFilePath)
 	if err != nil {
 		return err
 	}
@@ -132,3 +198,72 @@ func Install(s ssh.Interface, c *common.Cluster) error {
 
 	return nil
 }
+
+// UpgradeBinaries installs the kubernetes binaries of the cluster spec version on the node,
+// the kubelet is not restarted.
+func UpgradeBinaries(s ssh.Interface, c *common.Cluster, cfg *config.Config) error {
+	a, err := findArtifact(c)
+	if err != nil {
+		return err
+	}
+	if a != nil {
+		// the binaries are replaced after they are verified, so the running ones are kept on failure
+		return installArtifact(s, a, []string{"kubectl", "kubeadm", "kubelet"}, cfg, c.Spec.GetContainerRuntime())
+	}
+
+	k8sDir, _ := binDirs(c)
+	var CopyList = []devopsv1.File{
+		{
+			Src: k8sDir + "kubectl",
+			Dst: "/usr/local/bin/kubectl",
+		},
+		{
+			Src: k8sDir + "kubeadm",
+			Dst: "/usr/local/bin/kubeadm",
+		},
+		{
+			Src: k8sDir + "kubelet",
+			Dst: "/usr/bin/kubelet",
+		},
+	}
+
+	for _, ls := range CopyList {
+		// the running kubelet binary can't be overwritten in place
+		tmp := ls.Dst + ".new"
+		err := s.CopyFile(ls.Src, tmp)
+		if err != nil {
+			klog.Errorf("node: %s copy %s err: %v", s.HostIP(), ls.Src, err)
+			return err
+		}
+
+		_, _, _, err = s.Execf("chmod a+x %s && mv -f %s %s", tmp, tmp, ls.Dst)
+		if err != nil {
+			return err
+		}
+		klog.Infof("node: %s upgrade %s success", s.HostIP(), ls.Dst)
+	}
+
+	return nil
+}
+
+// RestartKubelet restarts the kubelet service on the node.
+func RestartKubelet(s ssh.Interface) error {
+	unitName := fmt.Sprintf("%s.service", "kubelet")
+	cmd := fmt.Sprintf("systemctl daemon-reload && systemctl restart %s", unitName)
+	if _, stderr, exit, err := s.Execf(cmd); err != nil || exit != 0 {
+		return fmt.Errorf("exec %q failed:exit %d:stderr %s:error %v", cmd, exit, stderr, err)
+	}
+
+	return nil
+}
+
+// Reboot reboots the node in the background, so the command returns before the ssh
+// connection is closed by the reboot.
+func Reboot(s ssh.Interface) error {
+	cmd := "nohup sh -c 'sleep 2 && systemctl reboot' >/dev/null 2>&1 &"
+	if _, stderr, exit, err := s.Execf(cmd); err != nil || exit != 0 {
+		return fmt.Errorf("exec %q failed:exit %d:stderr %s:error %v", cmd, exit, stderr, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package cri
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/constants"
+)
+
+const (
+	// containerNameLabel is the label of the kubernetes container name of the containers.
+	containerNameLabel = "io.kubernetes.container.name"
+)
+
+// Socket returns the CRI socket of the runtime.
+func Socket(runtime devopsv1.ContainerRuntimeType) string {
+	if runtime == devopsv1.ContainerRuntimeContainerd {
+		return constants.ContainerdCRISocket
+	}
+	return constants.DefaultDockerCRISocket
+}
+
+func crictl() string {
+	return "crictl --runtime-endpoint unix://" + constants.ContainerdCRISocket
+}
+
+// PsCmd returns the command printing the ids of the running containers of the kubernetes
+// container name, e.g. kube-apiserver.
+func PsCmd(runtime devopsv1.ContainerRuntimeType, name string) string {
+	if runtime == devopsv1.ContainerRuntimeContainerd {
+		return fmt.Sprintf("%s ps -q --state running --label %s=%s", crictl(), containerNameLabel, name)
+	}
+	return fmt.Sprintf("docker ps -q -f 'label=%s=%s'", containerNameLabel, name)
+}
+
+// RemoveCmd returns the command removing the running containers of the kubernetes container
+// name, the static pod containers are started again by kubelet.
+func RemoveCmd(runtime devopsv1.ContainerRuntimeType, name string) string {
+	if runtime == devopsv1.ContainerRuntimeContainerd {
+		return fmt.Sprintf("%s rm -f $(%s)", crictl(), PsCmd(runtime, name))
+	}
+	return fmt.Sprintf("docker rm -f $(%s)", PsCmd(runtime, name))
+}
+
+// RunCmd returns the command running the entrypoint of the image once with the host network,
+// the dirs are mounted at the same paths. The arguments of the entrypoint are appended to it.
+func RunCmd(runtime devopsv1.ContainerRuntimeType, image string, env []string, dirs []string, entrypoint string) string {
+	var args []string
+	if runtime == devopsv1.ContainerRuntimeContainerd {
+		// the images pulled by kubelet are in the k8s.io namespace
+		args = append(args, "ctr -n k8s.io run --rm --net-host")
+		for _, e := range env {
+			args = append(args, "--env "+e)
+		}
+		for _, dir := range dirs {
+			args = append(args, fmt.Sprintf("--mount type=bind,src=%s,dst=%s,options=rbind:rw", dir, dir))
+		}
+		args = append(args, image, fmt.Sprintf("%s-%d", entrypoint, time.Now().UnixNano()), entrypoint)
+		return strings.Join(args, " ")
+	}
+
+	args = append(args, "docker run --rm --net host")
+	for _, e := range env {
+		args = append(args, "-e "+e)
+	}
+	for _, dir := range dirs {
+		args = append(args, fmt.Sprintf("-v %s:%s", dir, dir))
+	}
+	args = append(args, "--entrypoint "+entrypoint, image)
+	return strings.Join(args, " ")
+}
@@ -0,0 +1,46 @@
+package cri
+
+import (
+	"testing"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+)
+
+func TestRunCmd(t *testing.T) {
+	tests := []struct {
+		name    string
+		runtime devopsv1.ContainerRuntimeType
+		want    string
+	}{
+		{
+			name:    "docker",
+			runtime: devopsv1.ContainerRuntimeDocker,
+			want:    "docker run --rm --net host -e A=1 -v /data:/data --entrypoint etcdctl etcd:3.4",
+		},
+		{
+			name:    "default",
+			runtime: "",
+			want:    "docker run --rm --net host -e A=1 -v /data:/data --entrypoint etcdctl etcd:3.4",
+		},
+		{
+			name:    "containerd",
+			runtime: devopsv1.ContainerRuntimeContainerd,
+			want:    "ctr -n k8s.io run --rm --net-host --env A=1 --mount type=bind,src=/data,dst=/data,options=rbind:rw etcd:3.4 etcdctl-",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RunCmd(tt.runtime, "etcd:3.4", []string{"A=1"}, []string{"/data"}, "etcdctl")
+			if tt.runtime == devopsv1.ContainerRuntimeContainerd {
+				// the container id has the time in it
+				if len(got) <= len(tt.want) || got[:len(tt.want)] != tt.want {
+					t.Errorf("RunCmd() = %q, want prefix %q", got, tt.want)
+				}
+				return
+			}
+			if got != tt.want {
+				t.Errorf("RunCmd() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
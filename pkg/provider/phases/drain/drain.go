@@ -0,0 +1,130 @@
+package drain
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
+	kubedrain "k8s.io/kubectl/pkg/drain"
+)
+
+// Options is how the pods of a node are drained, it maps onto the kubectl drain helper.
+type Options struct {
+	// Timeout bounds a drain, the pods left are reported in the error, 0 means no limit.
+	Timeout time.Duration
+	// Force drains the pods not managed by a controller, they are lost.
+	Force bool
+	// DeleteLocalData drains the pods with emptyDir volumes, their data is lost.
+	DeleteLocalData bool
+}
+
+// Cordon marks the node unschedulable, it is a no-op if the node is gone.
+func Cordon(ctx context.Context, cli kubernetes.Interface, name string) error {
+	return cordonOrUncordon(ctx, cli, name, true)
+}
+
+// Uncordon marks the node schedulable again, it is a no-op if the node is gone.
+func Uncordon(ctx context.Context, cli kubernetes.Interface, name string) error {
+	return cordonOrUncordon(ctx, cli, name, false)
+}
+
+func cordonOrUncordon(ctx context.Context, cli kubernetes.Interface, name string, desired bool) error {
+	node, err := cli.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "get node %s", name)
+	}
+	if node.Spec.Unschedulable == desired {
+		return nil
+	}
+
+	err = kubedrain.RunCordonOrUncordon(newHelper(ctx, cli, name, Options{}), node, desired)
+	if err != nil {
+		return errors.Wrapf(err, "set node %s unschedulable %t", name, desired)
+	}
+	klog.Infof("node: %s is set unschedulable %t", name, desired)
+	return nil
+}
+
+// Drain evicts the pods of the node through the eviction API, so the PodDisruptionBudgets
+// are respected, and waits them gone. The pods of the DaemonSets and the mirror pods are
+// left on the node. It fails once the timeout is reached or a pod may not be drained
+// without Force or DeleteLocalData.
+func Drain(ctx context.Context, cli kubernetes.Interface, name string, opts Options) error {
+	err := kubedrain.RunNodeDrain(newHelper(ctx, cli, name, opts), name)
+	if err != nil {
+		return errors.Wrapf(err, "drain node %s", name)
+	}
+	return nil
+}
+
+// Force deletes the pods left on the node with no grace period regardless of their disruption
+// budgets, including the ones already terminating on a dead kubelet.
+func Force(ctx context.Context, cli kubernetes.Interface, name string, opts Options) error {
+	helper := newHelper(ctx, cli, name, opts)
+	helper.Force = true
+	helper.DisableEviction = true
+	helper.GracePeriodSeconds = 0
+	err := kubedrain.RunNodeDrain(helper, name)
+	if err != nil {
+		return errors.Wrapf(err, "force drain node %s", name)
+	}
+	return nil
+}
+
+// CordonAndDrain cordons the node and drains its pods until the timeout, the pods left are
+// deleted by Force if the drain is forced, otherwise the node stays cordoned.
+func CordonAndDrain(ctx context.Context, cli kubernetes.Interface, name string, opts Options) error {
+	err := Cordon(ctx, cli, name)
+	if err != nil {
+		return err
+	}
+
+	err = Drain(ctx, cli, name, opts)
+	if err != nil && opts.Force {
+		klog.Warningf("node: %s drain failed, force delete the pods left: %v", name, err)
+		return Force(ctx, cli, name, opts)
+	}
+	return err
+}
+
+func newHelper(ctx context.Context, cli kubernetes.Interface, name string, opts Options) *kubedrain.Helper {
+	return &kubedrain.Helper{
+		Ctx:                 ctx,
+		Client:              cli,
+		Force:               opts.Force,
+		GracePeriodSeconds:  -1,
+		IgnoreAllDaemonSets: true,
+		Timeout:             opts.Timeout,
+		DeleteLocalData:     opts.DeleteLocalData,
+		Out:                 &logWriter{node: name},
+		ErrOut:              &logWriter{node: name, warning: true},
+		OnPodDeletedOrEvicted: func(pod *corev1.Pod, usingEviction bool) {
+			klog.V(4).Infof("node: %s pod %s/%s is drained, eviction %t", name, pod.Namespace, pod.Name, usingEviction)
+		},
+	}
+}
+
+// logWriter writes the output of the drain helper to the log.
+type logWriter struct {
+	node    string
+	warning bool
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	msg := strings.TrimSpace(string(p))
+	if w.warning {
+		klog.Warningf("node: %s %s", w.node, msg)
+	} else {
+		klog.V(4).Infof("node: %s %s", w.node, msg)
+	}
+	return len(p), nil
+}
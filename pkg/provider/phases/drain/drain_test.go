@@ -0,0 +1,184 @@
+package drain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	kubedrain "k8s.io/kubectl/pkg/drain"
+)
+
+func newPod(name, node string, mutate func(*corev1.Pod)) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(&metav1.ObjectMeta{Name: "web"}, schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "ReplicaSet"}),
+			},
+		},
+		Spec:   corev1.PodSpec{NodeName: node},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	if mutate != nil {
+		mutate(pod)
+	}
+	return pod
+}
+
+// newClient returns a client serving the eviction API, the evicted pods are deleted at once.
+func newClient(objs ...runtime.Object) (*fake.Clientset, *[]string) {
+	cli := fake.NewSimpleClientset(objs...)
+	cli.Resources = []*metav1.APIResourceList{
+		{GroupVersion: "policy/v1beta1"},
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{{Name: kubedrain.EvictionSubresource, Kind: kubedrain.EvictionKind}},
+		},
+	}
+	// the tracker ignores the field selectors, the pods of the other nodes are dropped here
+	cli.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		selector := action.(k8stesting.ListAction).GetListRestrictions().Fields
+		obj, err := cli.Tracker().List(schema.GroupVersionResource{Version: "v1", Resource: "pods"},
+			schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, action.GetNamespace())
+		if err != nil {
+			return true, nil, err
+		}
+		list := obj.(*corev1.PodList)
+		pods := list.Items[:0]
+		for _, pod := range list.Items {
+			if selector.Matches(fields.Set{"spec.nodeName": pod.Spec.NodeName}) {
+				pods = append(pods, pod)
+			}
+		}
+		list.Items = pods
+		return true, list, nil
+	})
+
+	evicted := &[]string{}
+	cli.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		name := action.(k8stesting.CreateAction).GetObject().(metav1.Object).GetName()
+		*evicted = append(*evicted, name)
+		return true, nil, cli.Tracker().Delete(schema.GroupVersionResource{Version: "v1", Resource: "pods"}, action.GetNamespace(), name)
+	})
+	return cli, evicted
+}
+
+func TestCordon(t *testing.T) {
+	cli := fake.NewSimpleClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}})
+	if err := Cordon(context.Background(), cli, "node1"); err != nil {
+		t.Fatalf("Cordon() error = %v", err)
+	}
+	node, _ := cli.CoreV1().Nodes().Get(context.Background(), "node1", metav1.GetOptions{})
+	if !node.Spec.Unschedulable {
+		t.Errorf("Cordon() node is schedulable")
+	}
+	if err := Cordon(context.Background(), cli, "gone"); err != nil {
+		t.Errorf("Cordon() of a missing node error = %v", err)
+	}
+
+	if err := Uncordon(context.Background(), cli, "node1"); err != nil {
+		t.Fatalf("Uncordon() error = %v", err)
+	}
+	node, _ = cli.CoreV1().Nodes().Get(context.Background(), "node1", metav1.GetOptions{})
+	if node.Spec.Unschedulable {
+		t.Errorf("Uncordon() node is unschedulable")
+	}
+}
+
+func TestDrain(t *testing.T) {
+	localData := func(p *corev1.Pod) {
+		p.Spec.Volumes = []corev1.Volume{{Name: "cache", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}}
+	}
+	unreplicated := func(p *corev1.Pod) { p.OwnerReferences = nil }
+
+	tests := []struct {
+		name    string
+		pods    []runtime.Object
+		opts    Options
+		evicted []string
+		wantErr bool
+	}{
+		{
+			name: "replicated pods",
+			pods: []runtime.Object{
+				newPod("web", "node1", nil),
+				newPod("other", "node2", nil),
+				newPod("static", "node1", func(p *corev1.Pod) {
+					p.Annotations = map[string]string{corev1.MirrorPodAnnotationKey: "hash"}
+				}),
+			},
+			evicted: []string{"web"},
+		},
+		{
+			name:    "local data",
+			pods:    []runtime.Object{newPod("cache", "node1", localData)},
+			wantErr: true,
+		},
+		{
+			name:    "local data deleted",
+			pods:    []runtime.Object{newPod("cache", "node1", localData)},
+			opts:    Options{DeleteLocalData: true},
+			evicted: []string{"cache"},
+		},
+		{
+			name:    "unreplicated",
+			pods:    []runtime.Object{newPod("bare", "node1", unreplicated)},
+			wantErr: true,
+		},
+		{
+			name:    "unreplicated forced",
+			pods:    []runtime.Object{newPod("bare", "node1", unreplicated)},
+			opts:    Options{Force: true},
+			evicted: []string{"bare"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli, evicted := newClient(tt.pods...)
+			tt.opts.Timeout = 10 * time.Second
+			err := Drain(context.Background(), cli, "node1", tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Drain() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(*evicted) != len(tt.evicted) || (len(tt.evicted) > 0 && (*evicted)[0] != tt.evicted[0]) {
+				t.Errorf("Drain() evicted = %v, want %v", *evicted, tt.evicted)
+			}
+		})
+	}
+}
+
+func TestForce(t *testing.T) {
+	deleting := metav1.NewTime(time.Now().Add(-time.Hour))
+	cli, evicted := newClient(
+		newPod("web", "node1", nil),
+		newPod("stuck", "node1", func(p *corev1.Pod) { p.DeletionTimestamp = &deleting }),
+		newPod("other", "node2", nil),
+	)
+
+	if err := Force(context.Background(), cli, "node1", Options{Timeout: 10 * time.Second}); err != nil {
+		t.Fatalf("Force() error = %v", err)
+	}
+	if len(*evicted) != 0 {
+		t.Errorf("Force() evicted = %v, want the pods deleted", *evicted)
+	}
+	for _, name := range []string{"web", "stuck"} {
+		if _, err := cli.CoreV1().Pods("default").Get(context.Background(), name, metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+			t.Errorf("Force() pod %s is kept, err = %v", name, err)
+		}
+	}
+	if _, err := cli.CoreV1().Pods("default").Get(context.Background(), "other", metav1.GetOptions{}); err != nil {
+		t.Errorf("Force() pod of another node is deleted, err = %v", err)
+	}
+}
@@ -0,0 +1,417 @@
+package ha
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"path"
+	"strings"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/constants"
+	"github.com/gostship/kunkka/pkg/provider/config"
+	"github.com/gostship/kunkka/pkg/util/ssh"
+	"github.com/gostship/kunkka/pkg/util/template"
+	"github.com/pkg/errors"
+	"k8s.io/klog"
+)
+
+const (
+	// KeepalivedVersion is the keepalived version of the static pod.
+	KeepalivedVersion = "2.0.17"
+	// HAProxyVersion is the haproxy version of the static pod.
+	HAProxyVersion = "2.1.4"
+
+	// healthzPort is the port of the haproxy liveness on the loopback address.
+	healthzPort = 8404
+
+	keepalivedTemplate = `global_defs {
+  router_id {{ .RouterID }}
+  script_user root
+  enable_script_security
+}
+
+vrrp_script check_apiserver {
+  script "{{ .CheckScript }}"
+  interval 3
+  timeout 5
+  fall 3
+  rise 2
+  weight -{{ .Weight }}
+}
+
+vrrp_instance {{ .Instance }} {
+  state BACKUP
+  interface {{ .Interface }}
+  virtual_router_id {{ .VRID }}
+  priority {{ .Priority }}
+  advert_int 1
+  authentication {
+    auth_type PASS
+    auth_pass {{ .AuthPass }}
+  }
+  unicast_src_ip {{ .IP }}
+{{- if .Peers }}
+  unicast_peer {
+{{- range .Peers }}
+    {{ . }}
+{{- end }}
+  }
+{{- end }}
+  virtual_ipaddress {
+    {{ .VIP }}
+  }
+  track_script {
+    check_apiserver
+  }
+}
+`
+
+	checkScriptTemplate = `#!/bin/sh
+# the vip is released once haproxy of the master can't reach an apiserver
+errorExit() {
+  echo "*** $*" 1>&2
+  exit 1
+}
+
+curl --silent --max-time 2 --insecure https://localhost:{{ .Port }}/healthz -o /dev/null || errorExit "Error GET https://localhost:{{ .Port }}/healthz"
+if ip addr | grep -q "inet {{ .VIP }}/"; then
+  curl --silent --max-time 2 --insecure https://{{ .VIP }}:{{ .Port }}/healthz -o /dev/null || errorExit "Error GET https://{{ .VIP }}:{{ .Port }}/healthz"
+fi
+`
+
+	haproxyTemplate = `global
+  log stdout format raw local0 info
+  maxconn 20000
+
+defaults
+  mode tcp
+  log global
+  option tcplog
+  option dontlognull
+  retries 3
+  timeout connect 5s
+  timeout client 1h
+  timeout server 1h
+
+frontend healthz
+  bind 127.0.0.1:{{ .HealthzPort }}
+  mode http
+  monitor-uri /healthz
+
+frontend apiserver
+  bind *:{{ .Port }}
+  default_backend apiserver
+
+backend apiserver
+  option httpchk GET /healthz
+  http-check expect status 200
+  balance roundrobin
+  default-server check check-ssl verify none inter 3s fall 3 rise 2
+{{- range .Masters }}
+  server {{ . }} {{ . }}:{{ $.APIServerPort }}
+{{- end }}
+`
+
+	keepalivedManifestTemplate = `apiVersion: v1
+kind: Pod
+metadata:
+  name: keepalived
+  namespace: kube-system
+  labels:
+    component: keepalived
+    tier: control-plane
+  annotations:
+    devops.gostship.io/config-hash: {{ .ConfigHash }}
+spec:
+  hostNetwork: true
+  priorityClassName: system-node-critical
+  containers:
+  - name: keepalived
+    image: {{ .Image }}
+    command:
+    - keepalived
+    - --dont-fork
+    - --log-console
+    - --use-file={{ .ConfigFile }}
+    securityContext:
+      capabilities:
+        add:
+        - NET_ADMIN
+        - NET_BROADCAST
+        - NET_RAW
+    volumeMounts:
+    - name: config
+      mountPath: {{ .ConfigDir }}
+      readOnly: true
+  volumes:
+  - name: config
+    hostPath:
+      path: {{ .ConfigDir }}
+      type: DirectoryOrCreate
+`
+
+	haproxyManifestTemplate = `apiVersion: v1
+kind: Pod
+metadata:
+  name: haproxy
+  namespace: kube-system
+  labels:
+    component: haproxy
+    tier: control-plane
+  annotations:
+    devops.gostship.io/config-hash: {{ .ConfigHash }}
+spec:
+  hostNetwork: true
+  priorityClassName: system-node-critical
+  containers:
+  - name: haproxy
+    image: {{ .Image }}
+    command:
+    - haproxy
+    - -f
+    - {{ .ConfigFile }}
+    livenessProbe:
+      failureThreshold: 8
+      httpGet:
+        host: 127.0.0.1
+        path: /healthz
+        port: {{ .HealthzPort }}
+        scheme: HTTP
+    volumeMounts:
+    - name: config
+      mountPath: {{ .ConfigDir }}
+      readOnly: true
+  volumes:
+  - name: config
+    hostPath:
+      path: {{ .ConfigDir }}
+      type: DirectoryOrCreate
+`
+)
+
+type keepalivedOption struct {
+	RouterID    string
+	Instance    string
+	CheckScript string
+	Weight      int
+	Interface   string
+	VRID        int32
+	Priority    int
+	AuthPass    string
+	IP          string
+	Peers       []string
+	VIP         string
+}
+
+type haproxyOption struct {
+	Port          int32
+	VIP           string
+	HealthzPort   int
+	APIServerPort int
+	Masters       []string
+}
+
+type manifestOption struct {
+	Image       string
+	ConfigHash  string
+	ConfigFile  string
+	ConfigDir   string
+	HealthzPort int
+}
+
+// Enabled reports whether the masters of the cluster hold the vip of DKEHA.
+func Enabled(c *devopsv1.Cluster) bool {
+	return c.Spec.Features.HA != nil && c.Spec.Features.HA.DKEHA != nil && c.Spec.Features.HA.DKEHA.VIP != ""
+}
+
+// Port returns the port of haproxy in front of the apiservers.
+func Port(c *devopsv1.Cluster) int32 {
+	if c.Spec.Features.HA.DKEHA.VPort != 0 {
+		return c.Spec.Features.HA.DKEHA.VPort
+	}
+	return constants.DKEHAPort
+}
+
+// VRID returns the virtual router id of keepalived, the last byte of the vip by default.
+func VRID(c *devopsv1.Cluster) (int32, error) {
+	dke := c.Spec.Features.HA.DKEHA
+	if dke.VRID != 0 {
+		return dke.VRID, nil
+	}
+	ip := net.ParseIP(dke.VIP).To4()
+	if ip == nil {
+		return 0, fmt.Errorf("cluster: %s vip %q is not an ipv4 address", c.Name, dke.VIP)
+	}
+	if ip[3] == 0 {
+		return 1, nil
+	}
+	return int32(ip[3]), nil
+}
+
+// Interface returns the network interface of the vip on the master, the interface of the
+// master ip, or the network device of the cluster if it is not found.
+func Interface(s ssh.Interface, c *devopsv1.Cluster) string {
+	if iface := ssh.GetNetworkInterface(s, s.HostIP()); iface != "" {
+		return iface
+	}
+	if c.Spec.NetworkDevice != "" {
+		return c.Spec.NetworkDevice
+	}
+	return "eth0"
+}
+
+// BuildFiles returns the keepalived and haproxy configs and the static pod manifests of the
+// master, keyed by the path on the master. The masters of the cluster are the haproxy
+// backends and the keepalived peers, so the files of every master are rebuilt once a
+// master is added or removed. The earlier masters have the higher priority of the vip.
+func BuildFiles(cfg *config.Config, c *devopsv1.Cluster, ip, iface string) (map[string][]byte, error) {
+	index := -1
+	masters := make([]string, 0, len(c.Spec.Machines))
+	for i, m := range c.Spec.Machines {
+		if m.IP == ip {
+			index = i
+		}
+		masters = append(masters, m.IP)
+	}
+	if index < 0 {
+		return nil, fmt.Errorf("cluster: %s has no master %s", c.Name, ip)
+	}
+
+	vrid, err := VRID(c)
+	if err != nil {
+		return nil, err
+	}
+	vip := c.Spec.Features.HA.DKEHA.VIP
+
+	peers := make([]string, 0, len(masters))
+	for _, m := range masters {
+		if m != ip {
+			peers = append(peers, m)
+		}
+	}
+	keepalivedCfg, err := template.ParseString(keepalivedTemplate, &keepalivedOption{
+		RouterID:    strings.ReplaceAll(ip, ".", "_"),
+		Instance:    fmt.Sprintf("VI_%d", vrid),
+		CheckScript: constants.KeepalivedCheckScriptFile,
+		// a failed master is below all the healthy ones
+		Weight:    len(masters) + 1,
+		Interface: iface,
+		VRID:      vrid,
+		Priority:  100 + len(masters) - index,
+		AuthPass:  authPass(c),
+		IP:        ip,
+		Peers:     peers,
+		VIP:       vip,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "keepalived config")
+	}
+
+	haOpt := &haproxyOption{
+		Port:          Port(c),
+		VIP:           vip,
+		HealthzPort:   healthzPort,
+		APIServerPort: constants.KubeAPIServerPort,
+		Masters:       masters,
+	}
+	checkScript, err := template.ParseString(checkScriptTemplate, haOpt)
+	if err != nil {
+		return nil, errors.Wrap(err, "keepalived check script")
+	}
+	haproxyCfg, err := template.ParseString(haproxyTemplate, haOpt)
+	if err != nil {
+		return nil, errors.Wrap(err, "haproxy config")
+	}
+
+	keepalivedPod, err := template.ParseString(keepalivedManifestTemplate, &manifestOption{
+		Image:      cfg.ImageFullName("keepalived", KeepalivedVersion),
+		ConfigHash: hash(keepalivedCfg, checkScript),
+		ConfigFile: constants.KeepalivedConfigFile,
+		ConfigDir:  path.Dir(constants.KeepalivedConfigFile),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "keepalived manifest")
+	}
+	haproxyPod, err := template.ParseString(haproxyManifestTemplate, &manifestOption{
+		Image:       cfg.ImageFullName("haproxy", HAProxyVersion),
+		ConfigHash:  hash(haproxyCfg),
+		ConfigFile:  constants.HAProxyConfigFile,
+		ConfigDir:   path.Dir(constants.HAProxyConfigFile),
+		HealthzPort: healthzPort,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "haproxy manifest")
+	}
+
+	return map[string][]byte{
+		constants.KeepalivedConfigFile:      keepalivedCfg,
+		constants.KeepalivedCheckScriptFile: checkScript,
+		constants.HAProxyConfigFile:         haproxyCfg,
+		constants.KeepavlivedManifestFile:   keepalivedPod,
+		constants.HAProxyManifestFile:       haproxyPod,
+	}, nil
+}
+
+// Install writes the files of the master which differ from the ones on it, kubelet restarts
+// the static pods once the config hash of the manifests changes.
+func Install(s ssh.Interface, cfg *config.Config, c *devopsv1.Cluster) error {
+	files, err := BuildFiles(cfg, c, s.HostIP(), Interface(s, c))
+	if err != nil {
+		return err
+	}
+
+	// the configs are written before the manifests starting the pods
+	for _, name := range []string{
+		constants.KeepalivedConfigFile,
+		constants.KeepalivedCheckScriptFile,
+		constants.HAProxyConfigFile,
+		constants.KeepavlivedManifestFile,
+		constants.HAProxyManifestFile,
+	} {
+		if current, err := s.ReadFile(name); err == nil && bytes.Equal(current, files[name]) {
+			continue
+		}
+
+		klog.Infof("node: %s write ha file %s", s.HostIP(), name)
+		err = s.WriteFile(bytes.NewReader(files[name]), name)
+		if err != nil {
+			return errors.Wrapf(err, "node: %s write %s", s.HostIP(), name)
+		}
+	}
+
+	_, err = s.CombinedOutput(fmt.Sprintf("chmod +x %s", constants.KeepalivedCheckScriptFile))
+	if err != nil {
+		return errors.Wrapf(err, "node: %s chmod %s", s.HostIP(), constants.KeepalivedCheckScriptFile)
+	}
+	return nil
+}
+
+// Uninstall removes the static pods of keepalived and haproxy from the master and their
+// configs, the manifests are removed first so kubelet stops the pods.
+func Uninstall(s ssh.Interface) error {
+	cmd := fmt.Sprintf("rm -f %s %s && rm -f %s %s %s", constants.KeepavlivedManifestFile, constants.HAProxyManifestFile,
+		constants.KeepalivedConfigFile, constants.KeepalivedCheckScriptFile, constants.HAProxyConfigFile)
+	_, err := s.CombinedOutput(cmd)
+	if err != nil {
+		return errors.Wrapf(err, "node: %s remove ha files", s.HostIP())
+	}
+	klog.Infof("node: %s ha files are removed", s.HostIP())
+	return nil
+}
+
+// authPass returns the vrrp password of the cluster, keepalived uses the first 8 characters.
+func authPass(c *devopsv1.Cluster) string {
+	sum := sha256.Sum256([]byte(c.Namespace + "/" + c.Name + "/" + c.Spec.Features.HA.DKEHA.VIP))
+	return hex.EncodeToString(sum[:])[:8]
+}
+
+func hash(data ...[]byte) string {
+	h := sha256.New()
+	for _, d := range data {
+		h.Write(d)
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
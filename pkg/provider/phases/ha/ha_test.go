@@ -0,0 +1,131 @@
+package ha
+
+import (
+	"strings"
+	"testing"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/constants"
+	"github.com/gostship/kunkka/pkg/provider/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newCluster(dke *devopsv1.DKEHA, ips ...string) *devopsv1.Cluster {
+	c := &devopsv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "demo"},
+		Spec: devopsv1.ClusterSpec{
+			Features: devopsv1.ClusterFeature{HA: &devopsv1.HA{DKEHA: dke}},
+		},
+	}
+	for _, ip := range ips {
+		c.Spec.Machines = append(c.Spec.Machines, &devopsv1.ClusterMachine{IP: ip})
+	}
+	return c
+}
+
+func TestVRID(t *testing.T) {
+	tests := []struct {
+		dke     *devopsv1.DKEHA
+		want    int32
+		wantErr bool
+	}{
+		{dke: &devopsv1.DKEHA{VIP: "10.28.0.100"}, want: 100},
+		{dke: &devopsv1.DKEHA{VIP: "10.28.1.0"}, want: 1},
+		{dke: &devopsv1.DKEHA{VIP: "10.28.0.100", VRID: 51}, want: 51},
+		{dke: &devopsv1.DKEHA{VIP: "vip"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := VRID(newCluster(tt.dke))
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("VRID(%v) error = %v, wantErr %v", tt.dke, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("VRID(%v) = %d, want %d", tt.dke, got, tt.want)
+		}
+	}
+}
+
+func TestPort(t *testing.T) {
+	if got := Port(newCluster(&devopsv1.DKEHA{VIP: "10.28.0.100"})); got != constants.DKEHAPort {
+		t.Errorf("Port() = %d, want %d", got, constants.DKEHAPort)
+	}
+	if got := Port(newCluster(&devopsv1.DKEHA{VIP: "10.28.0.100", VPort: 9443})); got != 9443 {
+		t.Errorf("Port() = %d, want 9443", got)
+	}
+}
+
+func TestBuildFiles(t *testing.T) {
+	c := newCluster(&devopsv1.DKEHA{VIP: "10.28.0.100"}, "10.28.0.10", "10.28.0.11", "10.28.0.12")
+
+	tests := []struct {
+		ip       string
+		priority string
+		peers    []string
+	}{
+		{ip: "10.28.0.10", priority: "priority 103", peers: []string{"10.28.0.11", "10.28.0.12"}},
+		{ip: "10.28.0.12", priority: "priority 101", peers: []string{"10.28.0.10", "10.28.0.11"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			files, err := BuildFiles(&config.Config{}, c, tt.ip, "bond0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(files) != 5 {
+				t.Fatalf("BuildFiles() = %d files, want 5", len(files))
+			}
+
+			keepalived := string(files[constants.KeepalivedConfigFile])
+			for _, want := range []string{
+				tt.priority,
+				"interface bond0",
+				"virtual_router_id 100",
+				"weight -4",
+				"unicast_src_ip " + tt.ip,
+				"unicast_peer {\n    " + strings.Join(tt.peers, "\n    ") + "\n  }",
+				"virtual_ipaddress {\n    10.28.0.100\n  }",
+			} {
+				if !strings.Contains(keepalived, want) {
+					t.Errorf("keepalived.conf has no %q:\n%s", want, keepalived)
+				}
+			}
+
+			haproxy := string(files[constants.HAProxyConfigFile])
+			for _, want := range []string{
+				"bind *:8443",
+				"server 10.28.0.10 10.28.0.10:6443",
+				"server 10.28.0.11 10.28.0.11:6443",
+				"server 10.28.0.12 10.28.0.12:6443",
+			} {
+				if !strings.Contains(haproxy, want) {
+					t.Errorf("haproxy.cfg has no %q:\n%s", want, haproxy)
+				}
+			}
+		})
+	}
+
+	if _, err := BuildFiles(&config.Config{}, c, "10.28.0.13", "eth0"); err == nil {
+		t.Error("BuildFiles() of a machine out of the masters has no error")
+	}
+}
+
+func TestBuildFilesMembership(t *testing.T) {
+	before, err := BuildFiles(&config.Config{}, newCluster(&devopsv1.DKEHA{VIP: "10.28.0.100"}, "10.28.0.10", "10.28.0.11"), "10.28.0.10", "eth0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	after, err := BuildFiles(&config.Config{}, newCluster(&devopsv1.DKEHA{VIP: "10.28.0.100"}, "10.28.0.10"), "10.28.0.10", "eth0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(after[constants.KeepalivedConfigFile]), "unicast_peer") {
+		t.Errorf("keepalived.conf of a single master has peers:\n%s", after[constants.KeepalivedConfigFile])
+	}
+	// the pods are restarted by kubelet with the new configs
+	for _, name := range []string{constants.KeepavlivedManifestFile, constants.HAProxyManifestFile} {
+		if string(before[name]) == string(after[name]) {
+			t.Errorf("%s is not changed once a master is removed", name)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package hostos
+
+import (
+	"bufio"
+	"strconv"
+	"strings"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/util/ssh"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// disksCmd lists the whole disks, sizes in bytes, as KEY="value" pairs
+	disksCmd = "lsblk -b -d -n -P -o NAME,SIZE,ROTA,TYPE,MODEL"
+	linksCmd = "ip -o link show"
+	addrsCmd = "ip -o addr show"
+)
+
+// virtualNICPrefixes are the interfaces created by the container runtimes and the cni plugins.
+var virtualNICPrefixes = []string{"veth", "cali", "docker", "cni", "flannel", "kube-ipvs", "tunl", "vxlan", "virbr", "br-"}
+
+// Discover discovers the cpu, memory, disks, nics and operating system of the host.
+func Discover(s ssh.Interface, status *devopsv1.HostStatus) error {
+	cpu, err := ssh.NumCPU(s)
+	if err != nil {
+		return errors.Wrapf(err, "node: %s get cpu", s.HostIP())
+	}
+	memory, err := ssh.MemoryCapacity(s)
+	if err != nil {
+		return errors.Wrapf(err, "node: %s get memory", s.HostIP())
+	}
+	avail, err := ssh.DiskAvail(s, "/")
+	if err != nil {
+		return errors.Wrapf(err, "node: %s get root disk", s.HostIP())
+	}
+
+	out, err := s.CombinedOutput(disksCmd)
+	if err != nil {
+		return errors.Wrapf(err, "node: %s list disks", s.HostIP())
+	}
+	disks, err := ParseDisks(string(out))
+	if err != nil {
+		return errors.Wrapf(err, "node: %s", s.HostIP())
+	}
+
+	links, err := s.CombinedOutput(linksCmd)
+	if err != nil {
+		return errors.Wrapf(err, "node: %s list links", s.HostIP())
+	}
+	addrs, err := s.CombinedOutput(addrsCmd)
+	if err != nil {
+		return errors.Wrapf(err, "node: %s list addresses", s.HostIP())
+	}
+
+	o, err := Detect(s)
+	if err != nil {
+		return err
+	}
+	info := devopsv1.MachineSystemInfo{}
+	Record(&info, o)
+	info.KernelVersion = readFact(s, "uname -r")
+	info.Architecture = readFact(s, "uname -m")
+	info.MachineID = readFact(s, "cat /etc/machine-id")
+	info.SystemUUID = readFact(s, "cat /sys/class/dmi/id/product_uuid")
+	info.BootID = readFact(s, "cat /proc/sys/kernel/random/boot_id")
+
+	status.CPU = int32(cpu)
+	status.Memory = resource.NewQuantity(int64(memory), resource.BinarySI)
+	status.RootDiskAvail = resource.NewQuantity(int64(avail)<<30, resource.BinarySI)
+	status.Disks = disks
+	status.NICs = ParseNICs(string(links), string(addrs))
+	status.SystemInfo = info
+	return nil
+}
+
+// readFact returns the output of the command, empty if it fails, e.g. the dmi of a vm is not
+// readable.
+func readFact(s ssh.Interface, cmd string) string {
+	out, err := s.CombinedOutput(cmd)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// ParseDisks parses the disks listed by lsblk.
+func ParseDisks(data string) ([]devopsv1.HostDisk, error) {
+	var disks []devopsv1.HostDisk
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		fields := parsePairs(scanner.Text())
+		if fields["TYPE"] != "disk" {
+			continue
+		}
+		size, err := strconv.ParseInt(fields["SIZE"], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid size of disk %s", fields["NAME"])
+		}
+		disks = append(disks, devopsv1.HostDisk{
+			Name:       fields["NAME"],
+			Size:       *resource.NewQuantity(size, resource.BinarySI),
+			Rotational: fields["ROTA"] == "1",
+			Model:      strings.TrimSpace(fields["MODEL"]),
+		})
+	}
+	return disks, nil
+}
+
+// parsePairs parses a line of KEY="value" pairs.
+func parsePairs(line string) map[string]string {
+	fields := map[string]string{}
+	for line = strings.TrimSpace(line); line != ""; line = strings.TrimSpace(line) {
+		i := strings.Index(line, `="`)
+		if i < 0 {
+			break
+		}
+		key := line[:i]
+		line = line[i+2:]
+		j := strings.Index(line, `"`)
+		if j < 0 {
+			break
+		}
+		fields[key] = line[:j]
+		line = line[j+1:]
+	}
+	return fields
+}
+
+// ParseNICs parses the ethernet interfaces listed by ip link and their addresses listed by ip
+// addr, the interfaces of the container runtimes and the cni plugins are left out.
+func ParseNICs(links, addrs string) []devopsv1.HostNIC {
+	var nics []devopsv1.HostNIC
+	index := map[string]int{}
+	scanner := bufio.NewScanner(strings.NewReader(links))
+	for scanner.Scan() {
+		// 2: eth0: <BROADCAST,MULTICAST,UP,LOWER_UP> mtu 1500 ... \    link/ether 52:54:00:12:34:56 brd ff:ff:ff:ff:ff:ff
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		name := strings.SplitN(strings.TrimSuffix(fields[1], ":"), "@", 2)[0]
+		mac := ""
+		for i := range fields {
+			if fields[i] == "link/ether" && i+1 < len(fields) {
+				mac = fields[i+1]
+			}
+		}
+		if mac == "" || isVirtualNIC(name) {
+			continue
+		}
+		index[name] = len(nics)
+		nics = append(nics, devopsv1.HostNIC{Name: name, MAC: mac})
+	}
+
+	scanner = bufio.NewScanner(strings.NewReader(addrs))
+	for scanner.Scan() {
+		// 2: eth0    inet 10.0.0.5/24 brd 10.0.0.255 scope global eth0\       valid_lft forever ...
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || (fields[2] != "inet" && fields[2] != "inet6") {
+			continue
+		}
+		i, ok := index[fields[1]]
+		if !ok || strings.HasPrefix(fields[3], "fe80:") {
+			continue
+		}
+		nics[i].IPs = append(nics[i].IPs, fields[3])
+	}
+	return nics
+}
+
+func isVirtualNIC(name string) bool {
+	for _, prefix := range virtualNICPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package hostos
+
+import (
+	"reflect"
+	"testing"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+)
+
+func TestParseDisks(t *testing.T) {
+	data := `NAME="sda" SIZE="480103981056" ROTA="0" TYPE="disk" MODEL="INTEL SSDSC2KB48"
+NAME="sdb" SIZE="4000787030016" ROTA="1" TYPE="disk" MODEL="ST4000NM0035-1V4"
+NAME="sr0" SIZE="1073741312" ROTA="1" TYPE="rom" MODEL="Virtual CDROM   "
+`
+	disks, err := ParseDisks(data)
+	if err != nil {
+		t.Fatalf("ParseDisks() error = %v", err)
+	}
+	if len(disks) != 2 {
+		t.Fatalf("ParseDisks() = %v, want 2 disks", disks)
+	}
+	if disks[0].Name != "sda" || disks[0].Rotational || disks[0].Model != "INTEL SSDSC2KB48" || disks[0].Size.Value() != 480103981056 {
+		t.Errorf("ParseDisks() sda = %+v", disks[0])
+	}
+	if disks[1].Name != "sdb" || !disks[1].Rotational {
+		t.Errorf("ParseDisks() sdb = %+v", disks[1])
+	}
+
+	if _, err := ParseDisks(`NAME="sda" SIZE="big" ROTA="0" TYPE="disk" MODEL=""`); err == nil {
+		t.Errorf("ParseDisks() with an invalid size returns no error")
+	}
+}
+
+func TestParseNICs(t *testing.T) {
+	links := `1: lo: <LOOPBACK,UP,LOWER_UP> mtu 65536 qdisc noqueue state UNKNOWN mode DEFAULT group default qlen 1000\    link/loopback 00:00:00:00:00:00 brd 00:00:00:00:00:00
+2: eth0: <BROADCAST,MULTICAST,UP,LOWER_UP> mtu 1500 qdisc mq state UP mode DEFAULT group default qlen 1000\    link/ether 52:54:00:12:34:56 brd ff:ff:ff:ff:ff:ff
+3: eth1: <BROADCAST,MULTICAST> mtu 1500 qdisc noop state DOWN mode DEFAULT group default qlen 1000\    link/ether 52:54:00:12:34:57 brd ff:ff:ff:ff:ff:ff
+4: docker0: <NO-CARRIER,BROADCAST,MULTICAST,UP> mtu 1500 qdisc noqueue state DOWN mode DEFAULT group default \    link/ether 02:42:9a:1b:2c:3d brd ff:ff:ff:ff:ff:ff
+5: bond0.100@bond0: <BROADCAST,MULTICAST,UP,LOWER_UP> mtu 1500 qdisc noqueue state UP mode DEFAULT group default qlen 1000\    link/ether 52:54:00:12:34:58 brd ff:ff:ff:ff:ff:ff
+`
+	addrs := `1: lo    inet 127.0.0.1/8 scope host lo\       valid_lft forever preferred_lft forever
+2: eth0    inet 10.28.0.10/22 brd 10.28.3.255 scope global eth0\       valid_lft forever preferred_lft forever
+2: eth0    inet6 fe80::5054:ff:fe12:3456/64 scope link \       valid_lft forever preferred_lft forever
+4: docker0    inet 172.17.0.1/16 brd 172.17.255.255 scope global docker0\       valid_lft forever preferred_lft forever
+`
+	want := []devopsv1.HostNIC{
+		{Name: "eth0", MAC: "52:54:00:12:34:56", IPs: []string{"10.28.0.10/22"}},
+		{Name: "eth1", MAC: "52:54:00:12:34:57"},
+		{Name: "bond0.100", MAC: "52:54:00:12:34:58"},
+	}
+	if got := ParseNICs(links, addrs); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseNICs() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,106 @@
+package hostos
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/util/ssh"
+	"github.com/pkg/errors"
+)
+
+// Family is the distro family of the host, the hosts of a family share the package
+// manager and the network config.
+type Family string
+
+const (
+	// RHEL is CentOS, RHEL and the distros like them, with yum and network-scripts.
+	RHEL Family = "rhel"
+	// Debian is Debian, Ubuntu and the distros like them, with apt and systemd-networkd.
+	Debian Family = "debian"
+)
+
+const osReleaseFile = "/etc/os-release"
+
+// OS is the operating system of the host read from /etc/os-release.
+type OS struct {
+	// ID is the distro id, e.g. centos or ubuntu.
+	ID string
+	// Version is the distro version id, e.g. 7 or 20.04.
+	Version string
+	// PrettyName is the distro name for the users, e.g. Ubuntu 20.04.1 LTS.
+	PrettyName string
+	// Codename is the release codename of the debian distros, e.g. focal.
+	Codename string
+	Family   Family
+}
+
+// MajorVersion returns the major version, e.g. 7 of CentOS 7.8.
+func (o *OS) MajorVersion() string {
+	return strings.SplitN(o.Version, ".", 2)[0]
+}
+
+// Detect reads the operating system of the host.
+func Detect(s ssh.Interface) (*OS, error) {
+	data, err := s.ReadFile(osReleaseFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "node: %s read %s", s.HostIP(), osReleaseFile)
+	}
+
+	o, err := Parse(string(data))
+	if err != nil {
+		return nil, errors.Wrapf(err, "node: %s", s.HostIP())
+	}
+	return o, nil
+}
+
+// Parse parses the os-release file, the distros which are not RHEL or Debian like
+// are not supported.
+func Parse(data string) (*OS, error) {
+	fields := map[string]string{}
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		fields[kv[0]] = strings.Trim(kv[1], `"'`)
+	}
+
+	o := &OS{
+		ID:         fields["ID"],
+		Version:    fields["VERSION_ID"],
+		PrettyName: fields["PRETTY_NAME"],
+		Codename:   fields["VERSION_CODENAME"],
+	}
+	if o.Codename == "" {
+		o.Codename = fields["UBUNTU_CODENAME"]
+	}
+
+	ids := append([]string{o.ID}, strings.Fields(fields["ID_LIKE"])...)
+	for _, id := range ids {
+		switch id {
+		case "centos", "rhel", "fedora", "rocky", "almalinux", "ol":
+			o.Family = RHEL
+		case "debian", "ubuntu":
+			o.Family = Debian
+		}
+		if o.Family != "" {
+			return o, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported os %q", o.ID)
+}
+
+// Record records the operating system in the machine info.
+func Record(info *devopsv1.MachineSystemInfo, o *OS) {
+	info.OperatingSystem = "linux"
+	info.OSImage = o.PrettyName
+	info.OSID = o.ID
+	info.OSVersion = o.Version
+}
@@ -0,0 +1,66 @@
+package hostos
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    OS
+		wantErr bool
+	}{
+		{
+			name: "centos",
+			data: `NAME="CentOS Linux"
+VERSION="7 (Core)"
+ID="centos"
+ID_LIKE="rhel fedora"
+VERSION_ID="7"
+PRETTY_NAME="CentOS Linux 7 (Core)"
+`,
+			want: OS{ID: "centos", Version: "7", PrettyName: "CentOS Linux 7 (Core)", Family: RHEL},
+		},
+		{
+			name: "ubuntu",
+			data: `NAME="Ubuntu"
+VERSION="20.04.1 LTS (Focal Fossa)"
+ID=ubuntu
+ID_LIKE=debian
+PRETTY_NAME="Ubuntu 20.04.1 LTS"
+VERSION_ID="20.04"
+VERSION_CODENAME=focal
+UBUNTU_CODENAME=focal
+`,
+			want: OS{ID: "ubuntu", Version: "20.04", PrettyName: "Ubuntu 20.04.1 LTS", Codename: "focal", Family: Debian},
+		},
+		{
+			name: "like",
+			data: `ID="rocky"
+ID_LIKE="rhel centos fedora"
+VERSION_ID="8.4"
+`,
+			want: OS{ID: "rocky", Version: "8.4", Family: RHEL},
+		},
+		{
+			name:    "unsupported",
+			data:    "ID=alpine\nVERSION_ID=3.12.0\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if *got != tt.want {
+				t.Errorf("Parse() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
@@ -1,22 +1,28 @@
 package joinnode
 
 import (
+	"context"
 	"fmt"
 	"os"
 
 	"strings"
 
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
 	kubeadmv1beta2 "github.com/gostship/kunkka/pkg/apis/kubeadm/v1beta2"
 	"github.com/gostship/kunkka/pkg/constants"
 	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/provider/condlog"
 	"github.com/gostship/kunkka/pkg/provider/config"
 	"github.com/gostship/kunkka/pkg/provider/phases/certs"
+	"github.com/gostship/kunkka/pkg/provider/phases/cri"
 	"github.com/gostship/kunkka/pkg/provider/phases/kubeadm"
 	"github.com/gostship/kunkka/pkg/util/pkiutil"
 	"github.com/gostship/kunkka/pkg/util/ssh"
 	"github.com/gostship/kunkka/pkg/util/template"
 	"github.com/pkg/errors"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func ApplyPodManifest(hostIP string, c *common.Cluster, cfg *config.Config, pathName string, podManifest string, fileMaps map[string]string) error {
@@ -85,7 +91,8 @@ func JoinMasterNode(hostIP string, c *common.Cluster, cfg *config.Config, isMast
 	return nil
 }
 
-func JoinNodePhase(s ssh.Interface, cfg *config.Config, c *common.Cluster, apiserver string, isMaster bool) error {
+func JoinNodePhase(s ssh.Interface, cfg *config.Config, c *common.Cluster, apiserver string, isMaster bool,
+	runtime devopsv1.ContainerRuntimeType) error {
 	hostIP := s.HostIP()
 	fileMaps := make(map[string]string)
 	err := JoinMasterNode(hostIP, c, cfg, isMaster, fileMaps)
@@ -99,7 +106,8 @@ func JoinNodePhase(s ssh.Interface, cfg *config.Config, c *common.Cluster, apise
 	}
 
 	nodeOpt := &kubeadmv1beta2.NodeRegistrationOptions{
-		Name: hostIP,
+		Name:      hostIP,
+		CRISocket: cri.Socket(runtime),
 	}
 	flagsEnv := BuildKubeletDynamicEnvFile(cfg.Registry.Prefix, nodeOpt)
 	fileMaps[constants.KubeletEnvFileName] = flagsEnv
@@ -130,3 +138,91 @@ func JoinNodePhase(s ssh.Interface, cfg *config.Config, c *common.Cluster, apise
 	}
 	return nil
 }
+
+// UpgradeKubeletConfig writes the kubelet configuration of the cluster spec again, the nodes
+// joined without kubeadm take the configuration of the new version this way.
+func UpgradeKubeletConfig(s ssh.Interface, c *common.Cluster) error {
+	hostIP := s.HostIP()
+	cfgYaml, err := KubeletMarshal(kubeadm.GetFullKubeletConfiguration(c))
+	if err != nil {
+		return errors.Wrapf(err, "node: %s failed marshal kubelet file", hostIP)
+	}
+
+	err = s.WriteFile(strings.NewReader(string(cfgYaml)), constants.KubeletConfigurationFileName)
+	if err != nil {
+		return errors.Wrapf(err, "node: %s failed to write for %s ", hostIP, constants.KubeletConfigurationFileName)
+	}
+	return nil
+}
+
+// RenewKubeletKubeconfig issues the kubelet kubeconfig of the worker node again with the
+// certificate authority of the credential, the apiserver of the current kubeconfig is kept.
+func RenewKubeletKubeconfig(s ssh.Interface, c *common.Cluster) error {
+	hostIP := s.HostIP()
+	data, err := s.ReadFile(constants.KubeletKubeConfigFileName)
+	if err != nil {
+		return errors.Wrapf(err, "node: %s read kubelet kubeconfig", hostIP)
+	}
+	kcfg := &clientcmdapi.Config{}
+	err = certs.DecodeKubeConfigByte(data, kcfg)
+	if err != nil {
+		return errors.Wrapf(err, "node: %s decode kubelet kubeconfig", hostIP)
+	}
+	var apiserver string
+	for _, v := range kcfg.Clusters {
+		apiserver = v.Server
+		break
+	}
+	if apiserver == "" {
+		return fmt.Errorf("node: %s kubelet kubeconfig has no apiserver", hostIP)
+	}
+
+	fileMaps := map[string]string{
+		constants.CACertName: string(c.ClusterCredential.CACert),
+	}
+	err = BuildKubeletKubeconfig(hostIP, c, apiserver, fileMaps)
+	if err != nil {
+		return errors.Wrapf(err, "node: %s failed build kubelet file", hostIP)
+	}
+	for pathName, va := range fileMaps {
+		klog.V(4).Infof("node: %s start write [%s] ...", hostIP, pathName)
+		err = s.WriteFile(strings.NewReader(va), pathName)
+		if err != nil {
+			return errors.Wrapf(err, "node: %s failed to write for %s ", hostIP, pathName)
+		}
+	}
+
+	klog.Infof("node: %s restart kubelet ... ", hostIP)
+	_, err = s.CombinedOutput("systemctl restart kubelet")
+	if err != nil {
+		return errors.Wrapf(err, "node: %s restart kubelet", hostIP)
+	}
+	return nil
+}
+
+// RenewMachines issues the kubelet kubeconfig of the worker machines of the cluster again
+// one by one, the machines being deleted are skipped.
+func RenewMachines(ctx context.Context, c *common.Cluster) error {
+	ms := &devopsv1.MachineList{}
+	err := c.Client.List(ctx, ms, client.InNamespace(c.Namespace))
+	if err != nil {
+		return errors.Wrapf(err, "list machines of cluster %s", c.Name)
+	}
+
+	for i := range ms.Items {
+		m := &ms.Items[i]
+		if m.Spec.ClusterName != c.Name || m.Spec.Machine == nil || !m.ObjectMeta.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		sh, err := condlog.SSH(ctx, &m.Spec)
+		if err != nil {
+			return errors.Wrap(err, m.Name)
+		}
+		err = RenewKubeletKubeconfig(sh, c)
+		if err != nil {
+			return errors.Wrap(err, m.Name)
+		}
+	}
+	return nil
+}
@@ -112,7 +112,13 @@ func BuildKubeletDynamicEnvFile(imageRepository string, nodeReg *kubeadmv1beta2.
 	kubeletFlags := map[string]string{}
 
 	kubeletFlags["cgroup-driver"] = "systemd"
-	kubeletFlags["network-plugin"] = "cni"
+	if nodeReg.CRISocket == "" || nodeReg.CRISocket == constants.DefaultDockerCRISocket {
+		kubeletFlags["network-plugin"] = "cni"
+		kubeletFlags["pod-infra-container-image"] = GetPauseImage(imageRepository)
+	} else {
+		kubeletFlags["container-runtime"] = "remote"
+		kubeletFlags["container-runtime-endpoint"] = "unix://" + nodeReg.CRISocket
+	}
 	// Pass the "--hostname-override" flag to the kubelet only if it's different from the hostname
 	nodeName, hostname, err := GetNodeNameAndHostname(nodeReg)
 	if err != nil {
@@ -123,7 +129,6 @@ func BuildKubeletDynamicEnvFile(imageRepository string, nodeReg *kubeadmv1beta2.
 		kubeletFlags["hostname-override"] = nodeName
 	}
 
-	kubeletFlags["pod-infra-container-image"] = GetPauseImage(imageRepository)
 	argList := BuildArgumentListFromMap(kubeletFlags, nodeReg.KubeletExtraArgs)
 	envFileContent := fmt.Sprintf("%s=%q\n", constants.KubeletEnvFileVariableName, strings.Join(argList, " "))
 
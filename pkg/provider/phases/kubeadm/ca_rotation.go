@@ -0,0 +1,153 @@
+package kubeadm
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	kubeadmv1beta2 "github.com/gostship/kunkka/pkg/apis/kubeadm/v1beta2"
+	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/provider/phases/certs"
+	"github.com/gostship/kunkka/pkg/util/pkiutil"
+	"github.com/pkg/errors"
+	certutil "k8s.io/client-go/util/cert"
+)
+
+// TrustNewCAs starts the rotation of the certificate authorities of the credential. The new
+// certificate authorities are appended to the bundles of the old ones, so the cluster trusts
+// both while the old ones still issue the certificates, the keys of the new ones are kept in
+// the rotation until IssueWithNewCAs.
+func TrustNewCAs(cfg *Config, c *common.Cluster, isHosted bool) error {
+	warp := &kubeadmv1beta2.WarpperConfiguration{
+		InitConfiguration:    cfg.InitConfiguration,
+		ClusterConfiguration: cfg.ClusterConfiguration,
+		IPs:                  c.IPs(),
+	}
+
+	cfgMaps := make(map[string][]byte)
+	keys := make(map[string][]byte)
+	bundles := make(map[string][]byte)
+	for _, cert := range getCertList(isHosted) {
+		if cert.CAName != "" {
+			continue
+		}
+
+		certPath, keyPath := caPaths(cert, warp.CertificatesDir)
+		current := c.ClusterCredential.CertsBinaryData[certPath]
+		if len(current) == 0 {
+			return fmt.Errorf("no certificate authority %s in the credential", cert.Name)
+		}
+		_, err := certs.CreateCACertAndKeyFiles(cert, warp, cfgMaps)
+		if err != nil {
+			return errors.Wrapf(err, "create certificate authority %s", cert.Name)
+		}
+
+		bundle, err := caBundle(current, cfgMaps[certPath])
+		if err != nil {
+			return errors.Wrapf(err, "bundle certificate authority %s", cert.Name)
+		}
+		bundles[certPath] = bundle
+		keys[keyPath] = cfgMaps[keyPath]
+	}
+
+	for certPath, bundle := range bundles {
+		setCredentialFile(c, certPath, bundle)
+	}
+	c.ClusterCredential.CARotation = &devopsv1.CARotation{
+		Phase: devopsv1.CARotationTrustBoth,
+		Keys:  keys,
+	}
+	return nil
+}
+
+// IssueWithNewCAs moves the new certificate authorities of the rotation to the front of the
+// bundles and issues the certificates of the cluster with them again, the old ones are still
+// trusted.
+func IssueWithNewCAs(cfg *Config, c *common.Cluster, isHosted bool) error {
+	rotation := c.ClusterCredential.CARotation
+	if rotation == nil || rotation.Phase != devopsv1.CARotationTrustBoth {
+		return fmt.Errorf("the certificate authorities of the credential are not in phase %s", devopsv1.CARotationTrustBoth)
+	}
+
+	for _, cert := range getCertList(isHosted) {
+		if cert.CAName != "" {
+			continue
+		}
+
+		certPath, keyPath := caPaths(cert, cfg.ClusterConfiguration.CertificatesDir)
+		list, err := certutil.ParseCertsPEM(c.ClusterCredential.CertsBinaryData[certPath])
+		if err != nil {
+			return errors.Wrapf(err, "parse certificate authority %s", cert.Name)
+		}
+		key := rotation.Keys[keyPath]
+		if len(list) != 2 || len(key) == 0 {
+			return fmt.Errorf("no new certificate authority %s in the rotation", cert.Name)
+		}
+
+		bundle, err := caBundle(pkiutil.EncodeCertPEM(list[1]), pkiutil.EncodeCertPEM(list[0]))
+		if err != nil {
+			return errors.Wrapf(err, "bundle certificate authority %s", cert.Name)
+		}
+		setCredentialFile(c, certPath, bundle)
+		setCredentialFile(c, keyPath, key)
+	}
+
+	err := buildCerts(cfg, c, isHosted, true)
+	if err != nil {
+		return err
+	}
+	rotation.Phase = devopsv1.CARotationReissue
+	rotation.Keys = nil
+	return nil
+}
+
+// DropOldCAs removes the old certificate authorities of the rotation from the bundles, the
+// cluster trusts the new ones only.
+func DropOldCAs(cfg *Config, c *common.Cluster, isHosted bool) error {
+	rotation := c.ClusterCredential.CARotation
+	if rotation == nil || rotation.Phase != devopsv1.CARotationReissue {
+		return fmt.Errorf("the certificate authorities of the credential are not in phase %s", devopsv1.CARotationReissue)
+	}
+
+	for _, cert := range getCertList(isHosted) {
+		if cert.CAName != "" {
+			continue
+		}
+
+		certPath, _ := caPaths(cert, cfg.ClusterConfiguration.CertificatesDir)
+		list, err := certutil.ParseCertsPEM(c.ClusterCredential.CertsBinaryData[certPath])
+		if err != nil {
+			return errors.Wrapf(err, "parse certificate authority %s", cert.Name)
+		}
+		setCredentialFile(c, certPath, pkiutil.EncodeCertPEM(list[0]))
+	}
+
+	rotation.Phase = devopsv1.CARotationDropOld
+	return nil
+}
+
+func caPaths(cert *certs.KubeadmCert, certsDir string) (string, string) {
+	return filepath.Join(certsDir, cert.BaseName+".crt"), filepath.Join(certsDir, cert.BaseName+".key")
+}
+
+// caBundle returns the PEM bundle of the certificate authorities, the first one issues the
+// certificates.
+func caBundle(first, second []byte) ([]byte, error) {
+	for _, data := range [][]byte{first, second} {
+		list, err := certutil.ParseCertsPEM(data)
+		if err != nil {
+			return nil, err
+		}
+		if len(list) != 1 {
+			return nil, fmt.Errorf("want one certificate, got %d", len(list))
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.Write(bytes.TrimSpace(first))
+	buf.WriteString("\n")
+	buf.Write(bytes.TrimSpace(second))
+	buf.WriteString("\n")
+	return buf.Bytes(), nil
+}
@@ -14,7 +14,9 @@ import (
 	"crypto/x509"
 
 	"os"
+	"path/filepath"
 
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
 	kubeadmv1beta2 "github.com/gostship/kunkka/pkg/apis/kubeadm/v1beta2"
 	"github.com/gostship/kunkka/pkg/constants"
 	"github.com/gostship/kunkka/pkg/controllers/common"
@@ -23,7 +25,9 @@ import (
 
 	"github.com/gostship/kunkka/pkg/provider/config"
 	"github.com/gostship/kunkka/pkg/provider/phases/certs"
+	"github.com/gostship/kunkka/pkg/provider/phases/cri"
 	"github.com/gostship/kunkka/pkg/util/k8sutil"
+	"github.com/gostship/kunkka/pkg/util/pkiutil"
 	"github.com/gostship/kunkka/pkg/util/ssh"
 	"github.com/gostship/kunkka/pkg/util/template"
 	corev1 "k8s.io/api/core/v1"
@@ -37,6 +41,7 @@ const (
 	joinControlPlaneCmd = `kubeadm join {{.ControlPlaneEndpoint}} \
 --node-name={{.NodeName}} --token={{.BootstrapToken}} \
 --control-plane --certificate-key={{.CertificateKey}} \
+--cri-socket={{.CRISocket}} \
 --skip-phases=control-plane-join/mark-control-plane \
 --discovery-token-unsafe-skip-ca-verification \
 --ignore-preflight-errors=ImagePull \
@@ -49,6 +54,7 @@ const (
 	joinNodeCmd = `kubeadm join {{.ControlPlaneEndpoint}} \
 --node-name={{.NodeName}} \
 --token={{.BootstrapToken}} \
+--cri-socket={{.CRISocket}} \
 --discovery-token-unsafe-skip-ca-verification \
 --ignore-preflight-errors=ImagePull \
 --ignore-preflight-errors=Port-10250 \
@@ -108,8 +114,20 @@ func Init(s ssh.Interface, kubeadmConfig *Config, extraCmd string) error {
 }
 
 func InitCerts(cfg *Config, c *common.Cluster, isHosted bool) error {
+	return buildCerts(cfg, c, isHosted, false)
+}
+
+// ReissueCerts issues the certificates of the cluster again with the certificate
+// authorities of the credential, the first ones of the bundles during a rotation. The
+// service account keys are kept, so the tokens of the cluster are still valid.
+func ReissueCerts(cfg *Config, c *common.Cluster, isHosted bool) error {
+	return buildCerts(cfg, c, isHosted, true)
+}
+
+func buildCerts(cfg *Config, c *common.Cluster, isHosted bool, reuseCA bool) error {
 	var lastCACert *certs.CaAll
 	cfgMaps := make(map[string][]byte)
+	current := c.ClusterCredential.CertsBinaryData
 
 	warp := &kubeadmv1beta2.WarpperConfiguration{
 		InitConfiguration:    cfg.InitConfiguration,
@@ -117,15 +135,16 @@ func InitCerts(cfg *Config, c *common.Cluster, isHosted bool) error {
 		IPs:                  c.IPs(),
 	}
 
-	var certList certs.Certificates
-	if !isHosted {
-		certList = certs.GetDefaultCertList()
-	} else {
-		certList = certs.GetCertsWithoutEtcd()
-	}
-
-	for _, cert := range certList {
+	for _, cert := range getCertList(isHosted) {
 		if cert.CAName == "" {
+			if reuseCA {
+				ret, err := loadCA(cert, warp.CertificatesDir, current, cfgMaps)
+				if err != nil {
+					return err
+				}
+				lastCACert = ret
+				continue
+			}
 			ret, err := certs.CreateCACertAndKeyFiles(cert, warp, cfgMaps)
 			if err != nil {
 				return err
@@ -142,48 +161,88 @@ func InitCerts(cfg *Config, c *common.Cluster, isHosted bool) error {
 		}
 	}
 
-	err := certs.CreateServiceAccountKeyAndPublicKeyFiles(cfg.ClusterConfiguration.CertificatesDir, x509.RSA, cfgMaps)
-	if err != nil {
-		return errors.Wrapf(err, "create sa public key")
+	saKey := filepath.Join(cfg.ClusterConfiguration.CertificatesDir, pkiutil.ServiceAccountKeyBaseName+".key")
+	saPub := filepath.Join(cfg.ClusterConfiguration.CertificatesDir, pkiutil.ServiceAccountKeyBaseName+".pub")
+	if len(current[saKey]) > 0 && len(current[saPub]) > 0 {
+		cfgMaps[saKey] = current[saKey]
+		cfgMaps[saPub] = current[saPub]
+	} else {
+		err := certs.CreateServiceAccountKeyAndPublicKeyFiles(cfg.ClusterConfiguration.CertificatesDir, x509.RSA, cfgMaps)
+		if err != nil {
+			return errors.Wrapf(err, "create sa public key")
+		}
 	}
 
 	if len(cfgMaps) == 0 {
 		return fmt.Errorf("no cert build")
 	}
 
+	for pathFile, v := range cfgMaps {
+		setCredentialFile(c, pathFile, v)
+	}
+
+	return nil
+}
+
+func getCertList(isHosted bool) certs.Certificates {
+	if !isHosted {
+		return certs.GetDefaultCertList()
+	}
+	return certs.GetCertsWithoutEtcd()
+}
+
+// setCredentialFile sets the file of the certs of the credential, and the field of the
+// credential kept for it.
+func setCredentialFile(c *common.Cluster, pathFile string, v []byte) {
 	if c.ClusterCredential.CertsBinaryData == nil {
 		c.ClusterCredential.CertsBinaryData = make(map[string][]byte)
 	}
 
-	for pathFile, v := range cfgMaps {
-		if pathFile == constants.CACertName {
-			c.ClusterCredential.CACert = v
-		}
+	if pathFile == constants.CACertName {
+		c.ClusterCredential.CACert = v
+	}
 
-		if pathFile == constants.CAKeyName {
-			c.ClusterCredential.CAKey = v
-		}
+	if pathFile == constants.CAKeyName {
+		c.ClusterCredential.CAKey = v
+	}
 
-		if pathFile == constants.EtcdCACertName {
-			c.ClusterCredential.ETCDCACert = v
-		}
+	if pathFile == constants.EtcdCACertName {
+		c.ClusterCredential.ETCDCACert = v
+	}
 
-		if pathFile == constants.EtcdCAKeyName {
-			c.ClusterCredential.ETCDCAKey = v
-		}
+	if pathFile == constants.EtcdCAKeyName {
+		c.ClusterCredential.ETCDCAKey = v
+	}
 
-		if pathFile == constants.APIServerEtcdClientCertName {
-			c.ClusterCredential.ETCDAPIClientCert = v
-		}
+	if pathFile == constants.APIServerEtcdClientCertName {
+		c.ClusterCredential.ETCDAPIClientCert = v
+	}
 
-		if pathFile == constants.APIServerEtcdClientKeyName {
-			c.ClusterCredential.ETCDAPIClientKey = v
-		}
+	if pathFile == constants.APIServerEtcdClientKeyName {
+		c.ClusterCredential.ETCDAPIClientKey = v
+	}
 
-		c.ClusterCredential.CertsBinaryData[pathFile] = v
+	c.ClusterCredential.CertsBinaryData[pathFile] = v
+}
+
+// loadCA loads the certificate authority of the spec from the certs of the credential.
+func loadCA(cert *certs.KubeadmCert, certsDir string, current map[string][]byte, cfgMaps map[string][]byte) (*certs.CaAll, error) {
+	certPath := filepath.Join(certsDir, cert.BaseName+".crt")
+	keyPath := filepath.Join(certsDir, cert.BaseName+".key")
+	if len(current[certPath]) == 0 || len(current[keyPath]) == 0 {
+		return nil, fmt.Errorf("no certificate authority %s in the credential", cert.Name)
 	}
 
-	return nil
+	caCert, caKey, err := certs.LoadCertAndKeyFromByte(current[keyPath], current[certPath])
+	if err != nil {
+		return nil, errors.Wrapf(err, "load certificate authority %s", cert.Name)
+	}
+	cfgMaps[certPath] = current[certPath]
+	cfgMaps[keyPath] = current[keyPath]
+	return &certs.CaAll{
+		CaCert: caCert,
+		CaKey:  caKey,
+		Cfg:    cert}, nil
 }
 
 type JoinControlPlaneOption struct {
@@ -191,6 +250,7 @@ type JoinControlPlaneOption struct {
 	BootstrapToken       string
 	CertificateKey       string
 	ControlPlaneEndpoint string
+	CRISocket            string
 }
 
 func JoinControlPlane(s ssh.Interface, c *common.Cluster) error {
@@ -199,6 +259,7 @@ func JoinControlPlane(s ssh.Interface, c *common.Cluster) error {
 		CertificateKey:       *c.ClusterCredential.CertificateKey,
 		ControlPlaneEndpoint: fmt.Sprintf("%s:6443", c.Spec.Machines[0].IP),
 		NodeName:             s.HostIP(),
+		CRISocket:            cri.Socket(c.Spec.GetContainerRuntime()),
 	}
 
 	cmd, err := template.ParseString(joinControlPlaneCmd, option)
@@ -218,9 +279,13 @@ type JoinNodeOption struct {
 	NodeName             string
 	BootstrapToken       string
 	ControlPlaneEndpoint string
+	CRISocket            string
 }
 
 func JoinNode(s ssh.Interface, option *JoinNodeOption) error {
+	if option.CRISocket == "" {
+		option.CRISocket = constants.DefaultDockerCRISocket
+	}
 	cmd, err := template.ParseString(joinNodeCmd, option)
 	if err != nil {
 		return errors.Wrap(err, "parse joinNodeCmd error")
@@ -234,7 +299,7 @@ func JoinNode(s ssh.Interface, option *JoinNodeOption) error {
 	return nil
 }
 
-func RenewCerts(s ssh.Interface) error {
+func RenewCerts(s ssh.Interface, runtime devopsv1.ContainerRuntimeType) error {
 	err := fixKubeadmBug1753(s)
 	if err != nil {
 		return fmt.Errorf("fixKubeadmBug1753(https://github.com/kubernetes/kubeadm/issues/1753) error: %w", err)
@@ -246,7 +311,7 @@ func RenewCerts(s ssh.Interface) error {
 		return err
 	}
 
-	err = RestartControlPlane(s)
+	err = RestartControlPlane(s, runtime)
 	if err != nil {
 		return err
 	}
@@ -254,6 +319,33 @@ func RenewCerts(s ssh.Interface) error {
 	return nil
 }
 
+// UpgradeApply upgrades the first control plane node and the cluster config to the version,
+// the kubeadm config of the version must be uploaded before. etcd and certs are managed by kunkka.
+func UpgradeApply(s ssh.Interface, version string) error {
+	cmd := fmt.Sprintf("kubeadm upgrade apply %s --yes --force --etcd-upgrade=false --certificate-renewal=false -v 5", version)
+	klog.Infof("node: %s upgrade cmd: %s", s.HostIP(), cmd)
+	out, err := s.CombinedOutput(cmd)
+	if err != nil {
+		return fmt.Errorf("exec %q error: %w", cmd, err)
+	}
+	klog.Info(string(out))
+
+	return nil
+}
+
+// UpgradeNode upgrades the other control plane nodes to the version of cluster config.
+func UpgradeNode(s ssh.Interface) error {
+	cmd := "kubeadm upgrade node --etcd-upgrade=false --certificate-renewal=false -v 5"
+	klog.Infof("node: %s upgrade cmd: %s", s.HostIP(), cmd)
+	out, err := s.CombinedOutput(cmd)
+	if err != nil {
+		return fmt.Errorf("exec %q error: %w", cmd, err)
+	}
+	klog.Info(string(out))
+
+	return nil
+}
+
 // https://github.com/kubernetes/kubeadm/issues/1753
 func fixKubeadmBug1753(s ssh.Interface) error {
 	needUpdate := false
@@ -293,10 +385,10 @@ func fixKubeadmBug1753(s ssh.Interface) error {
 	return nil
 }
 
-func RestartControlPlane(s ssh.Interface) error {
+func RestartControlPlane(s ssh.Interface, runtime devopsv1.ContainerRuntimeType) error {
 	targets := []string{"kube-apiserver", "kube-controller-manager", "kube-scheduler"}
 	for _, one := range targets {
-		err := RestartContainerByFilter(s, DockerFilterForControlPlane(one))
+		err := RestartContainer(s, runtime, one)
 		if err != nil {
 			return err
 		}
@@ -305,12 +397,28 @@ func RestartControlPlane(s ssh.Interface) error {
 	return nil
 }
 
-func DockerFilterForControlPlane(name string) string {
-	return fmt.Sprintf("label=io.kubernetes.container.name=%s", name)
+// RestartContainer removes the running containers of the control plane component and waits
+// kubelet to start them again.
+// WaitAPIServer waits the apiserver of the master to be healthy, so the masters restarted one
+// by one are not down together.
+func WaitAPIServer(s ssh.Interface, port int) error {
+	cmd := fmt.Sprintf("curl --silent --max-time 2 --insecure https://127.0.0.1:%d/healthz", port)
+	err := wait.PollImmediate(5*time.Second, 5*time.Minute, func() (bool, error) {
+		output, err := s.CombinedOutput(cmd)
+		if err != nil {
+			return false, nil
+		}
+		return strings.TrimSpace(string(output)) == "ok", nil
+	})
+	if err != nil {
+		return fmt.Errorf("node: %s wait apiserver healthy error: %w", s.HostIP(), err)
+	}
+
+	return nil
 }
 
-func RestartContainerByFilter(s ssh.Interface, filter string) error {
-	cmd := fmt.Sprintf("docker rm -f $(docker ps -q -f '%s')", filter)
+func RestartContainer(s ssh.Interface, runtime devopsv1.ContainerRuntimeType, name string) error {
+	cmd := cri.RemoveCmd(runtime, name)
 	klog.V(4).Infof("node: %s, cmd: %s", s.HostIP(), cmd)
 	_, err := s.CombinedOutput(cmd)
 	if err != nil {
@@ -318,7 +426,7 @@ func RestartContainerByFilter(s ssh.Interface, filter string) error {
 	}
 
 	err = wait.PollImmediate(5*time.Second, 5*time.Minute, func() (bool, error) {
-		cmd = fmt.Sprintf("docker ps -q -f '%s'", filter)
+		cmd = cri.PsCmd(runtime, name)
 		klog.V(4).Infof("wait node: %s, cmd: %s", s.HostIP(), cmd)
 		output, err := s.CombinedOutput(cmd)
 		if err != nil {
@@ -330,7 +438,7 @@ func RestartContainerByFilter(s ssh.Interface, filter string) error {
 		return true, nil
 	})
 	if err != nil {
-		return fmt.Errorf("restart container(%s) error: %w", filter, err)
+		return fmt.Errorf("restart container(%s) error: %w", name, err)
 	}
 
 	return nil
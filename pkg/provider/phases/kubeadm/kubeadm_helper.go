@@ -12,7 +12,9 @@ import (
 	kubeproxyv1alpha1 "github.com/gostship/kunkka/pkg/apis/kubeproxy/config/v1alpha1"
 	"github.com/gostship/kunkka/pkg/constants"
 	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/provider/addons/metallb"
 	"github.com/gostship/kunkka/pkg/provider/config"
+	"github.com/gostship/kunkka/pkg/provider/phases/cri"
 	"github.com/gostship/kunkka/pkg/util/json"
 	"github.com/gostship/kunkka/pkg/util/k8sutil"
 	corev1 "k8s.io/api/core/v1"
@@ -87,7 +89,8 @@ func GetInitConfiguration(c *common.Cluster) *kubeadmv1beta2.InitConfiguration {
 
 	if len(c.Cluster.Spec.Machines) > 0 {
 		initCfg.NodeRegistration = kubeadmv1beta2.NodeRegistrationOptions{
-			Name: c.Spec.Machines[0].IP,
+			Name:      c.Spec.Machines[0].IP,
+			CRISocket: cri.Socket(c.Spec.GetContainerRuntime()),
 		}
 
 		initCfg.LocalAPIEndpoint = kubeadmv1beta2.APIEndpoint{
@@ -156,11 +159,17 @@ func GetKubeProxyConfiguration(c *common.Cluster) *kubeproxyv1alpha1.KubeProxyCo
 
 	return &kubeproxyv1alpha1.KubeProxyConfiguration{
 		Mode: kubeproxyv1alpha1.ProxyMode(kubeProxyMode),
+		// metallb layer2 needs the addresses of kube-ipvs0 not answering arp
+		IPVS: kubeproxyv1alpha1.KubeProxyIPVSConfiguration{
+			StrictARP: kubeProxyMode == "ipvs" && metallb.Enabled(c),
+		},
 	}
 }
 
 func GetKubeletConfiguration(c *common.Cluster) *kubeletv1beta1.KubeletConfiguration {
 	return &kubeletv1beta1.KubeletConfiguration{
+		// kubeadm only detects the cgroup driver of docker, containerd uses systemd too
+		CgroupDriver: "systemd",
 		KubeReserved: map[string]string{
 			"cpu":    "100m",
 			"memory": "500Mi",
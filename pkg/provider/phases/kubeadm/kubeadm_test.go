@@ -0,0 +1,133 @@
+package kubeadm
+
+import (
+	"bytes"
+	"crypto/x509"
+	"testing"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/constants"
+	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/provider/config"
+	"github.com/gostship/kunkka/pkg/util/pkiutil"
+	"github.com/gostship/kunkka/pkg/util/pointer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	certutil "k8s.io/client-go/util/cert"
+)
+
+func newCluster() *common.Cluster {
+	return &common.Cluster{
+		Cluster: &devopsv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "demo"},
+			Spec: devopsv1.ClusterSpec{
+				Version:   constants.K8sVersions[0],
+				DNSDomain: "cluster.local",
+				Machines:  []*devopsv1.ClusterMachine{{IP: "10.28.0.10"}},
+				Etcd:      &devopsv1.Etcd{Local: &devopsv1.LocalEtcd{}},
+				Properties: devopsv1.ClusterProperty{
+					MaxNodePodNum: pointer.ToInt32(256),
+				},
+			},
+			Status: devopsv1.ClusterStatus{ServiceCIDR: "10.96.0.0/16"},
+		},
+		ClusterCredential: &devopsv1.ClusterCredential{
+			CredentialInfo: devopsv1.CredentialInfo{
+				BootstrapToken: pointer.ToString("abcdef.0123456789abcdef"),
+				CertificateKey: pointer.ToString("key"),
+			},
+		},
+	}
+}
+
+func TestReissueCerts(t *testing.T) {
+	c := newCluster()
+	cfg := GetKubeadmConfig(c, &config.Config{}, "https://10.28.0.10:6443")
+	if err := InitCerts(cfg, c, false); err != nil {
+		t.Fatal(err)
+	}
+	init := make(map[string][]byte, len(c.ClusterCredential.CertsBinaryData))
+	for name, data := range c.ClusterCredential.CertsBinaryData {
+		init[name] = data
+	}
+	saKey := constants.CertificatesDir + "sa.key"
+
+	if err := ReissueCerts(cfg, c, false); err != nil {
+		t.Fatal(err)
+	}
+	current := c.ClusterCredential.CertsBinaryData
+	for _, name := range []string{constants.CACertName, constants.CAKeyName, constants.EtcdCACertName, saKey} {
+		if !bytes.Equal(current[name], init[name]) {
+			t.Errorf("ReissueCerts() changes %s", name)
+		}
+	}
+	if bytes.Equal(current[constants.APIServerCertName], init[constants.APIServerCertName]) {
+		t.Errorf("ReissueCerts() keeps %s", constants.APIServerCertName)
+	}
+}
+
+func TestRotateCAs(t *testing.T) {
+	c := newCluster()
+	cfg := GetKubeadmConfig(c, &config.Config{}, "https://10.28.0.10:6443")
+	if err := InitCerts(cfg, c, false); err != nil {
+		t.Fatal(err)
+	}
+	oldCA := c.ClusterCredential.CACert
+	oldKey := c.ClusterCredential.CAKey
+	apiserver := c.ClusterCredential.CertsBinaryData[constants.APIServerCertName]
+	saKey := constants.CertificatesDir + "sa.key"
+	sa := c.ClusterCredential.CertsBinaryData[saKey]
+
+	if err := TrustNewCAs(cfg, c, false); err != nil {
+		t.Fatal(err)
+	}
+	bundle := parseCerts(t, c.ClusterCredential.CACert)
+	if len(bundle) != 2 || !bytes.Equal(pkiutil.EncodeCertPEM(bundle[0]), oldCA) {
+		t.Fatalf("TrustNewCAs() ca = %d certificates, want the old one first and the new one", len(bundle))
+	}
+	if len(parseCerts(t, c.ClusterCredential.CertsBinaryData[constants.EtcdCACertName])) != 2 {
+		t.Error("TrustNewCAs() does not bundle the etcd ca")
+	}
+	if !bytes.Equal(c.ClusterCredential.CAKey, oldKey) ||
+		!bytes.Equal(c.ClusterCredential.CertsBinaryData[constants.APIServerCertName], apiserver) {
+		t.Error("TrustNewCAs() changes the key of the ca or the certificates")
+	}
+	newCA := pkiutil.EncodeCertPEM(bundle[1])
+
+	if err := IssueWithNewCAs(cfg, c, false); err != nil {
+		t.Fatal(err)
+	}
+	bundle = parseCerts(t, c.ClusterCredential.CACert)
+	if len(bundle) != 2 || !bytes.Equal(pkiutil.EncodeCertPEM(bundle[0]), newCA) ||
+		!bytes.Equal(pkiutil.EncodeCertPEM(bundle[1]), oldCA) {
+		t.Fatal("IssueWithNewCAs() ca, want the new one first and the old one")
+	}
+	if c.ClusterCredential.CARotation.Phase != devopsv1.CARotationReissue || c.ClusterCredential.CARotation.Keys != nil {
+		t.Errorf("IssueWithNewCAs() rotation = %+v", c.ClusterCredential.CARotation)
+	}
+	cert := parseCerts(t, c.ClusterCredential.CertsBinaryData[constants.APIServerCertName])[0]
+	if err := cert.CheckSignatureFrom(bundle[0]); err != nil {
+		t.Errorf("IssueWithNewCAs() apiserver certificate is not issued by the new ca: %v", err)
+	}
+	if !bytes.Equal(c.ClusterCredential.CertsBinaryData[saKey], sa) {
+		t.Error("IssueWithNewCAs() changes the service account key")
+	}
+
+	if err := DropOldCAs(cfg, c, false); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(c.ClusterCredential.CACert, newCA) ||
+		len(parseCerts(t, c.ClusterCredential.CertsBinaryData[constants.EtcdCACertName])) != 1 {
+		t.Error("DropOldCAs() keeps the old ca")
+	}
+	if c.ClusterCredential.CARotation.Phase != devopsv1.CARotationDropOld {
+		t.Errorf("DropOldCAs() phase = %s", c.ClusterCredential.CARotation.Phase)
+	}
+}
+
+func parseCerts(t *testing.T, data []byte) []*x509.Certificate {
+	list, err := certutil.ParseCertsPEM(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return list
+}
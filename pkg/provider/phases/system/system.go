@@ -10,6 +10,10 @@ import (
 	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
 	"github.com/gostship/kunkka/pkg/constants"
 	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/provider/config"
+	"github.com/gostship/kunkka/pkg/provider/phases/cri"
+	"github.com/gostship/kunkka/pkg/provider/phases/hostos"
+	"github.com/gostship/kunkka/pkg/provider/phases/joinnode"
 	"github.com/gostship/kunkka/pkg/util/ssh"
 	"github.com/gostship/kunkka/pkg/util/template"
 	"github.com/pkg/errors"
@@ -28,23 +32,79 @@ type Option struct {
 	ResolvConf         string
 	CentosVersion      string
 	ExtraArgs          map[string]string
+
+	ContainerRuntime             string
+	ContainerdVersion            string
+	ContainerdInsecureRegistries []string
+	CRISocket                    string
+	PauseImage                   string
+
+	OSFamily      string
+	OSID          string
+	OSCodename    string
+	ChronyService string
 }
 
-func Install(s ssh.Interface, c *common.Cluster) error {
+func newOption(c *common.Cluster, cfg *config.Config, hostIP string, runtime devopsv1.ContainerRuntimeType, o *hostos.OS) *Option {
 	dockerVersion := "19.03.9"
 	if v, ok := c.Spec.DockerExtraArgs["version"]; ok {
 		dockerVersion = v
 	}
+	containerdVersion := "1.4.3"
+	if v, ok := c.Spec.ContainerdExtraArgs["version"]; ok {
+		containerdVersion = v
+	}
+	var insecureRegistries []string
+	if v, ok := c.Spec.ContainerdExtraArgs["insecure-registries"]; ok && v != "" {
+		insecureRegistries = strings.Split(v, ",")
+	}
 	option := &Option{
 		K8sVersion:    c.Spec.Version,
 		DockerVersion: dockerVersion,
 		Cgroupdriver:  "systemd", // cgroupfs or systemd
 		ExtraArgs:     c.Spec.KubeletExtraArgs,
-		HostIP:        s.HostIP(),
+		HostIP:        hostIP,
 		KernelRepo:    "yum-mirrors.example.com",
+
+		ContainerRuntime:             string(runtime),
+		ContainerdVersion:            containerdVersion,
+		ContainerdInsecureRegistries: insecureRegistries,
+		CRISocket:                    cri.Socket(runtime),
+		PauseImage:                   joinnode.GetPauseImage(cfg.Registry.Prefix),
+
+		OSFamily:      string(o.Family),
+		OSID:          o.ID,
+		OSCodename:    o.Codename,
+		ChronyService: "chronyd",
+	}
+	switch o.Family {
+	case hostos.RHEL:
+		if o.ID == "centos" {
+			option.CentosVersion = o.MajorVersion()
+		}
+	case hostos.Debian:
+		option.ChronyService = "chrony"
 	}
+	return option
+}
+
+// initShellTemplate returns the init system script template of the os family.
+func initShellTemplate(family hostos.Family) string {
+	if family == hostos.Debian {
+		return initShellHeader + debianShellTemplate + commonShellTemplate
+	}
+	return initShellHeader + rhelShellTemplate + commonShellTemplate
+}
 
-	initData, err := template.ParseString(initShellTemplate, option)
+// BuildInitScript renders the init system script of the host.
+func BuildInitScript(c *common.Cluster, cfg *config.Config, hostIP string, runtime devopsv1.ContainerRuntimeType, o *hostos.OS) ([]byte, error) {
+	return template.ParseString(initShellTemplate(o.Family), newOption(c, cfg, hostIP, runtime, o))
+}
+
+// Install initializes the system of the host of the os and installs the container runtime.
+func Install(s ssh.Interface, c *common.Cluster, cfg *config.Config, runtime devopsv1.ContainerRuntimeType, o *hostos.OS) error {
+	option := newOption(c, cfg, s.HostIP(), runtime, o)
+	initData, err := template.ParseString(initShellTemplate(o.Family), option)
 	if err != nil {
 		return err
 	}
@@ -81,6 +141,10 @@ func Install(s ssh.Interface, c *common.Cluster) error {
 	if kernelVersion >= 4 {
 		return nil
 	}
+	// only the rhel hosts install a new kernel in the init script
+	if o.Family != hostos.RHEL {
+		return errors.Errorf("node: %s kernel %s is older than 4.x", option.HostIP, versionStr)
+	}
 
 	klog.Infof("node: %s now kernel: %s,  start reboot ... ", option.HostIP, string(result))
 	_, _ = s.CombinedOutput("reboot")
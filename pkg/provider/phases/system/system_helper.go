@@ -1,12 +1,15 @@
 package system
 
 const (
-	initShellTemplate = `
+	initShellHeader = `
 #!/usr/bin/env bash
 
 set -xeuo pipefail
 
-function Update_yumrepo() {
+`
+
+	// rhelShellTemplate defines the package and system functions of the rhel hosts.
+	rhelShellTemplate = `function Update_repo() {
     mkdir -p /etc/yum.repos.d/repoBakDir
     mv /etc/yum.repos.d/*.repo /etc/yum.repos.d/repoBakDir/
 	rm -rvf /etc/yum.repos.d/*.repo
@@ -38,7 +41,7 @@ function Update_kernel() {
     yum remove -y $(rpm -qa|grep kernel|grep 3.10)
 }
 
-function Firewalld_process() {
+function Disable_firewall() {
     grep SELINUX=disabled /etc/selinux/config && echo -e "\033[32;32m 已关闭防火墙，退出防火墙设置 \033[0m \n" && return
 
     echo -e "\033[32;32m 关闭防火墙 \033[0m \n"
@@ -81,7 +84,91 @@ EOF
     chmod 755 /etc/sysconfig/modules/ipvs.modules && bash /etc/sysconfig/modules/ipvs.modules && lsmod | grep -e ip_vs -e nf_conntrack
 }
 
-function Install_depend_environment(){
+function Install_docker_package(){
+    yum-config-manager --add-repo http://mirrors.aliyun.com/docker-ce/linux/centos/docker-ce.repo
+    yum makecache fast
+    yum install -y docker-ce-{{ .DockerVersion }} docker-ce-cli-{{ .DockerVersion }}
+}
+
+function Install_containerd_package(){
+    yum-config-manager --add-repo http://mirrors.aliyun.com/docker-ce/linux/centos/docker-ce.repo
+    yum makecache fast
+    yum install -y containerd.io-{{ .ContainerdVersion }}
+}
+
+`
+
+	// debianShellTemplate defines the package and system functions of the debian hosts.
+	debianShellTemplate = `function Update_repo() {
+    export DEBIAN_FRONTEND=noninteractive
+    apt-get update
+}
+
+function Update_kernel() {
+    echo -e "\033[32;32m {{ .OSID }} 使用发行版内核，跳过内核升级 \033[0m \n"
+}
+
+function Disable_firewall() {
+    if command -v ufw &> /dev/null; then
+      echo -e "\033[32;32m 关闭防火墙 \033[0m \n"
+      ufw disable
+    fi
+
+    echo -e "\033[32;32m 关闭swap \033[0m \n"
+    swapoff -a && sed -i '/ swap / s/^\(.*\)$/#\1/g' /etc/fstab
+}
+
+function Install_depend_software(){
+    echo -e "\033[32;32m 开始安装依赖环境包 \033[0m \n"
+    apt-get install -y --no-install-recommends nfs-common curl apt-transport-https ca-certificates gnupg \
+           net-tools conntrack wget vim ntpdate libseccomp2 telnet \
+           ipvsadm ipset bridge-utils tree tcpdump bash-completion sysstat chrony jq psmisc socat \
+           iproute2 dstat lsof perl dnsutils ebtables ethtool
+}
+
+function Install_ipvs(){
+    if [ -f /etc/modules-load.d/ipvs.conf ]; then
+      echo -e "\033[32;32m 已完成系统ipvs配置 \033[0m \n"
+      return
+    fi
+
+    echo -e "\033[32;32m 开始配置系统ipvs \033[0m \n"
+    ipvs_modules="ip_vs ip_vs_lc ip_vs_wlc ip_vs_rr ip_vs_wrr ip_vs_lblc ip_vs_lblcr ip_vs_dh ip_vs_sh ip_vs_fo ip_vs_nq ip_vs_sed ip_vs_ftp nf_conntrack"
+    for kernel_module in ${ipvs_modules}; do
+      if /sbin/modinfo -F filename ${kernel_module} > /dev/null 2>&1; then
+        /sbin/modprobe ${kernel_module}
+        echo ${kernel_module} >> /etc/modules-load.d/ipvs.conf.tmp
+      fi
+    done
+    mv /etc/modules-load.d/ipvs.conf.tmp /etc/modules-load.d/ipvs.conf
+    lsmod | grep -e ip_vs -e nf_conntrack
+}
+
+function Add_docker_repo(){
+    curl -fsSL https://mirrors.aliyun.com/docker-ce/linux/{{ .OSID }}/gpg | apt-key add -
+    echo "deb [arch=$(dpkg --print-architecture)] https://mirrors.aliyun.com/docker-ce/linux/{{ .OSID }} {{ .OSCodename }} stable" > /etc/apt/sources.list.d/docker-ce.list
+    apt-get update
+}
+
+function Install_docker_package(){
+    Add_docker_repo
+    version=$(apt-cache madison docker-ce | awk '{print $3}' | grep -m1 ":{{ .DockerVersion }}~")
+    apt-get install -y docker-ce=${version} docker-ce-cli=${version}
+    apt-mark hold docker-ce docker-ce-cli
+}
+
+function Install_containerd_package(){
+    Add_docker_repo
+    version=$(apt-cache madison containerd.io | awk '{print $3}' | grep -m1 "^{{ .ContainerdVersion }}-")
+    apt-get install -y containerd.io=${version}
+    apt-mark hold containerd.io
+}
+
+`
+
+	// commonShellTemplate configures the system and the container runtime by the
+	// functions of the os family.
+	commonShellTemplate = `function Install_depend_environment(){
     if [ -f /etc/sysctl.d/k8s.conf ]; then
       echo -e "\033[32;32m  k8s.conf已存在；备份文件为k8s.conf.bak \033[0m \n" 
       cp /etc/sysctl.d/k8s.conf{,.bak}
@@ -138,7 +225,9 @@ net.ipv4.tcp_syncookies = 1
 net.netfilter.nf_conntrack_max = 2310720
 fs.inotify.max_user_watches = 89100
 fs.inotify.max_user_instances = 8192
+{{- if eq .OSFamily "rhel" }}
 fs.may_detach_mounts = 1
+{{- end }}
 fs.file-max = 52706963
 fs.nr_open = 52706963
 vm.swappiness = 0
@@ -152,7 +241,7 @@ EOF
     chattr +i /etc/sysctl.d/k8s.conf
     sysctl --system
     sysctl -p /etc/sysctl.d/k8s.conf
-    systemctl enable chronyd && systemctl start chronyd && chronyc sources
+    systemctl enable {{ .ChronyService }} && systemctl start {{ .ChronyService }} && chronyc sources
 }
 
 function Install_docker(){
@@ -162,9 +251,7 @@ function Install_docker(){
     fi
     
     echo -e "\033[32;32m 开始安装docker \033[0m \n" 
-    yum-config-manager --add-repo http://mirrors.aliyun.com/docker-ce/linux/centos/docker-ce.repo 
-    yum makecache fast
-    yum install -y docker-ce-{{ .DockerVersion }} docker-ce-cli-{{ .DockerVersion }}
+    Install_docker_package
 
     echo -e "\033[32;32m 开始写 docker daemon.json\033[0m \n"
     mkdir -p /etc/docker
@@ -205,14 +292,69 @@ EOF
     systemctl enable docker && systemctl daemon-reload && systemctl restart docker
 }
 
+function Install_containerd(){
+    if [ -f /etc/containerd/config.toml ] && grep -q SystemdCgroup /etc/containerd/config.toml; then
+      echo -e "\033[32;32m 已完成containerd安装 \033[0m \n"
+      return
+    fi
+
+    echo -e "\033[32;32m 开始安装containerd \033[0m \n"
+    Install_containerd_package
+
+    cat > /etc/modules-load.d/containerd.conf <<EOF
+overlay
+br_netfilter
+EOF
+    modprobe overlay
+
+    echo -e "\033[32;32m 开始写 containerd config.toml\033[0m \n"
+    mkdir -p /etc/containerd
+    cat > /etc/containerd/config.toml <<EOF
+version = 2
+root = "/var/lib/containerd"
+state = "/run/containerd"
+
+[grpc]
+  address = "{{ .CRISocket }}"
+
+[plugins."io.containerd.grpc.v1.cri"]
+  sandbox_image = "{{ .PauseImage }}"
+  [plugins."io.containerd.grpc.v1.cri".containerd]
+    snapshotter = "overlayfs"
+    default_runtime_name = "runc"
+    [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc]
+      runtime_type = "io.containerd.runc.v2"
+      [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc.options]
+        SystemdCgroup = {{ if eq (default "systemd" .Cgroupdriver) "systemd" }}true{{ else }}false{{ end }}
+  [plugins."io.containerd.grpc.v1.cri".cni]
+    bin_dir = "/opt/cni/bin"
+    conf_dir = "/etc/cni/net.d"
+  [plugins."io.containerd.grpc.v1.cri".registry.mirrors."docker.io"]
+    endpoint = ["https://mirror.ccs.tencentyun.com", "https://4xr1qpsp.mirror.aliyuncs.com"]
+{{- range .ContainerdInsecureRegistries }}
+  [plugins."io.containerd.grpc.v1.cri".registry.mirrors."{{ . }}"]
+    endpoint = ["http://{{ . }}"]
+  [plugins."io.containerd.grpc.v1.cri".registry.configs."{{ . }}".tls]
+    insecure_skip_verify = true
+{{- end }}
+EOF
+
+    cat > /etc/crictl.yaml <<EOF
+runtime-endpoint: unix://{{ .CRISocket }}
+image-endpoint: unix://{{ .CRISocket }}
+timeout: 10
+EOF
+    systemctl enable containerd && systemctl daemon-reload && systemctl restart containerd
+}
+
 # 初始化顺序
 echo -e "\033[32;32m 开始初始化结点 @{{ .HostIP }}@ \033[0m \n"
-Update_yumrepo && \
-Firewalld_process && \
+Update_repo && \
+Disable_firewall && \
 Install_depend_software && \
 Install_ipvs && \
 Install_depend_environment && \
-Install_docker && \
+{{ if eq .ContainerRuntime "containerd" }}Install_containerd{{ else }}Install_docker{{ end }} && \
 Update_kernel
 `
 )
@@ -0,0 +1,194 @@
+package upgrade
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/provider/condlog"
+	"github.com/gostship/kunkka/pkg/provider/config"
+	"github.com/gostship/kunkka/pkg/provider/phases/component"
+	"github.com/gostship/kunkka/pkg/provider/phases/drain"
+	"github.com/gostship/kunkka/pkg/provider/phases/kubeadm"
+	"github.com/gostship/kunkka/pkg/util/apiclient"
+	"github.com/gostship/kunkka/pkg/util/ssh"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ConditionTypeUpgradeKubelet records the kubelet upgrade progress of a machine.
+	ConditionTypeUpgradeKubelet = "EnsureUpgradeKubelet"
+
+	reasonUpgrading      = "Upgrading"
+	reasonFailedUpgrade  = "FailedUpgrade"
+	reasonUpgradeSuccess = "SuccessfulUpgrade"
+
+	nodeReadyInterval = 5 * time.Second
+	nodeReadyTimeout  = 5 * time.Minute
+)
+
+// NodeConfigurer applies the configuration of the spec version to the worker node after its
+// binaries are upgraded and before its kubelet restarts.
+type NodeConfigurer func(s ssh.Interface, c *common.Cluster) error
+
+// KubeadmNode upgrades the kubelet configuration of the worker node with kubeadm upgrade node.
+func KubeadmNode(s ssh.Interface, c *common.Cluster) error {
+	return kubeadm.UpgradeNode(s)
+}
+
+// Kubelet drains the node, upgrades the kubernetes binaries of the node to the cluster spec
+// version, runs the configurer if any, restarts the kubelet and uncordons the node once it is
+// ready with the new version.
+func Kubelet(ctx context.Context, s ssh.Interface, c *common.Cluster, cfg *config.Config, cli kubernetes.Interface,
+	configure NodeConfigurer) error {
+	// the nodes are registered with their ip
+	name := s.HostIP()
+	err := drainNode(ctx, cli, name, cfg.Drain)
+	if err != nil {
+		return err
+	}
+
+	err = component.UpgradeBinaries(s, c, cfg)
+	if err != nil {
+		return err
+	}
+
+	if configure != nil {
+		err = configure(s, c)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = component.RestartKubelet(s)
+	if err != nil {
+		return err
+	}
+
+	err = WaitNodeVersion(ctx, cli, name, c.Spec.Version)
+	if err != nil {
+		return err
+	}
+
+	return drain.Uncordon(ctx, cli, name)
+}
+
+// drainNode cordons the node and drains its pods until the drain timeout, the pods left are
+// deleted if the drain is forced, or the upgrade of the node fails and the node stays cordoned.
+func drainNode(ctx context.Context, cli kubernetes.Interface, name string, policy config.Drain) error {
+	opts := drain.Options{
+		Timeout:         policy.Timeout,
+		Force:           policy.Force,
+		DeleteLocalData: policy.DeleteLocalData,
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = config.DefaultDrain.Timeout
+	}
+	return drain.CordonAndDrain(ctx, cli, name, opts)
+}
+
+// WaitNodeVersion waits the node with the ip ready and the kubelet runs the version.
+func WaitNodeVersion(ctx context.Context, cli kubernetes.Interface, ip string, version string) error {
+	err := wait.PollImmediate(nodeReadyInterval, nodeReadyTimeout, func() (bool, error) {
+		ok, err := apiclient.CheckNodeVersion(ctx, cli, ip, version)
+		if err != nil {
+			klog.Warningf("node: %s check version err: %v", ip, err)
+			return false, nil
+		}
+		return ok, nil
+	})
+	if err != nil {
+		return fmt.Errorf("node: %s wait ready with version %s err: %v", ip, version, err)
+	}
+
+	return nil
+}
+
+// Machines upgrades the kubelet of the cluster machines one by one with the configurer, the
+// progress of each machine is recorded in the machine conditions.
+func Machines(ctx context.Context, c *common.Cluster, cfg *config.Config, configure NodeConfigurer) error {
+	clusterCtx, err := c.ClusterManager.Get(c.Name)
+	if err != nil {
+		return err
+	}
+
+	ms := &devopsv1.MachineList{}
+	err = c.Client.List(ctx, ms, client.InNamespace(c.Namespace))
+	if err != nil {
+		return err
+	}
+
+	machines := make([]*devopsv1.Machine, 0, len(ms.Items))
+	for i := range ms.Items {
+		m := &ms.Items[i]
+		if m.Spec.ClusterName != c.Name || m.Spec.Machine == nil || !m.ObjectMeta.DeletionTimestamp.IsZero() {
+			continue
+		}
+		machines = append(machines, m)
+	}
+	sort.Slice(machines, func(i, j int) bool {
+		return machines[i].Name < machines[j].Name
+	})
+
+	for _, m := range machines {
+		if m.Status.MachineInfo.KubeletVersion == c.Spec.Version {
+			continue
+		}
+
+		klog.Infof("cluster: %s upgrade machine: %s kubelet to %s", c.Name, m.Name, c.Spec.Version)
+		setCondition(m, devopsv1.ConditionUnknown, reasonUpgrading, fmt.Sprintf("upgrading to %s", c.Spec.Version))
+		err = c.Client.Status().Update(ctx, m)
+		if err != nil {
+			return err
+		}
+
+		err = machineKubelet(ctx, m, c, cfg, clusterCtx.KubeCli, configure)
+		if err != nil {
+			klog.Errorf("cluster: %s upgrade machine: %s err: %v", c.Name, m.Name, err)
+			setCondition(m, devopsv1.ConditionFalse, reasonFailedUpgrade, err.Error())
+			if uerr := c.Client.Status().Update(ctx, m); uerr != nil {
+				klog.Errorf("update machine: %s status err: %v", m.Name, uerr)
+			}
+			return errors.Wrap(err, m.Name)
+		}
+
+		setCondition(m, devopsv1.ConditionTrue, reasonUpgradeSuccess, "")
+		m.Status.MachineInfo.KubeletVersion = c.Spec.Version
+		err = c.Client.Status().Update(ctx, m)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func machineKubelet(ctx context.Context, m *devopsv1.Machine, c *common.Cluster, cfg *config.Config, cli kubernetes.Interface,
+	configure NodeConfigurer) error {
+	sh, err := condlog.SSH(ctx, &m.Spec)
+	if err != nil {
+		return err
+	}
+
+	return Kubelet(ctx, sh, c, cfg, cli, configure)
+}
+
+func setCondition(m *devopsv1.Machine, status devopsv1.ConditionStatus, reason, message string) {
+	now := metav1.Now()
+	m.SetCondition(devopsv1.MachineCondition{
+		Type:               ConditionTypeUpgradeKubelet,
+		Status:             status,
+		LastProbeTime:      now,
+		LastTransitionTime: now,
+		Reason:             reason,
+		Message:            message,
+	})
+}
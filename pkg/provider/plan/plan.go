@@ -0,0 +1,169 @@
+/*
+Copyright 2020 dke.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"context"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// ActionRun means the handler would run.
+	ActionRun = "Run"
+	// ActionSkip means the handler would be skipped by the SkipConditions.
+	ActionSkip = "Skip"
+	// ActionDone means the condition of the handler is already true.
+	ActionDone = "Done"
+
+	// DataKey is the key of the plan in the ConfigMap, the other keys are the artifacts.
+	DataKey = "plan.yaml"
+)
+
+// Step is a create handler in the plan.
+type Step struct {
+	Condition string `json:"condition"`
+	Action    string `json:"action"`
+}
+
+// Plan is the result of a dry run of the create handlers, nothing is written to the hosts.
+type Plan struct {
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Generation int64  `json:"generation"`
+	Steps      []Step `json:"steps"`
+	// Errors are the validation errors.
+	Errors []string `json:"errors,omitempty"`
+	// Preflight is the read-only preflight result of each host.
+	Preflight map[string]string `json:"preflight,omitempty"`
+	// Artifacts are the files the handlers would write, keyed by the ConfigMap key.
+	Artifacts map[string]string `json:"-"`
+}
+
+// New returns a empty plan of the object.
+func New(kind string, obj metav1.Object) *Plan {
+	return &Plan{
+		Kind:       kind,
+		Name:       obj.GetName(),
+		Generation: obj.GetGeneration(),
+		Preflight:  make(map[string]string),
+		Artifacts:  make(map[string]string),
+	}
+}
+
+// AddStep records the action of the handler.
+func (p *Plan) AddStep(condition string, action string) {
+	p.Steps = append(p.Steps, Step{Condition: condition, Action: action})
+}
+
+// AddArtifact records a rendered file.
+func (p *Plan) AddArtifact(key string, data []byte) {
+	p.Artifacts[key] = string(data)
+}
+
+// ConfigMapName returns the name of the plan ConfigMap of the object.
+func ConfigMapName(name string) string {
+	return name + "-plan"
+}
+
+// ToConfigMap saves the plan and its artifacts in a ConfigMap.
+func (p *Plan) ToConfigMap(namespace string) (*corev1.ConfigMap, error) {
+	data, err := yaml.Marshal(p)
+	if err != nil {
+		return nil, errors.Wrapf(err, "marshal plan of %s", p.Name)
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ConfigMapName(p.Name),
+			Namespace: namespace,
+		},
+		Data: map[string]string{DataKey: string(data)},
+	}
+	for k, v := range p.Artifacts {
+		cm.Data[k] = v
+	}
+	return cm, nil
+}
+
+// FromConfigMap loads the plan saved by ToConfigMap.
+func FromConfigMap(cm *corev1.ConfigMap) (*Plan, error) {
+	p := &Plan{}
+	if err := yaml.Unmarshal([]byte(cm.Data[DataKey]), p); err != nil {
+		return nil, errors.Wrapf(err, "unmarshal plan %s/%s", cm.Namespace, cm.Name)
+	}
+	p.Artifacts = make(map[string]string, len(cm.Data))
+	for k, v := range cm.Data {
+		if k != DataKey {
+			p.Artifacts[k] = v
+		}
+	}
+	return p, nil
+}
+
+// PreflightResult returns the preflight result of a host.
+func PreflightResult(err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return "ok"
+}
+
+// Load returns the saved plan of the object, or nil if there is none.
+func Load(ctx context.Context, cli client.Client, namespace string, name string) (*Plan, error) {
+	cm := &corev1.ConfigMap{}
+	err := cli.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ConfigMapName(name)}, cm)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return FromConfigMap(cm)
+}
+
+// Save creates or replaces the plan ConfigMap owned by the object, so it is
+// deleted with the object.
+func Save(ctx context.Context, cli client.Client, scheme *runtime.Scheme, owner metav1.Object, p *Plan) error {
+	cm, err := p.ToConfigMap(owner.GetNamespace())
+	if err != nil {
+		return err
+	}
+	if err := controllerutil.SetControllerReference(owner, cm, scheme); err != nil {
+		return err
+	}
+
+	old := &corev1.ConfigMap{}
+	err = cli.Get(ctx, types.NamespacedName{Namespace: cm.Namespace, Name: cm.Name}, old)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		return cli.Create(ctx, cm)
+	}
+	old.Data = cm.Data
+	old.OwnerReferences = cm.OwnerReferences
+	return cli.Update(ctx, old)
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/gostship/kunkka/pkg/constants"
 	"github.com/gostship/kunkka/pkg/controllers/common"
+	"github.com/gostship/kunkka/pkg/provider/phases/hostos"
 	"github.com/gostship/kunkka/pkg/util/ssh"
 	"k8s.io/klog"
 )
@@ -23,6 +24,7 @@ var tools = []string{"ipvsadm", "modprobe", "modinfo", "ip", "awk", "iptables"}
 func newCommonChecks(s ssh.Interface) []Checker {
 	return []Checker{
 		IsPrivilegedUserCheck{Interface: s},
+		OSCheck{Interface: s},
 		CPUArchCeck{Interface: s, Arch: 64},
 		KernelCheck{Interface: s, MinKernelVersion: 4, MinMajorVersion: 10},
 		// KernelModuleCheck{Interface: s, Module: "iptable_nat"},
@@ -142,6 +144,24 @@ func (ncc NumCPUCheck) Check() (warnings, errorList []error) {
 	return warnings, errorList
 }
 
+// OSCheck checks the distro of the host is supported
+type OSCheck struct {
+	ssh.Interface
+}
+
+// Name returns the label for OSCheck
+func (OSCheck) Name() string {
+	return "OS"
+}
+
+// Check checks the os-release of the host is RHEL or Debian like
+func (oc OSCheck) Check() (warnings, errorList []error) {
+	if _, err := hostos.Detect(oc.Interface); err != nil {
+		errorList = append(errorList, err)
+	}
+	return warnings, errorList
+}
+
 // CPUArchCeck checks cpu arch
 type CPUArchCeck struct {
 	ssh.Interface
@@ -7,7 +7,6 @@ import (
 	"compress/gzip"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"net/http"
 	"os"
 	pathpkg "path"
@@ -19,34 +18,122 @@ var CRDs = func() http.FileSystem {
 	fs := vfsgen۰FS{
 		"/": &vfsgen۰DirInfo{
 			name:    "/",
-			modTime: time.Date(2020, 6, 1, 9, 32, 7, 422423766, time.UTC),
+			modTime: time.Date(2026, 10, 18, 8, 7, 57, 11061004, time.UTC),
+		},
+		"/_.yaml": &vfsgen۰CompressedFileInfo{
+			name:             "_.yaml",
+			modTime:          time.Date(2021, 4, 6, 8, 24, 15, 0, time.UTC),
+			uncompressedSize: 340,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8c\x90\xb1\x6e\xf3\x30\x0c\x84\x77\x3d\x05\x91\xdd\xfe\x13\xfc\x4b\xe1\xb5\x9d\x3b\x14\x45\x77\x5a\x62\x03\xc2\x12\x29\x88\x54\xd0\xc7\x2f\x2c\x3b\x7b\x37\xdd\x41\xbc\xef\xc8\x30\x4d\x53\xc0\xca\x5f\xd4\x8c\x55\x16\xc0\xca\xf4\xe3\x24\xbb\xb2\x79\x7b\xb1\x99\xf5\xdf\xe3\xb6\x92\xe3\x2d\x6c\x2c\x69\x81\xd7\x6e\xae\xe5\x83\x4c\x7b\x8b\xf4\x46\xdf\x2c\xec\xac\x12\x0a\x39\x26\x74\x5c\x02\x00\x8a\xa8\xe3\x6e\xdb\x2e\x01\xa2\x8a\x37\xcd\x99\xda\x74\x27\x99\xb7\xbe\xd2\xda\x39\x27\x6a\x83\xf0\xe4\x3f\xae\xf3\xff\xf9\x1a\x00\x62\xa3\x31\xfe\xc9\x85\xcc\xb1\xd4\x05\xa4\xe7\x1c\xac\x52\xdc\x13\xef\x4d\x7b\x5d\xe0\x72\x09\x00\x82\x85\x4e\xcc\x51\x71\xb8\x00\x35\xf7\x86\xf9\x94\x16\xb5\xd2\x78\x9b\xa3\xf7\xf1\x1f\x63\xa4\xea\x94\xde\xff\x12\x10\x55\x12\x1f\x1b\x1d\x55\x00\xcc\xb5\x51\x3a\x8f\xf7\xb4\x7f\x07\x00\xb4\x53\x8f\x78\x54\x01\x00\x00"),
 		},
 		"/devops.gostship.io_clustercredentials.yaml": &vfsgen۰CompressedFileInfo{
 			name:             "devops.gostship.io_clustercredentials.yaml",
-			modTime:          time.Date(2020, 6, 1, 9, 32, 7, 423457834, time.UTC),
-			uncompressedSize: 2708,
+			modTime:          time.Date(2026, 10, 18, 7, 12, 0, 869920956, time.UTC),
+			uncompressedSize: 3953,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x56\x4f\x8f\xdc\xb6\x0f\xbd\xfb\x53\x10\xf9\x1d\x72\xf9\xd9\xb3\x8b\xa0\x40\xeb\xdb\x62\x92\x02\x8b\x6d\x83\x20\xbb\x58\x14\x28\x7a\x90\x25\x8e\x87\x59\x5b\x52\x49\x6a\xd0\xe9\xa7\x2f\x24\x7b\xfe\x6d\x27\x9d\x34\x9b\xfa\x66\x8a\x7a\x7c\xa2\xc8\x27\x56\x75\x5d\x57\x26\xd2\x23\xb2\x50\xf0\x2d\x98\x48\xf8\x87\xa2\xcf\x7f\xd2\x3c\x7d\x2f\x0d\x85\xc5\xe6\xba\x43\x35\xd7\xd5\x13\x79\xd7\xc2\x32\x89\x86\xf1\x23\x4a\x48\x6c\xf1\x2d\xae\xc8\x93\x52\xf0\xd5\x88\x6a\x9c\x51\xd3\x56\x00\xc6\xfb\xa0\x26\x9b\x25\xff\x02\xd8\xe0\x95\xc3\x30\x20\xd7\x3d\xfa\xe6\x29\x75\xd8\x25\x1a\x1c\x72\x89\xb0\x8b\xbf\xb9\x6a\xde\x34\x57\x15\x80\x65\x2c\xdb\x1f\x68\x44\x51\x33\xc6\x16\x7c\x1a\x86\x0a\xc0\x9b\x11\x5b\xb0\x43\x12\x45\xb6\x8c\x0e\xbd\x92\x19\xa4\x71\xb8\x09\x51\x9a\x3e\x88\xca\x9a\x62\x43\xa1\x92\x88\x36\xc7\xef\x39\xa4\xd8\xc2\x19\x8f\x09\x6f\x26\x39\x1f\x70\x82\x5e\xee\xa1\xcb\xda\x40\xa2\x77\xe7\xd7\x7f\x22\xd1\xe2\x13\x87\xc4\x66\x38\x47\xae\x2c\x0b\xf9\x3e\x0d\x86\xcf\x38\x54\x00\x62\x43\xc4\x16\xde\x67\x3a\xd1\x58\x74\x15\xc0\xc6\x0c\xe4\x4a\x1e\x26\x82\x21\xa2\xbf\xf9\x70\xfb\xf8\xe6\xde\xae\x71\x34\x93\x11\xc0\xa1\x58\xa6\x58\xfc\xfe\x4e\x0f\x18\x6d\x60\x27\xa0\x6b\x84\x43\x48\x20\xbf\x0a\x3c\x16\x74\xf0\x88\x0e\x1d\x68\x98\x11\x01\x8c\xb5\x28\xf3\x9e\x09\xb1\x99\xd7\x22\x87\x88\xac\xb4\xcb\x5a\xf1\x3e\xd4\xd0\xde\xf6\x8c\xd7\xeb\x4c\x7c\xf2\x01\x97\xab\x06\x27\xf4\xf9\xee\xd1\x81\x94\x43\x41\x58\x81\xae\x49\x80\x31\x32\x0a\xfa\xa9\x8e\x8e\x60\x21\xbb\x18\x0f\xa1\xfb\x84\x56\x1b\xb8\x47\xce\x20\x20\xeb\x90\x06\x97\x4b\x6d\x83\xac\xe5\xd8\xbd\xa7\x3f\xf7\xc8\x02\x1a\x4a\xc8\xc1\x28\xce\x57\xb6\xfb\xc8\x2b\xb2\x37\x43\x4e\x79\xc2\xff\x83\xf1\x0e\x46\xb3\x05\xc6\x1c\x03\x92\x3f\x42\x2b\x2e\xd2\xc0\xcf\x81\xb1\x64\xb1\x85\xb5\x6a\x94\x76\xb1\xe8\x49\x77\x5d\x63\xc3\x38\x26\x4f\xba\x5d\x94\xda\xa7\x2e\x69\x60\x59\x38\xdc\xe0\xb0\x10\xea\x6b\xc3\x76\x4d\x8a\x56\x13\xe3\xc2\x44\xaa\x0b\x71\x5f\x9a\xa6\x19\xdd\xff\x78\x6e\x31\x79\x7d\xc4\x54\xb7\xb9\x48\x44\x99\x7c\xbf\x37\x77\x21\xa8\x28\x9b\xf8\x10\x9e\xf0\xf3\x37\xf0\x63\x60\xc8\x8d\x67\xdc\x08\xb9\x69\x21\x30\x7c\x0a\xe4\x2f\xc1\x5b\xb3\x44\xd6\x7f\x84\xb5\xc1\xfb\x9c\xa7\xa3\x72\x39\x72\x9f\xea\xac\x85\x6e\xab\x78\x39\xd8\x1d\x6e\xdb\xaf\xdd\x9c\xeb\x72\x45\xd6\x28\x3e\x43\xf9\x36\x89\x18\x08\xbd\x5e\x4c\x46\x86\xae\x4d\x24\x29\x75\x09\xbf\x7c\x77\xf5\x03\x98\xa4\xeb\xaf\x3d\x54\x89\xfa\x25\xe7\xf9\xa6\x41\xcb\x25\x66\x35\x6a\x2f\xf9\xa2\x5a\x77\xf3\xe1\x76\x79\x36\x3b\xff\x26\xe8\x09\xd0\x0b\xca\x20\xe3\x2c\x6f\x2e\xde\xd3\xc3\xdd\x3b\x20\x0f\xfd\x10\xba\xa2\x92\x49\xf0\x45\x01\x5f\xc0\xb8\xbc\x3c\x9f\x55\xce\xfc\xee\x00\x09\x98\x79\xdb\xa4\x40\x07\x81\xcc\xa6\xdc\x79\x1f\xdf\xdd\x3f\xc0\x4e\x36\x8a\x88\x9e\xaa\x66\xd1\xcb\xc3\x36\x39\x48\x67\x96\x3a\xf2\x2b\xe4\x49\x7a\x57\x1c\xc6\x82\x88\xde\xc5\x40\x7e\xd7\xd8\xf9\x5e\x4e\x20\x25\x75\x23\x69\xd6\xea\xdf\x13\x8a\x66\x85\x6d\x60\x59\x5e\x7f\xe8\x10\x52\x74\x46\xd1\x35\x70\xeb\x61\x69\x46\x1c\x96\x46\xf0\x3f\x17\xce\x9c\x61\xa9\x73\x4a\x2f\x4b\xe7\xf1\xd0\x72\xea\x38\x65\x6b\x6f\x56\xf4\xc6\xeb\xed\xdb\x8b\xdd\xa0\x5f\xa4\xc1\x47\xad\x5a\x36\x3c\xef\xd5\x33\xd0\x39\xc7\xc4\xb8\xaf\x93\xfa\xb8\x49\xf7\xb6\x1d\xcf\xea\xec\x59\x0e\x83\xd6\xf5\xe1\xaf\xbc\xe3\xf5\x3c\x58\x95\x05\x80\xc2\xcd\xb5\xa0\x9c\x26\x6c\xd1\xc0\xa6\xc7\xd9\x22\x6a\x34\x95\x7d\x79\x4e\x88\x8a\xee\xfd\xf3\x31\xea\xd5\xab\x93\x99\xa8\xfc\xda\xe0\x1d\x4d\x53\x21\xfc\xfa\x5b\x35\xa1\xa2\x7b\xdc\xf1\xc8\xc6\xbf\x02\x00\x00\xff\xff\xa2\x02\xac\x4f\x94\x0a\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xb4\x57\x5f\x6f\x1b\x37\x0c\x7f\xbf\x4f\x41\x74\x0f\x7d\xa9\xcf\x2d\x8a\x01\xdb\xbd\x65\x6e\x07\x04\xd9\x8a\xa0\x0d\x8a\x01\xc3\x1e\x64\x89\xf6\xb1\xb9\x93\x34\x92\x72\xeb\x7d\xfa\x41\xba\x73\x7c\x71\x9c\x38\xe9\x9f\x7b\x93\x44\xfe\x48\xfe\x48\xf1\xa8\x6a\x36\x9b\x55\x26\xd2\x47\x64\xa1\xe0\x1b\x30\x91\xf0\x8b\xa2\xcf\x2b\xa9\xaf\x7f\x91\x9a\xc2\x7c\xf3\x6a\x89\x6a\x5e\x55\xd7\xe4\x5d\x03\x8b\x24\x1a\xfa\xf7\x28\x21\xb1\xc5\x37\xb8\x22\x4f\x4a\xc1\x57\x3d\xaa\x71\x46\x4d\x53\x01\x18\xef\x83\x9a\xbc\x2d\x79\x09\x60\x83\x57\x0e\x5d\x87\x3c\x5b\xa3\xaf\xaf\xd3\x12\x97\x89\x3a\x87\x5c\x2c\xec\xec\x6f\x5e\xd6\xaf\xeb\x97\x15\x80\x65\x2c\xea\x57\xd4\xa3\xa8\xe9\x63\x03\x3e\x75\x5d\x05\xe0\x4d\x8f\x0d\xd8\x2e\x89\x22\x5b\x46\x87\x5e\xc9\x74\x52\x3b\xdc\x84\x28\xf5\x3a\x88\x4a\x4b\xb1\xa6\x50\x49\x44\x9b\xed\xaf\x39\xa4\xd8\xc0\x11\x89\x01\x6f\x74\x72\x0c\x70\x80\x5e\xdc\x40\x97\xb3\x8e\x44\x2f\x8e\x9f\xff\x41\xa2\x45\x26\x76\x89\x4d\x77\xcc\xb9\x72\x2c\xe4\xd7\xa9\x33\x7c\x44\xa0\x02\x10\x1b\x22\x36\xf0\x2e\xbb\x13\x8d\x45\x57\x01\x6c\x4c\x47\xae\xf0\x30\x38\x18\x22\xfa\xb3\xcb\xf3\x8f\xaf\x3f\xd8\x16\x7b\x33\x6c\x02\x38\x14\xcb\x14\x8b\xdc\x5d\xf7\x80\xd1\x06\x76\x02\xda\x22\xec\x4d\x02\xf9\x55\xe0\xbe\xa0\x83\x47\x74\xe8\x40\xc3\x88\x08\x60\xac\x45\x19\x75\x06\xc4\x7a\x3c\x8b\x1c\x22\xb2\xd2\x8e\xb5\x22\xbd\xaf\xa1\x9b\xbd\x03\xbf\x9e\x67\xc7\x07\x19\x70\xb9\x6a\x70\x40\x1f\x73\x8f\x0e\xa4\x04\x05\x61\x05\xda\x92\x00\x63\x64\x14\xf4\x43\x1d\x4d\x60\x21\x8b\x18\x0f\x61\xf9\x09\xad\xd6\xf0\x01\x39\x83\x80\xb4\x21\x75\x2e\x97\xda\x06\x59\x4b\xd8\x6b\x4f\xff\xdd\x20\x0b\x68\x28\x26\x3b\xa3\x38\xa6\x6c\xf7\x91\x57\x64\x6f\xba\x4c\x79\xc2\x17\x60\xbc\x83\xde\x6c\x81\x31\xdb\x80\xe4\x27\x68\x45\x44\x6a\xf8\x33\x30\x16\x16\x1b\x68\x55\xa3\x34\xf3\xf9\x9a\x74\x77\x6b\x6c\xe8\xfb\xe4\x49\xb7\xf3\x52\xfb\xb4\x4c\x1a\x58\xe6\x0e\x37\xd8\xcd\x85\xd6\x33\xc3\xb6\x25\x45\xab\x89\x71\x6e\x22\xcd\x8a\xe3\xbe\x5c\x9a\xba\x77\x3f\xf1\x78\xc5\xe4\xf9\xc4\x53\xdd\xe6\x22\x11\x65\xf2\xeb\x9b\xed\x65\x08\x2a\xca\x26\x5e\x85\x6b\xbc\x3f\x03\xbf\x07\x86\x7c\xf1\x8c\xeb\x21\x5f\x5a\x08\x0c\x9f\x02\xf9\x53\xf0\xd6\x2c\x90\xf5\x41\x58\x1b\xbc\xcf\x3c\x4d\xca\x65\x22\x3e\xd4\x59\x03\xcb\xad\xe2\x69\x63\x17\xb8\x6d\xbe\x5a\xf9\xfd\xd8\x76\xee\xf5\x76\x71\xb6\x13\x01\x1a\x0a\x30\x72\x58\x33\x8a\x0c\x85\x87\xc0\xbb\xf3\x71\x6d\x73\xb1\xaf\xc8\x9a\x5b\xf6\x01\x4c\xd2\x36\x30\xe5\x8b\xf0\x02\x48\x81\x04\x3c\x75\x10\xbc\x45\xf0\x61\x0f\xc3\xc9\x4b\x3d\xd1\x3c\x76\x7f\xf2\x77\x8d\xdb\x83\x1d\x00\xe3\x5c\x69\xae\xa6\xbb\xbc\x47\xeb\x01\x8a\x1e\x20\xea\x08\x31\x17\xb8\x15\x30\x8c\x23\x27\xb4\x31\x8a\xc5\xa7\x1d\x0f\x1e\x3f\x4f\xb9\x98\xc6\x7f\xc7\xe8\x72\x0b\xd1\x68\x0b\xc9\x2b\x75\x59\x7b\x0b\x24\x92\xf0\x90\x50\x79\x31\x1c\x66\xbb\xd7\x18\x15\xc8\x1f\xb4\xa9\x3b\xd0\x82\x96\x51\xc1\x0c\xc9\x0b\xda\x22\x67\x37\xa1\x37\x8a\x4c\xa6\xab\xab\x63\xf1\x0f\xbd\xe2\xd6\x51\x6c\x8d\x60\xf3\x10\x25\xfb\x5a\xb9\xcc\xb2\xbb\x82\x11\x35\x6b\x7c\x6a\xb5\x1c\x54\x0c\x7c\x6e\xc9\xb6\x77\x5a\xb2\x40\x64\x8c\x86\xd1\xe5\xa4\xd6\xd5\xa3\x52\xc9\xf8\x6f\x22\x46\x37\x8d\x65\x36\x84\x57\x9d\xe0\x61\xe2\xed\xc1\xb5\xfb\x3e\x9d\x03\x59\xe5\x37\xf2\x86\xb7\x6f\xc6\xc9\xe0\xf1\x95\x7d\x6f\x55\xdf\x43\xc3\xf1\x00\x3b\x42\xaf\x27\xfb\x57\x0e\x6e\x66\x22\x49\xf9\x95\xc0\x5f\x3f\xbf\xfc\xb5\x64\xeb\x6b\xfb\x50\xb1\xfa\x18\x46\xbf\xab\xd1\xd2\x77\xf3\x00\xd1\x9c\x92\x45\xb5\xee\xec\xf2\x7c\x71\x94\x9d\xa7\x18\xbd\x05\xf4\x0d\x9d\x3b\xe3\x2c\xce\x4e\xe6\xe9\xea\xe2\x2d\x90\x87\x75\x17\x96\x65\xb0\x49\x82\xdf\x64\xf0\x5b\x3c\xfe\xa2\x4f\xaf\xe9\xa7\x94\x6e\x99\x46\xef\x9d\xa6\xf2\x2c\x0a\x24\x60\x46\xb4\x61\x2a\xd9\x0f\x4d\x79\x4b\x5b\x84\xf7\x6f\x3f\x5c\xc1\x6e\x94\x28\x83\xd5\x2d\x87\x06\x9b\x7b\x35\xd9\x8f\x53\x79\xfc\x21\xbf\x42\x2e\x5a\xb0\xe2\xd0\x17\x44\xf4\x2e\x06\xf2\xbb\x9f\x7d\x4e\xfc\x2d\x48\x49\xcb\x9e\x54\x4a\x5b\x42\x51\x01\x0d\x35\x2c\xca\x8b\x00\x96\x08\x29\x3a\xa3\xe8\x6a\x38\xf7\xb0\x30\x3d\x76\x0b\x23\xf8\xc3\x87\xa9\xcc\xb0\xcc\x32\xa5\xa7\xc7\xa9\x7c\x2f\x7f\x6c\x6a\x7b\xe3\x69\x95\xb9\xf9\xc1\x66\x26\x2f\xb2\x07\x05\x15\xbd\xf1\x7a\xfe\xe6\x64\xdf\xd0\x47\x0d\x98\x93\xa6\x56\x14\x0e\xbb\xda\x11\xe8\xc3\x7f\xd8\x6c\xda\xce\x6e\xf6\x76\x7e\x56\x47\x63\xd9\xbf\x22\x5f\xed\x57\x85\xbe\xd9\xf8\x6a\x2c\x07\x00\xc5\x37\xd7\x80\x72\x1a\xb0\x45\x03\x9b\x35\x8e\x3b\xa2\x46\x53\xd1\xcb\x8f\xa0\xa8\xe8\xde\x1d\xbe\x11\x9f\x3d\xbb\xf5\xe0\x2b\x4b\x1b\xfc\x90\x3c\x69\xe0\xef\x7f\xaa\x01\x15\xdd\xc7\x9d\x1f\x79\xf3\xff\x01\x00\x82\x50\x82\xef\x71\x0f\x00\x00"),
 		},
 		"/devops.gostship.io_clusters.yaml": &vfsgen۰CompressedFileInfo{
 			name:             "devops.gostship.io_clusters.yaml",
-			modTime:          time.Date(2020, 6, 1, 9, 32, 7, 475414708, time.UTC),
-			uncompressedSize: 18051,
+			modTime:          time.Date(2026, 10, 18, 8, 7, 56, 791061004, time.UTC),
+			uncompressedSize: 38319,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xec\x3d\x5d\x73\xdb\xb6\xb2\xef\xfa\x15\x3b\xb9\x77\x26\xc9\xad\x45\x27\x69\x7b\x26\x57\x2f\x1d\xd5\x76\x4f\x7c\x9a\xb8\x1e\xdb\xcd\x4b\x4e\xef\x0c\x44\xae\x44\x1c\x91\x00\x0b\x80\xb2\xd5\xd3\xf3\xdf\xef\xe0\x8b\xa4\x24\x82\xa4\x24\x27\xe9\x83\xfd\x92\x88\x04\x16\x8b\xc5\x7e\x61\xb1\x0b\x8e\xc6\xe3\xf1\x88\x14\xf4\x23\x0a\x49\x39\x9b\x00\x29\x28\x3e\x28\x64\xfa\x97\x8c\x96\x6f\x65\x44\xf9\xe9\xea\xf5\x0c\x15\x79\x3d\x5a\x52\x96\x4c\xe0\xac\x94\x8a\xe7\x37\x28\x79\x29\x62\x3c\xc7\x39\x65\x54\x51\xce\x46\x39\x2a\x92\x10\x45\x26\x23\x00\xc2\x18\x57\x44\x3f\x96\xfa\x27\x40\xcc\x99\x12\x3c\xcb\x50\x8c\x17\xc8\xa2\x65\x39\xc3\x59\x49\xb3\x04\x85\x19\xc1\x8f\xbf\x7a\x15\x7d\x1b\xbd\x1a\x01\xc4\x02\x4d\xf7\x3b\x9a\xa3\x54\x24\x2f\x26\xc0\xca\x2c\x1b\x01\x30\x92\xe3\x04\xe2\xac\x94\x0a\x85\x8c\x12\x5c\xf1\x42\x46\x0b\x2e\x95\x4c\x69\x11\x51\x3e\x92\x05\xc6\x06\x89\x24\x31\x98\x91\xec\x5a\x50\xa6\x50\x9c\xf1\xac\xcc\x2d\x46\x63\xf8\xc7\xed\x2f\x57\xd7\x44\xa5\x13\x88\xa4\x22\xaa\x94\x51\xc2\xe4\xe5\xf5\x08\x00\x20\x41\x19\x0b\x5a\x28\x83\xd3\x5d\x8a\x7e\x38\x30\x4d\xa2\x11\x80\xc7\xe3\xfc\xea\xd6\xf5\x51\xeb\x02\x27\x20\x95\xa0\x6c\x11\x18\x20\x72\xf3\x6c\x1f\xc3\xbd\x04\x3e\x07\x4d\x1e\xc1\x50\xa1\x6c\x8e\xf5\xf1\xe2\xe6\xf6\xf2\x97\xab\xa1\xa3\x15\x29\x91\x18\x9c\x8e\x9e\x8d\x69\xd1\x1c\xe1\xfa\xdd\xf4\xf6\xa2\x17\xbe\x5f\xe8\x68\x67\x91\x76\x47\x7b\x7e\xb6\xdd\x06\xa8\x04\x02\xaa\xfa\x29\xb0\x10\x28\x91\x29\xca\x16\xa0\x52\x04\x89\x62\x85\xc2\xb4\x80\xfb\x14\xd9\x08\x00\x00\x40\xa5\x54\x02\x9f\xfd\x0b\x63\x05\xf7\x44\x5a\x0e\xc1\x24\x82\xe7\x8d\x09\x4c\xff\xde\x44\x3f\x21\x0a\x47\x00\x0b\xc1\xcb\x62\x02\x2d\x9c\x62\xbb\x39\x16\x75\xec\x6d\x57\x7a\x04\x00\x90\x51\xa9\x7e\x6e\x3e\x7d\x4f\xa5\x1a\x01\x00\x14\x59\x29\x48\x56\xb3\xe1\x08\x00\x40\xa6\x5c\xa8\xab\x1a\xe0\x18\x56\xb1\x7d\x41\xd9\xa2\xcc\x88\xa8\xda\x8f\x00\x64\xcc\x35\x8a\xa6\x79\x41\x62\x4c\xf4\xb3\x72\x26\x9c\x5c\x39\x10\x76\x29\x27\xf0\xef\xff\x8c\x00\x56\x24\xa3\x89\x21\xa6\x7d\xc9\x0b\x64\xd3\xeb\xcb\x8f\xdf\xde\xc6\x29\xe6\xc4\x3e\xdc\xa2\xbf\x43\x1c\xa8\x34\xb4\xb5\x2d\x61\xce\x85\xf9\xe9\xdf\x4e\xaf\x2f\x47\x00\x00\x00\x85\xe0\x05\x0a\x45\x3d\x02\x00\x00\x0d\x05\x51\x3d\xdb\x5e\x66\x8d\x87\x6d\x03\x89\x56\x09\x68\xc7\x73\x3c\x8d\x09\x48\x3b\x32\x9f\xdb\x85\xac\x56\xdd\xcc\xa7\x01\x16\x74\x13\xc2\xdc\x4a\x47\x70\x6b\xb8\x41\x6a\xe2\x96\x59\xa2\xf5\xc8\x0a\x85\x02\x81\x31\x5f\x30\xfa\x47\x05\x59\x82\xe2\x66\xc8\x8c\x28\x74\xab\xe4\xff\x8c\xf0\x33\x92\x69\x0a\x96\x78\x02\x84\x25\x90\x93\x35\x08\xd4\x63\x40\xc9\x1a\xd0\x4c\x13\x19\xc1\x07\x2e\x10\x28\x9b\xf3\x09\xa4\x4a\x15\x72\x72\x7a\xba\xa0\xca\xab\xc4\x98\xe7\x79\xc9\xa8\x5a\x9f\x1a\xc5\x46\x67\xa5\xe2\x42\x9e\x26\xb8\xc2\xec\x54\xd2\xc5\x98\x88\x38\xa5\x0a\x63\x55\x0a\x3c\x25\x05\x1d\x1b\xc4\x99\xd1\x88\x51\x9e\xfc\x57\xb5\xce\xcf\x1b\x98\x6e\x09\x1d\x40\xc5\x96\x41\xba\x6b\xf6\xb4\x12\x65\xbb\x59\xfc\x77\x85\xea\xe6\xe2\xf6\x0e\xfc\xa0\x66\x09\x36\x69\x6e\xa8\x5d\x77\x93\x35\xe1\x35\xa1\x28\x9b\xa3\x30\xbd\x60\x2e\x78\x6e\x20\x22\x4b\x0a\x4e\x99\x32\x3f\xe2\x8c\x22\xdb\x24\xba\x2c\x67\x39\x55\x7a\xa5\x7f\x2f\x51\x2a\xbd\x3e\x11\x9c\x19\xc3\x00\x33\x84\xb2\x48\xac\xf8\x5e\x32\x38\x23\x39\x66\x67\x5a\x17\x7d\x6e\xb2\x6b\x0a\xcb\xb1\x26\x69\x3f\xe1\x9b\xf6\x6c\xb3\xa1\xa5\x56\xf5\xd8\xdb\x9b\xd6\x15\x72\x22\x76\x5b\x60\xbc\x21\x19\x09\x4a\x2a\x34\xf7\x2a\xa2\x10\xf8\x7c\x43\xf1\x84\x65\xd1\xc9\xa3\x5d\x9c\x8b\x07\x25\xc8\x54\x2c\xb6\xde\x6f\x5a\xbe\x76\x18\xc1\x59\x77\xcc\xd3\x8e\x5d\xec\x40\xa2\x0a\xf3\x9d\x87\x5b\x64\x78\x87\x59\x7e\x96\x12\xa1\x0c\x21\xb4\xbc\x89\xc4\x12\x82\x28\xbb\x90\xa8\x61\x67\x34\x36\x0a\x01\xf8\x1c\xbc\xb2\x8c\x76\x20\x17\x1d\x93\x02\x88\xf5\x30\x5a\xaf\xb6\xbd\xec\x9c\x75\xd5\xbb\x45\xdd\x0d\x06\xc0\x0e\x1d\x99\x79\x53\x70\x50\x6f\xbe\x42\x21\x68\x82\x1f\xb5\xfc\x1f\x04\x41\x90\x7b\xd3\xf9\x16\x55\x7b\xff\x61\x5c\x35\x68\xac\x0e\x0e\x03\x00\x00\x10\x58\xf0\x83\x66\x61\xf5\xf7\xd7\x9e\x40\xc7\x4b\xfb\x8a\x08\x41\xd6\x1b\x6f\x1c\xb7\x9f\x5d\x9e\xdf\x4c\x46\x03\x71\xd1\x5a\x90\x50\x86\xe2\xa6\x64\xda\x5f\x9a\x8c\x3a\x44\xf0\x6c\xab\xb1\xf7\x09\x2a\x20\x20\xdc\x0b\x63\xa4\x11\x18\x4f\x50\x9e\xb4\xc8\xf5\x9c\x94\x99\x51\xe8\x90\xf0\x78\xb9\x2b\xa1\xc8\xca\x7c\x1b\x95\xb1\x6b\xbb\xf3\xb8\x1a\x3e\xd9\x7b\xd6\xc9\xe7\x53\x80\xed\x94\xab\x07\x84\x94\x67\xc9\x86\x8f\x63\xbc\x0a\x4b\xcf\x3c\x27\x20\xb1\x20\x42\x5b\xb8\x9d\x51\x29\x93\x18\x97\x02\xc7\x02\x17\x54\x0f\x8e\x12\xf8\xbc\x31\xab\x68\xa8\x32\xae\x37\x55\x1f\x08\x23\x8b\xaf\x62\x10\x12\x2a\x8b\x8c\xac\xdb\xf4\x6d\x10\x5c\xc2\xe4\x39\xcf\x09\x65\x9d\xfc\x7a\x7e\x75\x6b\x5b\x79\x46\x4d\x98\x84\xc4\x3e\x29\x25\x26\x30\x5b\xc3\xf2\xad\x34\xfb\x05\x1a\x6b\x9f\xed\xdc\x71\xe6\xee\xc4\x38\x3c\xf3\xd6\x24\xe3\x31\xc9\x9e\x45\x83\x71\x35\x5c\xfb\x15\x08\x8b\x2a\x4e\x3a\xe9\x73\xa1\xe2\xc4\xb1\x61\xcc\xd9\x9c\x2e\x4a\x61\x6d\xa7\xf6\xee\x75\xef\x68\x34\xdc\x6c\xe2\x83\x75\x91\x77\xdf\x6c\x8f\xea\x1a\xba\xa7\x33\xd4\xa2\x70\x0f\x8a\x6b\x24\x18\xc6\x4a\xff\x97\xb0\x0a\xa0\xc1\xa4\x05\x68\xa5\xf0\xe0\xbd\x5e\x10\x23\x3d\x15\x6c\x22\x10\xf2\x52\x95\x24\xcb\xd6\x80\x0f\xba\x25\x5d\x61\x0b\x94\xa2\x47\x8f\xc7\xe4\x27\x9a\x05\xcc\xe1\xb6\x90\x4f\x75\x53\xa0\x12\x08\x83\xdb\xdb\xf7\x70\xa6\x01\xcf\xb5\x43\x82\x30\x2d\x55\xca\x05\x55\x6b\x98\xeb\x46\x9a\xfd\x02\x30\x01\x14\x07\x2b\xe0\x66\xea\xe0\x7c\x56\xeb\xd7\x44\x70\x83\xbf\x97\xc6\xf1\xa3\x73\x28\xf5\xc6\x10\x08\xdc\xbd\xbf\xf5\xd4\xd3\x6d\x0e\xb5\x48\x31\x0a\x35\x7c\xba\xae\x71\x63\xc2\x71\x35\x61\xc3\x45\x7e\xa2\xf5\x84\x82\x53\xfe\xc2\x13\xf5\x5b\x0f\x39\x68\xa6\x17\xbe\x35\xf0\xb9\xc5\x34\xc7\x7c\xa6\x63\x47\x35\x8e\x5a\x64\x3c\xf7\x5d\xb4\x88\x4e\x8f\xab\x3b\x18\xf3\xb0\xf9\xf7\x7f\x4b\x5c\x0f\x5e\xc3\x9f\x71\xbd\xb5\x84\x4b\x5c\xb7\x2d\x5c\x58\x08\x01\xe0\x8b\x2d\x9c\x70\x80\xdb\xe6\x36\x76\xa2\xda\xfe\xca\xf1\x6a\xeb\xcb\x8a\x19\x5a\xdf\x3a\x72\x8e\xf6\xf4\xdf\x8c\x91\xe8\xd5\x85\x56\x73\x15\x82\xaf\x68\x82\xdb\x5a\x78\xc9\xf8\x4c\x1a\xc6\xf2\xcf\x83\x9e\xa4\x8e\x5a\x18\x50\x7a\x99\x80\x32\xa9\x08\x8b\xf1\xb3\x2a\x46\xbd\xb1\x3d\xa7\x62\x10\x9b\x9d\xdb\xb6\x95\x19\xa6\x02\x63\xc5\xc5\xda\xa2\x7b\x4f\xb3\x0c\x8a\x8c\xc4\x08\x54\x49\x03\x38\xc4\x1f\x50\x59\x68\x63\x91\x4f\x57\x44\x9c\x66\x74\x76\xaa\xe1\x3c\x3b\x5c\x1b\x84\x6c\xf3\x7e\x36\x7a\xf0\x78\xbb\x06\xd1\x0e\x6f\x16\xc7\x20\x03\x44\x2c\xca\x1c\x99\x92\x9e\x39\x12\x1f\x9d\xea\x14\xc4\x19\x65\x44\xac\x4d\xd0\x53\xfb\xe2\x9a\x13\x68\x82\x40\x4c\x90\x80\xc6\x50\xf0\xa4\x9b\x4a\x01\x6e\x06\x00\x28\x10\x85\xd6\xf9\xb7\xd3\xab\x61\x6a\xf3\xba\xd1\x01\x24\x2a\xe9\xe6\x76\x5b\x9a\x41\x60\x9a\x19\x9e\x54\x74\x85\x36\x8a\x19\x9c\x96\x8f\x36\xea\xb9\x1b\x3c\x40\xd2\x05\xd3\x8a\x45\x0b\xf6\xd7\x53\xb5\x36\xd0\xbc\x17\x51\x6e\x37\xba\x3c\x22\x59\x2c\x2e\x7f\x09\xc2\x74\xab\x69\xa7\x38\xf6\x53\xa8\xc1\x57\x73\x24\x3a\x54\x27\xbb\x37\xae\xd6\x51\xfc\xc9\xb6\xdd\x08\x1e\xf9\xfe\xa0\x52\xa2\xac\x00\x32\x32\xcb\xcc\xe6\x60\xd4\xa6\x67\x03\x31\xa5\x4e\xd7\xd8\x40\xfc\x40\x4c\x18\x2f\x4e\x31\x29\xdb\xcd\xb3\x9d\xe4\x8c\xf3\x0c\x09\xdb\x79\xaf\xad\xb2\x9c\x8c\xf6\x5a\xce\xa2\x57\x5d\x25\x52\x1d\xc5\x09\x52\xc4\x47\xf4\xef\xe2\x14\xc3\x2b\x52\x05\xde\x48\x11\x1f\x12\x15\xea\x62\xdc\x94\x4c\x0e\xb1\x83\xcb\x61\xae\xd6\xf9\xcf\x17\xef\xa6\xde\x02\xae\x68\xe1\x63\x24\xb9\x61\x0b\x09\x29\x66\x76\x43\x8a\x58\x90\x8c\xae\x30\x39\x09\xd3\x35\x45\x20\x05\x95\x2e\xc0\x4e\x84\x36\xff\x24\x81\x19\xc9\xb4\xdd\x37\x70\x52\x52\x08\xfe\xb0\x06\xce\x00\x57\x28\xd6\x6e\xa0\x90\x4e\xe8\x9b\x26\x00\x68\xac\xc3\x2f\x07\x71\x0b\xc0\xaa\xe0\x42\x75\x41\xd9\x20\xda\xc7\x6b\x2e\x94\x27\x9a\xee\x09\x7c\xee\x67\x76\x02\x6f\xbf\xfb\xee\x5b\x3d\x55\x17\x4f\xea\x00\x0a\x40\xe4\x36\xd5\xf4\xd9\x1c\x32\xe0\x0c\xfe\xf6\xdd\x77\xdf\x46\x1d\xbd\xe7\x5c\xe4\x44\x4d\x80\x32\xf5\xed\x9b\x5e\x02\x50\xa6\x70\x81\x22\x4c\x01\x41\x93\xe1\x04\xb8\xb9\x3c\xaf\x99\x46\x68\xbf\x0d\x04\x37\x67\xaf\x34\x31\x07\xbd\x03\xd8\x05\x00\x80\x2a\xc8\x4b\x69\x0f\x4e\x18\xfd\xbd\x44\xa0\xcc\x40\x65\xa8\xee\xb9\x58\x6e\xb1\x63\x04\x97\x9a\xee\x9d\x20\xed\x59\x99\x86\xb9\x56\x55\xc8\x4f\x73\x76\xbd\x24\x8f\x41\xd5\x9c\x3c\xd0\xbc\xcc\x27\xf0\xe6\xfb\xef\xbb\x9a\x51\x66\x9b\xbd\x3e\x72\x85\xba\x75\x92\x39\x94\xa5\xc5\xa1\x4e\x94\x4a\xa9\x48\xae\x89\x50\xeb\xc9\x5f\x5d\x10\x1f\x93\xeb\x8f\xa1\xe9\xd8\xa2\x7a\x18\xc5\x3b\x5f\xa7\x9c\x2f\x5b\xa9\x3c\xdc\xdf\xef\x21\x75\xe7\xf0\xfe\x50\xf9\xfd\x8f\xfb\x3b\x03\xb4\x58\xc9\xfd\x7b\x69\x23\xf1\xa3\xb5\x11\x62\xc0\xde\xb4\x6e\xec\x55\x90\x3e\xd1\xcc\xb2\x99\xdb\x90\x7a\x99\xf7\xf1\xd3\x16\x88\x60\xda\xac\x0b\xdc\x00\x77\x52\x01\xa2\xd2\xee\x56\xb3\xcc\x86\x0b\xa8\xd2\x8f\x24\x2a\xb3\x6b\xbd\xac\x28\xd4\x0e\x5a\xc0\x75\x39\xcb\x68\xfc\xfe\x47\xdd\xcb\x39\x6e\xd1\x01\x46\x9c\x24\x89\x40\x29\x71\x98\x0f\x3f\xf5\xad\x81\x08\x34\x14\x10\x84\x2d\x6c\x10\x5e\xff\xf2\x0b\x0b\x05\xe7\x59\x58\x2d\x63\xb4\x88\xe0\xf5\xab\xe8\xcd\xdb\xe8\x55\xf4\xe6\xd5\xab\x71\xf5\xff\x37\xaf\x80\x0b\xf7\xea\x75\xf4\xea\xf4\xcd\xdb\xaf\xb7\xc7\x21\xf2\xaa\xd4\xc1\xae\x61\x94\xb9\xb5\x8d\x3d\xbf\x4c\x6f\x81\xd9\x07\xcd\x13\x21\xa0\x0c\x66\x8b\x02\x72\x9e\x60\x98\x3c\xcd\x53\xa2\xbf\x7d\xf7\xfd\xeb\x37\xd1\xe8\x70\x45\xd5\xaf\xa4\x62\x5e\x32\x35\x2c\xf0\xa9\x5b\xd6\x67\x5f\xfa\x87\x9b\x1d\xa9\x19\x23\xd3\xf1\x18\x85\x89\x49\x77\xe8\x74\xe4\x04\x89\x97\x27\x1b\xb3\x7d\x1b\x1d\x3c\x0b\x4d\xd2\x41\x93\xf8\xc0\x13\xdc\x18\x34\x23\x6b\x14\x41\x1a\xb7\x1d\xc8\xd5\x0a\xda\xf6\x0d\xbe\x9e\x2d\x8a\x43\x43\x33\x7a\xb3\x3f\x3c\xda\x50\x0b\xa4\x66\x2f\xeb\x27\xc9\x06\xdf\x69\x68\x70\x4f\x55\x0a\x94\x85\x63\x28\x8e\x33\x0f\x14\xb9\xa0\x0e\xd5\x08\x02\x95\x40\x1a\xc8\x35\x70\xd3\x79\x2d\x25\x8b\xb1\xcb\xd2\x6e\xb0\x98\xe2\x51\xb0\xed\x10\x27\xa2\xd2\x7a\x5d\x4d\x06\x3a\x13\xfd\x6a\x62\x3f\x9f\x62\xa8\x2f\xdd\xe7\x57\x00\x8c\xfd\x2c\xbb\x5a\x38\xdc\x7b\xd4\x68\x87\x5b\xd7\xaf\x46\x0b\x63\xa8\xa6\x7b\xd9\x99\xe7\xd7\x9b\x9d\x02\xe6\xc6\x82\x36\xc6\x26\x38\x03\x13\xcd\xa7\xf3\x36\x7b\x79\xb2\x61\xc3\x61\x81\x26\x21\x2b\xb7\x72\xa2\xd2\x30\x43\x3a\x1b\x1e\x95\x8c\xea\x0d\x15\x46\x6a\x7e\xea\x88\x3d\xd6\xc8\x4c\x3c\x66\x75\x2e\x6f\xf4\xfc\xab\xd9\x31\xad\x66\x07\x51\xfd\x86\xc4\xcb\x4a\x7f\xcb\x5d\x83\x5e\x27\xb0\xa5\x5c\xaa\xb0\x08\xea\xa6\x6e\x85\xf4\xd8\x40\xe7\x0d\xc7\x41\x93\x3f\x2f\xd4\xfa\x64\xdb\x6e\x74\x9c\x91\xc5\x19\xa1\xb9\xdd\xdc\x77\x04\xa2\x06\x92\xac\x93\xa5\x75\xe2\x41\x96\x61\x46\x65\xde\xeb\x26\x5e\xd7\x6d\x2b\x2f\x91\x3c\xd4\x76\x31\x27\x71\x6a\x72\xd6\x08\xa4\x84\x25\x59\x40\xcc\x44\xc9\x24\x70\x06\x44\x59\x76\x24\x39\x9a\x04\xde\x13\x78\x6d\xdf\x19\x9e\xe4\x0c\xf5\xf4\x39\xc3\xa8\x79\x12\xd0\x0a\xf1\xf5\xab\x68\xb4\xbf\x06\xea\xd6\x3b\x85\x13\x9f\xfd\x5d\x6f\xb9\xa4\xc5\x19\x67\x76\x5f\xb1\x6f\x18\x6f\xd0\x5a\xb6\xb1\x7e\x38\x6c\x4a\x19\xc9\xe8\x1f\x2d\xc6\x75\x63\x71\x7f\xaa\x9a\xb9\x23\x42\x5e\x10\x1d\x3b\xd0\xb1\x13\xe0\x73\x97\x2b\x65\x63\xa7\x3e\xbc\x60\xf8\xba\x2d\x81\xa2\x40\x91\x13\x86\x4c\x65\x6b\x10\x98\xf3\x15\x3a\xcc\xac\x44\x49\xc5\x05\x59\xec\x98\xdd\x21\xb9\x81\x15\x9a\x3a\x5e\xee\xb9\x90\x99\xff\x27\xc8\x14\x9d\xaf\xed\x21\x64\x35\x6b\x48\x42\x87\x69\x4e\xaa\x20\xa3\x73\x8c\xd7\x71\xb6\x83\xcf\x80\x5c\x8c\xdd\x95\xd0\x9a\xe2\x16\x33\x73\xea\xd5\x49\xf0\x77\x8d\x86\x56\xe0\xa5\xf3\x37\x35\x88\xc6\x0e\x43\xe7\xa4\x72\xb1\x36\xdb\x24\x92\x24\xb2\x2d\x56\x9d\x83\xe2\xf0\xa1\x92\x3f\xb9\x11\x6f\xbc\x4f\x69\x86\x4d\x45\x62\xf7\x62\x54\x51\x4d\x22\xca\x16\xfb\x44\xb8\x83\xce\xf3\x40\xc7\xd9\x4e\xae\xa1\xe0\x08\xc4\x3b\xd9\xac\xf5\x1f\x91\x40\x95\xf4\x53\x39\x01\xe2\xb5\x8c\x27\x19\x67\x16\x66\xb4\xb7\x84\x87\xcc\xc4\xae\x89\x90\x66\x99\x64\x03\xff\x86\xb6\x0f\x0f\x1c\x90\x62\x19\xe0\x0e\xd8\x3d\x40\xb2\x0d\x5b\xc6\x9f\xad\x21\x23\x33\xcc\x4e\xb4\xf9\x72\xd8\xe4\x40\xe7\x2d\x20\xc1\x8a\xe9\x21\x7b\xe5\x9c\xa8\x38\xbd\x78\xd0\x79\xde\x32\xa4\xcb\x76\xb0\xde\xee\x64\xd4\x49\xa5\x46\x0c\xd6\x15\x09\xbc\x57\x67\xce\x3f\xc3\x3e\xae\x2e\x43\x69\xb6\x04\x22\x10\xa6\x57\xe7\x98\x3c\x86\xe7\x3e\xed\x40\xca\x22\x5f\xbd\xd1\xda\x2f\x08\xb4\x4a\x36\x94\x4e\x59\x6a\x6e\x5d\xe2\xda\xd6\x10\x18\x8d\x8a\x82\x78\x30\x20\x30\xf3\x5e\x47\x07\x48\x9d\xa4\xa1\xbb\xbb\x52\x83\x23\xb7\x01\x4b\x5c\x77\xbd\xde\x22\x8c\x1e\xdb\x89\xb0\xa5\x90\x7e\x60\x70\xb7\x6e\xa4\x23\x8a\x49\xc5\xc6\xee\x00\x32\x74\xee\x61\x60\xf8\xe6\xc3\xd3\x70\x8f\x69\xf8\x2e\x8d\x8a\x05\xbb\x30\xcf\xa5\x5d\x04\xcd\xa5\x69\x30\x28\x59\x4f\xc0\x70\x42\xc3\x18\x46\xf0\x51\x57\xd9\x54\x03\x58\xbe\xbc\x64\x27\x70\xc5\x95\xfe\xe7\xe2\x81\x4a\xd5\x47\x18\xbd\xba\xe7\x1c\xe5\x15\x57\xa6\xfd\xa3\x90\xa9\x2b\xb3\xb9\x95\x48\xb6\x83\x33\xfd\xc6\xaa\xe9\x79\x36\xeb\x44\xf4\x41\xc1\xbc\x87\x5b\x9b\x2b\x04\x54\xc2\x25\x03\x2e\x3c\x35\xcc\x99\x81\x1d\xc6\x0e\xe0\xdd\x08\xc6\xd9\x38\xa8\xa3\x9a\x7f\x76\xfc\x8d\x11\x2c\x89\x81\x8b\x0d\x1a\x36\x07\xeb\x23\xff\x06\x2a\x16\x0d\xb8\x4b\xa9\x47\xd2\xd6\x1f\xe9\x94\x95\xc4\xb9\x12\x40\x7a\x40\x4a\x25\x88\xc2\x05\x8d\x21\x47\xb1\x40\x28\xb4\x46\x8c\x7a\xce\x6c\x3a\xf5\xd5\x5e\x6b\xdf\xbf\x41\x82\x81\xfb\xe9\x25\xae\x3b\xde\xfa\x65\xf8\x9c\x7b\x69\x63\x4c\xde\x6b\xe5\xf3\x95\x32\x76\x1a\x08\x18\xe1\x80\x9c\x98\xf3\xdc\x7f\x6b\xc5\x6e\x18\xec\x3f\x50\x10\xaa\x8f\xd1\xa6\xa6\x76\x2f\x0b\xcb\x47\xb3\x8f\x3b\x92\x6b\x82\xd7\x90\xa9\x04\xbd\x2e\x2b\x92\x21\xab\xb2\x63\x33\x63\x8a\x82\x60\xf9\x7c\xc7\xe6\x9e\xc0\x7d\xca\x25\xba\x14\x3f\xcc\x12\xa0\x12\x9e\x2d\x71\xfd\xec\x64\x43\x82\x82\x30\x75\xf3\x4b\xf6\xec\xa4\x4a\x54\xdf\x10\xdc\xca\xce\x71\x96\xad\xe1\x99\x79\xf7\x2c\xda\x31\xd3\xa3\xb0\xcc\xf5\x98\xef\xc3\x8f\x7a\x82\xaf\x74\x99\x6c\x86\xea\x2b\x24\x67\xfb\x6d\xf1\x21\xa5\x50\x2e\x9d\xc5\x79\xf6\xf5\xae\x5b\x3f\xf4\x80\x6d\xa9\x18\xf5\xa5\x50\x87\x56\x42\x09\x34\x1b\x28\x92\xc9\x1b\x9c\xb7\xb5\xd8\x46\x6d\xa3\x83\x47\x4d\x62\x2c\xb0\x72\xf6\xa5\x4c\x1b\x80\x47\x41\xfe\xb5\x93\x32\xb3\x39\x01\xaa\x64\xb5\xdb\x24\x4b\x84\x42\x60\xac\x41\xc4\x68\x0a\x97\xdc\xae\x28\xd3\x33\xe7\x2c\xe4\x13\x15\xbd\xba\x20\x5c\x7b\x35\x50\x4f\xf4\xd4\x60\xc1\x63\x64\xc5\xe8\x31\x3a\x5e\x99\xe1\x0f\x49\x8e\xd1\xbb\x88\x33\x46\x87\x2c\xb3\x2b\x35\x62\xb4\xa5\x72\xc0\xf1\x1a\xf0\x9a\x19\x63\x46\x0f\xcd\x4f\xb2\x51\x9e\x1b\x1d\x2e\x3f\x6a\x61\x16\xf7\x47\x75\xa7\xc9\x51\xdd\xf5\x9e\xf0\x8e\x2c\x8e\x84\xc1\x16\x78\xc1\x92\xe3\x81\xdc\x2a\x22\x8e\x4c\xfb\x2a\x67\x0c\x8f\x03\x51\x4a\x8d\x47\xff\xaa\x76\x9d\x01\xf4\xe6\x8f\x35\xb8\x27\xd0\x64\x71\x1f\x78\x41\x93\xc0\x0b\xbf\x0e\x5d\xaf\x0d\x85\x03\x0d\x2c\xed\x02\x2f\x3d\x55\x0e\x91\xdf\x50\x56\x48\xcf\x62\xfc\xab\xcc\x0b\x1d\x77\x92\x03\x04\xff\x1f\xbe\x6d\x15\xc6\x49\x09\x65\x5e\x5b\xcf\x88\x54\x66\x77\xdf\x50\xdd\xa3\xa0\x2f\x21\x90\xe8\x64\x48\x50\xa9\xe0\xe5\x22\xb5\x4e\xc8\x9c\x0a\xa9\x80\x5b\xb3\xe6\x0a\x06\x30\x71\x29\xe3\x59\x60\x33\xd0\xe9\x28\xb7\xe2\xef\x0e\xe2\x2c\xbe\xc0\x59\x65\x98\x0a\xa2\x52\x50\xbc\x0e\x29\x1d\x91\xa6\x33\xc4\x7a\xf6\xdb\x50\x57\x7a\x42\x14\x90\x86\x21\x25\x1a\xdb\x0e\x88\xcd\xd1\x4f\xaa\xb3\x1c\xed\xfb\x49\xcd\x63\x42\x1b\x8a\x13\x28\x88\x94\xf7\x5c\x24\x27\x50\x08\xba\x22\x0a\x7f\xc6\x75\x27\x50\xc2\x12\xd3\xe9\x3a\x15\x44\xa2\x0b\x34\x15\xd6\x45\xb2\xbe\x9e\x43\x51\x17\xfd\xcf\x10\x64\x4a\x44\x5f\x6a\x9a\xc9\x83\x70\x87\x1a\xde\x2d\xb2\x53\x34\x91\x34\x9b\x89\x56\xa5\xab\x75\x1a\xb8\x2d\xef\x81\xdf\x33\x6b\x87\x54\xea\x51\x69\x2e\x4a\x0d\xcc\x75\xe8\xa6\x67\x55\x2b\xe9\xdc\x65\x95\xfa\x13\x67\x7b\x0d\x44\xd8\x73\x1d\xca\x30\xfd\x1e\xc8\x60\xed\x3a\xd8\x1b\xd9\x0b\x62\xff\xfe\xb0\xd3\x3f\x69\x36\xe8\x5b\xc4\x01\xfb\xc4\xb0\xc6\xdb\x63\x52\x35\x37\x0f\x49\x86\xd3\xa9\x8e\x8f\x31\xa2\x16\xba\xe3\x51\xff\x82\x19\x7c\xd0\xd0\x10\x5f\x8a\x50\x5e\x4f\x1d\x49\xa8\xfe\xe4\xc3\x8e\xdc\xc3\xc3\x53\x0f\xfb\xe2\x18\x59\x47\x00\xe3\x4b\x5e\x94\xd0\x27\x00\xbd\x2b\xda\x83\x40\x37\xbb\xf7\x75\x0e\xb2\x78\x3f\x73\xf7\xb1\x75\x1f\x43\x1f\x3b\x71\x1d\x0d\x19\xe4\x54\x5d\xce\xcd\x0d\x30\x74\x4e\x7d\x36\x86\x4e\x09\x7a\x2e\x1d\x84\x63\x7d\x9e\x3b\x07\xd0\x5e\xbf\x73\xa7\x61\x02\x95\x40\x94\x72\xfe\x17\x87\xd4\x1d\x0e\x3e\xc3\xf9\x1c\x63\xf5\x2c\x1c\x56\x62\x40\xd8\x1a\x0a\x9e\xd8\x98\x7f\xc2\x51\x02\xe3\x0a\x14\xcf\x50\x10\x85\x06\x8c\x19\xe3\x98\x4a\x03\x8b\xc6\x60\x8f\xc9\x17\x9c\x5a\x0f\xc4\x76\x76\x26\xdd\xd2\x10\x38\xd3\x38\xcb\xbe\xb3\x1a\x80\x84\xef\x4e\xc7\x80\xf0\x71\x7d\x0b\xdd\xda\xfd\x2b\xee\xcb\x79\xba\x3d\x9c\x6b\x81\x73\x14\x75\x6b\xe3\x46\x5d\xf1\x8b\x07\x8c\x4b\x85\xd1\xb1\x7a\xb2\xe7\x00\xa7\x83\x54\x66\x66\x60\x4e\x70\x38\xcc\xdc\x05\x3a\x96\x25\xba\xe3\xd9\xac\x23\x41\x6e\x30\xde\x3a\xbf\x63\x9a\x24\x38\xbc\x1c\xe2\xce\xf7\x68\x1e\xdb\x98\x25\xa2\x39\x02\x51\xfa\x54\x3b\x4e\x7b\x5d\x39\x3b\x6d\x7d\x07\x1c\xd1\xc0\xbc\x8b\x69\x02\x97\xf7\x82\x2a\x85\x36\x92\x51\x2d\x51\xa7\x24\x6e\x6a\x8b\x84\x28\x1c\x6b\x74\x8e\x4e\xd0\x0f\xdf\xc3\x13\x10\x72\x3b\x2d\xd3\x0f\x62\x2e\x04\xca\x42\x67\x9c\xb0\x85\xaf\x1d\x35\x0d\x3a\x20\x1a\x56\x8a\x3e\xb7\xb5\xb5\x12\x34\xda\xff\x7c\xe1\x48\x83\xdb\xed\x4e\x74\x4e\x2d\x3c\xa9\xb1\x8f\x9a\x8d\x06\xb9\x15\x01\x87\x62\x5c\x21\xf7\x18\x17\x01\xb9\x92\x9e\x73\xd4\x19\x7d\x83\xef\x54\x71\xbd\xee\xf4\xfb\xae\x14\x95\xab\xba\xdd\xc6\x7d\x64\xae\xbf\x19\xa0\x23\xe4\x1c\x1c\xbf\x20\xa5\x0c\x60\xdb\x96\x54\x15\x36\x23\x6d\x91\x4a\xe7\x46\xad\x03\x17\x87\x51\x66\xc5\xd7\x45\xcb\xdb\xf4\xc7\x01\x75\x9f\x39\x79\xf0\x97\xb7\xd9\xec\xca\xab\xf6\x24\xea\xe3\x72\xd2\x72\xf2\x70\xc5\x13\xbc\xe6\xc9\x67\x01\xaf\xa3\xeb\x92\x67\xc9\x8d\xa6\xce\xd7\x2a\x96\x09\xbe\x72\x69\xb5\x75\xc9\x74\xe3\xf6\xcc\x5e\x5f\xe9\x80\x44\x2e\xe9\x2c\xf8\xd7\xb8\xcf\xc7\xa5\xe8\xb6\xdd\xef\xb5\x53\x62\xee\xda\x01\x95\x8d\x8b\x3c\x6c\x04\xc9\xdd\x2a\x05\xe6\xbd\xb6\x72\x8d\x2b\x90\x76\xdd\x18\xaa\x9e\xcb\xfa\x9e\x08\x1b\x48\xfa\xd0\xc2\xd7\x83\xc5\x5c\x21\x23\x4c\x5d\x9e\x0f\xd6\x4b\xaa\x45\x21\x05\x1b\xaf\xda\xef\xdd\x0b\xb4\x6f\x53\xeb\xe3\x0a\xc3\xcd\x87\xeb\x02\x37\x1e\xb8\x91\x7a\xaf\x76\xb4\xf7\xaf\xf6\x5d\xee\x68\x5a\x35\x9d\x9a\xa6\x46\x22\x33\x5e\xba\xf4\x1a\xdb\x8e\xcf\xb7\xdc\xb3\x16\xe5\x14\xbc\xfb\x31\x94\x82\xbe\x59\xb8\xe0\x32\xb3\xaa\xd6\x36\x5a\xab\x13\xc6\xbd\x33\xd1\x32\x26\xec\x77\x80\xe9\xd2\xa1\xeb\x93\xe3\xe6\xa4\xfd\xe5\x06\x6e\x98\xe7\xb2\x5d\xf5\x68\x00\xfb\x9e\x6a\x6a\x8b\x3d\x19\x0d\x72\xa9\xdc\xe8\xe1\x91\xe0\xeb\xee\x61\xdb\x84\x23\x4c\x70\x3f\x0d\xd3\xed\x04\x38\x33\x86\xda\xd6\x04\x9c\x54\x77\xc4\x5c\x5e\x43\x30\x09\xc0\x57\xe4\xf9\x3b\xac\x1f\xd5\x8b\x1a\xee\x2d\x6d\x49\xe3\xc1\x9e\xd2\xa1\x97\x91\x4e\x8b\xa2\x12\xd9\xda\x9f\x10\x98\x21\x91\x1b\x52\xca\x9a\x77\x92\xee\xc0\xac\x02\xd5\x7f\xd1\x8b\x4a\x37\x2f\x30\xc0\x22\xe3\x6b\x4c\x6c\x3f\xaf\xff\x0e\x92\x08\x5d\x35\xfe\xab\xb9\xbe\xf7\x8e\xe6\x3d\x61\xa7\xee\xfd\x54\xcf\x40\x39\x4a\x49\x16\x43\x24\xe4\x42\x08\x2e\x7c\x7b\xbf\x2c\x1a\x4f\x98\x13\x6a\x8a\x53\x6d\x99\x2a\x70\x01\x65\xb1\x10\x24\xb4\xff\xfd\x6b\x5e\xee\x6a\x2e\x6a\x1f\x40\x86\x69\x51\x5c\xeb\xa6\x1b\x9e\xbd\xe9\x6c\xd8\xb9\xd6\x87\x9d\x5c\x0d\x00\x5e\x18\x0e\x22\x92\xc0\x15\x0d\x73\xe5\xb1\x5a\xb3\x4b\x0d\x3d\xd6\x16\xac\xbe\x09\xaf\x6f\x7f\xd2\x68\xb8\xa5\x0d\x40\x72\xa1\xea\x7a\x1f\x7c\x28\xa8\xcd\xb2\x38\xc8\xe4\xd6\xe3\xb4\x68\xae\x1a\xb6\x5b\xe6\xba\xf5\xe3\x2b\x2d\x9e\xe7\x9c\x1d\xac\xb5\xa8\x3c\x9b\x0e\x89\xa9\xca\x33\x7b\xdb\x8a\x28\xb1\xf6\x25\xea\x69\x01\x71\x17\x32\x52\x94\x26\xe6\x1a\x0a\x3a\x98\xf3\x3d\xae\x88\x5b\x89\x0b\x26\x4b\x81\x37\xe6\xc1\xd9\xd4\x84\x8c\xba\x58\x3c\x54\x0e\xd4\xa5\x08\x36\x77\xdb\x8d\x8a\x16\x73\x36\xcd\xe7\xdb\x53\x39\x01\x5e\xd5\x57\xeb\x84\x36\x9b\x91\x13\x98\x4e\x75\x12\x6c\x2f\x5c\x6f\xc2\x39\x4c\x9f\x71\x35\x9d\x2b\x14\xdd\xa2\x7a\x94\x12\xb7\x57\xce\x0f\xa0\xd5\xad\x69\x08\x54\x36\x0e\x79\xdb\xd6\x5e\x76\x1f\xf7\xfa\xe4\xa6\xc6\xf9\xb9\x83\xb1\x7d\x6b\xcf\x36\xe0\xf0\x8d\x29\x02\x89\x2d\x05\x7f\x64\xb7\xa9\x55\x5f\x8d\xab\x55\x69\x79\x65\xa9\xf9\xd8\x3a\xee\x2c\xc5\x78\x79\xd7\x7b\x81\x74\x5b\x8f\xba\x9a\x40\x2a\x1b\xc7\xdd\x5e\xae\x51\x9b\x4c\x52\x26\x8b\xd6\x43\xf7\x3e\x9e\xeb\xb8\x15\x3a\x2f\x38\xc3\x96\x33\x9b\x3d\xf6\x34\x67\x1e\xc8\x86\x7e\xad\x2f\x41\x88\x79\xe1\xae\x6b\xd6\xfb\xa9\x76\xf5\xe8\x00\x6c\x59\x83\x9c\x1c\xa2\x6d\x75\x9a\x3a\x8d\x89\xdc\x23\xa9\xcf\x23\x70\xe3\xba\x76\xce\x24\x9c\xbe\xa9\xe7\x57\x7f\x9c\xc0\xfc\xda\x7b\x6e\xfd\xf3\x03\x00\x20\x2b\x42\x33\xbd\x35\x9d\x74\x5d\x95\x37\xe0\x28\x7c\xc8\x41\x78\x5c\x0a\x81\x4c\x7d\x89\xa1\xdc\x17\x1e\xbe\xc4\x50\xee\x63\x1a\x9f\x7f\xa8\xbe\x8c\xbd\x6a\x2d\x03\xef\x1d\xf9\x83\xf9\x7e\x86\x62\x81\xb7\x6e\x92\x07\xdf\x1b\xf7\xb8\xaa\xdb\x4b\xe6\x67\xdc\xde\xc6\xc1\x82\xe3\xbd\x34\x9a\x03\x52\xc7\x69\x12\x54\x84\x66\xb2\xb6\xad\x76\x51\xea\xf1\x42\x4e\xa3\x36\xcd\x87\x79\x8d\xda\x3a\x5c\x0b\x3e\xeb\xd8\x31\x6e\x06\xb0\x2a\x6b\x72\x8f\x1a\xf4\x0c\xfd\xdd\xfa\x0e\xc5\xe8\xf3\x39\x2c\x1a\xd7\x3b\x41\x98\xa4\xfe\xbb\x55\x7b\x21\xbc\x81\x26\xa8\x0a\x90\xbb\x4c\x06\x38\xf3\x21\x86\x51\x40\x0a\x39\x10\x66\xd2\xd3\xa2\xbf\xc2\xd6\xfa\x5d\x99\x13\x36\xd6\x1e\x90\x96\x6b\xdf\x11\x28\x4b\xcc\x0e\x92\x2d\x2a\x7e\xb2\x81\x4e\x4d\xbe\xd0\xcc\x2a\x62\x1c\xb8\xaf\x24\x72\x50\xac\xe3\x57\x73\x45\x9e\x89\x90\x8d\x6d\x7e\x64\xf5\x85\x21\x07\xa4\xe6\x7d\xbf\x52\xcf\x43\xcb\x61\x1d\x9b\x63\x31\x37\x9f\x79\x18\x80\xfa\x8d\x6d\xd9\x5a\x66\xed\x42\x19\xf6\xae\x05\xfb\xa8\xeb\x4e\x06\x00\x49\x59\x8c\x40\xed\x9a\x80\x2c\xe3\x18\x31\xc1\xa4\x9b\xad\x0e\x8f\x66\xee\x06\xcb\x03\x93\x74\x5b\x58\x37\xc7\x3a\xde\xb6\x29\xe1\x70\x46\x18\xcc\x10\xee\x44\x19\x4c\xd0\xf8\x89\x64\x12\x4f\xe0\x57\xb6\x64\xfc\xfe\xb0\xb5\x19\x18\x83\x35\x07\xa6\x0e\x63\x7f\x46\x3a\x40\x23\x1d\x6c\x5f\x02\x2a\xe2\xf1\xac\x8b\xf9\x94\xe1\xe0\x93\x99\x94\xd8\x0b\x70\xbb\xc3\x21\xef\xa6\xae\x55\x75\xd7\x8d\x2b\xf2\x6f\x5c\x74\x09\x84\x25\xf5\xf5\xa6\x73\x7b\xb3\x6a\x68\x8f\xe0\xae\x96\xe3\xae\xd4\x94\x33\x94\xee\xf2\x89\xa4\xbe\xc8\x45\xef\x24\x4c\x87\x58\x6f\xd6\xab\x90\xcb\xa8\xdd\x4b\x8a\x9e\x4a\xb6\x5a\xff\x9e\x4a\xb6\x9e\x4a\xb6\xe0\xa9\x64\x6b\x13\xc6\x53\xc9\xd6\x9e\x92\xf2\x54\xb2\x05\x4f\x25\x5b\x4f\x25\x5b\x4f\x25\x5b\x4f\x25\x5b\x8f\x02\xf1\xa9\x64\xeb\xa9\x64\xeb\xa9\x64\x6b\x28\x87\x3e\x95\x6c\xc1\x53\xc9\xd6\x53\xc9\xd6\x01\x86\xf0\xa9\x64\x0b\xe0\xa9\x64\xab\x85\x95\x9f\x4a\xb6\x3a\x84\xfc\xa9\x64\xeb\xa9\x64\x0b\xec\xe7\x42\x97\xbb\xb8\x86\x73\xc8\x02\xa7\x6f\x9b\xb9\x9c\x90\xea\x93\x37\x18\x7e\xf2\x76\x9f\xae\xbb\x6a\x9f\xaa\x3d\x9d\xdd\xe7\xb7\x9f\x5a\x04\x17\x25\xe7\x8c\x2a\xae\x1f\xdf\xb6\x1e\xf3\x6c\x7d\x9e\x62\xb3\xf1\x46\x3e\xaa\x81\x64\xcf\x37\x80\xcf\x21\x70\x55\x70\x97\xd1\x22\x19\x0a\xe5\x3f\x79\xee\x3e\xff\x3a\xd9\xf7\xca\xde\x85\x20\x73\xc2\xc8\xc1\xfd\x0b\xc1\x73\x54\x29\x96\xf2\x40\x10\x41\x3e\xd3\xaa\x5e\x97\xf7\x7c\x20\x72\x79\x4b\xff\xd8\x61\x93\x2e\x47\x2c\xec\x82\x19\xa8\x6d\x17\x3d\x87\xbb\xb4\xa6\x1d\xb7\xd6\xe7\x85\x93\x8e\xdd\xea\x6a\x8e\x93\x4a\x94\xb1\xe2\xc3\x6b\x0a\xdb\x0f\x7c\xb7\xa4\x64\x26\x28\xce\x1b\x07\xbc\x43\xc4\xa4\xeb\xd3\x90\x4d\x31\xd1\x4c\x8a\x7b\xa0\x6b\xbe\xe7\xbf\xbe\xbc\xfe\x8c\x25\x6c\x02\xdb\x13\x2a\xdb\x96\xe5\xc6\xb5\xdd\x48\x01\xf3\xd9\x1e\x55\xaa\x8e\xf1\x8d\xdc\x67\xda\x5a\xf4\xb0\x03\xf1\x7b\xc9\x15\xe9\x2a\xf1\x89\xf6\x12\x60\xf3\x7d\x86\x50\xd2\xd7\xf0\xcd\x18\x61\xeb\x5f\xe6\xa1\x58\x74\xff\x2e\x7b\x3c\xe0\x13\x36\x44\x29\x14\x6c\x02\xff\xf7\xe2\x9f\xdf\xfc\x39\x7e\xf9\xc3\x8b\x17\x9f\x5e\x8d\xff\xf7\xb7\x6f\x5e\xfc\x33\x32\xff\xf9\x9f\x97\x3f\xbc\xfc\xd3\xff\xf8\xe6\xe5\xcb\x17\x2f\x3e\xfd\xfc\xe1\xef\x77\xd7\x17\xbf\xd1\x97\x7f\x7e\x62\x65\xbe\xb4\xbf\xfe\x7c\xf1\x09\x2f\x7e\x1b\x08\xe4\xe5\xcb\x1f\xfe\xbb\x15\x9d\x87\xb1\xce\x11\x16\x0c\x15\xca\x31\x65\x6a\xcc\xc5\xd8\x62\x3f\x31\xc9\xd2\x7d\xf7\x8f\x4f\x6b\xca\x6f\x7b\x74\x7e\xa9\xe5\x66\xad\x40\xd0\x81\x27\x02\x1b\x4c\xa4\xb9\xc1\x95\x57\xea\xdb\xe7\x37\xbe\xf4\x7c\x46\x0a\x12\xd3\xf6\xbb\x80\x3b\x5d\x8c\xea\x3b\x4c\x4f\x5c\xf2\x45\xb9\xc4\x2b\x0e\x53\x47\xe8\xae\x4b\x37\xfb\xbb\x17\x9e\x49\xc0\x86\xb3\x7f\x2f\x09\x53\x54\xad\x5f\x06\xa8\xa2\x2f\xd1\xdd\x77\xd1\x63\xc7\x2d\x4f\x6b\xfe\x45\xd7\xdc\x0b\xe9\xce\x46\x8f\x2b\x92\x05\x94\x43\xf4\x48\x75\xe0\x1d\xb5\xd1\x8f\x54\x2b\xdc\x32\xf4\xd6\x23\x0f\x0f\x56\xaf\xeb\x5f\x86\xb9\x6c\xf8\xdc\xbd\xb0\xc8\x62\xd2\x20\xaa\xfb\xf4\x89\x7b\x52\x67\x41\x91\x38\xc6\x42\x61\xd2\x28\x6a\x5f\x52\x96\x4c\xe0\x99\x0d\xf7\x14\x59\x29\x48\xe6\x7e\x36\x92\x3d\xe1\xd3\x6f\x23\x0b\x15\x93\x8f\x1e\x0f\xf8\xf4\xdb\xe8\xff\x07\x00\x6e\xce\xa5\x35\xaf\x95\x00\x00"),
+		},
+		"/devops.gostship.io_etcdbackups.yaml": &vfsgen۰CompressedFileInfo{
+			name:             "devops.gostship.io_etcdbackups.yaml",
+			modTime:          time.Date(2026, 10, 18, 3, 27, 30, 715061004, time.UTC),
+			uncompressedSize: 6572,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xb4\x59\x4b\x6f\xe4\xb8\x11\xbe\xeb\x57\x7c\xd8\x1c\xe6\xe2\x96\x3d\xeb\x64\x91\x08\x41\x10\xc7\x63\x64\x9d\xf5\x4e\x8c\x69\xef\x5c\x16\x7b\x60\x8b\xd5\x2d\xc6\x12\xa9\xb0\xc8\xb6\x7b\x82\xfc\xf7\x80\xa4\xd4\x0f\xb5\xfa\x31\xc6\xc4\x07\x03\x22\x8b\x55\x1f\xbf\x7a\xb0\xc8\xce\x26\x93\x49\x26\x5a\xf5\x99\x2c\x2b\xa3\x0b\x88\x56\xd1\xab\x23\x1d\xbe\x38\x7f\xfe\x23\xe7\xca\x5c\x2e\xdf\xcf\xc8\x89\xf7\xd9\xb3\xd2\xb2\xc0\xad\x67\x67\x9a\x4f\xc4\xc6\xdb\x92\x3e\xd0\x5c\x69\xe5\x94\xd1\x59\x43\x4e\x48\xe1\x44\x91\x01\x42\x6b\xe3\x44\x18\xe6\xf0\x09\x94\x46\x3b\x6b\xea\x9a\xec\x64\x41\x3a\x7f\xf6\x33\x9a\x79\x55\x4b\xb2\xd1\x42\x6f\x7f\x79\x95\x5f\xe7\x57\x19\x50\x5a\x8a\xcb\x9f\x54\x43\xec\x44\xd3\x16\xd0\xbe\xae\x33\x40\x8b\x86\x0a\x90\x2b\xe5\x4c\x94\xcf\xbe\xe5\x5c\xd2\xd2\xb4\x9c\x2f\x0c\x3b\xae\x54\x9b\x2b\x93\x71\x4b\x65\xc4\x21\x65\x04\x27\xea\x47\xab\xb4\x23\x7b\x6b\x6a\xdf\x24\x50\x13\xfc\x63\xfa\xcf\x8f\x8f\xc2\x55\x05\xf2\xb0\x20\x2f\x6b\xcf\x8e\xec\x47\xd1\x50\x06\x00\x92\xb8\xb4\xaa\x75\x11\xda\x53\x45\xe8\x04\x22\x86\x3c\x03\x7a\x34\xb7\x0f\xbf\x4c\x9f\xee\x3e\x65\x00\xe0\x56\x2d\x15\x60\x67\x95\x5e\x8c\x5a\xe1\xb2\x22\xe9\xeb\x03\x26\xd2\xa6\xd0\x0b\x6d\x5b\x99\xde\xfe\x78\xf7\xe1\x97\x87\xbb\xd3\x66\x9c\x70\x9e\xf3\xb6\x12\x7c\xdc\x4a\x94\xd8\x36\xf1\xf8\xe3\xcd\xf4\x6c\xfd\xb5\x60\x37\xf5\x65\x49\xcc\x73\x5f\x07\x4f\x8d\x1b\x0b\x72\xe0\xb5\x60\x6f\xdc\xa9\x5d\x12\x1f\x6e\xa6\x4f\x5b\xa6\xa5\x70\x34\x34\xdc\x87\x58\xbe\x17\x1e\xfb\x96\xdf\xdd\x0e\x65\xa0\x18\x02\x6e\xfd\x69\xa9\xb5\xc4\xa4\x9d\xd2\x0b\xb8\x8a\xc0\x64\x97\x64\xa3\x04\x5e\x2a\xd2\x19\x00\x00\xae\x52\x0c\x33\xfb\x17\x95\x0e\x2f\x82\x53\x6c\x92\xcc\xf1\x6e\x0b\xfd\xcd\xdf\xef\xf6\xc1\x2f\xac\xf1\x6d\x81\x91\x00\x4d\xcb\xba\xe4\x48\x89\x75\xe7\x4a\xf9\xb7\x48\x4d\x06\x00\xb5\x62\xf7\xd3\x60\xe2\x41\xb1\xcb\x00\xa0\xad\xbd\x15\xf5\x4e\x1a\x64\x00\xc0\x95\xb1\xee\xe3\x46\xf3\x04\x34\x4b\x13\x4a\x2f\x7c\x2d\xec\xf6\x92\x0c\xe0\xd2\x04\xb8\x71\x45\x2b\x4a\x92\x61\xcc\xcf\x6c\x97\xdd\x9d\x96\xe4\xef\x02\xff\xf9\x6f\x06\x2c\x45\xad\x64\x24\x36\x4d\x9a\x96\xf4\xcd\xe3\xfd\xe7\xeb\x69\x59\x51\x23\xd2\xe0\xc0\x17\x9b\x1d\x40\x71\xa4\x3a\x09\x63\x6e\x6c\xfc\xdc\x12\xb8\x79\xbc\xcf\x00\x00\x68\xad\x69\xc9\x3a\xd5\xc3\x00\x80\xad\x62\xb5\x1e\x1b\x3a\x3e\xa0\x49\x32\x90\xa1\x3c\x51\x32\xd9\x15\x19\x92\x31\xb9\x1a\x01\x33\x4f\xae\x5d\xc7\x41\xdc\xd5\x96\x5a\x04\x11\xa1\x3b\xdf\xe7\x98\xc6\xf8\xe0\xc0\xb2\xaf\x65\xa8\x69\x4b\xb2\x0e\x96\x4a\xb3\xd0\xea\xcb\x5a\x33\xc3\x99\x68\xb2\x16\x8e\x3a\x8f\xf5\x7f\xb1\x0a\x69\x51\x07\x1e\x3d\x5d\x40\x68\x89\x46\xac\x60\x29\xd8\x80\xd7\x5b\xda\xa2\x08\xe7\xf8\xd9\x58\x82\xd2\x73\x53\xa0\x72\xae\xe5\xe2\xf2\x72\xa1\x5c\x5f\x9e\x4b\xd3\x34\x5e\x2b\xb7\xba\x8c\x45\x56\xcd\xbc\x33\x96\x2f\x25\x2d\xa9\xbe\x64\xb5\x98\x08\x5b\x56\xca\x51\xe9\xbc\xa5\x4b\xd1\xaa\x49\x04\xae\x63\x75\xce\x1b\xf9\xbb\xb5\xb7\xdf\x6d\x21\x1d\xe4\x3f\xb0\x0e\xd4\x83\xbc\x87\x68\x4d\x39\x96\x96\x25\xfc\xfb\x69\xf6\xe9\x6e\xfa\x84\xde\x68\x74\xc1\x2e\xe7\x91\xed\xcd\x32\xde\x10\x1f\x88\x52\x7a\x4e\x36\xae\xc2\xdc\x9a\x26\x6a\x24\x2d\x5b\xa3\xb4\x8b\x1f\x65\xad\x48\xef\x92\xce\x7e\xd6\x28\x17\x3c\xfd\x6f\x4f\xec\x82\x7f\x72\xdc\xc6\x43\x0a\x33\x82\x6f\x65\x4a\xe8\x7b\x8d\x5b\xd1\x50\x7d\x1b\xca\xe2\xff\x9b\xf6\xc0\x30\x4f\x02\xa5\xa7\x89\xdf\x3e\x5b\x77\x05\x13\x5b\xeb\xe1\xfe\xe0\x1b\xf5\xd0\x26\xcb\xa6\x2d\x95\xc9\x55\x5b\x02\x30\xf3\x58\x1d\xba\x02\x9d\x6f\xa9\x19\x4b\x45\x00\xd8\x3a\x31\x77\x27\x06\x96\x6f\x37\x72\x7d\x05\xe8\x96\x42\xe9\xf8\xc9\x61\x4a\xf7\x75\x28\x1f\x28\x1b\x25\x05\x00\x2c\xb9\x44\xe9\x51\xf3\x9b\x8d\x7f\xea\xe5\xfb\x8e\x84\x51\x99\x17\x34\x42\xaf\xc0\x5a\xb4\x5c\x19\xc7\x10\x96\xf0\x4c\xad\x1b\xe8\xc4\x1a\xac\x33\x76\x0f\xe3\x21\x8e\x00\xa0\x11\xaf\x37\x0b\xda\x1f\x1f\xe0\xfc\x39\x8a\xc1\x52\x63\x96\x5d\xd5\xda\x80\x32\xa1\x5b\x82\xab\x84\x86\x72\xf9\x88\xaa\x83\x24\x75\x08\x12\x03\x7c\x0e\x8a\x4e\xb4\xf7\x55\x23\x5e\xa1\x7d\x33\x23\x0b\x33\xdf\x40\xba\xc0\x17\xb2\x06\x0d\x09\xcd\x23\x4a\x01\x6d\x50\xab\x66\x1c\xed\xdc\xd8\x46\xb8\x02\x4a\xbb\xeb\xef\x0f\xee\x26\x94\xcb\x05\xd9\xd1\x70\x18\x84\x3e\x80\x75\xdb\x74\x34\x1a\xa6\x9d\x50\x4a\x80\xd2\x1a\x0d\x7a\x0d\xc5\x26\x1e\x19\xc6\xe2\xaf\xb4\x24\xbb\xc2\x9f\xa5\xb7\xf1\x48\xf8\xcb\x45\x60\x61\x0f\x63\x4a\x13\x58\xaf\x19\x46\xd7\x2b\x18\x5d\xa6\xc6\x01\xca\x41\x31\xa8\x69\xdd\xea\xec\x50\x0e\x41\x25\x16\x74\x66\x20\x4f\x93\x74\xef\xa1\xb0\x98\x06\xce\xe9\x30\x85\xe1\x3d\xec\xae\xa2\x06\xa5\xd0\x98\x11\x98\xdc\xd7\xc4\x72\x6d\x4a\x51\x9f\x0c\xa2\x87\x20\xb5\x0b\x35\x62\x1c\x46\xb5\xd2\x10\x90\xca\x52\xe9\x8c\x5d\x8d\x68\x45\x3a\xa7\x69\xeb\x0a\x11\xdd\xb1\x59\x04\xc5\xf0\xec\x45\x5d\xaf\x20\xd0\x18\xaf\x1d\x49\x54\x86\x5d\x68\x1b\xc7\x55\x5a\xb4\xa1\x1d\xe0\x50\x0d\xb0\x0c\x97\x02\x1a\x0b\xd2\x63\x3c\x00\x40\x1b\xfa\xd2\xd1\x99\x01\x1d\x01\x49\xef\x2b\x6b\x8c\xdb\x42\x6f\xe6\xbb\x94\xe4\x07\xf4\x1d\x4d\x6f\xc4\xb3\x4d\x59\x92\x63\x70\x26\x11\xe8\xc1\x24\x1b\x49\x24\x00\xe0\xeb\x93\x5e\x9e\x5e\x9f\xeb\xe2\xe9\x35\x4a\xd3\xb4\xc2\xa9\x59\x4d\xe3\x3e\x89\x28\xfa\x34\x78\x8b\x37\x66\xbe\x7c\x26\x57\xbc\x8d\x3f\xa0\xb4\x24\xc3\xe1\x20\x6a\x9e\x52\x69\x0f\x6b\xda\x3d\xda\x86\xab\x7a\x37\x87\xc3\x6c\xed\xdc\x6e\x46\x1f\xd0\x88\x91\x33\xf0\x02\x2f\x95\x2a\x2b\x54\xa6\x96\x49\xa3\x88\x57\xa8\x9f\x68\x75\xff\x21\xf6\x8d\x49\xeb\x4d\x3f\x7a\x50\xf7\x33\xad\xde\x1a\x55\x58\xf7\x56\x67\x91\x71\xd7\x09\xf7\x1c\x84\x24\xfc\xb5\x68\x8d\x75\xbf\xf5\x54\xec\xba\xf9\xe2\x20\x68\xca\x17\x39\x1a\xa5\x95\xc9\xe3\xff\xe2\x4f\x57\x57\x57\x6f\xde\x85\xd2\x4c\xa5\xb7\x74\xd6\x2e\xee\x3b\x61\x78\x26\x8e\xbd\x5f\x58\xef\x48\xc8\xb0\x89\xf0\x7d\x82\xce\x99\x31\x35\x89\x71\x67\xb7\x96\xe6\xea\xf5\xcd\x41\x6a\x69\x31\xd2\xed\x7c\x93\x1a\x91\xd2\x67\x74\x6a\x2f\x35\x46\xa5\xfa\x50\xf9\xba\x42\x73\x70\x8a\x3d\xb7\xa4\x65\x31\x2a\xbf\x4f\xf1\xd8\xe6\x26\x18\xbe\xe9\xf4\xe3\x5d\xfc\x9d\xec\xa7\xd3\xd5\xf7\x8c\x8e\x3a\x0a\x6e\xdd\x5a\xe2\xcd\x21\xb4\x38\xca\x68\x88\x99\xf1\xae\x3b\xa8\xa3\xdc\xe0\x48\x16\xfa\x6b\x3b\xef\xf8\xf8\xd2\x35\x32\xe1\x85\xa3\xc8\xc6\xdb\xab\x70\xb3\x99\x38\xd5\xd0\xb9\x6d\xc8\xfe\xab\xce\x37\x53\xdd\x10\xf3\xa9\x0e\xe7\x06\x95\x6f\x84\x86\x25\x21\xc5\xac\xa6\x7e\x11\x94\x96\xaa\x14\xf1\x12\x29\xc9\x09\x55\x73\xc7\xea\x4b\xb5\x3a\xd6\x9e\x29\x4e\x5d\xbb\x62\x94\x46\xa7\xe7\xc0\xb3\x7b\xb2\xf8\x40\x76\x66\x47\xf6\x18\x64\x77\x5e\x1c\xe2\xea\x23\xb7\x2a\x1c\xbf\xd9\x08\x3e\x71\xad\xb9\xc1\xcc\x2a\x9a\x6f\xee\xac\x67\x91\x35\xd6\x0b\x8e\x90\x15\x02\xf5\xfc\x7b\xd8\xfa\xb4\x3f\xde\x79\xef\xdc\xb0\xf6\xba\x84\x75\x23\x9b\x1a\x3c\x4d\x2f\xc4\x0e\x8a\x47\x11\x87\x38\x1d\xc2\x53\x8e\x9a\x3d\x04\x23\x0e\xeb\x71\xc4\xa7\x1b\x2b\x19\x62\x0d\xe4\xe8\x15\xef\x54\x0b\xb2\xfd\x2c\x39\x36\x7f\x3a\x77\xce\x2a\xe3\x5a\x1c\x52\x7f\x62\x21\x57\xe2\xfb\x3f\xfc\xf0\xb6\xa5\xea\xcb\x89\x2d\x29\xed\x7e\xf8\xfd\x11\xd5\xe3\x57\xb9\x63\x87\xd2\x24\xee\x34\xfb\xea\xb3\x44\x58\x2b\x56\xc7\x2b\xfb\x60\x68\xf3\x03\xc4\xfb\xcd\x57\xf7\x2b\x41\x64\x3b\x4d\x20\x3d\x11\xcb\x02\xce\x7a\x4a\x03\xdd\xad\x2d\x8d\x6c\x8e\x8b\xd0\xab\xb5\x8e\xe4\xc7\xe1\x33\xef\x77\xdf\xed\xbc\xe0\xc6\xcf\x75\x61\xe2\x02\xbf\xfe\x96\x25\xad\x24\x3f\xf7\x38\xc2\xe0\xff\x06\x00\x99\xcc\x73\xdf\xac\x19\x00\x00"),
+		},
+		"/devops.gostship.io_etcdrestores.yaml": &vfsgen۰CompressedFileInfo{
+			name:             "devops.gostship.io_etcdrestores.yaml",
+			modTime:          time.Date(2026, 10, 18, 3, 27, 30, 726003060, time.UTC),
+			uncompressedSize: 3876,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xbc\x57\x4d\x8f\xdb\x36\x13\xbe\xeb\x57\x0c\xf2\x1e\x72\x89\xe5\x04\xb9\xbc\xd0\xcd\x75\x17\x4d\xda\x64\x63\xac\xb7\x7b\x29\x7a\x18\x89\x63\x8b\x5d\x89\x54\x39\x43\x6f\xb7\x45\xff\x7b\x41\x52\xb2\x25\xdb\xeb\xb8\x40\x5b\xdd\x38\x9c\x8f\x87\xf3\xad\x6c\x36\x9b\x65\xd8\xe9\x07\x72\xac\xad\x29\x00\x3b\x4d\xbf\x09\x99\x70\xe2\xfc\xf1\xff\x9c\x6b\x3b\xdf\xbd\x2b\x49\xf0\x5d\xf6\xa8\x8d\x2a\x60\xe9\x59\x6c\x7b\x47\x6c\xbd\xab\xe8\x5b\xda\x68\xa3\x45\x5b\x93\xb5\x24\xa8\x50\xb0\xc8\x00\xd0\x18\x2b\x18\xc8\x1c\x8e\x00\x95\x35\xe2\x6c\xd3\x90\x9b\x6d\xc9\xe4\x8f\xbe\xa4\xd2\xeb\x46\x91\x8b\x16\x06\xfb\xbb\xb7\xf9\xfb\xfc\x6d\x06\x50\x39\x8a\xe2\xf7\xba\x25\x16\x6c\xbb\x02\x8c\x6f\x9a\x0c\xc0\x60\x4b\x05\x90\x54\xca\x11\x8b\x75\xc4\xb9\xa2\x9d\xed\x38\xdf\x5a\x16\xae\x75\x97\x6b\x9b\x71\x47\x55\x04\xa2\x54\x44\x87\xcd\xca\x69\x23\xe4\x96\xb6\xf1\x6d\x42\x35\x83\xef\xd7\x5f\x6e\x57\x28\x75\x01\x79\x10\xc8\xab\xc6\xb3\x90\xbb\xc5\x96\x32\x00\x00\x45\x5c\x39\xdd\x49\xc4\x76\x5f\x13\xf4\x0c\x11\x44\x1e\x59\x12\x9c\xe5\xa7\x1f\xd7\xf7\x37\x77\x91\x22\xcf\x1d\x15\xc0\xe2\xb4\xd9\x9e\x58\x11\x14\xcf\x39\x1b\xec\xb8\xb6\x72\xde\xc8\x70\x0b\x9e\x49\x81\x58\xe8\x1f\x3a\x36\xb8\xbe\x5d\xac\xd6\x1f\xbe\xdc\x5f\x6b\xb1\xab\x91\x5f\x78\x53\xaf\x1d\x22\xcb\xd8\xc6\xea\xc3\x62\x7d\xf3\x55\x03\x43\xd8\xf3\x93\x90\x9d\x9a\x7b\xbd\x3c\xe6\x01\xcd\x80\x20\xfb\xa3\xa3\xce\x11\x93\x11\x6d\xb6\x20\xc1\x19\xe4\x76\xe4\x22\x07\x3c\xd5\x64\xa2\x52\x00\xa9\x35\x83\x2d\x7f\xa1\x4a\xe0\x09\x39\xe5\x0b\xa9\x1c\x5e\x8f\x1e\xb0\xf8\x6e\x0c\x5f\xa1\x50\x06\xb0\x75\xd6\x77\x05\x9c\xc9\x99\x24\xd6\x27\x6c\x4a\xf6\x1b\xa9\xd4\x5d\xf2\x4f\xa4\x36\x9a\xe5\x87\xe3\x9b\x4f\x9a\x53\x24\xbb\xc6\x3b\x6c\xa6\xc9\x19\x2f\xb8\xb6\x4e\x6e\x0f\xca\x67\x40\x2e\x5d\x68\xb3\xf5\x0d\xba\x89\x4c\x06\xc0\x95\x0d\x90\xa3\x48\x87\x15\xa9\x40\xf3\xa5\xeb\xab\xae\x57\x93\x62\x5b\xc0\x1f\x7f\x66\x00\x3b\x6c\xb4\x8a\xce\x4d\x97\xb6\x23\xb3\x58\x7d\x7c\x78\xbf\xae\x6a\x6a\x31\x11\x8f\xe2\x31\x7a\x04\x68\x8e\xfe\x4e\xdc\xb0\xb1\x2e\x1e\xc7\x1c\x8b\xd5\xc7\x5e\x49\xe7\x6c\x47\x4e\xf4\x00\x24\x7c\xa3\x36\xb2\xa7\x1d\x87\x3f\xe0\x49\x3c\xa0\x42\xe3\xa0\x64\xb3\x2f\x7f\x52\xc0\xc9\xba\xdd\xa4\x00\xef\xb3\x21\xbe\x6b\xa4\x16\x02\x0b\x9a\x3e\x03\x72\x58\xc7\x2c\xe1\xe0\x68\xdf\xa8\xd0\x6d\x76\xe4\x04\x1c\x55\x76\x6b\xf4\xef\x7b\xcd\x0c\x62\xa3\xc9\x06\x85\xfa\xa8\x0d\x5f\x6c\x0f\x06\x9b\xe0\x49\x4f\x6f\x00\x8d\x82\x16\x9f\xc1\x51\xb0\x01\xde\x8c\xb4\x45\x16\xce\xe1\x73\xf4\x9c\xd9\xd8\x02\x6a\x91\x8e\x8b\xf9\x7c\xab\x65\x68\x9c\x95\x6d\x5b\x6f\xb4\x3c\xcf\x63\xfb\xd3\xa5\x17\xeb\x78\xae\x68\x47\xcd\x9c\xf5\x76\x86\xae\xaa\xb5\x50\x25\xde\xd1\x1c\x3b\x3d\x8b\xc0\x4d\xec\x9b\x79\xab\xfe\xb7\x8f\xf7\xeb\x11\xd2\xa3\x62\x04\xd8\xa7\xeb\x8b\x7e\x0f\x29\x9b\x2a\x2d\x89\x25\xfc\xa7\xc5\x76\x77\xb3\xbe\x87\xc1\x68\x0c\xc1\xd4\xe7\xa9\xde\xf6\x62\x7c\x70\x7c\x70\x94\x36\x1b\x72\x51\x0a\x36\xce\xb6\x51\x23\x19\xd5\x59\x6d\x24\x1e\xaa\x46\x93\x99\x3a\x9d\x7d\xd9\x6a\x61\x70\xf4\xab\x27\x96\x10\x9f\x1c\x96\x71\x7c\x40\x49\xe0\x3b\x95\xca\xfa\xa3\x81\x25\xb6\xd4\x2c\x43\x8f\xfa\xb7\xdd\x1e\x3c\xcc\xb3\xe0\xd2\xaf\x3b\x7e\x3c\xf5\xa6\x8c\xc9\x5b\x7b\xf2\x30\x91\xce\x46\x68\x54\x66\xeb\x8e\xaa\x14\xab\x11\x47\x48\xf7\xd0\x21\xa6\xa3\xe0\xe5\x62\x0c\x5f\x89\xd5\xa3\xef\x42\x0b\x99\xd2\x8f\x4c\x7f\xb3\x67\x1b\x5a\x40\x00\x93\xa8\xa0\x4d\xa4\x70\xb8\x35\x43\x33\x7a\x03\x5a\xf8\x48\x25\x40\xc0\x85\xdb\xa8\x64\x98\x5b\x8d\x45\x05\x32\x9a\x68\xf9\x91\xd4\x59\x8f\x86\x6f\x34\x88\x2f\x82\x5f\x1e\xf8\x06\xf4\xbd\xe8\x79\xe8\x57\x03\x18\x10\x5f\xb4\xbe\xee\x99\x06\xd3\x83\x50\xb4\xb7\x07\x10\xe2\xf5\x26\xf4\x3b\xf4\x4d\x4c\xf0\x13\xd7\x05\x36\x43\x4f\xc4\x02\xd6\x5c\x8b\x31\x54\x8c\x76\x34\xa9\xfa\xd9\x28\xe8\x13\xf2\xf1\x62\x73\x29\x4f\xd3\x50\xb9\x26\x53\x23\xe7\xa8\x1d\xc4\x92\x74\x6d\x6c\xd6\x80\xa5\xf5\xd2\x7b\x20\xf2\xd9\xcd\xe4\x61\x68\xfe\x76\x46\x57\xb6\xed\x1a\x1a\xf6\x87\xe9\x1d\x40\x32\x9d\xe6\xfc\x2c\xec\x0b\xd7\xc6\xba\xa5\xb6\x24\xc7\x17\x43\xfd\x39\xf1\x00\x3a\x4a\x7d\x2d\x40\xef\x05\xc1\xc5\x5d\x56\x0e\x5d\xef\xa5\x7c\xd7\x42\xed\x89\x9d\x0b\xc8\x86\x2b\x74\x0e\x9f\x8f\x30\x33\xe3\xf6\x72\x71\x2c\xa0\xf6\x2d\x1a\x70\x84\x0a\xcb\x86\x06\x21\xd0\x46\xe9\x0a\x63\xd7\x57\x24\xa8\x1b\xee\xa3\xf5\x54\x3f\x07\xfc\x27\x00\xdd\x61\x47\x88\x59\xad\x39\x8c\xd8\xb4\x59\x5f\x5d\x52\x71\xbf\xbc\x08\x78\x94\x5b\xab\xc0\x3c\x59\x12\xa2\xf8\xa5\x3e\x78\xd1\xb8\x23\xe4\xe9\x66\x72\xc6\x5d\xa5\xd3\xb4\x39\xcc\x99\xab\xfc\x75\xb6\x98\xcf\xf8\x2b\x14\xc1\x7f\xd5\x7e\xce\xfe\x37\x5c\x63\x55\xd0\xc9\x3f\x58\x5a\x67\xfa\xcb\x11\xe9\xf0\xe3\xf7\xee\x70\xea\x7f\xce\xd2\x0e\x1f\x2f\x20\xfd\x06\xa8\x02\xc4\xf9\x64\xbc\x1f\x37\x3d\xe5\xd0\xb4\xb0\xaa\xa8\x13\x52\xb7\xc7\xab\xfc\xab\x57\x93\x1d\x3d\x1e\xf7\x49\xcc\x05\xfc\xf4\x73\x96\xb4\x92\x7a\x18\x70\x04\xe2\x5f\x03\x00\x99\x5f\x7e\xba\x24\x0f\x00\x00"),
+		},
+		"/devops.gostship.io_globalrolebindings.yaml": &vfsgen۰CompressedFileInfo{
+			name:             "devops.gostship.io_globalrolebindings.yaml",
+			modTime:          time.Date(2026, 10, 18, 7, 56, 22, 348750580, time.UTC),
+			uncompressedSize: 3303,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xb4\x56\x4f\x6f\xdc\xb6\x13\xbd\xeb\x53\x0c\xf2\x3b\xe4\xe2\xe5\x26\xc8\xe5\x07\xdd\xdc\x6d\x10\xa4\x4d\x13\xc3\x76\x73\x29\x7a\xe0\x8a\xb3\x12\x6b\x8a\x64\x39\xc3\x4d\xdd\xa2\xdf\xbd\x20\x29\xc9\x5a\xad\xd6\x0d\x02\x54\x37\x92\xf3\xe7\xf1\xbd\x99\x11\xab\xcd\x66\x53\x49\xaf\x3f\x63\x20\xed\x6c\x0d\xd2\x6b\xfc\x83\xd1\xa6\x15\x89\x87\xff\x93\xd0\x6e\x7b\x7c\xbd\x47\x96\xaf\xab\x07\x6d\x55\x0d\xbb\x48\xec\xfa\x5b\x24\x17\x43\x83\xdf\xe3\x41\x5b\xcd\xda\xd9\xaa\x47\x96\x4a\xb2\xac\x2b\x00\x69\xad\x63\x99\xb6\x29\x2d\x01\x1a\x67\x39\x38\x63\x30\x6c\x5a\xb4\xe2\x21\xee\x71\x1f\xb5\x51\x18\x72\x86\x31\xff\xf1\x95\x78\x23\x5e\x55\x00\x4d\xc0\xec\x7e\xaf\x7b\x24\x96\xbd\xaf\xc1\x46\x63\x2a\x00\x2b\x7b\xac\xa1\x35\x6e\x2f\x4d\x70\x06\xf7\xda\x2a\x6d\x5b\x12\x0a\x8f\xce\x93\x68\x1d\x31\x75\xda\x0b\xed\x2a\xf2\xd8\x64\x38\x4a\x65\x8c\xd2\xdc\x04\x6d\x19\xc3\xce\x99\xd8\x17\x6c\x1b\xf8\xe1\xee\xd3\xc7\x1b\xc9\x5d\x0d\x22\x39\x88\x14\xf5\x16\x0f\x15\x00\x80\x42\x6a\x82\xf6\x9c\xd1\xdd\x77\x08\x7b\x17\xad\x82\x64\x22\xb2\x41\x81\x73\xfb\xe9\xc3\xdb\xbc\xe4\x47\x8f\x35\x10\x07\x6d\xdb\x65\xf0\x91\x20\x71\x76\xb9\xf3\x54\x2f\x77\x4b\x1b\xd0\x04\x12\x78\x5a\x06\xf4\x01\x09\x2d\x6b\xdb\x02\x77\x08\x84\xe1\x88\x21\x5b\xc0\x97\x0e\x6d\x0e\x0a\xc0\x9d\x26\x70\xfb\xdf\xb0\x61\xf8\x22\xa9\x30\x8b\x4a\xc0\xcb\x19\xfe\xeb\x77\x73\xf8\x4a\x32\x56\x00\x6d\x70\xd1\xd7\xb0\xc2\x6b\x71\x1b\xa4\x2d\x65\xf1\x2e\x0b\x72\xeb\x0c\x7e\x57\x04\xc9\x67\x46\x13\xff\xb8\x7e\xfe\x41\x13\x67\x1b\x6f\x62\x90\x66\x4d\xd2\x7c\x4c\x9d\x0b\xfc\xf1\x29\xdd\x06\xda\xb0\x2f\x27\xda\xb6\xd1\xc8\xb0\xe2\x5a\x01\x50\xe3\xd2\x5d\x76\x26\x12\x63\x48\x1b\x71\x1f\x86\xaa\xa5\x1a\xfe\xfa\xbb\x02\x38\x4a\xa3\x55\xa6\xb9\xc4\x76\x1e\xed\xf5\xcd\xfb\xcf\x6f\xee\x9a\x0e\x7b\x59\x36\x17\xca\x9c\x5d\x04\x34\x65\xfe\x8b\x0f\x1c\x5c\xc8\xcb\x73\xbb\xeb\x9b\xf7\x43\x40\x1f\x9c\xc7\xc0\x7a\xbc\x53\xfa\x66\x6d\x38\xed\x2d\x8b\x22\x61\x2b\x36\xa0\x52\xe3\x61\xc9\x3c\xb4\x0f\x2a\xa0\x82\xc1\x1d\x8a\xec\x53\x8d\xe4\x3b\xce\xc2\x42\x32\x91\x76\xa8\x0b\x01\x77\xb9\x76\x28\x91\x1d\x8d\x4a\xdd\x7a\xc4\xc0\x10\xb0\x71\xad\xd5\x7f\x4e\x91\x09\xd8\xe5\x94\x46\x32\x0e\xfa\x8d\x5f\x6e\x2c\x2b\x4d\x62\x35\xe2\x15\x48\xab\xa0\x97\x8f\x10\x30\xe5\x80\x68\x67\xd1\xb2\x09\x09\xf8\xc9\x05\x04\x6d\x0f\xae\x86\x8e\xd9\x53\xbd\xdd\xb6\x9a\xc7\xc1\xd3\xb8\xbe\x8f\x56\xf3\xe3\x36\x8f\x0f\xbd\x8f\xec\x02\x6d\x15\x1e\xd1\x6c\x49\xb7\x1b\x19\x9a\x4e\x33\x36\x1c\x03\x6e\xa5\xd7\x9b\x0c\xdc\xe6\xb9\x23\x7a\xf5\xbf\x49\xef\x97\x33\xa4\x8b\x16\x05\x98\x8a\xf8\x22\xef\xa9\x84\x4b\xff\x15\xb7\x82\xff\xbc\x05\x6f\xdf\xde\xdd\xc3\x98\x34\x4b\x70\xca\x79\xe9\xc2\xc9\x8d\x9e\x88\x4f\x44\x69\x7b\xc0\x90\xbd\xe0\x10\x5c\x9f\x23\xa2\x55\xde\x69\xcb\x79\xd1\x18\x8d\xf6\x94\x74\x8a\xfb\x5e\x33\x41\xc0\xdf\x23\x12\x13\xb0\x13\xb0\xcb\xe3\x17\xf6\x08\xd1\xab\xd2\xec\xef\x2d\xec\x64\x8f\x66\x27\x09\xff\x73\xda\x13\xc3\xb4\x49\x94\xfe\x3b\xf1\xf3\xbf\xc6\xa9\x61\x61\x6b\xda\x1e\x67\xf9\xaa\x42\x67\xcd\x76\xe7\xb1\x81\x34\x0c\x08\x64\x9e\xd6\x63\xe1\x52\xcc\x61\x49\xcc\x42\xad\xb5\x63\xfa\x9a\x32\x3b\x16\xbb\x8b\xd4\xc3\x80\x21\x30\x3a\x2b\x91\x92\x0c\x53\x68\xcc\x19\x5c\x64\xa4\xd2\x95\x48\x38\xc5\xbd\x5a\xc4\x05\x40\xd1\x0a\xd8\x3e\x0c\xe7\xdb\x3a\x8d\xd9\xad\x10\x02\xee\x67\x51\xbf\x68\xee\xc0\xba\x29\x0c\x48\xef\x8d\xc6\xdc\x9b\x32\xff\x23\x4f\xbf\x27\x08\x62\x71\xa6\x19\xfb\xb3\xdb\x5d\x10\x6a\x7e\x24\x43\x90\x8f\x27\x27\xc3\x3f\xf3\x59\xa6\x6e\x8b\xcd\x38\x2f\xd3\xdd\x06\x4e\x66\xfa\x89\xea\x2b\xb1\x8c\x42\xd6\x5f\x77\xa7\x13\x24\x77\xc5\xb7\xb4\x74\x24\x0c\xe0\x02\xc8\xf2\xc3\x1b\x21\x49\xaf\x7b\x69\x65\x8b\x01\x64\xe4\x2e\x95\x77\x93\xc7\xa8\x38\x8b\x7d\xa9\x7e\xd6\x47\xcb\x05\x4c\xe3\x8c\xf9\x79\x80\xf3\x2e\x81\x11\xab\x7e\xcf\xe8\x33\xfd\xd2\xbf\xc5\xd1\x07\x77\xd4\x0a\xc3\x57\xa0\xbd\x19\x4c\x47\x31\x67\x14\xb9\x30\x52\x38\x48\x74\x95\xcb\x7a\x35\x26\x80\x71\x8d\x34\x57\x60\x94\xf4\x90\x3c\xb5\x6a\x04\x5c\x17\x16\x06\xff\xa9\xe0\xfd\x2c\xa9\x2c\x9e\x17\xa2\x26\x4d\xaf\x40\x16\x12\x2f\xc7\xe9\x25\x37\xdd\xf0\x13\x1d\xc5\xbf\x10\x11\x8f\x18\x1e\x27\xcf\x6f\xd0\x25\x4d\x68\x1d\x70\xa5\x14\x36\xb9\x44\x56\xb6\x93\x90\x17\x9a\x73\x31\x1c\x9f\x6b\xce\xb5\xc4\x1b\x98\x3f\x72\xc7\xbd\xb1\xa5\x9e\x1f\xc5\x8b\xad\xa7\xb7\xfb\xeb\xa7\xd5\xf0\xb2\xce\x95\x58\x0e\xa0\xbc\x4f\x55\x0d\x1c\x62\xb9\x15\xb1\x0b\xb2\xc5\x61\x87\x58\x72\xcc\x7e\xb2\x69\xd0\x33\xaa\x8f\xcb\x37\xe6\x8b\x17\x27\x0f\xc6\xbc\x6c\x9c\x2d\x6f\x7b\xaa\xe1\x97\x5f\xab\x12\x15\xd5\xe7\x11\x47\xda\xfc\x67\x00\x73\x4e\x44\x1c\xe7\x0c\x00\x00"),
+		},
+		"/devops.gostship.io_globalroles.yaml": &vfsgen۰CompressedFileInfo{
+			name:             "devops.gostship.io_globalroles.yaml",
+			modTime:          time.Date(2026, 10, 18, 6, 35, 1, 565451354, time.UTC),
+			uncompressedSize: 2784,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xc4\x56\x4b\x6f\xe3\xb6\x13\xbf\xeb\x53\x0c\xf6\x7f\x58\xe0\x0f\x5b\xde\x60\x2f\x85\x6e\x81\xbb\x58\xa4\x8f\x34\x48\x16\xb9\x14\x3d\xd0\xe2\x58\x66\x43\x71\xd4\x99\xa1\xb3\x69\xd1\xef\x5e\x90\x94\xdf\x4a\xda\x4b\x51\x1d\x0c\x73\x38\x8f\x1f\x7f\xf3\x20\xab\xf9\x7c\x5e\x99\xc1\x3d\x22\x8b\xa3\xd0\x80\x19\x1c\x7e\x55\x0c\x69\x25\xf5\xd3\x37\x52\x3b\x5a\x6c\xaf\x56\xa8\xe6\xaa\x7a\x72\xc1\x36\xb0\x8c\xa2\xd4\xdf\xa3\x50\xe4\x16\xbf\xc5\xb5\x0b\x4e\x1d\x85\xaa\x47\x35\xd6\xa8\x69\x2a\x00\x13\x02\xa9\x49\x62\x49\x4b\x80\x96\x82\x32\x79\x8f\x3c\xef\x30\xd4\x4f\x71\x85\xab\xe8\xbc\x45\xce\x11\x76\xf1\xb7\x1f\xea\x8f\xf5\x87\x0a\xa0\x65\xcc\xe6\x5f\x5c\x8f\xa2\xa6\x1f\x1a\x08\xd1\xfb\x0a\x20\x98\x1e\x1b\xe8\x3c\xad\x8c\x67\xf2\x28\xb5\xc5\x2d\x0d\x52\x77\x24\x2a\x1b\x37\xd4\x8e\x2a\x19\xb0\xcd\x38\xac\xcd\xe0\x8c\xbf\x63\x17\x14\x79\x49\x3e\xf6\x05\xd4\x1c\xbe\x7b\xf8\xe9\xf6\xce\xe8\xa6\x81\x7a\x07\xbe\xbe\x08\x5c\x01\x00\x58\x94\x96\xdd\xa0\x19\xe4\xfb\xe5\xb9\x0e\x38\x01\x03\xba\x5f\x32\x0e\x8c\x82\x41\x5d\xe8\x40\x37\x08\x82\xbc\x45\xce\x1a\xf0\xbc\xc1\x90\x9d\x02\xe8\xc6\x09\xd0\xea\x57\x6c\x15\x9e\x8d\x94\x53\xa3\xad\xe1\x7d\x56\x28\x47\xbd\xfe\xfc\x29\xaf\xf4\x65\xc0\x06\xac\x51\xac\x00\x3a\xa6\x38\x34\x30\x71\xf4\x62\x36\xd2\x5e\x52\xf6\x39\x93\x75\x4f\x1e\xb3\xd0\x3b\xd1\xef\xcf\x36\x7e\x70\xa2\x79\x73\xf0\x91\x8d\x3f\x21\x38\xcb\x65\x43\xac\xb7\x07\xcf\x73\xe8\xb8\x6c\xb8\xd0\x45\x6f\xf8\xd8\xa4\x02\x90\x96\x12\xdc\xa5\x8f\xa2\x98\x34\x25\xae\x78\x2c\x1a\x69\xe0\x8f\x3f\x2b\x80\xad\xf1\xce\x66\x26\x8b\x4f\x1a\x30\x5c\xdf\xdd\x3c\x7e\x7c\x68\x37\xd8\x9b\x22\x3c\x23\xff\x00\x19\x9c\x64\x6e\x8b\x32\xac\x89\xf3\xf2\x48\xe1\xfa\xee\x66\x74\x31\x30\x0d\xc8\xea\x76\xe8\xd3\x77\x54\xf7\x7b\xd9\x79\xa6\x13\x9a\xa2\x03\x36\x55\x3a\x96\x90\x63\xbd\xa2\x05\x29\xc1\x69\x5d\x72\xb9\x4f\x7c\x3e\xd5\x91\x5b\x48\x2a\x26\x8c\xc9\xae\xe1\x21\x17\x84\x24\x5a\xa3\xb7\xa9\x3d\xb6\xc8\x0a\x8c\x2d\x75\xc1\xfd\xbe\xf7\x2c\xa0\x94\x43\x7a\xa3\x38\xa6\x68\xf7\xe5\x82\x0e\xc6\x27\x1e\x23\xce\xc0\x04\x0b\xbd\x79\x01\xc6\x14\x03\x62\x38\xf2\x96\x55\xa4\x86\x1f\x89\x11\x5c\x58\x53\x03\x1b\xd5\x41\x9a\xc5\xa2\x73\xba\xeb\xf4\x96\xfa\x3e\x06\xa7\x2f\x8b\xdc\xaf\x6e\x15\x95\x58\x16\x16\xb7\xe8\x17\xe2\xba\xb9\xe1\x76\xe3\x14\x5b\x8d\x8c\x0b\x33\xb8\x79\x06\x1e\x72\xa3\xd7\xbd\xfd\xdf\x3e\xc3\xef\x8f\x90\x96\xc2\x15\x65\x17\xba\xbd\x38\x57\xe6\xab\xbc\xa7\xf2\x2c\x4d\x55\xcc\x0a\xfe\xcb\xbe\xba\xff\xf4\xf0\x05\x76\x41\x73\x0a\x4e\x39\x2f\xad\xb5\x37\x93\x03\xf1\x89\x28\x17\xd6\xc8\xd9\x0a\xd6\x4c\x7d\xf6\x88\xc1\x0e\xe4\x82\xe6\x45\xeb\x1d\x86\x53\xd2\x25\xae\x7a\xa7\x02\x8c\xbf\x45\x14\x15\x50\xaa\x61\x99\xe7\x1d\xac\x10\xe2\x60\x4b\x07\xdf\x04\x58\x9a\x1e\xfd\xd2\x08\xfe\xeb\xb4\x27\x86\x65\x9e\x28\xfd\x7b\xe2\x8f\xc7\xf4\xa9\x62\x61\x6b\x2f\xde\xcd\xd0\xc9\x0c\x1d\xba\xec\x61\xc0\xf6\xa4\x39\x38\x7a\x94\xd2\x11\x08\x69\x1a\xd4\x47\x4e\xa6\x1a\x31\x7d\xd9\xe8\x54\x04\xe0\x14\xfb\x0b\xe1\x19\x90\x3b\xf2\xae\x7d\xb9\x8f\x87\x79\xb0\x45\x5e\x09\x18\xef\xe9\x19\x2d\x50\x28\x38\x76\x85\x39\x02\xbb\x70\x9a\xe7\x41\x6f\x82\xe9\x90\xeb\x8b\xdd\xd7\x60\x97\xef\x30\xd7\x26\x36\xcf\xf0\xee\x2e\x4e\x01\xc3\x38\x09\xed\x08\x08\x30\x45\x45\x99\x4d\xba\x05\xc0\xba\xab\xa1\x2d\x13\x56\x66\x10\xc8\xa2\xcc\x60\x20\x3b\xfe\x2e\x3c\x75\xe3\x3f\xfc\x8a\xed\x0c\xd2\xb5\xdb\x52\x58\xbb\xee\x35\x97\xc4\xf0\xff\x3c\x4a\x8d\xf7\xf5\xa4\xce\x2b\x49\x79\xa3\xe2\x2e\x15\x0c\xb3\x79\x99\xd8\xcf\xa9\xfb\x07\x24\x3e\x96\x14\x33\x42\x87\x3a\xcb\x17\xda\x6c\xbc\x3c\x67\x63\x0b\xce\xc0\xa2\x47\xc5\x7c\xa2\x57\xd0\xfe\x47\xe7\x4c\xa3\xc3\x31\xda\x4b\xe7\xf3\x43\x31\x4c\xec\x65\x76\xaa\xe9\x48\x67\xbd\xfb\x16\x88\xa9\xf0\xf3\xd2\x80\x6f\x8f\x84\x33\xd1\xe1\xd1\x76\x75\x58\x8d\x2f\xab\xf2\x72\xc9\x1b\x50\x1e\x3f\xb6\x01\xe5\x58\xfa\x4e\x94\xd8\x74\x38\x4a\x44\x8d\xc6\x6c\x67\xda\x16\x07\x45\x7b\x7b\xfe\x80\x79\xf7\xee\xe4\x6d\x92\x97\x2d\x85\xf2\xb6\x93\x06\x7e\xfe\xa5\x2a\x5e\xd1\x3e\xee\x70\x24\xe1\x5f\x03\x00\x38\xcf\x80\x10\xe0\x0a\x00\x00"),
+		},
+		"/devops.gostship.io_hosts.yaml": &vfsgen۰CompressedFileInfo{
+			name:             "devops.gostship.io_hosts.yaml",
+			modTime:          time.Date(2026, 10, 18, 8, 7, 56, 875061004, time.UTC),
+			uncompressedSize: 12270,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xdc\x5a\x6d\x73\xdb\x36\xf2\x7f\xaf\x4f\xb1\x93\xfe\x67\x6c\xff\x6b\x92\x49\x7a\x9d\xde\xe9\x4d\xc7\xe7\xe4\x1a\x35\x75\xe2\xb1\x92\xce\xdc\xb8\xb9\xcc\x8a\x58\x89\xa8\x49\x80\x05\x40\xdb\x6a\xd3\xef\x7e\xb3\x00\xa9\x47\x52\xa2\x5c\xa7\x77\x73\x7e\x63\x11\x04\xf6\x09\xfb\x5b\xec\x2e\x38\x88\xa2\x68\x80\xa5\xfc\x91\x8c\x95\x5a\x0d\x01\x4b\x49\xf7\x8e\x14\x3f\xd9\xf8\xe6\xaf\x36\x96\x3a\xb9\x7d\x36\x21\x87\xcf\x06\x37\x52\x89\x21\x9c\x57\xd6\xe9\xe2\x8a\xac\xae\x4c\x4a\x2f\x68\x2a\x95\x74\x52\xab\x41\x41\x0e\x05\x3a\x1c\x0e\x00\x50\x29\xed\x90\x87\x2d\x3f\x02\xa4\x5a\x39\xa3\xf3\x9c\x4c\x34\x23\x15\xdf\x54\x13\x9a\x54\x32\x17\x64\x3c\x87\x86\xff\xed\xd3\xf8\xab\xf8\xe9\x00\x20\x35\xe4\x97\xbf\x93\x05\x59\x87\x45\x39\x04\x55\xe5\xf9\x00\x40\x61\x41\x43\xc8\xb4\x75\x36\x16\x74\xab\x4b\x1b\xcf\xf8\x21\x93\x65\x2c\xf5\xc0\x96\x94\x7a\x09\x84\xf0\x62\x61\x7e\x69\xa4\x72\x64\xce\x75\x5e\x15\x41\x9c\x08\xbe\x1f\xbf\x7d\x73\x89\x2e\x1b\x42\xcc\x0b\x62\x59\x0e\x00\x00\x04\xd9\xd4\xc8\xd2\x79\x59\xde\x65\x04\xd6\x66\x4c\xc9\x90\xb5\xa0\xa7\xe0\x32\xf2\x9c\xe3\x01\x40\x23\xc9\xe8\xd2\x3f\xb8\x79\x49\x43\xb0\xce\x48\x35\x6b\x65\x61\x30\xbd\x69\x67\xc2\x6f\xba\xa8\x5f\x9d\x9d\xbf\xde\x4f\xdf\xa1\xab\x6c\x5c\x66\x68\xa9\x9d\x05\x93\x05\xff\x7e\x95\xf8\xe5\xab\xb3\xf1\xcb\xbe\xd4\xd3\xb2\x6a\xa7\x9d\xea\x4a\x39\x96\x3f\xd7\x33\x99\x62\x0e\x69\x59\xd9\x55\x36\xe7\x97\xef\x57\x98\xf0\x66\xcc\xc8\x74\x70\x29\xa8\xd0\x66\xde\xce\x28\xbc\xeb\xb2\xd4\xc5\xcb\x8b\xb7\x57\xff\xec\xad\x4d\x8e\xb2\xb8\xa2\x69\xcc\x8b\x3b\xf4\xca\x2b\xeb\xc8\x80\x36\x50\x60\x9a\x49\xc5\x43\x28\x0b\xa9\x66\xad\x02\x9c\xff\x70\x36\xba\xd8\xcb\xbf\x41\x49\xbc\xe5\xe1\xdb\x52\x1c\x9d\x6f\xce\x01\x69\x01\xc1\x2d\x1e\x0d\x95\x86\x2c\x29\xd7\x08\x65\xc9\xdc\x92\xf1\x33\xe0\x2e\x23\x35\x00\x00\x00\x70\x99\xb4\xa0\x27\x3f\x53\xea\xe0\x0e\x6d\x80\x17\x89\x18\x8e\x56\x14\x38\xfb\x6e\xd5\x19\x04\x3a\x1a\x00\xcc\x8c\xae\xca\x21\xb4\x20\x2d\x2c\xab\xf1\x1d\x62\xc3\x2b\x6d\x9d\x7f\xcc\xa5\x75\xaf\x17\x43\x3f\xc8\x7a\xb8\xcc\x2b\x83\x79\x8d\xde\x01\x00\x80\x95\x6a\x56\xe5\x68\xc2\xd8\x00\xc0\xa6\x9a\xb9\x9f\x07\xe3\xf3\x40\x35\x31\x75\xb0\xa9\x79\x85\x2d\x1c\xc2\x6f\xbf\x0f\x00\x6e\x31\x97\xc2\x1b\x29\xbc\xd4\x25\xa9\xb3\xcb\xd1\x8f\x5f\x8d\xd3\x8c\x0a\x0c\x83\x1b\x76\x65\x99\x40\x5a\x6f\xb0\x30\x0d\xa6\xda\xf8\x47\xff\xea\xec\x72\x74\x0a\xd8\xd8\xb2\xf6\x37\xa9\x6e\x49\xb9\xc6\x39\x01\x20\x78\x03\x09\x98\xcc\xc1\x2d\xfd\xc5\x02\x2a\xd1\x78\x8c\xe5\x97\x96\x72\x4a\x9d\x36\x71\xbd\xb2\x34\xba\x24\xe3\x64\xa3\x0f\x00\xc0\x4a\x10\x5e\x8c\x6d\x7a\x03\xab\x15\xe6\x80\xe0\xb0\x4b\x41\x83\x3a\x78\x92\x00\x1b\x74\xf1\x02\x4b\xbb\x74\x0e\x6f\x9e\x15\xb2\xc0\x53\x50\xd5\x0e\x11\xc3\xd8\x2b\x6a\xc1\x66\xba\xca\x05\xc7\xea\x5b\x32\x0e\x0c\xa5\x7a\xa6\xe4\xaf\x0b\xca\x16\x9c\xf6\x2c\x73\x74\x54\x6f\x69\xf3\xe7\x63\xac\xc2\x9c\x37\xa4\xa2\xd3\xda\x08\x73\x30\xc4\x3c\xa0\x52\x2b\xd4\xfc\x14\x1b\xc3\x85\x36\x6c\xd7\xa9\x1e\x42\xe6\x5c\x69\x87\x49\x32\x93\xae\x39\x76\x52\x5d\x14\x95\x92\x6e\x9e\xf8\xc3\x43\x4e\x2a\xa7\x8d\x4d\x04\xdd\x52\x9e\x58\x39\x8b\xd0\xa4\x99\x74\x94\xba\xca\x50\x82\xa5\x8c\xbc\xe0\xca\x9f\x3a\x71\x21\xbe\x58\xb8\xcd\xd1\x8a\xa4\x1b\xd8\x04\x58\x78\x6f\xa7\xdd\xd9\x91\x03\xf0\xc2\xb2\x20\xff\x36\xf6\xae\x5e\x8e\xdf\x41\xc3\xd4\x6f\xc1\xba\xcd\xbd\xb5\x97\xcb\xec\xd2\xf0\x6c\x28\xa9\xa6\x64\xfc\x2a\x98\x1a\x5d\x78\x8a\xa4\x44\xa9\xa5\x72\xb5\x7f\x49\x52\xeb\x46\xb7\xd5\xa4\x90\x8e\x77\xfa\x97\x8a\xac\xe3\xfd\x89\xe1\xdc\x1f\xbe\x30\x21\xa8\x4a\x11\x50\x3e\x52\x70\x8e\x05\xe5\xe7\x7c\x00\x7c\x6e\xb3\xb3\x85\x6d\xc4\x26\xdd\x6f\xf8\xd5\x9c\x61\x7d\x62\xb0\xd6\x62\xb8\x39\xd6\x5b\x77\x88\x41\x3b\x2e\x29\x5d\x83\x05\x1f\xdd\x0b\xfb\xe9\xe9\x12\xcf\x52\xad\xe3\x39\x5e\x21\xdb\x06\x4d\x00\x80\xd4\x90\x60\x15\x31\xb7\x57\x34\x5d\x7f\xb7\x21\xcc\xf9\xda\xd4\x26\xcc\x58\x4a\x0d\xb9\x26\x96\xb0\x6c\x4b\x92\xf5\xe8\x06\x51\xf0\x11\xf1\x14\xa4\x83\xa2\xb2\x7e\x3f\x6b\xc9\x6d\x86\x86\xc4\xaa\x4c\x21\x12\x97\x98\x52\xc3\x61\x99\x6f\xc5\x1b\x74\xbb\x54\x5c\x9c\x03\x5b\xa3\x1d\x7b\xb7\xba\xc8\xf3\x3e\x70\x25\x3b\xad\x34\x24\x36\x97\x45\xb0\x38\x93\x37\x07\x3d\x9b\x41\x1b\x87\x0d\x77\x01\x00\x90\xe5\x70\xd0\x53\x98\x9f\xab\xa2\x64\x27\xda\x32\x89\x74\x54\x6c\x0d\x6e\x6c\xf8\xf7\xf5\xe2\x10\x22\x26\x68\x79\x18\xb4\x5a\xec\x74\x89\x2e\x03\xa7\x01\x9b\x53\x61\x8b\xde\xae\x3d\xd9\xe7\x7c\xfb\x5d\xd0\x43\xc0\x02\x3a\xc0\x15\x3f\xc4\x0d\x2f\x3c\x6d\xa5\x0b\x70\x27\x59\xfa\x8c\xe0\x86\xe6\x16\x2a\xcb\x51\xbe\xa0\x53\x28\xd1\xda\x3b\x6d\xc4\x29\x94\x46\xde\xa2\xa3\xd7\x34\xf7\x51\x9f\x5f\x5c\x66\x06\x2d\x75\x91\xc4\x3c\x07\x5d\x86\xec\x3c\x0e\x79\x76\x10\x8b\xe3\xe0\xa4\x71\xf0\x53\xa0\x78\x16\x37\xa7\x6b\x73\xa0\x76\x90\xf4\x0a\x71\x22\x1d\xc3\xc8\xef\x44\x8d\x95\x2d\x60\xe8\x3b\x15\x72\xba\x25\x92\x3a\x48\xf6\xc4\xd7\x69\x20\xeb\x32\x7f\xf4\x1b\xaa\x4f\x3c\x12\x71\x2b\xdd\xdd\x5b\xbd\x0b\x84\x3d\xa0\xd8\x03\x90\x3d\xa9\x74\x81\x73\x07\x44\xf7\x01\x75\x0f\x5c\xbb\x40\xdb\x4b\xe0\xa5\xcf\xb5\x2f\x9f\x6a\x53\xa0\x1b\xc2\x64\xee\xe8\xa1\xf4\xd9\xd9\x1f\x26\x9c\x36\x6e\xb7\x58\x52\xb9\xaf\x9e\xef\x20\xbd\xac\x99\xb6\xdd\xa9\xc1\xde\xe7\x51\xbc\xc1\xfb\x03\x14\xef\x76\xa1\x08\xea\x7a\x7b\x7d\x90\xed\x34\x38\xc0\x5f\xc2\x2b\x34\x06\xe7\x6b\x6f\x0a\x64\x7b\x29\x54\xdb\x08\x58\x0b\x93\x17\xcb\x79\xe0\xf0\xa6\xce\x1b\xf8\xd4\x05\x5d\xb9\xad\xbc\x1f\x34\x4f\x94\xdb\x22\x4a\x0b\x4a\xbb\xa6\x1c\x88\x5b\xa5\x9c\x68\x9d\x13\xae\x27\xe1\x6d\x7e\xb1\xcb\x23\xba\x7d\x81\x03\xdf\x4e\x55\xaf\xb8\xc5\x50\xa7\x22\x3c\x79\xa9\xa9\x0f\x95\x71\xdf\x83\xb2\xcb\x1d\x3a\x16\xb4\xb9\x40\xb4\x71\x9e\x0d\x3a\xfd\x62\xcb\x23\xda\x33\xc3\x50\x0d\xee\xcc\x0d\xfd\x94\x46\xff\x29\xa6\xce\x82\x90\x36\xd5\xb7\x64\x48\x34\x47\xb5\x37\x07\x1f\x60\xd2\xd9\xb0\x99\xbd\x32\xc3\xba\x91\xb0\x3b\x27\xac\x27\x35\x22\xf4\x6f\x2d\xec\x17\x00\x3a\xab\xc7\x56\x51\xce\x2e\x47\x4d\xc5\xd8\x78\xb8\xa1\x29\x19\x52\x2e\x6e\x59\xbb\x13\xe1\x53\x49\xb9\xf0\x7d\x8d\x7d\x5c\x8f\x46\xd3\xc0\xc6\x78\x15\x35\x20\x94\x92\x52\x5a\x2b\x44\x41\x2a\xeb\x08\x45\x18\x6c\x21\x09\xc0\x6e\x63\xa8\x9e\x7f\x1a\xaa\xa5\x20\xdc\x4a\xf1\xea\x50\x2a\xc0\xd0\x19\xf0\xad\x97\xe4\x3b\x1d\x64\x6d\xa5\x89\x69\x4a\xd6\x7a\x3f\xa2\x82\x94\x3b\x05\x5b\xa5\x19\xa0\x65\x15\xd8\x7b\xd9\x7d\x28\x2e\x50\xc9\x29\x59\x17\xd7\x1c\xc8\xd8\xeb\xe7\x1f\xe2\x56\x92\xff\xd0\x06\xe8\x1e\x8b\x32\xa7\x53\x90\x75\xe2\xd1\x94\x7f\xde\xd8\x29\x79\x5f\xd0\x80\xb0\xa0\xe7\x53\x2d\xd9\xae\x38\x42\xa9\x45\xad\xf0\x9d\x57\x94\x23\x16\xe8\x5a\xd1\x8a\x20\x97\x37\x34\x84\x27\xbe\xd7\xb8\x14\xf1\x37\x46\xeb\xef\x4f\x5a\x69\x1e\xdf\x65\x64\x08\x9e\xf0\x94\x27\x41\xb0\x45\x85\xcf\x63\xab\xb9\x8e\xa7\x06\x2e\x43\x07\xce\xc8\xd9\x8c\xba\xf2\x26\x5e\x40\x1c\x31\x4f\x40\x1b\xd6\x5d\xe9\x15\x02\x9e\x2c\xef\x59\x49\xa9\x9c\x4a\x12\x5b\x02\x5f\x3f\xff\xd0\x21\xed\xba\x9d\x40\x2a\x41\xf7\xf0\x3c\x24\x7a\xd2\xb2\x7d\x4e\x38\x99\x64\xea\x73\xe5\xf0\x1e\xa4\x85\x34\xd3\x96\x14\x68\x95\xcf\xdb\xa5\xd5\x90\xe1\x2d\x81\xd5\xdc\x2f\xa3\x3c\x8f\x42\x09\x29\xe0\x0e\x7d\xa7\xb1\xd9\x2e\xf6\x30\x84\x12\x8d\x5b\xef\x9d\x1c\x1d\x8a\x99\xcd\x56\xc3\x8e\x96\xc3\x26\x3c\xff\x43\x85\x7b\x2f\xb5\xba\x72\x84\x75\xb5\xde\xac\x78\xd5\x4e\xb5\xf8\x7e\xc0\x28\x72\xe4\x35\x13\x3a\xb5\xac\x54\x4a\xa5\xb3\x09\x47\xed\x5b\x49\x77\xc9\x9d\x36\x37\x52\xcd\x22\x76\x87\x28\xec\x87\x4d\x7c\xf6\x99\x7c\xe1\xff\x1d\x3d\x6a\x29\xbb\xad\xca\x5a\x45\xf0\x39\xf5\x61\x3e\x36\x39\x58\x9d\xa6\x17\xd5\xf7\x6c\x38\x1a\x07\x58\xa6\x9b\x2b\xc1\x69\xb8\xcb\x64\x9a\x35\x8d\xc5\x65\x0c\x6b\xa1\x09\x50\xa0\x08\x81\x0f\xd5\xfc\xb3\xbb\x2d\x1b\xb2\x32\x2c\xcf\x3c\xaa\xcb\xb2\x08\x95\xe0\xdf\x56\x5a\xc7\xe3\x07\x5b\xae\x92\x3d\x40\xfa\x7e\xf4\xe2\xcf\x71\xe6\x4a\x1e\x8c\xc8\xce\xb4\x39\x2d\xab\xc7\xc9\x39\x85\xb4\x37\x0f\xea\x97\x70\x46\xf6\x42\xda\x9b\xba\x5f\x92\xeb\xf4\x86\xaf\x17\xe4\x12\x4a\x6d\xe9\xcf\xbe\xca\xb9\xd0\x82\xf2\x07\x95\x67\x0f\x2c\x6f\x00\x4c\x7d\xc5\x89\x79\x8f\x9e\xcc\xd5\x62\x32\x14\x84\xca\x02\x82\x2d\xa5\x52\x7c\xb0\xb0\x29\xe3\x1d\x12\xb4\x15\x0f\x00\x00\x00\x56\xfe\xda\x21\x3b\xaa\xf9\xdb\x69\x57\xf5\xbe\xaf\xaa\x5c\xce\xe9\x54\x1e\xa0\x44\xc7\x2d\xff\x21\xfc\xeb\xf8\xa7\x2f\x3f\x45\x27\xdf\x1e\x1f\x5f\x3f\x8d\xfe\xf6\xe1\xcb\xe3\x9f\x62\xff\xe3\xff\x4f\xbe\x3d\xf9\xd4\x3c\x7c\x79\x72\x72\x7c\x7c\xfd\xfa\xe2\xbb\x77\x97\x2f\x3f\xc8\x93\x4f\xd7\xaa\x2a\x6e\xc2\xd3\xa7\xe3\x6b\x7a\xf9\xa1\x27\x91\x93\x93\x6f\xff\xaf\x55\x9c\xfb\x68\x89\xb3\x48\x2a\x17\x69\x13\x05\xe9\x87\xe0\x4c\x45\x07\xd5\xa7\xad\xed\x8d\xc8\x9b\xfb\x31\x2a\xd4\x1c\x3d\x04\x7c\x01\x32\xe7\xcb\xbd\x2e\x48\x72\xff\x3e\xe2\xcb\xbc\xbe\x05\x5a\xb8\x23\x1d\x0e\x7a\x38\xc3\x6e\x37\xd8\xe9\x00\xff\x35\x5b\x7f\xd0\xa6\x17\x64\x2d\xce\x76\xf7\x04\xce\x20\xab\x0a\x54\x60\x08\x05\x4e\x72\x6a\x16\x81\x54\x42\xa6\xe8\xef\x7a\x04\x39\x94\xb9\x05\x9c\xe8\xca\xc1\x5d\x36\xef\xec\xde\x2f\x1a\x91\xb2\xae\x2f\x7a\x17\xda\x4a\xa6\x0f\x0e\xae\x6f\x46\xe7\x21\xb6\x96\xd9\xdc\xfa\x1b\x79\x45\x8e\xcf\x97\x70\x49\x37\xc5\x3f\x16\x69\x65\xd9\x3a\xdc\x29\x5f\xaf\x58\xda\x8d\x95\x26\xa3\x48\xff\xcc\xe0\xbe\x2b\x36\x14\x98\xf6\x8d\x18\x0f\x08\x0d\xfe\x23\x8d\xe1\x60\xcf\x16\x5f\xf2\xac\xc5\xdd\x12\x7b\x96\x2f\x4b\x6a\xa7\xdb\x71\xc1\xb5\x53\x73\x43\x68\xb5\xda\xc9\xfc\x0c\x26\x46\xd2\x74\x79\x9d\xd8\x0b\x20\xdb\x86\xc9\xe8\x0f\x02\xc4\x68\xed\xb3\x88\xb3\x5b\x94\xf9\xe7\x8e\x77\x1b\x27\xf9\x0a\xe7\xc5\x1e\xf8\x52\x00\x79\xc8\x87\x8d\xba\xa7\xc4\x52\xc2\x54\xe6\x64\xe7\xd6\x51\x11\xff\x4f\xc4\xd1\xa0\xcb\x88\x73\xdc\xdd\xed\x55\xdf\xd9\x1a\x2f\x66\xd7\x77\xe8\xe1\xfa\x49\x0a\x9b\x54\x9c\xd8\x82\xd3\x50\x29\xf9\x4b\x45\xf9\x1c\xa4\x6f\x0c\x4e\xdb\x1d\x46\x69\x41\x07\x75\xc5\x56\x0a\x87\xbd\xd9\x3c\xdf\x42\x9d\xad\x2c\x00\x43\xdc\x7d\x5c\x7e\xde\xc1\xdc\x0f\x2d\x23\x26\x5a\xbb\xd1\x8b\xbd\xbc\xff\xce\x5e\x32\x7a\xd1\xca\xf2\xe0\xa6\xdc\xa2\x4d\x72\x55\x29\xce\x1c\xfa\x16\x7f\xe7\x1b\xeb\xa0\x5e\xf8\x38\x52\xdd\xb0\x73\xe5\x7d\x65\x79\xed\x67\x3f\xb2\x04\xd5\x84\x2e\x8d\xbe\x9f\xf7\x16\xa2\x59\xf0\xf8\x72\xe4\xe4\x0e\x91\x22\x27\xf7\xb8\x32\xd4\x4d\xe7\x1e\xae\x79\x74\xd1\x4c\x6d\xe7\xec\x5b\x9d\x01\xbe\x9d\xb7\xdb\x00\xd0\x00\xdb\x9f\x10\x5a\x35\x47\x54\xd3\x07\x0f\x5f\xbd\x70\x87\x16\xa4\x85\x32\x74\x8a\x49\xc4\xf0\x03\xa1\x51\x50\x68\xd3\x4e\xd5\x7f\x28\x53\xa0\x3a\xfe\xfa\xa4\xe1\x1e\x49\x11\x2a\xef\x61\x92\x14\xa8\xbe\x89\xb5\x99\x25\xb9\x54\xd5\x3d\x3f\x46\x25\xce\xc8\xf2\xaf\xaf\x93\xe5\x82\xf8\xeb\x38\x73\x45\x7e\x70\x97\x80\x43\x8f\x3f\xf1\x42\x8c\xeb\x15\x63\xde\x36\x6b\x20\x2c\x7a\x94\x38\xa3\x6d\x8f\xad\x7c\x3b\x1e\xbd\x68\x8e\x2b\x21\xad\x33\x1a\xa4\x6f\x32\x26\xe4\xd2\x44\xdb\xc8\x50\x4e\x7c\x6b\x1f\xee\xdd\x53\x4e\x1e\xda\xdb\x3b\xbc\xe5\x93\x4a\xb9\x2a\x7e\x80\xa0\x45\x4b\x0a\xde\x22\x2b\xf8\x89\x8f\xe3\xee\xda\xf6\x45\xdb\xdb\x71\x3d\x73\xc3\x50\xcd\x8d\x49\xbb\xc1\x5a\xa8\x42\x30\xe2\x37\xa0\x0d\x3c\x7f\x1a\x3f\xfd\xcb\xc1\x42\x87\x53\xf6\xfd\xfb\x1e\x3b\x3b\x5e\x4c\x7d\x54\x90\x2e\xa1\xbf\x0e\xca\x77\x6b\x68\xad\x5b\xf9\x69\x57\x7f\xfd\x8a\x04\xbc\x42\x17\xbe\xf9\x5c\xf4\xc4\xc2\xa5\x4b\x6c\x48\x64\xc8\x57\x2a\x05\xf7\xc5\xaa\xa2\xf9\x58\x31\x21\x15\xbd\x1f\x27\x57\x24\x3e\xbe\x42\xf7\x71\x5c\x4d\x16\xea\x7e\xbc\x40\x85\x33\x7f\x53\x93\x3c\x4b\x18\xb7\xc9\xd5\xab\xf1\x45\x32\x23\xc7\xb0\x8a\x82\xdd\x22\xce\x2d\x3c\xaa\x1f\xa5\x6f\xd6\x32\xbc\x31\xb4\xfc\x84\xfd\xd9\xf2\xa9\xfe\xda\xdc\x17\x21\xe1\x05\x84\xaf\xd0\xc4\x4a\x5a\x65\x9d\x36\x8c\x89\x30\xb2\xbc\xce\x64\x13\x95\x8e\xc4\x9b\xcd\xaf\x6c\x9f\x3c\x59\xfb\x98\xd6\x3f\xa6\x5a\x85\xef\xdd\xed\x10\xae\x3f\x0c\x02\x55\x12\x3f\x36\x72\xf0\xe0\xbf\x07\x00\xf5\xe6\xec\x13\xee\x2f\x00\x00"),
+		},
+		"/devops.gostship.io_ipclaims.yaml": &vfsgen۰CompressedFileInfo{
+			name:             "devops.gostship.io_ipclaims.yaml",
+			modTime:          time.Date(2026, 10, 18, 6, 35, 1, 593209210, time.UTC),
+			uncompressedSize: 2642,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x3c\xcd\x73\xdb\xb6\x97\x77\xff\x15\x6f\xd2\x9d\x89\xbd\xb5\xe4\x76\x7b\xe9\x7a\x0f\x19\xaf\xed\x74\x3d\x49\x1c\x4d\xec\xe6\x92\x66\x67\x20\xe2\x49\xc4\x0a\x04\x18\x00\x94\xac\x4e\xfe\xf8\x1d\x7c\x49\xa2\x04\x50\x94\x1d\x27\xbf\x43\x79\x49\x04\x02\x0f\xef\xfb\x0b\xa0\x8f\x06\x83\xc1\x11\xa9\xd9\x47\x54\x9a\x49\x71\x0e\xa4\x66\xf8\x60\x50\xd8\x5f\x7a\x38\xfb\x5d\x0f\x99\x3c\x9b\xff\x3a\x46\x43\x7e\x3d\x9a\x31\x41\xcf\xe1\xb2\xd1\x46\x56\x1f\x50\xcb\x46\x15\x78\x85\x13\x26\x98\x61\x52\x1c\x55\x68\x08\x25\x86\x9c\x1f\x01\x10\x21\xa4\x21\x76\x58\xdb\x9f\x00\x85\x14\x46\x49\xce\x51\x0d\xa6\x28\x86\xb3\x66\x8c\xe3\x86\x71\x8a\xca\xed\x10\xf7\x9f\xff\x32\xfc\x6d\xf8\xcb\x11\x40\xa1\xd0\x2d\xbf\x67\x15\x6a\x43\xaa\xfa\x1c\x44\xc3\xf9\x11\x80\x20\x15\x9e\x43\xc1\x1b\x6d\x50\xe9\x21\xc5\xb9\xac\xf5\x70\x2a\xb5\xd1\x25\xab\x87\x4c\x1e\xe9\x1a\x0b\xbb\xeb\x54\xc9\xa6\x3e\x87\xc4\x0c\x0f\x25\xa0\x16\xc8\xf2\x00\xdd\x08\x67\xda\xbc\xd9\x1c\x7d\xcb\xb4\x71\x6f\x6a\xde\x28\xc2\xd7\xdb\xbb\x41\xcd\xc4\xb4\xe1\x44\xad\x86\x8f\x00\x74\x21\x6b\x3c\x87\x5b\xbb\x4d\x4d\x0a\xa4\x47\x00\x73\xc2\x19\x75\x54\xf9\x8d\x65\x8d\xe2\x62\x74\xf3\xf1\xb7\xbb\xa2\xc4\x8a\xf8\x41\x00\x8a\xba\x50\xac\x76\xf3\x22\x02\xc0\x34\x98\x12\xc1\xcf\x84\x89\x54\xee\x67\x7c\x7b\x31\xba\x09\x8b\x6b\x25\x6b\x54\x86\x45\xe2\xec\xb3\x21\xe0\xd5\xd8\xd6\x36\x2f\x2d\x1e\x7e\x0e\x50\x2b\x52\xf4\xfb\x05\xc1\x20\x05\xed\x77\x96\x13\x30\x25\xd3\xa0\xb0\x56\xa8\x51\x78\x21\x6f\x80\x05\x3b\x85\x08\x90\xe3\xff\xc3\xc2\x0c\xe1\x0e\x95\x05\x02\xba\x94\x0d\xa7\x56\x0f\xe6\xa8\x0c\x28\x2c\xe4\x54\xb0\xbf\x57\x90\x35\x18\xe9\xb6\xe4\xc4\x60\xe0\x76\x7c\x98\x30\xa8\x04\xe1\x96\x83\x0d\x9e\x02\x11\x14\x2a\xb2\x04\x85\x76\x0f\x68\xc4\x06\x34\x37\x45\x0f\xe1\x9d\x54\x08\x4c\x4c\xe4\x39\x94\xc6\xd4\xfa\xfc\xec\x6c\xca\x4c\x54\xe9\x42\x56\x55\x23\x98\x59\x9e\x39\xc5\x64\xe3\xc6\x48\xa5\xcf\x28\xce\x91\x9f\x69\x36\x1d\x10\x55\x94\xcc\x60\x61\x1a\x85\x67\xa4\x66\x03\x87\xb8\x70\x1a\x3d\xac\xe8\x4f\x2a\xe8\xbf\x7e\xb9\x81\xa9\x59\x5a\x99\x6b\xa3\x98\x98\xae\x86\x9d\x7a\x65\xf9\x6e\xd5\xcc\x0a\x97\x84\x65\x1e\xff\x35\x7b\xed\x90\xe5\xca\x87\xeb\xbb\x7b\x88\x9b\x3a\x11\xb4\x79\xee\xb8\xbd\x5e\xa6\xd7\x8c\xb7\x8c\x62\x62\x82\xca\x0b\x6e\xa2\x64\xe5\x20\xa2\xa0\xb5\x64\xc2\xb8\x1f\x05\x67\x28\xda\x4c\xd7\xcd\xb8\x62\xc6\x4a\xfa\x4b\x83\xda\x58\xf9\x0c\xe1\xd2\x19\x36\x8c\x11\x9a\x9a\x12\x83\x74\x08\x37\x02\x2e\x49\x85\xfc\x92\x68\x7c\x76\xb6\x5b\x0e\xeb\x81\x65\xe9\x7e\xc6\x6f\xfa\xa3\xf6\x44\xcf\xad\xd5\x70\xf4\x17\x49\x09\x05\x13\xbb\xab\xb1\x68\x59\x06\x45\xcd\x94\xd5\x5e\x43\x0c\x5a\x9d\xdf\x74\x20\x79\x5b\x0c\xf6\xe8\x85\x73\xfd\x60\x14\xb9\x50\xd3\xad\xf7\x00\x84\x52\xe7\x53\x09\x1f\x65\x60\x64\xa9\xee\xa0\xd3\x3e\xc1\x43\x5d\xde\x5c\x7d\xd8\x06\x98\x05\x17\xd7\x28\xa4\x56\x14\x84\x7f\xc0\xc9\xf6\xe2\x14\xcf\x5a\x0b\x9c\xcf\x62\x5a\x5a\xf3\x06\x6d\x23\x8c\x61\x73\xaf\x2a\xaa\x72\x4e\x64\xb8\x43\xe0\xcd\x04\xac\xb6\x59\xf9\xb0\x09\x43\x7a\x1a\x51\xd9\x08\x27\xb0\x60\x9c\xfb\x70\x81\x20\x05\xfe\x97\x5d\xb5\x5e\xb1\x03\xb3\x56\x72\xce\x28\x2a\xa8\x1a\x6d\xa0\x22\x33\x04\xdd\x58\x9d\xd5\xde\x3f\x6f\x63\x91\x13\xa2\x7d\x5c\x28\xda\x19\xdd\x36\x71\x1b\x03\xbc\xdf\xb4\x76\x3d\x41\x85\xc2\x24\x0d\xc5\x06\x45\x25\xd0\xa0\xb3\x15\x2a\x0b\x6d\xcd\xa4\xc0\xda\xe8\x33\x39\x47\x35\x67\xb8\x38\x5b\x48\x35\x63\x62\x3a\x58\x30\x53\x0e\xbc\x80\xf5\x99\x8b\x66\x67\x3f\xb9\x7f\x12\xf8\x00\xdc\xbf\xbf\x7a\x7f\x0e\x17\x94\x82\x34\x25\x2a\x68\x34\x4e\x1a\x0e\x13\x86\x9c\xea\xe1\x46\x84\x38\x75\xfe\xea\x14\x1a\x46\x5f\xbd\x4c\x80\x7a\x94\xce\xad\x84\xf5\x8e\x08\x32\xfd\x21\x7a\x4f\x99\xae\x39\x59\xde\x26\x24\x96\x05\x47\x85\xbe\x92\x15\x61\xa2\x53\xd9\xaf\x6e\xef\xfc\xac\x18\xa3\xa9\xd0\x40\xfd\x48\xa3\x91\xc2\x78\x09\xb3\xdf\x35\x68\x2b\xc0\xc2\x86\xa6\x2b\x9c\x90\x86\x9b\x5d\x49\x19\x09\x2f\x82\x86\x0f\xb9\x2c\x08\x7f\xb1\xad\x8d\x79\x5c\x65\x31\xfb\x21\x8c\x45\x53\xd0\x4e\xfe\x5c\x9b\x82\x42\x29\x39\xd5\x56\x11\x26\x6c\xda\x28\x67\xee\xce\x21\xd8\xd5\x87\x58\x9c\x4d\x4e\x6d\x26\xb0\xd7\xea\xae\xc3\xc4\x30\x3a\x46\x0d\xa5\x5c\x58\x0e\x17\x52\x08\x1b\x2b\x8d\xb4\x69\x4a\x04\xe8\x30\x49\x9a\x4e\x74\x39\x6f\xad\x40\x5c\xea\xb1\x82\x4d\x14\x42\xd5\x98\x86\x70\xbe\x04\x7c\xb0\x33\xd9\x1c\x13\x50\xba\x48\x72\x7b\x90\xd7\x8c\x27\x5d\xc9\x0e\x61\x97\x17\x76\xaa\x4b\x19\x04\xdc\xdd\xbd\x85\x4b\x0b\x78\xc2\x0a\xeb\xff\x2e\x1a\x53\x4a\xc5\xcc\x12\x26\x76\x92\x55\xbf\x0c\x4c\xa7\x6c\x1a\x0b\xeb\xfa\x2c\xe9\x10\x42\x73\xe1\x5d\x31\x7c\xc0\x2f\x8d\x8b\x6f\x6c\x02\x8d\x4d\x70\x81\xc0\xfd\xdb\xbb\xc8\xbd\xa4\xbb\x0e\x70\xf3\x4a\x14\x88\x45\x65\xfa\x93\x1b\x26\x6f\x10\x5c\xac\x08\x76\x5a\x14\x09\x5d\x13\x94\x25\xf9\x3b\x13\x1a\x33\xac\x8c\xd0\xb7\x35\x36\xce\xb6\xb1\xc2\x61\x5a\x61\x35\xb6\x25\xce\x1a\x47\x6b\x32\x51\xfb\xae\x13\xa6\x13\x1f\x66\xb0\xca\xee\xda\x03\xf3\x38\x85\x28\x45\x96\xc9\x19\x33\x5c\xf6\x96\xe1\x1b\x3f\x77\x43\x84\x33\x5c\xa6\x04\x97\x37\x42\x70\x61\xe4\xfb\x08\x4e\x05\xc0\x29\xda\x06\xc1\x54\xd3\xaf\x82\xae\x26\x5f\xae\x94\x21\xf9\x36\xb0\x33\x1b\x70\x13\x8e\xd7\x3e\x2e\x48\xec\xf5\x85\xde\x73\x85\xc4\x67\xdb\x0b\xcf\x84\x1c\x6b\xa7\x58\x71\x3c\xa7\x14\xae\x38\x73\xa0\x9c\x76\x32\xa1\x0d\x11\x05\x3e\xab\x63\xb4\xf9\xfb\x15\x53\xbd\xd4\xec\xca\xcf\x5d\x85\x61\xa6\xb0\x30\x52\x2d\x3d\xba\x2e\x4d\xac\x39\x29\x10\x6c\x55\x63\x01\xe7\xf4\x03\x56\x11\xda\x45\xe4\xb3\x39\x51\x67\x9c\x8d\xcf\x2c\x9c\x9d\x88\xbc\xe2\xce\x5e\x6f\x90\x8b\xcd\xf1\xe9\x17\xa3\x7b\xef\xb7\x1b\x10\xfd\xf6\x4e\x38\x0e\x19\x20\x6a\xda\x54\xb6\x5a\x8c\xca\x41\x63\x11\xde\x69\x88\x63\x26\x88\x5a\xc2\xa2\x44\x01\xaa\x11\x56\x13\x18\x45\x57\xc2\x12\xc3\x0a\xa8\x65\xd6\x31\x75\x6a\xb3\x7d\x6a\x44\x65\x7d\xfe\xdd\xc5\x6d\x3f\xb7\x39\xda\x58\x00\x1a\x8d\x0e\xb4\xdd\x35\xbe\x24\xbe\xe0\x4e\x27\x5d\xb1\x71\x9b\xcd\x8f\xed\x13\x9b\x2a\x4e\x5d\x2c\x1e\xa0\xd9\x54\x58\xc7\x62\x0d\xfb\xc7\xb9\x5a\xed\x6a\xc5\x83\x98\x72\xd7\x5a\xf2\x0d\xd9\xe2\x71\xf9\x97\x60\x4c\xb7\x9b\x0e\x8e\xe3\x30\x87\x9a\x7d\x35\x41\x62\x1a\xb5\x6b\x8e\xa9\xaa\xf7\xb5\x9f\xeb\x3a\x5c\x8a\x7a\x5f\x14\xd7\x83\x29\x89\xf1\x06\x28\xc8\x98\xbb\xe2\x60\x37\x0b\x77\xfd\x18\x5f\x05\x1c\x90\x1a\x17\x9a\xbd\xaf\x51\x11\x23\x93\xee\x72\x9f\xa7\x9d\xef\x76\x08\x77\x59\xf3\xc8\x98\x19\x60\x1f\x1a\xdd\x3c\x8f\xde\x11\xd7\x7f\x29\x4a\xa4\x4d\x3a\xe1\xf0\x40\xc6\x52\x72\x24\xbb\x9b\xd8\x3c\x23\x49\x73\x87\x82\xee\x63\x16\x00\xd5\xe6\x49\xba\xad\x55\xf1\x84\xf5\x5d\xec\x76\xda\xaf\xd3\xde\x75\x60\xf7\x4d\x07\xf7\x6e\xc7\xdc\x65\x8a\xd3\xba\xb9\xb7\xaf\xf7\xa5\x21\x7f\x8c\xfe\xb4\xf3\x5a\x5d\xb4\x69\xdd\x38\xd8\x36\xe7\xcd\xe8\xfc\x5e\x86\x94\xe4\x31\xfa\x6e\x4a\xa6\xe8\x88\x28\xb3\xcc\xc9\x61\xbf\x12\x00\xcc\x59\x9d\x7f\xd9\x4b\x11\x00\xe6\xb5\x54\x1d\xca\xe4\x3c\x70\x45\xcc\x39\x30\x61\x7e\xfb\x8f\xbd\xbb\x31\x61\x70\x8a\x29\xcf\x07\x7b\x35\xc7\x19\x2b\xab\xf3\xef\x2c\xaa\x8f\x0d\xed\x66\x96\x2d\x17\xbe\x0f\xab\x9f\x42\x7b\x2f\xfb\xc8\xbc\x2e\xa5\x9c\x25\x09\xeb\x9f\xf1\xed\xa1\xae\x73\xfb\x78\x7a\xf2\xf6\xbf\x0f\x77\x9e\xac\x9e\x27\x31\xea\x5e\x55\x37\x63\xce\x8a\xc7\xec\xa7\x67\xac\xbe\x94\xc2\xb3\xe5\x50\xaf\xdd\x8b\x49\x29\x1f\x96\x8f\xfb\x4c\x10\xce\xfe\x46\xd5\x1d\xf9\x5f\xaf\xa6\x85\x1a\x57\xd6\xe4\x4b\x83\xee\x1c\xd1\x7a\x36\x7f\x26\xe5\x83\xbf\x6b\x3b\x8f\x11\xb0\xaa\x4d\x22\xf6\x4b\xa8\x51\x55\x44\xa0\x30\x7c\x09\x0a\x2b\x39\xc7\x78\xba\xe3\x8e\x6e\xb4\x91\x8a\x4c\x71\xdb\x4b\x66\xd8\x92\x46\xd3\x75\xa4\x43\x99\x24\xdc\xff\x5d\x9f\x7e\xb2\xf4\x55\xf4\x8a\x6a\xa0\xb9\x6a\x30\x36\xc5\x38\x9b\x60\xb1\x2c\xf8\x0e\x3e\x3d\x9a\x89\xbb\x92\x98\x35\x63\xe4\x68\x7e\x40\x17\xb3\x22\x45\x69\x83\xd2\x36\xb4\x3e\x6c\x0d\x79\xdf\x3b\x0f\x22\xf2\xb5\x72\x69\x4b\x04\xec\x83\x9e\x43\xbe\x33\xd4\x75\x3b\xc2\x9c\x07\xdc\xa3\xf8\x9c\x8c\x91\x67\x6c\xe6\x90\xa2\x73\xaf\x8b\xdd\xe3\x24\x6b\xa2\xf5\xa8\x54\x44\x67\xe2\x40\x8c\x73\xe3\xa5\x49\xf7\xf0\xf6\x20\x60\xe1\x2f\xa4\xca\xf8\xf7\x27\x43\xcf\x46\xe9\xfd\xf1\x79\x5f\x64\xae\x15\x9b\x13\x83\x6f\x30\x93\x8c\x3c\x15\x77\x43\xf2\x6d\xc8\x96\x2e\xb7\xce\xce\xbc\x7f\x90\x14\x5f\xea\x00\x21\x5d\xeb\x75\x56\x7a\x2d\xf0\xf7\x01\xa0\x3f\x8a\xbe\xb7\x30\x9d\xc7\x34\x86\xd8\xfc\xde\xba\xbf\x92\x78\xfb\x79\x81\x93\x09\x16\xe6\x45\x36\xe4\x4b\x01\x44\x2c\xa1\x96\xd4\x3b\x56\x2a\x51\xbb\xe3\x42\x23\xb9\x2d\x83\xd0\x81\x71\x7b\xe4\x4a\xd4\x3e\x69\x87\x47\xa3\x2b\xf3\x68\x51\x18\xbb\x92\x43\x47\xab\x5f\x1c\x0f\xff\x1c\x0f\x2d\xde\xb5\xa4\x3e\x1a\x74\x40\x05\xa0\x72\x97\x1c\x07\x62\x08\x1f\x09\x67\x34\x40\xf7\x0d\x9d\x5b\x19\x2b\xa4\xdd\x23\xcf\xcd\x67\xe4\x4e\x20\xd7\xb3\x5d\xdf\xee\x56\x5e\x3f\x60\xd1\x98\x84\x17\x5f\x3f\xbd\x72\xd9\x59\x4e\x83\xf7\xb2\xca\x33\x67\x86\x4b\xab\x04\x63\x04\x52\xd7\x9c\x79\x95\x20\x9d\x14\x59\x7d\x7a\x32\xde\x86\x55\x78\x41\x69\x3e\x37\xdc\xc1\xfe\x3e\xae\xd8\xb8\x74\xe1\x45\xc4\x2a\x04\x62\x60\x51\xb2\xa2\xb4\x23\x9d\xd8\x7b\xb2\x17\x44\x5b\x4f\xec\xae\x53\x38\x8b\x90\x82\x2f\x61\xa1\x98\x31\xe8\x0f\xca\x56\x22\xea\xb4\x44\xff\x44\x6f\x41\x89\xc1\x81\x45\xe7\xc9\x15\x8a\xcd\x5f\xfa\x73\x66\x25\x4d\x7f\x97\xa5\x90\x4a\xa1\xae\x6d\x56\x27\xa6\xb1\xc1\xe8\x26\x74\x72\x66\x86\xcb\x27\x8a\x75\x7f\xbe\xef\x2d\x28\xfb\x7a\x86\xe9\x8e\x5c\x9f\x62\xa7\xb3\x77\xd5\x68\x9b\x91\xa7\x2f\x0f\xec\x21\x2d\x4f\xd4\x00\x12\xd5\xcb\x00\x92\x25\xdb\x60\x85\x42\x26\x7d\xea\xe8\x8f\xed\x12\x25\xd0\x2c\xa4\x9a\x5d\xe1\x9c\x15\xfd\x8f\xd7\xc3\xaa\x54\xef\xa0\xa5\x4f\xb7\xeb\x79\xad\xde\x41\x58\xbf\xaf\x7f\x90\xdd\x3f\xef\xfd\x53\xe9\x5d\x48\x8f\x96\xad\xbe\x1e\x31\xfe\x12\x53\xeb\x1e\x4b\x70\xf7\x3b\x8c\x7d\x44\x4f\xaf\x22\x0f\xf1\xfe\x91\xbf\x3d\x70\xdb\x54\x29\x9d\xd9\x97\x81\x74\xe7\x1f\x15\x79\xb8\x95\x14\x47\x92\x3e\x0b\x78\x39\x47\xa5\x25\xa7\x1f\x2c\x77\x7e\x54\x19\x9c\x7d\xe5\x6b\xd5\x8d\x76\xf8\xed\xfa\x66\xe8\xfa\xc9\xa4\x38\x8f\xa8\x71\x74\x08\xbc\x3f\xe2\xae\x46\xb8\x82\x92\xba\xfc\xb5\x73\x7c\x10\xe6\xd9\x60\xb4\x3e\xa4\x35\x40\x40\x63\x4d\x6c\x3e\x42\xc1\xbd\xb7\xc1\x69\xe3\x7a\xcb\x6e\xf6\xc1\xcc\x4b\xbd\x3e\x03\x84\x05\x33\x25\xbc\x4b\xe8\x75\x6f\xbb\x35\x28\x88\x30\x37\x57\xbd\x1d\x8d\x49\x78\x98\xec\xe4\x4c\x1f\x3c\x33\x3f\xe5\x8d\x07\x2b\x0c\xdb\x83\xcb\x1a\x5b\x03\xbb\x5d\xf1\xf4\xed\x44\x43\x4c\xa3\xf7\xde\x4f\x74\xb3\x36\x73\x91\x4d\x8f\x44\xc6\xb2\xf1\x17\x3d\x3d\x34\x77\x47\xb7\x45\x61\xc2\x39\x65\xaf\x2f\x52\xaa\x50\xeb\x3d\x6e\xf3\x6d\xe8\x7d\xac\x66\x83\x42\x52\x94\x64\xcc\x31\xe6\x00\x19\x87\x78\x40\xcd\x7d\xe1\x81\xbb\x1b\x66\x84\x89\x36\xd1\xf1\xe0\x2a\x6c\xf3\x32\x75\xc4\x15\xb0\x3b\xb4\x10\x2f\x65\xae\xfd\xbf\x93\x09\xc5\x4e\x49\x76\x27\xf8\xb1\xa5\x67\xca\x38\x12\xa4\x5c\xb6\xc9\x70\xcb\x4e\x41\x0a\x17\x79\x47\xce\x87\x9e\xae\xce\xff\x6f\x46\x20\x73\x0d\xe8\x1b\x11\xe7\x3c\x82\x15\x5d\xc9\x8f\x95\x49\xff\xf4\x67\xcb\x1a\x37\xf7\x3e\x28\xf5\x29\x64\x55\x4b\x81\x89\xea\xfa\x00\x35\xbe\x8c\x40\x5a\xc9\x85\x68\xaa\x31\x2a\x97\xd9\xc8\x9a\xa1\xbf\x17\x44\x8a\x32\xc1\xb6\x15\x16\xb1\xd4\x8c\x5a\xe7\xbb\x50\x87\xaa\xb7\xc2\x9a\xb3\x82\xf4\x69\x18\x6c\x53\xf0\x21\x2c\xed\xa4\x24\xa3\x1a\x81\xbe\xf5\x95\x6a\xf7\xeb\x60\xda\xf6\xd3\x67\x1f\x32\x27\x8c\x5b\x6f\x94\xaf\x6b\xfa\x9d\xbb\xf4\x39\x75\x29\x1a\xa5\x50\x74\x74\x11\xbe\xdd\x56\xe1\x5e\xfa\xf7\xd8\x2a\x7c\x02\xf0\xfc\x5b\xed\x3b\xee\x5c\xc9\x32\xf3\x3e\xb0\x3f\xf3\x36\x70\x2c\xf3\x36\x10\xd9\xe1\xab\xba\x0f\x85\xbe\xad\x93\x8b\x96\xf9\xac\x1e\x2d\x77\xfc\x72\x90\x47\x0b\x40\xd6\xa1\x99\xa2\x21\x8c\xeb\x75\x58\xf6\x42\x59\xef\x97\xe0\x54\xfc\xda\xe8\x91\xfd\x72\x4e\xb4\x19\x29\x39\xc6\x7b\x96\xab\xb5\xdb\x39\x0b\xd1\xc6\xf7\x6f\x16\x68\x41\x8f\x6d\xde\x6b\x51\x8d\x28\xa6\x9d\x4d\xbf\x7e\xcb\xde\x26\xbd\x36\xf7\x8a\xb8\xef\x22\xfc\x67\x6f\x07\x21\xdc\x42\x13\xcc\x0a\x10\x52\x7f\x6c\x64\x23\xb5\xcf\xfd\x72\x1d\x0d\x09\x44\xb8\x8f\x03\x9e\x91\xc8\x0a\xb5\x26\xd3\x3e\x94\xfd\x4f\x53\x11\x31\x50\x48\xa8\xcb\x18\xc3\x42\x60\x82\xba\x0b\xa0\x62\xba\xd2\x27\x9f\xdb\x5a\xf6\xe5\x28\x5b\x31\xe3\x51\xc9\x97\x42\xa2\x73\xf7\x62\x5a\x28\xff\x29\xd8\x97\xc6\x27\x45\x83\x85\x54\xf4\x74\xfd\x5d\x54\x00\xb2\xd6\xfd\x28\xa9\x64\x52\x0a\x41\x1d\x9e\x8a\xf9\x6e\xe5\x90\xc1\x3c\x14\x0f\xe1\xe4\x6a\x5d\x22\xb4\x75\x1f\x2e\x89\x80\x31\xc2\xbd\x6a\xb2\x4d\xe6\xd7\x84\x6b\x3c\x85\x3f\xc5\x4c\xc8\xc5\xe3\xb0\xee\x99\x90\xba\x76\x50\xc0\x38\x76\x80\x7a\xd8\xea\xa3\x3d\x6f\xc6\x78\xbe\x9d\xdf\xa5\x42\xdf\x8c\x7a\x97\xa9\x5c\x16\xb3\x5d\x44\xf3\x87\xeb\x19\xcb\x6b\xb1\xf4\x02\x4a\x6b\x75\xd0\xdf\xea\x16\xe5\xb2\xab\xd5\x65\xe5\xc3\x44\x70\xe1\x39\xb9\xe4\xfb\x83\x92\xba\x16\xc4\x3b\xa2\x67\x77\xec\xef\x1d\xdc\xbb\xb2\x8b\x7c\x4e\x51\x97\x89\xf3\xc7\x64\xc3\xcf\x4e\x6c\x35\x1c\xdd\xd2\x8d\x4e\xa3\xa5\x49\x1b\xd5\x14\x46\xf6\xef\x3a\xa6\xdd\xc9\x96\x1c\xc6\x8a\xe1\x64\xc3\x7d\xf4\x11\xc4\xae\x0e\x6e\xe4\xca\x1b\x82\x70\xd9\xf5\x01\xe8\x4e\x99\x36\x6a\x79\x33\x7a\xc6\x9e\x58\xfc\xae\xb5\x8f\x58\xe2\x87\xe7\xad\x02\x23\xe6\x12\xab\x44\x30\x7c\x22\xfc\xc0\xaa\xa6\x4a\x98\x79\x00\xf1\xa5\x91\x86\x74\xf5\x0c\x0e\xe9\xd5\x12\xce\x65\x41\x4c\xae\xa4\xe8\xdf\xe4\x24\x62\xf9\x7e\xe7\xfb\x4a\xff\x0c\x7a\x24\xcb\x83\xfd\x47\x23\x35\x31\xb6\x08\x3f\x87\xff\x3d\xfe\xeb\xe7\xaf\x83\x93\x57\xc7\xc7\x9f\x7e\x19\xfc\xe7\xe7\x9f\x8f\xff\x1a\xba\xff\xfc\xfb\xc9\xab\x93\xaf\xf1\xc7\xcf\x27\x27\xc7\xc7\x9f\xde\xbc\xfb\xe3\x7e\x74\xfd\x99\x9d\x7c\xfd\x24\x9a\x6a\xe6\x7f\x7d\x3d\xfe\x84\xd7\x9f\x7b\x02\x39\x39\x79\xf5\x6f\x49\x74\x1e\x06\xeb\x2f\x1f\x07\x4c\x98\x81\x54\x03\x8f\xfd\x39\x18\xd5\xa4\x32\x8d\xb6\xbd\xac\x39\xbf\x7d\x18\xb7\xfa\x4c\xdb\xb5\xa2\x56\xe6\x90\x3d\x7b\x25\x0a\x37\x94\xc8\x6a\x43\xe8\xd7\x32\x31\x1d\xb6\x3e\x0b\xb8\x24\x35\x29\x98\x49\x9e\x51\x75\xd6\x05\x41\x4f\xd2\xd5\xcc\x3f\x5a\xf2\x6c\x5a\x12\x1d\x87\x6b\x4c\xfa\x2f\xee\xd1\xf5\x14\x8e\x57\xfe\x40\x90\x0a\x4f\xe1\x4b\x43\x84\x61\x66\x79\x92\xe1\x0a\x53\xc9\x2e\x5e\xa7\xd0\x8b\xa0\x2d\xff\xc8\xfc\xbb\xca\x3c\x1a\xe9\xce\x19\xbd\x34\x84\x67\x9c\xc3\x81\xb2\x7d\xcc\x61\xcb\x37\x3a\x7c\x48\x6c\xbd\x35\xb4\xfe\xbb\x2a\xbf\xae\x7f\x39\xe5\x1a\x84\xbf\xa3\xe2\x5e\x84\x6f\x4c\xe8\x06\x53\xc3\x35\xc3\x30\xb2\xae\x24\x48\x51\x60\x6d\x90\xde\x6e\xff\xfd\x94\x17\xfe\xa6\x4e\xfc\x03\x29\xee\xe7\x46\x2b\x01\x3e\x7d\x3e\xf2\x50\x91\x7e\x8c\x78\xd8\xc1\xff\x0f\x00\x00\xff\xff\x8e\x54\x43\xbd\x83\x46\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xb4\x56\x4d\x73\xdb\x36\x13\xbe\xf3\x57\xec\xe4\x3d\xe4\x12\x51\xc9\xe4\xf2\x0e\x6f\x1e\xc5\xd3\x71\x9b\xd8\x1a\xcb\xe3\x4b\xa7\x07\x88\x58\x91\x5b\x83\x00\x8a\x5d\xc8\x71\x3b\xfd\xef\x1d\x00\xa4\x24\xca\xca\xa4\x39\x94\x37\x2c\x76\xf7\x59\x3e\xfb\x85\x6a\xb1\x58\x54\xca\xd3\x23\x06\x26\x67\x1b\x50\x9e\xf0\xab\xa0\x4d\x27\xae\x9f\xfe\xcf\x35\xb9\xe5\xfe\xc3\x16\x45\x7d\xa8\x9e\xc8\xea\x06\x56\x91\xc5\x0d\xf7\xc8\x2e\x86\x16\x3f\xe1\x8e\x2c\x09\x39\x5b\x0d\x28\x4a\x2b\x51\x4d\x05\xa0\xac\x75\xa2\x92\x98\xd3\x11\xa0\x75\x56\x82\x33\x06\xc3\xa2\x43\x5b\x3f\xc5\x2d\x6e\x23\x19\x8d\x21\x23\x4c\xf8\xfb\xf7\xf5\xc7\xfa\x7d\x05\xd0\x06\xcc\xe6\x0f\x34\x20\x8b\x1a\x7c\x03\x36\x1a\x53\x01\x58\x35\x60\x03\xe4\x5b\xa3\x68\xe0\x5a\xe3\xde\x79\xae\x3b\xc7\xc2\x3d\xf9\x9a\x5c\xc5\x1e\xdb\x1c\x84\xd6\x39\x32\x65\xd6\x81\xac\x60\x58\x39\x13\x87\x12\xd1\x02\x7e\xde\xdc\xdd\xae\x95\xf4\x0d\xd4\xc9\xa0\xf6\xce\x25\xf7\x00\x1a\xb9\x0d\xe4\x25\x07\xf4\xd0\x23\xa4\x1b\x70\x3b\x90\x1e\x21\xa3\xd6\x59\xaf\x04\xb2\xbe\xbb\xfb\x9c\x8f\xf2\xe2\xb1\x01\x96\x40\xb6\xbb\x08\x10\x94\xed\x70\x23\x2a\xc8\x65\x98\x1d\x05\x96\x14\x74\x40\xe6\x53\x88\xcd\xc3\xd5\xfd\xc3\x0f\x60\x5c\x5b\x7d\x19\xc1\xa8\xcb\x00\xd7\xb7\x9f\xbe\xeb\x7e\xca\x6e\xfd\x2a\x33\xaf\xb1\xde\xae\xce\x75\x80\x18\x14\xc8\xe1\x18\xd0\x07\x64\xb4\x42\xb6\xcb\xbc\x32\x86\x3d\x86\xac\x01\xcf\x3d\xda\xec\x14\x40\x7a\x62\x70\xdb\xdf\xb1\x15\x78\x56\x5c\xca\x02\x75\x0d\x6f\x4f\xc2\xbf\xfa\xe9\xfa\x24\x7c\xad\x04\x2b\x80\x2e\xb8\xe8\x1b\xb8\x50\x1e\xc5\x6c\xac\xcb\x52\xd3\x37\xeb\x55\xca\x6b\x96\x18\x62\xf9\xe5\x54\xfa\x99\xb8\x64\xcc\x9b\x18\x94\x39\xd6\x5e\x16\x72\xef\x82\xdc\x1e\x1d\x2e\xd2\x75\xb9\x21\xdb\x45\xa3\xc2\xc1\xa0\x02\xe0\xd6\xa5\x18\xb3\xbe\x57\x2d\xea\x24\x8b\xdb\x30\x76\x13\x37\xf0\xd7\xdf\x15\xc0\x5e\x19\xd2\x99\xc1\xe2\xd4\x79\xb4\x57\xeb\x9b\xc7\x8f\x9b\xb6\xc7\x41\x15\xe1\x19\xe9\x63\xb4\x40\x9c\x09\x2d\x9a\xb0\x73\x21\x1f\xa7\xdb\xab\xf5\xcd\xbb\x63\x21\x27\x65\xf7\x6c\x51\xc3\xf6\x65\xf4\x09\xf9\xf6\x8b\x6a\x7b\xb2\x08\x2e\xc0\xca\x44\x16\x0c\x10\x79\xca\xd5\x58\x41\xc8\xef\x40\x59\x3d\x17\x81\x0a\x08\x01\x0d\x2a\x46\x7d\x70\x99\x32\x0a\x24\x40\x0c\x1a\x0d\xa6\x0c\x8e\x77\x3e\x38\x8f\x41\x68\xa2\x2f\x7d\x27\x03\xe9\x20\x3b\xaf\xb0\xc4\x46\xd1\x01\x9d\x46\x10\x96\xbf\x1e\x07\x09\x6a\xe0\xf2\xff\xb9\x6d\x89\x8f\x05\x97\x59\x3d\x71\x0b\x49\x45\xd9\xb1\xc8\x6a\xd8\xe4\x42\xe4\x94\xd7\x68\x74\x9a\x5b\x7b\x0c\x02\x01\x5b\xd7\x59\xfa\xf3\xe0\x99\x41\x5c\x86\x34\x4a\x70\x2c\x90\xe9\xcb\xc3\xc6\x2a\x93\xf2\x18\xb1\xb0\x34\xa8\x17\x08\x98\x30\x20\xda\x13\x6f\x59\x85\x6b\xf8\xe2\x02\x02\xd9\x9d\x6b\xa0\x17\xf1\xdc\x2c\x97\x1d\xc9\x34\x82\x5b\x37\x0c\xd1\x92\xbc\x2c\xf3\x20\xa5\x6d\x14\x17\x78\xa9\x71\x8f\x66\xc9\xd4\x2d\x54\x68\x7b\x12\x6c\x25\x06\x5c\x2a\x4f\x8b\x1c\xb8\xcd\x13\xb8\x1e\xf4\xff\x0e\x15\xf6\xf6\x24\xd2\xb3\x7e\x07\x38\x74\xc4\x37\x79\x4f\x9d\x51\x9a\xb9\x98\x95\xf8\x5f\xf7\xf3\xfd\xf5\xe6\x01\x26\xd0\x9c\x82\x39\xe7\xa5\xa5\x0f\x66\x7c\x24\x3e\x11\x45\x76\x87\x21\x5b\xc1\x2e\xb8\x21\x7b\x44\xab\xbd\x23\x2b\x63\xf5\x12\xda\x39\xe9\x1c\xb7\x03\x49\xca\xf4\x1f\x11\x59\x52\x7e\x6a\x58\xe5\x45\x04\x5b\x84\xe8\x75\x99\x1c\x37\x16\x56\x6a\x40\xb3\x52\x8c\xff\x39\xed\x89\x61\x5e\x24\x4a\xbf\x4f\xfc\xe9\xfe\x9c\x2b\x16\xb6\x0e\xe2\x69\xbf\x5d\xcc\xd0\xd8\xe8\x1b\x8f\x6d\xc9\xd3\xd6\xb8\xf6\x09\x94\x31\xae\x4d\x04\x00\x59\x50\x79\xa5\xd5\x27\x2e\x2e\xb5\x61\xe9\x8d\x1d\xa3\xcc\x65\x67\x80\x77\x59\x65\x1a\x3b\x64\x35\x7e\x9d\x96\x65\x81\x26\x9b\x0f\xe7\x90\xc7\xdf\x4b\xed\xd2\x61\x98\xdd\x25\xed\xe6\xa2\xf6\x19\x6b\xe9\x9b\xb6\xde\x8f\x19\xe4\x55\xfc\x2f\x4d\x52\x51\x51\xc0\x19\xc2\x62\x64\x67\x26\x3a\xbc\x22\x26\xc1\x6c\x23\xcf\x84\xc7\xa7\xc0\x37\x73\x7d\x26\x3a\x3e\x93\x3e\x1c\x4f\xe3\x73\xa6\xac\xc2\x7c\x01\x65\x9b\xea\x06\x24\x44\x2c\x02\x71\x41\x75\x38\x4a\x58\x94\xc4\x6c\xa7\xda\x16\xbd\xa0\xbe\x3d\xdf\x88\x6f\xde\xcc\x56\x5e\x3e\xb6\xce\x96\x07\x15\x37\xf0\xeb\x6f\x55\xf1\x8a\xfa\x71\x8a\x23\x09\xff\x19\x00\x06\x2e\x76\x18\x52\x0a\x00\x00"),
+		},
+		"/devops.gostship.io_ippools.yaml": &vfsgen۰CompressedFileInfo{
+			name:             "devops.gostship.io_ippools.yaml",
+			modTime:          time.Date(2026, 10, 18, 6, 35, 1, 593209210, time.UTC),
+			uncompressedSize: 3842,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xb4\x57\xcd\x8e\xdb\x36\x10\xbe\xeb\x29\x06\xe9\x21\x97\x5a\x4e\x90\x4b\xa1\xdb\xd6\x09\x8a\x6d\x9a\xd4\x58\x6f\x02\xb4\x45\x0f\xb4\x38\x96\xd9\xa5\x48\x96\x33\x74\xea\x2d\xfa\xee\x05\x49\x49\x96\x6c\xed\x4f\x0a\x94\x27\x73\x38\x33\xdf\xe8\x9b\x1f\xd2\xc5\x62\xb1\x28\x84\x53\x9f\xd1\x93\xb2\xa6\x02\xe1\x14\xfe\xc5\x68\xe2\x8e\xca\xbb\xef\xa8\x54\x76\x79\x78\xbd\x45\x16\xaf\x8b\x3b\x65\x64\x05\xab\x40\x6c\xdb\x1b\x24\x1b\x7c\x8d\x6f\x71\xa7\x8c\x62\x65\x4d\xd1\x22\x0b\x29\x58\x54\x05\x80\x30\xc6\xb2\x88\x62\x8a\x5b\x80\xda\x1a\xf6\x56\x6b\xf4\x8b\x06\x4d\x79\x17\xb6\xb8\x0d\x4a\x4b\xf4\x09\xa1\xc7\x3f\xbc\x2a\xdf\x94\xaf\x0a\x80\xda\x63\x32\xbf\x55\x2d\x12\x8b\xd6\x55\x60\x82\xd6\x05\x80\x11\x2d\x56\xa0\x9c\xb3\x56\x53\x29\xf1\x60\x1d\x95\x8d\x25\xa6\xbd\x72\xa5\xb2\x05\x39\xac\x53\x0c\x52\xa6\xc0\x84\x5e\x7b\x65\x18\xfd\xca\xea\xd0\xe6\x80\x16\xf0\xe3\xe6\xe7\x8f\x6b\xc1\xfb\x0a\xca\x68\x50\x7a\x51\xdf\x15\x00\x00\x12\xa9\xf6\xca\x71\x8a\xe7\x76\x8f\x10\x4f\xc0\xee\x80\xf7\x08\x11\xb4\x4c\x6a\x39\x8c\x9b\xab\xd5\xfb\xb4\xe5\xa3\xc3\x0a\x88\xbd\x32\xcd\xac\xff\xa8\x30\xef\x3f\xfa\x4c\xf6\x63\xc7\xb7\xbf\xac\xdf\x3d\xed\x98\x05\x07\x2a\x49\xdd\x3f\xe0\xba\xb6\xc1\x70\x8c\x7d\xab\x6d\x7d\x47\x63\x80\xcd\xf5\xaf\x63\x80\x48\x50\x83\xfe\x01\x84\x40\x28\x9f\x40\x10\x5a\xdb\x5a\x30\xca\x19\xac\x4f\x9b\x77\x6f\x9f\xc6\xea\xeb\xa7\xbc\xc8\xfd\x25\xf4\xcb\xd5\xb9\x0e\x28\x02\x01\x3c\x6c\x3d\x3a\x8f\x84\x86\x95\x69\x52\xea\x08\xfd\x01\x7d\xd2\x80\x2f\x7b\x34\xc9\x29\x00\xef\x15\x81\xdd\xfe\x81\x35\xc3\x17\x41\xb9\xf0\x50\x96\xf0\x72\xf4\x01\x57\x3f\x8c\xb9\x92\x82\xb1\x00\x68\xbc\x0d\xae\x82\x99\x0a\xcc\x66\x5d\xe5\xe7\xae\xb9\x5e\xaf\xad\xd5\x49\xa0\x15\xf1\xfb\x91\xf0\x27\x45\x9c\x0e\x9c\x0e\x5e\xe8\xa1\xb6\x93\x8c\xf6\xd6\xf3\xc7\x93\xb7\x45\x3c\xcd\x27\xca\x34\x41\x0b\xdf\xeb\x17\x00\x54\xdb\x18\xdf\x4a\x07\xe2\xc4\x2f\x85\xad\xef\x1a\xb5\xb3\xcf\x09\xad\xe0\xef\x7f\x0a\x80\x83\xd0\x4a\x26\x1a\xf3\xa1\x75\x68\xae\xd6\xd7\x9f\xdf\x6c\xea\x3d\xb6\x22\x0b\xcf\x98\xcf\x31\x83\xa2\x44\x6a\x56\x84\x9d\xf5\x69\xdb\x1d\x5e\xad\xaf\x3b\x53\xe7\xad\x43\xcf\xaa\x87\x8f\x6b\x34\x6f\x06\xd9\x79\x7a\x63\x14\x59\x07\x64\x9c\x30\x98\xe1\xba\x39\x81\x12\x28\x03\xa7\xb6\x54\x74\xca\x76\xfa\x9a\x91\x5b\x48\xb5\x69\xba\x0c\x97\xb0\x49\x55\x40\x91\xd7\xa0\x25\xd4\xd6\x1c\xd0\x33\x78\xac\x6d\x63\xd4\xfd\xe0\x99\x80\x6d\x82\xd4\x82\xb1\xcb\x4f\xbf\xd2\x30\x31\x42\x47\xfe\x02\x7e\x0b\xc2\x48\x68\xc5\x11\x3c\x46\x0c\x08\x66\xe4\x2d\xa9\x50\x09\x1f\xac\x47\x50\x66\x67\x2b\xd8\x33\x3b\xaa\x96\xcb\x46\x71\x3f\x61\x6b\xdb\xb6\xc1\x28\x3e\x2e\xd3\x9c\x54\xdb\xc0\xd6\xd3\x52\xe2\x01\xf5\x92\x54\xb3\x10\xbe\xde\x2b\xc6\x9a\x83\xc7\xa5\x70\x6a\x91\x02\x37\x69\xc0\x96\xad\xfc\x66\xc8\xf2\xcb\x51\xa4\x67\xa3\x03\x60\x28\xc7\x07\x79\x8f\x75\x99\x3b\x29\x9b\xe5\xf8\x2f\x9b\xe9\xe6\xdd\xe6\x16\x7a\xd0\x94\x82\x29\xe7\xb9\x9f\x06\x33\x3a\x11\x1f\x89\x52\x66\x87\x3e\x59\xc1\xce\xdb\x36\x79\x44\x23\x9d\x55\x86\xd3\xa6\xd6\x0a\xcd\x94\x74\x0a\xdb\x56\x71\xcc\xf4\x9f\x01\x89\x09\xd8\x96\xb0\x4a\xf7\x0c\x6c\x11\x82\x93\xb9\x6d\xaf\x0d\xac\x44\x8b\x7a\x25\x08\xff\x77\xda\x23\xc3\xb4\x88\x94\x3e\x4d\xfc\xf8\x7a\x9c\x2a\x66\xb6\x06\x71\x7f\x7f\xcd\x66\x28\x77\xd8\xc6\x61\x3d\x69\x0c\x21\xa5\x47\x22\xa4\xc9\x45\xd5\x5d\x5f\xa6\x41\x02\xe1\x71\xca\xa7\xd3\x8a\x41\x19\xb6\xdd\xc0\x8e\x96\xdf\xc7\x5f\x1b\x75\x3f\x72\x98\xcb\x5b\x64\xa5\x54\x1a\xc3\xa0\x17\xd3\x0c\xe5\xe9\x5b\x8e\x64\x73\xdd\x1f\xd7\xb6\x87\x99\x8a\x2f\xef\x87\x31\x09\x3b\x11\x34\xdf\xd8\xc0\x0f\x58\x9d\xd1\x1d\x57\x23\x18\xbf\x88\xe3\xb3\xf5\x0d\xf2\x07\x41\x77\xcf\xd6\x8f\x2f\x83\xaf\x50\x8e\x79\x38\x57\x57\x8c\xed\x85\xf0\x22\xe7\x37\xd1\x36\xf7\x65\x72\x93\x86\xda\x29\x43\x5b\xcb\xfb\xd8\x40\x04\xca\xd4\x3a\x48\x94\xe5\x85\xc7\x87\x72\x91\x17\x4e\x87\xc2\x33\x3e\x27\x2f\x62\xe1\xf9\x3f\x58\xc6\x2e\x56\x1e\x67\x40\x17\x31\x96\x19\x69\x42\x2a\xe6\x41\xce\x1a\x68\x7c\x24\xbc\x17\xc7\xf3\x41\x62\xf0\x22\xe6\x09\xe1\x9b\xa4\xd2\xdf\x71\xb5\x92\xbe\x6f\xab\x81\xf4\xf2\xb9\x69\x4f\x07\xc5\xa3\xd9\x8d\x1d\x7d\x7b\x74\xd8\x03\x06\x12\x39\xc5\x7d\x23\x7f\x35\xec\x1c\xbd\x8b\xbe\x1d\x26\xb2\xe1\xd9\x7b\x12\xc4\x32\x9d\x88\x32\x63\x13\xd1\xf0\x9a\x7d\x6c\x8e\xe5\xa7\xc6\x13\x93\x2c\x29\xf5\x5f\xde\xcd\x16\x65\x4d\xb2\xc6\xcb\x67\xf7\xe3\xc5\x3c\xcc\xa6\x47\x19\xbf\xea\xb5\x7a\xd8\xad\xe2\x56\xb8\x21\xc7\xb3\x2f\xd9\xd3\xda\x59\xdf\x0a\xae\x60\x7b\x64\x7c\x6e\x15\xd0\xcc\xb0\x9b\x96\x5c\x1c\xba\x7d\xc1\xcd\xbd\xda\x9f\x33\x24\xe3\x33\xfd\x51\x94\x4f\x84\xf2\x02\xe5\xa9\xef\x7d\x08\x6f\x26\xe9\x67\xa2\xd3\xdf\xba\xd7\xa7\x5d\xf7\xff\x2b\x3f\xac\xd3\x01\xe4\xb7\xb9\xac\x80\x7d\xc8\x94\x12\x5b\x2f\x1a\xec\x24\xa7\x4a\x12\x75\x8d\x8e\x51\x7e\x3c\x7f\x5f\xbf\x78\x31\x79\x42\xa7\x6d\x6d\x4d\xfe\x07\x48\x15\xfc\xf6\x7b\x91\xbd\xa2\xfc\xdc\xc7\x11\x85\xff\x0e\x00\x5d\x91\x1d\xfa\x02\x0f\x00\x00"),
+		},
+		"/devops.gostship.io_kubernetesartifacts.yaml": &vfsgen۰CompressedFileInfo{
+			name:             "devops.gostship.io_kubernetesartifacts.yaml",
+			modTime:          time.Date(2026, 10, 18, 6, 35, 1, 789189160, time.UTC),
+			uncompressedSize: 4620,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xb4\x58\x41\x73\xdb\xb6\x12\xbe\xf3\x57\x7c\x93\x77\xc8\x7b\x33\x16\x15\xbf\x24\x7e\x19\xdd\x3c\x8a\x5f\xeb\x26\x71\x3c\x56\xea\x4b\xa7\x07\x88\x58\x89\xa8\x41\x80\x05\x96\x72\x9c\x4e\xff\x7b\x07\x00\x29\x51\x12\xa5\xa8\xc9\x54\x27\x61\xb1\xbb\xf8\xf6\xdb\xc5\x02\x60\x36\x1a\x8d\x32\x51\xab\x7b\x72\x5e\x59\x33\x81\xa8\x15\x7d\x66\x32\x61\xe4\xf3\x87\x37\x3e\x57\x76\xbc\x3a\x9f\x13\x8b\xf3\xec\x41\x19\x39\xc1\xb4\xf1\x6c\xab\x3b\xf2\xb6\x71\x05\xbd\xa5\x85\x32\x8a\x95\x35\x59\x45\x2c\xa4\x60\x31\xc9\x00\x61\x8c\x65\x11\xc4\x3e\x0c\x81\xc2\x1a\x76\x56\x6b\x72\xa3\x25\x99\xfc\xa1\x99\xd3\xbc\x51\x5a\x92\x8b\x2b\x74\xeb\xaf\x5e\xe4\x2f\xf3\x17\x19\x50\x38\x8a\xe6\x9f\x54\x45\x9e\x45\x55\x4f\x60\x1a\xad\x33\xc0\x88\x8a\x26\x08\x0e\x9c\x21\x26\x2f\x1c\xab\x85\x28\xd8\xe7\x92\x56\xb6\xf6\xf9\xd2\x7a\xf6\xa5\xaa\x73\x65\x33\x5f\x53\x11\xf1\x48\x19\x41\x0a\x7d\xeb\x94\x61\x72\x53\xab\x9b\x2a\x81\x1b\xe1\xa7\xd9\xc7\x9b\x5b\xc1\xe5\x04\x79\x30\xc8\x5b\x38\x19\x00\x48\xf2\x85\x53\x35\x47\x78\x9f\x4a\xea\xad\x8c\x56\x2f\xcf\x80\x0e\xd7\xfd\xd5\xdd\xec\xfa\xe3\x4d\x06\x00\xfc\x54\xd3\x04\x9e\x9d\x32\xcb\xdd\x75\x3a\xb2\xf2\xbd\x40\xf7\x57\x7d\x3e\xdd\xd5\x81\xf2\x10\xe0\xf5\xd0\x51\xed\xc8\x93\x61\x65\x96\xe0\x92\xe0\xc9\xad\xc8\x45\x0d\x3c\x96\x94\x42\x01\xb8\x54\x1e\x76\xfe\x1b\x15\x8c\x47\xe1\x13\xcb\x24\x73\x3c\xef\x85\x70\xf9\xc3\x55\x0f\xbe\x14\x4c\x19\xb0\x74\xb6\xa9\x27\x18\xa0\x38\x99\xb5\x69\x4e\x25\xf2\x6e\x4d\xd1\x65\x9b\x9c\x0c\x00\xb4\xf2\xfc\xee\x80\xc2\x7b\xe5\x93\x52\xad\x1b\x27\xf4\x60\x82\x33\x00\xf0\xa5\x75\x7c\xb3\x59\x71\x84\x07\x91\x26\x94\x59\x36\x5a\xb8\x21\xd3\x0c\xf0\x85\x0d\xe1\x4c\x75\xe3\x99\x5c\x10\x34\x73\xd7\x16\xb1\x9f\xe0\x8f\x3f\x33\x60\x25\xb4\x92\x91\xe9\xe4\xdb\xd6\x64\x2e\x6f\xaf\xef\x5f\xce\x8a\x92\x2a\x91\x84\x3b\xc9\xd9\x0f\x05\xca\xc7\x1c\x24\x23\x2c\xac\x8b\xc3\x01\xc5\xcb\xdb\xeb\xb3\x0c\xe8\x72\x43\x30\x56\x92\x87\x5d\xc4\x41\x91\xa0\xae\xc7\x6d\xb5\x41\xda\x47\xa3\xad\x90\x51\xb8\x50\x9a\x3c\x84\x91\x61\x5a\x2d\x9e\x82\x50\xb9\xb5\x53\x5f\x8a\xff\xbe\xbe\x80\x6f\x2a\x9f\xb7\xc2\xda\xd9\x9a\x1c\xab\x8e\x40\x00\xe8\xb5\x80\xb5\x6c\xb7\x08\x03\x11\x1d\x82\xb0\xe9\xc9\xf7\x51\x91\x84\x4f\xf1\x46\xb4\xca\x6f\x6a\x32\x12\xda\x73\x8b\xa0\x22\x4c\x5b\x87\x39\x66\xb1\x56\x7d\xc8\x6c\xa3\x65\xe8\x14\x2b\x72\x0c\x47\x85\x5d\x1a\xf5\x65\xed\xd9\x83\x6d\x5c\x52\x0b\xa6\xb6\x5a\xba\x5f\xdc\xd3\x46\xe8\x90\xc2\x86\xce\x22\x21\x95\x78\x82\xa3\xb0\x06\x1a\xd3\xf3\x16\x55\x7c\x8e\x0f\xd6\x11\x94\x59\xd8\x09\x4a\xe6\xda\x4f\xc6\xe3\xa5\xe2\xae\xe9\x15\xb6\xaa\x1a\xa3\xf8\x69\x1c\x5b\x97\x9a\x37\x6c\x9d\x1f\x4b\x5a\x91\x1e\x7b\xb5\x1c\x09\x57\x94\x8a\xa9\xe0\xc6\xd1\x58\xd4\x6a\x14\x81\x9b\xd8\xf3\xf2\x4a\xfe\x6b\x5d\x5c\xcf\x7b\x48\x77\x5a\x02\xb0\xde\x34\x07\x79\x0f\x3b\x26\xed\xf7\x64\x96\xf0\xef\x6f\xf9\xbb\xab\xd9\x27\x74\x8b\xc6\x14\x6c\x73\x1e\xd9\xde\x98\xf9\x0d\xf1\x81\x28\x65\x16\xe4\xa2\x15\x16\xce\x56\xd1\x23\x19\x59\x5b\x65\xb8\xad\x46\x45\x66\x9b\x74\xdf\xcc\x2b\xc5\x21\xd3\xbf\x37\xe4\x39\xe4\x27\xc7\x34\xb6\x7e\xcc\x09\x4d\x2d\x53\x73\xb9\x36\x98\x8a\x8a\xf4\x54\x78\xfa\xc7\x69\x0f\x0c\xfb\x51\xa0\xf4\xeb\xc4\xf7\x4f\xac\x6d\xc5\xc4\xd6\x5a\xdc\x1d\x23\x83\x19\xda\xdf\xd8\xb3\x9a\x8a\xae\x0b\x38\xd2\x24\x3c\xa1\x12\x46\x2d\xc8\x73\x2c\xfe\x5e\x8b\xea\x79\xc5\xf6\x81\x72\x78\xbb\x02\x48\x1b\x7f\x5b\x04\x28\xa6\x6a\x4f\xb8\x03\xb7\x03\xf9\x7f\xa5\x29\xd5\xd5\x22\xfe\x33\x9e\x85\xd6\x24\x61\xcd\xa6\x17\xe5\x7b\xbe\x0e\xe1\x01\xba\x63\x5e\x28\x43\xee\xae\x31\xe1\xf0\x19\xd2\xd9\xc1\x33\xdd\x31\x41\x45\xc2\xf8\x75\x73\x83\xf2\xb0\x46\x3f\x6d\x00\x0e\xba\xc4\x16\xec\xae\x65\xba\xe4\x32\x1f\x34\x21\xd3\x54\xc3\xf8\x46\x90\xb6\x78\x20\x77\x60\x72\x1d\xe4\x30\x94\xc1\x52\xdb\xfc\xa4\xe7\x13\x58\x79\xeb\xd7\xe7\x48\x2d\xb8\xec\x02\x8a\x8c\x1c\x4f\xd0\x09\x10\xe8\x33\x3b\x51\xf0\x5b\xe5\x4e\x40\x72\xb5\x56\x1e\xc8\x8c\x00\x2f\xbf\x74\xfe\x48\x42\x19\xb6\x83\x2e\xd3\xf9\x26\x95\x3b\x03\xe5\xcb\x3c\x8e\x0a\xa3\xc2\x71\xbf\x54\xe6\xdb\xc2\xa8\xac\x3c\xa5\xc0\x3e\x58\x49\x1d\x97\xb6\x60\xa1\x13\xfa\x60\xdd\x82\x79\xf1\xbf\xd7\xaf\xd7\xe7\xf4\x5c\x19\xe1\xd4\x37\x32\x1b\x6f\x50\x5f\x87\x14\xee\x2f\xfd\x94\xb6\x38\x42\x57\xd0\xc4\xdf\xb4\x72\x77\x93\x19\xb4\x3d\xd0\x17\x8e\x74\x87\x59\x74\x07\xe5\xc3\xf5\xd1\xd1\xf6\xd6\x12\x06\xfd\x36\x7c\xc0\x2f\xf6\x6f\x2a\xf9\x01\xd5\xe3\x4d\x05\x00\x10\x57\x3c\x3c\xbb\x17\x46\x51\x76\x39\xef\x43\xed\x48\x8f\xa1\x24\xd6\x8f\xb8\x04\x44\x25\x2f\x5e\xc1\x3a\x08\x57\x5d\xbc\x3a\xa6\xfb\x95\xf4\xf4\xaf\x63\x27\x47\x31\xfb\xf1\x32\xdc\xde\xda\x38\x4a\xfa\xdc\xbb\xcf\xf5\xcb\xe7\xbb\x71\x35\x4e\x9f\x0c\xea\xe7\xbb\xf7\x69\xe7\x87\xd3\xfb\xdf\xfe\x3f\xc1\xf8\x2c\x52\x94\x3a\x95\x23\x2d\x58\xad\x08\x6c\x8f\xb8\x44\x9b\x9a\x54\x6d\xa1\x31\x74\x01\x85\x4a\x10\x6c\xdd\x19\x1e\x4b\x95\xb2\x18\x5f\x33\x12\xf3\x78\xbb\x3d\xea\xb4\x33\xfe\x4e\x46\xc2\x8d\x46\x39\x92\x87\x48\x19\xc5\xa2\x3a\x38\x99\xb2\x74\x70\xba\x71\x3a\x3b\x06\x6d\xe7\xf2\xb1\xaf\x20\x9c\x13\x4f\xd9\xe9\xa0\x47\x90\x9e\x07\xa4\xa1\x5b\x0d\x88\xdb\x56\x92\xfd\x0d\x74\x87\x70\xad\xf6\x5f\x15\x7b\x05\xd5\xbd\x2a\xda\x32\xdf\x7f\x5f\xef\x3d\x88\x1a\x3f\x94\xba\x7e\x41\xa5\xad\x8d\xf3\xfc\xfc\x4d\x7e\x91\x67\x27\x15\xc0\x10\x7f\xa3\x74\xcf\xda\x92\xf4\xbf\x0e\x1c\x24\x66\x47\xb4\xf9\xc0\x71\xbe\x19\xb5\x5f\x1f\xe2\x99\x91\x26\xd0\x96\xfa\x04\xec\x1a\x4a\x02\xb6\x4e\x2c\xa9\x95\x78\x16\xdc\x44\x3b\x51\x14\x54\x33\xc9\x9b\xdd\xc7\xf7\xb3\x67\x5b\xef\xe8\x38\x2c\xac\x49\xdf\x3f\xfc\x04\xbf\xfc\x9a\x25\xaf\x24\xef\x3b\x1c\x41\xf8\xd7\x00\x90\xf2\x48\x15\x0c\x12\x00\x00"),
+		},
+		"/devops.gostship.io_machinehealthchecks.yaml": &vfsgen۰CompressedFileInfo{
+			name:             "devops.gostship.io_machinehealthchecks.yaml",
+			modTime:          time.Date(2026, 10, 18, 6, 43, 42, 423061004, time.UTC),
+			uncompressedSize: 9444,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xb4\x5a\x5f\x6f\xe3\xb8\x11\x7f\xd7\xa7\xf8\x21\x45\xb1\x2f\xb6\xb2\xdb\x6b\x81\xc2\x6f\x41\x36\xe8\xa6\xb7\x97\x0b\x92\xec\xa2\x45\xd1\x07\x5a\x1a\x5b\x6c\x24\x52\x25\x47\x4e\xdc\xc3\x7d\xf7\x82\xa4\x64\xeb\xbf\x9d\xf4\x9a\x97\x40\xe2\x70\xe6\x37\x7f\x38\x33\x1c\x39\x5a\x2e\x97\x91\x28\xe5\x77\x32\x56\x6a\xb5\x82\x28\x25\xbd\x32\x29\xf7\x64\xe3\xe7\x3f\xdb\x58\xea\xcb\xdd\xa7\x35\xb1\xf8\x14\x3d\x4b\x95\xae\x70\x5d\x59\xd6\xc5\x03\x59\x5d\x99\x84\x3e\xd3\x46\x2a\xc9\x52\xab\xa8\x20\x16\xa9\x60\xb1\x8a\x00\xa1\x94\x66\xe1\x5e\x5b\xf7\x08\x24\x5a\xb1\xd1\x79\x4e\x66\xb9\x25\x15\x3f\x57\x6b\x5a\x57\x32\x4f\xc9\x78\x09\x8d\xfc\xdd\xc7\xf8\x87\xf8\x63\x04\x24\x86\xfc\xf6\x27\x59\x90\x65\x51\x94\x2b\xa8\x2a\xcf\x23\x40\x89\x82\x56\x28\x44\x92\x49\x45\x19\x89\x9c\xb3\x24\xa3\xe4\xd9\xc6\x29\xed\x74\x69\xe3\xad\xb6\x6c\x33\x59\xc6\x52\x47\xb6\xa4\xc4\xe3\x49\x53\x0f\x52\xe4\xf7\x46\x2a\x26\x73\xad\xf3\xaa\x08\xe0\x96\xf8\xeb\xe3\xcf\x77\xf7\x82\xb3\x15\x62\xb7\x21\x4e\xf2\xca\x32\x99\x3b\x51\x50\x04\x00\x29\xd9\xc4\xc8\x92\x3d\xc4\xa7\x8c\x50\x13\x40\x6f\xc0\x19\x35\x60\x6c\x1c\x01\x0d\xc0\xeb\xaf\xdf\x1e\x9f\x6e\x1e\x22\x00\xe0\x7d\x49\x2b\x58\x36\x52\x6d\x07\x02\x59\x70\x65\x63\x7a\x2d\x29\x61\x4a\x7f\xaa\x59\x4d\xc8\xd5\x95\x62\x27\xb5\x91\x08\xaf\x3a\xa5\x6d\xc9\x37\x7f\xbb\xbf\xb9\x7e\xba\xf9\xdc\x12\xed\x54\xde\x92\x99\x90\x9d\x54\xc6\x90\xe2\x2f\xde\x96\xfb\x13\x92\x83\xc5\xf7\xa3\x3a\x7f\xb9\xb9\xfa\xfa\xf4\xe5\xef\xa7\x05\x37\xa1\x12\x0f\xdc\x3c\x94\xfe\xe1\xba\x4f\x03\x69\x21\xc0\x87\x47\x43\xa5\x21\x4b\x8a\xa5\xda\x7a\x7f\x58\x32\x3b\x32\x9e\x02\x2f\x19\xa9\x08\x00\x00\xce\xa4\x85\x5e\xff\x8b\x12\xc6\x8b\xb0\x21\xc6\x28\x8d\xf1\xa1\xa5\xc3\xd5\x5f\x6e\x5a\xf8\x53\xc1\x14\x01\x5b\xa3\xab\x72\x85\x91\x00\x0b\xdb\xea\x20\x0f\x07\xa4\x76\x61\x30\xe7\xb5\xf3\x4f\x04\x00\xb9\xb4\xfc\xe3\x04\xc1\x57\x69\x39\x02\x80\x32\xaf\x8c\xc8\x47\xc3\x3b\x02\x00\x9b\x69\xc3\x77\x47\x89\x4b\x14\x59\x12\x56\xa4\xda\x56\xb9\x30\x63\x7b\x23\xc0\x26\xda\xe9\xe3\xb7\x96\x22\xa1\xd4\xbd\xab\xd6\xa6\x3e\xc6\x35\xbb\x10\x10\x2b\xfc\xf2\x6b\x04\xec\x44\x2e\x53\x6f\xf9\xb0\xa8\x4b\x52\x57\xf7\xb7\xdf\x7f\x78\x4c\x32\x2a\x44\x78\xd9\x73\xd6\x50\x35\x48\xeb\x7d\x12\x36\x61\xa3\x8d\x7f\x1c\x21\xbc\xba\xbf\x8d\x00\x00\x28\x8d\x2e\xc9\xb0\x6c\x60\x01\x40\x2b\x4b\x1d\xde\xf5\x23\xc5\xa1\x0b\x34\x48\x5d\x5e\xa2\x20\xba\xce\x2e\x94\xc2\x06\x10\xfe\xd8\x4a\x7b\x0c\x1c\xaf\x65\x8b\x2d\x1c\x89\x50\x75\xb0\xc4\x78\xf4\x01\x65\x9d\xf9\xab\x3c\x75\xc9\x6c\x47\x86\x61\x28\xd1\x5b\x25\xff\x73\xe0\x6c\xc1\xda\x8b\xcc\x05\x53\xed\xd2\xe6\xcf\xa7\x1d\x25\x72\x67\xd7\x8a\x16\x10\x2a\x45\x21\xf6\x30\xe4\x64\xa0\x52\x2d\x6e\x9e\xc4\xc6\xf8\x49\x1b\x82\x54\x1b\xbd\x42\xc6\x5c\xda\xd5\xe5\xe5\x56\x72\x93\x97\x13\x5d\x14\x95\x92\xbc\xbf\xf4\xd9\x55\xae\x2b\xd6\xc6\x5e\xa6\xb4\xa3\xfc\xd2\xca\xed\x52\x98\x24\x93\x4c\x09\x57\x86\x2e\x45\x29\x97\x1e\xb8\xf2\x69\x39\x2e\xd2\xdf\x1d\xbc\xff\xa1\x85\xb4\x97\xac\x80\x43\x64\x4f\xda\xdd\x85\x75\x38\x94\x61\x5b\xc0\x3f\x3c\x97\x0f\x37\x8f\x4f\x68\x84\x7a\x17\x74\x6d\xee\xad\x7d\xdc\x66\x8f\x86\x77\x86\x92\x6a\x43\xc6\xef\xc2\xc6\xe8\xc2\x73\x24\x95\x96\x5a\x2a\xf6\x0f\x49\x2e\x49\x75\x8d\x6e\xab\x75\x21\xd9\x79\xfa\xdf\x15\x59\x76\xfe\x89\x71\xed\xab\x13\xd6\x84\xaa\x4c\x43\x06\xb8\x55\xb8\x16\x05\xe5\xd7\xc2\xd2\xff\xdd\xec\xce\xc2\x76\xe9\x4c\x7a\xda\xf0\xed\xa2\xda\x25\x0c\xd6\x3a\xbc\x6e\x2a\xdd\xa8\x87\x86\xa7\xed\xb1\xa4\xa4\x73\x48\xfa\x15\xc5\xc7\x67\xa6\x5f\x5a\x2c\xe1\x08\xf7\x10\x86\x60\xa8\xa0\x54\x7a\xdb\xb5\x08\xc6\xce\x2d\x00\xb4\xea\x69\x77\xa1\x07\xf3\xfa\x48\xd7\xa4\x8d\xd9\x4a\x7b\xc2\x76\x00\x90\x91\x30\xbc\x26\xc1\xae\x7a\xe8\x8a\x67\xe5\x7f\xe9\x11\x37\x20\x7c\x21\x69\x89\x77\xef\x2b\xd5\x54\x42\xb1\x61\x5f\xe1\xba\x7f\x21\x0b\x58\x3e\x22\x68\x74\x70\xbd\x4f\x4e\x0c\x6d\xa0\x55\x42\x90\x6c\xa1\x74\xea\xb9\x6e\xb5\xa2\x05\xfe\x54\x40\x6e\x06\x1c\x95\x66\x58\xe2\xb3\x55\x2f\xc4\xeb\xb7\x06\x64\x5f\x6d\xa1\xf6\x3f\x6f\xfa\x2f\x97\x83\xa2\x3d\xb6\x3a\x22\x69\x10\x6d\x47\xc1\x8d\x09\x55\x55\xac\x9d\x1b\x0d\x4a\x32\x89\x4b\xb9\x5b\x72\x06\x39\x9a\xb1\x71\xed\x62\xa0\xf8\x8b\xe4\xcc\x33\xd1\x8a\x2c\x2c\xcb\x3c\xf7\xa9\x77\x47\x0e\x49\x48\x06\xe2\x10\x92\x52\xab\x05\xc4\x5a\xef\x5c\xed\x97\x49\x06\xa5\x07\x1c\x5b\x7e\x3c\x46\xf2\x02\x56\x43\x40\x11\xbf\x68\xf3\x8c\x52\x18\xf6\x3d\x23\x52\x4d\xd6\x5b\x9f\xc5\x33\x21\xd5\x2f\x0a\xc2\xb7\xa2\x43\x8f\x1f\x94\xc0\x1f\x3f\xfe\x1e\x72\x33\xe5\xb4\xd7\xa5\x8b\x02\xa3\x88\xc9\x2e\xa5\xe2\xa5\x36\xcb\x60\xd8\x15\xd8\x54\xd4\x21\x6f\x69\x76\x4e\x14\x3f\x0c\xc8\xe7\xe2\x78\x2b\x77\xa4\xc0\x43\x1b\x71\x65\x14\x3a\x31\xde\x35\x32\xd6\xb4\xd1\x26\xf0\x53\xf4\xca\xd0\x81\xa1\x33\x92\x5a\xe0\xd3\xc7\x62\xc0\x72\xda\x1e\x93\xa1\xd5\x12\x68\xcf\xd5\xda\x42\xd4\xb8\x44\x12\x9e\x3d\x26\x48\x15\x94\xd2\x0a\x42\x1d\x23\x6f\x2a\x3a\x16\x78\x70\x0d\xa6\xe1\x1f\xc3\x81\x75\xcf\x6b\xad\xd9\x67\xc6\x07\x2a\x8d\xde\x49\xdf\x6b\x4c\xab\x25\x99\x8a\x01\xec\x19\x77\xed\x4b\x6f\x42\xa1\x6a\xe4\x35\xf0\x1e\xe0\x06\x60\x3c\xf4\xd9\xf4\x09\x0d\x4b\xc2\x18\xd1\x55\xd8\x52\x4e\x09\x6b\x33\x6b\xdc\xc7\x9a\xa8\xa6\xee\x55\x0c\xbd\xe9\xa4\xea\xf5\x1e\xb9\x58\x53\x3e\x3c\xc8\x22\xcf\x6b\x62\x97\xe0\x40\x45\xc9\xfb\xbe\x12\x53\x35\x24\x38\x86\x93\xec\xe6\xd5\xb5\x07\x76\x2c\x20\x06\xb8\xfb\x1b\xbc\x71\x7d\x27\xee\x70\x78\x94\x07\x03\xf8\x1e\x41\xba\x78\x73\x9d\xc7\x08\x67\xf8\x8b\x50\x9b\xca\xc7\xd9\xd5\xdd\xe7\x6e\x15\x3c\xe1\xfc\x01\xc8\xab\x19\x20\x75\x57\xd5\xac\x70\x26\xd8\x5f\xa5\x85\x54\x76\x94\x33\xea\xde\x71\x01\x81\x67\xda\x87\x36\x53\x28\x38\xa3\x8a\x03\x0b\x43\xbe\x41\x0d\xe5\xc8\x95\x74\x75\xe8\x39\x47\xb9\xce\x39\x05\x00\xe0\xb8\x4c\x2d\xf5\xd4\x75\xf2\xea\x7c\x14\xf4\x76\x2f\x3c\xaa\x70\x75\xab\x55\x15\x65\x99\x4b\xb2\x93\x3c\xe1\x5a\xb9\xc9\xd5\x99\x83\xd0\xfc\x35\x16\x39\x13\x76\x43\xde\x6a\x4f\x83\x89\x3f\xd8\x60\x4e\x17\x5f\x99\x2c\xc1\x1a\x62\x06\xb5\x25\x1f\x7b\xb5\xb5\xf1\xdd\x5d\xb2\x0e\xcc\x43\x44\xdd\xaa\x05\xee\x34\xbb\x7f\x37\xaf\xd2\x3a\x41\x2a\x9d\x61\xf9\x59\x93\xbd\xd3\xec\x69\xff\x27\x93\x04\x50\x67\x1a\x24\x10\x37\xe9\xca\xe5\x15\xa7\x57\xfb\x02\x60\x63\xdc\x6e\x42\xe1\xae\xf5\x9b\x51\x42\x5a\xdc\x2a\x68\xd3\x68\xee\x2f\x6e\x41\x44\x60\x5e\x54\xd6\xf7\xec\x4a\xab\x65\xc8\x1c\x35\xf7\x19\xa6\x8d\x5c\x48\xdb\x98\x52\x9b\x8e\xbd\x26\x04\xcd\xf0\x5c\x53\x9d\xb8\xf0\x94\xc9\x66\x4f\xb8\x4c\xe6\xee\x62\x8d\xb4\xf2\x26\xf0\x97\x21\xc1\xb4\x95\x09\x0a\x32\xdb\x39\x9c\xa5\xcb\x53\xd3\xae\x9b\xc9\x24\x67\xfb\x76\xba\x02\x34\x7f\x75\xda\x49\xc7\x05\x2d\x5d\xac\x4f\xac\xcc\xba\x77\xf4\xb6\x72\x1e\x2a\x9f\xbe\xbf\xba\x24\x31\xaa\x7d\x7b\xa8\x37\x9f\x9f\x4e\xd8\x67\x58\x33\x82\x50\x1f\xdc\x28\x44\x09\xbd\xc1\x2f\x2e\x9d\xfa\x40\xf9\x15\xa5\x90\xc6\xc6\xb8\xf2\x53\x97\x7c\xdc\xb3\x6d\x7a\xa9\xea\x8a\x79\x64\xed\xb8\x4a\x0b\x67\xf3\x9d\xc8\x49\x31\x58\x43\x28\x50\xee\x13\xff\x28\x4b\xbd\x19\x54\xb4\x05\x5e\x32\x6d\x43\x16\xdf\x48\xca\xfd\x3d\xfc\xe2\x99\xf6\x17\x8b\xce\xc9\x83\x1c\x4f\xa5\x17\xb7\xea\x22\x14\x89\xc1\x39\x68\xea\x0c\xb4\xca\xf7\xb8\xf0\x6b\x17\xf1\xa0\x08\x8e\xb2\x9d\x2d\x8c\x33\x11\x31\xb9\x74\x68\x7e\xae\xb5\x0a\x5e\x9f\x6f\x07\xbf\x0d\xe9\x0f\x5d\xa1\xbf\x6c\x25\xc7\xf7\x85\x78\xae\x27\x14\x93\x17\x85\x83\x78\xd7\x04\x8a\x74\x0f\xa5\x19\x4f\xa6\x22\x6f\xba\xcf\xd2\x3e\xdf\x3b\x8f\x54\x86\xc2\x5b\x37\xe6\x7a\xd3\x2d\xee\x9c\x4e\x71\xa8\x53\x08\xd0\xae\x3e\x2d\x75\x1a\xf8\x03\xb6\x2d\x7b\x86\x3b\x28\x67\x6d\x0e\x96\xc5\xde\x36\x41\x1b\x66\x82\x5e\xa3\xfa\x3a\x11\x47\x6f\xeb\x0f\x02\x87\x77\x9d\x4c\x1e\xbf\xef\x9c\xb7\xd7\x2d\xbf\x7d\xe3\x74\x1a\x5c\xd6\x9a\x8c\x2c\xd4\x38\xc7\x56\xf6\x25\x45\x6f\x3e\x01\xfd\x7c\x38\x06\x6a\x89\xfe\x77\x8a\x19\xe6\x43\x1f\x9c\x1a\x10\xf9\x0d\x4d\xa7\x16\x82\xa5\x3f\x85\xe9\x7e\x7d\x98\x0f\x85\xee\x47\x86\xee\x1a\x5c\x74\x15\x82\xfd\xe8\xe1\x87\x3f\x8c\x5a\x64\x6c\x28\xd1\xff\x68\x32\x9b\x11\x6e\x7a\xc4\x87\x09\x53\xf3\x65\xc3\x3d\x98\x4a\x29\x77\x78\x0e\x1a\x86\x7e\x94\x86\xed\x57\x93\x32\xfd\x4c\x62\x4d\x6e\xd3\xe1\x46\x48\x29\xc4\x56\x48\x85\xf5\xbe\x7b\x67\x8e\x7f\x13\xb5\xdd\x58\xc9\xfb\xc8\x9d\xc7\x29\x4b\xba\xc1\xe6\xd2\x85\xe5\xd9\xe3\x22\xb2\x56\x6c\x69\x75\x2e\xbd\x21\x61\xbb\xc3\x78\x0c\xaf\xb6\x8e\x04\xd2\xe2\x49\xeb\x9f\x84\xda\x7f\x1b\x66\x1d\xd3\xbf\xb7\x5b\xd6\x65\x39\x62\xf0\xf5\xbe\x33\x58\x7a\xd7\x04\xe1\x2a\xcf\xf5\x0b\xa5\x27\x40\x0f\xe8\x47\x43\x65\x38\xb9\x1a\xbb\xef\xfa\xed\x27\xa0\xbf\x2f\x0a\x58\x98\x2d\xf1\x7c\xcc\x3f\x05\x9a\x43\xe5\x6b\x90\x62\x23\x64\x1e\x9a\x54\x7f\x84\xa1\x4d\x6b\xa2\x36\xd0\x63\x38\x61\x7b\x4f\xf9\xea\xe4\x98\x80\xac\x69\xb0\xfc\x42\x1f\xd4\x02\xa3\x1d\xe5\xa9\xc9\x9f\x4a\x11\x26\xfe\x47\x37\xbe\xb5\x60\x4d\x0c\x4d\x47\x74\xfa\xd2\x84\x00\x09\x65\xfb\xa3\xb5\x9a\x4d\x9d\x0b\x3c\xb0\xa9\xfb\x6c\x98\xc2\x8d\x4c\xee\xc2\xe0\xed\x30\x4c\x1e\x99\xbf\xc9\xa9\xcb\x8a\xb4\x48\x8d\x3f\x4b\xf1\x4c\x15\x5c\x6b\x9d\x93\x50\x23\x14\x4e\x60\x0b\xd1\x19\xd6\x78\xfb\x28\x6b\x02\xfa\xe4\x80\xeb\x8c\xb2\xdf\x83\x3d\x96\x23\xcf\xcb\x94\x67\x49\xab\x91\xbe\xab\x41\x99\xc8\xb8\x67\xed\x1d\xcf\xbe\xb3\x39\xf8\x25\xdb\x4f\x7e\xc4\x88\xdf\x87\x61\x7a\x36\x7b\x22\xb1\x8e\x17\xdf\x36\x81\x0f\x97\x78\xd6\x71\x63\xf9\xf2\x54\xd6\xec\x75\xbf\x8f\x52\x25\x74\x06\xf6\x6f\x9d\x0d\x73\xf3\x74\x4b\xa4\x4e\xd9\xf5\x37\x88\xbc\xb9\x2e\x75\xaa\xeb\x7f\x73\xd7\x39\xb2\xa1\xf7\xea\xf8\xc3\x9d\x4f\xc7\xa7\xfa\x57\x35\xe1\xf7\x14\x7e\x01\xe1\x27\x19\x69\xeb\xe3\x86\x65\x6d\x5c\xf0\x87\x37\xc7\xf6\x54\x24\x09\x95\x4c\xe9\x5d\xff\x67\x15\x17\x17\x9d\x5f\x48\xf8\xc7\xe3\x25\x6e\x85\x7f\xfc\x33\x0a\x5c\x29\xfd\xde\xe0\x70\x2f\xff\x3b\x00\xb6\xaf\xce\xf9\xe4\x24\x00\x00"),
 		},
 		"/devops.gostship.io_machines.yaml": &vfsgen۰CompressedFileInfo{
 			name:             "devops.gostship.io_machines.yaml",
-			modTime:          time.Date(2020, 6, 1, 9, 32, 7, 480011481, time.UTC),
-			uncompressedSize: 8599,
+			modTime:          time.Date(2026, 10, 18, 8, 7, 56, 979061004, time.UTC),
+			uncompressedSize: 17027,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xbc\x3c\x5f\x73\xdb\xb6\x93\xef\xfa\x14\x3b\xbe\x07\xdf\xcd\x58\x54\xd2\x5e\xa7\x37\x7a\xf3\x39\xe9\xc5\x6d\xe2\x78\x2c\x27\x2f\x37\x37\x1d\x88\x58\x8a\xa8\x41\x80\x05\x40\x3b\x6a\xa7\xdf\xfd\x06\xff\x28\x4a\x22\x48\xca\x76\x7e\x79\x69\x45\x00\xbb\x8b\xfd\x8f\xc5\xc2\xb3\xf9\x7c\x3e\x23\x35\xfb\x8a\x4a\x33\x29\x96\x40\x6a\x86\xdf\x0c\x0a\xfb\x4b\x67\x0f\xff\xa5\x33\x26\x17\x8f\x6f\xd7\x68\xc8\xdb\xd9\x03\x13\x74\x09\x57\x8d\x36\xb2\xba\x43\x2d\x1b\x95\xe3\x3b\x2c\x98\x60\x86\x49\x31\xab\xd0\x10\x4a\x0c\x59\xce\x00\x88\x10\xd2\x10\xfb\x59\xdb\x9f\x00\xb9\x14\x46\x49\xce\x51\xcd\x37\x28\xb2\x87\x66\x8d\xeb\x86\x71\x8a\xca\x61\x88\xf8\x1f\xdf\x64\x3f\x66\x6f\x66\x00\xb9\x42\xb7\xfc\x9e\x55\xa8\x0d\xa9\xea\x25\x88\x86\xf3\x19\x80\x20\x15\x2e\xa1\x22\x79\xc9\x04\xea\x8c\xe2\xa3\xac\x75\xb6\x91\xda\xe8\x92\xd5\x19\x93\x33\x5d\x63\xee\x88\xa0\xd4\x51\x46\xf8\xad\x62\xc2\xa0\xba\x92\xbc\xa9\x3c\x45\x73\xf8\x75\xf5\xf9\xe6\x96\x98\x72\x09\x99\x36\xc4\x34\x3a\xab\x4b\xa2\x71\x06\x00\x40\x51\xe7\x8a\xd5\xc6\xd1\x74\x5f\x22\xe4\xbc\x31\xa8\xc0\xcd\xc8\x66\x00\x91\x8c\xdb\x0f\x97\xab\xf7\x33\x00\x00\xb3\xad\x71\x09\xda\x28\x26\x36\x87\xf0\x23\x67\xb2\xa3\x5d\x1d\x63\x3b\xbf\x3a\x9c\x03\x4c\x03\x01\xd3\xfe\x54\x58\x2b\xd4\x28\x0c\x13\x1b\x30\x25\x82\x46\xf5\x88\xca\xcd\x80\xa7\x12\xc5\x0c\x00\x00\xc0\x94\x4c\x83\x5c\xff\x81\xb9\x81\x27\xa2\x3d\x4b\x91\x66\x70\xde\xd9\xc0\xe5\xff\x74\xc9\xa7\xc4\xe0\x0c\x60\xa3\x64\x53\x2f\xa1\x87\xb5\x7e\x59\x90\xa9\xd7\x87\x4f\x5e\x12\x33\x00\x00\xce\xb4\xf9\xad\xfb\xf5\x23\xd3\x66\x06\x00\x50\xf3\x46\x11\xbe\x93\xdb\x0c\x00\x40\x97\x52\x99\x9b\x1d\xc0\x39\x54\xb9\x1f\x60\x62\xd3\x70\xa2\xda\xf9\x33\x00\x9d\x4b\x4b\xa2\x9b\x5e\x93\x1c\xa9\xfd\xd6\xac\x55\x50\xc4\x00\xc2\x8b\x72\x09\x7f\xff\x33\x03\x78\x24\x9c\x51\xc7\x4c\x3f\x28\x6b\x14\x97\xb7\xd7\x5f\x7f\x5c\xe5\x25\x56\xc4\x7f\x3c\xe0\x7f\x20\x1c\x98\x76\xbc\xf5\x33\xa1\x90\xca\xfd\x8c\xa3\x97\xb7\xd7\x61\x71\xad\x64\x8d\xca\xb0\x48\x00\x00\x40\xc7\xa2\xda\x6f\x87\x62\xb6\x74\xf8\x39\x40\xad\x0d\xa1\xc7\x17\x2c\x01\x29\x68\x8f\x59\x16\x5e\x90\xad\xd4\xdd\x7e\x3a\x60\xc1\x4e\x21\x22\x48\x3a\x83\x95\xd3\x06\x6d\x99\xdb\x70\x6a\x0d\xef\x11\x95\x01\x85\xb9\xdc\x08\xf6\x57\x0b\x59\x83\x91\x0e\x25\x27\x06\x83\x94\xe2\x3f\x67\x2d\x82\x70\xcb\xc1\x06\x2f\x80\x08\x0a\x15\xd9\x82\x42\x8b\x03\x1a\xd1\x81\xe6\xa6\xe8\x0c\x3e\x49\x85\xc0\x44\x21\x97\x50\x1a\x53\xeb\xe5\x62\xb1\x61\x26\xfa\x90\x5c\x56\x55\x23\x98\xd9\x2e\x9c\x27\x60\xeb\xc6\x48\xa5\x17\x14\x1f\x91\x2f\x34\xdb\xcc\x89\xca\x4b\x66\x30\x37\x8d\xc2\x05\xa9\xd9\xdc\x11\x2e\x9c\x0b\xc9\x2a\xfa\x6f\xad\x9c\xcf\x3b\x94\x1e\x18\x1d\x40\xab\x96\x49\xbe\x5b\xf5\xf4\x16\xe5\x97\x79\xfa\x8f\x8d\xea\xee\xfd\xea\x1e\x22\x52\x27\x82\x7d\x9e\x3b\x6e\xef\x96\xe9\x1d\xe3\x2d\xa3\x98\x28\x50\xb9\x55\x50\x28\x59\x39\x88\x28\x68\x2d\x99\x30\xee\x47\xce\x19\x8a\x7d\xa6\xeb\x66\x5d\x31\x63\x25\xfd\x67\x83\xda\x58\xf9\x64\x70\xe5\x3c\x29\xac\x11\x9a\x9a\x7a\xf3\xbd\x16\x70\x45\x2a\xe4\x57\xd6\x17\x7d\x6f\xb6\x5b\x0e\xeb\xb9\x65\xe9\x38\xe3\xbb\x01\x60\x7f\xa2\xe7\x56\xfb\x39\x3a\xe8\x5e\x09\x05\x13\x5b\xd5\x98\x7b\x39\x75\x46\x41\x16\xd1\x23\x64\x9d\xf5\x7d\x36\x08\x00\xd6\x6b\x6b\x83\xca\xba\x8c\xfd\x81\xc4\x06\x62\xa0\x22\x4c\xa0\xba\x6b\x84\x61\xc7\x0b\xf7\x68\xbd\x3a\x98\x1c\xbd\x46\x0b\x04\x54\x18\x70\x66\x8c\x91\xf8\x8b\x03\xa0\x60\x7d\x00\x69\xb8\x69\x8d\x32\x90\x0e\x72\x7f\xa7\x00\x00\x28\x9a\xea\x90\xaa\x39\x50\x99\x3f\xa0\x3a\xfa\xdc\x52\x42\xa7\x32\xa0\x40\x62\x95\xe1\x10\x43\x8a\xc7\x00\x00\x05\xe3\x7d\x9f\x01\x98\xc1\xaa\x77\x60\x18\x1e\x00\x00\x00\xd5\x26\x35\x34\x40\xfe\xee\x9f\x56\xf9\x0b\xd6\x5b\x2b\x64\x0a\x69\x3f\x88\xb9\xa5\x2e\x31\xa2\x55\x3e\x4b\xa3\x3c\x30\x85\xc3\x61\xa2\x14\xd9\x1e\x8d\x96\x52\x3e\xf4\xf2\xa9\x9b\xe2\x0c\xf3\x73\x64\xc3\x83\xc4\xe9\x07\x56\x5f\x49\xe1\x51\x9d\x2a\xe8\x49\x88\xfb\xb6\x9d\x24\xa9\x60\x82\x70\xf6\x17\x2a\x3d\x68\x9c\xbf\xb4\xd3\x9c\x1f\x11\x20\x6b\xf2\x67\x83\x2e\x49\x01\x59\x84\xc0\x05\xa6\x24\x06\xaa\x46\x3b\x27\x8b\x55\x6d\x8e\xd9\x6f\x24\xd4\xa8\x2a\x22\x50\x18\x6e\xa3\x60\x25\x1f\x31\x50\xe6\xfd\xbb\x36\x52\x91\xcd\x91\xa9\x26\xd8\xd2\x4f\xa6\x75\x53\xd1\x81\x08\xf7\xff\xd4\x3a\xe2\x62\x6b\x43\x12\xd9\xed\x1a\x68\x93\xe0\x65\x74\x1a\x9c\x15\x98\x6f\x73\x7e\x44\xcf\xa0\x34\x52\x92\x28\xa5\x36\x2b\xe4\x98\x1b\xa9\x06\x19\xfe\xa1\x33\x11\x72\x4e\x58\xa5\x81\xb8\xe5\xd1\xfd\x31\x17\x5b\xa4\xda\x02\xd1\x5d\x7f\x78\x44\xa6\x14\x39\x02\x33\xc0\x34\x08\x69\x40\xa3\xc9\x4e\xf0\x47\xb9\x6c\x44\xaf\xef\x38\x70\xde\x8d\x30\x3b\x8f\xdd\x88\x96\x4c\x4b\xb2\xf6\x3b\x40\x0a\xeb\x2d\x90\xc8\xda\x1e\x98\x00\x44\x03\x33\x36\xee\xdb\x19\xfa\x02\x48\xdc\x56\x64\x82\x14\x1e\x66\x96\xd4\x7f\x9b\x70\x6d\x7a\xc0\x2b\x92\x3f\x8c\x6e\xe4\x8e\xe4\x0f\xa0\x1d\xe3\x75\x87\xfe\xb0\x19\x0b\x22\x8d\x38\x61\x97\x3a\x21\xef\x23\xdc\xad\xbc\x8f\xf1\xaf\xb7\xc0\xc9\x1a\xf9\x05\x10\xce\x03\x35\x15\xb0\xa2\x07\x24\x78\xc3\xeb\x23\x73\x2c\x4e\x54\xc4\xe4\xe5\xfb\x6f\x36\x0d\xd3\x29\xef\x74\x44\xf5\xe1\x22\xe7\x20\x5a\xc7\xe0\xa8\x6e\x59\x10\x23\x41\xe5\xb2\xbc\x04\x74\x70\xa7\xc4\xee\x4c\x20\x0a\xe1\xf2\xe6\x1d\xd2\xd4\x9a\x01\x9f\x79\x44\xf0\xe5\x00\x51\x21\x9b\x8d\x23\xd6\x9f\x25\x81\xb6\xf9\x8d\x0e\xee\xcf\x6a\xeb\x03\x6e\x7d\x8a\xef\x7c\x24\x2a\x12\xc1\x80\x42\x77\x38\xb0\x92\x1b\x00\xf9\x80\x5b\xb7\x3c\x9c\x04\x92\x33\xc7\x44\xd9\x42\x1b\x1a\x3e\x60\x8c\xc5\x1d\x4c\xd8\x73\xc8\x7e\x70\xb4\xfb\x93\x71\x60\x0a\xa9\x6b\xce\x50\x0f\xc2\x05\x30\x32\x1b\x9c\x31\x21\xe9\x00\x80\x96\x87\x27\x6c\x23\x2e\xe9\x1c\x28\xbc\x60\xce\xb5\x17\x82\xd5\xd2\x92\xd5\xa3\x1b\x70\x9a\xd0\x09\x6f\x19\x7c\xb5\x87\xe0\x16\x81\xd7\xcb\x6b\x71\x01\x37\xd2\xd8\xff\xbc\xff\xc6\xb4\x19\x63\x8c\x95\xee\x3b\x89\xfa\x46\x1a\x37\xff\x55\xd8\xe4\x09\x3c\x81\x49\x7e\x41\x08\xe6\x2e\x4e\xd9\x7d\x76\x8f\x71\x3a\x83\xeb\x62\x44\x5b\xbb\x12\x02\xa6\xe1\x5a\x80\x54\x91\x1b\xee\x08\xee\xd1\x78\x04\x31\x31\x10\x52\xcc\x93\x3e\xaa\xfb\xcf\xe3\xdf\xc3\xe0\x59\x0c\x52\xed\xf1\xb0\x8b\x6c\x8c\xfd\x7b\xa4\x78\x32\xe0\xbe\x64\x91\x48\x5f\x1e\xe0\xb6\x28\x12\x92\x03\x20\x23\x20\xb5\x51\xc4\xe0\x86\xe5\x50\xa1\xda\x20\xd4\xd6\x23\x0e\xef\x6d\xc4\x5f\x9d\x24\xfb\xe1\x74\x37\xfe\x1b\xce\xc1\x01\x00\xe6\xd6\x46\x06\x46\xa3\x18\x46\xce\x01\xc9\xa4\x7c\x0a\xa5\x2e\x98\x7c\xb4\xce\x27\xc9\x9d\xe9\x69\xfa\x44\x1e\x1e\x47\x33\x4f\x80\x33\x0e\xa8\x48\x0d\xb2\x80\xbf\xad\x63\x77\x0a\xf6\x0f\xd4\x84\x29\x9d\xc1\xa5\x2b\xad\xf1\xb4\x7d\x74\xd7\x30\x11\x72\xb4\x1d\x78\x0b\x99\x69\xb0\x72\x79\x24\x1c\x85\x01\x23\x81\x08\x40\xee\x42\x51\x12\xac\x2c\x8e\x62\xee\x05\x3c\x95\x52\xa3\x15\x20\x14\x0c\x39\x05\xa6\xe1\xec\x01\xb7\x67\x17\x7b\x16\x94\x84\x69\xa7\x5f\x8b\x33\x1f\xba\x8e\x0c\xb7\x8d\x73\x52\xf0\x2d\x9c\xb9\xb1\xb3\xec\x28\x4c\xcf\xd2\x36\x37\x12\xbe\x27\x1d\xe7\x7a\x87\x93\x43\x21\x6d\x1c\x2e\x39\xf8\x44\xf4\xa0\x4c\xe9\x73\xcf\x08\xc0\x57\x14\x59\xac\x98\x84\xdc\xf5\xa4\x0c\x5a\xa1\x3b\x7c\x10\xae\xef\xb0\x18\x4f\xa5\xf7\xa6\x47\xa2\x34\xe6\x0a\xdb\xa4\x5a\xeb\xb2\x03\x76\x96\xd0\x92\x6e\x91\x04\x98\xd1\xed\x29\x8d\x3c\x20\xd4\x0a\x73\x0b\x20\x47\x90\xae\xe2\xed\x8e\x14\xdc\xee\x58\x8a\xfe\xcc\xa3\x1e\xb1\x37\xd1\x53\x1b\x9a\x6c\x87\x22\xd6\xa2\x9f\x09\x61\xc8\xbf\xcd\x1d\xf4\xe4\x80\x43\x3b\x3b\x51\x2b\x6d\x5e\x7e\x25\xd8\xb8\x38\xbd\xc2\x5c\x09\x66\xcd\xa8\x60\x9b\x46\xb9\x0c\xc4\x95\xc1\xdb\xca\xd4\x4e\xdd\x72\xc1\x9e\xc1\xfa\x50\xf2\xba\x93\x8d\x79\xbe\x08\x36\x4f\xcf\x5e\xca\xe8\xb3\x97\xda\x33\xd5\x3d\xd9\xbc\x60\xbd\xd8\xe0\x7b\x41\x5f\x06\x60\x65\x88\x32\xcf\x06\xa1\x9b\xb5\xc0\xe7\x2f\x6f\xb4\xc5\x3f\x26\xb9\xd4\xb9\x76\x4c\xf7\xbb\xba\xd1\x3b\x61\xf3\xd4\xfb\x99\xd1\xde\xcf\x91\xdf\xe9\x41\xc7\xcb\xde\x61\xcf\xa7\xde\xa1\xc8\x83\x53\xed\x90\xd5\xcb\x53\x0f\xe4\x7f\x34\x55\x6d\xeb\x2b\x7a\xd4\x78\x7f\x8d\x33\xdb\xd2\x46\x49\x98\x88\xbe\x75\x4d\xb4\x9d\x36\x5c\x7d\x01\x00\x9f\x53\x92\xbc\x44\x1b\x5b\x95\x6c\x36\xa5\x0f\xcb\x05\x53\xda\x80\xf4\xa1\x27\x97\x42\x60\x6e\x90\x02\x65\x0a\x73\xc3\x7b\xd3\xe3\x81\xc4\xb1\x97\x72\x9f\xc4\x04\x4a\x41\x8a\x36\x7c\xd4\xc4\x94\x60\xe4\xae\xc0\xf2\xcc\xca\xf2\x78\x74\x1b\x8f\x71\xee\x2a\x47\x03\x31\x40\x3a\x81\x8e\x1c\x84\xb9\x8b\x24\x6c\x80\x27\x66\x77\x53\xba\x0c\x48\x5b\x5d\x52\xd6\xb1\x5f\x40\x4d\xb4\x7e\x92\x8a\x5e\x40\xad\xd8\x23\x31\xf8\x5b\x38\x5f\xdb\x81\xdb\x52\x11\x8d\x43\x60\x5d\xb1\xa5\xf6\xd9\xa6\xcf\x77\x02\x79\xf6\x5e\x6a\x8d\xa0\x4b\xa2\x90\x5e\x00\x66\x9b\x0c\xd6\xdb\xae\x22\x0c\x9d\x43\xdc\xe6\x5c\x2d\x09\xae\x9d\x84\x42\x76\xd8\x06\xa3\xa8\x61\xf2\x49\xf8\xe8\x60\xca\x88\x6c\x00\x6c\x47\x14\xc7\xa0\x76\x4d\x03\x21\x25\x34\x25\xfa\x13\xec\x00\x44\x7f\x47\x99\xce\xdb\xa6\xd6\x21\x86\x32\x83\x89\x3e\x72\x62\x96\x70\x02\xb4\x29\x27\xa2\x44\xde\x30\x25\x7b\x98\xe0\xbb\x86\x3c\xd8\xe4\x8d\xec\x34\x39\x0d\xa6\x90\xaa\x22\x66\x09\xeb\xad\xc1\x97\xe2\xb2\xe6\xf4\x32\x82\xa5\x32\xe3\xa4\x32\x61\x7e\xfc\x61\x04\x4d\x3a\x22\x02\x40\xc7\xe2\xbf\x3f\x63\xa2\xc7\xf9\x8e\x97\x64\x89\x82\xd5\xdc\xf1\xf3\xb5\x2f\xc9\x78\xf2\x08\xfe\xaf\xb9\x25\x1b\x56\xea\x11\xa9\x0d\x22\x1e\x52\xe1\xe1\x85\x09\xb5\x1d\x53\xd8\x61\x55\x1d\x56\xd2\x97\x6c\xd4\x9e\xd8\x27\xa4\x38\xd7\x85\x6b\x21\x60\x05\x43\xea\x23\x83\x90\x14\xcf\x75\x58\xff\x92\x1c\xe4\x3e\x00\xf3\xbd\x1b\xf7\x16\x1e\x30\x0d\xc4\x98\x90\x0b\x49\x28\xc3\xdd\xd5\x19\x16\x05\xe6\xe6\x6c\x96\x08\x98\x02\x88\xd8\x42\x2d\xa9\xaf\x47\x53\x89\xfe\x32\xcb\x48\x8e\x8a\x18\x74\x40\x1c\x86\xec\x99\x79\x8c\x27\x60\x62\xfe\x72\x17\x6c\xd5\xe7\x04\x7e\x69\x0c\xb4\x8e\x6f\x20\x85\xa5\x56\x8f\xdd\x20\x50\x79\xbc\x0d\x07\x20\xd6\x9a\x3d\x6c\x17\xa7\xe1\x46\xda\x0e\x2a\xda\xf0\xc1\x9c\xe5\x56\x61\x81\x6a\x37\xd7\xa5\x3b\x37\xf2\xfd\x37\xcc\x1b\x83\xd9\x4b\xbc\xdc\xe0\x75\xc2\x00\x83\xdc\x8e\xc0\xdd\x26\x48\x58\x63\xb8\x41\x70\x0a\x40\x9c\x86\xbc\x88\x2a\xdb\x13\x72\x49\x29\xd2\x89\xb4\xdd\xc7\xf9\xdd\x0b\x02\xc7\x78\x56\x21\x10\x03\x4f\x25\xcb\xcb\x9d\x28\x86\xd2\x4e\xa2\xad\x43\x74\x9d\x44\x4e\xb7\x5d\x81\xec\x49\x31\x63\xd0\x9f\xf0\x5b\xc6\x0f\xd8\xd3\xbe\xad\x53\x62\x70\x6e\x49\x79\x09\x4f\x5c\xa5\x67\x2a\x3f\xe2\x46\xfd\x2a\xc8\xa5\x52\xa8\x6b\xdb\xa3\x20\x36\xb1\x87\xa6\x15\x61\xf6\xfd\x22\x9c\xd7\xf5\xd9\x69\xb5\xe9\x17\x04\xb9\xa1\xa0\x3d\xb0\x99\xd4\x36\xe6\xb1\x26\x34\x1b\x0d\xdd\xbd\x41\x7b\xde\x12\x34\xb5\xc6\x59\x93\x46\x27\xba\xb1\xd6\x52\x72\x24\xfb\xbd\x8d\x06\x05\x11\xe6\xfa\xdd\xe4\xfe\x2d\x37\x30\x6d\x72\x1f\x53\xe6\xdd\xa6\xb1\xbd\xef\x16\xc8\x68\x63\x9b\xef\x3e\x1d\x6b\x6d\x73\xb3\xba\x96\xcc\x84\xb7\x24\x26\x05\x90\xb5\x6c\xc2\xed\xa5\x9f\xe7\xda\x3b\xfb\xea\xc4\x53\x5a\xe0\x08\xa5\xb6\xe0\x8e\xc3\xad\x32\x1f\xc3\xcd\x77\x3b\xdb\x9f\xfd\xc9\x9a\x63\x34\xa6\x1e\x9c\x30\xb1\xc3\x25\x6c\xfb\xd2\x03\xdf\x55\xe6\xbb\xbb\x8e\x0d\xb6\x01\xcd\xb9\xee\x4f\xe3\x2c\x80\x6c\x76\x5a\xa4\x0c\xcb\x26\x06\xff\x40\x40\x1a\xd9\xd4\x34\x71\x1c\xdd\xa7\x7d\x54\x6e\xd9\x05\x48\xe1\xce\xbf\xb7\xcd\x9a\xb3\xdc\xde\xcf\xfa\x56\xdc\xeb\x5b\x48\xde\x85\x5c\x8b\x38\xe7\x19\xe4\xa6\x3d\xdc\x3c\x52\xd6\x33\x72\x60\x0d\xa3\x7e\x2d\xe5\xd3\xf2\x64\x5b\xd9\x09\x9a\xd5\xf6\xa6\xed\x74\x8b\xa2\x21\x8c\xeb\x56\xaf\xf2\x46\x29\x14\x66\x87\x6f\xd6\x93\xb1\x85\x56\xeb\x4f\xfd\xaa\x3e\xa6\x67\x9c\x68\x73\xab\xe4\x1a\xef\x59\x35\x45\xfc\x1f\x89\x36\xa1\x69\x1f\x2d\xe8\x35\xd2\x58\xf5\xf0\x24\xf6\x0b\x73\x5a\xcc\x1d\xd1\x50\x4b\xeb\xbd\x22\x42\xb3\xf8\xd4\xe0\x24\x82\xf7\xc8\x04\xd3\x02\x42\xea\xdb\xe1\xa4\x88\xde\x2b\x95\xff\x48\x20\xc2\x95\x73\xbe\xe3\x26\x2b\xd4\x9a\x6c\xa6\xec\xec\x43\x53\x11\x31\x57\x48\xa8\x73\x79\x61\x21\x30\x41\x59\x4e\x5c\x4b\x78\xd4\x27\xef\x9d\x2d\xfb\x52\x3b\x6b\x99\xf1\x2c\xd7\xa1\x90\xe8\xfd\x67\x03\x09\x92\xbf\x08\xf6\x67\xe3\xdd\xc5\xdc\x57\x0b\xdb\xa6\xf0\x00\x64\xa7\xfb\x51\x52\xe7\x29\x71\x70\x27\xd9\x97\x52\x6e\xd4\xc0\x5d\xcf\x5e\xa2\xed\x66\xf6\xb6\xde\x15\x84\x71\xa4\xa0\x1a\xd1\x36\xb0\x95\x44\x50\x9e\xac\x98\x68\x16\x5a\x05\xdd\x1e\x74\x93\xe7\x88\x14\xe9\xb0\x5a\xa5\x0b\x35\x63\x45\x9a\xe3\x08\x9f\xd8\x64\x08\xf2\x61\x8f\xbb\x50\xbe\x6f\xe1\xb6\xbf\x1f\xd6\x08\xf7\xaa\x49\x1e\x90\x7e\x21\x5c\xe3\x05\x7c\x11\x0f\x42\x3e\x89\xef\x19\x90\xee\xb7\x75\x7b\xa3\x6c\x97\x1c\xd3\xfb\xba\xe1\x25\xe1\x22\x5e\x2f\xba\x70\xdb\x14\x4f\xa7\x67\x9b\x21\xf8\x5f\xdb\xe7\x14\x43\xf9\xd2\xca\x57\xfb\x19\xd5\x8b\xa6\x61\x54\x83\x91\xd0\x38\x83\xe4\xdb\xb6\x6d\xb7\x2d\x4c\x9c\x72\xf1\xde\x7d\x8f\xb1\x9c\x4d\xc8\x57\x2e\x3b\x0b\x40\xa1\xcd\xd1\x7d\xcf\x6a\xc4\x7e\x6a\x0d\x66\x2d\x65\x4f\xbe\x7d\x84\xfb\xbf\xa5\x34\x70\xfd\xae\x17\xe5\xc9\xed\xa6\x87\x8f\x2e\x7a\x5e\x4f\x4d\x7b\x7f\x11\x16\xbe\x0e\x55\x0f\xa8\x04\xf2\xa9\xb4\xfc\xe6\x66\xbf\x32\x05\xcd\x1a\x6f\x95\xfc\xb6\x9d\x4c\x44\x5c\xf0\xfa\x74\x70\x34\xa7\x50\xc1\xd1\xbc\x2e\x0d\xd1\x36\xc7\x55\xf3\xfc\x53\x9c\xda\x8f\x19\x7e\x91\x2a\x98\xeb\xf0\x15\xa8\x37\x64\x97\x02\x48\x11\x2f\xbc\xc2\xf9\x30\x3c\xee\x8a\x8d\x4b\xb5\xab\x60\xb9\xea\xd1\x47\x24\x4a\x40\x25\x13\xb7\x54\x2e\x41\xaa\x88\xf8\xf7\x9f\xfe\x23\x62\x9f\x33\xea\x5f\x6f\x2d\x17\x8b\x8a\x88\x9f\x33\xa9\x36\x0b\xce\x44\xf3\xcd\xfe\x9c\xd7\x64\x83\xda\xfe\xdf\x4f\x8b\xdd\x82\xec\xa7\xac\x34\x15\x3f\x3f\x95\x8d\xbe\xad\x8a\x89\xcd\x6a\xab\x0d\x56\x93\x7c\xcc\xe7\xb8\x06\xfc\xa2\x57\xf1\x33\x52\x4f\x10\xe5\xe7\xd5\xf5\xbb\x18\x91\x28\xd3\x46\x49\x60\x14\x64\x01\x0b\x34\xf9\x42\xea\xb9\x42\x8e\xf6\x42\xd4\x5f\x67\xe6\x28\x8c\xec\x4f\x72\xac\xc8\xd7\x8d\x30\x4d\xf6\x0c\x42\xab\x44\x1a\x79\x40\x2b\xb8\x89\xaf\xa3\xee\x52\x4f\xb5\xb6\xcf\xab\x30\xf3\x80\x51\x8f\xf1\x6b\x2f\xc3\x7a\xa0\x82\x67\xe2\xcf\x20\x15\xfc\xf0\x26\x7b\xf3\x9f\x27\x13\xad\x9d\x7a\x7c\xf9\x32\x41\xb2\xab\x76\xea\xab\x1a\xe9\xce\xf4\xf7\x8d\xf2\x7e\xcf\x5a\xc3\xed\x41\xe2\x9d\x96\x84\x3b\xa4\xf0\x81\x98\xf0\x90\x21\xbe\xab\x24\x79\x6e\x2b\x02\x0a\x69\x49\x4c\x96\xcb\x6a\x41\x65\xde\x54\xf1\x4d\xee\x02\xc5\xfc\xcb\x6a\x71\x87\xf4\xf7\x0f\xc4\xfc\xbe\x6a\xd6\xed\x76\x7f\xff\x44\x04\xd9\xb8\x8e\xc3\xc5\xdb\x85\xb5\xdb\xc5\xdd\x87\xd5\xa7\xc5\x06\x8d\x35\xab\xb9\xe7\xdb\xdc\xe6\x12\xce\xaa\x4f\xe3\x7b\xba\x9f\xb0\xff\x00\x74\xf0\xb2\xa0\xb4\x87\x1f\x98\x7e\xf8\x79\x2a\xb7\xbd\x2d\xd6\xd5\xae\x25\xd1\xb9\x4a\xa6\xd3\x89\x63\x72\x37\xee\x85\xfd\x20\xc1\x41\xc2\xb7\x76\xe2\xde\xd3\x69\xb7\xb4\xf3\x42\xd4\x62\xd7\x46\x35\xf9\x71\x13\x69\x12\x7d\xff\xf1\xeb\x80\x61\x6b\xc5\xb0\xe8\x1c\xb7\xa6\x70\xec\x38\x9b\x2d\xb1\x8f\x63\x36\x25\xc6\x89\xdc\xea\x91\xfb\xc1\xa7\xdd\xdf\x55\x78\xbb\xfb\x15\xfe\xfe\x81\xab\x22\xfb\x01\xf0\x7f\x42\x80\x2e\xc1\xa8\x06\xfd\x07\xff\xa0\x2d\x7c\xd9\x9d\x7a\xac\x0d\xd4\x06\xe9\xcd\xe1\x9f\x01\x38\x3b\xdb\x7b\xe7\xef\x7e\x76\x8a\x3b\xf0\xbf\xff\x37\xf3\x50\x91\x7e\x8d\x74\xd8\x8f\xff\x3f\x00\x50\x80\x51\xf4\x83\x42\x00\x00"),
+		},
+		"/devops.gostship.io_racks.yaml": &vfsgen۰CompressedFileInfo{
+			name:             "devops.gostship.io_racks.yaml",
+			modTime:          time.Date(2026, 10, 18, 6, 35, 1, 859061004, time.UTC),
+			uncompressedSize: 3405,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x5a\x4f\x6f\xdb\x3a\x12\xbf\xfb\x53\x0c\xba\x87\xec\x02\xb1\xfd\xba\x0f\xc5\x2e\x7c\xcb\xa6\x2d\x1a\xb4\xe9\x0b\xe2\xb4\x97\xc5\xa2\xa0\xc4\xb1\xc5\x35\x45\xaa\xe4\x30\x89\xdf\xa7\x5f\x0c\x29\xd9\x92\x25\x25\x4e\xd6\xd5\xc9\xa2\xc8\x99\x1f\x67\x7e\xf3\x47\x94\x27\xd3\xe9\x74\x22\x2a\xf5\x1d\x9d\x57\xd6\x2c\x40\x54\x0a\x1f\x09\x0d\xdf\xf9\xd9\xe6\x9f\x7e\xa6\xec\xfc\xfe\x6d\x86\x24\xde\x4e\x36\xca\xc8\x05\x5c\x06\x4f\xb6\xbc\x45\x6f\x83\xcb\xf1\x3d\xae\x94\x51\xa4\xac\x99\x94\x48\x42\x0a\x12\x8b\x09\x80\x30\xc6\x92\xe0\x61\xcf\xb7\x00\xb9\x35\xe4\xac\xd6\xe8\xa6\x6b\x34\xb3\x4d\xc8\x30\x0b\x4a\x4b\x74\x51\x43\xa3\xff\xfe\xb7\xd9\xef\xb3\xdf\x26\x00\xb9\xc3\xb8\xfc\x4e\x95\xe8\x49\x94\xd5\x02\x4c\xd0\x7a\x02\x60\x44\x89\x0b\x28\x45\x5e\x28\x83\x7e\x26\xf1\xde\x56\x7e\xb6\xb6\x9e\x7c\xa1\xaa\x99\xb2\x13\x5f\x61\xce\x5a\xd7\xce\x86\x6a\x01\x03\x33\x92\x94\x1a\x5a\xda\xd6\x75\x12\x18\x47\xb4\xf2\xf4\xb9\x3d\xfa\x45\x79\x8a\x4f\x2a\x1d\x9c\xd0\x7b\xf5\x71\xd0\x2b\xb3\x0e\x5a\xb8\xdd\xf0\x04\xc0\xe7\xb6\xc2\x05\x7c\x65\x35\x95\xc8\x51\x4e\x00\xee\x85\x56\x32\xee\x2a\x29\xb6\x15\x9a\x8b\x9b\xab\xef\xbf\x2f\xf3\x02\x4b\x91\x06\x01\x24\xfa\xdc\xa9\x2a\xce\x6b\x00\x80\xf2\x40\x05\x42\x9a\x09\x2b\xeb\xe2\x6d\xf3\xf4\xe2\xe6\xaa\x5e\x5c\x39\x5b\xa1\x23\xd5\x6c\x8e\xaf\x96\x83\x77\x63\x07\x6a\xce\x18\x47\x9a\x03\x92\x5d\x8a\x49\x5f\xed\x18\x94\xe0\x93\x66\xbb\x02\x2a\x94\x07\x87\x95\x43\x8f\x26\x39\xb9\x25\x16\x78\x8a\x30\x60\xb3\xff\x62\x4e\x33\x58\xa2\x63\x21\xe0\x0b\x1b\xb4\x64\x1e\xdc\xa3\x23\x70\x98\xdb\xb5\x51\x7f\xee\x24\x7b\x20\x1b\x55\x6a\x41\x58\x5b\xbb\xb9\x94\x21\x74\x46\x68\xb6\x60\xc0\x73\x10\x46\x42\x29\xb6\xe0\x90\x75\x40\x30\x2d\x69\x71\x8a\x9f\xc1\xb5\x75\x08\xca\xac\xec\x02\x0a\xa2\xca\x2f\xe6\xf3\xb5\xa2\x86\xd2\xb9\x2d\xcb\x60\x14\x6d\xe7\x91\x98\x2a\x0b\x64\x9d\x9f\x4b\xbc\x47\x3d\xf7\x6a\x3d\x15\x2e\x2f\x14\x61\x4e\xc1\xe1\x5c\x54\x6a\x1a\x81\x9b\xc8\xe8\x59\x29\xff\xe2\x6a\xfe\xfb\xb3\x16\x52\xda\xb2\xcf\x3d\x39\x65\xd6\xbb\xe1\x48\xaf\x51\xbb\x33\xcd\xd8\xb9\xa2\x5e\x96\xf0\xef\xcd\xcb\x43\x6c\x95\xdb\x0f\xcb\x3b\x68\x94\x46\x17\x74\x6d\x1e\xad\xbd\x5f\xe6\xf7\x86\x67\x43\x29\xb3\x42\x97\x1c\xb7\x72\xb6\x8c\x12\xd1\xc8\xca\x2a\x43\xf1\x26\xd7\x0a\x4d\xd7\xe8\x3e\x64\xa5\x22\xf6\xf4\xcf\x80\x9e\xd8\x3f\x33\xb8\x8c\x81\x0d\x19\x42\xa8\xa4\x20\x94\x33\xb8\x32\x70\x29\x4a\xd4\x97\xc2\xe3\x2f\x37\x3b\x5b\xd8\x4f\xd9\xa4\xcf\x1b\xbe\x9d\x8f\xba\x13\x93\xb5\x76\xc3\x4d\xbe\x18\xf4\x50\x1d\x62\xcb\x0a\xf3\xe4\xa7\xd6\x53\xa6\x7a\x1d\xf2\xb3\xd6\xfa\xa1\x18\x8c\x39\x50\x07\x4f\xe8\x38\x27\x74\x1f\x8c\x6c\x80\xaf\x95\x32\x42\xab\x3f\xd1\xf9\xc3\x25\x1d\x94\x1f\x77\xd3\x22\x48\x03\xb6\x12\x3f\x03\xc6\x4c\xc6\x28\x53\x54\x00\x15\x82\xa0\x0c\x3e\x7a\x10\xcb\x8a\xb6\x07\x42\x81\xa3\xb0\x42\x57\x0a\x83\x86\x34\x87\x58\x69\xef\xb1\xe1\x57\x24\x8f\x27\xeb\xc4\xba\xb3\x63\xbe\x14\x61\xd9\x03\x39\x06\x93\x6d\xd0\xe4\x34\x13\x7f\x4b\xf6\xf2\x6a\xcb\x7c\x17\xfb\x5d\x83\x0c\x3d\x93\x74\x8c\x09\x5a\xad\x30\xdf\xe6\xba\x87\xe7\x09\xa3\x36\x8f\x84\x73\xa2\x6b\x01\x55\x1d\xed\x19\x2d\x32\xd4\xbd\x0d\x0b\x29\x63\x35\x14\xfa\x66\x84\x05\x47\x21\x3b\x60\x28\x5f\x95\xf0\xfe\xa6\x70\xc2\xf7\xc8\xb3\xb2\xae\x14\xb4\x80\x6c\x4b\x78\x2c\x7a\x96\xf6\x60\x9d\x3c\x89\x2c\xeb\x68\x4c\x8e\x32\xf4\xfb\xdf\x07\x05\x71\x52\x5f\xa3\xeb\x4a\x72\xea\x5e\x10\x7e\xc6\xed\x29\x70\x91\x50\x86\x9e\x8e\x9b\xab\x55\x8c\x7e\xb5\x52\x28\xcf\x13\x1b\xad\xc4\x33\x5f\xaf\x7d\x0d\xc7\xef\x6a\x21\x29\xdd\xde\xb1\x9c\x18\x93\x44\x22\x2f\x50\x72\x80\x15\x22\x31\xff\x0d\xae\x56\x98\xd3\x1b\x38\xa8\xa0\xe9\x12\x66\x0b\x95\x95\x29\x68\xa5\x45\x0f\x9c\x7a\xc9\x6a\x74\x82\x30\x0a\x88\xd2\xfb\xc4\x1f\x4b\x40\xe9\x4a\x4a\x87\x9e\x1c\xec\xe4\x16\x7f\x06\xe5\x38\xc7\xf3\x9e\xd2\xb2\xd4\x00\x60\xb2\x0f\x58\xc3\x08\x7d\x03\x71\x50\x24\xf4\x61\xc7\xc5\x33\xf8\xce\xfd\x50\x2d\xd7\x83\x70\x08\x5f\x2d\x37\x38\x32\x68\x3c\x87\x1b\x87\x2b\x74\xfb\x91\x11\xe1\xdc\x0b\x7c\xb5\x1f\x1e\x31\x0f\x34\x90\x03\xe0\xe9\x68\xe3\x6b\xd3\x67\xdb\xb3\xc6\x48\xdb\xdf\xe0\x96\xdd\x99\x21\x88\xaa\xd2\x2a\x39\x57\x44\xef\xbf\x0a\x09\xa9\x12\x2f\xa4\xc4\x5e\x54\x0e\xe0\xb9\x6b\xe6\xb6\x8a\x7e\x32\xae\x2a\x11\x04\xc1\x43\xa1\xf2\x62\x6f\xee\x11\xf3\x3d\x08\xcf\x39\x2b\x16\xf2\xc8\x53\x6b\xf4\x16\x1e\x9c\x22\x42\x13\xfb\xcc\x9d\x71\x47\x62\x22\x5d\x4d\x7c\x72\x53\x30\x65\x08\xaf\xd9\x7f\xac\x52\xc7\xec\x7d\xe7\x81\xd4\x2d\xe5\xd6\x39\xf4\x95\x35\x32\xb6\x4b\xb6\xc5\xd0\x0d\x6e\x5f\xe1\x0a\x57\x3b\xbb\x8f\x65\x5a\xf3\x75\xe0\xc1\x06\x07\xaa\xe9\x58\x36\x1f\x2f\x41\x84\x46\x18\xba\x7a\x7f\x74\x21\x8a\x0f\x8e\x9d\x1c\x3c\x77\xd2\x47\x37\x20\x43\x86\x98\xb6\x1b\x99\xce\xb8\xaa\x3a\xb7\x5c\x17\x3a\x03\xac\xa4\x33\xd0\xa0\x79\xb6\x43\x23\x41\xc1\x3f\xdb\xa3\xc5\x59\xed\x78\xe0\x46\x94\x79\xc9\xbd\x9a\xc8\x6c\x48\xcd\x6e\x92\x96\xde\x53\x3a\x66\x78\x41\x2f\x27\xa4\x74\xe8\x7d\x3f\xc3\x76\x80\x7d\xa9\xdb\xaf\xdd\x6c\x70\x28\xf2\x42\x64\x1a\x1b\x9a\x0e\xe8\x84\x23\x2b\x4d\xbd\xed\x8b\x24\x3c\xbe\x62\x0b\x65\xba\xbb\x6e\xde\x14\x6b\x35\x67\x7e\xa8\xd0\x24\x01\x2f\xad\x23\xf5\xb2\x23\xe3\xb5\x06\x30\xae\x0c\x8e\xc8\x8f\x03\x4c\x1f\xb7\x4b\xa3\x2a\x2e\x3b\x07\x6b\x90\x5d\x71\x13\x32\xad\xf2\x73\xf8\xf0\x98\xde\x29\xaf\x6e\xc0\xba\x91\xe4\x78\x65\x9a\x39\x27\xce\x21\x35\xb2\x81\x27\x07\x21\xd2\xd6\xf3\xa2\x2c\x92\x73\x32\x6c\x9d\xbf\xec\xaf\x17\x30\xeb\xb2\x11\xb2\xe7\x96\x44\x12\x4a\xfb\x1d\xaf\xf2\xe0\x1c\x1a\xda\xeb\x1b\x30\x54\x73\x66\x70\x3d\x4c\xf5\xe7\x78\xa6\x85\xa7\x1b\x67\x33\xe4\x92\x77\x84\xfb\xbf\x08\x4f\xa9\x0a\x3e\x20\x8b\xce\xb8\x2e\x33\xd4\x06\xe2\x2f\xac\x60\x8c\xf5\xce\x09\xe3\x55\x73\x78\xf5\x22\xc0\x1d\x98\x40\x3b\x41\x28\xd3\xab\x17\x73\x38\x65\xaf\x11\xc6\x72\xff\x61\x2c\x15\xe8\x7e\xe1\x26\x4b\xf4\x5e\xac\x8f\xd9\xd9\xa7\x50\x0a\x33\x75\x28\x64\x4c\x79\xf5\x42\x50\x46\xaa\x5c\xc4\xb3\x8d\x86\x4f\x29\x3b\xb3\xf9\xc6\x76\xb6\x33\xc6\xab\x52\x87\x43\xe1\xbb\xe7\x5f\x23\x90\xbf\x19\xf5\x33\xa4\x74\x31\xe5\x17\xa4\xf3\xfd\xe9\x46\x2d\x64\xcf\xfd\xc6\x53\x83\x59\x15\x6a\x3a\xfc\xbf\xc8\xfb\xb5\x6f\x04\x79\x5d\xfe\xea\xb7\xea\x7d\x91\xeb\x72\x1f\x2e\x85\xe1\x8e\xf5\xce\x05\x3c\x1f\x81\xfd\x51\x68\x8f\xe7\xf0\xcd\x6c\x8c\x7d\x78\x1d\xea\x23\x53\xf5\xdd\xb6\xda\x9d\x03\xf0\x92\x3e\xde\xd3\x26\xde\x91\xe0\x39\x5d\xde\xd5\x36\xdf\xf4\x55\xa7\xd9\x99\xb5\x1a\x87\x5b\x8e\x2b\xb3\xb2\x4f\x76\x12\x4b\x8c\x8d\x84\x92\x7e\x1e\x82\x92\xf1\xb4\x34\x44\xaa\xea\xed\xee\xf0\x64\xf7\x02\x7b\x68\xb5\xa7\x12\x6c\xfb\xc8\x6d\xc8\x63\xbd\x4a\x7e\xd1\x5a\xc0\xcd\x96\x75\x84\x12\xb2\xbd\xf6\x31\x3a\x8c\x39\x2c\xb3\x76\xa0\xdf\xed\xe9\xfe\x97\xb5\x04\x57\xef\x07\x55\x0e\xd1\xe4\x49\x9d\x75\x41\x43\x77\x1b\x0c\xe7\xc1\x81\x03\xf2\x41\x10\x97\x07\xeb\xa0\x39\x35\x3f\x09\xaa\x0d\x3a\x83\xfa\x58\x2c\x9f\xe3\xec\x13\x23\x08\x19\xde\x38\xfb\xb8\x3d\x1a\x44\xb3\xe0\xf4\x38\x34\xd2\x4b\x50\x68\xa4\xd3\x62\x68\x62\xf3\x79\x6a\x9e\x5d\x37\x53\x87\x35\xc3\x47\xeb\xea\x70\x6d\x7d\x23\xea\x5f\x75\x20\xc7\xe2\x68\x0d\x28\x53\x1f\xce\xa7\xa3\xce\x74\x7e\xaf\x50\xc7\x2f\x06\x55\x3c\x1f\x89\x27\x12\x5f\x50\x38\x03\xa5\x75\xc3\x52\x63\xeb\x50\x0a\xf3\xd7\x77\x7f\x6b\xb4\x4f\x95\x4c\x07\xf4\x8b\xf9\xbc\x14\xe6\x1f\x33\xeb\xd6\x73\xad\x4c\x78\xe4\xdb\x69\x25\xd6\xe8\xf9\xd7\xbb\xf9\x7e\xc1\xec\xdd\xac\xa0\x52\x9f\xbd\xd4\x8c\x9c\x7a\x62\xb1\x5f\x6e\x3d\x61\x79\x54\x8e\xf9\xa3\x59\x03\x69\xd1\x49\xf2\x8c\xf5\x57\xe5\x48\xdf\xd2\x01\xf0\xc7\x12\xe2\xc4\xd3\xb0\xc8\xc7\x0d\x7c\xfb\x76\x04\x8d\x96\xbb\xa9\x27\xa5\xd1\x9e\x9c\x5d\xda\xdc\x75\xf8\x54\x9f\x83\xe6\xc3\x05\xd7\xc2\x2d\x4a\xf8\x24\x08\x0a\xeb\xc9\xef\x3e\xee\x88\x3c\xe7\xb7\x39\x87\xb2\x10\x34\xcb\x6d\x39\x97\x36\x0f\x65\xf3\x61\x70\x8e\x66\xfa\x6d\x39\xbf\x45\xf9\xe3\x93\xa0\x1f\xcb\x90\xed\xb6\xfb\xe3\x5a\x18\xb1\x46\x9e\x3a\x7f\x3b\x67\x66\xcd\x6f\x3f\x2d\xaf\xe7\x6b\x24\x76\xfc\x34\xd9\x6d\xca\xd5\x2e\xf2\xee\x65\x76\x1f\x2d\xdd\x23\xcd\x6b\xc7\x0f\x17\x50\x70\xe3\x0a\xc7\x37\xae\x0f\x45\x74\xd3\x58\x0a\x61\xfb\xc6\x60\x56\x7e\xbc\xb5\x19\x3f\x5f\x2f\x06\x0e\xfd\x87\xde\xd7\x6e\x78\x62\xe7\xfb\x6d\x5c\xda\xfa\x4c\xc5\xda\x3d\xb9\x90\x53\xef\xa5\x77\x54\xfd\x70\xeb\x7c\x60\xb0\xcc\x29\x5c\xb5\x5a\xe5\x63\x2c\xd6\xef\xb7\x5a\xe7\x04\x2d\x8b\x71\xd3\xd6\x8b\xbb\x11\xb8\x03\x7e\x3f\x18\xda\xff\xd7\xe0\xed\xfe\x2e\xf6\x45\xd3\xfa\xbf\x05\xf1\x01\x80\x47\x77\x8f\x72\x01\xe4\x42\x72\x6c\xfd\xe1\xab\x1e\xd9\xf7\xe5\x1c\x03\x15\xa1\xfc\x7a\xf8\x9f\x82\x37\x6f\xe2\x4d\xf3\xa7\x81\x78\xdb\x7a\x31\x87\x7f\xff\x67\x92\xa4\xa2\xfc\xde\xe0\xe0\xc1\xff\x05\x00\x00\xff\xff\xfe\xbd\x07\xd1\x97\x21\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xb4\x57\x5f\x6f\xdb\x36\x10\x7f\xf7\xa7\x38\x74\x0f\x7d\x89\xe5\x24\xdd\x86\x42\x18\x06\x18\x6e\xd1\x65\x5b\xb3\x20\x0e\x3a\x0c\xc3\x1e\x68\xf2\x2c\xdd\x22\x91\x1a\xef\xe8\x2c\x1d\xf6\xdd\x07\x92\x92\xfc\xb7\x6e\x5f\x26\x20\x40\xee\x78\xe4\xfd\xee\x77\x7f\x48\x4f\xa6\xd3\xe9\x44\x75\xf4\x01\x3d\x93\xb3\x25\xa8\x8e\xf0\x6f\x41\x1b\x25\x2e\x1e\x5f\x73\x41\x6e\xb6\xb9\x5a\xa1\xa8\xab\xc9\x23\x59\x53\xc2\x22\xb0\xb8\xf6\x1e\xd9\x05\xaf\xf1\x0d\xae\xc9\x92\x90\xb3\x93\x16\x45\x19\x25\xaa\x9c\x00\x28\x6b\x9d\xa8\xa8\xe6\x28\x02\x68\x67\xc5\xbb\xa6\x41\x3f\xad\xd0\x16\x8f\x61\x85\xab\x40\x8d\x41\x9f\x3c\x0c\xfe\x37\x97\xc5\xab\xe2\x72\x02\xa0\x3d\xa6\xed\x0f\xd4\x22\x8b\x6a\xbb\x12\x6c\x68\x9a\x09\x80\x55\x2d\x96\xe0\x95\x7e\xe4\xc2\xe0\xc6\x75\x5c\x54\x8e\x85\x6b\xea\x0a\x72\x13\xee\x50\x27\x04\xc6\x24\x58\xaa\xb9\xf3\x64\x05\xfd\xc2\x35\xa1\xcd\x70\xa6\xf0\xe3\xf2\x97\xdb\x3b\x25\x75\x09\x45\xdc\x50\x68\x32\x7e\x02\x00\x60\x90\xb5\xa7\x4e\x12\x9a\x87\x1a\x93\x23\x88\xcb\x45\x5a\xcf\xde\x17\x37\x6f\xee\x93\x28\xcf\x1d\x96\xc0\xe2\xc9\x56\x27\x0f\xae\x94\xe0\x93\x7a\x3e\x73\x76\x6f\xb1\x7b\xfc\xbb\xf9\xc3\xdb\x5f\xe7\xbf\x7d\xd6\xc3\xc0\x78\x71\xc4\xd6\xb1\xbf\x97\x8b\x43\x1b\x20\x06\x05\x32\x8a\x1e\x3b\x8f\x8c\x56\xc8\x56\x20\x35\x02\xa3\xdf\xa0\x4f\x16\xf0\x54\xa3\x4d\x87\x02\x48\x4d\x0c\x6e\xf5\x27\x6a\x81\x27\xc5\x39\x55\x68\x0a\x78\xb9\x13\xc2\xfc\xdd\xdb\x1d\xf8\x46\x09\x4e\x00\x2a\xef\x42\x57\xc2\x89\xac\xe5\x6d\x7d\xad\xe4\x3a\xbb\x57\xfa\x31\x89\x0d\xb1\xfc\x34\xaa\x7e\x26\x96\xa4\xee\x9a\xe0\x55\xd3\x57\x42\xd2\x30\xd9\x2a\x34\xca\x67\xdd\x04\x80\xb5\x8b\xde\x17\x4d\x60\x41\x1f\x15\x61\xe5\xfb\xc2\xe5\x12\xfe\xf9\x77\x02\xb0\x51\x0d\x99\x44\x4c\x76\xee\x3a\xb4\xf3\xbb\x9b\x0f\xaf\x96\xba\xc6\x56\x95\x7d\xd0\x7b\x5c\x46\x1c\x40\x9c\x48\xca\x66\xb0\x76\x3e\x89\x69\x69\x7e\x77\xd3\x6f\xeb\xbc\xeb\xd0\x0b\x0d\xa1\xc5\x6f\xa7\xdf\x46\xdd\x61\xb2\x22\x82\x6c\x03\x26\x76\x18\x66\x67\x7d\x9f\xa0\x01\xce\x6e\xdd\x3a\xa7\x63\xcc\x5d\x8a\x64\xe7\x58\x88\x26\xca\xf6\xf9\x2a\x60\x99\x72\xca\xc0\xb5\x0b\x8d\x89\x6d\xb9\x41\x2f\xe0\x51\xbb\xca\xd2\xc7\xf1\x64\x06\x71\xc9\x65\xa3\x04\x7b\xc6\x87\x2f\xb5\x93\x55\x4d\xe4\x2e\xe0\x05\x28\x6b\xa0\x55\xcf\xe0\x31\xfa\x80\x60\x77\x4e\x4b\x26\x5c\xc0\x7b\xe7\x11\xc8\xae\x5d\x09\xb5\x48\xc7\xe5\x6c\x56\x91\x0c\x13\x46\xbb\xb6\x0d\x96\xe4\x79\x96\xe6\x04\xad\x82\x38\xcf\x33\x83\x1b\x6c\x66\x4c\xd5\x54\x79\x5d\x93\xa0\x96\xe0\x71\xa6\x3a\x9a\x26\xe0\x36\x0d\x98\xa2\x35\x5f\x8d\x59\x7d\xb9\x83\xf4\xa0\x75\x00\xc6\xe2\xfa\x24\xef\xb1\xce\x72\x5f\xe4\x6d\x19\xff\x71\x6b\xdc\xbf\x5d\x3e\xc0\xe0\x34\xa5\x60\x9f\xf3\xdc\x1d\xe3\x36\xde\x12\x1f\x89\x22\xbb\x46\x9f\x76\xc1\xda\xbb\x36\x9d\x88\xd6\x74\x8e\xac\x24\x41\x37\x84\x76\x9f\x74\x0e\xab\x96\x84\xc1\xe3\x5f\x01\x59\x62\x7e\x0a\x58\xa4\x39\x0b\x2b\x84\xd0\x99\xdc\x84\x37\x16\x16\xaa\xc5\x66\xa1\x18\xff\x77\xda\x23\xc3\x3c\x8d\x94\x7e\x9e\xf8\xdd\xeb\x61\xdf\x30\xb3\x35\xaa\x87\x09\x7e\x32\x43\xb1\xbf\x96\x1d\xea\xbd\xb6\xb0\x28\x4f\xce\x3f\xa6\x52\x4f\xbd\x7f\x91\xd4\xb5\x63\x49\xc5\xd9\xb9\xf8\xe7\x1a\x3e\x6c\x0c\x19\x86\xb0\xf2\x98\x26\x90\x81\xef\xa2\xfc\xfd\x74\xdc\xdb\xcb\x9d\x33\xc5\xce\xee\x53\x7d\x1d\x3f\xc5\xb7\xa1\x5d\xa1\xdf\xd7\x1e\xc4\x30\x5f\x66\xa3\x61\x84\xcc\x97\x60\xb3\xa2\x87\x24\xae\x8b\xff\x26\x64\xfc\x44\xa2\x6b\x50\x72\x70\x22\x24\xcb\xfe\xf2\xc8\xf1\x6a\xd5\x90\x76\x60\x9d\x41\xde\x8b\xae\x43\xf4\xf0\x44\x52\x03\xc9\x05\x7c\xfb\xf5\x37\x57\xd7\x40\x6b\xb0\xee\xf8\x50\x46\x29\x0e\x94\x6b\xe7\x5b\x25\x25\x90\x95\x57\xd7\x07\x6b\x39\x81\x71\x20\x54\xe8\xf7\xd6\xe2\x95\x79\x96\x85\x78\x87\x02\x1d\x25\x70\x00\x7d\x01\x58\x54\x05\x5c\x5d\x16\xd7\xaf\x8b\xcb\xe2\x72\x76\x7d\x5d\x9c\x74\x7e\x50\x66\xf1\xd3\x79\xe0\x47\x0f\xe7\x21\x6c\xed\x06\x24\xfd\xd6\x04\x7f\x80\xd3\xeb\x18\xc8\x46\xf9\x88\xb3\x08\xf7\x8b\xb1\xf5\x19\x2b\xbf\xd4\xbe\x55\xd1\xf7\xd9\x30\xde\x27\x13\x68\x51\x59\xde\x26\xbd\x76\x8d\xc9\x62\xdb\xaf\x2b\x5d\xc7\x96\x39\x0d\x75\xe5\x5c\x83\x6a\xff\xea\x88\x2d\xfb\x43\xbc\xa5\xcf\xbb\x1f\xac\x52\x17\x45\x87\xca\x18\x8f\xcc\xdb\x2a\x8c\x07\x8d\xd4\xe6\xfa\x24\x7b\xb2\xa0\xf7\xbb\x17\xcd\x96\x7c\x1f\x12\xfb\xe4\xfb\x78\x18\x5c\x52\xb4\x87\xf1\x90\x60\x7b\x84\xf8\x0c\xc3\xc3\x92\xf2\xbe\x7f\xa8\x0d\x5f\xe7\xcc\x6d\x68\xcf\x06\x7f\x97\x4c\x86\xea\x61\xfa\x88\x79\x0a\xc5\x91\xd3\xd3\x00\xab\xc6\x7d\xaa\x40\x4e\x75\x4e\x7c\x76\x91\xc6\xcf\x56\xef\x72\x6b\x37\xfa\xcf\xaa\x54\xbd\xf9\x5e\x8e\x5a\x83\x6b\x15\x1a\x01\xef\x82\x1c\x57\x6f\x9f\xa2\x08\x38\x01\xe5\x2f\x2c\xe5\x78\x11\x91\xc7\xbd\xcb\x74\x0a\xe3\x43\x7a\x50\xec\x3e\x80\x07\x5d\xe6\xf5\xfc\x3d\x70\xa0\xda\xfe\x42\xb8\xda\x4a\xfd\x63\x3e\xbf\x38\xd3\x42\x66\x0f\x4d\x09\xe2\x43\x8e\x95\xc5\x79\x55\x61\xaf\x61\x51\x12\xd2\x3e\xa5\x35\x76\x82\xe6\xf6\xf0\xe1\xf9\xe2\xc5\xde\xfb\x32\x89\xda\xd9\xfc\x73\x82\x4b\xf8\xfd\x8f\x49\x3e\x15\xcd\x87\x01\x47\x54\xfe\x37\x00\x9c\x8f\x61\x4d\x4d\x0d\x00\x00"),
 		},
 	}
 	fs["/"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
+		fs["/_.yaml"].(os.FileInfo),
 		fs["/devops.gostship.io_clustercredentials.yaml"].(os.FileInfo),
 		fs["/devops.gostship.io_clusters.yaml"].(os.FileInfo),
+		fs["/devops.gostship.io_etcdbackups.yaml"].(os.FileInfo),
+		fs["/devops.gostship.io_etcdrestores.yaml"].(os.FileInfo),
+		fs["/devops.gostship.io_globalrolebindings.yaml"].(os.FileInfo),
+		fs["/devops.gostship.io_globalroles.yaml"].(os.FileInfo),
+		fs["/devops.gostship.io_hosts.yaml"].(os.FileInfo),
+		fs["/devops.gostship.io_ipclaims.yaml"].(os.FileInfo),
+		fs["/devops.gostship.io_ippools.yaml"].(os.FileInfo),
+		fs["/devops.gostship.io_kubernetesartifacts.yaml"].(os.FileInfo),
+		fs["/devops.gostship.io_machinehealthchecks.yaml"].(os.FileInfo),
 		fs["/devops.gostship.io_machines.yaml"].(os.FileInfo),
+		fs["/devops.gostship.io_racks.yaml"].(os.FileInfo),
 	}
 
 	return fs
@@ -125,7 +212,7 @@ func (f *vfsgen۰CompressedFile) Read(p []byte) (n int, err error) {
 	}
 	if f.grPos < f.seekPos {
 		// Fast-forward.
-		_, err = io.CopyN(ioutil.Discard, f.gr, f.seekPos-f.grPos)
+		_, err = io.CopyN(io.Discard, f.gr, f.seekPos-f.grPos)
 		if err != nil {
 			return 0, err
 		}
@@ -3,6 +3,7 @@ package apiclient
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -144,3 +145,41 @@ func getPodConditionFromList(conditions []corev1.PodCondition, conditionType cor
 	}
 	return -1, nil
 }
+
+// CheckNodeVersion check the node with the internal ip is ready and the kubelet runs the version
+func CheckNodeVersion(ctx context.Context, client kubernetes.Interface, ip string, version string) (bool, error) {
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return false, err
+	}
+
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if !nodeHasAddress(node, ip) {
+			continue
+		}
+		if node.Status.NodeInfo.KubeletVersion != version {
+			return false, nil
+		}
+		for _, cond := range node.Status.Conditions {
+			if cond.Type == corev1.NodeReady {
+				return cond.Status == corev1.ConditionTrue, nil
+			}
+		}
+		return false, nil
+	}
+
+	return false, fmt.Errorf("can't find node with address %s", ip)
+}
+
+func nodeHasAddress(node *corev1.Node, ip string) bool {
+	if node.Name == ip {
+		return true
+	}
+	for _, addr := range node.Status.Addresses {
+		if addr.Address == ip {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package authutil
+
+import (
+	"context"
+	"errors"
+
+	"k8s.io/klog"
+)
+
+// ErrInvalidCredentials means the username or password is wrong,
+// the next authenticator of a chain is tried.
+var ErrInvalidCredentials = errors.New("invalid username or password")
+
+// ErrUserNotFound means the user is deleted from its provider.
+var ErrUserNotFound = errors.New("user not found")
+
+// User is an authenticated user.
+type User struct {
+	Name   string   `json:"username"`
+	Email  string   `json:"email,omitempty"`
+	Groups []string `json:"groups,omitempty"`
+	// Provider is the name of the authenticator.
+	Provider string `json:"provider,omitempty"`
+}
+
+// Authenticator authenticates a user with the username and password.
+type Authenticator interface {
+	Name() string
+	Authenticate(ctx context.Context, username, password string) (*User, error)
+}
+
+// UserLookup is a authenticator which finds a user without the password.
+type UserLookup interface {
+	Name() string
+	LookupUser(ctx context.Context, username string) (*User, error)
+}
+
+// UserResolver returns the current user of a user from a token.
+type UserResolver interface {
+	Resolve(ctx context.Context, user *User) (*User, error)
+}
+
+// Chain tries the authenticators in order, the first successful one wins.
+type Chain []Authenticator
+
+var _ Authenticator = Chain{}
+
+func (c Chain) Name() string {
+	return "chain"
+}
+
+func (c Chain) Authenticate(ctx context.Context, username, password string) (*User, error) {
+	if username == "" || password == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	for _, a := range c {
+		u, err := a.Authenticate(ctx, username, password)
+		if err == nil {
+			u.Provider = a.Name()
+			return u, nil
+		}
+		if err != ErrInvalidCredentials {
+			// a broken backend must not block the others
+			klog.Warningf("authenticator %s err: %v", a.Name(), err)
+		}
+	}
+
+	return nil, ErrInvalidCredentials
+}
+
+// Resolve looks the user up again in its provider, the users of the other
+// providers, e.g. oidc, are returned as they are and bounded by the session lifetime.
+func (c Chain) Resolve(ctx context.Context, user *User) (*User, error) {
+	for _, a := range c {
+		if a.Name() != user.Provider {
+			continue
+		}
+		l, ok := a.(UserLookup)
+		if !ok {
+			break
+		}
+		u, err := l.LookupUser(ctx, user.Name)
+		if err != nil {
+			return nil, err
+		}
+		u.Provider = a.Name()
+		return u, nil
+	}
+
+	return user, nil
+}
@@ -0,0 +1,160 @@
+package authutil
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	VerbGet    = "get"
+	VerbCreate = "create"
+	VerbUpdate = "update"
+	VerbDelete = "delete"
+
+	// ResourceProfile is the user detail and the ui configs, which every authenticated user can get.
+	ResourceProfile = "profile"
+)
+
+// Attributes is the request to authorize.
+type Attributes struct {
+	User     *User
+	Verb     string
+	Resource string
+	// Cluster is the cluster the route is scoped to, empty for the global routes.
+	Cluster string
+}
+
+func (a *Attributes) String() string {
+	s := fmt.Sprintf("%s %s", a.Verb, a.Resource)
+	if a.Cluster != "" {
+		s += fmt.Sprintf(" of cluster %s", a.Cluster)
+	}
+	return s
+}
+
+// Authorizer decides whether the user can access the resource, the reason tells why not.
+type Authorizer interface {
+	Authorize(ctx context.Context, a *Attributes) (allowed bool, reason string, err error)
+}
+
+// RoleAuthorizer authorizes with the GlobalRoles bound to the user and its groups.
+type RoleAuthorizer struct {
+	Client client.Reader
+	// AdminGroups are allowed everything without any binding, so the first admin
+	// can log in and create the bindings.
+	AdminGroups []string
+}
+
+var _ Authorizer = &RoleAuthorizer{}
+
+// NewRoleAuthorizer returns a authorizer of the roles read by the client.
+func NewRoleAuthorizer(cli client.Reader, adminGroups []string) *RoleAuthorizer {
+	return &RoleAuthorizer{
+		Client:      cli,
+		AdminGroups: adminGroups,
+	}
+}
+
+func (r *RoleAuthorizer) Authorize(ctx context.Context, a *Attributes) (bool, string, error) {
+	if a.User == nil {
+		return false, "the request is not authenticated", nil
+	}
+	for _, g := range a.User.Groups {
+		if containsString(r.AdminGroups, g) {
+			return true, "", nil
+		}
+	}
+	if a.Resource == ResourceProfile && a.Verb == VerbGet {
+		return true, "", nil
+	}
+
+	bindings := &devopsv1.GlobalRoleBindingList{}
+	if err := r.Client.List(ctx, bindings); err != nil {
+		return false, "", fmt.Errorf("list global role bindings err: %v", err)
+	}
+	roles := &devopsv1.GlobalRoleList{}
+	if err := r.Client.List(ctx, roles); err != nil {
+		return false, "", fmt.Errorf("list global roles err: %v", err)
+	}
+	roleMap := make(map[string]*devopsv1.GlobalRole, len(roles.Items))
+	for i := range roles.Items {
+		roleMap[roles.Items[i].Name] = &roles.Items[i]
+	}
+
+	for i := range bindings.Items {
+		b := &bindings.Items[i]
+		if !bindingMatches(b, a) {
+			continue
+		}
+		role, ok := roleMap[b.Spec.RoleRef]
+		if !ok {
+			continue
+		}
+		for _, rule := range role.Spec.Rules {
+			if ruleAllows(&rule, a.Verb, a.Resource) {
+				return true, "", nil
+			}
+		}
+	}
+
+	return false, fmt.Sprintf("user %s cannot %s: no global role binding allows it", a.User.Name, a.String()), nil
+}
+
+func bindingMatches(b *devopsv1.GlobalRoleBinding, a *Attributes) bool {
+	// a binding of clusters never grants the global routes
+	if len(b.Spec.Clusters) > 0 && !containsString(b.Spec.Clusters, a.Cluster) {
+		return false
+	}
+
+	for _, s := range b.Spec.Subjects {
+		switch s.Kind {
+		case devopsv1.SubjectKindUser:
+			// the same username of different providers are different users
+			provider := s.Provider
+			if provider == "" {
+				provider = devopsv1.SubjectProviderLocal
+			}
+			if s.Name == a.User.Name && provider == a.User.Provider {
+				return true
+			}
+		case devopsv1.SubjectKindGroup:
+			if s.Provider != "" && s.Provider != a.User.Provider {
+				continue
+			}
+			if containsString(a.User.Groups, s.Name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func ruleAllows(rule *devopsv1.PolicyRule, verb, resource string) bool {
+	if !containsString(rule.Verbs, "*") && !containsString(rule.Verbs, verb) {
+		return false
+	}
+
+	for _, r := range rule.Resources {
+		if r == "*" || r == resource {
+			return true
+		}
+		// pods/* matches the subresources of pods
+		if strings.HasSuffix(r, "/*") && strings.HasPrefix(resource, strings.TrimSuffix(r, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
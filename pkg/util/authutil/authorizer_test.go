@@ -0,0 +1,181 @@
+package authutil
+
+import (
+	"context"
+	"testing"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRoleAuthorizer(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = devopsv1.AddToScheme(scheme)
+	cli := fake.NewFakeClientWithScheme(scheme,
+		&devopsv1.GlobalRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "viewer"},
+			Spec: devopsv1.GlobalRoleSpec{Rules: []devopsv1.PolicyRule{
+				{Verbs: []string{"get"}, Resources: []string{"clusters", "pods"}},
+			}},
+		},
+		&devopsv1.GlobalRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "developer"},
+			Spec: devopsv1.GlobalRoleSpec{Rules: []devopsv1.PolicyRule{
+				{Verbs: []string{"get"}, Resources: []string{"kubeconfig", "pods/*"}},
+			}},
+		},
+		&devopsv1.GlobalRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "dev-viewer"},
+			Spec: devopsv1.GlobalRoleBindingSpec{
+				RoleRef:  "viewer",
+				Subjects: []devopsv1.Subject{{Kind: devopsv1.SubjectKindGroup, Name: "dev"}},
+			},
+		},
+		&devopsv1.GlobalRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "dev-developer"},
+			Spec: devopsv1.GlobalRoleBindingSpec{
+				RoleRef:  "developer",
+				Subjects: []devopsv1.Subject{{Kind: devopsv1.SubjectKindGroup, Name: "dev"}},
+				Clusters: []string{"dev-cluster"},
+			},
+		},
+		&devopsv1.GlobalRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "bob-missing"},
+			Spec: devopsv1.GlobalRoleBindingSpec{
+				RoleRef:  "missing",
+				Subjects: []devopsv1.Subject{{Kind: devopsv1.SubjectKindUser, Name: "bob"}},
+			},
+		},
+		&devopsv1.GlobalRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "admin-viewer"},
+			Spec: devopsv1.GlobalRoleBindingSpec{
+				RoleRef:  "viewer",
+				Subjects: []devopsv1.Subject{{Kind: devopsv1.SubjectKindUser, Name: "admin"}},
+			},
+		},
+		&devopsv1.GlobalRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "ldap-carol-developer"},
+			Spec: devopsv1.GlobalRoleBindingSpec{
+				RoleRef:  "developer",
+				Subjects: []devopsv1.Subject{{Kind: devopsv1.SubjectKindUser, Name: "carol", Provider: "ldap"}},
+			},
+		},
+		&devopsv1.GlobalRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "oidc-ops-viewer"},
+			Spec: devopsv1.GlobalRoleBindingSpec{
+				RoleRef:  "viewer",
+				Subjects: []devopsv1.Subject{{Kind: devopsv1.SubjectKindGroup, Name: "ops", Provider: "oidc"}},
+			},
+		},
+	)
+	authz := NewRoleAuthorizer(cli, []string{"platform-admin"})
+
+	dev := &User{Name: "alice", Groups: []string{"dev"}, Provider: "local"}
+	tests := []struct {
+		name  string
+		attrs *Attributes
+		want  bool
+	}{
+		{
+			name:  "admin group",
+			attrs: &Attributes{User: &User{Name: "admin", Groups: []string{"platform-admin"}, Provider: "local"}, Verb: VerbDelete, Resource: "clusters", Cluster: "prod"},
+			want:  true,
+		},
+		{
+			name:  "not authenticated",
+			attrs: &Attributes{Verb: VerbGet, Resource: ResourceProfile},
+			want:  false,
+		},
+		{
+			name:  "profile",
+			attrs: &Attributes{User: &User{Name: "bob", Provider: "local"}, Verb: VerbGet, Resource: ResourceProfile},
+			want:  true,
+		},
+		{
+			name:  "missing role",
+			attrs: &Attributes{User: &User{Name: "bob", Provider: "local"}, Verb: VerbGet, Resource: "clusters"},
+			want:  false,
+		},
+		{
+			name:  "global binding",
+			attrs: &Attributes{User: dev, Verb: VerbGet, Resource: "pods", Cluster: "prod"},
+			want:  true,
+		},
+		{
+			name:  "verb not allowed",
+			attrs: &Attributes{User: dev, Verb: VerbCreate, Resource: "clusters"},
+			want:  false,
+		},
+		{
+			name:  "kubeconfig of bound cluster",
+			attrs: &Attributes{User: dev, Verb: VerbGet, Resource: "kubeconfig", Cluster: "dev-cluster"},
+			want:  true,
+		},
+		{
+			name:  "kubeconfig of other cluster",
+			attrs: &Attributes{User: dev, Verb: VerbGet, Resource: "kubeconfig", Cluster: "prod"},
+			want:  false,
+		},
+		{
+			name:  "subresource wildcard",
+			attrs: &Attributes{User: dev, Verb: VerbGet, Resource: "pods/exec", Cluster: "dev-cluster"},
+			want:  true,
+		},
+		{
+			name:  "local user binding",
+			attrs: &Attributes{User: &User{Name: "admin", Provider: "local"}, Verb: VerbGet, Resource: "clusters"},
+			want:  true,
+		},
+		{
+			name:  "local user binding of ldap user",
+			attrs: &Attributes{User: &User{Name: "admin", Provider: "ldap"}, Verb: VerbGet, Resource: "clusters"},
+			want:  false,
+		},
+		{
+			name:  "local user binding of oidc user",
+			attrs: &Attributes{User: &User{Name: "admin", Provider: "oidc"}, Verb: VerbGet, Resource: "clusters"},
+			want:  false,
+		},
+		{
+			name:  "ldap user binding",
+			attrs: &Attributes{User: &User{Name: "carol", Provider: "ldap"}, Verb: VerbGet, Resource: "kubeconfig", Cluster: "prod"},
+			want:  true,
+		},
+		{
+			name:  "ldap user binding of local user",
+			attrs: &Attributes{User: &User{Name: "carol", Provider: "local"}, Verb: VerbGet, Resource: "kubeconfig", Cluster: "prod"},
+			want:  false,
+		},
+		{
+			name:  "group binding of provider",
+			attrs: &Attributes{User: &User{Name: "dave", Groups: []string{"ops"}, Provider: "oidc"}, Verb: VerbGet, Resource: "pods"},
+			want:  true,
+		},
+		{
+			name:  "group binding of other provider",
+			attrs: &Attributes{User: &User{Name: "dave", Groups: []string{"ops"}, Provider: "ldap"}, Verb: VerbGet, Resource: "pods"},
+			want:  false,
+		},
+		{
+			name:  "group binding of any provider",
+			attrs: &Attributes{User: &User{Name: "erin", Groups: []string{"dev"}, Provider: "ldap"}, Verb: VerbGet, Resource: "pods"},
+			want:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reason, err := authz.Authorize(context.TODO(), tt.attrs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Authorize() = %v, want %v, reason: %s", got, tt.want, reason)
+			}
+			if !got && reason == "" {
+				t.Errorf("Authorize() denied without a reason")
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package authutil
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
+)
+
+const (
+	staticKeyID = "static"
+	// keySize is the size of the generated HS256 keys
+	keySize          = 32
+	keySyncPeriod    = time.Minute
+	minStaticKeySize = 16
+)
+
+type signingKey struct {
+	ID      string
+	Secret  []byte
+	Created time.Time
+}
+
+// KeySet holds the JWT signing keys. The newest key signs the new tokens, all the keys
+// verify the tokens, so a rotated key keeps working until the tokens signed by it expire.
+//
+// The keys come from a static secret or a Kubernetes secret, each entry of the secret is
+// a key named with its unix creation time. With the rotation period set, a new key is
+// added to the secret when the newest one is older than the period, and the keys older
+// than the period plus the retention are removed.
+type KeySet struct {
+	KubeCli        kubernetes.Interface
+	Namespace      string
+	Name           string
+	RotationPeriod time.Duration
+	Retention      time.Duration
+
+	mu   sync.RWMutex
+	keys []signingKey
+	now  func() time.Time
+}
+
+// NewStaticKeySet returns a key set of one fixed key.
+func NewStaticKeySet(secret string) (*KeySet, error) {
+	if len(secret) < minStaticKeySize {
+		return nil, fmt.Errorf("jwt secret must be at least %d bytes", minStaticKeySize)
+	}
+
+	return &KeySet{
+		keys: []signingKey{{ID: staticKeyID, Secret: []byte(secret)}},
+		now:  time.Now,
+	}, nil
+}
+
+// NewSecretKeySet returns a key set of the secret, the secret is created if not found.
+func NewSecretKeySet(kubeCli kubernetes.Interface, namespace, name string, rotation, retention time.Duration) *KeySet {
+	return &KeySet{
+		KubeCli:        kubeCli,
+		Namespace:      namespace,
+		Name:           name,
+		RotationPeriod: rotation,
+		Retention:      retention,
+		now:            time.Now,
+	}
+}
+
+// SigningKey returns the newest key.
+func (k *KeySet) SigningKey() (string, []byte, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	if len(k.keys) == 0 {
+		return "", nil, fmt.Errorf("no jwt signing key")
+	}
+	key := k.keys[len(k.keys)-1]
+	return key.ID, key.Secret, nil
+}
+
+// VerificationKey returns the key of the id.
+func (k *KeySet) VerificationKey(id string) ([]byte, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	for _, key := range k.keys {
+		if key.ID == id {
+			return key.Secret, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown jwt key %q", id)
+}
+
+// Start syncs the keys with the secret periodically, it implements the manager.Runnable.
+func (k *KeySet) Start(stop <-chan struct{}) error {
+	if k.KubeCli == nil {
+		return nil
+	}
+
+	wait.Until(func() {
+		if err := k.Sync(context.Background()); err != nil {
+			klog.Errorf("sync jwt keys err: %v", err)
+		}
+	}, keySyncPeriod, stop)
+	return nil
+}
+
+// Sync loads the keys from the secret, and rotates them if needed.
+func (k *KeySet) Sync(ctx context.Context) error {
+	if k.KubeCli == nil {
+		return nil
+	}
+
+	secrets := k.KubeCli.CoreV1().Secrets(k.Namespace)
+	secret, err := secrets.Get(ctx, k.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: k.Name, Namespace: k.Namespace},
+			Type:       corev1.SecretTypeOpaque,
+		}
+		if err := k.rotate(secret); err != nil {
+			return err
+		}
+		secret, err = secrets.Create(ctx, secret, metav1.CreateOptions{})
+		if apierrors.IsAlreadyExists(err) {
+			secret, err = secrets.Get(ctx, k.Name, metav1.GetOptions{})
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("get jwt keys secret %s/%s err: %v", k.Namespace, k.Name, err)
+	}
+
+	keys := parseKeys(secret.Data)
+	if k.needRotate(keys) {
+		klog.Infof("rotate jwt keys of secret %s/%s", k.Namespace, k.Name)
+		if err := k.rotate(secret); err != nil {
+			return err
+		}
+		// a conflict means another replica rotated, the keys are loaded in the next sync
+		updated, err := secrets.Update(ctx, secret, metav1.UpdateOptions{})
+		if err != nil {
+			klog.Warningf("update jwt keys secret %s/%s err: %v", k.Namespace, k.Name, err)
+		} else {
+			keys = parseKeys(updated.Data)
+		}
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("jwt keys secret %s/%s is empty", k.Namespace, k.Name)
+	}
+
+	k.mu.Lock()
+	k.keys = keys
+	k.mu.Unlock()
+	return nil
+}
+
+func (k *KeySet) needRotate(keys []signingKey) bool {
+	if len(keys) == 0 {
+		return true
+	}
+	if k.RotationPeriod <= 0 {
+		return false
+	}
+	newest := keys[len(keys)-1]
+	return !newest.Created.IsZero() && k.now().Sub(newest.Created) >= k.RotationPeriod
+}
+
+// rotate adds a new key to the secret and removes the expired ones.
+func (k *KeySet) rotate(secret *corev1.Secret) error {
+	now := k.now()
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+
+	if k.RotationPeriod > 0 {
+		for _, key := range parseKeys(secret.Data) {
+			if !key.Created.IsZero() && now.Sub(key.Created) > k.RotationPeriod+k.Retention {
+				delete(secret.Data, key.ID)
+			}
+		}
+	}
+
+	data := make([]byte, keySize)
+	if _, err := rand.Read(data); err != nil {
+		return err
+	}
+	secret.Data[strconv.FormatInt(now.Unix(), 10)] = data
+	return nil
+}
+
+// parseKeys returns the keys sorted from the oldest to the newest,
+// the keys not named with a timestamp are the oldest.
+func parseKeys(data map[string][]byte) []signingKey {
+	keys := make([]signingKey, 0, len(data))
+	for id, secret := range data {
+		if len(secret) == 0 {
+			continue
+		}
+		key := signingKey{ID: id, Secret: secret}
+		if ts, err := strconv.ParseInt(id, 10, 64); err == nil {
+			key.Created = time.Unix(ts, 0)
+		}
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if !keys[i].Created.Equal(keys[j].Created) {
+			return keys[i].Created.Before(keys[j].Created)
+		}
+		return keys[i].ID < keys[j].ID
+	})
+	return keys
+}
@@ -0,0 +1,198 @@
+package authutil
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	ldapDefaultUserAttribute  = "uid"
+	ldapDefaultMailAttribute  = "mail"
+	ldapDefaultGroupAttribute = "memberOf"
+	ldapTimeout               = 10 * time.Second
+)
+
+// LDAPConfig is the config of the LDAP authenticator.
+type LDAPConfig struct {
+	// URL is ldap://host:389 or ldaps://host:636.
+	URL string
+	// StartTLS upgrades the ldap:// connection to tls before binding.
+	StartTLS           bool
+	InsecureSkipVerify bool
+	// BindDN and BindPassword are the service account to search the user, anonymous if empty.
+	BindDN       string
+	BindPassword string
+	// BaseDN is where the users are searched.
+	BaseDN string
+	// UserAttribute is matched with the username, defaults to uid.
+	UserAttribute string
+	// ObjectClass restricts the object class of the users, optional.
+	ObjectClass    string
+	MailAttribute  string
+	GroupAttribute string
+}
+
+// LDAPAuthenticator searches the user with the service account then binds as the user
+// to check the password.
+type LDAPAuthenticator struct {
+	LDAPConfig
+}
+
+var _ Authenticator = &LDAPAuthenticator{}
+var _ UserLookup = &LDAPAuthenticator{}
+
+// NewLDAPAuthenticator returns a LDAP authenticator of the config.
+func NewLDAPAuthenticator(cfg LDAPConfig) (*LDAPAuthenticator, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil || (u.Scheme != "ldap" && u.Scheme != "ldaps") || u.Host == "" {
+		return nil, fmt.Errorf("invalid ldap url %q", cfg.URL)
+	}
+	if cfg.BaseDN == "" {
+		return nil, fmt.Errorf("ldap base dn is empty")
+	}
+	if cfg.UserAttribute == "" {
+		cfg.UserAttribute = ldapDefaultUserAttribute
+	}
+	if cfg.MailAttribute == "" {
+		cfg.MailAttribute = ldapDefaultMailAttribute
+	}
+	if cfg.GroupAttribute == "" {
+		cfg.GroupAttribute = ldapDefaultGroupAttribute
+	}
+
+	return &LDAPAuthenticator{LDAPConfig: cfg}, nil
+}
+
+func (a *LDAPAuthenticator) Name() string {
+	return "ldap"
+}
+
+func (a *LDAPAuthenticator) Authenticate(ctx context.Context, username, password string) (*User, error) {
+	// an empty password is an unauthenticated bind, which succeeds on most servers
+	if username == "" || password == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	conn, err := a.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	entry, err := a.search(conn, username)
+	if err == ErrUserNotFound {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = conn.Bind(entry.DN, password)
+	if err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("ldap bind user %s err: %v", username, err)
+	}
+	return a.user(username, entry), nil
+}
+
+// LookupUser searches the user with the service account and returns the current groups.
+func (a *LDAPAuthenticator) LookupUser(ctx context.Context, username string) (*User, error) {
+	conn, err := a.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	entry, err := a.search(conn, username)
+	if err != nil {
+		return nil, err
+	}
+	return a.user(username, entry), nil
+}
+
+// search binds as the service account and finds the single entry of the username.
+func (a *LDAPAuthenticator) search(conn *ldap.Conn, username string) (*ldap.Entry, error) {
+	if a.BindDN != "" {
+		err := conn.Bind(a.BindDN, a.BindPassword)
+		if err != nil {
+			return nil, fmt.Errorf("ldap service account bind err: %v", err)
+		}
+	}
+
+	filter := fmt.Sprintf("(%s=%s)", ldap.EscapeFilter(a.UserAttribute), ldap.EscapeFilter(username))
+	if a.ObjectClass != "" {
+		filter = fmt.Sprintf("(&(objectClass=%s)%s)", ldap.EscapeFilter(a.ObjectClass), filter)
+	}
+	res, err := conn.Search(ldap.NewSearchRequest(a.BaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+		2, int(ldapTimeout.Seconds()), false, filter, []string{a.MailAttribute, a.GroupAttribute}, nil))
+	if err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		return nil, fmt.Errorf("ldap search user %s err: %v", username, err)
+	}
+	if res == nil || len(res.Entries) != 1 {
+		return nil, ErrUserNotFound
+	}
+	return res.Entries[0], nil
+}
+
+func (a *LDAPAuthenticator) user(username string, entry *ldap.Entry) *User {
+	user := &User{Name: username, Email: entry.GetEqualFoldAttributeValue(a.MailAttribute)}
+	for _, g := range entry.GetEqualFoldAttributeValues(a.GroupAttribute) {
+		user.Groups = append(user.Groups, groupName(g))
+	}
+	return user
+}
+
+func (a *LDAPAuthenticator) dial(ctx context.Context) (*ldap.Conn, error) {
+	u, _ := url.Parse(a.URL)
+	host := u.Host
+	if u.Port() == "" {
+		if u.Scheme == "ldaps" {
+			host = net.JoinHostPort(u.Hostname(), ldap.DefaultLdapsPort)
+		} else {
+			host = net.JoinHostPort(u.Hostname(), ldap.DefaultLdapPort)
+		}
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName:         u.Hostname(),
+		InsecureSkipVerify: a.InsecureSkipVerify,
+	}
+	dialer := &net.Dialer{Timeout: ldapTimeout}
+	if d, ok := ctx.Deadline(); ok {
+		dialer.Deadline = d
+	}
+
+	// the tls config is only used by ldaps://
+	conn, err := ldap.DialURL(u.Scheme+"://"+host, ldap.DialWithDialer(dialer), ldap.DialWithTLSConfig(tlsConfig))
+	if err != nil {
+		return nil, fmt.Errorf("dial ldap %s err: %v", host, err)
+	}
+	conn.SetTimeout(ldapTimeout)
+
+	if a.StartTLS && u.Scheme == "ldap" {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("ldap %s start tls err: %v", host, err)
+		}
+	}
+	return conn, nil
+}
+
+// groupName returns the cn of a group dn, or the value itself.
+func groupName(dn string) string {
+	first := strings.SplitN(dn, ",", 2)[0]
+	kv := strings.SplitN(first, "=", 2)
+	if len(kv) == 2 && strings.EqualFold(strings.TrimSpace(kv[0]), "cn") {
+		return strings.TrimSpace(kv[1])
+	}
+	return dn
+}
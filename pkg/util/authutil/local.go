@@ -0,0 +1,127 @@
+package authutil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"golang.org/x/crypto/bcrypt"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
+)
+
+// LocalUser is a user entry of the users secret, the key of the entry is the username.
+//
+//	admin: {"passwordHash": "$2a$10$...", "email": "admin@gostship.io", "groups": ["platform-admin"]}
+//
+// The hash can be generated with `htpasswd -bnBC 10 "" <password> | tr -d ':\n'`.
+type LocalUser struct {
+	PasswordHash string   `json:"passwordHash"`
+	Email        string   `json:"email,omitempty"`
+	Groups       []string `json:"groups,omitempty"`
+}
+
+// SecretUserStore authenticates the users saved in a Kubernetes secret with bcrypt hashes.
+type SecretUserStore struct {
+	KubeCli    kubernetes.Interface
+	Namespace  string
+	SecretName string
+}
+
+var _ Authenticator = &SecretUserStore{}
+var _ UserLookup = &SecretUserStore{}
+
+// NewSecretUserStore returns a store of the secret.
+func NewSecretUserStore(kubeCli kubernetes.Interface, namespace, name string) *SecretUserStore {
+	return &SecretUserStore{
+		KubeCli:    kubeCli,
+		Namespace:  namespace,
+		SecretName: name,
+	}
+}
+
+func (s *SecretUserStore) Name() string {
+	return "local"
+}
+
+func (s *SecretUserStore) Authenticate(ctx context.Context, username, password string) (*User, error) {
+	users, err := s.users(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	u, ok := users[username]
+	if !ok {
+		// compare anyway, so the response time does not tell whether the user exists
+		bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return nil, ErrInvalidCredentials
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	return &User{Name: username, Email: u.Email, Groups: u.Groups}, nil
+}
+
+// LookupUser returns the user with the current email and groups.
+func (s *SecretUserStore) LookupUser(ctx context.Context, username string) (*User, error) {
+	users, err := s.users(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	u, ok := users[username]
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+	return &User{Name: username, Email: u.Email, Groups: u.Groups}, nil
+}
+
+// List returns the users of the store sorted by name.
+func (s *SecretUserStore) List(ctx context.Context) ([]User, error) {
+	users, err := s.users(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]User, 0, len(users))
+	for name, u := range users {
+		result = append(result, User{Name: name, Email: u.Email, Groups: u.Groups, Provider: s.Name()})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+func (s *SecretUserStore) users(ctx context.Context) (map[string]*LocalUser, error) {
+	secret, err := s.KubeCli.CoreV1().Secrets(s.Namespace).Get(ctx, s.SecretName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get users secret %s/%s err: %v", s.Namespace, s.SecretName, err)
+	}
+
+	// a broken entry must not lock out the other users
+	users := make(map[string]*LocalUser, len(secret.Data))
+	for name, data := range secret.Data {
+		u := &LocalUser{}
+		if err := json.Unmarshal(data, u); err != nil {
+			klog.Warningf("users secret %s/%s skips the invalid user %s: %v", s.Namespace, s.SecretName, name, err)
+			continue
+		}
+		if _, err := bcrypt.Cost([]byte(u.PasswordHash)); err != nil {
+			klog.Warningf("users secret %s/%s skips the user %s with a invalid password hash: %v", s.Namespace, s.SecretName, name, err)
+			continue
+		}
+		users[name] = u
+	}
+	return users, nil
+}
+
+// dummyHash is the bcrypt hash of a random string.
+var dummyHash = []byte("$2a$10$7EqJtq98hPqEX7fNZaFWoOhi5BWX4Z0Ga7bJUDSyDHUmwyhIQ1eSe")
@@ -1,58 +1,196 @@
 package authutil
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/dgrijalva/jwt-go"
 	"github.com/gostship/kunkka/pkg/apimanager/model/auth"
 	"k8s.io/klog"
-	"time"
 )
 
 const DefaultIssuerName = "kunkka"
 
-var (
-	Password = "P@88w0rd"
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+
+	DefaultAccessTokenTTL  = 2 * time.Hour
+	DefaultRefreshTokenTTL = 7 * 24 * time.Hour
+	// DefaultSessionTTL is the lifetime of a login, the tokens are not refreshed after it.
+	DefaultSessionTTL = 7 * 24 * time.Hour
 )
 
+// ErrInvalidToken means the token is malformed, expired or signed by a unknown key.
+var ErrInvalidToken = errors.New("invalid token")
+
+// ErrSessionExpired means the login is older than the session lifetime.
+var ErrSessionExpired = errors.New("session expired, please login again")
+
 type Claims struct {
-	Username string `json:"username"`
-	UID      string `json:"uid"`
-	// Currently, we are not using any field in jwt.StandardClaims
+	Username string   `json:"username"`
+	UID      string   `json:"uid"`
+	Email    string   `json:"email,omitempty"`
+	Groups   []string `json:"groups,omitempty"`
+	Provider string   `json:"provider,omitempty"`
+	// TokenType is access or refresh, a refresh token is never accepted as an access token.
+	TokenType string `json:"token_type"`
+	// AuthTime is when the user logged in, kept by the refreshed tokens.
+	AuthTime int64 `json:"auth_time,omitempty"`
 	jwt.StandardClaims
 }
 
-func IssueTo(username string) (*auth.Token, error) {
-	clm := &Claims{
-		Username: username,
-		UID:      "0",
-		StandardClaims: jwt.StandardClaims{
-			IssuedAt:  time.Now().Unix(),
-			Issuer:    DefaultIssuerName,
-			NotBefore: time.Now().Unix(),
-		},
+// User returns the user of the claims.
+func (c *Claims) User() *User {
+	return &User{Name: c.Username, Email: c.Email, Groups: c.Groups, Provider: c.Provider}
+}
+
+// TokenIssuer issues and verifies the HS256 tokens signed by the key set.
+type TokenIssuer struct {
+	Keys            *KeySet
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	// SessionTTL bounds the refreshes of a login, the user logs in again after it.
+	SessionTTL time.Duration
+	// Users resolves the user again on refresh, so a deleted user or a removed
+	// group is not kept by refreshing the tokens, optional.
+	Users UserResolver
+
+	now func() time.Time
+}
+
+// NewTokenIssuer returns a issuer of the keys, zero ttl means the default.
+func NewTokenIssuer(keys *KeySet, accessTTL, refreshTTL time.Duration) *TokenIssuer {
+	if accessTTL <= 0 {
+		accessTTL = DefaultAccessTokenTTL
+	}
+	if refreshTTL <= 0 {
+		refreshTTL = DefaultRefreshTokenTTL
 	}
-	JwtSecret := DefaultIssuerName
-	ExpiresAt := clm.IssuedAt + int64(6*time.Hour)
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, clm)
+	return &TokenIssuer{
+		Keys:            keys,
+		AccessTokenTTL:  accessTTL,
+		RefreshTokenTTL: refreshTTL,
+		SessionTTL:      DefaultSessionTTL,
+		now:             time.Now,
+	}
+}
+
+// IssueTo issues a access token and a refresh token to the user who just logged in.
+func (i *TokenIssuer) IssueTo(user *User) (*auth.Token, error) {
+	return i.issue(user, i.now())
+}
 
-	tokenString, err := token.SignedString([]byte(JwtSecret))
+func (i *TokenIssuer) issue(user *User, authTime time.Time) (*auth.Token, error) {
+	accessToken, err := i.sign(user, TokenTypeAccess, i.AccessTokenTTL, authTime)
+	if err != nil {
+		klog.Error(err)
+		return nil, err
+	}
+	// the refresh token never outlives the session
+	refreshTTL := i.RefreshTokenTTL
+	if i.SessionTTL > 0 {
+		if left := authTime.Add(i.SessionTTL).Sub(i.now()); left < refreshTTL {
+			refreshTTL = left
+		}
+	}
+	refreshToken, err := i.sign(user, TokenTypeRefresh, refreshTTL, authTime)
 	if err != nil {
 		klog.Error(err)
 		return nil, err
 	}
 
 	result := &auth.Token{
-		AccessToken: tokenString,
-		TokenType:   "Bearer",
-		ExpiresIn:   int(ExpiresAt),
+		AccessToken:  accessToken,
+		TokenType:    "Bearer",
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(i.AccessTokenTTL / time.Second),
 	}
 
 	return result, nil
 }
 
-func Authenticate(password string) bool {
-	if password == Password {
-		return true
+// Refresh issues a new token pair with a valid refresh token, the user and
+// the groups are resolved again and the session lifetime is kept.
+func (i *TokenIssuer) Refresh(ctx context.Context, refreshToken string) (*auth.Token, error) {
+	clm, err := i.Verify(refreshToken, TokenTypeRefresh)
+	if err != nil {
+		return nil, err
+	}
+
+	authTime := time.Unix(clm.AuthTime, 0)
+	if clm.AuthTime == 0 {
+		authTime = time.Unix(clm.IssuedAt, 0)
+	}
+	if i.SessionTTL > 0 && !i.now().Before(authTime.Add(i.SessionTTL)) {
+		return nil, ErrSessionExpired
+	}
+
+	user := clm.User()
+	if i.Users != nil {
+		user, err = i.Users.Resolve(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return i.issue(user, authTime)
+}
+
+// Verify parses the token and checks the signature, expiry and type.
+func (i *TokenIssuer) Verify(tokenString string, tokenType string) (*Claims, error) {
+	clm := &Claims{}
+	parser := &jwt.Parser{ValidMethods: []string{jwt.SigningMethodHS256.Name}}
+	token, err := parser.ParseWithClaims(tokenString, clm, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return i.Keys.VerificationKey(kid)
+	})
+	if err != nil || !token.Valid {
+		klog.V(4).Infof("verify token err: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	// jwt-go skips the zero exp, every token here must expire
+	if clm.ExpiresAt == 0 || !clm.VerifyExpiresAt(i.now().Unix(), true) {
+		return nil, ErrInvalidToken
+	}
+	if clm.Issuer != DefaultIssuerName || clm.TokenType != tokenType || clm.Username == "" {
+		return nil, ErrInvalidToken
+	}
+	return clm, nil
+}
+
+func (i *TokenIssuer) sign(user *User, tokenType string, ttl time.Duration, authTime time.Time) (string, error) {
+	kid, key, err := i.Keys.SigningKey()
+	if err != nil {
+		return "", err
+	}
+
+	now := i.now()
+	clm := &Claims{
+		Username:  user.Name,
+		UID:       user.Name,
+		Email:     user.Email,
+		Groups:    user.Groups,
+		Provider:  user.Provider,
+		TokenType: tokenType,
+		AuthTime:  authTime.Unix(),
+		StandardClaims: jwt.StandardClaims{
+			IssuedAt:  now.Unix(),
+			Issuer:    DefaultIssuerName,
+			NotBefore: now.Unix(),
+			ExpiresAt: now.Add(ttl).Unix(),
+			Subject:   user.Name,
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, clm)
+	token.Header["kid"] = kid
+	tokenString, err := token.SignedString(key)
+	if err != nil {
+		return "", fmt.Errorf("sign %s token err: %v", tokenType, err)
 	}
-	return false
+	return tokenString, nil
 }
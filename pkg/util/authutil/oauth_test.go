@@ -0,0 +1,230 @@
+package authutil
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestTokenIssuer(t *testing.T) {
+	keys, err := NewStaticKeySet("0123456789abcdef")
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer := NewTokenIssuer(keys, time.Hour, 2*time.Hour)
+	now := time.Now()
+	issuer.now = func() time.Time { return now }
+
+	token, err := issuer.IssueTo(&User{Name: "admin", Email: "admin@gostship.io", Groups: []string{"ops"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clm, err := issuer.Verify(token.AccessToken, TokenTypeAccess)
+	if err != nil {
+		t.Fatalf("verify access token err: %v", err)
+	}
+	if clm.Username != "admin" || len(clm.Groups) != 1 || clm.Groups[0] != "ops" {
+		t.Errorf("unexpected claims: %+v", clm)
+	}
+
+	if _, err := issuer.Verify(token.RefreshToken, TokenTypeAccess); err != ErrInvalidToken {
+		t.Errorf("refresh token is accepted as a access token")
+	}
+	if _, err := issuer.Verify(token.AccessToken+"x", TokenTypeAccess); err != ErrInvalidToken {
+		t.Errorf("tampered token is accepted")
+	}
+
+	other, _ := NewStaticKeySet("fedcba9876543210")
+	if _, err := NewTokenIssuer(other, 0, 0).Verify(token.AccessToken, TokenTypeAccess); err != ErrInvalidToken {
+		t.Errorf("token signed by another key is accepted")
+	}
+
+	refreshed, err := issuer.Refresh(context.TODO(), token.RefreshToken)
+	if err != nil {
+		t.Fatalf("refresh err: %v", err)
+	}
+	if _, err := issuer.Verify(refreshed.AccessToken, TokenTypeAccess); err != nil {
+		t.Errorf("verify refreshed token err: %v", err)
+	}
+
+	issuer.now = func() time.Time { return now.Add(90 * time.Minute) }
+	if _, err := issuer.Verify(token.AccessToken, TokenTypeAccess); err != ErrInvalidToken {
+		t.Errorf("expired access token is accepted")
+	}
+	if _, err := issuer.Refresh(context.TODO(), token.RefreshToken); err != nil {
+		t.Errorf("refresh with a valid refresh token err: %v", err)
+	}
+}
+
+func TestTokenIssuerRefresh(t *testing.T) {
+	keys, _ := NewStaticKeySet("0123456789abcdef")
+	now := time.Now()
+	newIssuer := func(objs ...runtime.Object) *TokenIssuer {
+		cli := fake.NewSimpleClientset(objs...)
+		issuer := NewTokenIssuer(keys, time.Hour, 2*time.Hour)
+		issuer.SessionTTL = 3 * time.Hour
+		issuer.Users = Chain{NewSecretUserStore(cli, "kunkka-system", "kunkka-users")}
+		issuer.now = func() time.Time { return now }
+		return issuer
+	}
+	bob, _ := json.Marshal(&LocalUser{PasswordHash: string(dummyHash), Groups: []string{"ops"}})
+	users := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "kunkka-users", Namespace: "kunkka-system"},
+		Data:       map[string][]byte{"bob": bob},
+	}
+
+	token, err := newIssuer().IssueTo(&User{Name: "bob", Groups: []string{"dev"}, Provider: "local"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	oidcToken, err := newIssuer().IssueTo(&User{Name: "bob", Groups: []string{"dev"}, Provider: "oidc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	shortSession := newIssuer(users)
+	shortSession.SessionTTL = time.Hour
+
+	tests := []struct {
+		name       string
+		issuer     *TokenIssuer
+		token      string
+		after      time.Duration
+		wantGroups []string
+		wantErr    error
+	}{
+		{name: "groups are resolved again", issuer: newIssuer(users), token: token.RefreshToken, wantGroups: []string{"ops"}},
+		{name: "deleted user", issuer: newIssuer(), token: token.RefreshToken, wantErr: ErrUserNotFound},
+		{name: "user of other provider", issuer: newIssuer(), token: oidcToken.RefreshToken, wantGroups: []string{"dev"}},
+		{name: "refresh token expired", issuer: newIssuer(users), token: token.RefreshToken, after: 3 * time.Hour, wantErr: ErrInvalidToken},
+		{name: "session expired", issuer: shortSession, token: token.RefreshToken, after: time.Hour, wantErr: ErrSessionExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.issuer.now = func() time.Time { return now.Add(tt.after) }
+			refreshed, err := tt.issuer.Refresh(context.TODO(), tt.token)
+			if err != tt.wantErr {
+				t.Fatalf("Refresh() err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			clm, err := tt.issuer.Verify(refreshed.AccessToken, TokenTypeAccess)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(clm.Groups) != len(tt.wantGroups) || (len(clm.Groups) > 0 && clm.Groups[0] != tt.wantGroups[0]) {
+				t.Errorf("groups = %v, want %v", clm.Groups, tt.wantGroups)
+			}
+			if clm.AuthTime != now.Unix() {
+				t.Errorf("auth time %d is not kept, want %d", clm.AuthTime, now.Unix())
+			}
+		})
+	}
+
+	// the refreshed refresh token never outlives the session
+	issuer := newIssuer(users)
+	issuer.now = func() time.Time { return now.Add(2 * time.Hour) }
+	refreshed, err := issuer.Refresh(context.TODO(), token.RefreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer.now = func() time.Time { return now.Add(3*time.Hour + time.Second) }
+	if _, err := issuer.Refresh(context.TODO(), refreshed.RefreshToken); err == nil {
+		t.Errorf("refresh token is valid after the session")
+	}
+}
+
+func TestKeySetRotation(t *testing.T) {
+	cli := fake.NewSimpleClientset()
+	keys := NewSecretKeySet(cli, "kunkka-system", "kunkka-jwt-keys", time.Hour, time.Hour)
+	now := time.Now()
+	keys.now = func() time.Time { return now }
+	if err := keys.Sync(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+
+	issuer := NewTokenIssuer(keys, 0, 0)
+	issuer.now = keys.now
+	old, err := issuer.IssueTo(&User{Name: "admin"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldID, _, _ := keys.SigningKey()
+
+	now = now.Add(61 * time.Minute)
+	if err := keys.Sync(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+	newID, _, _ := keys.SigningKey()
+	if newID == oldID {
+		t.Fatalf("key is not rotated")
+	}
+	// the token signed by the rotated key is still valid
+	if _, err := issuer.Verify(old.RefreshToken, TokenTypeRefresh); err != nil {
+		t.Errorf("verify token of the rotated key err: %v", err)
+	}
+
+	now = now.Add(2 * time.Hour)
+	if err := keys.Sync(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := keys.VerificationKey(oldID); err == nil {
+		t.Errorf("expired key %s is not removed", oldID)
+	}
+}
+
+func TestSecretUserStore(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	admin, _ := json.Marshal(&LocalUser{PasswordHash: string(hash), Email: "admin@gostship.io"})
+	cli := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "kunkka-users", Namespace: "kunkka-system"},
+		Data: map[string][]byte{
+			"admin":    admin,
+			"broken":   []byte("{"),
+			"nopasswd": []byte(`{"passwordHash": "secret"}`),
+		},
+	})
+	store := NewSecretUserStore(cli, "kunkka-system", "kunkka-users")
+
+	tests := []struct {
+		name     string
+		username string
+		password string
+		wantErr  bool
+	}{
+		{name: "valid", username: "admin", password: "secret"},
+		{name: "wrong password", username: "admin", password: "P@88w0rd", wantErr: true},
+		{name: "unknown user", username: "guest", password: "secret", wantErr: true},
+		{name: "empty password", username: "admin", password: "", wantErr: true},
+		{name: "invalid hash", username: "nopasswd", password: "secret", wantErr: true},
+	}
+	chain := Chain{store}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := chain.Authenticate(context.TODO(), tt.username, tt.password)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Authenticate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (user.Email != "admin@gostship.io" || user.Provider != "local") {
+				t.Errorf("unexpected user: %+v", user)
+			}
+		})
+	}
+
+	users, err := store.List(context.TODO())
+	if err != nil || len(users) != 1 || users[0].Name != "admin" {
+		t.Errorf("List() = %v, %v", users, err)
+	}
+}
@@ -0,0 +1,173 @@
+package authutil
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc"
+	"golang.org/x/oauth2"
+)
+
+const (
+	oidcDefaultUsernameClaim = "preferred_username"
+	oidcDefaultGroupsClaim   = "groups"
+	oidcHTTPTimeout          = 30 * time.Second
+)
+
+// OIDCConfig is the config of a generic OpenID Connect provider.
+type OIDCConfig struct {
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is the callback of the apimanager registered in the provider.
+	RedirectURL string
+	// Scopes are requested besides openid, defaults to profile, email and groups.
+	Scopes []string
+	// UsernameClaim defaults to preferred_username, the sub claim is used if it is missing.
+	UsernameClaim      string
+	GroupsClaim        string
+	InsecureSkipVerify bool
+}
+
+// OIDCProvider logs the users in with the authorization code flow of the provider,
+// the id token is verified with the keys of the provider.
+type OIDCProvider struct {
+	OIDCConfig
+
+	client *http.Client
+
+	mu       sync.Mutex
+	provider *oidc.Provider
+	verifier *oidc.IDTokenVerifier
+}
+
+// NewOIDCProvider returns a provider of the config, the discovery is loaded on the first login.
+func NewOIDCProvider(cfg OIDCConfig) (*OIDCProvider, error) {
+	if cfg.IssuerURL == "" || cfg.ClientID == "" || cfg.RedirectURL == "" {
+		return nil, fmt.Errorf("oidc issuer url, client id and redirect url are required")
+	}
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"profile", "email", "groups"}
+	}
+	if cfg.UsernameClaim == "" {
+		cfg.UsernameClaim = oidcDefaultUsernameClaim
+	}
+	if cfg.GroupsClaim == "" {
+		cfg.GroupsClaim = oidcDefaultGroupsClaim
+	}
+
+	return &OIDCProvider{
+		OIDCConfig: cfg,
+		client: &http.Client{
+			Timeout: oidcHTTPTimeout,
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: cfg.InsecureSkipVerify},
+			},
+		},
+	}, nil
+}
+
+func (p *OIDCProvider) Name() string {
+	return "oidc"
+}
+
+// AuthCodeURL returns the login url of the provider, the state is checked in the callback
+// and the nonce of the id token is derived from it.
+func (p *OIDCProvider) AuthCodeURL(ctx context.Context, state string) (string, error) {
+	cfg, _, err := p.load()
+	if err != nil {
+		return "", err
+	}
+
+	return cfg.AuthCodeURL(state, oidc.Nonce(oidcNonce(state))), nil
+}
+
+// Exchange exchanges the code for the id token and returns its user.
+func (p *OIDCProvider) Exchange(ctx context.Context, code, state string) (*User, error) {
+	cfg, verifier, err := p.load()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = oidc.ClientContext(ctx, p.client)
+	token, err := cfg.Exchange(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("oidc exchange code err: %v", err)
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, fmt.Errorf("oidc token response has no id_token")
+	}
+
+	idToken, err := verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("oidc verify id token err: %v", err)
+	}
+	if idToken.Nonce != oidcNonce(state) {
+		return nil, fmt.Errorf("oidc id token nonce mismatch")
+	}
+	claims := map[string]interface{}{}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("oidc decode id token claims err: %v", err)
+	}
+
+	user := &User{Provider: p.Name()}
+	user.Name, _ = claims[p.UsernameClaim].(string)
+	if user.Name == "" {
+		user.Name = idToken.Subject
+	}
+	user.Email, _ = claims["email"].(string)
+	switch groups := claims[p.GroupsClaim].(type) {
+	case []interface{}:
+		for _, g := range groups {
+			if s, ok := g.(string); ok {
+				user.Groups = append(user.Groups, s)
+			}
+		}
+	case string:
+		user.Groups = []string{groups}
+	}
+	if user.Name == "" {
+		return nil, fmt.Errorf("oidc id token has no username")
+	}
+	return user, nil
+}
+
+// load discovers the provider once, its remote key set caches the keys and
+// reloads them for a unknown kid, since the provider may have rotated them.
+func (p *OIDCProvider) load() (*oauth2.Config, *oidc.IDTokenVerifier, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.provider == nil {
+		// the key set keeps the context to fetch the keys, so it must outlive
+		// the request, the requests are bounded by the client timeout
+		ctx := oidc.ClientContext(context.Background(), p.client)
+		provider, err := oidc.NewProvider(ctx, p.IssuerURL)
+		if err != nil {
+			return nil, nil, fmt.Errorf("oidc discover %s err: %v", p.IssuerURL, err)
+		}
+		p.provider = provider
+		p.verifier = provider.Verifier(&oidc.Config{ClientID: p.ClientID})
+	}
+
+	return &oauth2.Config{
+		ClientID:     p.ClientID,
+		ClientSecret: p.ClientSecret,
+		RedirectURL:  p.RedirectURL,
+		Endpoint:     p.provider.Endpoint(),
+		Scopes:       append([]string{oidc.ScopeOpenID}, p.Scopes...),
+	}, p.verifier, nil
+}
+
+func oidcNonce(state string) string {
+	sum := sha256.Sum256([]byte(state))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,135 @@
+package authutil
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+)
+
+// fakeOIDCServer is a provider issuing the id token of the claims for any code.
+type fakeOIDCServer struct {
+	*httptest.Server
+	key    *rsa.PrivateKey
+	kid    string
+	claims map[string]interface{}
+}
+
+func newFakeOIDCServer(t *testing.T) *fakeOIDCServer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeOIDCServer{key: key, kid: "k1"}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                                s.URL,
+			"authorization_endpoint":                s.URL + "/auth",
+			"token_endpoint":                        s.URL + "/token",
+			"jwks_uri":                              s.URL + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: &key.PublicKey, KeyID: s.kid, Algorithm: "RS256", Use: "sig"},
+		}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key},
+			(&jose.SignerOptions{}).WithHeader("kid", s.kid))
+		if err != nil {
+			t.Fatal(err)
+		}
+		payload, _ := json.Marshal(s.claims)
+		jws, err := signer.Sign(payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		idToken, _ := jws.CompactSerialize()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"id_token":     idToken,
+		})
+	})
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+func TestOIDCProvider(t *testing.T) {
+	srv := newFakeOIDCServer(t)
+	defer srv.Close()
+
+	p, err := NewOIDCProvider(OIDCConfig{
+		IssuerURL:   srv.URL,
+		ClientID:    "kunkka",
+		RedirectURL: "https://kunkka.example.com/oauth/oidc/callback",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loginURL, err := p.AuthCodeURL(context.TODO(), "state")
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, _ := url.Parse(loginURL)
+	if u.Query().Get("nonce") != oidcNonce("state") {
+		t.Errorf("login url has no nonce: %s", loginURL)
+	}
+
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{
+			"iss":                srv.URL,
+			"sub":                "1234",
+			"aud":                "kunkka",
+			"exp":                time.Now().Add(time.Hour).Unix(),
+			"iat":                time.Now().Unix(),
+			"nonce":              oidcNonce("state"),
+			"preferred_username": "alice",
+			"email":              "alice@gostship.io",
+			"groups":             []string{"dev"},
+		}
+	}
+	tests := []struct {
+		name    string
+		mutate  func(map[string]interface{})
+		want    string
+		wantErr bool
+	}{
+		{name: "valid", mutate: func(map[string]interface{}) {}, want: "alice"},
+		{name: "sub as username", mutate: func(c map[string]interface{}) { delete(c, "preferred_username") }, want: "1234"},
+		{name: "other audience", mutate: func(c map[string]interface{}) { c["aud"] = "other" }, wantErr: true},
+		{name: "other issuer", mutate: func(c map[string]interface{}) { c["iss"] = "https://evil.example.com" }, wantErr: true},
+		{name: "expired", mutate: func(c map[string]interface{}) { c["exp"] = time.Now().Add(-time.Hour).Unix() }, wantErr: true},
+		{name: "nonce mismatch", mutate: func(c map[string]interface{}) { c["nonce"] = oidcNonce("other") }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv.claims = valid()
+			tt.mutate(srv.claims)
+			user, err := p.Exchange(context.TODO(), "code", "state")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Exchange() err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if user.Name != tt.want || user.Provider != "oidc" || user.Email != "alice@gostship.io" ||
+				len(user.Groups) != 1 || user.Groups[0] != "dev" {
+				t.Errorf("unexpected user: %+v", user)
+			}
+		})
+	}
+}
@@ -42,12 +42,26 @@ var user = `
 }
 `
 
-func BuildUserMap() (*auth.UserMap, error) {
-	obj := &auth.UserMap{}
+// BuildUserMap returns the user list of the console, the items are built from the template.
+func BuildUserMap(users []User) (*auth.UserMap, error) {
+	tmpl := &auth.UserMap{}
 
-	err := json.Unmarshal([]byte(user), obj)
+	err := json.Unmarshal([]byte(user), tmpl)
 	if err != nil {
 		return nil, err
 	}
+
+	obj := tmpl
+	itemTmpl := tmpl.Items[0]
+	obj.Items = obj.Items[:0]
+	for _, u := range users {
+		item := itemTmpl
+		item.Metadata.Name = u.Name
+		item.Metadata.SelfLink = "/apis/iam.kubesphere.io/v1alpha2/users/" + u.Name
+		item.Metadata.UID = ""
+		item.Spec.Email = u.Email
+		obj.Items = append(obj.Items, item)
+	}
+	obj.TotalItems = len(obj.Items)
 	return obj, nil
 }
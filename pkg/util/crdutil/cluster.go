@@ -19,6 +19,15 @@ metadata:
   annotations:
     k8s.io/action: EnsureCni,EnsureExtKubeconfig,EnsureMetricsServer
 ---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: {{ .Cls.ClusterName }}-ssh
+  namespace: {{ .Cls.ClusterName }}
+type: Opaque
+stringData:
+  password: {{ .Cls.Password | quote }}
+---
 apiVersion: devops.gostship.io/v1
 kind: Cluster
 metadata:
@@ -26,6 +35,9 @@ metadata:
   namespace: {{ .Cls.ClusterName }}
   annotations:
     kunkka.io/description: {{ .Cls.Description }}
+{{- if .Cls.DryRun }}
+    k8s.io/dryRun: "true"
+{{- end }}
   labels:
     cluster-role.kunkka.io/cluster-role: "member"
     cluster.kunkka.io/group: {{ .Cls.ClusterGroup }}
@@ -35,7 +47,7 @@ spec:
   displayName: {{ .Cls.ClusterName }}
   type: {{ .Cls.ClusterType }}
   version: {{ .Cls.ClusterVersion }}
-  networkType: eth0
+  networkType: {{ default "eth0" .Cls.NetworkType }}
   clusterCIDR: {{ (index $.Cfg 0).ClusterCIDR }}
   serviceCIDR: {{ (index $.Cfg 0).Cni.DefaultRoute }}
   dnsDomain: cluster.local
@@ -59,7 +71,9 @@ spec:
     - ip: {{ $elem.Machine }}
       port: 22
       username: {{  $.Cls.UserName }}
-      password: {{ $.Cls.Password }}
+      credentialsRef:
+        name: {{ $.Cls.ClusterName }}-ssh
+        namespace: {{ $.Cls.ClusterName }}
       hostCni:
         id: {{ $elem.Cni.ID }}
         subnet: {{ $elem.Cni.Subnet }}
@@ -67,6 +81,7 @@ spec:
         rangeEnd: {{ $elem.Cni.RangeEnd }}
         defaultRoute: {{ $elem.Cni.DefaultRoute }}
         gw: {{ $elem.Cni.GW }}
+        rackTag: {{ $elem.Cni.RackTag }}
         useState: 1       
     {{ end }}
   apiServerExtraArgs:
@@ -79,6 +94,9 @@ spec:
     "bind-address": "0.0.0.0"
   schedulerExtraArgs:
     "bind-address": "0.0.0.0"
+{{- if .Cls.ContainerRuntime }}
+  containerRuntime: {{ .Cls.ContainerRuntime }}
+{{- end }}
   dockerExtraArgs:
     registry-mirrors: https://4xr1qpsp.mirror.aliyuncs.com
     version: {{ .Cls.DockerVersion }}
@@ -231,6 +249,9 @@ metadata:
   namespace: {{ .Cls.ClusterName }}
   annotations:
     kunkka.io/description: {{ .Cls.Description }}
+{{- if .Cls.DryRun }}
+    k8s.io/dryRun: "true"
+{{- end }}
     k8s.io/apiSvcVip: "10.248.225.11"
     k8s.io/action: EnsureKubeMaster,EnsureExtKubeconfig,EnsureAddons,EnsureCni
   labels:
@@ -242,7 +263,7 @@ spec:
   displayName: demo
   type: {{ .Cls.ClusterType }}
   version: {{ .Cls.ClusterVersion }}
-  networkType: eth0
+  networkType: {{ default "eth0" .Cls.NetworkType }}
   clusterCIDR: {{ (index $.Cfg 1).ClusterCIDR }}
   serviceCIDR: {{ (index $.Cfg 1).ServiceCIDR }}
   dnsDomain: cluster.local
@@ -276,6 +297,9 @@ spec:
     "bind-address": "0.0.0.0"
   schedulerExtraArgs:
     "bind-address": "0.0.0.0"
+{{- if .Cls.ContainerRuntime }}
+  containerRuntime: {{ .Cls.ContainerRuntime }}
+{{- end }}
   dockerExtraArgs:
     registry-mirrors: https://4xr1qpsp.mirror.aliyuncs.com
     version: {{ .Cls.DockerVersion }}
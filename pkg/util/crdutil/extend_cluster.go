@@ -37,7 +37,6 @@ spec:
     - ip: {{ $elem }}
       port: 22
       username: "root"
-      password: "123123"
     {{ end }}
 status:
  conditions:
@@ -11,6 +11,15 @@ import (
 
 var nodeTemplate = `
 {{ range $index, $element := .Cni }}
+apiVersion: v1
+kind: Secret
+metadata:
+  name: {{ $element.Machine }}-ssh
+  namespace: {{ $.Node.ClusterName }}
+type: Opaque
+stringData:
+  password: {{ $.Node.Password | quote }}
+---
 apiVersion: devops.gostship.io/v1
 kind: Machine
 metadata:
@@ -26,7 +35,9 @@ spec:
     ip: {{ $element.Machine }}
     port: 22
     username: {{ $.Node.UserName }}
-    password: {{ $.Node.Password }}
+    credentialsRef:
+      name: {{ $element.Machine }}-ssh
+      namespace: {{ $.Node.ClusterName }}
     hostCni:
       id: {{  $element.Cni.ID }}
       subnet: {{  $element.Cni.Subnet }}
@@ -37,6 +48,9 @@ spec:
       gw: {{ $element.Cni.GW }}
       rackTag: {{ $element.Cni.RackTag }}
       useState: 1
+{{- if $.Node.ContainerRuntime }}
+  containerRuntime: {{ $.Node.ContainerRuntime }}
+{{- end }}
   dockerExtraArgs:
     registry-mirrors: https://4xr1qpsp.mirror.aliyuncs.com
     version: {{ $.Node.DockerVersion }}
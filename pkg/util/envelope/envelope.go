@@ -0,0 +1,189 @@
+package envelope
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+// IdentityProvider is the name of the data kept unencrypted, used when no provider is set.
+const IdentityProvider = "identity"
+
+// dekSize is the size of the AES-256 data encryption keys.
+const dekSize = 32
+
+// Provider encrypts the data encryption keys with a key encryption key it holds.
+type Provider interface {
+	// Name identifies the provider and its key, it is kept with the sealed data so the
+	// data is opened by the same provider.
+	Name() string
+	Encrypt(ctx context.Context, plaintext []byte) ([]byte, error)
+	Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error)
+}
+
+// Sealed is the data encrypted with a data encryption key, the key is encrypted by the
+// provider.
+type Sealed struct {
+	Provider   string
+	DEK        []byte
+	Ciphertext []byte
+}
+
+// Envelope seals the data with a new data encryption key each time.
+type Envelope struct {
+	provider Provider
+	// decrypters only open the data sealed by the replaced keys
+	decrypters []Provider
+}
+
+// New returns an envelope of the provider, the data is kept unencrypted if it is nil. The
+// decrypters are the providers of the replaced keys, they only open the data sealed before,
+// which is sealed by the provider once it is saved again.
+func New(provider Provider, decrypters ...Provider) *Envelope {
+	return &Envelope{provider: provider, decrypters: decrypters}
+}
+
+// Provider returns the name of the provider sealing the data.
+func (e *Envelope) Provider() string {
+	if e == nil || e.provider == nil {
+		return IdentityProvider
+	}
+	return e.provider.Name()
+}
+
+// Seal encrypts the data with a new data encryption key. The additional data is authenticated
+// and not kept, the data is opened only with the same one, e.g. the name of the object the
+// data is kept in, so the sealed data may not be moved to another object.
+func (e *Envelope) Seal(ctx context.Context, plaintext, additionalData []byte) (*Sealed, error) {
+	if e == nil || e.provider == nil {
+		return &Sealed{Provider: IdentityProvider, Ciphertext: plaintext}, nil
+	}
+
+	dek := make([]byte, dekSize)
+	if _, err := io.ReadFull(rand.Reader, dek); err != nil {
+		return nil, fmt.Errorf("generate data encryption key err: %v", err)
+	}
+	ciphertext, err := aesGCMSeal(dek, plaintext, additionalData)
+	if err != nil {
+		return nil, err
+	}
+	encryptedDEK, err := e.provider.Encrypt(ctx, dek)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt data encryption key with %s err: %v", e.provider.Name(), err)
+	}
+	return &Sealed{Provider: e.provider.Name(), DEK: encryptedDEK, Ciphertext: ciphertext}, nil
+}
+
+// Open decrypts the sealed data, it must be sealed by the provider or a decrypter of the
+// envelope with the same additional data, or kept unencrypted.
+func (e *Envelope) Open(ctx context.Context, sealed *Sealed, additionalData []byte) ([]byte, error) {
+	if sealed.Provider == IdentityProvider {
+		return sealed.Ciphertext, nil
+	}
+	provider := e.find(sealed.Provider)
+	if provider == nil {
+		return nil, fmt.Errorf("the data is sealed by the kms provider %s, which is not configured", sealed.Provider)
+	}
+
+	dek, err := provider.Decrypt(ctx, sealed.DEK)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt data encryption key with %s err: %v", sealed.Provider, err)
+	}
+	return aesGCMOpen(dek, sealed.Ciphertext, additionalData)
+}
+
+func (e *Envelope) find(name string) Provider {
+	if e == nil {
+		return nil
+	}
+	if e.provider != nil && e.provider.Name() == name {
+		return e.provider
+	}
+	for _, p := range e.decrypters {
+		if p.Name() == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// localProvider encrypts the data encryption keys with an AES key read from a file.
+type localProvider struct {
+	name string
+	key  []byte
+}
+
+// NewLocalProvider returns a provider of the AES-256 key in the file, the key is base64
+// encoded, e.g. generated by `head -c 32 /dev/urandom | base64`. The name tells the keys
+// apart, a new name is required once the key is replaced, and the replaced key is kept as a
+// decrypter of the envelope until the data is sealed again.
+func NewLocalProvider(name, keyFile string) (Provider, error) {
+	data, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("read kms key file %s err: %v", keyFile, err)
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("decode kms key file %s err: %v", keyFile, err)
+	}
+	if len(key) != dekSize {
+		return nil, fmt.Errorf("kms key of %s has %d bytes, want %d", keyFile, len(key), dekSize)
+	}
+	return &localProvider{name: "local/" + name, key: key}, nil
+}
+
+func (p *localProvider) Name() string {
+	return p.name
+}
+
+func (p *localProvider) Encrypt(ctx context.Context, plaintext []byte) ([]byte, error) {
+	return aesGCMSeal(p.key, plaintext, nil)
+}
+
+func (p *localProvider) Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error) {
+	return aesGCMOpen(p.key, ciphertext, nil)
+}
+
+// aesGCMSeal encrypts the plaintext and authenticates the additional data, the nonce is the
+// prefix of the result.
+func aesGCMSeal(key, plaintext, additionalData []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce err: %v", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func aesGCMOpen(key, ciphertext, additionalData []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, fmt.Errorf("ciphertext is too short")
+	}
+	nonce := ciphertext[:aead.NonceSize()]
+	plaintext, err := aead.Open(nil, nonce, ciphertext[aead.NonceSize():], additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt err: %v", err)
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
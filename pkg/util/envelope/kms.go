@@ -0,0 +1,46 @@
+package envelope
+
+import (
+	"context"
+	"fmt"
+)
+
+// KMSService is the service of a KMS plugin, the same as the v1beta1 KeyManagementService of
+// the Kubernetes KMS plugins, so the gRPC client of a plugin socket implements it.
+type KMSService interface {
+	// Version returns the name and the version of the plugin runtime.
+	Version(ctx context.Context) (runtimeName, runtimeVersion string, err error)
+	Encrypt(ctx context.Context, plaintext []byte) ([]byte, error)
+	Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error)
+}
+
+// kmsProvider encrypts the data encryption keys by a KMS plugin.
+type kmsProvider struct {
+	name    string
+	service KMSService
+}
+
+// NewKMSProvider returns a provider of the KMS plugin, the name tells the keys of the plugins
+// apart.
+func NewKMSProvider(ctx context.Context, name string, service KMSService) (Provider, error) {
+	runtimeName, runtimeVersion, err := service.Version(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get version of kms plugin %s err: %v", name, err)
+	}
+	if runtimeName == "" || runtimeVersion == "" {
+		return nil, fmt.Errorf("kms plugin %s returns an empty runtime name or version", name)
+	}
+	return &kmsProvider{name: "kms/" + name, service: service}, nil
+}
+
+func (p *kmsProvider) Name() string {
+	return p.name
+}
+
+func (p *kmsProvider) Encrypt(ctx context.Context, plaintext []byte) ([]byte, error) {
+	return p.service.Encrypt(ctx, plaintext)
+}
+
+func (p *kmsProvider) Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error) {
+	return p.service.Decrypt(ctx, ciphertext)
+}
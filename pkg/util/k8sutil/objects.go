@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/gostship/kunkka/pkg/k8sclient"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
@@ -114,6 +115,10 @@ func LoadObjs(f io.Reader) ([]runtime.Object, error) {
 			k8sclient.GetScheme(), k8sclient.GetScheme(), json.SerializerOptions{Yaml: true})
 
 		obj, _, err := s.Decode([]byte(yaml), nil, nil)
+		if runtime.IsNotRegisteredError(err) {
+			// the custom resources of the addons, e.g. the calico ip pools
+			obj, _, err = s.Decode([]byte(yaml), nil, &unstructured.Unstructured{})
+		}
 		if err != nil {
 			klog.Errorf("Failed to parse YAML to a k8s object: %v, yaml: \n %s", err, yaml)
 			continue
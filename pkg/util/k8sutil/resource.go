@@ -11,6 +11,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -113,6 +114,14 @@ func prepareResourceForUpdate(current, desired runtime.Object) {
 	case *corev1.Service:
 		svc := desired.(*corev1.Service)
 		svc.Spec.ClusterIP = current.(*corev1.Service).Spec.ClusterIP
+	case *unstructured.Unstructured:
+		obj := desired.(*unstructured.Unstructured)
+		if obj.GetKind() != "Service" {
+			return
+		}
+		if ip, ok, _ := unstructured.NestedString(current.(*unstructured.Unstructured).Object, "spec", "clusterIP"); ok {
+			unstructured.SetNestedField(obj.Object, ip, "spec", "clusterIP")
+		}
 	}
 }
 
@@ -5,15 +5,26 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/gostship/kunkka/pkg/apimanager/model"
 	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
 	"github.com/gostship/kunkka/pkg/util/k8sutil"
 	"github.com/gostship/kunkka/pkg/util/template"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	// MetaCredentialsNamespace and MetaCredentialsName name the Secret holding the ssh
+	// credentials of the meta cluster machines, it must be created before deploying.
+	MetaCredentialsNamespace = "host"
+	MetaCredentialsName      = "host-ssh"
+)
+
 var MetaTemlate = `
 apiVersion: devops.gostship.io/v1
 kind: Cluster
@@ -35,15 +46,21 @@ spec:
   - ip: 10.248.224.183
     port: 22
     username: root
-    password: "hNKKTFCAOp6r58A"
+    credentialsRef:
+      name: {{ .Name }}
+      namespace: {{ .Namespace }}
   - ip: 10.248.224.201
     port: 22
     username: root
-    password: "hNKKTFCAOp6r58A"
+    credentialsRef:
+      name: {{ .Name }}
+      namespace: {{ .Namespace }}
   - ip: 10.248.224.199
     port: 22
     username: root
-    password: "hNKKTFCAOp6r58A"
+    credentialsRef:
+      name: {{ .Name }}
+      namespace: {{ .Namespace }}
 status:
  conditions:
  - lastProbeTime: "2020-08-03T12:22:14Z"
@@ -551,8 +568,8 @@ var ClusterRoleTemp = `
 ]
 `
 
-func BuildMetaObj() (*devopsv1.Cluster, error) {
-	data, err := template.ParseString(MetaTemlate, "")
+func BuildMetaObj(cli client.Client) (*devopsv1.Cluster, error) {
+	data, err := template.ParseString(MetaTemlate, types.NamespacedName{Namespace: MetaCredentialsNamespace, Name: MetaCredentialsName})
 	var meta *devopsv1.Cluster
 	var ok bool
 	if err != nil {
@@ -569,9 +586,47 @@ func BuildMetaObj() (*devopsv1.Cluster, error) {
 			break
 		}
 	}
+	if meta == nil {
+		return nil, errors.New("meta cluster template has no cluster")
+	}
+	if err := checkMetaCredentials(cli, meta); err != nil {
+		return nil, err
+	}
 	return meta, nil
 }
 
+// checkMetaCredentials marks the meta cluster not ready when the Secret referenced by the
+// credentialsRef of its machines is missing.
+func checkMetaCredentials(cli client.Client, meta *devopsv1.Cluster) error {
+	ctx := context.Background()
+	for i := range meta.Spec.Machines {
+		for _, ref := range meta.Spec.Machines[i].CredentialsRefs() {
+			secret := &corev1.Secret{}
+			err := cli.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, secret)
+			if err == nil {
+				continue
+			}
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+
+			msg := fmt.Sprintf("ssh credentials secret %s/%s of the meta cluster machines is not found, create it before deploying", ref.Namespace, ref.Name)
+			klog.Warning(msg)
+			now := metav1.Now()
+			meta.Status.Conditions = []devopsv1.ClusterCondition{{
+				Type:               "Ready",
+				Status:             devopsv1.ConditionFalse,
+				Reason:             "CredentialsNotFound",
+				Message:            msg,
+				LastProbeTime:      now,
+				LastTransitionTime: now,
+			}}
+			return nil
+		}
+	}
+	return nil
+}
+
 func BuildExtendObj(cli client.Client) ([]devopsv1.Cluster, error) {
 	ctx := context.Background()
 	cms := &corev1.ConfigMapList{}
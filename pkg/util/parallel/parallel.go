@@ -0,0 +1,75 @@
+package parallel
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Errors are the errors of the items keyed by the item names, e.g. the host addresses.
+type Errors map[string]error
+
+func (e Errors) Error() string {
+	names := make([]string, 0, len(e))
+	for name := range e {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	msgs := make([]string, 0, len(names))
+	for _, name := range names {
+		msgs = append(msgs, fmt.Sprintf("%s: %v", name, e[name]))
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// ForEach runs f on each of the names with at most workers goroutines, f runs on all
+// the names even if some fail, and the failures are returned as Errors. The names not
+// started yet when the ctx is done fail with the ctx error.
+//
+// workers <= 1 runs f in the order of the names.
+func ForEach(ctx context.Context, workers int, names []string, f func(ctx context.Context, i int) error) error {
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(names) {
+		workers = len(names)
+	}
+
+	var (
+		mu   sync.Mutex
+		errs = Errors{}
+		wg   sync.WaitGroup
+	)
+	items := make(chan int)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range items {
+				err := ctx.Err()
+				if err == nil {
+					err = f(ctx, i)
+				}
+				if err != nil {
+					mu.Lock()
+					errs[names[i]] = err
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for i := range names {
+		items <- i
+	}
+	close(items)
+	wg.Wait()
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
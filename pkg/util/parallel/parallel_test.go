@@ -0,0 +1,57 @@
+package parallel
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestForEach(t *testing.T) {
+	names := []string{"10.0.0.3", "10.0.0.1", "10.0.0.2", "10.0.0.4"}
+
+	var running, max int32
+	err := ForEach(context.TODO(), 2, names, func(ctx context.Context, i int) error {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			m := atomic.LoadInt32(&max)
+			if n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		if names[i] == "10.0.0.3" || names[i] == "10.0.0.1" {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+	if max > 2 {
+		t.Errorf("ForEach() runs %d at the same time, want at most 2", max)
+	}
+
+	errs, ok := err.(Errors)
+	if !ok || len(errs) != 2 {
+		t.Fatalf("ForEach() err = %v, want errors of 2 hosts", err)
+	}
+	want := "10.0.0.1: connection refused; 10.0.0.3: connection refused"
+	if err.Error() != want {
+		t.Errorf("ForEach() err = %q, want %q", err.Error(), want)
+	}
+
+	var order []string
+	err = ForEach(context.TODO(), 1, names, func(ctx context.Context, i int) error {
+		order = append(order, names[i])
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range names {
+		if order[i] != names[i] {
+			t.Fatalf("ForEach() of 1 worker order = %v, want %v", order, names)
+		}
+	}
+}
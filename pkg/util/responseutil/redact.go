@@ -0,0 +1,77 @@
+package responseutil
+
+import (
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+)
+
+// redact returns a copy of the clusters and machines in data without the inline ssh
+// credentials, the other data is returned as is.
+func redact(data interface{}) interface{} {
+	switch obj := data.(type) {
+	case *devopsv1.Cluster:
+		if obj == nil {
+			return obj
+		}
+		c := obj.DeepCopy()
+		c.RedactCredentials()
+		return c
+	case devopsv1.Cluster:
+		c := obj.DeepCopy()
+		c.RedactCredentials()
+		return c
+	case []*devopsv1.Cluster:
+		items := make([]*devopsv1.Cluster, 0, len(obj))
+		for _, c := range obj {
+			items = append(items, redact(c).(*devopsv1.Cluster))
+		}
+		return items
+	case []devopsv1.Cluster:
+		items := make([]devopsv1.Cluster, 0, len(obj))
+		for i := range obj {
+			items = append(items, *redact(&obj[i]).(*devopsv1.Cluster))
+		}
+		return items
+	case *devopsv1.ClusterList:
+		if obj == nil {
+			return obj
+		}
+		l := obj.DeepCopy()
+		for i := range l.Items {
+			l.Items[i].RedactCredentials()
+		}
+		return l
+	case *devopsv1.Machine:
+		if obj == nil {
+			return obj
+		}
+		m := obj.DeepCopy()
+		m.RedactCredentials()
+		return m
+	case devopsv1.Machine:
+		m := obj.DeepCopy()
+		m.RedactCredentials()
+		return m
+	case []*devopsv1.Machine:
+		items := make([]*devopsv1.Machine, 0, len(obj))
+		for _, m := range obj {
+			items = append(items, redact(m).(*devopsv1.Machine))
+		}
+		return items
+	case []devopsv1.Machine:
+		items := make([]devopsv1.Machine, 0, len(obj))
+		for i := range obj {
+			items = append(items, *redact(&obj[i]).(*devopsv1.Machine))
+		}
+		return items
+	case *devopsv1.MachineList:
+		if obj == nil {
+			return obj
+		}
+		l := obj.DeepCopy()
+		for i := range l.Items {
+			l.Items[i].RedactCredentials()
+		}
+		return l
+	}
+	return data
+}
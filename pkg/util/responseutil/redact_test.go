@@ -0,0 +1,90 @@
+package responseutil
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	devopsv1 "github.com/gostship/kunkka/pkg/apis/devops/v1"
+)
+
+func newClusterMachine() *devopsv1.ClusterMachine {
+	return &devopsv1.ClusterMachine{
+		IP:         "10.0.0.1",
+		Port:       22,
+		Username:   "root",
+		Password:   "secret-password",
+		PrivateKey: []byte("secret-key"),
+	}
+}
+
+func TestRedact(t *testing.T) {
+	cluster := func() *devopsv1.Cluster {
+		c := &devopsv1.Cluster{}
+		c.Name = "c1"
+		c.Spec.Machines = []*devopsv1.ClusterMachine{newClusterMachine(), nil}
+		return c
+	}
+	machine := func() *devopsv1.Machine {
+		m := &devopsv1.Machine{}
+		m.Name = "m1"
+		m.Spec.Machine = newClusterMachine()
+		return m
+	}
+
+	tests := []struct {
+		name string
+		data interface{}
+	}{
+		{"*Cluster", cluster()},
+		{"Cluster", *cluster()},
+		{"[]*Cluster", []*devopsv1.Cluster{cluster(), cluster()}},
+		{"[]Cluster", []devopsv1.Cluster{*cluster(), *cluster()}},
+		{"*ClusterList", &devopsv1.ClusterList{Items: []devopsv1.Cluster{*cluster()}}},
+		{"*Machine", machine()},
+		{"Machine", *machine()},
+		{"[]*Machine", []*devopsv1.Machine{machine(), machine()}},
+		{"[]Machine", []devopsv1.Machine{*machine(), *machine()}},
+		{"*MachineList", &devopsv1.MachineList{Items: []devopsv1.Machine{*machine()}}},
+		{"*Machine without machine", &devopsv1.Machine{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, err := json.Marshal(tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			out, err := json.Marshal(redact(tt.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Contains(string(out), "secret") {
+				t.Errorf("redact() keeps the credentials: %s", out)
+			}
+			if strings.Contains(string(before), "10.0.0.1") && !strings.Contains(string(out), "10.0.0.1") {
+				t.Errorf("redact() drops the machine address: %s", out)
+			}
+			after, _ := json.Marshal(tt.data)
+			if string(after) != string(before) {
+				t.Errorf("redact() modifies the data:\n%s\nwant\n%s", after, before)
+			}
+		})
+	}
+
+	// nil objects and the other data are returned as is
+	if c := redact((*devopsv1.Cluster)(nil)); c.(*devopsv1.Cluster) != nil {
+		t.Errorf("redact() of nil cluster = %v", c)
+	}
+	if l := redact((*devopsv1.ClusterList)(nil)); l.(*devopsv1.ClusterList) != nil {
+		t.Errorf("redact() of nil cluster list = %v", l)
+	}
+	if m := redact((*devopsv1.Machine)(nil)); m.(*devopsv1.Machine) != nil {
+		t.Errorf("redact() of nil machine = %v", m)
+	}
+	if l := redact((*devopsv1.MachineList)(nil)); l.(*devopsv1.MachineList) != nil {
+		t.Errorf("redact() of nil machine list = %v", l)
+	}
+	if s := redact("secret"); s != "secret" {
+		t.Errorf("redact() of other data = %v", s)
+	}
+}
@@ -27,18 +27,18 @@ func (g *Gin) RespError(str string) {
 	return
 }
 
-// http success response
+// http success response, the ssh credentials of the clusters and machines are redacted
 func (g *Gin) RespSuccess(state bool, msg interface{}, data interface{}, total int) {
 	g.Ctx.IndentedJSON(200, gin.H{
 		"success":     state,
 		"message":     msg,
-		"items":       data,
+		"items":       redact(data),
 		"total_count": total,
 	})
 	return
 }
 
 func (g *Gin) RespJson(data interface{}) {
-	g.Ctx.JSON(200, data)
+	g.Ctx.JSON(200, redact(data))
 	return
 }
@@ -10,9 +10,9 @@ import (
 
 // GetNetworkInterface return network interface name by ip
 func GetNetworkInterface(s Interface, ip string) string {
-	stdout, _, _, _ := s.Execf("ip a | grep '%s' |awk '{print $NF}'", ip)
+	stdout, _, _, _ := s.Execf("ip -o addr show | awk '$4 ~ /^%s\\//{print $2; exit}'", ip)
 
-	return stdout
+	return strings.TrimSpace(stdout)
 }
 
 // Timestamp returns target node timestamp.
@@ -0,0 +1,243 @@
+package ssh
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/sync/singleflight"
+	"k8s.io/klog"
+)
+
+// handshakeTimeout bounds the handshake like the read deadline of the direct connections.
+const handshakeTimeout = 30 * time.Second
+
+// bastions is the connections of the jump hosts, shared by all the machines behind them.
+var bastions = &bastionPool{clients: make(map[string]*ssh.Client)}
+
+// jumpHop is a jump host of the chain, the key identifies the chain up to the host.
+type jumpHop struct {
+	key  string
+	addr string
+	// hostKeyID identifies the host for its pinned key
+	hostKeyID string
+	user      string
+	auth      []ssh.AuthMethod
+	timeout   time.Duration
+}
+
+// jumpDialer dials the host through the chain of the jump hosts.
+type jumpDialer struct {
+	hops []jumpHop
+	pool *bastionPool
+}
+
+var _ sshDialer = &jumpDialer{}
+
+func newJumpDialer(hosts []Config) (*jumpDialer, error) {
+	hops := make([]jumpHop, 0, len(hosts))
+	key := ""
+	via := make([]string, 0, len(hosts))
+	for i := range hosts {
+		c := &hosts[i]
+		if c.Port == 0 {
+			c.Port = 22
+		}
+		auth, err := c.authMethods()
+		if err != nil {
+			return nil, fmt.Errorf("jump host %s: %v", c.Host, err)
+		}
+		if c.DialTimeOut == 0 {
+			c.DialTimeOut = 5 * time.Second
+		}
+
+		key = connKey(key, c)
+		addr := fmt.Sprintf("%s:%d", c.Host, c.Port)
+		hops = append(hops, jumpHop{
+			key:       key,
+			addr:      addr,
+			hostKeyID: HostKeyID(via, addr),
+			user:      c.User,
+			auth:      auth,
+			timeout:   c.DialTimeOut,
+		})
+		via = append(via, addr)
+	}
+
+	return &jumpDialer{hops: hops, pool: bastions}, nil
+}
+
+// connKey identifies the connection to the host with the credential, the prefix is the key of
+// the jump host the connection goes through.
+func connKey(prefix string, c *Config) string {
+	sum := sha256.Sum256([]byte(c.Password + "\x00" + string(c.PrivateKey) + "\x00" + string(c.PassPhrase)))
+	return fmt.Sprintf("%s/%s@%s:%d#%s", prefix, c.User, c.Host, c.Port, hex.EncodeToString(sum[:8]))
+}
+
+func (d *jumpDialer) Dial(network, addr string, config *ssh.ClientConfig) (*ssh.Client, error) {
+	bastion, err := d.pool.get(d.hops)
+	if err != nil {
+		return nil, err
+	}
+	last := d.hops[len(d.hops)-1]
+	conn, err := bastion.Dial(network, addr)
+	if err != nil {
+		// the shared connection may be broken since its last use, dial the chain again once
+		if _, _, perr := bastion.SendRequest("keepalive@openssh.com", true, nil); perr == nil {
+			return nil, fmt.Errorf("dial %s through %s: %v", addr, last.addr, err)
+		}
+		d.pool.invalidate(last.key, bastion)
+		bastion, err = d.pool.get(d.hops)
+		if err != nil {
+			return nil, err
+		}
+		conn, err = bastion.Dial(network, addr)
+		if err != nil {
+			return nil, fmt.Errorf("dial %s through %s: %v", addr, last.addr, err)
+		}
+	}
+
+	return newClient(conn, addr, config)
+}
+
+// bastionPool keeps a connection for each chain of the jump hosts.
+type bastionPool struct {
+	sync.Mutex
+	clients map[string]*ssh.Client
+	// dials are the jump hosts being dialed by the key, out of the lock
+	dials singleflight.Group
+}
+
+// get returns the connection of the last jump host, the missing connections of the chain
+// are dialed through the previous ones.
+func (p *bastionPool) get(hops []jumpHop) (*ssh.Client, error) {
+	var prev *ssh.Client
+	for _, hop := range hops {
+		client, err := p.connect(hop, prev)
+		if err != nil {
+			return nil, err
+		}
+		prev = client
+	}
+	return prev, nil
+}
+
+// connect returns the connection of the jump host, it is dialed through prev if missing. The
+// concurrent callers of the jump host wait for the same dial.
+func (p *bastionPool) connect(hop jumpHop, prev *ssh.Client) (*ssh.Client, error) {
+	p.Lock()
+	client, ok := p.clients[hop.key]
+	p.Unlock()
+	if ok {
+		return client, nil
+	}
+
+	v, err, _ := p.dials.Do(hop.key, func() (interface{}, error) {
+		p.Lock()
+		client, ok := p.clients[hop.key]
+		p.Unlock()
+		if ok {
+			return client, nil
+		}
+
+		client, err := dialHop(hop, prev)
+		if err != nil {
+			return nil, err
+		}
+		klog.V(4).Infof("connected to jump host %s", hop.addr)
+		p.Lock()
+		p.clients[hop.key] = client
+		p.Unlock()
+		go p.watch(hop.key, client)
+		return client, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*ssh.Client), nil
+}
+
+// dialHop connects to the jump host, directly or through the previous one.
+func dialHop(hop jumpHop, prev *ssh.Client) (*ssh.Client, error) {
+	verifier := newHostKeyVerifier(hop.hostKeyID)
+	config := &ssh.ClientConfig{
+		User:            hop.user,
+		Auth:            hop.auth,
+		HostKeyCallback: verifier.Verify,
+		Timeout:         hop.timeout,
+	}
+	var client *ssh.Client
+	var err error
+	if prev == nil {
+		client, err = (&realSSHDialer{}).Dial("tcp", hop.addr, config)
+	} else {
+		var conn net.Conn
+		conn, err = prev.Dial("tcp", hop.addr)
+		if err == nil {
+			client, err = newClient(conn, hop.addr, config)
+		}
+	}
+	if mismatch := verifier.Mismatch(); mismatch != nil {
+		if client != nil {
+			client.Close()
+		}
+		return nil, mismatch
+	}
+	if err != nil {
+		return nil, fmt.Errorf("dial jump host %s: %v", hop.addr, err)
+	}
+	return client, nil
+}
+
+// watch drops the connection from the pool once it is closed.
+func (p *bastionPool) watch(key string, client *ssh.Client) {
+	err := client.Wait()
+	klog.V(4).Infof("jump host %s disconnected: %v", client.RemoteAddr(), err)
+	p.invalidate(key, client)
+}
+
+// invalidate closes the connection and removes it from the pool, unless it is replaced.
+func (p *bastionPool) invalidate(key string, client *ssh.Client) {
+	p.Lock()
+	defer p.Unlock()
+
+	if p.clients[key] == client {
+		delete(p.clients, key)
+	}
+	client.Close()
+}
+
+// newClient runs the handshake over the connection, the connections tunnelled through a
+// jump host support no deadline, so the timeout is enforced by closing it.
+func newClient(conn net.Conn, addr string, config *ssh.ClientConfig) (*ssh.Client, error) {
+	type result struct {
+		client *ssh.Client
+		err    error
+	}
+	done := make(chan result, 1)
+	go func() {
+		c, chans, reqs, err := ssh.NewClientConn(conn, addr, config)
+		if err != nil {
+			done <- result{err: err}
+			return
+		}
+		done <- result{client: ssh.NewClient(c, chans, reqs)}
+	}()
+
+	timer := time.NewTimer(handshakeTimeout)
+	defer timer.Stop()
+	select {
+	case r := <-done:
+		if r.err != nil {
+			conn.Close()
+		}
+		return r.client, r.err
+	case <-timer.C:
+		conn.Close()
+		return nil, fmt.Errorf("ssh handshake with %s timed out after %s", addr, handshakeTimeout)
+	}
+}
@@ -0,0 +1,49 @@
+package ssh
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewJumpDialer(t *testing.T) {
+	bastion := Config{User: "root", Host: "10.28.0.1", Password: "secret"}
+	inner := Config{User: "ops", Host: "192.168.0.1", Port: 2222, Password: "secret"}
+
+	d, err := newJumpDialer([]Config{bastion, inner})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.hops) != 2 || d.hops[0].addr != "10.28.0.1:22" || d.hops[1].addr != "192.168.0.1:2222" {
+		t.Fatalf("newJumpDialer() hops = %+v", d.hops)
+	}
+	if !strings.HasPrefix(d.hops[1].key, d.hops[0].key+"/") {
+		t.Errorf("newJumpDialer() key %q is not under %q", d.hops[1].key, d.hops[0].key)
+	}
+	if d.hops[0].hostKeyID != "10.28.0.1:22" || d.hops[1].hostKeyID != "10.28.0.1:22/192.168.0.1:2222" {
+		t.Errorf("newJumpDialer() host key ids = %q, %q", d.hops[0].hostKeyID, d.hops[1].hostKeyID)
+	}
+	if strings.Contains(d.hops[0].key, "secret") {
+		t.Errorf("newJumpDialer() key %q contains the password", d.hops[0].key)
+	}
+
+	same, err := newJumpDialer([]Config{bastion})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if same.hops[0].key != d.hops[0].key {
+		t.Errorf("newJumpDialer() keys of the same bastion differ: %q, %q", same.hops[0].key, d.hops[0].key)
+	}
+
+	bastion.Password = "other"
+	other, err := newJumpDialer([]Config{bastion})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.hops[0].key == d.hops[0].key {
+		t.Errorf("newJumpDialer() keys of different credentials are equal: %q", other.hops[0].key)
+	}
+
+	if _, err := newJumpDialer([]Config{{User: "root", Host: "10.28.0.1"}}); err == nil {
+		t.Error("newJumpDialer() without credentials succeeds")
+	}
+}
@@ -0,0 +1,279 @@
+package ssh
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/gostship/kunkka/pkg/constants"
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
+)
+
+const (
+	// hostKeyLabel marks the secrets of the pinned host keys
+	hostKeyLabel = "devops.gostship.io/host-key"
+	// hostKeyData is the key of the pinned host key in the authorized_keys format
+	hostKeyData = "hostKey"
+	// hostKeyAddressAnnotation is the host the key is pinned for, the address behind its jump
+	// hosts
+	hostKeyAddressAnnotation = "devops.gostship.io/address"
+)
+
+// ErrHostKeyPinned means another host key is pinned for the host meanwhile.
+var ErrHostKeyPinned = errors.New("host key is already pinned")
+
+// HostKeyStore keeps the host keys pinned on the first connection, the keys are in the
+// authorized_keys format. The hosts are identified by HostKeyID, as the same address behind
+// other jump hosts may be another host.
+type HostKeyStore interface {
+	// Get returns the pinned key of the host, empty if there is none.
+	Get(id string) (string, error)
+	// Pin saves the key of the host, ErrHostKeyPinned if a key is pinned already.
+	Pin(id, key string) error
+	// Remove drops the pinned key of the host, so the next connection pins a new one.
+	Remove(id string) error
+}
+
+var (
+	hostKeysLock sync.RWMutex
+	hostKeys     HostKeyStore
+
+	memoryHostKeys     = NewMemoryHostKeyStore()
+	memoryHostKeysWarn sync.Once
+)
+
+// SetHostKeyStore sets the store of the pinned host keys, the keys are kept in memory until
+// it is set.
+func SetHostKeyStore(store HostKeyStore) {
+	hostKeysLock.Lock()
+	defer hostKeysLock.Unlock()
+
+	hostKeys = store
+}
+
+func hostKeyStore() HostKeyStore {
+	hostKeysLock.RLock()
+	defer hostKeysLock.RUnlock()
+
+	if hostKeys == nil {
+		memoryHostKeysWarn.Do(func() {
+			klog.Warning("no host key store is set, the host keys pinned on the first ssh connection are kept in memory only")
+		})
+		return memoryHostKeys
+	}
+	return hostKeys
+}
+
+// HostKeyID identifies the host of the address reached through the jump hosts, it is the
+// address itself for the hosts dialed directly.
+func HostKeyID(via []string, addr string) string {
+	return strings.Join(append(via[:len(via):len(via)], addr), hostKeyIDSeparator)
+}
+
+// hostKeyIDSeparator separates the addresses of the chain in the host key id.
+const hostKeyIDSeparator = "/"
+
+// Rekey drops the pinned host key of the host of the config, reached through its jump hosts,
+// used once the host is reinstalled. The keys of the jump hosts are kept.
+func Rekey(c *Config) error {
+	via := make([]string, 0, len(c.JumpHosts))
+	for i := range c.JumpHosts {
+		via = append(via, hostAddr(&c.JumpHosts[i]))
+	}
+	return hostKeyStore().Remove(HostKeyID(via, hostAddr(c)))
+}
+
+// hostAddr returns the address of the host of the config, on the port 22 by default.
+func hostAddr(c *Config) string {
+	port := c.Port
+	if port == 0 {
+		port = 22
+	}
+	return fmt.Sprintf("%s:%d", c.Host, port)
+}
+
+// HostKeyMismatchError means the host presents another key than the pinned one.
+type HostKeyMismatchError struct {
+	Addr     string
+	Pinned   string
+	Received string
+}
+
+func (e *HostKeyMismatchError) Error() string {
+	return fmt.Sprintf("host key mismatch for %s: pinned %s, received %s, the host may be reinstalled or impersonated, "+
+		"verify it and add the annotation %s to trust the new key", e.Addr, e.Pinned, e.Received, constants.ClusterAnnotationRekeyHosts)
+}
+
+// hostKeyVerifier pins the key of the host on the first connection and rejects the other
+// keys afterwards.
+type hostKeyVerifier struct {
+	id       string
+	lock     sync.Mutex
+	mismatch *HostKeyMismatchError
+}
+
+func newHostKeyVerifier(id string) *hostKeyVerifier {
+	return &hostKeyVerifier{id: id}
+}
+
+func (v *hostKeyVerifier) Verify(hostname string, remote net.Addr, key ssh.PublicKey) error {
+	store := hostKeyStore()
+	received := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key)))
+	pinned, err := store.Get(v.id)
+	if err != nil {
+		return err
+	}
+	if pinned == "" {
+		err = store.Pin(v.id, received)
+		if err == nil {
+			klog.Infof("pin host key %s for %s", ssh.FingerprintSHA256(key), v.id)
+			return nil
+		}
+		if !errors.Is(err, ErrHostKeyPinned) {
+			return err
+		}
+		if pinned, err = store.Get(v.id); err != nil {
+			return err
+		}
+	}
+	if pinned == received {
+		return nil
+	}
+
+	mismatch := &HostKeyMismatchError{Addr: v.id, Pinned: pinned, Received: ssh.FingerprintSHA256(key)}
+	if pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(pinned)); err == nil {
+		mismatch.Pinned = ssh.FingerprintSHA256(pub)
+	}
+	v.lock.Lock()
+	v.mismatch = mismatch
+	v.lock.Unlock()
+	return mismatch
+}
+
+// Mismatch returns the mismatch of the last verification, the handshake error hides it.
+func (v *hostKeyVerifier) Mismatch() error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if v.mismatch == nil {
+		return nil
+	}
+	return v.mismatch
+}
+
+// memoryHostKeyStore keeps the host keys in memory.
+type memoryHostKeyStore struct {
+	sync.Mutex
+	keys map[string]string
+}
+
+// NewMemoryHostKeyStore returns a store keeping the host keys until the process exits.
+func NewMemoryHostKeyStore() HostKeyStore {
+	return &memoryHostKeyStore{keys: make(map[string]string)}
+}
+
+func (s *memoryHostKeyStore) Get(id string) (string, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.keys[id], nil
+}
+
+func (s *memoryHostKeyStore) Pin(id, key string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if _, ok := s.keys[id]; ok {
+		return ErrHostKeyPinned
+	}
+	s.keys[id] = key
+	return nil
+}
+
+func (s *memoryHostKeyStore) Remove(id string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	delete(s.keys, id)
+	return nil
+}
+
+// SecretHostKeyStore keeps the key of each host in a Kubernetes secret.
+type SecretHostKeyStore struct {
+	KubeCli   kubernetes.Interface
+	Namespace string
+}
+
+var _ HostKeyStore = &SecretHostKeyStore{}
+
+// NewSecretHostKeyStore returns a store of the secrets in the namespace.
+func NewSecretHostKeyStore(kubeCli kubernetes.Interface, namespace string) *SecretHostKeyStore {
+	return &SecretHostKeyStore{
+		KubeCli:   kubeCli,
+		Namespace: namespace,
+	}
+}
+
+// SecretName returns the name of the secret of the host key of the host, the name of the
+// hosts behind jump hosts ends with a hash of the chain.
+func (s *SecretHostKeyStore) SecretName(id string) string {
+	addr := id
+	if i := strings.LastIndex(id, hostKeyIDSeparator); i >= 0 {
+		addr = id[i+1:]
+	}
+	name := "host-key-" + strings.NewReplacer(":", "-", "[", "", "]", "").Replace(strings.ToLower(addr))
+	if addr != id {
+		sum := sha256.Sum256([]byte(id))
+		name += "-" + hex.EncodeToString(sum[:5])
+	}
+	return name
+}
+
+func (s *SecretHostKeyStore) Get(id string) (string, error) {
+	secret, err := s.KubeCli.CoreV1().Secrets(s.Namespace).Get(context.Background(), s.SecretName(id), metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("get host key secret %s/%s err: %v", s.Namespace, s.SecretName(id), err)
+	}
+	return string(secret.Data[hostKeyData]), nil
+}
+
+func (s *SecretHostKeyStore) Pin(id, key string) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        s.SecretName(id),
+			Namespace:   s.Namespace,
+			Labels:      map[string]string{hostKeyLabel: "true"},
+			Annotations: map[string]string{hostKeyAddressAnnotation: id},
+		},
+		Data: map[string][]byte{hostKeyData: []byte(key)},
+	}
+	_, err := s.KubeCli.CoreV1().Secrets(s.Namespace).Create(context.Background(), secret, metav1.CreateOptions{})
+	if err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return ErrHostKeyPinned
+		}
+		return fmt.Errorf("create host key secret %s/%s err: %v", s.Namespace, secret.Name, err)
+	}
+	return nil
+}
+
+func (s *SecretHostKeyStore) Remove(id string) error {
+	err := s.KubeCli.CoreV1().Secrets(s.Namespace).Delete(context.Background(), s.SecretName(id), metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("delete host key secret %s/%s err: %v", s.Namespace, s.SecretName(id), err)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package ssh
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestHostKeyPinning(t *testing.T) {
+	store := NewMemoryHostKeyStore()
+	SetHostKeyStore(store)
+	defer SetHostKeyStore(nil)
+
+	srv := newTestServer(t)
+	defer srv.listener.Close()
+	c := srv.config()
+	addr := fmt.Sprintf("%s:%d", c.Host, c.Port)
+
+	// the key of a reinstalled host
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(other)))
+	if err := store.Pin(addr, otherKey); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := New(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, _, err = s.Exec("hostname")
+	var mismatch *HostKeyMismatchError
+	if !errors.As(err, &mismatch) || mismatch.Pinned != ssh.FingerprintSHA256(other) {
+		t.Fatalf("Exec() with another pinned key = %v, want a mismatch", err)
+	}
+
+	if err := Rekey(c); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := s.Exec("hostname"); err != nil {
+		t.Fatalf("Exec() after rekey: %v", err)
+	}
+	pinned, err := store.Get(addr)
+	if err != nil || pinned == "" || pinned == otherKey {
+		t.Errorf("Get() after the first connection = %q, %v", pinned, err)
+	}
+}
+
+func TestSecretHostKeyStore(t *testing.T) {
+	store := NewSecretHostKeyStore(fake.NewSimpleClientset(), "kunkka-system")
+	addr := "[fd00::10]:22"
+	if name := store.SecretName(addr); name != "host-key-fd00--10-22" {
+		t.Errorf("SecretName() = %s", name)
+	}
+	jumped := HostKeyID([]string{"10.28.0.1:22"}, addr)
+	if name := store.SecretName(jumped); !strings.HasPrefix(name, "host-key-fd00--10-22-") {
+		t.Errorf("SecretName() of a jumped host = %s", name)
+	}
+	if store.SecretName(jumped) == store.SecretName(HostKeyID([]string{"10.29.0.1:22"}, addr)) {
+		t.Errorf("SecretName() of the address behind other jump hosts is %s", store.SecretName(jumped))
+	}
+
+	if err := store.Pin(addr, "ssh-ed25519 AAAA"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Pin(addr, "ssh-rsa BBBB"); err != ErrHostKeyPinned {
+		t.Errorf("Pin() of a pinned address = %v, want %v", err, ErrHostKeyPinned)
+	}
+	if key, err := store.Get(addr); err != nil || key != "ssh-ed25519 AAAA" {
+		t.Errorf("Get() = %q, %v", key, err)
+	}
+	if err := store.Remove(addr); err != nil {
+		t.Fatal(err)
+	}
+	if key, err := store.Get(addr); err != nil || key != "" {
+		t.Errorf("Get() after Remove() = %q, %v", key, err)
+	}
+}
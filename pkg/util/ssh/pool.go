@@ -0,0 +1,316 @@
+package ssh
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/sync/singleflight"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog"
+)
+
+var errConnClosed = errors.New("ssh connection is closed")
+
+// PoolConfig is the policy of the connections shared by the SSH of the same host and user.
+type PoolConfig struct {
+	// KeepAlive is the interval the idle connections are probed.
+	KeepAlive time.Duration
+	// IdleTimeout is the time an unused connection is kept before it is closed.
+	IdleTimeout time.Duration
+	// MaxSessions is the max sessions opened on a connection at the same time, the sftp
+	// client counts as one, 0 means no limit. It must be below the MaxSessions of sshd.
+	MaxSessions int
+}
+
+var DefaultPoolConfig = PoolConfig{
+	KeepAlive:   30 * time.Second,
+	IdleTimeout: 5 * time.Minute,
+	MaxSessions: 8,
+}
+
+var defaultPool = newConnPool(DefaultPoolConfig)
+
+// ConfigurePool sets the policy of the connection pool, the connections already open keep
+// their keepalive interval.
+func ConfigurePool(c PoolConfig) {
+	defaultPool.Lock()
+	defer defaultPool.Unlock()
+
+	defaultPool.config = c
+}
+
+// connPool keeps the open connections by the host, the user and the credential.
+type connPool struct {
+	sync.Mutex
+	config PoolConfig
+	conns  map[string][]*pooledConn
+	// dials are the connections being dialed by the key, out of the lock
+	dials singleflight.Group
+}
+
+func newConnPool(c PoolConfig) *connPool {
+	return &connPool{
+		config: c,
+		conns:  make(map[string][]*pooledConn),
+	}
+}
+
+// pooledConn is a shared connection, the sessions and the sftp client are guarded by the
+// lock of the pool.
+type pooledConn struct {
+	key      string
+	client   *ssh.Client
+	sftp     *sftp.Client
+	sessions int
+	lastUsed time.Time
+	closed   bool
+}
+
+// acquire leases a connection of the key with a free session, a connection is dialed if
+// none is available or fresh is set. The concurrent callers of the key wait for the same dial,
+// and dial again if its connection is used up by the others. The lease must be given back by
+// release or done.
+func (p *connPool) acquire(key string, fresh bool, dial func() (*ssh.Client, error)) (*pooledConn, error) {
+	for {
+		p.Lock()
+		for _, conn := range p.conns[key] {
+			if !fresh && p.available(conn) {
+				conn.sessions++
+				p.Unlock()
+				return conn, nil
+			}
+		}
+		p.Unlock()
+
+		v, err, shared := p.dials.Do(key, func() (interface{}, error) {
+			client, err := dial()
+			if err != nil {
+				return nil, err
+			}
+
+			p.Lock()
+			defer p.Unlock()
+			conn := &pooledConn{key: key, client: client, lastUsed: time.Now()}
+			p.conns[key] = append(p.conns[key], conn)
+			go p.keepAlive(conn, p.config.KeepAlive)
+			return conn, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		conn := v.(*pooledConn)
+		p.Lock()
+		if p.available(conn) {
+			conn.sessions++
+			p.Unlock()
+			return conn, nil
+		}
+		p.Unlock()
+		if !shared {
+			return nil, errConnClosed
+		}
+		fresh = false
+	}
+}
+
+func (p *connPool) available(conn *pooledConn) bool {
+	if conn.closed {
+		return false
+	}
+	if p.config.MaxSessions <= 0 {
+		return true
+	}
+	used := conn.sessions
+	if conn.sftp != nil {
+		used++
+	}
+	return used < p.config.MaxSessions
+}
+
+// release gives back the lease, the broken connection is closed and dropped.
+func (p *connPool) release(conn *pooledConn, broken bool) {
+	p.Lock()
+	defer p.Unlock()
+
+	conn.sessions--
+	conn.lastUsed = time.Now()
+	if broken {
+		p.closeLocked(conn)
+	}
+}
+
+// done gives back the lease after an operation, the connection is probed if the operation
+// fails for another reason than the remote file.
+func (p *connPool) done(conn *pooledConn, err error) {
+	var status *sftp.StatusError
+	if err == nil || errors.Is(err, os.ErrNotExist) || errors.Is(err, os.ErrPermission) || errors.As(err, &status) {
+		p.release(conn, false)
+		return
+	}
+
+	_, _, perr := conn.client.SendRequest("keepalive@openssh.com", true, nil)
+	p.release(conn, perr != nil)
+}
+
+// sftpClient returns the sftp client of the leased connection, it is opened on first use
+// and shared by the leases of the connection.
+func (p *connPool) sftpClient(conn *pooledConn) (*sftp.Client, error) {
+	p.Lock()
+	client := conn.sftp
+	p.Unlock()
+	if client != nil {
+		return client, nil
+	}
+
+	client, err := sftp.NewClient(conn.client)
+	if err != nil {
+		return nil, err
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	if conn.sftp != nil || conn.closed {
+		// opened by another lease meanwhile, or the connection is closed
+		client.Close()
+		if conn.closed {
+			return nil, errConnClosed
+		}
+		return conn.sftp, nil
+	}
+	conn.sftp = client
+	return client, nil
+}
+
+// keepAlive probes the connection and closes it once it is broken or idle for too long.
+func (p *connPool) keepAlive(conn *pooledConn, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultPoolConfig.KeepAlive
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		p.Lock()
+		if conn.closed {
+			p.Unlock()
+			return
+		}
+		if conn.sessions == 0 && p.config.IdleTimeout > 0 && time.Since(conn.lastUsed) > p.config.IdleTimeout {
+			klog.V(4).Infof("close idle ssh connection %s", conn.key)
+			p.closeLocked(conn)
+			p.Unlock()
+			return
+		}
+		p.Unlock()
+
+		if _, _, err := conn.client.SendRequest("keepalive@openssh.com", true, nil); err != nil {
+			klog.V(4).Infof("ssh connection %s is broken: %v", conn.key, err)
+			p.Lock()
+			p.closeLocked(conn)
+			p.Unlock()
+			return
+		}
+	}
+}
+
+func (p *connPool) closeLocked(conn *pooledConn) {
+	if conn.closed {
+		return
+	}
+	conn.closed = true
+	if conn.sftp != nil {
+		conn.sftp.Close()
+	}
+	conn.client.Close()
+
+	conns := p.conns[conn.key]
+	for i := range conns {
+		if conns[i] == conn {
+			conns = append(conns[:i], conns[i+1:]...)
+			break
+		}
+	}
+	if len(conns) == 0 {
+		delete(p.conns, conn.key)
+	} else {
+		p.conns[conn.key] = conns
+	}
+}
+
+// dial connects to the host, it is tried again within the retry period when always is set or
+// the SSH has a retry count.
+func (s *SSH) dial(always bool) (*ssh.Client, error) {
+	verifier := newHostKeyVerifier(s.hostKeyID)
+	config := &ssh.ClientConfig{
+		User:            s.User,
+		Auth:            s.authMethods,
+		HostKeyCallback: verifier.Verify,
+	}
+	client, err := s.dialer.Dial("tcp", s.addr, config)
+	if mismatch := verifier.Mismatch(); mismatch != nil {
+		return nil, mismatch
+	}
+	if err != nil && (always || s.Retry > 0) {
+		err = wait.Poll(5*time.Second, time.Duration(s.Retry)*5*time.Second, func() (bool, error) {
+			if client, err = s.dialer.Dial("tcp", s.addr, config); err != nil {
+				return false, err
+			}
+			return true, nil
+		})
+	}
+	if mismatch := verifier.Mismatch(); mismatch != nil {
+		return nil, mismatch
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting SSH client to %s@%s: '%v'", s.User, s.addr, err)
+	}
+	return client, nil
+}
+
+// newSession leases a pooled connection and opens a session on it. A connection broken
+// since its last use is replaced by a new one once.
+func (s *SSH) newSession() (*pooledConn, *ssh.Session, error) {
+	var err error
+	for _, fresh := range []bool{false, true} {
+		var conn *pooledConn
+		conn, err = s.pool.acquire(s.key, fresh, func() (*ssh.Client, error) { return s.dial(false) })
+		if err != nil {
+			return nil, nil, err
+		}
+		var session *ssh.Session
+		session, err = conn.client.NewSession()
+		if err == nil {
+			return conn, session, nil
+		}
+		// the session may be refused by the MaxSessions of sshd on a healthy connection
+		var refused *ssh.OpenChannelError
+		s.pool.release(conn, !errors.As(err, &refused))
+	}
+	return nil, nil, fmt.Errorf("error creating session to %s@%s: '%v'", s.User, s.addr, err)
+}
+
+// newSFTP leases a pooled connection and returns its sftp client. A connection broken since
+// its last use is replaced by a new one once.
+func (s *SSH) newSFTP() (*pooledConn, *sftp.Client, error) {
+	var err error
+	for _, fresh := range []bool{false, true} {
+		var conn *pooledConn
+		conn, err = s.pool.acquire(s.key, fresh, func() (*ssh.Client, error) { return s.dial(true) })
+		if err != nil {
+			return nil, nil, err
+		}
+		var client *sftp.Client
+		client, err = s.pool.sftpClient(conn)
+		if err == nil {
+			return conn, client, nil
+		}
+		s.pool.release(conn, true)
+	}
+	return nil, nil, fmt.Errorf("error creating sftp client to %s@%s: '%v'", s.User, s.addr, err)
+}
@@ -0,0 +1,180 @@
+package ssh
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/binary"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+// testServer is a sshd running the exec requests with exit status 0 and serving sftp.
+type testServer struct {
+	listener net.Listener
+	accepted int32
+}
+
+func newTestServer(t *testing.T) *testServer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			return nil, nil
+		},
+	}
+	config.AddHostKey(signer)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := &testServer{listener: l}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			atomic.AddInt32(&srv.accepted, 1)
+			go srv.serve(conn, config)
+		}
+	}()
+	return srv
+}
+
+func (srv *testServer) serve(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for newChan := range chans {
+		ch, requests, err := newChan.Accept()
+		if err != nil {
+			continue
+		}
+		go func() {
+			defer ch.Close()
+			for req := range requests {
+				switch req.Type {
+				case "exec":
+					req.Reply(true, nil)
+					ch.Write([]byte("ok"))
+					status := make([]byte, 4)
+					binary.BigEndian.PutUint32(status, 0)
+					ch.SendRequest("exit-status", false, status)
+					return
+				case "subsystem":
+					req.Reply(true, nil)
+					server, err := sftp.NewServer(ch)
+					if err != nil {
+						return
+					}
+					server.Serve()
+					return
+				default:
+					req.Reply(false, nil)
+				}
+			}
+		}()
+	}
+}
+
+func (srv *testServer) config() *Config {
+	addr := srv.listener.Addr().(*net.TCPAddr)
+	return &Config{User: "root", Host: addr.IP.String(), Port: addr.Port, Password: "secret"}
+}
+
+func TestPooledConnection(t *testing.T) {
+	srv := newTestServer(t)
+	defer srv.listener.Close()
+
+	dir, err := ioutil.TempDir("", "ssh")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "kubelet.conf")
+	if err := ioutil.WriteFile(file, []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		s, err := New(srv.config())
+		if err != nil {
+			t.Fatal(err)
+		}
+		stdout, _, exit, err := s.Exec("hostname")
+		if err != nil || exit != 0 || stdout != "ok" {
+			t.Fatalf("Exec() = %q, %d, %v", stdout, exit, err)
+		}
+		data, err := s.ReadFile(file)
+		if err != nil || string(data) != "data" {
+			t.Fatalf("ReadFile() = %q, %v", data, err)
+		}
+		if _, err := s.ReadFile(file + "." + strconv.Itoa(i)); err == nil {
+			t.Fatal("ReadFile() of a missing file succeeds")
+		}
+	}
+	if n := atomic.LoadInt32(&srv.accepted); n != 1 {
+		t.Errorf("the server accepts %d connections, want 1", n)
+	}
+
+	// the broken connection is replaced on next use
+	defaultPool.Lock()
+	for _, conn := range defaultPool.conns[connKey("", srv.config())] {
+		conn.client.Close()
+	}
+	defaultPool.Unlock()
+	s, err := New(srv.config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := s.Exec("hostname"); err != nil {
+		t.Fatalf("Exec() after the connection is broken: %v", err)
+	}
+	if n := atomic.LoadInt32(&srv.accepted); n != 2 {
+		t.Errorf("the server accepts %d connections, want 2", n)
+	}
+}
+
+func TestPooledConnectionConcurrentDial(t *testing.T) {
+	srv := newTestServer(t)
+	defer srv.listener.Close()
+
+	// the first callers of the host wait for the same dial
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s, err := New(srv.config())
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if _, _, _, err := s.Exec("hostname"); err != nil {
+				t.Errorf("Exec() = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := atomic.LoadInt32(&srv.accepted); n != 1 {
+		t.Errorf("the server accepts %d connections, want 1", n)
+	}
+}
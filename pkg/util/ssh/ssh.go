@@ -15,9 +15,7 @@ import (
 	"time"
 
 	"github.com/gostship/kunkka/pkg/util/hash"
-	"github.com/pkg/sftp"
 	"golang.org/x/crypto/ssh"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog"
 )
 
@@ -29,6 +27,11 @@ type SSH struct {
 	authMethods []ssh.AuthMethod
 	dialer      sshDialer
 	Retry       int
+	// key identifies the pooled connections the SSH shares
+	key  string
+	pool *connPool
+	// hostKeyID identifies the host for its pinned key
+	hostKeyID string
 }
 
 type Config struct {
@@ -42,6 +45,9 @@ type Config struct {
 	// seconds). This timeout is only intended to catch otherwise uncaught hangs.
 	DialTimeOut time.Duration
 	Retry       int
+	// JumpHosts is the chain of the bastions the host is reached through, the first one is
+	// dialed directly and each of the others is dialed through the previous one.
+	JumpHosts []Config
 }
 
 type Interface interface {
@@ -62,25 +68,28 @@ type Interface interface {
 }
 
 func New(c *Config) (*SSH, error) {
-	if c.Password == "" && c.PrivateKey == nil {
-		return nil, errors.New("password or privateKey at least one")
+	authMethods, err := c.authMethods()
+	if err != nil {
+		return nil, err
 	}
+	addr := fmt.Sprintf("%s:%d", c.Host, c.Port)
 
-	authMethods := make([]ssh.AuthMethod, 0)
-	if c.Password != "" {
-		authMethods = append(authMethods, ssh.Password(c.Password))
+	if c.DialTimeOut == 0 {
+		c.DialTimeOut = 5 * time.Second
 	}
-	if len(c.PrivateKey) != 0 {
-		signer, err := MakePrivateKeySigner(c.PrivateKey, c.PassPhrase)
+
+	var dialer sshDialer = &realSSHDialer{}
+	key := connKey("", c)
+	hostKeyID := addr
+	if len(c.JumpHosts) > 0 {
+		jump, err := newJumpDialer(c.JumpHosts)
 		if err != nil {
 			return nil, err
 		}
-		authMethods = append(authMethods, ssh.PublicKeys(signer))
-	}
-	addr := fmt.Sprintf("%s:%d", c.Host, c.Port)
-
-	if c.DialTimeOut == 0 {
-		c.DialTimeOut = 5 * time.Second
+		dialer = jump
+		last := jump.hops[len(jump.hops)-1]
+		key = connKey(last.key, c)
+		hostKeyID = last.hostKeyID + hostKeyIDSeparator + addr
 	}
 
 	return &SSH{
@@ -89,11 +98,33 @@ func New(c *Config) (*SSH, error) {
 		Port:        c.Port,
 		addr:        addr,
 		authMethods: authMethods,
-		dialer:      &timeoutDialer{&realSSHDialer{}, c.DialTimeOut},
+		dialer:      &timeoutDialer{dialer, c.DialTimeOut},
 		Retry:       c.Retry,
+		key:         key,
+		pool:        defaultPool,
+		hostKeyID:   hostKeyID,
 	}, nil
 }
 
+func (c *Config) authMethods() ([]ssh.AuthMethod, error) {
+	if c.Password == "" && c.PrivateKey == nil {
+		return nil, errors.New("password or privateKey at least one")
+	}
+
+	authMethods := make([]ssh.AuthMethod, 0)
+	if c.Password != "" {
+		authMethods = append(authMethods, ssh.Password(c.Password))
+	}
+	if len(c.PrivateKey) != 0 {
+		signer, err := MakePrivateKeySigner(c.PrivateKey, c.PassPhrase)
+		if err != nil {
+			return nil, err
+		}
+		authMethods = append(authMethods, ssh.PublicKeys(signer))
+	}
+	return authMethods, nil
+}
+
 func (s *SSH) Ping() error {
 	_, _, _, err := s.Exec("pwd")
 
@@ -120,30 +151,12 @@ func (s *SSH) Execf(format string, a ...interface{}) (stdout string, stderr stri
 }
 
 func (s *SSH) Exec(cmd string) (stdout string, stderr string, exit int, err error) {
-	// Setup the config, dial the server, and open a session.
-	config := &ssh.ClientConfig{
-		User:            s.User,
-		Auth:            s.authMethods,
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
-	}
-	client, err := s.dialer.Dial("tcp", s.addr, config)
-	if err != nil && s.Retry > 0 {
-		err = wait.Poll(5*time.Second, time.Duration(s.Retry)*5*time.Second, func() (bool, error) {
-			if client, err = s.dialer.Dial("tcp", s.addr, config); err != nil {
-				return false, err
-			}
-			return true, nil
-		})
-	}
-	if err != nil {
-		return "", "", 0, fmt.Errorf("error getting SSH client to %s@%s: '%v'", s.User, s.addr, err)
-	}
-	defer client.Close()
-
-	session, err := client.NewSession()
+	// Lease a pooled connection and open a session.
+	conn, session, err := s.newSession()
 	if err != nil {
-		return "", "", 0, fmt.Errorf("error creating session to %s@%s: '%v'", s.User, s.addr, err)
+		return "", "", 0, err
 	}
+	defer func() { s.pool.done(conn, err) }()
 	defer session.Close()
 
 	// Run the command.
@@ -169,30 +182,12 @@ func (s *SSH) Exec(cmd string) (stdout string, stderr string, exit int, err erro
 }
 
 func (s *SSH) ExecStream(cmd string, stdout, stderr io.Writer) (exit int, err error) {
-	// Setup the config, dial the server, and open a session.
-	config := &ssh.ClientConfig{
-		User:            s.User,
-		Auth:            s.authMethods,
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
-	}
-	client, err := s.dialer.Dial("tcp", s.addr, config)
-	if err != nil && s.Retry > 0 {
-		err = wait.Poll(5*time.Second, time.Duration(s.Retry)*5*time.Second, func() (bool, error) {
-			if client, err = s.dialer.Dial("tcp", s.addr, config); err != nil {
-				return false, err
-			}
-			return true, nil
-		})
-	}
-	if err != nil {
-		return 0, fmt.Errorf("error getting SSH client to %s@%s: '%v'", s.User, s.addr, err)
-	}
-	defer client.Close()
-
-	session, err := client.NewSession()
+	// Lease a pooled connection and open a session.
+	conn, session, err := s.newSession()
 	if err != nil {
-		return 0, fmt.Errorf("error creating session to %s@%s: '%v'", s.User, s.addr, err)
+		return 0, err
 	}
+	defer func() { s.pool.done(conn, err) }()
 	defer session.Close()
 
 	// Run the command.
@@ -232,30 +227,11 @@ func (s *SSH) CopyFile(src, dst string) error {
 	}
 	klog.Infof("[%s] copy `%s` to %q", s.addr, src, dst)
 
-	config := &ssh.ClientConfig{
-		User:            s.User,
-		Auth:            s.authMethods,
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
-	}
-	client, err := s.dialer.Dial("tcp", s.addr, config)
-	if err != nil {
-		err = wait.Poll(5*time.Second, time.Duration(s.Retry)*5*time.Second, func() (bool, error) {
-			if client, err = s.dialer.Dial("tcp", s.addr, config); err != nil {
-				return false, err
-			}
-			return true, nil
-		})
-	}
-	if err != nil {
-		return err
-	}
-	defer client.Close()
-
-	sftpClient, err := sftp.NewClient(client)
+	conn, sftpClient, err := s.newSFTP()
 	if err != nil {
 		return err
 	}
-	defer sftpClient.Close()
+	defer func() { s.pool.done(conn, err) }()
 
 	srcFile, err := os.Open(src)
 
@@ -278,30 +254,11 @@ func (s *SSH) CopyFile(src, dst string) error {
 func (s *SSH) WriteFile(src io.Reader, dst string) error {
 	klog.Infof("[%s] Write data to %q", s.addr, dst)
 
-	config := &ssh.ClientConfig{
-		User:            s.User,
-		Auth:            s.authMethods,
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
-	}
-	client, err := s.dialer.Dial("tcp", s.addr, config)
-	if err != nil {
-		err = wait.Poll(5*time.Second, time.Duration(s.Retry)*5*time.Second, func() (bool, error) {
-			if client, err = s.dialer.Dial("tcp", s.addr, config); err != nil {
-				return false, err
-			}
-			return true, nil
-		})
-	}
+	conn, sftpClient, err := s.newSFTP()
 	if err != nil {
 		return err
 	}
-	defer client.Close()
-
-	sftpClient, err := sftp.NewClient(client)
-	if err != nil {
-		return err
-	}
-	defer sftpClient.Close()
+	defer func() { s.pool.done(conn, err) }()
 
 	err = sftpClient.MkdirAll(path.Dir(dst))
 	if err != nil {
@@ -318,32 +275,14 @@ func (s *SSH) WriteFile(src io.Reader, dst string) error {
 }
 
 func (s *SSH) Stat(p string) (os.FileInfo, error) {
-	config := &ssh.ClientConfig{
-		User:            s.User,
-		Auth:            s.authMethods,
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
-	}
-	client, err := s.dialer.Dial("tcp", s.addr, config)
-	if err != nil {
-		err = wait.Poll(5*time.Second, time.Duration(s.Retry)*5*time.Second, func() (bool, error) {
-			if client, err = s.dialer.Dial("tcp", s.addr, config); err != nil {
-				return false, err
-			}
-			return true, nil
-		})
-	}
+	conn, sftpClient, err := s.newSFTP()
 	if err != nil {
 		return nil, err
 	}
-	defer client.Close()
+	defer func() { s.pool.done(conn, err) }()
 
-	sftpClient, err := sftp.NewClient(client)
-	if err != nil {
-		return nil, err
-	}
-	defer sftpClient.Close()
-
-	return sftpClient.Stat(p)
+	info, err := sftpClient.Stat(p)
+	return info, err
 }
 
 func (s *SSH) Exist(filename string) (bool, error) {
@@ -356,35 +295,17 @@ func (s *SSH) Exist(filename string) (bool, error) {
 }
 
 func (s *SSH) ReadFile(filename string) ([]byte, error) {
-	config := &ssh.ClientConfig{
-		User:            s.User,
-		Auth:            s.authMethods,
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
-	}
-	client, err := s.dialer.Dial("tcp", s.addr, config)
-	if err != nil {
-		err = wait.Poll(5*time.Second, time.Duration(s.Retry)*5*time.Second, func() (bool, error) {
-			if client, err = s.dialer.Dial("tcp", s.addr, config); err != nil {
-				return false, fmt.Errorf("read file %s error: %w", filename, err)
-			}
-			return true, nil
-		})
-	}
-	if err != nil {
-		return nil, fmt.Errorf("read file %s error: %w", filename, err)
-	}
-	defer client.Close()
-
-	sftpClient, err := sftp.NewClient(client)
+	conn, sftpClient, err := s.newSFTP()
 	if err != nil {
 		return nil, fmt.Errorf("read file %s error: %w", filename, err)
 	}
-	defer sftpClient.Close()
+	defer func() { s.pool.done(conn, err) }()
 
 	f, err := sftpClient.Open(filename)
 	if err != nil {
 		return nil, fmt.Errorf("read file %s error: %w", filename, err)
 	}
+	defer f.Close()
 	data := new(bytes.Buffer)
 	_, err = f.WriteTo(data)
 	if err != nil {